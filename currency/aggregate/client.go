@@ -0,0 +1,256 @@
+package aggregate
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/code-payments/ocp-server/currency"
+	"github.com/code-payments/ocp-server/metrics"
+)
+
+const (
+	metricsStructName = "currency.aggregate.client"
+
+	sourceFailureEventName = "ExchangeRateSourceFailure"
+	rejectedRateEventName  = "ExchangeRateRejected"
+)
+
+var (
+	ErrNoSources          = errors.New("no exchange rate sources configured")
+	ErrInsufficientQuorum = errors.New("insufficient exchange rate sources available")
+)
+
+// Source is a named exchange rate provider
+type Source struct {
+	Name   string
+	Client currency.Client
+}
+
+// Option configures the aggregate client
+type Option func(c *client)
+
+// WithMinSources sets the minimum number of sources that must agree on a rate
+// for it to be returned. Defaults to 1.
+func WithMinSources(n int) Option {
+	return func(c *client) {
+		if n > 0 {
+			c.minSources = n
+		}
+	}
+}
+
+// WithMaxDeviation sets the maximum relative deviation from the median before
+// a source's rate is rejected as an outlier (eg. 0.05 for 5%). Zero disables
+// outlier rejection.
+func WithMaxDeviation(fraction float64) Option {
+	return func(c *client) {
+		c.maxDeviation = fraction
+	}
+}
+
+// WithMaxAge sets the maximum age of the current rates returned by a source
+// before they're considered stale and ignored. Zero disables the check.
+func WithMaxAge(maxAge time.Duration) Option {
+	return func(c *client) {
+		c.maxAge = maxAge
+	}
+}
+
+type client struct {
+	sources      []Source
+	minSources   int
+	maxDeviation float64
+	maxAge       time.Duration
+}
+
+// NewClient returns a currency.Client that queries all sources concurrently
+// and returns the median rate per currency across the sources that respond.
+// A failing or stale source doesn't fail the request as long as enough other
+// sources are available.
+func NewClient(sources []Source, opts ...Option) currency.Client {
+	c := &client{
+		sources:    sources,
+		minSources: 1,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// GetCurrentRates implements currency.Client.GetCurrentRates
+func (c *client) GetCurrentRates(ctx context.Context, base string) (*currency.ExchangeData, error) {
+	tracer := metrics.TraceMethodCall(ctx, metricsStructName, "GetCurrentRates")
+	defer tracer.End()
+
+	res, err := c.aggregate(ctx, base, "GetCurrentRates", func(ctx context.Context, source currency.Client) (*currency.ExchangeData, error) {
+		data, err := source.GetCurrentRates(ctx, base)
+		if err != nil {
+			return nil, err
+		}
+
+		if c.maxAge > 0 && !data.Timestamp.IsZero() && time.Since(data.Timestamp) > c.maxAge {
+			return nil, errors.Errorf("rates are stale as of %s", data.Timestamp.UTC().Format(time.RFC3339))
+		}
+
+		return data, nil
+	})
+	if err != nil {
+		tracer.OnError(err)
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetHistoricalRates implements currency.Client.GetHistoricalRates
+func (c *client) GetHistoricalRates(ctx context.Context, base string, timestamp time.Time) (*currency.ExchangeData, error) {
+	tracer := metrics.TraceMethodCall(ctx, metricsStructName, "GetHistoricalRates")
+	defer tracer.End()
+
+	res, err := c.aggregate(ctx, base, "GetHistoricalRates", func(ctx context.Context, source currency.Client) (*currency.ExchangeData, error) {
+		return source.GetHistoricalRates(ctx, base, timestamp)
+	})
+	if err != nil {
+		tracer.OnError(err)
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *client) aggregate(
+	ctx context.Context,
+	base string,
+	methodName string,
+	fetch func(ctx context.Context, source currency.Client) (*currency.ExchangeData, error),
+) (*currency.ExchangeData, error) {
+	if len(c.sources) == 0 {
+		return nil, ErrNoSources
+	}
+
+	results := make([]*currency.ExchangeData, len(c.sources))
+	errs := make([]error, len(c.sources))
+
+	var wg sync.WaitGroup
+	for i, source := range c.sources {
+		wg.Add(1)
+		go func(i int, source Source) {
+			defer wg.Done()
+
+			results[i], errs[i] = fetch(ctx, source.Client)
+		}(i, source)
+	}
+	wg.Wait()
+
+	var available []*currency.ExchangeData
+	var lastErr error
+	for i, source := range c.sources {
+		if errs[i] != nil {
+			// An invalid base is a caller error and won't be resolved by other
+			// sources when there's only one of them
+			if errs[i] == currency.ErrInvalidBase && len(c.sources) == 1 {
+				return nil, errs[i]
+			}
+
+			lastErr = errors.Wrapf(errs[i], "error querying %s source", source.Name)
+			metrics.RecordEvent(ctx, sourceFailureEventName, map[string]interface{}{
+				"source": source.Name,
+				"method": methodName,
+				"base":   base,
+				"error":  errs[i].Error(),
+			})
+			continue
+		}
+
+		available = append(available, results[i])
+	}
+
+	if len(available) < c.minSources {
+		if lastErr == nil {
+			lastErr = ErrInsufficientQuorum
+		}
+		return nil, errors.Wrapf(lastErr, "%d of %d required sources available", len(available), c.minSources)
+	}
+
+	ratesBySymbol := make(map[string][]float64)
+	var oldest time.Time
+	for _, data := range available {
+		for symbol, rate := range data.Rates {
+			if rate <= 0 || math.IsNaN(rate) || math.IsInf(rate, 0) {
+				continue
+			}
+
+			symbol = strings.ToLower(symbol)
+			ratesBySymbol[symbol] = append(ratesBySymbol[symbol], rate)
+		}
+
+		if oldest.IsZero() || (!data.Timestamp.IsZero() && data.Timestamp.Before(oldest)) {
+			oldest = data.Timestamp
+		}
+	}
+
+	res := &currency.ExchangeData{
+		Base:      strings.ToLower(base),
+		Rates:     make(map[string]float64),
+		Timestamp: oldest,
+	}
+	for symbol, rates := range ratesBySymbol {
+		rate, ok := c.medianWithoutOutliers(rates)
+		if !ok {
+			metrics.RecordEvent(ctx, rejectedRateEventName, map[string]interface{}{
+				"method":       methodName,
+				"base":         base,
+				"symbol":       symbol,
+				"source_count": len(rates),
+			})
+			continue
+		}
+
+		res.Rates[symbol] = rate
+	}
+	return res, nil
+}
+
+// medianWithoutOutliers computes the median of the provided rates after
+// rejecting any rate that deviates too far from the median of all rates. False
+// is returned when not enough rates remain to satisfy the minimum number of
+// sources.
+func (c *client) medianWithoutOutliers(rates []float64) (float64, bool) {
+	if len(rates) < c.minSources {
+		return 0, false
+	}
+
+	m := median(rates)
+	if c.maxDeviation <= 0 {
+		return m, true
+	}
+
+	var accepted []float64
+	for _, rate := range rates {
+		if math.Abs(rate-m)/m <= c.maxDeviation {
+			accepted = append(accepted, rate)
+		}
+	}
+
+	if len(accepted) == 0 || len(accepted) < c.minSources {
+		return 0, false
+	}
+	return median(accepted), true
+}
+
+func median(values []float64) float64 {
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
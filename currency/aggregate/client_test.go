@@ -0,0 +1,135 @@
+package aggregate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/code-payments/ocp-server/currency"
+	"github.com/code-payments/ocp-server/currency/fixture"
+)
+
+func TestGetCurrentRates_MedianWithOutlierRejection(t *testing.T) {
+	now := time.Now()
+
+	source1 := fixture.NewClient()
+	source1.SetCurrentRates("usd", map[string]float64{"cad": 1.30, "eur": 0.90, "jpy": 150}, now)
+
+	source2 := fixture.NewClient()
+	source2.SetCurrentRates("usd", map[string]float64{"cad": 1.32, "eur": 0.92}, now.Add(-time.Minute))
+
+	source3 := fixture.NewClient()
+	source3.SetCurrentRates("usd", map[string]float64{"cad": 1.31, "eur": 9.2}, now)
+
+	client := NewClient(
+		[]Source{
+			{Name: "source1", Client: source1},
+			{Name: "source2", Client: source2},
+			{Name: "source3", Client: source3},
+		},
+		WithMaxDeviation(0.05),
+	)
+
+	data, err := client.GetCurrentRates(context.Background(), "USD")
+	require.NoError(t, err)
+
+	assert.Equal(t, "usd", data.Base)
+	assert.Equal(t, now.Add(-time.Minute).Unix(), data.Timestamp.Unix())
+	assert.InDelta(t, 1.31, data.Rates["cad"], 0.000001)
+	assert.InDelta(t, 0.91, data.Rates["eur"], 0.000001)
+	assert.InDelta(t, 150, data.Rates["jpy"], 0.000001)
+}
+
+func TestGetCurrentRates_MinSources(t *testing.T) {
+	now := time.Now()
+
+	source1 := fixture.NewClient()
+	source1.SetCurrentRates("usd", map[string]float64{"cad": 1.30, "jpy": 150}, now)
+
+	source2 := fixture.NewClient()
+	source2.SetCurrentRates("usd", map[string]float64{"cad": 1.32}, now)
+
+	client := NewClient(
+		[]Source{
+			{Name: "source1", Client: source1},
+			{Name: "source2", Client: source2},
+		},
+		WithMinSources(2),
+	)
+
+	data, err := client.GetCurrentRates(context.Background(), "usd")
+	require.NoError(t, err)
+	assert.InDelta(t, 1.31, data.Rates["cad"], 0.000001)
+	assert.NotContains(t, data.Rates, "jpy")
+
+	source2.SetError(errors.New("unavailable"))
+	_, err = client.GetCurrentRates(context.Background(), "usd")
+	assert.Error(t, err)
+}
+
+func TestGetCurrentRates_SourceFailures(t *testing.T) {
+	now := time.Now()
+
+	healthy := fixture.NewClient()
+	healthy.SetCurrentRates("usd", map[string]float64{"cad": 1.30}, now)
+
+	failing := fixture.NewClient()
+	failing.SetError(errors.New("unavailable"))
+
+	stale := fixture.NewClient()
+	stale.SetCurrentRates("usd", map[string]float64{"cad": 2.0}, now.Add(-time.Hour))
+
+	client := NewClient(
+		[]Source{
+			{Name: "failing", Client: failing},
+			{Name: "stale", Client: stale},
+			{Name: "healthy", Client: healthy},
+		},
+		WithMaxAge(5*time.Minute),
+	)
+
+	data, err := client.GetCurrentRates(context.Background(), "usd")
+	require.NoError(t, err)
+	assert.Equal(t, 1.30, data.Rates["cad"])
+
+	healthy.SetError(errors.New("unavailable"))
+	_, err = client.GetCurrentRates(context.Background(), "usd")
+	assert.Error(t, err)
+
+	_, err = NewClient(nil).GetCurrentRates(context.Background(), "usd")
+	assert.Equal(t, ErrNoSources, err)
+
+	_, err = NewClient([]Source{{Name: "healthy", Client: fixture.NewClient()}}).GetCurrentRates(context.Background(), "usd")
+	assert.Equal(t, currency.ErrInvalidBase, err)
+}
+
+func TestGetHistoricalRates(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	source1 := fixture.NewClient()
+	source1.AddHistoricalRates("usd", map[string]float64{"cad": 1.30}, ts)
+
+	source2 := fixture.NewClient()
+	source2.AddHistoricalRates("usd", map[string]float64{"cad": 1.40}, ts.Add(-time.Hour))
+
+	client := NewClient(
+		[]Source{
+			{Name: "source1", Client: source1},
+			{Name: "source2", Client: source2},
+		},
+		WithMaxAge(time.Minute),
+	)
+
+	data, err := client.GetHistoricalRates(context.Background(), "usd", ts.Add(time.Minute))
+	require.NoError(t, err)
+	assert.InDelta(t, 1.35, data.Rates["cad"], 0.000001)
+	assert.Equal(t, ts.Add(-time.Hour), data.Timestamp)
+
+	data, err = client.GetHistoricalRates(context.Background(), "usd", ts.Add(-time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 1.40, data.Rates["cad"])
+}
@@ -0,0 +1,138 @@
+package fixture
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/code-payments/ocp-server/currency"
+)
+
+var (
+	ErrNoRates = errors.New("no rates available at the requested time")
+)
+
+// Client is an offline currency.Client that serves exchange rates from
+// in-memory fixtures. It's intended for tests and local environments that
+// cannot reach external providers.
+type Client struct {
+	mu         sync.Mutex
+	current    map[string]*currency.ExchangeData
+	historical map[string][]*currency.ExchangeData
+	err        error
+}
+
+// NewClient returns a new fixture client without any rates
+func NewClient() *Client {
+	return &Client{
+		current:    make(map[string]*currency.ExchangeData),
+		historical: make(map[string][]*currency.ExchangeData),
+	}
+}
+
+// SetCurrentRates sets the rates returned by GetCurrentRates for a base
+// currency. The rates are also made available to GetHistoricalRates.
+func (c *Client) SetCurrentRates(base string, rates map[string]float64, ts time.Time) {
+	data := newExchangeData(base, rates, ts)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.current[data.Base] = data
+	c.addHistoricalRates(data)
+}
+
+// AddHistoricalRates adds rates returned by GetHistoricalRates for a base
+// currency.
+func (c *Client) AddHistoricalRates(base string, rates map[string]float64, ts time.Time) {
+	data := newExchangeData(base, rates, ts)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.addHistoricalRates(data)
+}
+
+// SetError sets an error that is returned by all calls until it is cleared
+// with a nil value
+func (c *Client) SetError(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.err = err
+}
+
+// GetCurrentRates implements currency.Client.GetCurrentRates
+func (c *Client) GetCurrentRates(_ context.Context, base string) (*currency.ExchangeData, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	data, ok := c.current[strings.ToLower(base)]
+	if !ok {
+		return nil, currency.ErrInvalidBase
+	}
+	return cloneExchangeData(data), nil
+}
+
+// GetHistoricalRates implements currency.Client.GetHistoricalRates. The most
+// recent rates at or before the timestamp within the same day are returned.
+func (c *Client) GetHistoricalRates(_ context.Context, base string, timestamp time.Time) (*currency.ExchangeData, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	history, ok := c.historical[strings.ToLower(base)]
+	if !ok {
+		return nil, currency.ErrInvalidBase
+	}
+
+	// History is sorted in descending order by time
+	for _, data := range history {
+		if data.Timestamp.After(timestamp) {
+			continue
+		}
+
+		y1, m1, d1 := data.Timestamp.UTC().Date()
+		y2, m2, d2 := timestamp.UTC().Date()
+		if y1 != y2 || m1 != m2 || d1 != d2 {
+			break
+		}
+
+		return cloneExchangeData(data), nil
+	}
+	return nil, ErrNoRates
+}
+
+func (c *Client) addHistoricalRates(data *currency.ExchangeData) {
+	history := append(c.historical[data.Base], data)
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].Timestamp.After(history[j].Timestamp)
+	})
+	c.historical[data.Base] = history
+}
+
+func newExchangeData(base string, rates map[string]float64, ts time.Time) *currency.ExchangeData {
+	data := &currency.ExchangeData{
+		Base:      strings.ToLower(base),
+		Rates:     make(map[string]float64, len(rates)),
+		Timestamp: ts,
+	}
+	for symbol, rate := range rates {
+		data.Rates[strings.ToLower(symbol)] = rate
+	}
+	return data
+}
+
+func cloneExchangeData(data *currency.ExchangeData) *currency.ExchangeData {
+	return newExchangeData(data.Base, data.Rates, data.Timestamp)
+}
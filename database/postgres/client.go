@@ -20,6 +20,10 @@ type Config struct {
 	DbName             string
 	MaxOpenConnections int
 	MaxIdleConnections int
+
+	// RunMigrations applies any pending schema migrations when the database
+	// provider is created
+	RunMigrations bool
 }
 
 // Get a DB connection pool using AWS IAM credentials
@@ -67,9 +71,7 @@ func NewWithUsernameAndPassword(username, password, hostname, port, dbname strin
 	// IMPORTANT: Supported by Aurora Serverless clusters
 
 	// Use password based authentication
-	dsn := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable",
-		username, password, hostname, port, dbname,
-	)
+	dsn := UsernameAndPasswordConnectionString(username, password, hostname, port, dbname)
 
 	// TODO: either switch to IAM (non-serverless only) or enable SSL (download the db cert)
 	// (https://docs.aws.amazon.com/AmazonRDS/latest/AuroraUserGuide/AuroraPostgreSQL.Security.html)
@@ -88,3 +90,11 @@ func NewWithUsernameAndPassword(username, password, hostname, port, dbname strin
 
 	return db, nil
 }
+
+// UsernameAndPasswordConnectionString returns the connection string used to
+// connect with username/password credentials
+func UsernameAndPasswordConnectionString(username, password, hostname, port, dbname string) string {
+	return fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable",
+		username, password, hostname, port, dbname,
+	)
+}
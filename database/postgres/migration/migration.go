@@ -0,0 +1,296 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	schemaVersionTableName = "ocp__core_schema_version"
+
+	// Arbitrary, but fixed, key for the session-level advisory lock that guards
+	// applying migrations across concurrently starting servers
+	advisoryLockKey int64 = 0x6f63705f6d6967
+
+	upFileSuffix   = ".up.sql"
+	downFileSuffix = ".down.sql"
+)
+
+var (
+	ErrInvalidMigrations = errors.New("invalid migrations")
+	ErrUnknownVersion    = errors.New("database schema version is unknown")
+)
+
+// Migration is a single versioned schema change for a store
+type Migration struct {
+	Store   string
+	Version uint32
+	Name    string
+
+	Up   string
+	Down string
+}
+
+// Load loads the ordered set of migrations for a store from a directory with
+// files named <version>_<name>.up.sql and <version>_<name>.down.sql
+func Load(store string, fsys fs.FS, dir string) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[uint32]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		fileName := entry.Name()
+
+		var baseName string
+		var isUp bool
+		switch {
+		case strings.HasSuffix(fileName, upFileSuffix):
+			baseName = strings.TrimSuffix(fileName, upFileSuffix)
+			isUp = true
+		case strings.HasSuffix(fileName, downFileSuffix):
+			baseName = strings.TrimSuffix(fileName, downFileSuffix)
+		default:
+			continue
+		}
+
+		parts := strings.SplitN(baseName, "_", 2)
+		if len(parts) != 2 || len(parts[1]) == 0 {
+			return nil, errors.Wrapf(ErrInvalidMigrations, "%s: file name must be <version>_<name>", fileName)
+		}
+
+		version, err := strconv.ParseUint(parts[0], 10, 32)
+		if err != nil {
+			return nil, errors.Wrapf(ErrInvalidMigrations, "%s: invalid version", fileName)
+		}
+
+		contents, err := fs.ReadFile(fsys, path.Join(dir, fileName))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[uint32(version)]
+		if !ok {
+			migration = &Migration{
+				Store:   store,
+				Version: uint32(version),
+				Name:    parts[1],
+			}
+			byVersion[uint32(version)] = migration
+		} else if migration.Name != parts[1] {
+			return nil, errors.Wrapf(ErrInvalidMigrations, "%s: version %d has conflicting names", store, version)
+		}
+
+		if isUp {
+			migration.Up = string(contents)
+		} else {
+			migration.Down = string(contents)
+		}
+	}
+
+	res := make([]*Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		res = append(res, migration)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Version < res[j].Version
+	})
+
+	if err := validate(res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Up applies, in order, all migrations that haven't yet been applied to the
+// database. Each migration is applied in its own transaction alongside the
+// schema version update, and the whole operation is guarded by an advisory
+// lock so concurrent callers apply each migration exactly once.
+func Up(ctx context.Context, db *sql.DB, migrations []*Migration) error {
+	if err := validate(migrations); err != nil {
+		return err
+	}
+
+	return withLock(ctx, db, func(conn *sql.Conn) error {
+		applied, err := getAppliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		latestByStore := make(map[string]uint32)
+		for _, migration := range migrations {
+			if migration.Version > latestByStore[migration.Store] {
+				latestByStore[migration.Store] = migration.Version
+			}
+		}
+		for store, version := range applied {
+			latest, ok := latestByStore[store]
+			if ok && version > latest {
+				return errors.Wrapf(ErrUnknownVersion, "%s: database at version %d, latest known is %d", store, version, latest)
+			}
+		}
+
+		for _, migration := range migrations {
+			if migration.Version <= applied[migration.Store] {
+				continue
+			}
+
+			err := inTx(ctx, conn, func(tx *sql.Tx) error {
+				if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+					return err
+				}
+
+				query := `INSERT INTO ` + schemaVersionTableName + ` (store, version, name, applied_at) VALUES ($1, $2, $3, NOW())`
+				_, err := tx.ExecContext(ctx, query, migration.Store, migration.Version, migration.Name)
+				return err
+			})
+			if err != nil {
+				return errors.Wrapf(err, "error applying %s", migration)
+			}
+		}
+
+		return nil
+	})
+}
+
+// Down reverts, in reverse order, all migrations that have been applied to the
+// database
+func Down(ctx context.Context, db *sql.DB, migrations []*Migration) error {
+	if err := validate(migrations); err != nil {
+		return err
+	}
+
+	return withLock(ctx, db, func(conn *sql.Conn) error {
+		applied, err := getAppliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(migrations) - 1; i >= 0; i-- {
+			migration := migrations[i]
+			if migration.Version > applied[migration.Store] {
+				continue
+			}
+
+			err := inTx(ctx, conn, func(tx *sql.Tx) error {
+				if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+					return err
+				}
+
+				query := `DELETE FROM ` + schemaVersionTableName + ` WHERE store = $1 AND version = $2`
+				_, err := tx.ExecContext(ctx, query, migration.Store, migration.Version)
+				return err
+			})
+			if err != nil {
+				return errors.Wrapf(err, "error reverting %s", migration)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (m *Migration) String() string {
+	return fmt.Sprintf("%s/%04d_%s", m.Store, m.Version, m.Name)
+}
+
+// validate ensures each store's migrations have up and down SQL, and are
+// numbered contiguously from 1 in the order they're provided
+func validate(migrations []*Migration) error {
+	lastByStore := make(map[string]uint32)
+	for _, migration := range migrations {
+		if len(migration.Store) == 0 {
+			return errors.Wrap(ErrInvalidMigrations, "store is required")
+		}
+
+		if len(strings.TrimSpace(migration.Up)) == 0 {
+			return errors.Wrapf(ErrInvalidMigrations, "%s: up sql is required", migration)
+		}
+
+		if len(strings.TrimSpace(migration.Down)) == 0 {
+			return errors.Wrapf(ErrInvalidMigrations, "%s: down sql is required", migration)
+		}
+
+		expected := lastByStore[migration.Store] + 1
+		if migration.Version != expected {
+			return errors.Wrapf(ErrInvalidMigrations, "%s: expected version %d", migration, expected)
+		}
+		lastByStore[migration.Store] = migration.Version
+	}
+	return nil
+}
+
+func withLock(ctx context.Context, db *sql.DB, fn func(conn *sql.Conn) error) error {
+	// Advisory locks are held by the session, so everything needs to run over
+	// the same connection
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, advisoryLockKey); err != nil {
+		return err
+	}
+	defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, advisoryLockKey)
+
+	query := `CREATE TABLE IF NOT EXISTS ` + schemaVersionTableName + ` (
+		store TEXT NOT NULL,
+		version INTEGER NOT NULL,
+		name TEXT NOT NULL,
+
+		applied_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+		PRIMARY KEY (store, version)
+	)`
+	if _, err := conn.ExecContext(ctx, query); err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+func getAppliedVersions(ctx context.Context, conn *sql.Conn) (map[string]uint32, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT store, MAX(version) FROM `+schemaVersionTableName+` GROUP BY store`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make(map[string]uint32)
+	for rows.Next() {
+		var store string
+		var version uint32
+		if err := rows.Scan(&store, &version); err != nil {
+			return nil, err
+		}
+		res[store] = version
+	}
+	return res, rows.Err()
+}
+
+func inTx(ctx context.Context, conn *sql.Conn, fn func(tx *sql.Tx) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
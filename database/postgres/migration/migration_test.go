@@ -0,0 +1,66 @@
+package migration
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_HappyPath(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/0002_add_index.up.sql":       {Data: []byte("CREATE INDEX ...")},
+		"migrations/0002_add_index.down.sql":     {Data: []byte("DROP INDEX ...")},
+		"migrations/0001_create_tables.up.sql":   {Data: []byte("CREATE TABLE ...")},
+		"migrations/0001_create_tables.down.sql": {Data: []byte("DROP TABLE ...")},
+		"migrations/README.md":                   {Data: []byte("ignored")},
+	}
+
+	migrations, err := Load("store", fsys, "migrations")
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+
+	assert.Equal(t, "store", migrations[0].Store)
+	assert.EqualValues(t, 1, migrations[0].Version)
+	assert.Equal(t, "create_tables", migrations[0].Name)
+	assert.Equal(t, "CREATE TABLE ...", migrations[0].Up)
+	assert.Equal(t, "DROP TABLE ...", migrations[0].Down)
+	assert.Equal(t, "store/0001_create_tables", migrations[0].String())
+
+	assert.Equal(t, "store", migrations[1].Store)
+	assert.EqualValues(t, 2, migrations[1].Version)
+	assert.Equal(t, "add_index", migrations[1].Name)
+	assert.Equal(t, "CREATE INDEX ...", migrations[1].Up)
+	assert.Equal(t, "DROP INDEX ...", migrations[1].Down)
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	for _, fsys := range []fstest.MapFS{
+		// Missing down
+		{
+			"migrations/0001_create_tables.up.sql": {Data: []byte("CREATE TABLE ...")},
+		},
+		// Gap in versions
+		{
+			"migrations/0001_create_tables.up.sql":   {Data: []byte("CREATE TABLE ...")},
+			"migrations/0001_create_tables.down.sql": {Data: []byte("DROP TABLE ...")},
+			"migrations/0003_add_index.up.sql":       {Data: []byte("CREATE INDEX ...")},
+			"migrations/0003_add_index.down.sql":     {Data: []byte("DROP INDEX ...")},
+		},
+		// Conflicting names for a version
+		{
+			"migrations/0001_create_tables.up.sql": {Data: []byte("CREATE TABLE ...")},
+			"migrations/0001_other_name.down.sql":  {Data: []byte("DROP TABLE ...")},
+		},
+		// Invalid version
+		{
+			"migrations/first_create_tables.up.sql":   {Data: []byte("CREATE TABLE ...")},
+			"migrations/first_create_tables.down.sql": {Data: []byte("DROP TABLE ...")},
+		},
+	} {
+		_, err := Load("store", fsys, "migrations")
+		assert.True(t, errors.Is(err, ErrInvalidMigrations))
+	}
+}
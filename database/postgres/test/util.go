@@ -35,6 +35,13 @@ const (
 
 // StartPostgresDB starts a Docker container using the postgres image and returns a postgres client for testing purposes.
 func StartPostgresDB(pool *dockertest.Pool) (db *sql.DB, closeFunc func(), err error) {
+	db, _, closeFunc, err = StartPostgresDBWithConnectionString(pool)
+	return db, closeFunc, err
+}
+
+// StartPostgresDBWithConnectionString is a variation of StartPostgresDB that
+// also returns the connection string for tests that need dedicated connections.
+func StartPostgresDBWithConnectionString(pool *dockertest.Pool) (db *sql.DB, databaseUrl string, closeFunc func(), err error) {
 	closeFunc = func() {}
 
 	// Pulls the image, creates a container based on it and runs it
@@ -55,7 +62,7 @@ func StartPostgresDB(pool *dockertest.Pool) (db *sql.DB, closeFunc func(), err e
 
 	// Check if the container resource was generated as expected
 	if err != nil {
-		return nil, closeFunc, errors.Wrapf(err, "failed to start resource")
+		return nil, "", closeFunc, errors.Wrapf(err, "failed to start resource")
 	}
 
 	// Uncomment this to view docker container logs (note: this will fully consume os.Stdout)
@@ -77,7 +84,7 @@ func StartPostgresDB(pool *dockertest.Pool) (db *sql.DB, closeFunc func(), err e
 	*/
 
 	hostAndPort := resource.GetHostPort(fmt.Sprintf("%d/tcp", port))
-	databaseUrl := fmt.Sprintf("postgres://%s:%s@%s/%s?sslmode=disable", user, password, hostAndPort, dbname)
+	databaseUrl = fmt.Sprintf("postgres://%s:%s@%s/%s?sslmode=disable", user, password, hostAndPort, dbname)
 
 	// You may need to adjust this number if it is too low for your test environment.
 	resource.Expire(containerAutoKill) // Tell docker to hard kill the container in 120 seconds
@@ -94,8 +101,8 @@ func StartPostgresDB(pool *dockertest.Pool) (db *sql.DB, closeFunc func(), err e
 		retry.Backoff(backoff.Constant(500*time.Millisecond), 500*time.Second),
 	)
 	if err != nil {
-		return nil, closeFunc, errors.Wrap(err, "timed out waiting for postgres container to become available")
+		return nil, "", closeFunc, errors.Wrap(err, "timed out waiting for postgres container to become available")
 	}
 
-	return db, closeFunc, nil
+	return db, databaseUrl, closeFunc, nil
 }
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"crypto/tls"
 	"expvar"
 	"flag"
@@ -32,6 +33,8 @@ import (
 	"github.com/code-payments/ocp-server/metrics"
 	newrelic_metrics "github.com/code-payments/ocp-server/metrics/newrelic"
 	noop_metrics "github.com/code-payments/ocp-server/metrics/noop"
+	otel_metrics "github.com/code-payments/ocp-server/metrics/otel"
+	prometheus_metrics "github.com/code-payments/ocp-server/metrics/prometheus"
 	"github.com/code-payments/ocp-server/osutil"
 )
 
@@ -105,8 +108,19 @@ func Run(app App, options ...Option) error {
 
 	log = zap.New(getLogCore(getLogLevel(config.LogLevel)))
 
+	metricsProviderType := config.MetricsProvider
+	if len(metricsProviderType) == 0 {
+		metricsProviderType = metricsProviderNoop
+		if len(config.NewRelicLicenseKey) > 0 {
+			metricsProviderType = metricsProviderNewRelic
+		}
+	}
+
 	var metricsProvider metrics.Provider
-	if len(config.NewRelicLicenseKey) > 0 {
+	var metricsHandler http.Handler
+	shutdownMetrics := func(context.Context) error { return nil }
+	switch metricsProviderType {
+	case metricsProviderNewRelic:
 		nr, err := newrelic.NewApplication(
 			newrelic.ConfigFromEnvironment(),
 			newrelic.ConfigAppName(config.AppName),
@@ -128,8 +142,26 @@ func Run(app App, options ...Option) error {
 			os.Exit(1)
 		}
 		log = zap.New(nrLogCore)
-	} else {
+	case metricsProviderPrometheus:
+		promProvider := prometheus_metrics.NewProvider(metricsNamespace)
+		metricsProvider = promProvider
+		metricsHandler = promProvider.Handler()
+	case metricsProviderOtel:
+		tracerProvider, err := newOtelTracerProvider(config)
+		if err != nil {
+			log.With(zap.Error(err)).Error("error creating otel tracer provider")
+			os.Exit(1)
+		}
+		shutdownMetrics = tracerProvider.Shutdown
+
+		promProvider := prometheus_metrics.NewProvider(metricsNamespace)
+		metricsProvider = otel_metrics.NewProvider(tracerProvider.Tracer(config.AppName), promProvider)
+		metricsHandler = promProvider.Handler()
+	case metricsProviderNoop:
 		metricsProvider = noop_metrics.NewProvider()
+	default:
+		log.Error(fmt.Sprintf("unsupported metrics provider: %s", metricsProviderType))
+		os.Exit(1)
 	}
 
 	if len(config.AppName) == 0 {
@@ -155,7 +187,11 @@ func Run(app App, options ...Option) error {
 		debugHTTPMux.HandleFunc("/debug/pprof/trace", pprof.Trace)
 	}
 
-	if config.EnableExpvar || config.EnablePprof {
+	if metricsHandler != nil {
+		debugHTTPMux.Handle("/metrics", metricsHandler)
+	}
+
+	if config.EnableExpvar || config.EnablePprof || metricsHandler != nil {
 		go func() {
 			for {
 				if err := http.ListenAndServe(config.DebugListenAddress, debugHTTPMux); err != nil {
@@ -326,6 +362,11 @@ func Run(app App, options ...Option) error {
 		insecureServ.GracefulStop()
 		app.Stop()
 
+		// Flush any buffered traces
+		if err := shutdownMetrics(context.Background()); err != nil {
+			log.With(zap.Error(err)).Warn("failed to shutdown metrics provider")
+		}
+
 		close(shutdownCh)
 	}()
 
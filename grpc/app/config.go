@@ -50,8 +50,20 @@ type BaseConfig struct {
 	MemoryLeakCronSchedule string `mapstructure:"memory_leak_cron_schedule"`
 
 	// Metrics configuration across many providers
+	//
+	// MetricsProvider selects the metrics backend, and is one of newrelic,
+	// prometheus, otel or noop. When empty, New Relic is used when a license key
+	// is configured, otherwise metrics are disabled. The otel provider exports
+	// traces via OTLP, and exposes all other metrics via Prometheus. Prometheus
+	// metrics are served on the debug listener at /metrics.
+	MetricsProvider    string `mapstructure:"metrics_provider"`
 	NewRelicLicenseKey string `mapstructure:"new_relic_license_key"`
 
+	// OtelExporterEndpoint is an optional OTLP HTTP endpoint URL that traces are
+	// exported to. When empty, the standard OTEL_EXPORTER_OTLP_* environment
+	// variables are used.
+	OtelExporterEndpoint string `mapstructure:"otel_exporter_endpoint"`
+
 	// Arbitrary configuration that the service can define / implement.
 	//
 	// Users should use mapstructure.Decode for ServiceConfig.
@@ -98,5 +110,7 @@ func init() {
 	_ = viper.BindEnv("enable_memory_leak_cron", "ENABLE_MEMORY_LEAK_CRON")
 	_ = viper.BindEnv("memory_leak_cron_schedule", "MEMORY_LEAK_CRON_SCHEDULE")
 
+	_ = viper.BindEnv("metrics_provider", "METRICS_PROVIDER")
 	_ = viper.BindEnv("new_relic_license_key", "NEW_RELIC_LICENSE_KEY")
+	_ = viper.BindEnv("otel_exporter_endpoint", "OTEL_EXPORTER_ENDPOINT")
 }
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	metricsProviderNewRelic   = "newrelic"
+	metricsProviderPrometheus = "prometheus"
+	metricsProviderOtel       = "otel"
+	metricsProviderNoop       = "noop"
+
+	metricsNamespace = "ocp"
+)
+
+func newOtelTracerProvider(config BaseConfig) (*sdktrace.TracerProvider, error) {
+	var opts []otlptracehttp.Option
+	if len(config.OtelExporterEndpoint) > 0 {
+		opts = append(opts, otlptracehttp.WithEndpointURL(config.OtelExporterEndpoint))
+	}
+
+	exporter, err := otlptracehttp.New(context.Background(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewSchemaless(attribute.String("service.name", config.AppName)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tracerProvider)
+
+	return tracerProvider, nil
+}
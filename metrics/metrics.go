@@ -20,3 +20,11 @@ func RecordDuration(ctx context.Context, metricName string, duration time.Durati
 		provider.RecordDuration(metricName, duration)
 	}
 }
+
+// RecordGauge records the current value of a gauge metric
+func RecordGauge(ctx context.Context, metricName string, value float64, labels map[string]string) {
+	provider, ok := ctx.Value(ProviderContextKey).(Provider)
+	if ok && provider != nil {
+		provider.RecordGauge(metricName, value, labels)
+	}
+}
@@ -3,6 +3,7 @@ package newrelic
 import (
 	"net/http"
 	"net/url"
+	"sort"
 	"time"
 
 	"github.com/newrelic/go-agent/v3/newrelic"
@@ -46,6 +47,23 @@ func (p *Provider) RecordDuration(metricName string, duration time.Duration) {
 	p.app.RecordCustomMetric(metricName, float64(duration/time.Millisecond))
 }
 
+// RecordGauge records a gauge metric. New Relic custom metrics don't support
+// labels, so they're encoded into the metric name.
+func (p *Provider) RecordGauge(metricName string, value float64, labels map[string]string) {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	qualified := metricName
+	for _, key := range keys {
+		qualified += "/" + key + "=" + labels[key]
+	}
+
+	p.app.RecordCustomMetric(qualified, value)
+}
+
 // Trace wraps a New Relic transaction
 type Trace struct {
 	txn *newrelic.Transaction
@@ -30,6 +30,9 @@ func (p *Provider) RecordCount(metricName string, count uint64) {}
 // RecordDuration is a no-op
 func (p *Provider) RecordDuration(metricName string, duration time.Duration) {}
 
+// RecordGauge is a no-op
+func (p *Provider) RecordGauge(metricName string, value float64, labels map[string]string) {}
+
 // Trace is a no-op trace
 type Trace struct{}
 
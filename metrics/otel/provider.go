@@ -0,0 +1,191 @@
+package otel
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/code-payments/ocp-server/metrics"
+)
+
+// Provider implements the metrics.Provider interface by exporting traces to
+// OpenTelemetry. OpenTelemetry is only used for tracing, so all other metrics,
+// including trace durations, are delegated to another provider (eg. Prometheus).
+type Provider struct {
+	tracer   trace.Tracer
+	delegate metrics.Provider
+}
+
+// NewProvider creates a new OpenTelemetry metrics provider
+func NewProvider(tracer trace.Tracer, delegate metrics.Provider) *Provider {
+	return &Provider{
+		tracer:   tracer,
+		delegate: delegate,
+	}
+}
+
+// StartTrace starts a new trace, which is a root span in OpenTelemetry
+func (p *Provider) StartTrace(name string) metrics.Trace {
+	ctx, span := p.tracer.Start(context.Background(), name, trace.WithSpanKind(trace.SpanKindServer))
+	return &Trace{
+		tracer:   p.tracer,
+		ctx:      ctx,
+		span:     span,
+		delegate: p.delegate.StartTrace(name),
+	}
+}
+
+// RecordEvent records a custom event with key-value attributes
+func (p *Provider) RecordEvent(eventName string, attributes map[string]interface{}) {
+	p.delegate.RecordEvent(eventName, attributes)
+}
+
+// RecordCount records a count metric
+func (p *Provider) RecordCount(metricName string, count uint64) {
+	p.delegate.RecordCount(metricName, count)
+}
+
+// RecordDuration records a duration metric
+func (p *Provider) RecordDuration(metricName string, duration time.Duration) {
+	p.delegate.RecordDuration(metricName, duration)
+}
+
+// RecordGauge records a gauge metric
+func (p *Provider) RecordGauge(metricName string, value float64, labels map[string]string) {
+	p.delegate.RecordGauge(metricName, value, labels)
+}
+
+// Trace wraps an OpenTelemetry root span
+type Trace struct {
+	tracer   trace.Tracer
+	ctx      context.Context
+	span     trace.Span
+	delegate metrics.Trace
+}
+
+// StartSpan starts a new child span within the trace
+func (t *Trace) StartSpan(name string) metrics.Span {
+	_, span := t.tracer.Start(t.ctx, name)
+	return &Span{
+		span:     span,
+		delegate: t.delegate.StartSpan(name),
+	}
+}
+
+// AddAttribute adds a key-value attribute to the trace
+func (t *Trace) AddAttribute(key string, value interface{}) {
+	t.span.SetAttributes(toAttribute(key, value))
+	t.delegate.AddAttribute(key, value)
+}
+
+// OnError records an error on the trace
+func (t *Trace) OnError(err error) {
+	if err == nil {
+		return
+	}
+
+	t.span.RecordError(err)
+	t.span.SetStatus(codes.Error, err.Error())
+	t.delegate.OnError(err)
+}
+
+// SetRequest sets HTTP request information on the trace
+func (t *Trace) SetRequest(r metrics.Request) {
+	t.span.SetAttributes(attribute.String("http.request.method", r.Method))
+	if u, ok := r.URL.(*url.URL); ok && u != nil {
+		t.span.SetAttributes(attribute.String("url.full", u.String()))
+	}
+	t.delegate.SetRequest(r)
+}
+
+// SetResponse sets the HTTP response writer for the trace
+func (t *Trace) SetResponse(w http.ResponseWriter) http.ResponseWriter {
+	return &responseWriter{
+		ResponseWriter: t.delegate.SetResponse(w),
+		span:           t.span,
+	}
+}
+
+// End completes the trace
+func (t *Trace) End() {
+	t.span.End()
+	t.delegate.End()
+}
+
+// Span wraps an OpenTelemetry child span
+type Span struct {
+	span     trace.Span
+	delegate metrics.Span
+}
+
+// AddAttribute adds a key-value attribute to the span
+func (s *Span) AddAttribute(key string, value interface{}) {
+	s.span.SetAttributes(toAttribute(key, value))
+	s.delegate.AddAttribute(key, value)
+}
+
+// End completes the span
+func (s *Span) End() {
+	s.span.End()
+	s.delegate.End()
+}
+
+// responseWriter records the status code written for a trace on its span
+type responseWriter struct {
+	http.ResponseWriter
+	span trace.Span
+}
+
+func (w *responseWriter) Header() http.Header {
+	if w.ResponseWriter == nil {
+		return http.Header{}
+	}
+	return w.ResponseWriter.Header()
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if w.ResponseWriter == nil {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseWriter) WriteHeader(statusCode int) {
+	w.span.SetAttributes(attribute.Int("response.status_code", statusCode))
+	if w.ResponseWriter != nil {
+		w.ResponseWriter.WriteHeader(statusCode)
+	}
+}
+
+func toAttribute(key string, value interface{}) attribute.KeyValue {
+	switch typed := value.(type) {
+	case string:
+		return attribute.String(key, typed)
+	case bool:
+		return attribute.Bool(key, typed)
+	case int:
+		return attribute.Int(key, typed)
+	case int32:
+		return attribute.Int64(key, int64(typed))
+	case int64:
+		return attribute.Int64(key, typed)
+	case uint32:
+		return attribute.Int64(key, int64(typed))
+	case uint64:
+		return attribute.Int64(key, int64(typed))
+	case float32:
+		return attribute.Float64(key, float64(typed))
+	case float64:
+		return attribute.Float64(key, typed)
+	case fmt.Stringer:
+		return attribute.String(key, typed.String())
+	default:
+		return attribute.String(key, fmt.Sprint(value))
+	}
+}
@@ -0,0 +1,50 @@
+package otel
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	noop_metrics "github.com/code-payments/ocp-server/metrics/noop"
+)
+
+func TestProvider_Traces(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	p := NewProvider(tracerProvider.Tracer("test"), noop_metrics.NewProvider())
+
+	trace := p.StartTrace("transaction.v1.Transaction/SubmitIntent")
+	trace.AddAttribute("intent", "intent_id")
+	trace.AddAttribute("action_count", uint64(3))
+
+	span := trace.StartSpan("intent_handler Validate")
+	span.AddAttribute("valid", true)
+	span.End()
+
+	trace.SetResponse(nil).WriteHeader(5)
+	trace.OnError(errors.New("failure"))
+	trace.End()
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 2)
+
+	child, root := ended[0], ended[1]
+	assert.Equal(t, "intent_handler Validate", child.Name())
+	assert.Equal(t, root.SpanContext().SpanID(), child.Parent().SpanID())
+	assert.Contains(t, child.Attributes(), attribute.Bool("valid", true))
+
+	assert.Equal(t, "transaction.v1.Transaction/SubmitIntent", root.Name())
+	assert.False(t, root.Parent().IsValid())
+	assert.Contains(t, root.Attributes(), attribute.String("intent", "intent_id"))
+	assert.Contains(t, root.Attributes(), attribute.Int64("action_count", 3))
+	assert.Contains(t, root.Attributes(), attribute.Int("response.status_code", 5))
+	assert.Equal(t, codes.Error, root.Status().Code)
+	require.Len(t, root.Events(), 1)
+	assert.Equal(t, "exception", root.Events()[0].Name)
+}
@@ -0,0 +1,310 @@
+package prometheus
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/code-payments/ocp-server/metrics"
+)
+
+var invalidMetricNameCharacters = regexp.MustCompile(`[^a-zA-Z0-9_]+`)
+
+// Provider implements the metrics.Provider interface by exposing Prometheus
+// metrics to be scraped.
+//
+// Custom events are counted by name, and their attributes are dropped, since
+// they typically contain high cardinality values (eg. IDs). Traces and spans
+// are recorded as duration histograms.
+type Provider struct {
+	namespace string
+	registry  *prometheus.Registry
+
+	events *prometheus.CounterVec
+	traces *prometheus.HistogramVec
+	spans  *prometheus.HistogramVec
+
+	mu         sync.Mutex
+	counters   map[string]prometheus.Counter
+	histograms map[string]prometheus.Histogram
+	gauges     map[string]*prometheus.GaugeVec
+}
+
+// NewProvider creates a new Prometheus metrics provider, where all metrics are
+// prefixed with the provided namespace
+func NewProvider(namespace string) *Provider {
+	namespace = toMetricName(namespace)
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	p := &Provider{
+		namespace: namespace,
+		registry:  registry,
+
+		events: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "events_total",
+			Help:      "Count of custom events by name",
+		}, []string{"event"}),
+		traces: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "trace_duration_seconds",
+			Help:      "Duration of traces",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"trace", "status_code", "error"}),
+		spans: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "span_duration_seconds",
+			Help:      "Duration of spans within traces",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"trace", "span"}),
+
+		counters:   make(map[string]prometheus.Counter),
+		histograms: make(map[string]prometheus.Histogram),
+		gauges:     make(map[string]*prometheus.GaugeVec),
+	}
+
+	registry.MustRegister(p.events, p.traces, p.spans)
+
+	return p
+}
+
+// Handler returns the HTTP handler that serves metrics to Prometheus scrapers
+func (p *Provider) Handler() http.Handler {
+	return promhttp.HandlerFor(p.registry, promhttp.HandlerOpts{})
+}
+
+// Registry returns the underlying Prometheus registry for cases where direct
+// access is needed (eg. registering additional collectors)
+func (p *Provider) Registry() *prometheus.Registry {
+	return p.registry
+}
+
+// StartTrace starts a new trace
+func (p *Provider) StartTrace(name string) metrics.Trace {
+	return &Trace{
+		provider: p,
+		name:     name,
+		start:    time.Now(),
+	}
+}
+
+// RecordEvent counts a custom event
+func (p *Provider) RecordEvent(eventName string, attributes map[string]interface{}) {
+	p.events.WithLabelValues(eventName).Inc()
+}
+
+// RecordCount records a count metric
+func (p *Provider) RecordCount(metricName string, count uint64) {
+	name := toMetricName(metricName) + "_total"
+
+	p.mu.Lock()
+	counter, ok := p.counters[name]
+	if !ok {
+		counter = prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: p.namespace,
+			Name:      name,
+			Help:      metricName,
+		})
+		if err := p.registry.Register(counter); err != nil {
+			p.mu.Unlock()
+			return
+		}
+		p.counters[name] = counter
+	}
+	p.mu.Unlock()
+
+	counter.Add(float64(count))
+}
+
+// RecordDuration records a duration metric
+func (p *Provider) RecordDuration(metricName string, duration time.Duration) {
+	name := toMetricName(metricName) + "_seconds"
+
+	p.mu.Lock()
+	histogram, ok := p.histograms[name]
+	if !ok {
+		histogram = prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: p.namespace,
+			Name:      name,
+			Help:      metricName,
+			Buckets:   prometheus.DefBuckets,
+		})
+		if err := p.registry.Register(histogram); err != nil {
+			p.mu.Unlock()
+			return
+		}
+		p.histograms[name] = histogram
+	}
+	p.mu.Unlock()
+
+	histogram.Observe(duration.Seconds())
+}
+
+// RecordGauge records a gauge metric. Values recorded with label keys that
+// differ from the first recording of the metric are dropped.
+func (p *Provider) RecordGauge(metricName string, value float64, labels map[string]string) {
+	name := toMetricName(metricName)
+
+	p.mu.Lock()
+	gauge, ok := p.gauges[name]
+	if !ok {
+		labelNames := make([]string, 0, len(labels))
+		for key := range labels {
+			labelNames = append(labelNames, key)
+		}
+		sort.Strings(labelNames)
+
+		gauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: p.namespace,
+			Name:      name,
+			Help:      metricName,
+		}, labelNames)
+		if err := p.registry.Register(gauge); err != nil {
+			p.mu.Unlock()
+			return
+		}
+		p.gauges[name] = gauge
+	}
+	p.mu.Unlock()
+
+	metric, err := gauge.GetMetricWith(labels)
+	if err != nil {
+		return
+	}
+	metric.Set(value)
+}
+
+// Trace measures the duration of a unit of work
+type Trace struct {
+	provider *Provider
+	name     string
+	start    time.Time
+
+	mu         sync.Mutex
+	hasError   bool
+	statusCode int
+}
+
+// StartSpan starts a new span within the trace
+func (t *Trace) StartSpan(name string) metrics.Span {
+	return &Span{
+		trace: t,
+		name:  name,
+		start: time.Now(),
+	}
+}
+
+// AddAttribute is a no-op, since attributes are typically high cardinality
+func (t *Trace) AddAttribute(key string, value interface{}) {}
+
+// OnError records an error on the trace
+func (t *Trace) OnError(err error) {
+	if err == nil {
+		return
+	}
+
+	t.mu.Lock()
+	t.hasError = true
+	t.mu.Unlock()
+}
+
+// SetRequest is a no-op
+func (t *Trace) SetRequest(r metrics.Request) {}
+
+// SetResponse captures the status code written to the response, which is
+// recorded with the trace
+func (t *Trace) SetResponse(w http.ResponseWriter) http.ResponseWriter {
+	return &responseWriter{ResponseWriter: w, trace: t}
+}
+
+// End completes the trace
+func (t *Trace) End() {
+	t.mu.Lock()
+	statusCode := ""
+	if t.statusCode != 0 {
+		statusCode = strconv.Itoa(t.statusCode)
+	}
+	hasError := strconv.FormatBool(t.hasError)
+	t.mu.Unlock()
+
+	t.provider.traces.WithLabelValues(t.name, statusCode, hasError).Observe(time.Since(t.start).Seconds())
+}
+
+// Span measures the duration of an operation within a trace
+type Span struct {
+	trace *Trace
+	name  string
+	start time.Time
+}
+
+// AddAttribute is a no-op, since attributes are typically high cardinality
+func (s *Span) AddAttribute(key string, value interface{}) {}
+
+// End completes the span
+func (s *Span) End() {
+	s.trace.provider.spans.WithLabelValues(s.trace.name, s.name).Observe(time.Since(s.start).Seconds())
+}
+
+// responseWriter captures the status code written for a trace. The underlying
+// writer is optional, since gRPC traces only report a status code.
+type responseWriter struct {
+	http.ResponseWriter
+	trace *Trace
+}
+
+func (w *responseWriter) Header() http.Header {
+	if w.ResponseWriter == nil {
+		return http.Header{}
+	}
+	return w.ResponseWriter.Header()
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if w.ResponseWriter == nil {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseWriter) WriteHeader(statusCode int) {
+	w.trace.mu.Lock()
+	w.trace.statusCode = statusCode
+	w.trace.mu.Unlock()
+
+	if w.ResponseWriter != nil {
+		w.ResponseWriter.WriteHeader(statusCode)
+	}
+}
+
+// toMetricName converts a name into a valid snake case Prometheus metric name
+// (eg. "StateMachineBatchProcessed" to "state_machine_batch_processed")
+func toMetricName(name string) string {
+	var sb strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				sb.WriteRune('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+
+	res := invalidMetricNameCharacters.ReplaceAllString(sb.String(), "_")
+	return strings.Trim(res, "_")
+}
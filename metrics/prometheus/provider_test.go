@@ -0,0 +1,71 @@
+package prometheus
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvider_ExposesMetrics(t *testing.T) {
+	p := NewProvider("ocp")
+
+	p.RecordEvent("StateMachineBatchProcessed", map[string]interface{}{"id": "high_cardinality"})
+	p.RecordEvent("StateMachineBatchProcessed", nil)
+	p.RecordCount("FulfillmentsSubmitted", 3)
+	p.RecordDuration("BlockchainSubmission", 250*time.Millisecond)
+	p.RecordGauge("NonceCount", 10, map[string]string{"state": "available", "pool": "solana:mainnet"})
+	p.RecordGauge("NonceCount", 2, map[string]string{"state": "reserved", "pool": "solana:mainnet"})
+
+	// Inconsistent label keys are dropped
+	p.RecordGauge("NonceCount", 99, map[string]string{"state": "invalid"})
+
+	trace := p.StartTrace("transaction.v1.Transaction/SubmitIntent")
+	span := trace.StartSpan("intent_handler Validate")
+	span.End()
+	trace.SetResponse(nil).WriteHeader(5)
+	trace.OnError(errors.New("failure"))
+	trace.End()
+
+	body := scrape(t, p)
+	assert.Contains(t, body, `ocp_events_total{event="StateMachineBatchProcessed"} 2`)
+	assert.NotContains(t, body, "high_cardinality")
+	assert.Contains(t, body, `ocp_fulfillments_submitted_total 3`)
+	assert.Contains(t, body, `ocp_blockchain_submission_seconds_count 1`)
+	assert.Contains(t, body, `ocp_nonce_count{pool="solana:mainnet",state="available"} 10`)
+	assert.Contains(t, body, `ocp_nonce_count{pool="solana:mainnet",state="reserved"} 2`)
+	assert.NotContains(t, body, `state="invalid"`)
+	assert.Contains(t, body, `ocp_trace_duration_seconds_count{error="true",status_code="5",trace="transaction.v1.Transaction/SubmitIntent"} 1`)
+	assert.Contains(t, body, `ocp_span_duration_seconds_count{span="intent_handler Validate",trace="transaction.v1.Transaction/SubmitIntent"} 1`)
+	assert.Contains(t, body, "go_goroutines")
+}
+
+func TestToMetricName(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		expected string
+	}{
+		{"StateMachineBatchProcessed", "state_machine_batch_processed"},
+		{"GeyserConsumerQueuePollingCheck", "geyser_consumer_queue_polling_check"},
+		{"HTTPRequest", "http_request"},
+		{"Vm2Count", "vm2_count"},
+		{"already_snake_case", "already_snake_case"},
+		{"with spaces.and-dashes", "with_spaces_and_dashes"},
+	} {
+		assert.Equal(t, tc.expected, toMetricName(tc.in), tc.in)
+	}
+}
+
+func scrape(t *testing.T, p *Provider) string {
+	w := httptest.NewRecorder()
+	p.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	require.Equal(t, 200, w.Code)
+
+	body, err := io.ReadAll(w.Body)
+	require.NoError(t, err)
+	return string(body)
+}
@@ -20,6 +20,10 @@ type Provider interface {
 
 	// RecordDuration records a duration metric
 	RecordDuration(metricName string, duration time.Duration)
+
+	// RecordGauge records the current value of a gauge metric, qualified by a
+	// set of labels. A metric must always be recorded with the same label keys.
+	RecordGauge(metricName string, value float64, labels map[string]string)
 }
 
 // Request contains HTTP request information for tracing
@@ -3,108 +3,279 @@ package aml
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"go.uber.org/zap"
 
-	currency_util "github.com/code-payments/ocp-server/ocp/currency"
-	ocp_data "github.com/code-payments/ocp-server/ocp/data"
-	"github.com/code-payments/ocp-server/ocp/data/intent"
 	currency_lib "github.com/code-payments/ocp-server/currency"
 	"github.com/code-payments/ocp-server/metrics"
-)
-
-var (
-	// These limits are intentionally higher than that enforced on clients,
-	// so we can do better rounding on limits per currency.
-	//
-	// todo: configurable
-	maxDailyUsdLimit = 1.2 * currency_util.MaxDailyUsdLimit
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/intent"
 )
 
 // Guard gates money movement by applying rules on operations of interest to
 // discourage money laundering.
 type Guard struct {
-	log  *zap.Logger
-	data ocp_data.Provider
+	log      *zap.Logger
+	data     ocp_data.Provider
+	policies PolicyProvider
 }
 
-func NewGuard(log *zap.Logger, data ocp_data.Provider) *Guard {
+func NewGuard(log *zap.Logger, data ocp_data.Provider, policies PolicyProvider) *Guard {
 	return &Guard{
-		log:  log,
-		data: data,
+		log:      log,
+		data:     data,
+		policies: policies,
 	}
 }
 
+// moneyMovement is the normalized view of an intent that AML rules are
+// evaluated against
+type moneyMovement struct {
+	action Action
+
+	owner string
+
+	currency       currency_lib.Code
+	nativeAmount   float64
+	usdMarketValue float64
+
+	recipients []*recipient
+}
+
+type recipient struct {
+	owner          string
+	usdMarketValue float64
+}
+
 // AllowMoneyMovement determines whether an intent that moves funds is allowed
 // to be executed.
 func (g *Guard) AllowMoneyMovement(ctx context.Context, intentRecord *intent.Record) (bool, error) {
 	tracer := metrics.TraceMethodCall(ctx, metricsStructName, "AllowMoneyMovement")
 	defer tracer.End()
 
-	var currency currency_lib.Code
-	var nativeAmount float64
-	var usdMarketValue float64
-	var consumptionCalculator func(ctx context.Context, owner string, since time.Time) (uint64, float64, error)
-	var action string
+	movement, err := toMoneyMovement(intentRecord)
+	if err != nil {
+		tracer.OnError(err)
+		return false, err
+	}
+
+	log := g.log.With(
+		zap.String("method", "AllowMoneyMovement"),
+		zap.String("action", string(movement.action)),
+		zap.String("owner", movement.owner),
+		zap.String("currency", string(movement.currency)),
+		zap.Float64("native_amount", movement.nativeAmount),
+		zap.Float64("usd_value", movement.usdMarketValue),
+	)
+
+	policy, policyErr := g.policies.GetPolicy(ctx)
+	if policyErr != nil && policy == nil {
+		tracer.OnError(policyErr)
+		return false, policyErr
+	} else if policyErr != nil {
+		log.With(zap.Error(policyErr)).Warn("failure getting latest aml policy, using last valid policy")
+	}
+
+	for _, rule := range policy.GetTier(movement.owner).Rules {
+		if !rule.AppliesTo(movement.action) {
+			continue
+		}
+
+		var reason string
+		switch rule.Type {
+		case RuleTypeMaxPerTransaction:
+			reason = evaluateMaxPerTransaction(rule, movement)
+		case RuleTypeMaxSentUsdValue:
+			reason, err = g.evaluateMaxSentUsdValue(ctx, rule, movement)
+		case RuleTypeMaxSentCount:
+			reason, err = g.evaluateMaxSentCount(ctx, rule, movement)
+		case RuleTypeMaxReceivedUsdValue:
+			// Evaluated against each recipient's tier below
+			continue
+		}
+		if err != nil {
+			tracer.OnError(err)
+			return false, err
+		}
+
+		if len(reason) > 0 {
+			log.With(zap.String("reason", reason)).Info("denying intent")
+			recordDenialEvent(ctx, movement.action, reason)
+			return false, nil
+		}
+	}
+
+	for _, recipient := range movement.recipients {
+		for _, rule := range policy.GetTier(recipient.owner).Rules {
+			if rule.Type != RuleTypeMaxReceivedUsdValue || !rule.AppliesTo(movement.action) {
+				continue
+			}
+
+			reason, err := g.evaluateMaxReceivedUsdValue(ctx, rule, recipient)
+			if err != nil {
+				tracer.OnError(err)
+				return false, err
+			}
+
+			if len(reason) > 0 {
+				log.With(
+					zap.String("reason", reason),
+					zap.String("recipient", recipient.owner),
+				).Info("denying intent")
+				recordDenialEvent(ctx, movement.action, reason)
+				return false, nil
+			}
+		}
+	}
+
+	return true, nil
+}
+
+func toMoneyMovement(intentRecord *intent.Record) (*moneyMovement, error) {
+	movement := &moneyMovement{
+		owner: intentRecord.InitiatorOwnerAccount,
+	}
+
 	switch intentRecord.IntentType {
 	case intent.SendPublicPayment:
-		// Public withdrawals are always allowed
-		if intentRecord.SendPublicPaymentMetadata.IsWithdrawal {
-			return true, nil
+		metadata := intentRecord.SendPublicPaymentMetadata
+
+		movement.action = ActionSendPayment
+		if metadata.IsWithdrawal {
+			movement.action = ActionWithdraw
 		}
 
-		// Public gives and sends are subject to limits
-		currency = intentRecord.SendPublicPaymentMetadata.ExchangeCurrency
-		nativeAmount = intentRecord.SendPublicPaymentMetadata.NativeAmount
-		usdMarketValue = intentRecord.SendPublicPaymentMetadata.UsdMarketValue
-		consumptionCalculator = g.data.GetTransactedAmountForAntiMoneyLaundering
-		action = actionSendPayment
+		movement.currency = metadata.ExchangeCurrency
+		movement.nativeAmount = metadata.NativeAmount
+		movement.usdMarketValue = metadata.UsdMarketValue
+
+		if !metadata.IsWithdrawal && metadata.DestinationOwnerAccount != movement.owner {
+			movement.recipients = append(movement.recipients, &recipient{
+				owner:          metadata.DestinationOwnerAccount,
+				usdMarketValue: metadata.UsdMarketValue,
+			})
+		}
 	case intent.ReceivePaymentsPublicly:
-		// Public receives are always allowed
-		return true, nil
+		metadata := intentRecord.ReceivePaymentsPubliclyMetadata
+
+		movement.action = ActionReceivePayment
+
+		movement.currency = metadata.OriginalExchangeCurrency
+		movement.nativeAmount = metadata.OriginalNativeAmount
+		movement.usdMarketValue = metadata.UsdMarketValue
+
+		movement.recipients = append(movement.recipients, &recipient{
+			owner:          movement.owner,
+			usdMarketValue: metadata.UsdMarketValue,
+		})
 	case intent.PublicDistribution:
-		// Public distributions are always allowed
-		return true, nil
+		metadata := intentRecord.PublicDistributionMetadata
+
+		movement.action = ActionDistribute
+
+		movement.usdMarketValue = metadata.UsdMarketValue
+
+		for _, distribution := range metadata.Distributions {
+			var usdMarketValue float64
+			if metadata.Quantity > 0 {
+				usdMarketValue = metadata.UsdMarketValue * float64(distribution.Quantity) / float64(metadata.Quantity)
+			}
+
+			movement.recipients = append(movement.recipients, &recipient{
+				owner:          distribution.DestinationOwnerAccount,
+				usdMarketValue: usdMarketValue,
+			})
+		}
 	default:
-		err := errors.New("intent record must be a send or receive payment")
-		tracer.OnError(err)
-		return false, err
+		return nil, errors.New("intent record must be a send or receive payment")
 	}
 
-	log := g.log.With(
-		zap.String("method", "AllowMoneyMovement"),
-		zap.String("owner", intentRecord.InitiatorOwnerAccount),
-		zap.String("currency", string(currency)),
-		zap.Float64("native_amount", nativeAmount),
-		zap.Float64("usd_value", usdMarketValue),
-	)
+	return movement, nil
+}
 
-	sendLimit, ok := currency_util.SendLimits[currency]
-	if !ok {
-		log.Info("denying intent with unsupported currency")
-		recordDenialEvent(ctx, action, "unsupported currency")
-		return false, nil
+func evaluateMaxPerTransaction(rule *Rule, movement *moneyMovement) string {
+	if len(rule.MaxNativeAmounts) > 0 {
+		maxNativeAmount, ok := rule.MaxNativeAmounts[movement.currency]
+		if !ok {
+			return getDenialReason(rule, "unsupported currency")
+		}
+
+		if movement.nativeAmount > maxNativeAmount {
+			return getDenialReason(rule, "exceeds per-transaction value")
+		}
 	}
 
-	if nativeAmount > sendLimit.PerTransaction {
-		log.Info("denying intent that exceeds per-transaction value")
-		recordDenialEvent(ctx, action, "exceeds per-transaction value")
-		return false, nil
+	if rule.MaxUsdValue > 0 && movement.usdMarketValue > rule.MaxUsdValue {
+		return getDenialReason(rule, "exceeds per-transaction usd value")
 	}
 
-	// Bound the maximum dollar value of payments in the last day
-	_, usdInLastDay, err := consumptionCalculator(ctx, intentRecord.InitiatorOwnerAccount, time.Now().Add(-24*time.Hour))
+	return ""
+}
+
+func (g *Guard) evaluateMaxSentUsdValue(ctx context.Context, rule *Rule, movement *moneyMovement) (string, error) {
+	since, err := getWindowStart(rule)
 	if err != nil {
-		tracer.OnError(err)
-		return false, err
+		return "", err
 	}
 
-	if usdInLastDay+usdMarketValue > maxDailyUsdLimit {
-		log.Info("denying intent that exceeds daily usd limit")
-		recordDenialEvent(ctx, action, "exceeds daily usd value")
-		return false, nil
+	_, usdSent, err := g.data.GetTransactedAmountForAntiMoneyLaundering(ctx, movement.owner, since)
+	if err != nil {
+		return "", err
 	}
 
-	return true, nil
+	if usdSent+movement.usdMarketValue > rule.MaxUsdValue {
+		return getDenialReason(rule, fmt.Sprintf("exceeds %s usd send value", rule.Window)), nil
+	}
+	return "", nil
+}
+
+func (g *Guard) evaluateMaxSentCount(ctx context.Context, rule *Rule, movement *moneyMovement) (string, error) {
+	since, err := getWindowStart(rule)
+	if err != nil {
+		return "", err
+	}
+
+	count, err := g.data.GetTransactedCountForAntiMoneyLaundering(ctx, movement.owner, since)
+	if err != nil {
+		return "", err
+	}
+
+	if count+1 > rule.MaxCount {
+		return getDenialReason(rule, fmt.Sprintf("exceeds %s send count", rule.Window)), nil
+	}
+	return "", nil
+}
+
+func (g *Guard) evaluateMaxReceivedUsdValue(ctx context.Context, rule *Rule, recipient *recipient) (string, error) {
+	since, err := getWindowStart(rule)
+	if err != nil {
+		return "", err
+	}
+
+	_, usdReceived, err := g.data.GetReceivedAmountForAntiMoneyLaundering(ctx, recipient.owner, since)
+	if err != nil {
+		return "", err
+	}
+
+	if usdReceived+recipient.usdMarketValue > rule.MaxUsdValue {
+		return getDenialReason(rule, fmt.Sprintf("exceeds %s usd receive value", rule.Window)), nil
+	}
+	return "", nil
+}
+
+func getWindowStart(rule *Rule) (time.Time, error) {
+	duration, err := rule.Window.Duration()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Now().Add(-duration), nil
+}
+
+func getDenialReason(rule *Rule, defaultReason string) string {
+	if len(rule.Reason) > 0 {
+		return rule.Reason
+	}
+	return defaultReason
 }
@@ -9,6 +9,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
 
+	"github.com/code-payments/ocp-server/config/memory"
+	"github.com/code-payments/ocp-server/config/wrapper"
 	currency_lib "github.com/code-payments/ocp-server/currency"
 	"github.com/code-payments/ocp-server/ocp/common"
 	currency_util "github.com/code-payments/ocp-server/ocp/currency"
@@ -55,6 +57,8 @@ func TestGuard_SendPublicPayment_PerTransactionValue(t *testing.T) {
 func TestGuard_SendPublicPayment_DailyUsdLimit(t *testing.T) {
 	env := setupAmlTest(t)
 
+	maxDailyUsdLimit := 1.2 * currency_util.MaxDailyUsdLimit
+
 	for _, tc := range []struct {
 		consumedUsdValue float64
 		at               time.Time
@@ -136,6 +140,213 @@ func TestGuard_ReceivePaymentsPublicly(t *testing.T) {
 	}
 }
 
+func TestGuard_OwnerTiers(t *testing.T) {
+	env := setupAmlTest(t)
+
+	restricted := testutil.NewRandomAccount(t)
+	unrestricted := testutil.NewRandomAccount(t)
+
+	env.setPolicy(t, &Policy{
+		DefaultTier: "restricted",
+		OwnerTiers: map[string]string{
+			unrestricted.PublicKey().ToBase58(): "unrestricted",
+		},
+		Tiers: map[string]*Tier{
+			"restricted": {
+				Rules: []*Rule{
+					{Type: RuleTypeMaxPerTransaction, Actions: []Action{ActionSendPayment, ActionWithdraw}, MaxUsdValue: 10},
+				},
+			},
+			"unrestricted": {
+				Rules: []*Rule{},
+			},
+		},
+	})
+
+	for _, isWithdraw := range []bool{true, false} {
+		allow, err := env.guard.AllowMoneyMovement(env.ctx, makeSendPublicPaymentIntent(t, restricted, 10, isWithdraw, time.Now()))
+		require.NoError(t, err)
+		assert.True(t, allow)
+
+		allow, err = env.guard.AllowMoneyMovement(env.ctx, makeSendPublicPaymentIntent(t, restricted, 11, isWithdraw, time.Now()))
+		require.NoError(t, err)
+		assert.False(t, allow)
+
+		allow, err = env.guard.AllowMoneyMovement(env.ctx, makeSendPublicPaymentIntent(t, unrestricted, 1_000_000, isWithdraw, time.Now()))
+		require.NoError(t, err)
+		assert.True(t, allow)
+	}
+}
+
+func TestGuard_RollingWindows(t *testing.T) {
+	env := setupAmlTest(t)
+
+	env.setPolicy(t, &Policy{
+		DefaultTier: "default",
+		Tiers: map[string]*Tier{
+			"default": {
+				Rules: []*Rule{
+					{Type: RuleTypeMaxSentUsdValue, Actions: []Action{ActionSendPayment}, Window: Window1h, MaxUsdValue: 100},
+					{Type: RuleTypeMaxSentUsdValue, Actions: []Action{ActionSendPayment}, Window: Window7d, MaxUsdValue: 500},
+				},
+			},
+		},
+	})
+
+	owner := testutil.NewRandomAccount(t)
+	intentRecord := makeSendPublicPaymentIntent(t, owner, 50, false, time.Now())
+
+	// Within both windows
+	require.NoError(t, env.data.SaveIntent(env.ctx, makeSendPublicPaymentIntent(t, owner, 50, false, time.Now().Add(-30*time.Minute))))
+	allow, err := env.guard.AllowMoneyMovement(env.ctx, intentRecord)
+	require.NoError(t, err)
+	assert.True(t, allow)
+
+	// Breaches the 1h window
+	require.NoError(t, env.data.SaveIntent(env.ctx, makeSendPublicPaymentIntent(t, owner, 1, false, time.Now().Add(-30*time.Minute))))
+	allow, err = env.guard.AllowMoneyMovement(env.ctx, intentRecord)
+	require.NoError(t, err)
+	assert.False(t, allow)
+
+	// Breaches only the 7d window
+	owner = testutil.NewRandomAccount(t)
+	intentRecord = makeSendPublicPaymentIntent(t, owner, 50, false, time.Now())
+	require.NoError(t, env.data.SaveIntent(env.ctx, makeSendPublicPaymentIntent(t, owner, 451, false, time.Now().Add(-72*time.Hour))))
+	allow, err = env.guard.AllowMoneyMovement(env.ctx, intentRecord)
+	require.NoError(t, err)
+	assert.False(t, allow)
+}
+
+func TestGuard_Velocity(t *testing.T) {
+	env := setupAmlTest(t)
+
+	env.setPolicy(t, &Policy{
+		DefaultTier: "default",
+		Tiers: map[string]*Tier{
+			"default": {
+				Rules: []*Rule{
+					{Type: RuleTypeMaxSentCount, Actions: []Action{ActionSendPayment}, Window: Window1h, MaxCount: 3},
+				},
+			},
+		},
+	})
+
+	owner := testutil.NewRandomAccount(t)
+	intentRecord := makeSendPublicPaymentIntent(t, owner, 1, false, time.Now())
+
+	for i := 0; i < 3; i++ {
+		allow, err := env.guard.AllowMoneyMovement(env.ctx, intentRecord)
+		require.NoError(t, err)
+		assert.True(t, allow)
+
+		require.NoError(t, env.data.SaveIntent(env.ctx, makeSendPublicPaymentIntent(t, owner, 1, false, time.Now())))
+	}
+
+	allow, err := env.guard.AllowMoneyMovement(env.ctx, intentRecord)
+	require.NoError(t, err)
+	assert.False(t, allow)
+
+	// Withdrawals aren't subject to the rule
+	allow, err = env.guard.AllowMoneyMovement(env.ctx, makeSendPublicPaymentIntent(t, owner, 1, true, time.Now()))
+	require.NoError(t, err)
+	assert.True(t, allow)
+}
+
+func TestGuard_ReceiveLimits(t *testing.T) {
+	env := setupAmlTest(t)
+
+	env.setPolicy(t, &Policy{
+		DefaultTier: "default",
+		Tiers: map[string]*Tier{
+			"default": {
+				Rules: []*Rule{
+					{Type: RuleTypeMaxReceivedUsdValue, Actions: []Action{ActionSendPayment, ActionReceivePayment}, Window: Window24h, MaxUsdValue: 100},
+				},
+			},
+		},
+	})
+
+	sender := testutil.NewRandomAccount(t)
+	receiver := testutil.NewRandomAccount(t)
+
+	// Sends are evaluated against the destination owner's received value
+	paymentRecord := makeSendPublicPaymentIntent(t, sender, 60, false, time.Now())
+	paymentRecord.SendPublicPaymentMetadata.DestinationOwnerAccount = receiver.PublicKey().ToBase58()
+	allow, err := env.guard.AllowMoneyMovement(env.ctx, paymentRecord)
+	require.NoError(t, err)
+	assert.True(t, allow)
+	require.NoError(t, env.data.SaveIntent(env.ctx, paymentRecord))
+
+	paymentRecord = makeSendPublicPaymentIntent(t, sender, 60, false, time.Now())
+	paymentRecord.SendPublicPaymentMetadata.DestinationOwnerAccount = receiver.PublicKey().ToBase58()
+	allow, err = env.guard.AllowMoneyMovement(env.ctx, paymentRecord)
+	require.NoError(t, err)
+	assert.False(t, allow)
+
+	// Public receives are evaluated against the initiator's received value
+	allow, err = env.guard.AllowMoneyMovement(env.ctx, makeReceivePaymentsPubliclyIntent(t, receiver, 40, time.Now()))
+	require.NoError(t, err)
+	assert.True(t, allow)
+
+	allow, err = env.guard.AllowMoneyMovement(env.ctx, makeReceivePaymentsPubliclyIntent(t, receiver, 41, time.Now()))
+	require.NoError(t, err)
+	assert.False(t, allow)
+
+	// The sender hasn't received anything
+	allow, err = env.guard.AllowMoneyMovement(env.ctx, makeReceivePaymentsPubliclyIntent(t, sender, 100, time.Now()))
+	require.NoError(t, err)
+	assert.True(t, allow)
+}
+
+func TestGuard_ConfigPolicyProvider(t *testing.T) {
+	env := setupAmlTest(t)
+
+	rawPolicy := memory.NewConfig([]byte(`{
+		"default_tier": "default",
+		"tiers": {
+			"default": {
+				"rules": [
+					{"type": "max_per_transaction", "actions": ["SendPayment"], "max_usd_value": 5}
+				]
+			}
+		}
+	}`))
+	env.guard = NewGuard(zaptest.NewLogger(t), env.data, NewConfigPolicyProvider(wrapper.NewBytesConfig(rawPolicy, nil)))
+
+	owner := testutil.NewRandomAccount(t)
+
+	allow, err := env.guard.AllowMoneyMovement(env.ctx, makeSendPublicPaymentIntent(t, owner, 6, false, time.Now()))
+	require.NoError(t, err)
+	assert.False(t, allow)
+
+	// Thresholds are picked up without recreating the guard
+	rawPolicy.SetValue([]byte(`{
+		"default_tier": "default",
+		"tiers": {
+			"default": {
+				"rules": [
+					{"type": "max_per_transaction", "actions": ["SendPayment"], "max_usd_value": 10}
+				]
+			}
+		}
+	}`))
+
+	allow, err = env.guard.AllowMoneyMovement(env.ctx, makeSendPublicPaymentIntent(t, owner, 6, false, time.Now()))
+	require.NoError(t, err)
+	assert.True(t, allow)
+
+	// Invalid policies fall back to the last valid one
+	rawPolicy.SetValue([]byte(`{"default_tier": "missing"}`))
+
+	allow, err = env.guard.AllowMoneyMovement(env.ctx, makeSendPublicPaymentIntent(t, owner, 6, false, time.Now()))
+	require.NoError(t, err)
+	assert.True(t, allow)
+
+	allow, err = env.guard.AllowMoneyMovement(env.ctx, makeSendPublicPaymentIntent(t, owner, 11, false, time.Now()))
+	require.NoError(t, err)
+	assert.False(t, allow)
+}
+
 type amlTestEnv struct {
 	ctx   context.Context
 	data  ocp_data.Provider
@@ -147,7 +358,9 @@ func setupAmlTest(t *testing.T) (env amlTestEnv) {
 
 	env.ctx = context.Background()
 	env.data = ocp_data.NewTestDataProvider()
-	env.guard = NewGuard(log, env.data)
+	policies, err := NewStaticPolicyProvider(DefaultPolicy())
+	require.NoError(t, err)
+	env.guard = NewGuard(log, env.data, policies)
 
 	testutil.SetupRandomSubsidizer(t, env.data)
 
@@ -161,6 +374,12 @@ func setupAmlTest(t *testing.T) (env amlTestEnv) {
 	return env
 }
 
+func (e *amlTestEnv) setPolicy(t *testing.T, policy *Policy) {
+	policies, err := NewStaticPolicyProvider(policy)
+	require.NoError(t, err)
+	e.guard = NewGuard(zaptest.NewLogger(t), e.data, policies)
+}
+
 func makeSendPublicPaymentIntent(t *testing.T, owner *common.Account, usdMarketValue float64, isWithdraw bool, at time.Time) *intent.Record {
 	return &intent.Record{
 		IntentId:   testutil.NewRandomAccount(t).PublicKey().ToBase58(),
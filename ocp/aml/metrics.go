@@ -10,13 +10,11 @@ const (
 	metricsStructName = "aml.guard"
 
 	eventName = "AntiMoneyLaunderingGuardDenial"
-
-	actionSendPayment = "SendPayment"
 )
 
-func recordDenialEvent(ctx context.Context, action, reason string) {
+func recordDenialEvent(ctx context.Context, action Action, reason string) {
 	kvPairs := map[string]interface{}{
-		"action": action,
+		"action": string(action),
 		"reason": reason,
 		"count":  1,
 	}
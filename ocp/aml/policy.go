@@ -0,0 +1,326 @@
+package aml
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/code-payments/ocp-server/config"
+	"github.com/code-payments/ocp-server/config/env"
+	currency_lib "github.com/code-payments/ocp-server/currency"
+	currency_util "github.com/code-payments/ocp-server/ocp/currency"
+)
+
+const (
+	PolicyConfigEnvName = "AML_POLICY"
+
+	defaultTierName = "default"
+)
+
+// Action is a type of money movement that AML rules can apply to
+type Action string
+
+const (
+	ActionSendPayment    Action = "SendPayment"
+	ActionWithdraw       Action = "Withdraw"
+	ActionReceivePayment Action = "ReceivePayment"
+	ActionDistribute     Action = "Distribute"
+)
+
+// RuleType determines how a rule is evaluated
+type RuleType string
+
+const (
+	// RuleTypeMaxPerTransaction bounds the value of a single money movement. When
+	// native amounts are provided, any currency without one is unsupported.
+	RuleTypeMaxPerTransaction RuleType = "max_per_transaction"
+
+	// RuleTypeMaxSentUsdValue bounds the USD value of payments sent by the
+	// initiating owner over a rolling window
+	RuleTypeMaxSentUsdValue RuleType = "max_sent_usd_value"
+
+	// RuleTypeMaxSentCount bounds the number of payments sent by the initiating
+	// owner over a rolling window
+	RuleTypeMaxSentCount RuleType = "max_sent_count"
+
+	// RuleTypeMaxReceivedUsdValue bounds the USD value received by each owner
+	// getting funds over a rolling window. It's evaluated against the rules in
+	// the recipient's tier.
+	RuleTypeMaxReceivedUsdValue RuleType = "max_received_usd_value"
+)
+
+// Window is a rolling time window that a rule is evaluated over
+type Window string
+
+const (
+	Window1h  Window = "1h"
+	Window24h Window = "24h"
+	Window7d  Window = "7d"
+	Window30d Window = "30d"
+)
+
+// Policy is a declarative set of AML rules, grouped into tiers that owners are
+// assigned to.
+type Policy struct {
+	// DefaultTier is the tier for any owner that isn't explicitly assigned one
+	DefaultTier string `json:"default_tier"`
+
+	// OwnerTiers assigns owners to tiers by their public key
+	OwnerTiers map[string]string `json:"owner_tiers,omitempty"`
+
+	// Tiers are the rules that apply to owners, keyed by tier name
+	Tiers map[string]*Tier `json:"tiers"`
+}
+
+// Tier is a named set of rules that apply to a group of owners
+type Tier struct {
+	Rules []*Rule `json:"rules"`
+}
+
+// Rule is a single AML check
+type Rule struct {
+	Type RuleType `json:"type"`
+
+	// Actions are the money movements this rule applies to
+	Actions []Action `json:"actions"`
+
+	// Window is required for all rolling rule types
+	Window Window `json:"window,omitempty"`
+
+	MaxUsdValue      float64                       `json:"max_usd_value,omitempty"`
+	MaxNativeAmounts map[currency_lib.Code]float64 `json:"max_native_amounts,omitempty"`
+	MaxCount         uint64                        `json:"max_count,omitempty"`
+
+	// Reason optionally overrides the denial reason that's recorded
+	Reason string `json:"reason,omitempty"`
+}
+
+// DefaultPolicy returns the policy that's applied when none is configured
+func DefaultPolicy() *Policy {
+	maxNativeAmounts := make(map[currency_lib.Code]float64)
+	for currency, sendLimit := range currency_util.SendLimits {
+		maxNativeAmounts[currency] = sendLimit.PerTransaction
+	}
+
+	return &Policy{
+		DefaultTier: defaultTierName,
+		Tiers: map[string]*Tier{
+			defaultTierName: {
+				Rules: []*Rule{
+					{
+						Type:             RuleTypeMaxPerTransaction,
+						Actions:          []Action{ActionSendPayment},
+						MaxNativeAmounts: maxNativeAmounts,
+					},
+					{
+						// Intentionally higher than that enforced on clients, so
+						// we can do better rounding on limits per currency.
+						Type:        RuleTypeMaxSentUsdValue,
+						Actions:     []Action{ActionSendPayment},
+						Window:      Window24h,
+						MaxUsdValue: 1.2 * currency_util.MaxDailyUsdLimit,
+						Reason:      "exceeds daily usd value",
+					},
+				},
+			},
+		},
+	}
+}
+
+// ParsePolicy parses and validates a JSON encoded policy
+func ParsePolicy(data []byte) (*Policy, error) {
+	var policy Policy
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&policy); err != nil {
+		return nil, errors.Wrap(err, "invalid policy json")
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (p *Policy) Validate() error {
+	if _, ok := p.Tiers[p.DefaultTier]; !ok {
+		return errors.Errorf("default tier %s is not defined", p.DefaultTier)
+	}
+
+	for owner, tierName := range p.OwnerTiers {
+		if _, ok := p.Tiers[tierName]; !ok {
+			return errors.Errorf("tier %s for owner %s is not defined", tierName, owner)
+		}
+	}
+
+	for tierName, tier := range p.Tiers {
+		if tier == nil {
+			return errors.Errorf("tier %s is nil", tierName)
+		}
+
+		for i, rule := range tier.Rules {
+			if err := rule.Validate(); err != nil {
+				return errors.Wrapf(err, "invalid rule %d in tier %s", i, tierName)
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetTier gets the tier an owner is assigned to
+func (p *Policy) GetTier(owner string) *Tier {
+	tierName, ok := p.OwnerTiers[owner]
+	if !ok {
+		tierName = p.DefaultTier
+	}
+	return p.Tiers[tierName]
+}
+
+func (r *Rule) Validate() error {
+	if r == nil {
+		return errors.New("rule is nil")
+	}
+
+	if len(r.Actions) == 0 {
+		return errors.New("at least one action is required")
+	}
+	for _, action := range r.Actions {
+		switch action {
+		case ActionSendPayment, ActionWithdraw, ActionReceivePayment, ActionDistribute:
+		default:
+			return errors.Errorf("unsupported action %s", action)
+		}
+	}
+
+	switch r.Type {
+	case RuleTypeMaxPerTransaction:
+		if r.MaxUsdValue <= 0 && len(r.MaxNativeAmounts) == 0 {
+			return errors.New("max usd value or max native amounts are required")
+		}
+		if len(r.Window) > 0 {
+			return errors.New("window is not supported")
+		}
+	case RuleTypeMaxSentUsdValue, RuleTypeMaxReceivedUsdValue:
+		if r.MaxUsdValue <= 0 {
+			return errors.New("max usd value is required")
+		}
+	case RuleTypeMaxSentCount:
+		if r.MaxCount == 0 {
+			return errors.New("max count is required")
+		}
+	default:
+		return errors.Errorf("unsupported rule type %s", r.Type)
+	}
+
+	if r.Type != RuleTypeMaxPerTransaction {
+		if _, err := r.Window.Duration(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AppliesTo returns whether the rule applies to the money movement action
+func (r *Rule) AppliesTo(action Action) bool {
+	for _, applicable := range r.Actions {
+		if applicable == action {
+			return true
+		}
+	}
+	return false
+}
+
+func (w Window) Duration() (time.Duration, error) {
+	switch w {
+	case Window1h:
+		return time.Hour, nil
+	case Window24h:
+		return 24 * time.Hour, nil
+	case Window7d:
+		return 7 * 24 * time.Hour, nil
+	case Window30d:
+		return 30 * 24 * time.Hour, nil
+	}
+	return 0, errors.Errorf("unsupported window %s", w)
+}
+
+// PolicyProvider provides the latest AML policy
+type PolicyProvider interface {
+	GetPolicy(ctx context.Context) (*Policy, error)
+}
+
+type staticPolicyProvider struct {
+	policy *Policy
+}
+
+// NewStaticPolicyProvider returns a PolicyProvider that always provides the
+// same policy
+func NewStaticPolicyProvider(policy *Policy) (PolicyProvider, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	return &staticPolicyProvider{policy: policy}, nil
+}
+
+// GetPolicy implements PolicyProvider.GetPolicy
+func (p *staticPolicyProvider) GetPolicy(_ context.Context) (*Policy, error) {
+	return p.policy, nil
+}
+
+type configPolicyProvider struct {
+	config config.Bytes
+
+	mu        sync.Mutex
+	lastRaw   []byte
+	lastValid *Policy
+}
+
+// NewConfigPolicyProvider returns a PolicyProvider that parses a JSON encoded
+// policy from config on every call, so thresholds can be changed without a
+// redeploy. The default policy is used when the config has no value. If an
+// updated config is invalid, the last valid policy continues to be used.
+func NewConfigPolicyProvider(config config.Bytes) PolicyProvider {
+	return &configPolicyProvider{
+		config:    config,
+		lastValid: DefaultPolicy(),
+	}
+}
+
+// NewEnvPolicyProvider returns a PolicyProvider backed by the AML_POLICY
+// environment variable
+func NewEnvPolicyProvider() PolicyProvider {
+	return NewConfigPolicyProvider(env.NewBytesConfig(PolicyConfigEnvName, nil))
+}
+
+// GetPolicy implements PolicyProvider.GetPolicy
+func (p *configPolicyProvider) GetPolicy(ctx context.Context) (*Policy, error) {
+	raw := p.config.Get(ctx)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if bytes.Equal(raw, p.lastRaw) {
+		return p.lastValid, nil
+	}
+
+	var policy *Policy
+	if len(raw) == 0 {
+		policy = DefaultPolicy()
+	} else {
+		var err error
+		policy, err = ParsePolicy(raw)
+		if err != nil {
+			return p.lastValid, err
+		}
+	}
+
+	p.lastRaw = raw
+	p.lastValid = policy
+	return policy, nil
+}
@@ -100,3 +100,17 @@ func (g *Guard) AllowSwap(ctx context.Context, owner, fromMint, toMint *common.A
 	}
 	return allow, nil
 }
+
+func (g *Guard) AllowLaunchCurrency(ctx context.Context, owner *common.Account) (bool, error) {
+	tracer := metrics.TraceMethodCall(ctx, metricsStructName, "AllowLaunchCurrency")
+	defer tracer.End()
+
+	allow, reason, err := g.integration.AllowLaunchCurrency(ctx, owner)
+	if err != nil {
+		return false, err
+	}
+	if !allow {
+		recordDenialEvent(ctx, actionLaunchCurrency, reason)
+	}
+	return allow, nil
+}
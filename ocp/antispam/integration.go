@@ -22,6 +22,8 @@ type Integration interface {
 	AllowDistribution(ctx context.Context, owner *common.Account, isPublic bool) (bool, string, error)
 
 	AllowSwap(ctx context.Context, owner, fromMint, toMint *common.Account) (bool, string, error)
+
+	AllowLaunchCurrency(ctx context.Context, owner *common.Account) (bool, string, error)
 }
 
 type allowEverythingIntegration struct {
@@ -55,3 +57,7 @@ func (i *allowEverythingIntegration) AllowDistribution(ctx context.Context, owne
 func (i *allowEverythingIntegration) AllowSwap(ctx context.Context, owner, fromMint, toMint *common.Account) (bool, string, error) {
 	return true, "", nil
 }
+
+func (i *allowEverythingIntegration) AllowLaunchCurrency(ctx context.Context, owner *common.Account) (bool, string, error) {
+	return true, "", nil
+}
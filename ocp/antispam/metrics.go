@@ -19,6 +19,8 @@ const (
 	actionSwap = "Swap"
 
 	actionWelcomeBonus = "WelcomeBonus"
+
+	actionLaunchCurrency = "LaunchCurrency"
 )
 
 func recordDenialEvent(ctx context.Context, action, reason string) {
@@ -16,6 +16,7 @@ import (
 	"github.com/code-payments/ocp-server/ocp/data/currency"
 	"github.com/code-payments/ocp-server/ocp/data/timelock"
 	"github.com/code-payments/ocp-server/solana"
+	"github.com/code-payments/ocp-server/solana/currencycreator"
 	timelock_token_v1 "github.com/code-payments/ocp-server/solana/timelock/v1"
 	"github.com/code-payments/ocp-server/solana/token"
 	"github.com/code-payments/ocp-server/solana/vm"
@@ -243,7 +244,7 @@ func (a *Account) GetTimelockAccounts(vmConfig *VmConfig) (*TimelockAccounts, er
 		Mint:         vmConfig.Mint.PublicKey().ToBytes(),
 		VmAuthority:  vmConfig.Authority.PublicKey().ToBytes(),
 		Owner:        a.PublicKey().ToBytes(),
-		LockDuration: timelock_token_v1.DefaultNumDaysLocked,
+		LockDuration: vmConfig.LockDurationInDays,
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "error getting timelock state address")
@@ -454,7 +455,10 @@ func IsManagedByCode(ctx context.Context, timelockRecord *timelock.Record) bool
 	// todo: We don't support unlocking timelock accounts and leaving the open,
 	//       but we may need to scan the intents system for a RevokeWithAuthority
 	//       instruction as another negative case for this function.
-	return timelockRecord.IsLocked()
+	//
+	// The owner may have requested to unlock the account before it's observed
+	// on the blockchain, in which case we stop managing it right away.
+	return timelockRecord.IsLocked() && !timelockRecord.IsUnlockRequested()
 }
 
 // ToDBRecord transforms the TimelockAccounts struct to a default timelock.Record
@@ -535,6 +539,7 @@ func ValidateExternalTokenAccount(ctx context.Context, data ocp_data.Provider, t
 
 type LaunchpadCurrencyAccounts struct {
 	Mint               *Account
+	MintBump           uint8
 	CurrencyConfig     *Account
 	CurrencyConfigBump uint8
 	LiquidityPool      *Account
@@ -568,6 +573,7 @@ func GetLaunchpadCurrencyAccounts(metadataRecord *currency.MetadataRecord) (*Lau
 	}
 	return &LaunchpadCurrencyAccounts{
 		Mint:               mint,
+		MintBump:           metadataRecord.MintBump,
 		CurrencyConfig:     currencyConfig,
 		CurrencyConfigBump: metadataRecord.CurrencyConfigBump,
 		LiquidityPool:      liquidityPool,
@@ -579,6 +585,82 @@ func GetLaunchpadCurrencyAccounts(metadataRecord *currency.MetadataRecord) (*Lau
 	}, nil
 }
 
+// DeriveLaunchpadCurrencyAccounts derives the launchpad currency accounts for
+// a currency, which doesn't need to exist on the blockchain yet.
+func DeriveLaunchpadCurrencyAccounts(authority *Account, name string, seed *Account) (*LaunchpadCurrencyAccounts, error) {
+	mintAddress, mintBump, err := currencycreator.GetMintAddress(&currencycreator.GetMintAddressArgs{
+		Authority: authority.PublicKey().ToBytes(),
+		Name:      name,
+		Seed:      seed.PublicKey().ToBytes(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	currencyConfigAddress, currencyConfigBump, err := currencycreator.GetCurrencyAddress(&currencycreator.GetCurrencyAddressArgs{
+		Mint: mintAddress,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	liquidityPoolAddress, liquidityPoolBump, err := currencycreator.GetPoolAddress(&currencycreator.GetPoolAddressArgs{
+		Currency: currencyConfigAddress,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	vaultBaseAddress, vaultBaseBump, err := currencycreator.GetVaultAddress(&currencycreator.GetVaultAddressArgs{
+		Pool: liquidityPoolAddress,
+		Mint: CoreMintAccount.PublicKey().ToBytes(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	vaultMintAddress, vaultMintBump, err := currencycreator.GetVaultAddress(&currencycreator.GetVaultAddressArgs{
+		Pool: liquidityPoolAddress,
+		Mint: mintAddress,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mint, err := NewAccountFromPublicKeyBytes(mintAddress)
+	if err != nil {
+		return nil, err
+	}
+	currencyConfig, err := NewAccountFromPublicKeyBytes(currencyConfigAddress)
+	if err != nil {
+		return nil, err
+	}
+	liquidityPool, err := NewAccountFromPublicKeyBytes(liquidityPoolAddress)
+	if err != nil {
+		return nil, err
+	}
+	vaultBase, err := NewAccountFromPublicKeyBytes(vaultBaseAddress)
+	if err != nil {
+		return nil, err
+	}
+	vaultMint, err := NewAccountFromPublicKeyBytes(vaultMintAddress)
+	if err != nil {
+		return nil, err
+	}
+	return &LaunchpadCurrencyAccounts{
+		Mint:               mint,
+		MintBump:           mintBump,
+		CurrencyConfig:     currencyConfig,
+		CurrencyConfigBump: currencyConfigBump,
+		LiquidityPool:      liquidityPool,
+		LiquidityPoolBump:  liquidityPoolBump,
+		VaultBase:          vaultBase,
+		VaultBaseBump:      vaultBaseBump,
+		VaultMint:          vaultMint,
+		VaultMintBump:      vaultMintBump,
+	}, nil
+}
+
 func isOnCurve(pubKey ed25519.PublicKey) bool {
 	if len(pubKey) != ed25519.PublicKeySize {
 		return false
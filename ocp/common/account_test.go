@@ -12,6 +12,7 @@ import (
 	commonpb "github.com/code-payments/ocp-protobuf-api/generated/go/common/v1"
 
 	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/solana/currencycreator"
 	timelock_token_v1 "github.com/code-payments/ocp-server/solana/timelock/v1"
 	"github.com/code-payments/ocp-server/solana/token"
 	"github.com/code-payments/ocp-server/solana/vm"
@@ -211,6 +212,54 @@ func TestGetVmSwapAccounts(t *testing.T) {
 	assert.EqualValues(t, vmConfig.Mint.PublicKey().ToBytes(), actual.Mint.PublicKey().ToBytes())
 }
 
+func TestDeriveLaunchpadCurrencyAccounts(t *testing.T) {
+	authorityAccount := newRandomTestAccount(t)
+	seedAccount := newRandomTestAccount(t)
+	name := "Jeffy"
+
+	expectedMintAddress, expectedMintBump, err := currencycreator.GetMintAddress(&currencycreator.GetMintAddressArgs{
+		Authority: authorityAccount.PublicKey().ToBytes(),
+		Name:      name,
+		Seed:      seedAccount.PublicKey().ToBytes(),
+	})
+	require.NoError(t, err)
+
+	expectedCurrencyConfigAddress, expectedCurrencyConfigBump, err := currencycreator.GetCurrencyAddress(&currencycreator.GetCurrencyAddressArgs{
+		Mint: expectedMintAddress,
+	})
+	require.NoError(t, err)
+
+	expectedLiquidityPoolAddress, expectedLiquidityPoolBump, err := currencycreator.GetPoolAddress(&currencycreator.GetPoolAddressArgs{
+		Currency: expectedCurrencyConfigAddress,
+	})
+	require.NoError(t, err)
+
+	expectedVaultBaseAddress, expectedVaultBaseBump, err := currencycreator.GetVaultAddress(&currencycreator.GetVaultAddressArgs{
+		Pool: expectedLiquidityPoolAddress,
+		Mint: CoreMintAccount.PublicKey().ToBytes(),
+	})
+	require.NoError(t, err)
+
+	expectedVaultMintAddress, expectedVaultMintBump, err := currencycreator.GetVaultAddress(&currencycreator.GetVaultAddressArgs{
+		Pool: expectedLiquidityPoolAddress,
+		Mint: expectedMintAddress,
+	})
+	require.NoError(t, err)
+
+	actual, err := DeriveLaunchpadCurrencyAccounts(authorityAccount, name, seedAccount)
+	require.NoError(t, err)
+	assert.EqualValues(t, expectedMintAddress, actual.Mint.PublicKey().ToBytes())
+	assert.Equal(t, expectedMintBump, actual.MintBump)
+	assert.EqualValues(t, expectedCurrencyConfigAddress, actual.CurrencyConfig.PublicKey().ToBytes())
+	assert.Equal(t, expectedCurrencyConfigBump, actual.CurrencyConfigBump)
+	assert.EqualValues(t, expectedLiquidityPoolAddress, actual.LiquidityPool.PublicKey().ToBytes())
+	assert.Equal(t, expectedLiquidityPoolBump, actual.LiquidityPoolBump)
+	assert.EqualValues(t, expectedVaultBaseAddress, actual.VaultBase.PublicKey().ToBytes())
+	assert.Equal(t, expectedVaultBaseBump, actual.VaultBaseBump)
+	assert.EqualValues(t, expectedVaultMintAddress, actual.VaultMint.PublicKey().ToBytes())
+	assert.Equal(t, expectedVaultMintBump, actual.VaultMintBump)
+}
+
 func TestIsOnCurve(t *testing.T) {
 	for _, tc := range []struct {
 		expected  bool
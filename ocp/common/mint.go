@@ -21,8 +21,6 @@ var (
 	CoreMintSymbol        = config.CoreMintSymbol
 
 	ErrUnsupportedMint = errors.New("unsupported mint")
-
-	jeffyMintAccount, _ = NewAccountFromPublicKeyString(config.JeffyMintPublicKey)
 )
 
 func GetBackwardsCompatMint(protoMint *commonpb.SolanaAccountId) (*Account, error) {
@@ -62,7 +60,7 @@ func GetMintQuarksPerUnit(mint *Account) uint64 {
 }
 
 func IsSupportedMint(ctx context.Context, data ocp_data.Provider, mintAccount *Account) (bool, error) {
-	_, err := GetVmConfigForMint(ctx, data, mintAccount)
+	_, err := GetPublicVmConfigForMint(ctx, data, mintAccount)
 	if err == ErrUnsupportedMint {
 		return false, nil
 	} else if err != nil {
@@ -7,35 +7,15 @@ import (
 
 	"github.com/code-payments/ocp-server/ocp/config"
 	ocp_data "github.com/code-payments/ocp-server/ocp/data"
-	"github.com/code-payments/ocp-server/ocp/data/fulfillment"
-	"github.com/code-payments/ocp-server/ocp/data/nonce"
 	"github.com/code-payments/ocp-server/solana"
 )
 
 // todo: always assumes mainnet
 
-const (
+var (
 	// Important Note: Be very careful changing this value, as it will completely
 	// change timelock PDAs and have consequences with existing splitter treasuries.
 	realSubsidizerPublicKey = config.SubsidizerPublicKey
-
-	// Ensure this is a large enough buffer. The enforcement of a min balance isn't
-	// perfect to say the least.
-	//
-	// todo: configurable
-	minSubsidizerBalance = 10_000_000_000 // 10 SOL
-)
-
-// todo: doesn't consider external deposits or swaps
-// todo: need a better system given fees are dynamic, we'll consider the worst case for each fulfillment type to be safe
-var (
-	lamportsByFulfillment = map[fulfillment.Type]uint64{
-		fulfillment.InitializeLockedTimelockAccount: 5050,
-		fulfillment.NoPrivacyTransferWithAuthority:  203928 + 5125,
-		fulfillment.NoPrivacyWithdraw:               5100,
-		fulfillment.CloseEmptyTimelockAccount:       5100,
-	}
-	lamportsPerCreateNonceAccount uint64 = 1450000
 )
 
 var (
@@ -118,53 +98,3 @@ func GetCurrentSubsidizerBalance(ctx context.Context, data ocp_data.Provider) (u
 	}
 	return accountInfo.Lamports, nil
 }
-
-// EstimateUsedSubsidizerBalance estimates the number of lamports that will be used
-// by in flight fulfillments.
-//
-// todo: This assumes fees are constant, which might not be the case in the future.
-func EstimateUsedSubsidizerBalance(ctx context.Context, data ocp_data.Provider) (uint64, error) {
-	var fees uint64
-
-	pendingFulfillmentsByType, err := data.GetPendingFulfillmentCountByType(ctx)
-	if err != nil {
-		return 0, err
-	}
-
-	for fulfillmentType, lamportsConsumed := range lamportsByFulfillment {
-		count, ok := pendingFulfillmentsByType[fulfillmentType]
-		if !ok {
-			continue
-		}
-
-		fees += uint64(count) * lamportsConsumed
-	}
-
-	numNoncesBeingCreated, err := data.GetNonceCountByState(ctx, nonce.EnvironmentSolana, nonce.EnvironmentInstanceSolanaMainnet, nonce.StateUnknown)
-	if err != nil {
-		return 0, err
-	}
-	fees += lamportsPerCreateNonceAccount * numNoncesBeingCreated
-
-	return fees, nil
-}
-
-// EnforceMinimumSubsidizerBalance returns ErrSubsidizerRequiresFunding if the
-// subsidizer's estimated available balance breaks a given threshold.
-func EnforceMinimumSubsidizerBalance(ctx context.Context, data ocp_data.Provider) error {
-	balance, err := GetCurrentSubsidizerBalance(ctx, data)
-	if err != nil {
-		return err
-	}
-
-	fees, err := EstimateUsedSubsidizerBalance(ctx, data)
-	if err != nil {
-		return err
-	}
-
-	if fees < balance && balance-fees > minSubsidizerBalance {
-		return nil
-	}
-
-	return ErrSubsidizerRequiresFunding
-}
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	timelock_token_v1 "github.com/code-payments/ocp-server/solana/timelock/v1"
 )
 
 // Required because we'd have a dependency loop with the testutil package
@@ -17,16 +19,18 @@ func newRandomTestAccount(t *testing.T) *Account {
 func newRandomVmConfig(t *testing.T, isCore bool) *VmConfig {
 	if isCore {
 		return &VmConfig{
-			Authority: newRandomTestAccount(t),
-			Vm:        newRandomTestAccount(t),
-			Omnibus:   newRandomTestAccount(t),
-			Mint:      CoreMintAccount,
+			Authority:          newRandomTestAccount(t),
+			Vm:                 newRandomTestAccount(t),
+			Omnibus:            newRandomTestAccount(t),
+			Mint:               CoreMintAccount,
+			LockDurationInDays: timelock_token_v1.DefaultNumDaysLocked,
 		}
 	}
 	return &VmConfig{
-		Authority: newRandomTestAccount(t),
-		Vm:        newRandomTestAccount(t),
-		Omnibus:   newRandomTestAccount(t),
-		Mint:      newRandomTestAccount(t),
+		Authority:          newRandomTestAccount(t),
+		Vm:                 newRandomTestAccount(t),
+		Omnibus:            newRandomTestAccount(t),
+		Mint:               newRandomTestAccount(t),
+		LockDurationInDays: timelock_token_v1.DefaultNumDaysLocked,
 	}
 }
@@ -3,58 +3,120 @@ package common
 import (
 	"context"
 
+	"github.com/code-payments/ocp-server/cache"
 	"github.com/code-payments/ocp-server/ocp/config"
 	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/vm/registry"
+	timelock_token_v1 "github.com/code-payments/ocp-server/solana/timelock/v1"
 )
 
 var (
 	CoreMintVmAccount, _        = NewAccountFromPublicKeyString(config.CoreMintVmAccountPublicKey)
 	CoreMintVmOmnibusAccount, _ = NewAccountFromPublicKeyString(config.CoreMintVmOmnibusPublicKey)
 
-	// todo: DB store to track VM per mint
-	jeffyAuthority, _        = NewAccountFromPublicKeyString(config.JeffyAuthorityPublicKey)
-	jeffyVmAccount, _        = NewAccountFromPublicKeyString(config.JeffyVmAccountPublicKey)
-	jeffyVmOmnibusAccount, _ = NewAccountFromPublicKeyString(config.JeffyVmOmnibusPublicKey)
+	// VM registry records are immutable, so public configs can be cached
+	// indefinitely. Authority private keys are never cached, so they're always
+	// loaded from the vault with the latest encryption.
+	vmConfigCache = cache.NewCache(10_000)
 )
 
 type VmConfig struct {
-	Authority *Account
-	Vm        *Account
-	Omnibus   *Account
-	Mint      *Account
+	Authority          *Account
+	Vm                 *Account
+	Omnibus            *Account
+	Mint               *Account
+	LockDurationInDays uint8
 }
 
+// GetVmConfigForMint gets the VM configuration for a mint, including the
+// authority's private key for signing. The core mint VM is statically
+// configured, and all other VMs are loaded from the VM registry.
 func GetVmConfigForMint(ctx context.Context, data ocp_data.Provider, mint *Account) (*VmConfig, error) {
-	switch mint.PublicKey().ToBase58() {
-	case CoreMintAccount.PublicKey().ToBase58():
+	publicVmConfig, err := GetPublicVmConfigForMint(ctx, data, mint)
+	if err != nil {
+		return nil, err
+	}
+
+	if IsCoreMint(mint) {
+		return publicVmConfig, nil
+	}
+
+	vaultRecord, err := data.GetKey(ctx, publicVmConfig.Authority.PublicKey().ToBase58())
+	if err != nil {
+		return nil, err
+	}
+
+	authority, err := NewAccountFromPrivateKeyString(vaultRecord.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VmConfig{
+		Authority:          authority,
+		Vm:                 publicVmConfig.Vm,
+		Omnibus:            publicVmConfig.Omnibus,
+		Mint:               publicVmConfig.Mint,
+		LockDurationInDays: publicVmConfig.LockDurationInDays,
+	}, nil
+}
+
+// GetPublicVmConfigForMint gets the VM configuration for a mint without loading
+// the authority's private key from the vault. It should be used whenever the
+// config isn't used to sign as the VM authority.
+func GetPublicVmConfigForMint(ctx context.Context, data ocp_data.Provider, mint *Account) (*VmConfig, error) {
+	if IsCoreMint(mint) {
 		return &VmConfig{
-			Authority: GetSubsidizer(),
-			Vm:        CoreMintVmAccount,
-			Omnibus:   CoreMintVmOmnibusAccount,
-			Mint:      CoreMintAccount,
+			Authority:          GetSubsidizer(),
+			Vm:                 CoreMintVmAccount,
+			Omnibus:            CoreMintVmOmnibusAccount,
+			Mint:               CoreMintAccount,
+			LockDurationInDays: timelock_token_v1.DefaultNumDaysLocked,
 		}, nil
-		/*
-			case jeffyMintAccount.PublicKey().ToBase58():
-				if jeffyAuthority.PrivateKey() == nil {
-					vaultRecord, err := data.GetKey(ctx, jeffyAuthority.PublicKey().ToBase58())
-					if err != nil {
-						return nil, err
-					}
-
-					jeffyAuthority, err = NewAccountFromPrivateKeyString(vaultRecord.PrivateKey)
-					if err != nil {
-						return nil, err
-					}
-				}
-
-				return &VmConfig{
-					Authority: jeffyAuthority,
-					Vm:        jeffyVmAccount,
-					Omnibus:   jeffyVmOmnibusAccount,
-					Mint:      mint,
-				}, nil
-		*/
-	default:
+	}
+
+	cached, ok := vmConfigCache.Retrieve(mint.PublicKey().ToBase58())
+	if ok {
+		return cached.(*VmConfig), nil
+	}
+
+	publicVmConfig, err := getPublicVmConfigFromRegistry(ctx, data, mint)
+	if err != nil {
+		return nil, err
+	}
+	vmConfigCache.Insert(mint.PublicKey().ToBase58(), publicVmConfig, 1)
+	return publicVmConfig, nil
+}
+
+// getPublicVmConfigFromRegistry loads a VM configuration from the VM registry
+// without the authority's private key
+func getPublicVmConfigFromRegistry(ctx context.Context, data ocp_data.Provider, mint *Account) (*VmConfig, error) {
+	registryRecord, err := data.GetRegisteredVmByMint(ctx, mint.PublicKey().ToBase58())
+	if err == registry.ErrNotFound {
 		return nil, ErrUnsupportedMint
+	} else if err != nil {
+		return nil, err
+	}
+
+	authority, err := NewAccountFromPublicKeyString(registryRecord.Authority)
+	if err != nil {
+		return nil, err
+	}
+
+	vm, err := NewAccountFromPublicKeyString(registryRecord.Vm)
+	if err != nil {
+		return nil, err
 	}
+
+	omnibus, err := NewAccountFromPublicKeyString(registryRecord.Omnibus)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VmConfig{
+		Authority:          authority,
+		Vm:                 vm,
+		Omnibus:            omnibus,
+		Mint:               mint,
+		LockDurationInDays: registryRecord.LockDurationInDays,
+	}, nil
 }
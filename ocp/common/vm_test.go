@@ -0,0 +1,89 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/vault"
+	"github.com/code-payments/ocp-server/ocp/data/vm/registry"
+	timelock_token_v1 "github.com/code-payments/ocp-server/solana/timelock/v1"
+)
+
+func TestGetVmConfigForMint(t *testing.T) {
+	ctx := context.Background()
+	data := ocp_data.NewTestDataProvider()
+
+	subsidizer := newRandomTestAccount(t)
+	require.NoError(t, InjectTestSubsidizer(ctx, data, subsidizer))
+
+	vmConfig, err := GetVmConfigForMint(ctx, data, CoreMintAccount)
+	require.NoError(t, err)
+	assert.Equal(t, subsidizer.PublicKey().ToBase58(), vmConfig.Authority.PublicKey().ToBase58())
+	assert.Equal(t, CoreMintVmAccount.PublicKey().ToBase58(), vmConfig.Vm.PublicKey().ToBase58())
+	assert.Equal(t, CoreMintVmOmnibusAccount.PublicKey().ToBase58(), vmConfig.Omnibus.PublicKey().ToBase58())
+	assert.Equal(t, CoreMintAccount.PublicKey().ToBase58(), vmConfig.Mint.PublicKey().ToBase58())
+	assert.Equal(t, timelock_token_v1.DefaultNumDaysLocked, vmConfig.LockDurationInDays)
+
+	expected := newRandomVmConfig(t, false)
+	expected.LockDurationInDays = 7
+
+	_, err = GetVmConfigForMint(ctx, data, expected.Mint)
+	assert.Equal(t, ErrUnsupportedMint, err)
+
+	supported, err := IsSupportedMint(ctx, data, expected.Mint)
+	require.NoError(t, err)
+	assert.False(t, supported)
+
+	require.NoError(t, data.RegisterVm(ctx, &registry.Record{
+		Mint:               expected.Mint.PublicKey().ToBase58(),
+		Vm:                 expected.Vm.PublicKey().ToBase58(),
+		Omnibus:            expected.Omnibus.PublicKey().ToBase58(),
+		Authority:          expected.Authority.PublicKey().ToBase58(),
+		LockDurationInDays: expected.LockDurationInDays,
+	}))
+
+	// Authority private key isn't in the vault, which is only required for
+	// signing
+	_, err = GetVmConfigForMint(ctx, data, expected.Mint)
+	assert.Error(t, err)
+
+	publicVmConfig, err := GetPublicVmConfigForMint(ctx, data, expected.Mint)
+	require.NoError(t, err)
+	assert.Equal(t, expected.Authority.PublicKey().ToBase58(), publicVmConfig.Authority.PublicKey().ToBase58())
+	assert.Nil(t, publicVmConfig.Authority.PrivateKey())
+	assert.Equal(t, expected.Vm.PublicKey().ToBase58(), publicVmConfig.Vm.PublicKey().ToBase58())
+
+	supported, err = IsSupportedMint(ctx, data, expected.Mint)
+	require.NoError(t, err)
+	assert.True(t, supported)
+
+	require.NoError(t, data.SaveKey(ctx, &vault.Record{
+		PublicKey:  expected.Authority.PublicKey().ToBase58(),
+		PrivateKey: expected.Authority.PrivateKey().ToBase58(),
+		State:      vault.StateAvailable,
+	}))
+
+	for i := 0; i < 2; i++ {
+		vmConfig, err = GetVmConfigForMint(ctx, data, expected.Mint)
+		require.NoError(t, err)
+		assert.Equal(t, expected.Authority.PublicKey().ToBase58(), vmConfig.Authority.PublicKey().ToBase58())
+		assert.Equal(t, expected.Authority.PrivateKey().ToBase58(), vmConfig.Authority.PrivateKey().ToBase58())
+		assert.Equal(t, expected.Vm.PublicKey().ToBase58(), vmConfig.Vm.PublicKey().ToBase58())
+		assert.Equal(t, expected.Omnibus.PublicKey().ToBase58(), vmConfig.Omnibus.PublicKey().ToBase58())
+		assert.Equal(t, expected.Mint.PublicKey().ToBase58(), vmConfig.Mint.PublicKey().ToBase58())
+		assert.Equal(t, expected.LockDurationInDays, vmConfig.LockDurationInDays)
+	}
+
+	supported, err = IsSupportedMint(ctx, data, expected.Mint)
+	require.NoError(t, err)
+	assert.True(t, supported)
+
+	// Authority private keys aren't cached with the rest of the VM config
+	cached, ok := vmConfigCache.Retrieve(expected.Mint.PublicKey().ToBase58())
+	require.True(t, ok)
+	assert.Nil(t, cached.(*VmConfig).Authority.PrivateKey())
+}
@@ -1,44 +1,166 @@
 package config
 
 import (
+	"context"
+	"crypto/ed25519"
+
 	"github.com/mr-tron/base58"
+	"github.com/pkg/errors"
 
+	"github.com/code-payments/ocp-server/config"
+	"github.com/code-payments/ocp-server/config/env"
 	"github.com/code-payments/ocp-server/usdf"
 )
 
-// todo: make these environment configs
-
 const (
-	CoreMintPublicKeyString = usdf.Mint
-	CoreMintQuarksPerUnit   = uint64(usdf.QuarksPerUsdf)
-	CoreMintDecimals        = usdf.Decimals
-	CoreMintName            = "USDF"
-	CoreMintSymbol          = "USDF"
-	CoreMintDescription     = "Your cash reserves are held in USDF, a fully backed digital dollar supported 1:1 by U.S. dollars. This ensures your funds retain the same value and stability as traditional USD, while benefiting from faster, more transparent transactions on modern financial infrastructure. You can deposit additional funds at any time, or withdraw your USDF for U.S. dollars whenever you like."
-	CoreMintImageUrl        = "https://flipcash-currency-assets.s3.us-east-1.amazonaws.com/todo/icon.png"
+	CoreMintPublicKeyConfigEnvName = "CORE_MINT_PUBLIC_KEY"
+	defaultCoreMintPublicKey       = usdf.Mint
+
+	CoreMintDecimalsConfigEnvName = "CORE_MINT_DECIMALS"
+	defaultCoreMintDecimals       = usdf.Decimals
+
+	CoreMintNameConfigEnvName = "CORE_MINT_NAME"
+	defaultCoreMintName       = "USDF"
+
+	CoreMintSymbolConfigEnvName = "CORE_MINT_SYMBOL"
+	defaultCoreMintSymbol       = "USDF"
+
+	CoreMintDescriptionConfigEnvName = "CORE_MINT_DESCRIPTION"
+	defaultCoreMintDescription       = "Your cash reserves are held in USDF, a fully backed digital dollar supported 1:1 by U.S. dollars. This ensures your funds retain the same value and stability as traditional USD, while benefiting from faster, more transparent transactions on modern financial infrastructure. You can deposit additional funds at any time, or withdraw your USDF for U.S. dollars whenever you like."
 
-	SubsidizerPublicKey = "cash11ndAmdKFEnG2wrQQ5Zqvr1kN9htxxLyoPLYFUV"
+	CoreMintImageUrlConfigEnvName = "CORE_MINT_IMAGE_URL"
+	defaultCoreMintImageUrl       = "https://flipcash-currency-assets.s3.us-east-1.amazonaws.com/todo/icon.png"
+
+	SubsidizerPublicKeyConfigEnvName = "SUBSIDIZER_PUBLIC_KEY"
+	defaultSubsidizerPublicKey       = "cash11ndAmdKFEnG2wrQQ5Zqvr1kN9htxxLyoPLYFUV"
 
 	// todo: replace with real VM
-	CoreMintVmAccountPublicKey = "BVMGLfRgr3nVFCH5DuW6VR2kfSDxq4EFEopXfwCDpYzb"
-	CoreMintVmOmnibusPublicKey = "GNw1t85VH8b1CcwB5933KBC7PboDPJ5EcQdGynbfN1Pb"
-
-	// todo: replace with new Jeffy
-	// todo: DB store to track VM per mint
-	JeffyMintPublicKey      = "52MNGpgvydSwCtC2H4qeiZXZ1TxEuRVCRGa8LAfk2kSj"
-	JeffyAuthorityPublicKey = "jfy1btcfsjSn2WCqLVaxiEjp4zgmemGyRsdCPbPwnZV"
-	JeffyVmAccountPublicKey = "Bii3UFB9DzPq6UxgewF5iv9h1Gi8ZnP6mr7PtocHGNta"
-	JeffyVmOmnibusPublicKey = "CQ5jni8XTXEcMFXS1ytNyTVbJBZHtHCzEtjBPowB3MLD"
+	CoreMintVmAccountPublicKeyConfigEnvName = "CORE_MINT_VM_ACCOUNT_PUBLIC_KEY"
+	defaultCoreMintVmAccountPublicKey       = "BVMGLfRgr3nVFCH5DuW6VR2kfSDxq4EFEopXfwCDpYzb"
+
+	CoreMintVmOmnibusPublicKeyConfigEnvName = "CORE_MINT_VM_OMNIBUS_PUBLIC_KEY"
+	defaultCoreMintVmOmnibusPublicKey       = "GNw1t85VH8b1CcwB5933KBC7PboDPJ5EcQdGynbfN1Pb"
+
+	maxCoreMintDecimals = 18
 )
 
+// Core mint and VM configuration, which is loaded from the environment at
+// startup so the same binary can be pointed at different deployments.
 var (
-	CoreMintPublicKeyBytes []byte
+	CoreMintPublicKeyString string
+	CoreMintPublicKeyBytes  []byte
+	CoreMintQuarksPerUnit   uint64
+	CoreMintDecimals        uint8
+	CoreMintName            string
+	CoreMintSymbol          string
+	CoreMintDescription     string
+	CoreMintImageUrl        string
+
+	SubsidizerPublicKey string
+
+	CoreMintVmAccountPublicKey string
+	CoreMintVmOmnibusPublicKey string
 )
 
+type conf struct {
+	coreMintPublicKey          config.String
+	coreMintDecimals           config.Uint64
+	coreMintName               config.String
+	coreMintSymbol             config.String
+	coreMintDescription        config.String
+	coreMintImageUrl           config.String
+	subsidizerPublicKey        config.String
+	coreMintVmAccountPublicKey config.String
+	coreMintVmOmnibusPublicKey config.String
+}
+
+func withEnvConfigs() *conf {
+	return &conf{
+		coreMintPublicKey:          env.NewStringConfig(CoreMintPublicKeyConfigEnvName, defaultCoreMintPublicKey),
+		coreMintDecimals:           env.NewUint64Config(CoreMintDecimalsConfigEnvName, defaultCoreMintDecimals),
+		coreMintName:               env.NewStringConfig(CoreMintNameConfigEnvName, defaultCoreMintName),
+		coreMintSymbol:             env.NewStringConfig(CoreMintSymbolConfigEnvName, defaultCoreMintSymbol),
+		coreMintDescription:        env.NewStringConfig(CoreMintDescriptionConfigEnvName, defaultCoreMintDescription),
+		coreMintImageUrl:           env.NewStringConfig(CoreMintImageUrlConfigEnvName, defaultCoreMintImageUrl),
+		subsidizerPublicKey:        env.NewStringConfig(SubsidizerPublicKeyConfigEnvName, defaultSubsidizerPublicKey),
+		coreMintVmAccountPublicKey: env.NewStringConfig(CoreMintVmAccountPublicKeyConfigEnvName, defaultCoreMintVmAccountPublicKey),
+		coreMintVmOmnibusPublicKey: env.NewStringConfig(CoreMintVmOmnibusPublicKeyConfigEnvName, defaultCoreMintVmOmnibusPublicKey),
+	}
+}
+
 func init() {
-	decoded, err := base58.Decode(CoreMintPublicKeyString)
-	if err != nil {
+	if err := load(context.Background(), withEnvConfigs()); err != nil {
 		panic(err)
 	}
-	CoreMintPublicKeyBytes = decoded
+}
+
+// load validates the provided configs and, only if they're all valid, applies
+// them to the package-level values
+func load(ctx context.Context, c *conf) error {
+	coreMintPublicKeyString := c.coreMintPublicKey.Get(ctx)
+	coreMintPublicKeyBytes, err := decodePublicKey(coreMintPublicKeyString)
+	if err != nil {
+		return errors.Wrap(err, "invalid core mint public key")
+	}
+
+	coreMintDecimals := c.coreMintDecimals.Get(ctx)
+	if coreMintDecimals > maxCoreMintDecimals {
+		return errors.Errorf("core mint decimals must be at most %d", maxCoreMintDecimals)
+	}
+	coreMintQuarksPerUnit := uint64(1)
+	for i := uint64(0); i < coreMintDecimals; i++ {
+		coreMintQuarksPerUnit *= 10
+	}
+
+	coreMintName := c.coreMintName.Get(ctx)
+	if len(coreMintName) == 0 {
+		return errors.New("core mint name is required")
+	}
+
+	coreMintSymbol := c.coreMintSymbol.Get(ctx)
+	if len(coreMintSymbol) == 0 {
+		return errors.New("core mint symbol is required")
+	}
+
+	subsidizerPublicKey := c.subsidizerPublicKey.Get(ctx)
+	if _, err := decodePublicKey(subsidizerPublicKey); err != nil {
+		return errors.Wrap(err, "invalid subsidizer public key")
+	}
+
+	coreMintVmAccountPublicKey := c.coreMintVmAccountPublicKey.Get(ctx)
+	if _, err := decodePublicKey(coreMintVmAccountPublicKey); err != nil {
+		return errors.Wrap(err, "invalid core mint vm account public key")
+	}
+
+	coreMintVmOmnibusPublicKey := c.coreMintVmOmnibusPublicKey.Get(ctx)
+	if _, err := decodePublicKey(coreMintVmOmnibusPublicKey); err != nil {
+		return errors.Wrap(err, "invalid core mint vm omnibus public key")
+	}
+
+	CoreMintPublicKeyString = coreMintPublicKeyString
+	CoreMintPublicKeyBytes = coreMintPublicKeyBytes
+	CoreMintQuarksPerUnit = coreMintQuarksPerUnit
+	CoreMintDecimals = uint8(coreMintDecimals)
+	CoreMintName = coreMintName
+	CoreMintSymbol = coreMintSymbol
+	CoreMintDescription = c.coreMintDescription.Get(ctx)
+	CoreMintImageUrl = c.coreMintImageUrl.Get(ctx)
+
+	SubsidizerPublicKey = subsidizerPublicKey
+
+	CoreMintVmAccountPublicKey = coreMintVmAccountPublicKey
+	CoreMintVmOmnibusPublicKey = coreMintVmOmnibusPublicKey
+
+	return nil
+}
+
+func decodePublicKey(value string) ([]byte, error) {
+	decoded, err := base58.Decode(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(decoded) != ed25519.PublicKeySize {
+		return nil, errors.Errorf("public key must be %d bytes", ed25519.PublicKeySize)
+	}
+	return decoded, nil
 }
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mr-tron/base58"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/code-payments/ocp-server/usdc"
+	"github.com/code-payments/ocp-server/usdf"
+)
+
+func TestLoad_Defaults(t *testing.T) {
+	require.NoError(t, load(context.Background(), withEnvConfigs()))
+
+	assert.Equal(t, usdf.Mint, CoreMintPublicKeyString)
+	assert.Equal(t, base58.Encode(CoreMintPublicKeyBytes), CoreMintPublicKeyString)
+	assert.EqualValues(t, usdf.QuarksPerUsdf, CoreMintQuarksPerUnit)
+	assert.EqualValues(t, usdf.Decimals, CoreMintDecimals)
+	assert.Equal(t, defaultCoreMintName, CoreMintName)
+	assert.Equal(t, defaultCoreMintSymbol, CoreMintSymbol)
+	assert.Equal(t, defaultSubsidizerPublicKey, SubsidizerPublicKey)
+	assert.Equal(t, defaultCoreMintVmAccountPublicKey, CoreMintVmAccountPublicKey)
+	assert.Equal(t, defaultCoreMintVmOmnibusPublicKey, CoreMintVmOmnibusPublicKey)
+}
+
+func TestLoad_FromEnv(t *testing.T) {
+	t.Cleanup(func() {
+		require.NoError(t, load(context.Background(), withEnvConfigs()))
+	})
+
+	t.Setenv(CoreMintPublicKeyConfigEnvName, usdc.Mint)
+	t.Setenv(CoreMintDecimalsConfigEnvName, "9")
+	t.Setenv(CoreMintNameConfigEnvName, "USD Coin")
+	t.Setenv(CoreMintSymbolConfigEnvName, "USDC")
+	t.Setenv(CoreMintDescriptionConfigEnvName, "description")
+	t.Setenv(CoreMintImageUrlConfigEnvName, "https://example.com/usdc.png")
+	t.Setenv(SubsidizerPublicKeyConfigEnvName, defaultCoreMintVmAccountPublicKey)
+	t.Setenv(CoreMintVmAccountPublicKeyConfigEnvName, defaultCoreMintVmOmnibusPublicKey)
+	t.Setenv(CoreMintVmOmnibusPublicKeyConfigEnvName, defaultSubsidizerPublicKey)
+
+	require.NoError(t, load(context.Background(), withEnvConfigs()))
+
+	assert.Equal(t, usdc.Mint, CoreMintPublicKeyString)
+	assert.EqualValues(t, usdc.TokenMint, CoreMintPublicKeyBytes)
+	assert.EqualValues(t, 1_000_000_000, CoreMintQuarksPerUnit)
+	assert.EqualValues(t, 9, CoreMintDecimals)
+	assert.Equal(t, "USD Coin", CoreMintName)
+	assert.Equal(t, "USDC", CoreMintSymbol)
+	assert.Equal(t, "description", CoreMintDescription)
+	assert.Equal(t, "https://example.com/usdc.png", CoreMintImageUrl)
+	assert.Equal(t, defaultCoreMintVmAccountPublicKey, SubsidizerPublicKey)
+	assert.Equal(t, defaultCoreMintVmOmnibusPublicKey, CoreMintVmAccountPublicKey)
+	assert.Equal(t, defaultSubsidizerPublicKey, CoreMintVmOmnibusPublicKey)
+}
+
+func TestLoad_Validation(t *testing.T) {
+	for _, tc := range []struct {
+		envName string
+		value   string
+	}{
+		{CoreMintPublicKeyConfigEnvName, "invalid"},
+		{CoreMintPublicKeyConfigEnvName, "11111"},
+		{CoreMintDecimalsConfigEnvName, "19"},
+		{SubsidizerPublicKeyConfigEnvName, "invalid"},
+		{CoreMintVmAccountPublicKeyConfigEnvName, "invalid"},
+		{CoreMintVmOmnibusPublicKeyConfigEnvName, "invalid"},
+	} {
+		t.Run(tc.envName+"="+tc.value, func(t *testing.T) {
+			t.Setenv(tc.envName, tc.value)
+
+			assert.Error(t, load(context.Background(), withEnvConfigs()))
+
+			// Invalid configs are never partially applied
+			assert.Equal(t, usdf.Mint, CoreMintPublicKeyString)
+			assert.EqualValues(t, usdf.Decimals, CoreMintDecimals)
+			assert.Equal(t, defaultSubsidizerPublicKey, SubsidizerPublicKey)
+		})
+	}
+}
@@ -22,3 +22,15 @@ func GetLatestExchangeRateTime() time.Time {
 	queryTimeUnix = queryTimeUnix - (queryTimeUnix % secondsInUpdateInterval) - 1
 	return time.Unix(queryTimeUnix, 0)
 }
+
+// GetExchangeRateUpdateInterval gets the interval at which exchange rates are
+// expected to be available
+func GetExchangeRateUpdateInterval() time.Duration {
+	return timePerExchangeRateUpdate
+}
+
+// GetExchangeRateIntervalStart gets the start of the exchange rate update
+// interval that contains the provided time
+func GetExchangeRateIntervalStart(t time.Time) time.Time {
+	return t.Truncate(timePerExchangeRateUpdate)
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/pkg/errors"
 
 	commonpb "github.com/code-payments/ocp-protobuf-api/generated/go/common/v1"
+
+	"github.com/code-payments/ocp-server/pointer"
 )
 
 var AllAccountTypes = []commonpb.AccountType{
@@ -31,6 +33,10 @@ type Record struct {
 
 	RequiresAutoReturnCheck bool
 
+	// GiftCardExpiresAt is when a remote send gift card's balance is returned
+	// back to the issuer if it hasn't been claimed. It's set at issuance.
+	GiftCardExpiresAt *time.Time
+
 	CreatedAt time.Time
 }
 
@@ -46,6 +52,7 @@ func (r *Record) Clone() Record {
 		RequiresDepositSync:     r.RequiresDepositSync,
 		DepositsLastSyncedAt:    r.DepositsLastSyncedAt,
 		RequiresAutoReturnCheck: r.RequiresAutoReturnCheck,
+		GiftCardExpiresAt:       pointer.TimeCopy(r.GiftCardExpiresAt),
 		CreatedAt:               r.CreatedAt,
 	}
 }
@@ -61,6 +68,7 @@ func (r *Record) CopyTo(dst *Record) {
 	dst.RequiresDepositSync = r.RequiresDepositSync
 	dst.DepositsLastSyncedAt = r.DepositsLastSyncedAt
 	dst.RequiresAutoReturnCheck = r.RequiresAutoReturnCheck
+	dst.GiftCardExpiresAt = pointer.TimeCopy(r.GiftCardExpiresAt)
 	dst.CreatedAt = r.CreatedAt
 }
 
@@ -126,6 +134,14 @@ func (r *Record) Validate() error {
 		return errors.New("only remote send gift cards can have auto-return checks")
 	}
 
+	if r.GiftCardExpiresAt != nil && r.AccountType != commonpb.AccountType_REMOTE_SEND_GIFT_CARD {
+		return errors.New("only remote send gift cards can have an expiry")
+	}
+
+	if r.RequiresAutoReturnCheck && r.GiftCardExpiresAt == nil {
+		return errors.New("gift card expiry is required for auto-return checks")
+	}
+
 	return nil
 }
 
@@ -41,12 +41,12 @@ func (a ByDepositsLastSyncedAt) Less(i, j int) bool {
 	return a[i].DepositsLastSyncedAt.Unix() < a[j].DepositsLastSyncedAt.Unix()
 }
 
-type ByCreatedAt []*account.Record
+type ByGiftCardExpiresAt []*account.Record
 
-func (a ByCreatedAt) Len() int      { return len(a) }
-func (a ByCreatedAt) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
-func (a ByCreatedAt) Less(i, j int) bool {
-	return a[i].CreatedAt.Unix() < a[j].CreatedAt.Unix()
+func (a ByGiftCardExpiresAt) Len() int      { return len(a) }
+func (a ByGiftCardExpiresAt) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a ByGiftCardExpiresAt) Less(i, j int) bool {
+	return a[i].GiftCardExpiresAt.Before(*a[j].GiftCardExpiresAt)
 }
 
 func New() account.Store {
@@ -403,7 +403,7 @@ func (s *store) CountRequiringDepositSync(ctx context.Context) (uint64, error) {
 }
 
 // GetPrioritizedRequiringAutoReturnCheck implements account.Store.GetPrioritizedRequiringAutoReturnCheck
-func (s *store) GetPrioritizedRequiringAutoReturnCheck(ctx context.Context, minAge time.Duration, limit uint64) ([]*account.Record, error) {
+func (s *store) GetPrioritizedRequiringAutoReturnCheck(ctx context.Context, expiredBefore time.Time, limit uint64) ([]*account.Record, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -411,7 +411,7 @@ func (s *store) GetPrioritizedRequiringAutoReturnCheck(ctx context.Context, minA
 
 	var res []*account.Record
 	for _, item := range items {
-		if time.Since(item.CreatedAt) <= minAge {
+		if item.GiftCardExpiresAt == nil || item.GiftCardExpiresAt.After(expiredBefore) {
 			continue
 		}
 
@@ -423,7 +423,7 @@ func (s *store) GetPrioritizedRequiringAutoReturnCheck(ctx context.Context, minA
 		return nil, account.ErrAccountInfoNotFound
 	}
 
-	sorted := ByCreatedAt(res)
+	sorted := ByGiftCardExpiresAt(res)
 	sort.Sort(sorted)
 
 	if len(res) > int(limit) {
@@ -0,0 +1,22 @@
+CREATE TABLE ocp__core_accountinfo (
+	id SERIAL NOT NULL PRIMARY KEY,
+
+	owner_account TEXT NOT NULL,
+	authority_account TEXT NOT NULL,
+	token_account TEXT NOT NULL,
+	mint_account TEXT NOT NULL,
+
+	account_type INTEGER NOT NULL,
+	index INTEGER NOT NULL,
+
+	requires_deposit_sync BOOL NOT NULL,
+	deposits_last_synced_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	requires_auto_return_check BOOL NOT NULL,
+
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	CONSTRAINT ocp__core_accountinfov2__uniq__token_account UNIQUE (token_account),
+	CONSTRAINT ocp__core_accountinfov2__uniq__authority_account__and__mint_account UNIQUE (authority_account, mint_account),
+	CONSTRAINT ocp__core_accountinfov2__uniq__owner_account__and__mint_account__and__account_type__and__index UNIQUE(owner_account, mint_account, account_type, index)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE ocp__core_accountinfo
+	DROP COLUMN gift_card_expires_at;
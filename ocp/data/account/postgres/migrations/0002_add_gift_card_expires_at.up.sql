@@ -0,0 +1,7 @@
+ALTER TABLE ocp__core_accountinfo
+	ADD COLUMN gift_card_expires_at TIMESTAMP WITH TIME ZONE NULL;
+
+-- Gift cards issued before expiry was configurable used a fixed 7 day expiry
+UPDATE ocp__core_accountinfo
+	SET gift_card_expires_at = created_at + INTERVAL '7 days'
+	WHERE account_type = 2;
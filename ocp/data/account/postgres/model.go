@@ -13,6 +13,7 @@ import (
 
 	"github.com/code-payments/ocp-server/ocp/data/account"
 	pgutil "github.com/code-payments/ocp-server/database/postgres"
+	"github.com/code-payments/ocp-server/pointer"
 )
 
 const (
@@ -33,7 +34,8 @@ type model struct {
 	RequiresDepositSync  bool      `db:"requires_deposit_sync"`
 	DepositsLastSyncedAt time.Time `db:"deposits_last_synced_at"`
 
-	RequiresAutoReturnCheck bool `db:"requires_auto_return_check"`
+	RequiresAutoReturnCheck bool         `db:"requires_auto_return_check"`
+	GiftCardExpiresAt       sql.NullTime `db:"gift_card_expires_at"`
 
 	CreatedAt time.Time `db:"created_at"`
 }
@@ -43,6 +45,11 @@ func toModel(obj *account.Record) (*model, error) {
 		return nil, err
 	}
 
+	var giftCardExpiresAt sql.NullTime
+	if obj.GiftCardExpiresAt != nil {
+		giftCardExpiresAt = sql.NullTime{Valid: true, Time: obj.GiftCardExpiresAt.UTC()}
+	}
+
 	return &model{
 		OwnerAccount:     obj.OwnerAccount,
 		AuthorityAccount: obj.AuthorityAccount,
@@ -56,6 +63,7 @@ func toModel(obj *account.Record) (*model, error) {
 		DepositsLastSyncedAt: obj.DepositsLastSyncedAt.UTC(),
 
 		RequiresAutoReturnCheck: obj.RequiresAutoReturnCheck,
+		GiftCardExpiresAt:       giftCardExpiresAt,
 
 		CreatedAt: obj.CreatedAt.UTC(),
 	}, nil
@@ -77,6 +85,7 @@ func fromModel(obj *model) *account.Record {
 		DepositsLastSyncedAt: obj.DepositsLastSyncedAt,
 
 		RequiresAutoReturnCheck: obj.RequiresAutoReturnCheck,
+		GiftCardExpiresAt:       pointer.TimeIfValid(obj.GiftCardExpiresAt.Valid, obj.GiftCardExpiresAt.Time),
 
 		CreatedAt: obj.CreatedAt,
 	}
@@ -89,9 +98,9 @@ func (m *model) dbInsert(ctx context.Context, db *sqlx.DB) error {
 		}
 
 		query := `INSERT INTO ` + tableName + `
-			(owner_account, authority_account, token_account, mint_account, account_type, index, requires_deposit_sync, deposits_last_synced_at, requires_auto_return_check, created_at)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-			RETURNING id, owner_account, authority_account, token_account, mint_account, account_type, index, requires_deposit_sync, deposits_last_synced_at, requires_auto_return_check, created_at
+			(owner_account, authority_account, token_account, mint_account, account_type, index, requires_deposit_sync, deposits_last_synced_at, requires_auto_return_check, gift_card_expires_at, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			RETURNING id, owner_account, authority_account, token_account, mint_account, account_type, index, requires_deposit_sync, deposits_last_synced_at, requires_auto_return_check, gift_card_expires_at, created_at
 		`
 		err := tx.QueryRowxContext(
 			ctx,
@@ -105,6 +114,7 @@ func (m *model) dbInsert(ctx context.Context, db *sqlx.DB) error {
 			m.RequiresDepositSync,
 			m.DepositsLastSyncedAt,
 			m.RequiresAutoReturnCheck,
+			m.GiftCardExpiresAt,
 			m.CreatedAt,
 		).StructScan(m)
 		if err == nil {
@@ -136,7 +146,7 @@ func (m *model) dbUpdate(ctx context.Context, db *sqlx.DB) error {
 	query := `UPDATE ` + tableName + `
 		SET requires_deposit_sync = $2, deposits_last_synced_at = $3, requires_auto_return_check = $4
 		WHERE token_account = $1
-		RETURNING id, owner_account, authority_account, token_account, mint_account, account_type, index, requires_deposit_sync, deposits_last_synced_at, requires_auto_return_check, created_at
+		RETURNING id, owner_account, authority_account, token_account, mint_account, account_type, index, requires_deposit_sync, deposits_last_synced_at, requires_auto_return_check, gift_card_expires_at, created_at
 	`
 
 	err := db.QueryRowxContext(
@@ -157,7 +167,7 @@ func (m *model) dbUpdate(ctx context.Context, db *sqlx.DB) error {
 func dbGetByTokenAddress(ctx context.Context, db *sqlx.DB, address string) (*model, error) {
 	res := &model{}
 
-	query := `SELECT id, owner_account, authority_account, token_account, mint_account, account_type, index, requires_deposit_sync, deposits_last_synced_at, requires_auto_return_check, created_at FROM ` + tableName + `
+	query := `SELECT id, owner_account, authority_account, token_account, mint_account, account_type, index, requires_deposit_sync, deposits_last_synced_at, requires_auto_return_check, gift_card_expires_at, created_at FROM ` + tableName + `
 		WHERE token_account = $1
 	`
 
@@ -182,7 +192,7 @@ func dbGetByTokenAddressBatch(ctx context.Context, db *sqlx.DB, addresses ...str
 	}
 
 	query := fmt.Sprintf(
-		`SELECT id, owner_account, authority_account, token_account, mint_account, account_type, index, requires_deposit_sync, deposits_last_synced_at, requires_auto_return_check, created_at FROM `+tableName+`
+		`SELECT id, owner_account, authority_account, token_account, mint_account, account_type, index, requires_deposit_sync, deposits_last_synced_at, requires_auto_return_check, gift_card_expires_at, created_at FROM `+tableName+`
 		WHERE token_account IN (%s)`,
 		strings.Join(individualFilters, ", "),
 	)
@@ -200,7 +210,7 @@ func dbGetByTokenAddressBatch(ctx context.Context, db *sqlx.DB, addresses ...str
 func dbGetByAuthorityAddress(ctx context.Context, db *sqlx.DB, address string) ([]*model, error) {
 	var res []*model
 
-	query := `SELECT id, owner_account, authority_account, token_account, mint_account, account_type, index, requires_deposit_sync, deposits_last_synced_at, requires_auto_return_check, created_at FROM ` + tableName + `
+	query := `SELECT id, owner_account, authority_account, token_account, mint_account, account_type, index, requires_deposit_sync, deposits_last_synced_at, requires_auto_return_check, gift_card_expires_at, created_at FROM ` + tableName + `
 		WHERE authority_account = $1
 	`
 
@@ -222,7 +232,7 @@ func dbGetByAuthorityAddress(ctx context.Context, db *sqlx.DB, address string) (
 func dbGetLatestByOwnerAddress(ctx context.Context, db *sqlx.DB, address string) ([]*model, error) {
 	var res1 []*model
 
-	query1 := `SELECT DISTINCT ON (mint_account, account_type) id, owner_account, authority_account, token_account, mint_account, account_type, index, requires_deposit_sync, deposits_last_synced_at, requires_auto_return_check, created_at FROM ` + tableName + `
+	query1 := `SELECT DISTINCT ON (mint_account, account_type) id, owner_account, authority_account, token_account, mint_account, account_type, index, requires_deposit_sync, deposits_last_synced_at, requires_auto_return_check, gift_card_expires_at, created_at FROM ` + tableName + `
 		WHERE owner_account = $1 AND account_type NOT IN ($2)
 		ORDER BY mint_account, account_type, index DESC
 	`
@@ -240,7 +250,7 @@ func dbGetLatestByOwnerAddress(ctx context.Context, db *sqlx.DB, address string)
 
 	var res2 []*model
 
-	query2 := `SELECT id, owner_account, authority_account, token_account, mint_account, account_type, index, requires_deposit_sync, deposits_last_synced_at, requires_auto_return_check, created_at FROM ` + tableName + `
+	query2 := `SELECT id, owner_account, authority_account, token_account, mint_account, account_type, index, requires_deposit_sync, deposits_last_synced_at, requires_auto_return_check, gift_card_expires_at, created_at FROM ` + tableName + `
 		WHERE owner_account = $1 AND account_type IN ($2)
 		ORDER BY index ASC
 	`
@@ -267,7 +277,7 @@ func dbGetLatestByOwnerAddress(ctx context.Context, db *sqlx.DB, address string)
 func dbGetLatestByOwnerAddressAndType(ctx context.Context, db *sqlx.DB, address string, accountType commonpb.AccountType) ([]*model, error) {
 	var res []*model
 
-	query := `SELECT DISTINCT ON (mint_account) id, owner_account, authority_account, token_account, mint_account, account_type, index, requires_deposit_sync, deposits_last_synced_at, requires_auto_return_check, created_at FROM ` + tableName + `
+	query := `SELECT DISTINCT ON (mint_account) id, owner_account, authority_account, token_account, mint_account, account_type, index, requires_deposit_sync, deposits_last_synced_at, requires_auto_return_check, gift_card_expires_at, created_at FROM ` + tableName + `
 		WHERE owner_account = $1 AND account_type = $2
 		ORDER BY mint_account, index DESC
 	`
@@ -291,7 +301,7 @@ func dbGetLatestByOwnerAddressAndType(ctx context.Context, db *sqlx.DB, address
 func dbGetPrioritizedRequiringDepositSync(ctx context.Context, db *sqlx.DB, limit uint64) ([]*model, error) {
 	var res []*model
 
-	query := `SELECT id, owner_account, authority_account, token_account, mint_account, account_type, index, requires_deposit_sync, deposits_last_synced_at, requires_auto_return_check, created_at FROM ` + tableName + `
+	query := `SELECT id, owner_account, authority_account, token_account, mint_account, account_type, index, requires_deposit_sync, deposits_last_synced_at, requires_auto_return_check, gift_card_expires_at, created_at FROM ` + tableName + `
 		WHERE requires_deposit_sync = TRUE
 		ORDER BY deposits_last_synced_at ASC
 		LIMIT $1
@@ -327,19 +337,19 @@ func dbCountRequiringDepositSync(ctx context.Context, db *sqlx.DB) (uint64, erro
 	return res, nil
 }
 
-func dbGetPrioritizedRequiringAutoReturnChecks(ctx context.Context, db *sqlx.DB, minAge time.Duration, limit uint64) ([]*model, error) {
+func dbGetPrioritizedRequiringAutoReturnChecks(ctx context.Context, db *sqlx.DB, expiredBefore time.Time, limit uint64) ([]*model, error) {
 	var res []*model
 
-	query := `SELECT id, owner_account, authority_account, token_account, mint_account, account_type, index, requires_deposit_sync, deposits_last_synced_at, requires_auto_return_check, created_at FROM ` + tableName + `
-		WHERE requires_auto_return_check = TRUE AND created_at <= $1
-		ORDER BY created_at ASC
+	query := `SELECT id, owner_account, authority_account, token_account, mint_account, account_type, index, requires_deposit_sync, deposits_last_synced_at, requires_auto_return_check, gift_card_expires_at, created_at FROM ` + tableName + `
+		WHERE requires_auto_return_check = TRUE AND gift_card_expires_at <= $1
+		ORDER BY gift_card_expires_at ASC
 		LIMIT $2
 	`
 	err := db.SelectContext(
 		ctx,
 		&res,
 		query,
-		time.Now().Add(-minAge),
+		expiredBefore.UTC(),
 		limit,
 	)
 	if err != nil {
@@ -147,8 +147,8 @@ func (s *store) CountRequiringDepositSync(ctx context.Context) (uint64, error) {
 }
 
 // GetPrioritizedRequiringAutoReturnCheck implements account.Store.GetPrioritizedRequiringAutoReturnCheck
-func (s *store) GetPrioritizedRequiringAutoReturnCheck(ctx context.Context, minAge time.Duration, limit uint64) ([]*account.Record, error) {
-	models, err := dbGetPrioritizedRequiringAutoReturnChecks(ctx, s.db, minAge, limit)
+func (s *store) GetPrioritizedRequiringAutoReturnCheck(ctx context.Context, expiredBefore time.Time, limit uint64) ([]*account.Record, error) {
+	models, err := dbGetPrioritizedRequiringAutoReturnChecks(ctx, s.db, expiredBefore, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"os"
 	"testing"
@@ -11,6 +12,7 @@ import (
 	"github.com/code-payments/ocp-server/ocp/data/account"
 	"github.com/code-payments/ocp-server/ocp/data/account/tests"
 
+	"github.com/code-payments/ocp-server/database/postgres/migration"
 	postgrestest "github.com/code-payments/ocp-server/database/postgres/test"
 
 	_ "github.com/jackc/pgx/v4/stdlib"
@@ -21,39 +23,6 @@ var (
 	teardown  func()
 )
 
-const (
-	// Used for testing ONLY, the table and migrations are external to this repository
-	tableCreate = `
-	CREATE TABLE ocp__core_accountinfo (
-		id SERIAL NOT NULL PRIMARY KEY,
-
-		owner_account TEXT NOT NULL,
-		authority_account TEXT NOT NULL,
-		token_account TEXT NOT NULL,
-		mint_account TEXT NOT NULL,
-
-		account_type INTEGER NOT NULL,
-		index INTEGER NOT NULL,
-
-		requires_deposit_sync BOOL NOT NULL,
-		deposits_last_synced_at TIMESTAMP WITH TIME ZONE NOT NULL,
-
-		requires_auto_return_check BOOL NOT NULL,
-
-		created_at TIMESTAMP WITH TIME ZONE NOT NULL,
-
-		CONSTRAINT ocp__core_accountinfov2__uniq__token_account UNIQUE (token_account),
-		CONSTRAINT ocp__core_accountinfov2__uniq__authority_account__and__mint_account UNIQUE (authority_account, mint_account),
-		CONSTRAINT ocp__core_accountinfov2__uniq__owner_account__and__mint_account__and__account_type__and__index UNIQUE(owner_account, mint_account, account_type, index)
-	);
-	`
-
-	// Used for testing ONLY, the table and migrations are external to this repository
-	tableDestroy = `
-		DROP TABLE ocp__core_accountinfo;
-	`
-)
-
 func TestMain(m *testing.M) {
 	log := zap.Must(zap.NewDevelopment())
 
@@ -101,7 +70,13 @@ func TestAccountPostgresStore(t *testing.T) {
 }
 
 func createTestTables(log *zap.Logger, db *sql.DB) error {
-	_, err := db.Exec(tableCreate)
+	migrations, err := Migrations()
+	if err != nil {
+		log.With(zap.Error(err)).Error("could not load migrations")
+		return err
+	}
+
+	err = migration.Up(context.Background(), db, migrations)
 	if err != nil {
 		log.With(zap.Error(err)).Error("could not create test tables")
 		return err
@@ -110,7 +85,13 @@ func createTestTables(log *zap.Logger, db *sql.DB) error {
 }
 
 func resetTestTables(log *zap.Logger, db *sql.DB) error {
-	_, err := db.Exec(tableDestroy)
+	migrations, err := Migrations()
+	if err != nil {
+		log.With(zap.Error(err)).Error("could not load migrations")
+		return err
+	}
+
+	err = migration.Down(context.Background(), db, migrations)
 	if err != nil {
 		log.With(zap.Error(err)).Error("could not drop test tables")
 		return err
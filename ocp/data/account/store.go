@@ -56,8 +56,9 @@ type Store interface {
 	CountRequiringDepositSync(ctx context.Context) (uint64, error)
 
 	// GetPrioritizedRequiringAutoReturnCheck gets a set of account info objects where
-	// RequiresAutoReturnCheck is true and older than minAge that's prioritized by CreatedAt
-	GetPrioritizedRequiringAutoReturnCheck(ctx context.Context, minAge time.Duration, limit uint64) ([]*Record, error)
+	// RequiresAutoReturnCheck is true and GiftCardExpiresAt is at or before expiredBefore
+	// that's prioritized by GiftCardExpiresAt
+	GetPrioritizedRequiringAutoReturnCheck(ctx context.Context, expiredBefore time.Time, limit uint64) ([]*Record, error)
 
 	// CountRequiringAutoReturnCheck counts the number of account info objects where
 	// RequiresAutoReturnCheck is true
@@ -12,6 +12,7 @@ import (
 	commonpb "github.com/code-payments/ocp-protobuf-api/generated/go/common/v1"
 
 	"github.com/code-payments/ocp-server/ocp/data/account"
+	"github.com/code-payments/ocp-server/pointer"
 )
 
 func RunTests(t *testing.T, s account.Store, teardown func()) {
@@ -424,11 +425,19 @@ func testRemoteSendEdgeCases(t *testing.T, s account.Store) {
 			AccountType:             commonpb.AccountType_REMOTE_SEND_GIFT_CARD,
 			Index:                   uint64(0),
 			RequiresAutoReturnCheck: true,
+			GiftCardExpiresAt:       pointer.Time(time.Now().Add(24 * time.Hour)),
 		}
 		cloned := remoteSendRecord.Clone()
 
 		primaryRecord := remoteSendRecord.Clone()
 		primaryRecord.AccountType = commonpb.AccountType_PRIMARY
+		primaryRecord.RequiresAutoReturnCheck = false
+		assert.Error(t, s.Put(ctx, &primaryRecord))
+		primaryRecord.GiftCardExpiresAt = nil
+
+		missingExpiryRecord := remoteSendRecord.Clone()
+		missingExpiryRecord.GiftCardExpiresAt = nil
+		assert.Error(t, s.Put(ctx, &missingExpiryRecord))
 
 		require.NoError(t, s.Put(ctx, remoteSendRecord))
 		assert.Error(t, s.Put(ctx, &primaryRecord))
@@ -575,7 +584,9 @@ func testAutoReturnCheckMethods(t *testing.T, s account.Store) {
 	t.Run("testAutoReturnCheckMethods", func(t *testing.T) {
 		ctx := context.Background()
 
-		_, err := s.GetPrioritizedRequiringAutoReturnCheck(ctx, time.Duration(0), 10)
+		now := time.Now()
+
+		_, err := s.GetPrioritizedRequiringAutoReturnCheck(ctx, now, 10)
 		assert.Equal(t, account.ErrAccountInfoNotFound, err)
 
 		count, err := s.CountRequiringAutoReturnCheck(ctx)
@@ -585,13 +596,14 @@ func testAutoReturnCheckMethods(t *testing.T, s account.Store) {
 		var records []*account.Record
 		for i := 0; i < 10; i++ {
 			record := &account.Record{
-				OwnerAccount:     fmt.Sprintf("owner%d", i),
-				AuthorityAccount: fmt.Sprintf("owner%d", i),
-				TokenAccount:     fmt.Sprintf("token%d", i),
-				MintAccount:      "mint",
-				AccountType:      commonpb.AccountType_REMOTE_SEND_GIFT_CARD,
-				Index:            uint64(0),
-				CreatedAt:        time.Now().Add(time.Duration(-i) * time.Hour),
+				OwnerAccount:      fmt.Sprintf("owner%d", i),
+				AuthorityAccount:  fmt.Sprintf("owner%d", i),
+				TokenAccount:      fmt.Sprintf("token%d", i),
+				MintAccount:       "mint",
+				AccountType:       commonpb.AccountType_REMOTE_SEND_GIFT_CARD,
+				Index:             uint64(0),
+				GiftCardExpiresAt: pointer.Time(now.Add(time.Duration(-i) * time.Hour)),
+				CreatedAt:         now.Add(-24 * time.Hour),
 			}
 
 			if i < 7 {
@@ -606,7 +618,7 @@ func testAutoReturnCheckMethods(t *testing.T, s account.Store) {
 		require.NoError(t, err)
 		assert.EqualValues(t, 7, count)
 
-		result, err := s.GetPrioritizedRequiringAutoReturnCheck(ctx, time.Duration(0), 10)
+		result, err := s.GetPrioritizedRequiringAutoReturnCheck(ctx, now, 10)
 		require.NoError(t, err)
 		require.Len(t, result, 7)
 
@@ -614,7 +626,7 @@ func testAutoReturnCheckMethods(t *testing.T, s account.Store) {
 			assertEquivalentRecords(t, records[6-i], actual)
 		}
 
-		result, err = s.GetPrioritizedRequiringAutoReturnCheck(ctx, time.Duration(0), 3)
+		result, err = s.GetPrioritizedRequiringAutoReturnCheck(ctx, now, 3)
 		require.NoError(t, err)
 		require.Len(t, result, 3)
 
@@ -622,13 +634,16 @@ func testAutoReturnCheckMethods(t *testing.T, s account.Store) {
 			assertEquivalentRecords(t, records[6-i], actual)
 		}
 
-		result, err = s.GetPrioritizedRequiringAutoReturnCheck(ctx, 2*time.Hour+time.Second, 10)
+		result, err = s.GetPrioritizedRequiringAutoReturnCheck(ctx, now.Add(-2*time.Hour-time.Second), 10)
 		require.NoError(t, err)
 		require.Len(t, result, 4)
 
 		for i, actual := range result {
 			assertEquivalentRecords(t, records[6-i], actual)
 		}
+
+		_, err = s.GetPrioritizedRequiringAutoReturnCheck(ctx, now.Add(-7*time.Hour), 10)
+		assert.Equal(t, account.ErrAccountInfoNotFound, err)
 	})
 }
 
@@ -642,4 +657,10 @@ func assertEquivalentRecords(t *testing.T, obj1, obj2 *account.Record) {
 	assert.Equal(t, obj1.RequiresDepositSync, obj2.RequiresDepositSync)
 	assert.Equal(t, obj1.DepositsLastSyncedAt.Unix(), obj2.DepositsLastSyncedAt.Unix())
 	assert.Equal(t, obj1.RequiresAutoReturnCheck, obj2.RequiresAutoReturnCheck)
+	if obj1.GiftCardExpiresAt == nil {
+		assert.Nil(t, obj2.GiftCardExpiresAt)
+	} else {
+		require.NotNil(t, obj2.GiftCardExpiresAt)
+		assert.Equal(t, obj1.GiftCardExpiresAt.Unix(), obj2.GiftCardExpiresAt.Unix())
+	}
 }
@@ -18,6 +18,9 @@ const (
 	NoPrivacyTransfer
 	NoPrivacyWithdraw
 	CloseEmptyAccount
+	InitializeLaunchpadCurrency
+	CreateLaunchpadCurrencyAlt
+	InitializeLaunchpadCurrencyVm
 )
 
 type State uint8
@@ -166,6 +169,12 @@ func (t Type) String() string {
 		return "no_privacy_withdraw"
 	case CloseEmptyAccount:
 		return "close_empty_account"
+	case InitializeLaunchpadCurrency:
+		return "initialize_launchpad_currency"
+	case CreateLaunchpadCurrencyAlt:
+		return "create_launchpad_currency_alt"
+	case InitializeLaunchpadCurrencyVm:
+		return "initialize_launchpad_currency_vm"
 	}
 
 	return "unknown"
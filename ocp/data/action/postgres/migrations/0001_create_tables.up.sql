@@ -0,0 +1,23 @@
+CREATE TABLE ocp__core_action(
+	id SERIAL NOT NULL PRIMARY KEY,
+
+	intent TEXT NOT NULL,
+	intent_type INTEGER NOT NULL,
+
+	action_id INTEGER NOT NULL,
+	action_type INTEGER NOT NULL,
+
+	source TEXT NOT NULL,
+	destination TEXT NULL,
+	quantity INTEGER NULL,
+
+	fee_type INTEGER NULL,
+
+	state INTEGER NOT NULL,
+
+	version INTEGER NOT NULL,
+
+	created_at timestamp with time zone NOT NULL,
+
+	CONSTRAINT ocp__core_action__uniq__intent__and__action_id UNIQUE (intent, action_id)
+);
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"os"
 	"testing"
@@ -11,45 +12,12 @@ import (
 	"github.com/code-payments/ocp-server/ocp/data/action"
 	"github.com/code-payments/ocp-server/ocp/data/action/tests"
 
+	"github.com/code-payments/ocp-server/database/postgres/migration"
 	postgrestest "github.com/code-payments/ocp-server/database/postgres/test"
 
 	_ "github.com/jackc/pgx/v4/stdlib"
 )
 
-const (
-	// Used for testing ONLY, the table and migrations are external to this repository
-	tableCreate = `
-		CREATE TABLE ocp__core_action(
-			id SERIAL NOT NULL PRIMARY KEY,
-
-			intent TEXT NOT NULL,
-			intent_type INTEGER NOT NULL,
-
-			action_id INTEGER NOT NULL,
-			action_type INTEGER NOT NULL,
-
-			source TEXT NOT NULL,
-			destination TEXT NULL,
-			quantity INTEGER NULL,
-
-			fee_type INTEGER NULL,
-
-			state INTEGER NOT NULL,
-
-			version INTEGER NOT NULL,
-
-			created_at timestamp with time zone NOT NULL, 
-
-			CONSTRAINT ocp__core_action__uniq__intent__and__action_id UNIQUE (intent, action_id)
-		);
-	`
-
-	// Used for testing ONLY, the table and migrations are external to this repository
-	tableDestroy = `
-		DROP TABLE ocp__core_action;
-	`
-)
-
 var (
 	testStore action.Store
 	teardown  func()
@@ -102,7 +70,13 @@ func TestActionPostgresStore(t *testing.T) {
 }
 
 func createTestTables(log *zap.Logger, db *sql.DB) error {
-	_, err := db.Exec(tableCreate)
+	migrations, err := Migrations()
+	if err != nil {
+		log.With(zap.Error(err)).Error("could not load migrations")
+		return err
+	}
+
+	err = migration.Up(context.Background(), db, migrations)
 	if err != nil {
 		log.With(zap.Error(err)).Error("could not create test tables")
 		return err
@@ -111,7 +85,13 @@ func createTestTables(log *zap.Logger, db *sql.DB) error {
 }
 
 func resetTestTables(log *zap.Logger, db *sql.DB) error {
-	_, err := db.Exec(tableDestroy)
+	migrations, err := Migrations()
+	if err != nil {
+		log.With(zap.Error(err)).Error("could not load migrations")
+		return err
+	}
+
+	err = migration.Down(context.Background(), db, migrations)
 	if err != nil {
 		log.With(zap.Error(err)).Error("could not drop test tables")
 		return err
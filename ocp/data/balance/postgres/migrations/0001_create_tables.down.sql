@@ -0,0 +1,3 @@
+DROP TABLE ocp__core_cachedbalanceversion;
+DROP TABLE ocp__core_opencloselocks;
+DROP TABLE ocp__core_externalbalancecheckpoint;
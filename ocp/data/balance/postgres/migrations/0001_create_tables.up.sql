@@ -0,0 +1,29 @@
+CREATE TABLE ocp__core_cachedbalanceversion (
+	id SERIAL NOT NULL PRIMARY KEY,
+
+	token_account TEXT NOT NULL,
+	version INTEGER NOT NULL,
+
+	CONSTRAINT ocp__core_cachedbalanceversion__unique__token_account UNIQUE (token_account)
+);
+
+CREATE TABLE ocp__core_opencloselocks (
+	id SERIAL NOT NULL PRIMARY KEY,
+
+	token_account TEXT NOT NULL,
+	is_open BOOL NOT NULL,
+
+	CONSTRAINT ocp__core_opencloselocks__unique__token_account UNIQUE (token_account)
+);
+
+CREATE TABLE ocp__core_externalbalancecheckpoint (
+	id SERIAL NOT NULL PRIMARY KEY,
+
+	token_account TEXT NOT NULL,
+	quarks INTEGER NOT NULL,
+	slot_checkpoint INTEGER NOT NULL,
+
+	last_updated_at TIMESTAMP WITH TIME ZONE,
+
+	CONSTRAINT ocp__core_balanceexternalcheckpoint__uniq__token_account UNIQUE (token_account)
+);
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"os"
 	"testing"
@@ -11,6 +12,7 @@ import (
 	"github.com/code-payments/ocp-server/ocp/data/balance"
 	"github.com/code-payments/ocp-server/ocp/data/balance/tests"
 
+	"github.com/code-payments/ocp-server/database/postgres/migration"
 	postgrestest "github.com/code-payments/ocp-server/database/postgres/test"
 
 	_ "github.com/jackc/pgx/v4/stdlib"
@@ -21,48 +23,6 @@ var (
 	teardown  func()
 )
 
-const (
-	// Used for testing ONLY, the table and migrations are external to this repository
-	tableCreate = `
-	CREATE TABLE ocp__core_cachedbalanceversion (
-		id SERIAL NOT NULL PRIMARY KEY,
-
-		token_account TEXT NOT NULL,
-		version INTEGER NOT NULL,
-
-		CONSTRAINT ocp__core_cachedbalanceversion__unique__token_account UNIQUE (token_account)
-	);
-
-	CREATE TABLE ocp__core_opencloselocks (
-		id SERIAL NOT NULL PRIMARY KEY,
-
-		token_account TEXT NOT NULL,
-		is_open BOOL NOT NULL,
-
-		CONSTRAINT ocp__core_opencloselocks__unique__token_account UNIQUE (token_account)
-	);
-
-	CREATE TABLE ocp__core_externalbalancecheckpoint (
-		id SERIAL NOT NULL PRIMARY KEY,
-
-		token_account TEXT NOT NULL,
-		quarks INTEGER NOT NULL,
-		slot_checkpoint INTEGER NOT NULL,
-
-		last_updated_at TIMESTAMP WITH TIME ZONE,
-
-		CONSTRAINT ocp__core_balanceexternalcheckpoint__uniq__token_account UNIQUE (token_account)
-	);
-	`
-
-	// Used for testing ONLY, the table and migrations are external to this repository
-	tableDestroy = `
-		DROP TABLE ocp__core_cachedbalanceversion;
-		DROP TABLE ocp__core_opencloselocks;
-		DROP TABLE ocp__core_externalbalancecheckpoint;
-	`
-)
-
 func TestMain(m *testing.M) {
 	log := zap.Must(zap.NewDevelopment())
 
@@ -110,7 +70,13 @@ func TestBalancePostgresStore(t *testing.T) {
 }
 
 func createTestTables(log *zap.Logger, db *sql.DB) error {
-	_, err := db.Exec(tableCreate)
+	migrations, err := Migrations()
+	if err != nil {
+		log.With(zap.Error(err)).Error("could not load migrations")
+		return err
+	}
+
+	err = migration.Up(context.Background(), db, migrations)
 	if err != nil {
 		log.With(zap.Error(err)).Error("could not create test tables")
 		return err
@@ -119,7 +85,13 @@ func createTestTables(log *zap.Logger, db *sql.DB) error {
 }
 
 func resetTestTables(log *zap.Logger, db *sql.DB) error {
-	_, err := db.Exec(tableDestroy)
+	migrations, err := Migrations()
+	if err != nil {
+		log.With(zap.Error(err)).Error("could not load migrations")
+		return err
+	}
+
+	err = migration.Down(context.Background(), db, migrations)
 	if err != nil {
 		log.With(zap.Error(err)).Error("could not drop test tables")
 		return err
@@ -0,0 +1,161 @@
+package batch
+
+import (
+	"errors"
+	"time"
+)
+
+type State uint8
+
+const (
+	StateUnknown   State = iota
+	StatePending         // submitted to the solana network
+	StateConfirmed       // tx confirmed
+	StateFailed          // tx failed
+)
+
+// Record links a set of fulfillments whose virtual instructions have been
+// packed into a single submitted Solana transaction.
+type Record struct {
+	Id uint64
+
+	Vm string
+
+	// Fulfillments are the IDs of the packed fulfillments, in the order their
+	// virtual instructions appear in the transaction.
+	Fulfillments []uint64
+
+	Signature string
+	Nonce     string
+	Blockhash string
+	Data      []byte
+
+	State State
+
+	Version uint64
+
+	CreatedAt time.Time
+}
+
+func (r *Record) Validate() error {
+	if len(r.Vm) == 0 {
+		return errors.New("vm is required")
+	}
+
+	if len(r.Fulfillments) < 2 {
+		return errors.New("at least two fulfillments are required")
+	}
+
+	seen := make(map[uint64]struct{})
+	for _, id := range r.Fulfillments {
+		if id == 0 {
+			return errors.New("fulfillment id is required")
+		}
+
+		if _, ok := seen[id]; ok {
+			return errors.New("duplicate fulfillment id")
+		}
+		seen[id] = struct{}{}
+	}
+
+	if len(r.Signature) == 0 {
+		return errors.New("signature is required")
+	}
+
+	if len(r.Nonce) == 0 {
+		return errors.New("nonce is required")
+	}
+
+	if len(r.Blockhash) == 0 {
+		return errors.New("blockhash is required")
+	}
+
+	if len(r.Data) == 0 && !r.State.IsTerminal() {
+		return errors.New("data is required for non-terminal batches")
+	}
+
+	return nil
+}
+
+func (r *Record) Clone() Record {
+	var data []byte
+	if r.Data != nil {
+		data = make([]byte, len(r.Data))
+		copy(data, r.Data)
+	}
+
+	fulfillments := make([]uint64, len(r.Fulfillments))
+	copy(fulfillments, r.Fulfillments)
+
+	return Record{
+		Id: r.Id,
+
+		Vm: r.Vm,
+
+		Fulfillments: fulfillments,
+
+		Signature: r.Signature,
+		Nonce:     r.Nonce,
+		Blockhash: r.Blockhash,
+		Data:      data,
+
+		State: r.State,
+
+		Version: r.Version,
+
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+func (r *Record) CopyTo(dst *Record) {
+	dst.Id = r.Id
+
+	dst.Vm = r.Vm
+
+	dst.Fulfillments = make([]uint64, len(r.Fulfillments))
+	copy(dst.Fulfillments, r.Fulfillments)
+
+	dst.Signature = r.Signature
+	dst.Nonce = r.Nonce
+	dst.Blockhash = r.Blockhash
+	dst.Data = r.Data
+
+	dst.State = r.State
+
+	dst.Version = r.Version
+
+	dst.CreatedAt = r.CreatedAt
+}
+
+// Contains returns whether the fulfillment is packed into this batch
+func (r *Record) Contains(fulfillmentId uint64) bool {
+	for _, id := range r.Fulfillments {
+		if id == fulfillmentId {
+			return true
+		}
+	}
+	return false
+}
+
+func (s State) IsTerminal() bool {
+	switch s {
+	case StateConfirmed, StateFailed:
+		return true
+	}
+	return false
+}
+
+func (s State) String() string {
+	switch s {
+	case StateUnknown:
+		return "unknown"
+	case StatePending:
+		return "pending"
+	case StateConfirmed:
+		return "confirmed"
+	case StateFailed:
+		return "failed"
+	}
+
+	return "unknown"
+}
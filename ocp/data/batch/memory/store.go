@@ -0,0 +1,235 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/code-payments/ocp-server/database/query"
+	"github.com/code-payments/ocp-server/ocp/data/batch"
+)
+
+type ById []*batch.Record
+
+func (a ById) Len() int           { return len(a) }
+func (a ById) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a ById) Less(i, j int) bool { return a[i].Id < a[j].Id }
+
+type store struct {
+	mu      sync.RWMutex
+	records []*batch.Record
+	last    uint64
+}
+
+func New() batch.Store {
+	return &store{}
+}
+
+func (s *store) Put(_ context.Context, data *batch.Record) error {
+	if err := data.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if data.Id != 0 || s.findBySignature(data.Signature) != nil {
+		return batch.ErrExists
+	}
+	for _, fulfillmentId := range data.Fulfillments {
+		if s.findByFulfillment(fulfillmentId) != nil {
+			return batch.ErrExists
+		}
+	}
+
+	s.last++
+	data.Id = s.last
+	if data.CreatedAt.IsZero() {
+		data.CreatedAt = time.Now()
+	}
+	data.Version++
+
+	c := data.Clone()
+	s.records = append(s.records, &c)
+
+	return nil
+}
+
+func (s *store) Update(_ context.Context, data *batch.Record) error {
+	if err := data.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item := s.findById(data.Id)
+	if item == nil {
+		return batch.ErrNotFound
+	}
+
+	if item.Version != data.Version {
+		return batch.ErrStaleVersion
+	}
+
+	data.Version++
+
+	item.Data = data.Data
+	item.State = data.State
+	item.Version = data.Version
+
+	item.CopyTo(data)
+
+	return nil
+}
+
+func (s *store) GetById(_ context.Context, id uint64) (*batch.Record, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	item := s.findById(id)
+	if item == nil {
+		return nil, batch.ErrNotFound
+	}
+
+	cloned := item.Clone()
+	return &cloned, nil
+}
+
+func (s *store) GetBySignature(_ context.Context, signature string) (*batch.Record, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	item := s.findBySignature(signature)
+	if item == nil {
+		return nil, batch.ErrNotFound
+	}
+
+	cloned := item.Clone()
+	return &cloned, nil
+}
+
+func (s *store) GetByFulfillment(_ context.Context, fulfillmentId uint64) (*batch.Record, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	item := s.findByFulfillment(fulfillmentId)
+	if item == nil {
+		return nil, batch.ErrNotFound
+	}
+
+	cloned := item.Clone()
+	return &cloned, nil
+}
+
+func (s *store) GetAllByState(_ context.Context, state batch.State, cursor query.Cursor, limit uint64, direction query.Ordering) ([]*batch.Record, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if items := s.findByState(state); len(items) > 0 {
+		res := s.filter(items, cursor, limit, direction)
+
+		if len(res) == 0 {
+			return nil, batch.ErrNotFound
+		}
+
+		return cloneRecords(res), nil
+	}
+
+	return nil, batch.ErrNotFound
+}
+
+func (s *store) CountByState(_ context.Context, state batch.State) (uint64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	items := s.findByState(state)
+	return uint64(len(items)), nil
+}
+
+func (s *store) findById(id uint64) *batch.Record {
+	for _, item := range s.records {
+		if item.Id == id {
+			return item
+		}
+	}
+	return nil
+}
+
+func (s *store) findBySignature(signature string) *batch.Record {
+	for _, item := range s.records {
+		if item.Signature == signature {
+			return item
+		}
+	}
+	return nil
+}
+
+func (s *store) findByFulfillment(fulfillmentId uint64) *batch.Record {
+	for _, item := range s.records {
+		if item.Contains(fulfillmentId) {
+			return item
+		}
+	}
+	return nil
+}
+
+func (s *store) findByState(state batch.State) []*batch.Record {
+	var res []*batch.Record
+	for _, item := range s.records {
+		if item.State == state {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+func (s *store) filter(items []*batch.Record, cursor query.Cursor, limit uint64, direction query.Ordering) []*batch.Record {
+	var start uint64
+
+	start = 0
+	if direction == query.Descending {
+		start = s.last + 1
+	}
+	if len(cursor) > 0 {
+		start = cursor.ToUint64()
+	}
+
+	var res []*batch.Record
+	for _, item := range items {
+		if item.Id > start && direction == query.Ascending {
+			res = append(res, item)
+		}
+		if item.Id < start && direction == query.Descending {
+			res = append(res, item)
+		}
+	}
+
+	if direction == query.Descending {
+		sort.Sort(sort.Reverse(ById(res)))
+	}
+
+	if len(res) >= int(limit) {
+		return res[:limit]
+	}
+
+	return res
+}
+
+func cloneRecords(items []*batch.Record) []*batch.Record {
+	var res []*batch.Record
+	for _, item := range items {
+		cloned := item.Clone()
+		res = append(res, &cloned)
+	}
+	return res
+}
+
+func (s *store) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records = nil
+	s.last = 0
+}
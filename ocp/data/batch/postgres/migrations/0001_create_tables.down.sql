@@ -0,0 +1,2 @@
+DROP TABLE ocp__core_fulfillmentbatchentry;
+DROP TABLE ocp__core_fulfillmentbatch;
@@ -0,0 +1,23 @@
+CREATE TABLE ocp__core_fulfillmentbatch(
+	id SERIAL NOT NULL PRIMARY KEY,
+
+	vm TEXT NOT NULL,
+
+	signature TEXT NOT NULL UNIQUE,
+	nonce TEXT NOT NULL,
+	blockhash TEXT NOT NULL,
+	data BYTEA,
+
+	state INTEGER NOT NULL,
+	version INTEGER NOT NULL,
+
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE TABLE ocp__core_fulfillmentbatchentry(
+	batch_id INTEGER NOT NULL REFERENCES ocp__core_fulfillmentbatch(id),
+	fulfillment_id INTEGER NOT NULL UNIQUE,
+	batch_index INTEGER NOT NULL,
+
+	UNIQUE(batch_id, batch_index)
+);
@@ -0,0 +1,268 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	pgutil "github.com/code-payments/ocp-server/database/postgres"
+	q "github.com/code-payments/ocp-server/database/query"
+	"github.com/code-payments/ocp-server/ocp/data/batch"
+)
+
+const (
+	batchTableName = "ocp__core_fulfillmentbatch"
+	entryTableName = "ocp__core_fulfillmentbatchentry"
+)
+
+type batchModel struct {
+	Id        sql.NullInt64 `db:"id"`
+	Vm        string        `db:"vm"`
+	Signature string        `db:"signature"`
+	Nonce     string        `db:"nonce"`
+	Blockhash string        `db:"blockhash"`
+	Data      []byte        `db:"data"`
+	State     uint8         `db:"state"`
+	Version   uint64        `db:"version"`
+	CreatedAt time.Time     `db:"created_at"`
+
+	Fulfillments []uint64 `db:"-"`
+}
+
+type entryModel struct {
+	BatchId       uint64 `db:"batch_id"`
+	FulfillmentId uint64 `db:"fulfillment_id"`
+	BatchIndex    uint32 `db:"batch_index"`
+}
+
+func toModel(obj *batch.Record) (*batchModel, error) {
+	if err := obj.Validate(); err != nil {
+		return nil, err
+	}
+
+	if obj.CreatedAt.IsZero() {
+		obj.CreatedAt = time.Now().UTC()
+	}
+
+	fulfillments := make([]uint64, len(obj.Fulfillments))
+	copy(fulfillments, obj.Fulfillments)
+
+	return &batchModel{
+		Id:           sql.NullInt64{Int64: int64(obj.Id), Valid: obj.Id > 0},
+		Vm:           obj.Vm,
+		Signature:    obj.Signature,
+		Nonce:        obj.Nonce,
+		Blockhash:    obj.Blockhash,
+		Data:         obj.Data,
+		State:        uint8(obj.State),
+		Version:      obj.Version,
+		CreatedAt:    obj.CreatedAt,
+		Fulfillments: fulfillments,
+	}, nil
+}
+
+func fromModel(m *batchModel) *batch.Record {
+	fulfillments := make([]uint64, len(m.Fulfillments))
+	copy(fulfillments, m.Fulfillments)
+
+	return &batch.Record{
+		Id:           uint64(m.Id.Int64),
+		Vm:           m.Vm,
+		Fulfillments: fulfillments,
+		Signature:    m.Signature,
+		Nonce:        m.Nonce,
+		Blockhash:    m.Blockhash,
+		Data:         m.Data,
+		State:        batch.State(m.State),
+		Version:      m.Version,
+		CreatedAt:    m.CreatedAt,
+	}
+}
+
+func (m *batchModel) dbPut(ctx context.Context, db *sqlx.DB) error {
+	return pgutil.ExecuteInTx(ctx, db, sql.LevelDefault, func(tx *sqlx.Tx) error {
+		if m.Id.Valid {
+			return batch.ErrExists
+		}
+
+		query := `INSERT INTO ` + batchTableName + `
+			(vm, signature, nonce, blockhash, data, state, version, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7 + 1, $8)
+			RETURNING
+				id, vm, signature, nonce, blockhash, data, state, version, created_at`
+
+		err := tx.QueryRowxContext(
+			ctx,
+			query,
+			m.Vm,
+			m.Signature,
+			m.Nonce,
+			m.Blockhash,
+			m.Data,
+			m.State,
+			m.Version,
+			m.CreatedAt,
+		).StructScan(m)
+		if err != nil {
+			return pgutil.CheckUniqueViolation(err, batch.ErrExists)
+		}
+
+		query = `INSERT INTO ` + entryTableName + `
+			(batch_id, fulfillment_id, batch_index)
+			VALUES ($1, $2, $3)`
+
+		for i, fulfillmentId := range m.Fulfillments {
+			_, err = tx.ExecContext(ctx, query, m.Id.Int64, fulfillmentId, i)
+			if err != nil {
+				return pgutil.CheckUniqueViolation(err, batch.ErrExists)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (m *batchModel) dbUpdate(ctx context.Context, db *sqlx.DB) error {
+	return pgutil.ExecuteInTx(ctx, db, sql.LevelDefault, func(tx *sqlx.Tx) error {
+		if !m.Id.Valid {
+			return batch.ErrNotFound
+		}
+
+		query := `UPDATE ` + batchTableName + `
+			SET data = $2, state = $3, version = version + 1
+			WHERE id = $1 AND version = $4
+			RETURNING
+				id, vm, signature, nonce, blockhash, data, state, version, created_at`
+
+		err := tx.QueryRowxContext(
+			ctx,
+			query,
+			m.Id,
+			m.Data,
+			m.State,
+			m.Version,
+		).StructScan(m)
+		if err != nil {
+			return pgutil.CheckNoRows(err, batch.ErrStaleVersion)
+		}
+		return nil
+	})
+}
+
+func dbGetById(ctx context.Context, db *sqlx.DB, id uint64) (*batchModel, error) {
+	res := &batchModel{}
+
+	query := `SELECT id, vm, signature, nonce, blockhash, data, state, version, created_at
+		FROM ` + batchTableName + `
+		WHERE id = $1
+		LIMIT 1`
+
+	err := db.GetContext(ctx, res, query, id)
+	if err != nil {
+		return nil, pgutil.CheckNoRows(err, batch.ErrNotFound)
+	}
+
+	if err := res.dbLoadFulfillments(ctx, db); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func dbGetBySignature(ctx context.Context, db *sqlx.DB, signature string) (*batchModel, error) {
+	res := &batchModel{}
+
+	query := `SELECT id, vm, signature, nonce, blockhash, data, state, version, created_at
+		FROM ` + batchTableName + `
+		WHERE signature = $1
+		LIMIT 1`
+
+	err := db.GetContext(ctx, res, query, signature)
+	if err != nil {
+		return nil, pgutil.CheckNoRows(err, batch.ErrNotFound)
+	}
+
+	if err := res.dbLoadFulfillments(ctx, db); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func dbGetByFulfillment(ctx context.Context, db *sqlx.DB, fulfillmentId uint64) (*batchModel, error) {
+	res := &batchModel{}
+
+	query := `SELECT b.id, b.vm, b.signature, b.nonce, b.blockhash, b.data, b.state, b.version, b.created_at
+		FROM ` + batchTableName + ` AS b
+		JOIN ` + entryTableName + ` AS e ON e.batch_id = b.id
+		WHERE e.fulfillment_id = $1
+		LIMIT 1`
+
+	err := db.GetContext(ctx, res, query, fulfillmentId)
+	if err != nil {
+		return nil, pgutil.CheckNoRows(err, batch.ErrNotFound)
+	}
+
+	if err := res.dbLoadFulfillments(ctx, db); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func dbGetAllByState(ctx context.Context, db *sqlx.DB, state batch.State, cursor q.Cursor, limit uint64, direction q.Ordering) ([]*batchModel, error) {
+	res := []*batchModel{}
+
+	query := `SELECT
+		id, vm, signature, nonce, blockhash, data, state, version, created_at
+		FROM ` + batchTableName + `
+		WHERE state = $1`
+
+	opts := []interface{}{state}
+	query, opts = q.PaginateQuery(query, opts, cursor, limit, direction)
+
+	err := db.SelectContext(ctx, &res, query, opts...)
+	if err != nil {
+		return nil, pgutil.CheckNoRows(err, batch.ErrNotFound)
+	}
+
+	if len(res) == 0 {
+		return nil, batch.ErrNotFound
+	}
+
+	for _, m := range res {
+		if err := m.dbLoadFulfillments(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func dbCountByState(ctx context.Context, db *sqlx.DB, state batch.State) (uint64, error) {
+	var res uint64
+	query := `SELECT COUNT(*) FROM ` + batchTableName + ` WHERE state = $1`
+	err := db.GetContext(ctx, &res, query, state)
+	if err != nil {
+		return 0, err
+	}
+	return res, nil
+}
+
+func (m *batchModel) dbLoadFulfillments(ctx context.Context, db *sqlx.DB) error {
+	entries := []*entryModel{}
+
+	query := `SELECT batch_id, fulfillment_id, batch_index
+		FROM ` + entryTableName + `
+		WHERE batch_id = $1
+		ORDER BY batch_index ASC`
+
+	err := db.SelectContext(ctx, &entries, query, m.Id.Int64)
+	if err != nil && !pgutil.IsNoRows(err) {
+		return err
+	}
+
+	m.Fulfillments = make([]uint64, len(entries))
+	for i, entry := range entries {
+		m.Fulfillments[i] = entry.FulfillmentId
+	}
+	return nil
+}
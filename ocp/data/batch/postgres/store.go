@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/code-payments/ocp-server/database/query"
+	"github.com/code-payments/ocp-server/ocp/data/batch"
+)
+
+type store struct {
+	db *sqlx.DB
+}
+
+func New(db *sql.DB) batch.Store {
+	return &store{
+		db: sqlx.NewDb(db, "pgx"),
+	}
+}
+
+func (s *store) Put(ctx context.Context, record *batch.Record) error {
+	obj, err := toModel(record)
+	if err != nil {
+		return err
+	}
+
+	err = obj.dbPut(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	res := fromModel(obj)
+	res.CopyTo(record)
+
+	return nil
+}
+
+func (s *store) Update(ctx context.Context, record *batch.Record) error {
+	obj, err := toModel(record)
+	if err != nil {
+		return err
+	}
+
+	err = obj.dbUpdate(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	res := fromModel(obj)
+	res.CopyTo(record)
+
+	return nil
+}
+
+func (s *store) GetById(ctx context.Context, id uint64) (*batch.Record, error) {
+	obj, err := dbGetById(ctx, s.db, id)
+	if err != nil {
+		return nil, err
+	}
+	return fromModel(obj), nil
+}
+
+func (s *store) GetBySignature(ctx context.Context, signature string) (*batch.Record, error) {
+	obj, err := dbGetBySignature(ctx, s.db, signature)
+	if err != nil {
+		return nil, err
+	}
+	return fromModel(obj), nil
+}
+
+func (s *store) GetByFulfillment(ctx context.Context, fulfillmentId uint64) (*batch.Record, error) {
+	obj, err := dbGetByFulfillment(ctx, s.db, fulfillmentId)
+	if err != nil {
+		return nil, err
+	}
+	return fromModel(obj), nil
+}
+
+func (s *store) GetAllByState(ctx context.Context, state batch.State, cursor query.Cursor, limit uint64, direction query.Ordering) ([]*batch.Record, error) {
+	models, err := dbGetAllByState(ctx, s.db, state, cursor, limit, direction)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*batch.Record, len(models))
+	for i, model := range models {
+		res[i] = fromModel(model)
+	}
+	return res, nil
+}
+
+func (s *store) CountByState(ctx context.Context, state batch.State) (uint64, error) {
+	return dbCountByState(ctx, s.db, state)
+}
@@ -0,0 +1,40 @@
+package batch
+
+import (
+	"context"
+	"errors"
+
+	"github.com/code-payments/ocp-server/database/query"
+)
+
+var (
+	ErrNotFound     = errors.New("batch not found")
+	ErrExists       = errors.New("batch or batched fulfillment already exists")
+	ErrStaleVersion = errors.New("batch version is stale")
+)
+
+type Store interface {
+	// Put creates a new batch, along with the links to each of its fulfillments.
+	//
+	// Returns ErrExists if the signature is already used, or if any fulfillment
+	// is already linked to another batch.
+	Put(ctx context.Context, record *Record) error
+
+	// Update updates the state and transaction data of an existing batch
+	Update(ctx context.Context, record *Record) error
+
+	// GetById gets a batch by its ID
+	GetById(ctx context.Context, id uint64) (*Record, error)
+
+	// GetBySignature gets a batch by its Solana transaction signature
+	GetBySignature(ctx context.Context, signature string) (*Record, error)
+
+	// GetByFulfillment gets the batch a fulfillment was packed into
+	GetByFulfillment(ctx context.Context, fulfillmentId uint64) (*Record, error)
+
+	// GetAllByState gets all batches in the provided state
+	GetAllByState(ctx context.Context, state State, cursor query.Cursor, limit uint64, direction query.Ordering) ([]*Record, error)
+
+	// CountByState returns the count of batches in the provided state
+	CountByState(ctx context.Context, state State) (uint64, error)
+}
@@ -0,0 +1,291 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/code-payments/ocp-server/database/query"
+	"github.com/code-payments/ocp-server/ocp/data/batch"
+)
+
+func RunTests(t *testing.T, s batch.Store, teardown func()) {
+	for _, tf := range []func(t *testing.T, s batch.Store){
+		testRoundTrip,
+		testPutDuplicates,
+		testUpdateHappyPath,
+		testUpdateStaleRecord,
+		testGetAllByState,
+	} {
+		tf(t, s)
+		teardown()
+	}
+}
+
+func testRoundTrip(t *testing.T, s batch.Store) {
+	t.Run("testRoundTrip", func(t *testing.T) {
+		ctx := context.Background()
+
+		_, err := s.GetById(ctx, 1)
+		assert.Equal(t, batch.ErrNotFound, err)
+
+		_, err = s.GetBySignature(ctx, "test_signature")
+		assert.Equal(t, batch.ErrNotFound, err)
+
+		for _, fulfillmentId := range []uint64{3, 1, 2} {
+			_, err = s.GetByFulfillment(ctx, fulfillmentId)
+			assert.Equal(t, batch.ErrNotFound, err)
+		}
+
+		count, err := s.CountByState(ctx, batch.StatePending)
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, count)
+
+		expected := &batch.Record{
+			Vm: "test_vm",
+
+			Fulfillments: []uint64{3, 1, 2},
+
+			Signature: "test_signature",
+			Nonce:     "test_nonce",
+			Blockhash: "test_blockhash",
+			Data:      []byte("test_data"),
+
+			State: batch.StatePending,
+
+			CreatedAt: time.Now(),
+		}
+
+		require.NoError(t, s.Put(ctx, expected))
+		assert.EqualValues(t, 1, expected.Id)
+		assert.EqualValues(t, 1, expected.Version)
+
+		cloned := expected.Clone()
+
+		actual, err := s.GetById(ctx, expected.Id)
+		require.NoError(t, err)
+		assertEquivalentRecords(t, &cloned, actual)
+
+		actual, err = s.GetBySignature(ctx, "test_signature")
+		require.NoError(t, err)
+		assertEquivalentRecords(t, &cloned, actual)
+
+		for _, fulfillmentId := range []uint64{3, 1, 2} {
+			actual, err = s.GetByFulfillment(ctx, fulfillmentId)
+			require.NoError(t, err)
+			assertEquivalentRecords(t, &cloned, actual)
+		}
+
+		_, err = s.GetByFulfillment(ctx, 4)
+		assert.Equal(t, batch.ErrNotFound, err)
+
+		count, err = s.CountByState(ctx, batch.StatePending)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, count)
+	})
+}
+
+func testPutDuplicates(t *testing.T, s batch.Store) {
+	t.Run("testPutDuplicates", func(t *testing.T) {
+		ctx := context.Background()
+
+		record := &batch.Record{
+			Vm: "test_vm",
+
+			Fulfillments: []uint64{1, 2},
+
+			Signature: "test_signature1",
+			Nonce:     "test_nonce1",
+			Blockhash: "test_blockhash",
+			Data:      []byte("test_data"),
+
+			State: batch.StatePending,
+		}
+		require.NoError(t, s.Put(ctx, record))
+
+		assert.Equal(t, batch.ErrExists, s.Put(ctx, record))
+
+		duplicateSignature := &batch.Record{
+			Vm: "test_vm",
+
+			Fulfillments: []uint64{3, 4},
+
+			Signature: "test_signature1",
+			Nonce:     "test_nonce2",
+			Blockhash: "test_blockhash",
+			Data:      []byte("test_data"),
+
+			State: batch.StatePending,
+		}
+		assert.Equal(t, batch.ErrExists, s.Put(ctx, duplicateSignature))
+
+		duplicateFulfillment := &batch.Record{
+			Vm: "test_vm",
+
+			Fulfillments: []uint64{3, 2},
+
+			Signature: "test_signature2",
+			Nonce:     "test_nonce2",
+			Blockhash: "test_blockhash",
+			Data:      []byte("test_data"),
+
+			State: batch.StatePending,
+		}
+		assert.Equal(t, batch.ErrExists, s.Put(ctx, duplicateFulfillment))
+
+		_, err := s.GetByFulfillment(ctx, 3)
+		assert.Equal(t, batch.ErrNotFound, err)
+
+		_, err = s.GetBySignature(ctx, "test_signature2")
+		assert.Equal(t, batch.ErrNotFound, err)
+	})
+}
+
+func testUpdateHappyPath(t *testing.T, s batch.Store) {
+	t.Run("testUpdateHappyPath", func(t *testing.T) {
+		ctx := context.Background()
+
+		expected := &batch.Record{
+			Vm: "test_vm",
+
+			Fulfillments: []uint64{1, 2},
+
+			Signature: "test_signature",
+			Nonce:     "test_nonce",
+			Blockhash: "test_blockhash",
+			Data:      []byte("test_data"),
+
+			State: batch.StatePending,
+		}
+		assert.Equal(t, batch.ErrNotFound, s.Update(ctx, expected))
+
+		require.NoError(t, s.Put(ctx, expected))
+		assert.EqualValues(t, 1, expected.Version)
+
+		expected.State = batch.StateConfirmed
+		expected.Data = []byte("test_data_updated")
+
+		require.NoError(t, s.Update(ctx, expected))
+		assert.EqualValues(t, 1, expected.Id)
+		assert.EqualValues(t, 2, expected.Version)
+		assert.Equal(t, []uint64{1, 2}, expected.Fulfillments)
+
+		actual, err := s.GetById(ctx, expected.Id)
+		require.NoError(t, err)
+		assertEquivalentRecords(t, expected, actual)
+
+		count, err := s.CountByState(ctx, batch.StatePending)
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, count)
+
+		count, err = s.CountByState(ctx, batch.StateConfirmed)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, count)
+	})
+}
+
+func testUpdateStaleRecord(t *testing.T, s batch.Store) {
+	t.Run("testUpdateStaleRecord", func(t *testing.T) {
+		ctx := context.Background()
+
+		expected := &batch.Record{
+			Vm: "test_vm",
+
+			Fulfillments: []uint64{1, 2},
+
+			Signature: "test_signature",
+			Nonce:     "test_nonce",
+			Blockhash: "test_blockhash",
+			Data:      []byte("test_data"),
+
+			State: batch.StatePending,
+		}
+		require.NoError(t, s.Put(ctx, expected))
+
+		stale := expected.Clone()
+
+		expected.State = batch.StateFailed
+		require.NoError(t, s.Update(ctx, expected))
+
+		stale.State = batch.StateConfirmed
+		assert.Equal(t, batch.ErrStaleVersion, s.Update(ctx, &stale))
+
+		actual, err := s.GetById(ctx, expected.Id)
+		require.NoError(t, err)
+		assert.Equal(t, batch.StateFailed, actual.State)
+		assert.EqualValues(t, 2, actual.Version)
+	})
+}
+
+func testGetAllByState(t *testing.T, s batch.Store) {
+	t.Run("testGetAllByState", func(t *testing.T) {
+		ctx := context.Background()
+
+		_, err := s.GetAllByState(ctx, batch.StatePending, query.EmptyCursor, 1, query.Ascending)
+		assert.Equal(t, batch.ErrNotFound, err)
+
+		var records []*batch.Record
+		for i := range 100 {
+			state := batch.StatePending
+			if i >= 50 {
+				state = batch.StateConfirmed
+			}
+
+			record := &batch.Record{
+				Vm: "test_vm",
+
+				Fulfillments: []uint64{uint64(2*i + 1), uint64(2*i + 2)},
+
+				Signature: fmt.Sprintf("test_signature_%d", i),
+				Nonce:     fmt.Sprintf("test_nonce_%d", i),
+				Blockhash: fmt.Sprintf("test_blockhash_%d", i),
+				Data:      []byte(fmt.Sprintf("test_data_%d", i)),
+
+				State: state,
+			}
+			require.NoError(t, s.Put(ctx, record))
+
+			records = append(records, record)
+		}
+
+		allActual, err := s.GetAllByState(ctx, batch.StatePending, query.EmptyCursor, 100, query.Ascending)
+		require.NoError(t, err)
+		require.Len(t, allActual, 50)
+		for i, actual := range allActual {
+			assertEquivalentRecords(t, records[i], actual)
+		}
+
+		allActual, err = s.GetAllByState(ctx, batch.StatePending, query.EmptyCursor, 10, query.Descending)
+		require.NoError(t, err)
+		require.Len(t, allActual, 10)
+		for i, actual := range allActual {
+			assertEquivalentRecords(t, records[50-i-1], actual)
+		}
+
+		allActual, err = s.GetAllByState(ctx, batch.StatePending, query.ToCursor(records[23].Id), 10, query.Ascending)
+		require.NoError(t, err)
+		require.Len(t, allActual, 10)
+		for i, actual := range allActual {
+			assertEquivalentRecords(t, records[23+i+1], actual)
+		}
+
+		_, err = s.GetAllByState(ctx, batch.StatePending, query.ToCursor(records[50].Id), 10, query.Ascending)
+		assert.Equal(t, batch.ErrNotFound, err)
+	})
+}
+
+func assertEquivalentRecords(t *testing.T, obj1, obj2 *batch.Record) {
+	assert.Equal(t, obj1.Id, obj2.Id)
+	assert.Equal(t, obj1.Vm, obj2.Vm)
+	assert.Equal(t, obj1.Fulfillments, obj2.Fulfillments)
+	assert.Equal(t, obj1.Signature, obj2.Signature)
+	assert.Equal(t, obj1.Nonce, obj2.Nonce)
+	assert.Equal(t, obj1.Blockhash, obj2.Blockhash)
+	assert.Equal(t, obj1.Data, obj2.Data)
+	assert.Equal(t, obj1.State, obj2.State)
+	assert.Equal(t, obj1.Version, obj2.Version)
+}
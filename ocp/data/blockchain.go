@@ -22,6 +22,7 @@ type BlockchainData interface {
 
 	GetBlockchainAccountInfo(ctx context.Context, account string, commitment solana.Commitment) (*solana.AccountInfo, error)
 	GetBlockchainAccountDataAfterBlock(ctx context.Context, account string, slot uint64) ([]byte, uint64, error)
+	GetBlockchainMultipleAccountInfos(ctx context.Context, accounts []string, commitment solana.Commitment) ([]*solana.AccountInfo, error)
 	GetBlockchainBalance(ctx context.Context, account string) (uint64, uint64, error)
 	GetBlockchainBlock(ctx context.Context, slot uint64) (*solana.Block, error)
 	GetBlockchainBlockSignatures(ctx context.Context, slot uint64) ([]string, error)
@@ -99,6 +100,25 @@ func (dp *BlockchainProvider) GetBlockchainAccountDataAfterBlock(ctx context.Con
 	}
 	return data, block, err
 }
+func (dp *BlockchainProvider) GetBlockchainMultipleAccountInfos(ctx context.Context, accounts []string, commitment solana.Commitment) ([]*solana.AccountInfo, error) {
+	tracer := metrics.TraceMethodCall(ctx, blockchainProviderMetricsName, "GetBlockchainMultipleAccountInfos")
+	defer tracer.End()
+
+	accountIds := make([]ed25519.PublicKey, len(accounts))
+	for i, account := range accounts {
+		accountId, err := base58.Decode(account)
+		if err != nil {
+			return nil, err
+		}
+		accountIds[i] = accountId
+	}
+
+	res, err := dp.sc.GetMultipleAccounts(accountIds, commitment)
+	if err != nil {
+		tracer.OnError(err)
+	}
+	return res, err
+}
 func (dp *BlockchainProvider) GetBlockchainTokenAccountInfo(ctx context.Context, account, mint string, commitment solana.Commitment) (*token.Account, error) {
 	tracer := metrics.TraceMethodCall(ctx, blockchainProviderMetricsName, "GetBlockchainTokenAccountInfo")
 	defer tracer.End()
@@ -1,6 +1,8 @@
 package data
 
 import (
+	"time"
+
 	"github.com/code-payments/ocp-server/config"
 	"github.com/code-payments/ocp-server/config/env"
 )
@@ -8,11 +10,45 @@ import (
 const (
 	FixerApiKeyConfigEnvName = "FIXER_API_KEY"
 	defaultFixerApiKey       = ""
+
+	CoreMintExchangeRateSourcesConfigEnvName = "EXCHANGE_RATE_CORE_MINT_SOURCES"
+	defaultCoreMintExchangeRateSources       = coinGeckoExchangeRateSourceName
+
+	UsdExchangeRateSourcesConfigEnvName = "EXCHANGE_RATE_USD_SOURCES"
+	defaultUsdExchangeRateSources       = fixerExchangeRateSourceName
+
+	ExchangeRateMinSourcesConfigEnvName = "EXCHANGE_RATE_MIN_SOURCES"
+	defaultExchangeRateMinSources       = 1
+
+	ExchangeRateMaxDeviationConfigEnvName = "EXCHANGE_RATE_MAX_DEVIATION"
+	defaultExchangeRateMaxDeviation       = 0.05
+
+	ExchangeRateMaxSourceAgeConfigEnvName = "EXCHANGE_RATE_MAX_SOURCE_AGE"
+	defaultExchangeRateMaxSourceAge       = 2 * time.Hour
+
+	ExchangeRateMaxStalenessConfigEnvName = "EXCHANGE_RATE_MAX_STALENESS"
+	defaultExchangeRateMaxStaleness       = time.Hour
+
+	ExchangeRateMaxStalenessOverridesConfigEnvName = "EXCHANGE_RATE_MAX_STALENESS_OVERRIDES"
+	defaultExchangeRateMaxStalenessOverrides       = ""
 )
 
 // todo: Add other data store configs here (eg. postgres, solana, etc).
 type conf struct {
 	fixerApiKey config.String
+
+	// Comma-separated exchange rate source names for each leg of the exchange
+	// rate computation
+	coreMintExchangeRateSources config.String
+	usdExchangeRateSources      config.String
+
+	// Aggregation and staleness guards for exchange rates across sources.
+	// Staleness overrides are comma-separated currency limits (eg. "ars=15m,ves=6h").
+	exchangeRateMinSources            config.Uint64
+	exchangeRateMaxDeviation          config.Float64
+	exchangeRateMaxSourceAge          config.Duration
+	exchangeRateMaxStaleness          config.Duration
+	exchangeRateMaxStalenessOverrides config.String
 }
 
 // ConfigProvider defines how config values are pulled
@@ -25,6 +61,15 @@ func WithEnvConfigs() ConfigProvider {
 	return func() *conf {
 		return &conf{
 			fixerApiKey: env.NewStringConfig(FixerApiKeyConfigEnvName, defaultFixerApiKey),
+
+			coreMintExchangeRateSources: env.NewStringConfig(CoreMintExchangeRateSourcesConfigEnvName, defaultCoreMintExchangeRateSources),
+			usdExchangeRateSources:      env.NewStringConfig(UsdExchangeRateSourcesConfigEnvName, defaultUsdExchangeRateSources),
+
+			exchangeRateMinSources:            env.NewUint64Config(ExchangeRateMinSourcesConfigEnvName, defaultExchangeRateMinSources),
+			exchangeRateMaxDeviation:          env.NewFloat64Config(ExchangeRateMaxDeviationConfigEnvName, defaultExchangeRateMaxDeviation),
+			exchangeRateMaxSourceAge:          env.NewDurationConfig(ExchangeRateMaxSourceAgeConfigEnvName, defaultExchangeRateMaxSourceAge),
+			exchangeRateMaxStaleness:          env.NewDurationConfig(ExchangeRateMaxStalenessConfigEnvName, defaultExchangeRateMaxStaleness),
+			exchangeRateMaxStalenessOverrides: env.NewStringConfig(ExchangeRateMaxStalenessOverridesConfigEnvName, defaultExchangeRateMaxStalenessOverrides),
 		}
 	}
 }
@@ -73,10 +73,11 @@ func (s *store) PutExchangeRates(ctx context.Context, data *currency.MultiRateRe
 
 	for symbol, item := range data.Rates {
 		s.exchangeRateRecords = append(s.exchangeRateRecords, &currency.ExchangeRateRecord{
-			Id:     s.lastExchangeRateIndex,
-			Rate:   item,
-			Time:   data.Time,
-			Symbol: symbol,
+			Id:        s.lastExchangeRateIndex,
+			Rate:      item,
+			Time:      data.Time,
+			Symbol:    symbol,
+			FreshTime: data.GetFreshTime(symbol),
 		})
 		s.lastExchangeRateIndex = s.lastExchangeRateIndex + 1
 	}
@@ -113,12 +114,21 @@ func (s *store) GetAllExchangeRates(ctx context.Context, t time.Time) (*currency
 	sort.Sort(RateByTime(s.exchangeRateRecords))
 
 	result := currency.MultiRateRecord{
-		Rates: make(map[string]float64),
+		Rates:      make(map[string]float64),
+		FreshTimes: make(map[string]time.Time),
 	}
 	for _, item := range s.exchangeRateRecords {
 		if item.Time.Unix() <= t.Unix() && item.Time.Format(dateFormat) == t.Format(dateFormat) {
+			// Records are sorted most recent first
+			if _, ok := result.Rates[item.Symbol]; ok {
+				continue
+			}
+			if result.Time.IsZero() {
+				result.Time = item.Time
+			}
+
 			result.Rates[item.Symbol] = item.Rate
-			result.Time = item.Time
+			result.FreshTimes[item.Symbol] = item.FreshTime
 		}
 	}
 
@@ -193,6 +203,21 @@ func (s *store) GetMetadata(ctx context.Context, mint string) (*currency.Metadat
 	return nil, currency.ErrNotFound
 }
 
+func (s *store) GetAllMetadata(ctx context.Context) ([]*currency.MetadataRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.metadataRecords) == 0 {
+		return nil, currency.ErrNotFound
+	}
+
+	res := make([]*currency.MetadataRecord, len(s.metadataRecords))
+	for i, item := range s.metadataRecords {
+		res[i] = item.Clone()
+	}
+	return res, nil
+}
+
 func (s *store) PutReserveRecord(ctx context.Context, data *currency.ReserveRecord) error {
 	if err := data.Validate(); err != nil {
 		return err
@@ -12,11 +12,28 @@ type ExchangeRateRecord struct {
 	Time   time.Time
 	Rate   float64
 	Symbol string
+
+	// FreshTime is when the rate was last returned by a provider. It's before
+	// Time when the rate was carried forward, and defaults to Time when unset.
+	FreshTime time.Time
 }
 
 type MultiRateRecord struct {
 	Time  time.Time
 	Rates map[string]float64
+
+	// FreshTimes is when each rate was last returned by a provider. Rates
+	// without an entry are fresh as of Time.
+	FreshTimes map[string]time.Time
+}
+
+// GetFreshTime returns when the rate for the provided symbol was last returned
+// by a provider
+func (r *MultiRateRecord) GetFreshTime(symbol string) time.Time {
+	if freshTime, ok := r.FreshTimes[symbol]; ok && !freshTime.IsZero() {
+		return freshTime
+	}
+	return r.Time
 }
 
 type MetadataRecord struct {
@@ -0,0 +1,17 @@
+package postgres
+
+import (
+	"embed"
+
+	"github.com/code-payments/ocp-server/database/postgres/migration"
+)
+
+const migrationStoreName = "currency"
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migrations returns the ordered schema migrations for the currency store
+func Migrations() ([]*migration.Migration, error) {
+	return migration.Load(migrationStoreName, migrationFiles, "migrations")
+}
@@ -0,0 +1,3 @@
+DROP TABLE ocp__core_exchangerate;
+DROP TABLE ocp__core_currencymetadata;
+DROP TABLE ocp__core_currencyreserve;
@@ -0,0 +1,56 @@
+CREATE TABLE ocp__core_exchangerate (
+	id serial NOT NULL PRIMARY KEY,
+
+	for_date VARCHAR(10) NOT NULL,
+	for_timestamp TIMESTAMP WITH TIME ZONE NOT NULL,
+	currency_code VARCHAR(3) NOT NULL,
+	currency_rate NUMERIC(18, 9) NOT NULL,
+
+	CONSTRAINT ocp__core_exchangerate__uniq__timestamp__and__code UNIQUE (for_timestamp, currency_code),
+	CONSTRAINT ocp__core_exchangerate__currency_code CHECK (currency_code::text ~ '^[a-z]{3}$')
+);
+CREATE TABLE ocp__core_currencymetadata (
+	id serial NOT NULL PRIMARY KEY,
+
+	name TEXT NOT NULL,
+	symbol TEXT NOT NULL,
+	description TEXT NOT NULL,
+	image_url TEXT NOT NULL,
+
+	seed TEXT UNIQUE NOT NULL,
+
+	authority TEXT NOT NULL,
+
+	mint TEXT UNIQUE NOT NULL,
+	mint_bump INTEGER NOT NULL,
+	decimals INTEGER NOT NULL,
+
+	currency_config TEXT UNIQUE NOT NULL,
+	currency_config_bump INTEGER NOT NULL,
+
+	liquidity_pool TEXT UNIQUE NOT NULL,
+	liquidity_pool_bump INTEGER NOT NULL,
+
+	vault_mint TEXT UNIQUE NOT NULL,
+	vault_mint_bump INTEGER NOT NULL,
+
+	vault_core TEXT UNIQUE NOT NULL,
+	vault_core_bump INTEGER NOT NULL,
+
+	sell_fee_bps INTEGER NOT NULL,
+
+	alt TEXT NOT NULL,
+
+	created_by TEXT NOT NULL,
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+CREATE TABLE ocp__core_currencyreserve (
+	id serial NOT NULL PRIMARY KEY,
+
+	for_date VARCHAR(10) NOT NULL,
+	for_timestamp TIMESTAMP WITH TIME ZONE NOT NULL,
+	mint TEXT NOT NULL,
+	supply_from_bonding BIGINT NOT NULL,
+
+	CONSTRAINT ocp__core_currencyreserve__uniq__timestamp__and__mint UNIQUE (for_timestamp, mint)
+);
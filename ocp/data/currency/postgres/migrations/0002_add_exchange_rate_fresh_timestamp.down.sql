@@ -0,0 +1,2 @@
+ALTER TABLE ocp__core_exchangerate
+	DROP COLUMN fresh_timestamp;
@@ -0,0 +1,7 @@
+ALTER TABLE ocp__core_exchangerate
+	ADD COLUMN fresh_timestamp TIMESTAMP WITH TIME ZONE;
+
+UPDATE ocp__core_exchangerate SET fresh_timestamp = for_timestamp;
+
+ALTER TABLE ocp__core_exchangerate
+	ALTER COLUMN fresh_timestamp SET NOT NULL;
@@ -22,29 +22,37 @@ const (
 )
 
 type exchangeRateModel struct {
-	Id           sql.NullInt64 `db:"id"`
-	ForDate      string        `db:"for_date"`
-	ForTimestamp time.Time     `db:"for_timestamp"`
-	CurrencyCode string        `db:"currency_code"`
-	CurrencyRate float64       `db:"currency_rate"`
+	Id             sql.NullInt64 `db:"id"`
+	ForDate        string        `db:"for_date"`
+	ForTimestamp   time.Time     `db:"for_timestamp"`
+	CurrencyCode   string        `db:"currency_code"`
+	CurrencyRate   float64       `db:"currency_rate"`
+	FreshTimestamp time.Time     `db:"fresh_timestamp"`
 }
 
 func toExchangeRateModel(obj *currency.ExchangeRateRecord) *exchangeRateModel {
+	freshTime := obj.FreshTime
+	if freshTime.IsZero() {
+		freshTime = obj.Time
+	}
+
 	return &exchangeRateModel{
-		Id:           sql.NullInt64{Int64: int64(obj.Id), Valid: obj.Id > 0},
-		ForDate:      obj.Time.UTC().Format(dateFormat),
-		ForTimestamp: obj.Time.UTC(),
-		CurrencyCode: obj.Symbol,
-		CurrencyRate: obj.Rate,
+		Id:             sql.NullInt64{Int64: int64(obj.Id), Valid: obj.Id > 0},
+		ForDate:        obj.Time.UTC().Format(dateFormat),
+		ForTimestamp:   obj.Time.UTC(),
+		CurrencyCode:   obj.Symbol,
+		CurrencyRate:   obj.Rate,
+		FreshTimestamp: freshTime.UTC(),
 	}
 }
 
 func fromExchangeRateModel(obj *exchangeRateModel) *currency.ExchangeRateRecord {
 	return &currency.ExchangeRateRecord{
-		Id:     uint64(obj.Id.Int64),
-		Time:   obj.ForTimestamp.UTC(),
-		Symbol: obj.CurrencyCode,
-		Rate:   obj.CurrencyRate,
+		Id:        uint64(obj.Id.Int64),
+		Time:      obj.ForTimestamp.UTC(),
+		Symbol:    obj.CurrencyCode,
+		Rate:      obj.CurrencyRate,
+		FreshTime: obj.FreshTimestamp.UTC(),
 	}
 }
 
@@ -227,13 +235,14 @@ func makeTimeBasedRangeQuery(table, condition string, ordering q.Ordering, inter
 func (m *exchangeRateModel) txSave(ctx context.Context, tx *sqlx.Tx) error {
 	err := tx.QueryRowxContext(ctx,
 		`INSERT INTO `+exchangeRateTableName+`
-		(for_date, for_timestamp, currency_code, currency_rate)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id, for_date, for_timestamp, currency_code, currency_rate`,
+		(for_date, for_timestamp, currency_code, currency_rate, fresh_timestamp)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, for_date, for_timestamp, currency_code, currency_rate, fresh_timestamp`,
 		m.ForDate,
 		m.ForTimestamp,
 		m.CurrencyCode,
 		m.CurrencyRate,
+		m.FreshTimestamp,
 	).StructScan(m)
 
 	return pgutil.CheckUniqueViolation(err, currency.ErrExists)
@@ -351,6 +360,22 @@ func dbGetMetadataByMint(ctx context.Context, db *sqlx.DB, mint string) (*metada
 	return res, pgutil.CheckNoRows(err, currency.ErrNotFound)
 }
 
+func dbGetAllMetadata(ctx context.Context, db *sqlx.DB) ([]*metadataModel, error) {
+	res := []*metadataModel{}
+	err := db.SelectContext(ctx, &res,
+		`SELECT id, name, symbol, description, image_url, seed, authority, mint, mint_bump, decimals, currency_config, currency_config_bump, liquidity_pool, liquidity_pool_bump, vault_mint, vault_mint_bump, vault_core, vault_core_bump, sell_fee_bps, alt, created_by, created_at
+		FROM `+metadataTableName+`
+		ORDER BY id ASC`,
+	)
+	if err != nil {
+		return nil, pgutil.CheckNoRows(err, currency.ErrNotFound)
+	}
+	if len(res) == 0 {
+		return nil, currency.ErrNotFound
+	}
+	return res, nil
+}
+
 func dbGetReserveByMintAndTime(ctx context.Context, db *sqlx.DB, mint string, t time.Time, ordering q.Ordering) (*reserveModel, error) {
 	res := &reserveModel{}
 	err := db.GetContext(ctx, res,
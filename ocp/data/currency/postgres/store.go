@@ -28,9 +28,10 @@ func (s *store) PutExchangeRates(ctx context.Context, obj *currency.MultiRateRec
 		// Loop through all rates and save individual records (within a transaction)
 		for symbol, item := range obj.Rates {
 			err := toExchangeRateModel(&currency.ExchangeRateRecord{
-				Time:   obj.Time,
-				Rate:   item,
-				Symbol: symbol,
+				Time:      obj.Time,
+				Rate:      item,
+				Symbol:    symbol,
+				FreshTime: obj.GetFreshTime(symbol),
 			}).txSave(ctx, tx)
 
 			if err != nil {
@@ -57,11 +58,13 @@ func (s *store) GetAllExchangeRates(ctx context.Context, t time.Time) (*currency
 	}
 
 	res := &currency.MultiRateRecord{
-		Time:  list[0].ForTimestamp,
-		Rates: map[string]float64{},
+		Time:       list[0].ForTimestamp,
+		Rates:      map[string]float64{},
+		FreshTimes: map[string]time.Time{},
 	}
 	for _, item := range list {
 		res.Rates[item.CurrencyCode] = item.CurrencyRate
+		res.FreshTimes[item.CurrencyCode] = item.FreshTimestamp.UTC()
 	}
 
 	return res, nil
@@ -124,6 +127,19 @@ func (s *store) GetMetadata(ctx context.Context, mint string) (*currency.Metadat
 	return fromMetadataModel(model), nil
 }
 
+func (s *store) GetAllMetadata(ctx context.Context) ([]*currency.MetadataRecord, error) {
+	models, err := dbGetAllMetadata(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*currency.MetadataRecord, len(models))
+	for i, model := range models {
+		res[i] = fromMetadataModel(model)
+	}
+	return res, nil
+}
+
 func (s *store) PutReserveRecord(ctx context.Context, record *currency.ReserveRecord) error {
 	model, err := toReserveModel(record)
 	if err != nil {
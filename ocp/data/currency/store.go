@@ -49,6 +49,11 @@ type Store interface {
 	// GetMetadata gets currency creator mint metadata by the mint address
 	GetMetadata(ctx context.Context, mint string) (*MetadataRecord, error)
 
+	// GetAllMetadata gets all currency creator mint metadata, ordered by creation
+	//
+	// ErrNotFound is returned if no metadata exists
+	GetAllMetadata(ctx context.Context) ([]*MetadataRecord, error)
+
 	// PutReserveRecord puts a currency creator mint reserve records into the store.
 	PutReserveRecord(ctx context.Context, record *ReserveRecord) error
 
@@ -2,6 +2,7 @@ package tests
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -18,6 +19,7 @@ func RunTests(t *testing.T, s currency.Store, teardown func()) {
 		testExchangeRateRoundTrip,
 		testGetExchangeRatesInRange,
 		testMetadataRoundTrip,
+		testGetAllMetadata,
 		testReserveRoundTrip,
 	} {
 		tf(t, s)
@@ -60,11 +62,33 @@ func testExchangeRateRoundTrip(t *testing.T, s currency.Store) {
 	assert.Equal(t, now.Unix(), record.Time.Unix())
 	assert.EqualValues(t, rates, record.Rates)
 
+	for symbol := range rates {
+		assert.Equal(t, now.Unix(), record.GetFreshTime(symbol).Unix())
+	}
+
+	// Rates carried forward keep the time they were last fresh
+	later := now.Add(time.Minute)
+	require.NoError(t, s.PutExchangeRates(context.Background(), &currency.MultiRateRecord{
+		Time:       later,
+		Rates:      rates,
+		FreshTimes: map[string]time.Time{"cad": now},
+	}))
+
+	record, err = s.GetAllExchangeRates(context.Background(), later)
+	require.NoError(t, err)
+	assert.Equal(t, later.Unix(), record.Time.Unix())
+	assert.Equal(t, later.Unix(), record.GetFreshTime("usd").Unix())
+	assert.Equal(t, now.Unix(), record.GetFreshTime("cad").Unix())
+
+	single, err = s.GetExchangeRate(context.Background(), "cad", later)
+	require.NoError(t, err)
+	assert.Equal(t, now.Unix(), single.FreshTime.Unix())
+
 	// within same day, should return entry
 	record, err = s.GetAllExchangeRates(context.Background(), time.Date(2021, 01, 29, 14, 0, 5, 0, time.UTC))
 	require.NoError(t, err)
 
-	assert.Equal(t, now.Unix(), record.Time.Unix())
+	assert.Equal(t, later.Unix(), record.Time.Unix())
 	assert.EqualValues(t, rates, record.Rates)
 
 	// day after, should be empty
@@ -163,6 +187,60 @@ func testMetadataRoundTrip(t *testing.T, s currency.Store) {
 	assertEquivalentMetadataRecords(t, cloned, actual)
 }
 
+func testGetAllMetadata(t *testing.T, s currency.Store) {
+	_, err := s.GetAllMetadata(context.Background())
+	assert.Equal(t, currency.ErrNotFound, err)
+
+	var expected []*currency.MetadataRecord
+	for i := 0; i < 3; i++ {
+		suffix := fmt.Sprintf("%d", i)
+
+		record := &currency.MetadataRecord{
+			Name:        "Currency" + suffix,
+			Symbol:      "CUR" + suffix,
+			Description: "description",
+			ImageUrl:    "https://example.com/icon.png",
+
+			Seed: "seed" + suffix,
+
+			Authority: "authority",
+
+			Mint:     "mint" + suffix,
+			MintBump: 255,
+			Decimals: currencycreator.DefaultMintDecimals,
+
+			CurrencyConfig:     "config" + suffix,
+			CurrencyConfigBump: 255,
+
+			LiquidityPool:     "pool" + suffix,
+			LiquidityPoolBump: 255,
+
+			VaultMint:     "vault_mint" + suffix,
+			VaultMintBump: 255,
+
+			VaultCore:     "vault_core" + suffix,
+			VaultCoreBump: 255,
+
+			SellFeeBps: currencycreator.DefaultSellFeeBps,
+
+			Alt: "alt",
+
+			CreatedBy: "creator",
+			CreatedAt: time.Now(),
+		}
+		require.NoError(t, s.PutMetadata(context.Background(), record))
+
+		expected = append(expected, record.Clone())
+	}
+
+	actual, err := s.GetAllMetadata(context.Background())
+	require.NoError(t, err)
+	require.Len(t, actual, len(expected))
+	for i := range expected {
+		assertEquivalentMetadataRecords(t, expected[i], actual[i])
+	}
+}
+
 func testReserveRoundTrip(t *testing.T, s currency.Store) {
 	now := time.Date(2021, 01, 29, 13, 0, 5, 0, time.UTC)
 
@@ -0,0 +1,15 @@
+CREATE TABLE ocp__core_externaldeposit(
+	id SERIAL NOT NULL PRIMARY KEY,
+
+	signature TEXT NOT NULL,
+	destination TEXT NOT NULL,
+	amount BIGINT NOT NULL CHECK (amount > 0),
+	usd_market_value NUMERIC(18, 9) NOT NULL,
+
+	slot BIGINT NOT NULL,
+	confirmation_state INTEGER NOT NULL,
+
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	CONSTRAINT ocp__core_externaldeposit__uniq__destination__and__signature UNIQUE (destination, signature)
+);
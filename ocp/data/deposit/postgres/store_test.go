@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"os"
 	"testing"
@@ -11,37 +12,12 @@ import (
 	"github.com/code-payments/ocp-server/ocp/data/deposit"
 	"github.com/code-payments/ocp-server/ocp/data/deposit/tests"
 
+	"github.com/code-payments/ocp-server/database/postgres/migration"
 	postgrestest "github.com/code-payments/ocp-server/database/postgres/test"
 
 	_ "github.com/jackc/pgx/v4/stdlib"
 )
 
-const (
-	// Used for testing ONLY, the table and migrations are external to this repository
-	tableCreate = `
-		CREATE TABLE ocp__core_externaldeposit(
-			id SERIAL NOT NULL PRIMARY KEY,
-
-			signature TEXT NOT NULL,
-			destination TEXT NOT NULL,
-			amount BIGINT NOT NULL CHECK (amount > 0),
-			usd_market_value NUMERIC(18, 9) NOT NULL,
-
-			slot BIGINT NOT NULL,
-			confirmation_state INTEGER NOT NULL,
-
-			created_at TIMESTAMP WITH TIME ZONE NOT NULL,
-
-			CONSTRAINT ocp__core_externaldeposit__uniq__destination__and__signature UNIQUE (destination, signature)
-		);
-	`
-
-	// Used for testing ONLY, the table and migrations are external to this repository
-	tableDestroy = `
-		DROP TABLE ocp__core_externaldeposit;
-	`
-)
-
 var (
 	testStore deposit.Store
 	teardown  func()
@@ -94,7 +70,13 @@ func TestDepositPostgresStore(t *testing.T) {
 }
 
 func createTestTables(log *zap.Logger, db *sql.DB) error {
-	_, err := db.Exec(tableCreate)
+	migrations, err := Migrations()
+	if err != nil {
+		log.With(zap.Error(err)).Error("could not load migrations")
+		return err
+	}
+
+	err = migration.Up(context.Background(), db, migrations)
 	if err != nil {
 		log.With(zap.Error(err)).Error("could not create test tables")
 		return err
@@ -103,7 +85,13 @@ func createTestTables(log *zap.Logger, db *sql.DB) error {
 }
 
 func resetTestTables(log *zap.Logger, db *sql.DB) error {
-	_, err := db.Exec(tableDestroy)
+	migrations, err := Migrations()
+	if err != nil {
+		log.With(zap.Error(err)).Error("could not load migrations")
+		return err
+	}
+
+	err = migration.Down(context.Background(), db, migrations)
 	if err != nil {
 		log.With(zap.Error(err)).Error("could not drop test tables")
 		return err
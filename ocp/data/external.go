@@ -2,10 +2,14 @@ package data
 
 import (
 	"context"
-	"errors"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/pkg/errors"
+
 	currency_lib "github.com/code-payments/ocp-server/currency"
+	"github.com/code-payments/ocp-server/currency/aggregate"
 	"github.com/code-payments/ocp-server/currency/coingecko"
 	"github.com/code-payments/ocp-server/currency/fixer"
 	"github.com/code-payments/ocp-server/metrics"
@@ -17,8 +21,39 @@ import (
 
 const (
 	webProviderMetricsName = "data.web_provider"
+
+	exchangeRateFallbackEventName = "ExchangeRateFallback"
+)
+
+const (
+	coinGeckoExchangeRateSourceName = "coingecko"
+	fixerExchangeRateSourceName     = "fixer"
 )
 
+// ExchangeRateSourceFactory creates a client for an exchange rate source
+type ExchangeRateSourceFactory func(configProvider ConfigProvider) (currency_lib.Client, error)
+
+var (
+	exchangeRateSourcesMu sync.RWMutex
+	exchangeRateSources   = map[string]ExchangeRateSourceFactory{
+		coinGeckoExchangeRateSourceName: func(_ ConfigProvider) (currency_lib.Client, error) {
+			return coingecko.NewClient(), nil
+		},
+		fixerExchangeRateSourceName: func(configProvider ConfigProvider) (currency_lib.Client, error) {
+			return fixer.NewClient(configProvider().fixerApiKey.Get(context.Background())), nil
+		},
+	}
+)
+
+// RegisterExchangeRateSource registers an exchange rate source, so it can be
+// referenced by name in the exchange rate source configs
+func RegisterExchangeRateSource(name string, factory ExchangeRateSourceFactory) {
+	exchangeRateSourcesMu.Lock()
+	defer exchangeRateSourcesMu.Unlock()
+
+	exchangeRateSources[strings.ToLower(name)] = factory
+}
+
 type WebData interface {
 
 	// Currency
@@ -29,78 +64,198 @@ type WebData interface {
 }
 
 type WebProvider struct {
-	coinGecko currency_lib.Client
-	fixer     currency_lib.Client
+	coreMintRates currency_lib.Client
+	usdRates      currency_lib.Client
+
+	// lastKnownRates provides the most recently stored exchange rates, which
+	// are carried forward for currencies that providers fail to return
+	lastKnownRates        func(ctx context.Context, t time.Time) (*currency.MultiRateRecord, error)
+	maxStaleness          time.Duration
+	maxStalenessOverrides map[string]time.Duration
 }
 
 func NewWebProvider(configProvider ConfigProvider) (WebData, error) {
 	conf := configProvider()
+	ctx := context.Background()
+
+	aggregateOpts := []aggregate.Option{
+		aggregate.WithMinSources(int(conf.exchangeRateMinSources.Get(ctx))),
+		aggregate.WithMaxDeviation(conf.exchangeRateMaxDeviation.Get(ctx)),
+		aggregate.WithMaxAge(conf.exchangeRateMaxSourceAge.Get(ctx)),
+	}
+
+	coreMintRates, err := newExchangeRateClient(configProvider, conf.coreMintExchangeRateSources.Get(ctx), aggregateOpts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating core mint exchange rate client")
+	}
+
+	usdRates, err := newExchangeRateClient(configProvider, conf.usdExchangeRateSources.Get(ctx), aggregateOpts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating usd exchange rate client")
+	}
+
+	maxStalenessOverrides, err := parseMaxStalenessOverrides(conf.exchangeRateMaxStalenessOverrides.Get(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing exchange rate staleness overrides")
+	}
+
+	return newWebProvider(coreMintRates, usdRates, conf.exchangeRateMaxStaleness.Get(ctx), maxStalenessOverrides), nil
+}
+
+// NewTestWebProvider returns a web provider backed by the provided exchange
+// rate clients (eg. offline fixtures)
+func NewTestWebProvider(coreMintRates, usdRates currency_lib.Client) WebData {
+	return newWebProvider(coreMintRates, usdRates, defaultExchangeRateMaxStaleness, nil)
+}
+
+func newWebProvider(coreMintRates, usdRates currency_lib.Client, maxStaleness time.Duration, maxStalenessOverrides map[string]time.Duration) *WebProvider {
 	return &WebProvider{
-		coinGecko: coingecko.NewClient(),
-		fixer:     fixer.NewClient(conf.fixerApiKey.Get(context.Background())),
-	}, nil
+		coreMintRates:         coreMintRates,
+		usdRates:              usdRates,
+		maxStaleness:          maxStaleness,
+		maxStalenessOverrides: maxStalenessOverrides,
+	}
 }
 
 // Currency
 // --------------------------------------------------------------------------------
+
+// GetCurrentExchangeRatesFromExternalProviders gets the current exchange rates
+// across all configured providers. Currencies that providers fail to return are
+// carried forward from the last stored rates, as long as they were last returned
+// by a provider within the currency's staleness limit. Otherwise, they're dropped.
+//
+// The time each currency was last returned by a provider is carried forward with
+// the rate, so it's preserved across restarts.
 func (dp *WebProvider) GetCurrentExchangeRatesFromExternalProviders(ctx context.Context) (*currency.MultiRateRecord, error) {
 	tracer := metrics.TraceMethodCall(ctx, webProviderMetricsName, "GetCurrentExchangeRatesFromExternalProviders")
 	defer tracer.End()
 
-	coinGeckoRates := make(map[string]float64)
-	var err error
+	now := time.Now()
+
+	freshRates, fetchErr := dp.getCurrentExchangeRates(ctx)
+
+	res := &currency.MultiRateRecord{
+		Time:       now,
+		Rates:      make(map[string]float64),
+		FreshTimes: make(map[string]time.Time),
+	}
+
+	for symbol, rate := range freshRates {
+		res.Rates[symbol] = rate
+		res.FreshTimes[symbol] = now
+	}
+
+	var fallbackCount, droppedCount int
+	if dp.lastKnownRates != nil {
+		lastKnown, err := dp.lastKnownRates(ctx, now)
+		if err == nil {
+			for symbol, rate := range lastKnown.Rates {
+				if _, ok := res.Rates[symbol]; ok {
+					continue
+				}
+
+				lastFreshRateTime := lastKnown.GetFreshTime(symbol)
+				if now.Sub(lastFreshRateTime) > dp.getMaxStaleness(symbol) {
+					droppedCount++
+					continue
+				}
+
+				res.Rates[symbol] = rate
+				res.FreshTimes[symbol] = lastFreshRateTime
+				fallbackCount++
+			}
+		} else if err != currency.ErrNotFound {
+			tracer.OnError(err)
+		}
+	}
+
+	if fetchErr != nil || fallbackCount > 0 || droppedCount > 0 {
+		kvPairs := map[string]interface{}{
+			"fresh_count":    len(freshRates),
+			"fallback_count": fallbackCount,
+			"dropped_count":  droppedCount,
+		}
+		if fetchErr != nil {
+			kvPairs["error"] = fetchErr.Error()
+		}
+		metrics.RecordEvent(ctx, exchangeRateFallbackEventName, kvPairs)
+	}
+
+	if _, ok := res.Rates[string(currency_lib.USD)]; !ok {
+		err := fetchErr
+		if err == nil {
+			err = errors.New("usd rate missing")
+		}
+		tracer.OnError(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// getCurrentExchangeRates gets the current exchange rates from providers. When
+// the USD leg fails, the USD rate is still returned alongside the error.
+func (dp *WebProvider) getCurrentExchangeRates(ctx context.Context) (map[string]float64, error) {
+	coreMintRates := make(map[string]float64)
 
 	switch config.CoreMintPublicKeyString {
 	case usdc.Mint, usdf.Mint:
-		coinGeckoRates[string(currency_lib.USD)] = 1.0
+		coreMintRates[string(currency_lib.USD)] = 1.0
 	default:
-		coinGeckoData, err := dp.coinGecko.GetCurrentRates(ctx, string(config.CoreMintSymbol))
+		coreMintData, err := dp.coreMintRates.GetCurrentRates(ctx, string(config.CoreMintSymbol))
 		if err != nil {
-			return nil, err
+			return nil, errors.Wrap(err, "error getting core mint exchange rates")
 		}
-		coinGeckoRates = coinGeckoData.Rates
+		coreMintRates = coreMintData.Rates
 	}
 
-	fixerData, err := dp.fixer.GetCurrentRates(ctx, string(currency_lib.USD))
-	if err != nil {
-		return nil, err
+	usdRates := make(map[string]float64)
+	usdData, usdErr := dp.usdRates.GetCurrentRates(ctx, string(currency_lib.USD))
+	if usdErr == nil {
+		usdRates = usdData.Rates
+	} else {
+		usdErr = errors.Wrap(usdErr, "error getting usd exchange rates")
 	}
 
-	rates, err := computeAllExchangeRates(coinGeckoRates, fixerData.Rates)
+	rates, err := computeAllExchangeRates(coreMintRates, usdRates)
 	if err != nil {
 		return nil, err
 	}
+	return rates, usdErr
+}
 
-	return &currency.MultiRateRecord{
-		Time:  time.Now(),
-		Rates: rates,
-	}, nil
+func (dp *WebProvider) getMaxStaleness(symbol string) time.Duration {
+	if maxStaleness, ok := dp.maxStalenessOverrides[symbol]; ok {
+		return maxStaleness
+	}
+	return dp.maxStaleness
 }
+
 func (dp *WebProvider) GetPastExchangeRatesFromExternalProviders(ctx context.Context, t time.Time) (*currency.MultiRateRecord, error) {
 	tracer := metrics.TraceMethodCall(ctx, webProviderMetricsName, "GetPastExchangeRatesFromExternalProviders")
 	defer tracer.End()
 
-	coinGeckoRates := make(map[string]float64)
+	coreMintRates := make(map[string]float64)
 	ts := t
 	var err error
 	switch config.CoreMintPublicKeyString {
 	case usdc.Mint, usdf.Mint:
-		coinGeckoRates[string(currency_lib.USD)] = 1.0
+		coreMintRates[string(currency_lib.USD)] = 1.0
 	default:
-		coinGeckoData, err := dp.coinGecko.GetCurrentRates(ctx, string(config.CoreMintSymbol))
+		coreMintData, err := dp.coreMintRates.GetHistoricalRates(ctx, string(config.CoreMintSymbol), t.UTC())
 		if err != nil {
 			return nil, err
 		}
-		coinGeckoRates = coinGeckoData.Rates
-		ts = coinGeckoData.Timestamp
+		coreMintRates = coreMintData.Rates
 	}
 
-	fixerData, err := dp.fixer.GetHistoricalRates(ctx, string(currency_lib.USD), t.UTC())
+	usdData, err := dp.usdRates.GetHistoricalRates(ctx, string(currency_lib.USD), t.UTC())
 	if err != nil {
 		return nil, err
 	}
 
-	rates, err := computeAllExchangeRates(coinGeckoRates, fixerData.Rates)
+	rates, err := computeAllExchangeRates(coreMintRates, usdData.Rates)
 	if err != nil {
 		return nil, err
 	}
@@ -129,3 +284,62 @@ func computeAllExchangeRates(coreMintRates map[string]float64, usdRates map[stri
 	}
 	return res, nil
 }
+
+func newExchangeRateClient(configProvider ConfigProvider, sourceNames string, opts ...aggregate.Option) (currency_lib.Client, error) {
+	exchangeRateSourcesMu.RLock()
+	defer exchangeRateSourcesMu.RUnlock()
+
+	var sources []aggregate.Source
+	for _, name := range strings.Split(sourceNames, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if len(name) == 0 {
+			continue
+		}
+
+		factory, ok := exchangeRateSources[name]
+		if !ok {
+			return nil, errors.Errorf("unknown exchange rate source: %s", name)
+		}
+
+		client, err := factory(configProvider)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error creating %s exchange rate source", name)
+		}
+
+		sources = append(sources, aggregate.Source{
+			Name:   name,
+			Client: client,
+		})
+	}
+
+	if len(sources) == 0 {
+		return nil, aggregate.ErrNoSources
+	}
+	return aggregate.NewClient(sources, opts...), nil
+}
+
+// parseMaxStalenessOverrides parses comma-separated currency staleness limits
+// (eg. "ars=15m,ves=6h")
+func parseMaxStalenessOverrides(value string) (map[string]time.Duration, error) {
+	res := make(map[string]time.Duration)
+	for _, override := range strings.Split(value, ",") {
+		override = strings.TrimSpace(override)
+		if len(override) == 0 {
+			continue
+		}
+
+		parts := strings.Split(override, "=")
+		if len(parts) != 2 {
+			return nil, errors.Errorf("invalid staleness override: %s", override)
+		}
+
+		symbol := strings.ToLower(strings.TrimSpace(parts[0]))
+		maxStaleness, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid staleness override for %s", symbol)
+		}
+
+		res[symbol] = maxStaleness
+	}
+	return res, nil
+}
@@ -1,9 +1,16 @@
 package data
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/code-payments/ocp-server/currency/fixture"
 	"github.com/code-payments/ocp-server/ocp/config"
+	"github.com/code-payments/ocp-server/ocp/data/currency"
+	currency_memory_client "github.com/code-payments/ocp-server/ocp/data/currency/memory"
+	"github.com/code-payments/ocp-server/usdc"
 	"github.com/code-payments/ocp-server/usdf"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -55,3 +62,82 @@ func TestComputeAllExchangeRates_UsdRateMissing(t *testing.T) {
 	_, err := computeAllExchangeRates(coreMintRates, usdRates)
 	assert.Error(t, err)
 }
+
+func TestGetCurrentExchangeRatesFromExternalProviders_Fallback(t *testing.T) {
+	ctx := context.Background()
+
+	coreMintRates := fixture.NewClient()
+	coreMintRates.SetCurrentRates(string(config.CoreMintSymbol), map[string]float64{"usd": 0.5}, time.Now())
+
+	usdRates := fixture.NewClient()
+	usdRates.SetCurrentRates("usd", map[string]float64{"usd": 1.0, "cad": 1.3, "ves": 36.5}, time.Now())
+
+	store := currency_memory_client.New()
+
+	provider := newWebProvider(coreMintRates, usdRates, time.Hour, map[string]time.Duration{"ves": time.Minute})
+	provider.lastKnownRates = store.GetAllExchangeRates
+
+	initial, err := provider.GetCurrentExchangeRatesFromExternalProviders(ctx)
+	require.NoError(t, err)
+	require.Len(t, initial.Rates, 3)
+	require.NoError(t, store.PutExchangeRates(ctx, initial))
+
+	// The USD leg fails, so all other currencies are carried forward
+	usdRates.SetError(errors.New("unavailable"))
+
+	carried, err := provider.GetCurrentExchangeRatesFromExternalProviders(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, initial.Rates, carried.Rates)
+	for symbol := range initial.Rates {
+		if symbol == "usd" {
+			continue
+		}
+		assert.Equal(t, initial.FreshTimes[symbol], carried.FreshTimes[symbol])
+	}
+
+	// Currencies are dropped once their staleness limit is exceeded, which is
+	// tracked with the stored rates so it holds for a restarted provider
+	stale := currency_memory_client.New()
+	require.NoError(t, stale.PutExchangeRates(ctx, &currency.MultiRateRecord{
+		Time:       initial.Time,
+		Rates:      initial.Rates,
+		FreshTimes: map[string]time.Time{"ves": time.Now().Add(-2 * time.Minute)},
+	}))
+
+	provider = newWebProvider(coreMintRates, usdRates, time.Hour, map[string]time.Duration{"ves": time.Minute})
+	provider.lastKnownRates = stale.GetAllExchangeRates
+
+	carried, err = provider.GetCurrentExchangeRatesFromExternalProviders(ctx)
+	require.NoError(t, err)
+	assert.Len(t, carried.Rates, 2)
+	assert.Equal(t, initial.Rates["usd"], carried.Rates["usd"])
+	assert.Equal(t, initial.Rates["cad"], carried.Rates["cad"])
+	assert.NotContains(t, carried.Rates, "ves")
+
+	// Without a core mint rate, there's nothing to fall back to for USD
+	switch config.CoreMintPublicKeyString {
+	case usdc.Mint, usdf.Mint:
+	default:
+		coreMintRates.SetError(errors.New("unavailable"))
+		provider.lastKnownRates = nil
+
+		_, err = provider.GetCurrentExchangeRatesFromExternalProviders(ctx)
+		assert.Error(t, err)
+	}
+}
+
+func TestParseMaxStalenessOverrides(t *testing.T) {
+	overrides, err := parseMaxStalenessOverrides(" ARS=15m, ves=6h ,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{"ars": 15 * time.Minute, "ves": 6 * time.Hour}, overrides)
+
+	overrides, err = parseMaxStalenessOverrides("")
+	require.NoError(t, err)
+	assert.Empty(t, overrides)
+
+	_, err = parseMaxStalenessOverrides("ars")
+	assert.Error(t, err)
+
+	_, err = parseMaxStalenessOverrides("ars=soon")
+	assert.Error(t, err)
+}
@@ -0,0 +1,152 @@
+package feeburn
+
+import (
+	"errors"
+	"time"
+)
+
+type State uint8
+
+const (
+	StateUnknown   State = iota
+	StatePending         // Transaction is being submitted
+	StateConfirmed       // Transaction was finalized and fees were burned
+	StateFailed          // Transaction was finalized with an error, or abandoned before landing
+)
+
+// Record is a transaction that burns the sell fees accumulated in a launchpad
+// currency's liquidity pool
+type Record struct {
+	Id uint64
+
+	Mint          string
+	LiquidityPool string
+
+	// Fees burned, in core mint quarks. This is the amount accumulated in the
+	// liquidity pool when the transaction was made, and is updated to the
+	// amount actually burned once the transaction is confirmed.
+	Quarks uint64
+
+	Signature string
+	Nonce     string
+	Blockhash string
+
+	// Only available while the transaction is pending
+	TransactionBlob []byte
+
+	// Lamports paid by the subsidizer, which is only available once the
+	// transaction is finalized
+	Fee uint64
+
+	State State
+
+	Version uint64
+
+	CreatedAt time.Time
+}
+
+func (r *Record) Validate() error {
+	if len(r.Mint) == 0 {
+		return errors.New("mint is required")
+	}
+
+	if len(r.LiquidityPool) == 0 {
+		return errors.New("liquidity pool is required")
+	}
+
+	if len(r.Signature) == 0 {
+		return errors.New("signature is required")
+	}
+
+	if len(r.Nonce) == 0 {
+		return errors.New("nonce is required")
+	}
+
+	if len(r.Blockhash) == 0 {
+		return errors.New("blockhash is required")
+	}
+
+	if r.State == StateUnknown {
+		return errors.New("state is required")
+	}
+
+	if r.State == StatePending && r.Quarks == 0 {
+		return errors.New("quarks must be positive when pending")
+	}
+
+	if r.State == StatePending && len(r.TransactionBlob) == 0 {
+		return errors.New("transaction blob is required when pending")
+	}
+
+	return nil
+}
+
+func (r *Record) Clone() Record {
+	var transactionBlob []byte
+	if r.TransactionBlob != nil {
+		transactionBlob = make([]byte, len(r.TransactionBlob))
+		copy(transactionBlob, r.TransactionBlob)
+	}
+
+	return Record{
+		Id: r.Id,
+
+		Mint:          r.Mint,
+		LiquidityPool: r.LiquidityPool,
+
+		Quarks: r.Quarks,
+
+		Signature: r.Signature,
+		Nonce:     r.Nonce,
+		Blockhash: r.Blockhash,
+
+		TransactionBlob: transactionBlob,
+
+		Fee: r.Fee,
+
+		State: r.State,
+
+		Version: r.Version,
+
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+func (r *Record) CopyTo(dst *Record) {
+	dst.Id = r.Id
+
+	dst.Mint = r.Mint
+	dst.LiquidityPool = r.LiquidityPool
+
+	dst.Quarks = r.Quarks
+
+	dst.Signature = r.Signature
+	dst.Nonce = r.Nonce
+	dst.Blockhash = r.Blockhash
+
+	dst.TransactionBlob = r.TransactionBlob
+
+	dst.Fee = r.Fee
+
+	dst.State = r.State
+
+	dst.Version = r.Version
+
+	dst.CreatedAt = r.CreatedAt
+}
+
+func (s State) IsTerminal() bool {
+	return s == StateConfirmed || s == StateFailed
+}
+
+func (s State) String() string {
+	switch s {
+	case StatePending:
+		return "pending"
+	case StateConfirmed:
+		return "confirmed"
+	case StateFailed:
+		return "failed"
+	}
+	return "unknown"
+}
@@ -0,0 +1,210 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/code-payments/ocp-server/database/query"
+	"github.com/code-payments/ocp-server/ocp/data/feeburn"
+)
+
+type ById []*feeburn.Record
+
+func (a ById) Len() int           { return len(a) }
+func (a ById) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a ById) Less(i, j int) bool { return a[i].Id < a[j].Id }
+
+type store struct {
+	mu      sync.Mutex
+	last    uint64
+	records []*feeburn.Record
+}
+
+// New returns a new in memory feeburn.Store
+func New() feeburn.Store {
+	return &store{}
+}
+
+// Put implements feeburn.Store.Put
+func (s *store) Put(_ context.Context, record *feeburn.Record) error {
+	if err := record.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, item := range s.records {
+		if item.Signature == record.Signature {
+			return feeburn.ErrAlreadyExists
+		}
+
+		if item.Mint == record.Mint && item.State == feeburn.StatePending && record.State == feeburn.StatePending {
+			return feeburn.ErrAlreadyExists
+		}
+	}
+
+	s.last++
+	record.Id = s.last
+	record.Version = 1
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+
+	cloned := record.Clone()
+	s.records = append(s.records, &cloned)
+
+	return nil
+}
+
+// Update implements feeburn.Store.Update
+func (s *store) Update(_ context.Context, record *feeburn.Record) error {
+	if err := record.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item := s.find(func(item *feeburn.Record) bool { return item.Signature == record.Signature })
+	if item == nil {
+		return feeburn.ErrNotFound
+	}
+
+	if item.Version != record.Version {
+		return feeburn.ErrStaleVersion
+	}
+
+	record.Version++
+
+	cloned := record.Clone()
+	item.Quarks = cloned.Quarks
+	item.TransactionBlob = cloned.TransactionBlob
+	item.Fee = cloned.Fee
+	item.State = cloned.State
+	item.Version = cloned.Version
+
+	return nil
+}
+
+// GetBySignature implements feeburn.Store.GetBySignature
+func (s *store) GetBySignature(_ context.Context, signature string) (*feeburn.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item := s.find(func(item *feeburn.Record) bool { return item.Signature == signature })
+	if item == nil {
+		return nil, feeburn.ErrNotFound
+	}
+
+	cloned := item.Clone()
+	return &cloned, nil
+}
+
+// GetLatestByMint implements feeburn.Store.GetLatestByMint
+func (s *store) GetLatestByMint(_ context.Context, mint string) (*feeburn.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var latest *feeburn.Record
+	for _, item := range s.records {
+		if item.Mint == mint && (latest == nil || item.Id > latest.Id) {
+			latest = item
+		}
+	}
+
+	if latest == nil {
+		return nil, feeburn.ErrNotFound
+	}
+
+	cloned := latest.Clone()
+	return &cloned, nil
+}
+
+// GetAllByState implements feeburn.Store.GetAllByState
+func (s *store) GetAllByState(_ context.Context, state feeburn.State, cursor query.Cursor, limit uint64, direction query.Ordering) ([]*feeburn.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var start uint64
+	if direction == query.Descending {
+		start = s.last + 1
+	}
+	if len(cursor) > 0 {
+		start = cursor.ToUint64()
+	}
+
+	var res []*feeburn.Record
+	for _, item := range s.records {
+		if item.State != state {
+			continue
+		}
+
+		if (direction == query.Ascending && item.Id > start) || (direction == query.Descending && item.Id < start) {
+			cloned := item.Clone()
+			res = append(res, &cloned)
+		}
+	}
+
+	if direction == query.Descending {
+		sort.Sort(sort.Reverse(ById(res)))
+	} else {
+		sort.Sort(ById(res))
+	}
+
+	if len(res) > int(limit) {
+		res = res[:limit]
+	}
+
+	if len(res) == 0 {
+		return nil, feeburn.ErrNotFound
+	}
+	return res, nil
+}
+
+// CountByState implements feeburn.Store.CountByState
+func (s *store) CountByState(_ context.Context, state feeburn.State) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var res uint64
+	for _, item := range s.records {
+		if item.State == state {
+			res++
+		}
+	}
+	return res, nil
+}
+
+// GetTotalQuarksBurnedByMint implements feeburn.Store.GetTotalQuarksBurnedByMint
+func (s *store) GetTotalQuarksBurnedByMint(_ context.Context, mint string) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var res uint64
+	for _, item := range s.records {
+		if item.Mint == mint && item.State == feeburn.StateConfirmed {
+			res += item.Quarks
+		}
+	}
+	return res, nil
+}
+
+func (s *store) find(matches func(item *feeburn.Record) bool) *feeburn.Record {
+	for _, item := range s.records {
+		if matches(item) {
+			return item
+		}
+	}
+	return nil
+}
+
+func (s *store) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.last = 0
+	s.records = nil
+}
@@ -0,0 +1,30 @@
+CREATE TABLE ocp__core_currencyfeeburn (
+	id SERIAL NOT NULL PRIMARY KEY,
+
+	mint TEXT NOT NULL,
+	liquidity_pool TEXT NOT NULL,
+
+	quarks BIGINT NOT NULL,
+
+	signature TEXT NOT NULL,
+	nonce TEXT NOT NULL,
+	blockhash TEXT NOT NULL,
+
+	transaction_blob BYTEA NULL,
+
+	fee BIGINT NOT NULL,
+
+	state INTEGER NOT NULL,
+
+	version INTEGER NOT NULL,
+
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	CONSTRAINT ocp__core_currencyfeeburn__uniq__signature UNIQUE (signature)
+);
+
+-- At most one fee burn can be in flight for a currency at a time
+CREATE UNIQUE INDEX ocp__core_currencyfeeburn__uniq__pending_mint ON ocp__core_currencyfeeburn (mint) WHERE state = 1;
+
+CREATE INDEX ocp__core_currencyfeeburn__idx__mint ON ocp__core_currencyfeeburn (mint);
+CREATE INDEX ocp__core_currencyfeeburn__idx__state ON ocp__core_currencyfeeburn (state);
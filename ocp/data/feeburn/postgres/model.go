@@ -0,0 +1,239 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	pgutil "github.com/code-payments/ocp-server/database/postgres"
+	q "github.com/code-payments/ocp-server/database/query"
+	"github.com/code-payments/ocp-server/ocp/data/feeburn"
+)
+
+const (
+	tableName = "ocp__core_currencyfeeburn"
+
+	allColumns = `id, mint, liquidity_pool, quarks, signature, nonce, blockhash, transaction_blob, fee, state, version, created_at`
+)
+
+type model struct {
+	Id sql.NullInt64 `db:"id"`
+
+	Mint          string `db:"mint"`
+	LiquidityPool string `db:"liquidity_pool"`
+
+	Quarks uint64 `db:"quarks"`
+
+	Signature string `db:"signature"`
+	Nonce     string `db:"nonce"`
+	Blockhash string `db:"blockhash"`
+
+	TransactionBlob []byte `db:"transaction_blob"`
+
+	Fee uint64 `db:"fee"`
+
+	State uint8 `db:"state"`
+
+	Version uint64 `db:"version"`
+
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func toModel(obj *feeburn.Record) (*model, error) {
+	if err := obj.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &model{
+		Id: sql.NullInt64{Int64: int64(obj.Id), Valid: obj.Id > 0},
+
+		Mint:          obj.Mint,
+		LiquidityPool: obj.LiquidityPool,
+
+		Quarks: obj.Quarks,
+
+		Signature: obj.Signature,
+		Nonce:     obj.Nonce,
+		Blockhash: obj.Blockhash,
+
+		TransactionBlob: obj.TransactionBlob,
+
+		Fee: obj.Fee,
+
+		State: uint8(obj.State),
+
+		Version: obj.Version,
+
+		CreatedAt: obj.CreatedAt.UTC(),
+	}, nil
+}
+
+func fromModel(obj *model) *feeburn.Record {
+	return &feeburn.Record{
+		Id: uint64(obj.Id.Int64),
+
+		Mint:          obj.Mint,
+		LiquidityPool: obj.LiquidityPool,
+
+		Quarks: obj.Quarks,
+
+		Signature: obj.Signature,
+		Nonce:     obj.Nonce,
+		Blockhash: obj.Blockhash,
+
+		TransactionBlob: obj.TransactionBlob,
+
+		Fee: obj.Fee,
+
+		State: feeburn.State(obj.State),
+
+		Version: obj.Version,
+
+		CreatedAt: obj.CreatedAt,
+	}
+}
+
+func (m *model) dbPut(ctx context.Context, db *sqlx.DB) error {
+	return pgutil.ExecuteInTx(ctx, db, sql.LevelDefault, func(tx *sqlx.Tx) error {
+		query := `INSERT INTO ` + tableName + `
+			(mint, liquidity_pool, quarks, signature, nonce, blockhash, transaction_blob, fee, state, version, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, 1, $10)
+			RETURNING ` + allColumns
+
+		if m.CreatedAt.IsZero() {
+			m.CreatedAt = time.Now().UTC()
+		}
+
+		err := tx.QueryRowxContext(
+			ctx,
+			query,
+			m.Mint,
+			m.LiquidityPool,
+			m.Quarks,
+			m.Signature,
+			m.Nonce,
+			m.Blockhash,
+			m.TransactionBlob,
+			m.Fee,
+			m.State,
+			m.CreatedAt,
+		).StructScan(m)
+
+		return pgutil.CheckUniqueViolation(err, feeburn.ErrAlreadyExists)
+	})
+}
+
+func (m *model) dbUpdate(ctx context.Context, db *sqlx.DB) error {
+	return pgutil.ExecuteInTx(ctx, db, sql.LevelDefault, func(tx *sqlx.Tx) error {
+		query := `UPDATE ` + tableName + `
+			SET quarks = $3, transaction_blob = $4, fee = $5, state = $6, version = version + 1
+			WHERE signature = $1 AND version = $2
+			RETURNING ` + allColumns
+
+		err := tx.QueryRowxContext(
+			ctx,
+			query,
+			m.Signature,
+			m.Version,
+			m.Quarks,
+			m.TransactionBlob,
+			m.Fee,
+			m.State,
+		).StructScan(m)
+		if err == nil {
+			return nil
+		} else if err != sql.ErrNoRows {
+			return err
+		}
+
+		// Distinguish between a missing record and a stale version
+		var count uint64
+		err = tx.GetContext(ctx, &count, `SELECT COUNT(*) FROM `+tableName+` WHERE signature = $1`, m.Signature)
+		if err != nil {
+			return err
+		} else if count == 0 {
+			return feeburn.ErrNotFound
+		}
+		return feeburn.ErrStaleVersion
+	})
+}
+
+func dbGetBySignature(ctx context.Context, db *sqlx.DB, signature string) (*model, error) {
+	res := &model{}
+
+	query := `SELECT ` + allColumns + `
+		FROM ` + tableName + `
+		WHERE signature = $1
+		LIMIT 1`
+
+	err := db.GetContext(ctx, res, query, signature)
+	if err != nil {
+		return nil, pgutil.CheckNoRows(err, feeburn.ErrNotFound)
+	}
+	return res, nil
+}
+
+func dbGetLatestByMint(ctx context.Context, db *sqlx.DB, mint string) (*model, error) {
+	res := &model{}
+
+	query := `SELECT ` + allColumns + `
+		FROM ` + tableName + `
+		WHERE mint = $1
+		ORDER BY id DESC
+		LIMIT 1`
+
+	err := db.GetContext(ctx, res, query, mint)
+	if err != nil {
+		return nil, pgutil.CheckNoRows(err, feeburn.ErrNotFound)
+	}
+	return res, nil
+}
+
+func dbGetAllByState(ctx context.Context, db *sqlx.DB, state feeburn.State, cursor q.Cursor, limit uint64, direction q.Ordering) ([]*model, error) {
+	res := []*model{}
+
+	query := `SELECT ` + allColumns + `
+		FROM ` + tableName + `
+		WHERE state = $1`
+
+	opts := []interface{}{state}
+	query, opts = q.PaginateQuery(query, opts, cursor, limit, direction)
+
+	err := db.SelectContext(ctx, &res, query, opts...)
+	if err != nil {
+		return nil, pgutil.CheckNoRows(err, feeburn.ErrNotFound)
+	}
+
+	if len(res) == 0 {
+		return nil, feeburn.ErrNotFound
+	}
+	return res, nil
+}
+
+func dbCountByState(ctx context.Context, db *sqlx.DB, state feeburn.State) (uint64, error) {
+	var res uint64
+
+	query := `SELECT COUNT(*) FROM ` + tableName + ` WHERE state = $1`
+
+	err := db.GetContext(ctx, &res, query, state)
+	if err != nil {
+		return 0, err
+	}
+	return res, nil
+}
+
+func dbGetTotalQuarksBurnedByMint(ctx context.Context, db *sqlx.DB, mint string) (uint64, error) {
+	var res uint64
+
+	query := `SELECT COALESCE(SUM(quarks), 0)
+		FROM ` + tableName + `
+		WHERE mint = $1 AND state = $2`
+
+	err := db.GetContext(ctx, &res, query, mint, feeburn.StateConfirmed)
+	if err != nil {
+		return 0, err
+	}
+	return res, nil
+}
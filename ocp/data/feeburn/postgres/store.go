@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/code-payments/ocp-server/database/query"
+	"github.com/code-payments/ocp-server/ocp/data/feeburn"
+)
+
+type store struct {
+	db *sqlx.DB
+}
+
+// New returns a new postgres feeburn.Store
+func New(db *sql.DB) feeburn.Store {
+	return &store{
+		db: sqlx.NewDb(db, "pgx"),
+	}
+}
+
+// Put implements feeburn.Store.Put
+func (s *store) Put(ctx context.Context, record *feeburn.Record) error {
+	model, err := toModel(record)
+	if err != nil {
+		return err
+	}
+
+	err = model.dbPut(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	res := fromModel(model)
+	res.CopyTo(record)
+
+	return nil
+}
+
+// Update implements feeburn.Store.Update
+func (s *store) Update(ctx context.Context, record *feeburn.Record) error {
+	model, err := toModel(record)
+	if err != nil {
+		return err
+	}
+
+	err = model.dbUpdate(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	res := fromModel(model)
+	res.CopyTo(record)
+
+	return nil
+}
+
+// GetBySignature implements feeburn.Store.GetBySignature
+func (s *store) GetBySignature(ctx context.Context, signature string) (*feeburn.Record, error) {
+	model, err := dbGetBySignature(ctx, s.db, signature)
+	if err != nil {
+		return nil, err
+	}
+	return fromModel(model), nil
+}
+
+// GetLatestByMint implements feeburn.Store.GetLatestByMint
+func (s *store) GetLatestByMint(ctx context.Context, mint string) (*feeburn.Record, error) {
+	model, err := dbGetLatestByMint(ctx, s.db, mint)
+	if err != nil {
+		return nil, err
+	}
+	return fromModel(model), nil
+}
+
+// GetAllByState implements feeburn.Store.GetAllByState
+func (s *store) GetAllByState(ctx context.Context, state feeburn.State, cursor query.Cursor, limit uint64, direction query.Ordering) ([]*feeburn.Record, error) {
+	models, err := dbGetAllByState(ctx, s.db, state, cursor, limit, direction)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*feeburn.Record, len(models))
+	for i, model := range models {
+		res[i] = fromModel(model)
+	}
+	return res, nil
+}
+
+// CountByState implements feeburn.Store.CountByState
+func (s *store) CountByState(ctx context.Context, state feeburn.State) (uint64, error) {
+	return dbCountByState(ctx, s.db, state)
+}
+
+// GetTotalQuarksBurnedByMint implements feeburn.Store.GetTotalQuarksBurnedByMint
+func (s *store) GetTotalQuarksBurnedByMint(ctx context.Context, mint string) (uint64, error) {
+	return dbGetTotalQuarksBurnedByMint(ctx, s.db, mint)
+}
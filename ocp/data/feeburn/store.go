@@ -0,0 +1,53 @@
+package feeburn
+
+import (
+	"context"
+	"errors"
+
+	"github.com/code-payments/ocp-server/database/query"
+)
+
+var (
+	ErrNotFound      = errors.New("fee burn not found")
+	ErrAlreadyExists = errors.New("fee burn already exists")
+	ErrStaleVersion  = errors.New("fee burn version is stale")
+)
+
+// Store tracks transactions that burn sell fees accumulated in launchpad
+// currency liquidity pools
+type Store interface {
+	// Put creates a new fee burn
+	//
+	// Returns ErrAlreadyExists if a fee burn with the same signature exists, or
+	// if the mint already has a pending fee burn.
+	Put(ctx context.Context, record *Record) error
+
+	// Update updates the quarks, transaction blob, fee and state of an existing
+	// fee burn
+	//
+	// Returns ErrNotFound if the fee burn doesn't exist, and ErrStaleVersion if
+	// the record was updated since it was last read.
+	Update(ctx context.Context, record *Record) error
+
+	// GetBySignature gets a fee burn by its transaction signature
+	//
+	// Returns ErrNotFound if no record is found.
+	GetBySignature(ctx context.Context, signature string) (*Record, error)
+
+	// GetLatestByMint gets the most recently created fee burn for a mint
+	//
+	// Returns ErrNotFound if no record is found.
+	GetLatestByMint(ctx context.Context, mint string) (*Record, error)
+
+	// GetAllByState gets all fee burns in a state
+	//
+	// Returns ErrNotFound if no records are found.
+	GetAllByState(ctx context.Context, state State, cursor query.Cursor, limit uint64, direction query.Ordering) ([]*Record, error)
+
+	// CountByState returns the count of fee burns in a state
+	CountByState(ctx context.Context, state State) (uint64, error)
+
+	// GetTotalQuarksBurnedByMint gets the total quarks burned across all
+	// confirmed fee burns for a mint
+	GetTotalQuarksBurnedByMint(ctx context.Context, mint string) (uint64, error)
+}
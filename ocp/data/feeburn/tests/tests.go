@@ -0,0 +1,243 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/code-payments/ocp-server/database/query"
+	"github.com/code-payments/ocp-server/ocp/data/feeburn"
+)
+
+func RunTests(t *testing.T, s feeburn.Store, teardown func()) {
+	for _, tf := range []func(t *testing.T, s feeburn.Store){
+		testRoundTrip,
+		testUpdate,
+		testUniqueness,
+		testGetAllByState,
+		testGetTotalQuarksBurnedByMint,
+	} {
+		tf(t, s)
+		teardown()
+	}
+}
+
+func testRoundTrip(t *testing.T, s feeburn.Store) {
+	t.Run("testRoundTrip", func(t *testing.T) {
+		ctx := context.Background()
+
+		_, err := s.GetBySignature(ctx, "signature1")
+		assert.Equal(t, feeburn.ErrNotFound, err)
+
+		_, err = s.GetLatestByMint(ctx, "mint1")
+		assert.Equal(t, feeburn.ErrNotFound, err)
+
+		expected := newTestRecord("mint1", "signature1", 1_000)
+		cloned := expected.Clone()
+
+		require.NoError(t, s.Put(ctx, expected))
+		assert.True(t, expected.Id > 0)
+		assert.EqualValues(t, 1, expected.Version)
+		assert.False(t, expected.CreatedAt.IsZero())
+		assertEquivalentRecords(t, &cloned, expected)
+
+		actual, err := s.GetBySignature(ctx, "signature1")
+		require.NoError(t, err)
+		assert.Equal(t, expected.Id, actual.Id)
+		assert.Equal(t, expected.Version, actual.Version)
+		assert.Equal(t, expected.CreatedAt.Unix(), actual.CreatedAt.Unix())
+		assertEquivalentRecords(t, expected, actual)
+
+		actual, err = s.GetLatestByMint(ctx, "mint1")
+		require.NoError(t, err)
+		assert.Equal(t, expected.Id, actual.Id)
+		assertEquivalentRecords(t, expected, actual)
+
+		expected.State = feeburn.StateConfirmed
+		require.NoError(t, s.Update(ctx, expected))
+
+		latest := newTestRecord("mint1", "signature2", 2_000)
+		require.NoError(t, s.Put(ctx, latest))
+
+		actual, err = s.GetLatestByMint(ctx, "mint1")
+		require.NoError(t, err)
+		assertEquivalentRecords(t, latest, actual)
+	})
+}
+
+func testUpdate(t *testing.T, s feeburn.Store) {
+	t.Run("testUpdate", func(t *testing.T) {
+		ctx := context.Background()
+
+		record := newTestRecord("mint1", "signature1", 1_000)
+		assert.Equal(t, feeburn.ErrNotFound, s.Update(ctx, record))
+
+		require.NoError(t, s.Put(ctx, record))
+
+		stale := record.Clone()
+
+		record.Quarks = 1_234
+		record.TransactionBlob = nil
+		record.Fee = 5_050
+		record.State = feeburn.StateConfirmed
+		require.NoError(t, s.Update(ctx, record))
+		assert.EqualValues(t, 2, record.Version)
+
+		stale.State = feeburn.StateFailed
+		assert.Equal(t, feeburn.ErrStaleVersion, s.Update(ctx, &stale))
+
+		actual, err := s.GetBySignature(ctx, "signature1")
+		require.NoError(t, err)
+		assertEquivalentRecords(t, record, actual)
+		assert.EqualValues(t, 1_234, actual.Quarks)
+		assert.Empty(t, actual.TransactionBlob)
+		assert.EqualValues(t, 5_050, actual.Fee)
+		assert.Equal(t, feeburn.StateConfirmed, actual.State)
+		assert.EqualValues(t, 2, actual.Version)
+
+		// Only the quarks, transaction blob, fee and state are updatable
+		record.LiquidityPool = "other"
+		require.NoError(t, s.Update(ctx, record))
+
+		actual, err = s.GetBySignature(ctx, "signature1")
+		require.NoError(t, err)
+		assert.Equal(t, "pool_mint1", actual.LiquidityPool)
+	})
+}
+
+func testUniqueness(t *testing.T, s feeburn.Store) {
+	t.Run("testUniqueness", func(t *testing.T) {
+		ctx := context.Background()
+
+		record := newTestRecord("mint1", "signature1", 1_000)
+		require.NoError(t, s.Put(ctx, record))
+
+		assert.Equal(t, feeburn.ErrAlreadyExists, s.Put(ctx, newTestRecord("mint2", "signature1", 1_000)))
+		assert.Equal(t, feeburn.ErrAlreadyExists, s.Put(ctx, newTestRecord("mint1", "signature2", 1_000)))
+		require.NoError(t, s.Put(ctx, newTestRecord("mint2", "signature2", 1_000)))
+
+		// Another fee burn can be made once the pending one is finalized
+		record.TransactionBlob = nil
+		record.State = feeburn.StateFailed
+		require.NoError(t, s.Update(ctx, record))
+		require.NoError(t, s.Put(ctx, newTestRecord("mint1", "signature3", 1_000)))
+	})
+}
+
+func testGetAllByState(t *testing.T, s feeburn.Store) {
+	t.Run("testGetAllByState", func(t *testing.T) {
+		ctx := context.Background()
+
+		_, err := s.GetAllByState(ctx, feeburn.StatePending, query.EmptyCursor, 10, query.Ascending)
+		assert.Equal(t, feeburn.ErrNotFound, err)
+
+		var records []*feeburn.Record
+		for i, mint := range []string{"mint1", "mint2", "mint3", "mint4"} {
+			record := newTestRecord(mint, "signature_"+mint, uint64(i+1))
+			require.NoError(t, s.Put(ctx, record))
+			records = append(records, record)
+		}
+
+		records[1].State = feeburn.StateConfirmed
+		require.NoError(t, s.Update(ctx, records[1]))
+
+		actual, err := s.GetAllByState(ctx, feeburn.StatePending, query.EmptyCursor, 10, query.Ascending)
+		require.NoError(t, err)
+		require.Len(t, actual, 3)
+		assert.Equal(t, records[0].Signature, actual[0].Signature)
+		assert.Equal(t, records[2].Signature, actual[1].Signature)
+		assert.Equal(t, records[3].Signature, actual[2].Signature)
+
+		actual, err = s.GetAllByState(ctx, feeburn.StatePending, query.EmptyCursor, 2, query.Descending)
+		require.NoError(t, err)
+		require.Len(t, actual, 2)
+		assert.Equal(t, records[3].Signature, actual[0].Signature)
+		assert.Equal(t, records[2].Signature, actual[1].Signature)
+
+		actual, err = s.GetAllByState(ctx, feeburn.StatePending, query.ToCursor(records[0].Id), 10, query.Ascending)
+		require.NoError(t, err)
+		require.Len(t, actual, 2)
+		assert.Equal(t, records[2].Signature, actual[0].Signature)
+		assert.Equal(t, records[3].Signature, actual[1].Signature)
+
+		actual, err = s.GetAllByState(ctx, feeburn.StateConfirmed, query.EmptyCursor, 10, query.Ascending)
+		require.NoError(t, err)
+		require.Len(t, actual, 1)
+		assert.Equal(t, records[1].Signature, actual[0].Signature)
+
+		_, err = s.GetAllByState(ctx, feeburn.StateFailed, query.EmptyCursor, 10, query.Ascending)
+		assert.Equal(t, feeburn.ErrNotFound, err)
+
+		for state, expected := range map[feeburn.State]uint64{
+			feeburn.StatePending:   3,
+			feeburn.StateConfirmed: 1,
+			feeburn.StateFailed:    0,
+		} {
+			count, err := s.CountByState(ctx, state)
+			require.NoError(t, err)
+			assert.Equal(t, expected, count)
+		}
+	})
+}
+
+func testGetTotalQuarksBurnedByMint(t *testing.T, s feeburn.Store) {
+	t.Run("testGetTotalQuarksBurnedByMint", func(t *testing.T) {
+		ctx := context.Background()
+
+		total, err := s.GetTotalQuarksBurnedByMint(ctx, "mint1")
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, total)
+
+		for i, state := range []feeburn.State{feeburn.StateConfirmed, feeburn.StateFailed, feeburn.StateConfirmed, feeburn.StatePending} {
+			record := newTestRecord("mint1", fmt.Sprintf("signature%d", i), uint64(10*(i+1)))
+			require.NoError(t, s.Put(ctx, record))
+
+			if state != feeburn.StatePending {
+				record.TransactionBlob = nil
+				record.State = state
+				require.NoError(t, s.Update(ctx, record))
+			}
+		}
+
+		other := newTestRecord("mint2", "other", 1_000)
+		require.NoError(t, s.Put(ctx, other))
+		other.State = feeburn.StateConfirmed
+		require.NoError(t, s.Update(ctx, other))
+
+		total, err = s.GetTotalQuarksBurnedByMint(ctx, "mint1")
+		require.NoError(t, err)
+		assert.EqualValues(t, 40, total)
+	})
+}
+
+func newTestRecord(mint, signature string, quarks uint64) *feeburn.Record {
+	return &feeburn.Record{
+		Mint:          mint,
+		LiquidityPool: "pool_" + mint,
+
+		Quarks: quarks,
+
+		Signature: signature,
+		Nonce:     "nonce",
+		Blockhash: "blockhash",
+
+		TransactionBlob: []byte("transaction"),
+
+		State: feeburn.StatePending,
+	}
+}
+
+func assertEquivalentRecords(t *testing.T, obj1, obj2 *feeburn.Record) {
+	assert.Equal(t, obj1.Mint, obj2.Mint)
+	assert.Equal(t, obj1.LiquidityPool, obj2.LiquidityPool)
+	assert.Equal(t, obj1.Quarks, obj2.Quarks)
+	assert.Equal(t, obj1.Signature, obj2.Signature)
+	assert.Equal(t, obj1.Nonce, obj2.Nonce)
+	assert.Equal(t, obj1.Blockhash, obj2.Blockhash)
+	assert.EqualValues(t, obj1.TransactionBlob, obj2.TransactionBlob)
+	assert.Equal(t, obj1.Fee, obj2.Fee)
+	assert.Equal(t, obj1.State, obj2.State)
+}
@@ -18,6 +18,9 @@ const (
 	NoPrivacyTransferWithAuthority
 	NoPrivacyWithdraw
 	CloseEmptyTimelockAccount
+	InitializeLaunchpadCurrency
+	CreateLaunchpadCurrencyAlt
+	InitializeLaunchpadCurrencyVm
 )
 
 type State uint8
@@ -46,9 +49,9 @@ type Record struct {
 	Nonce     *string
 	Blockhash *string
 
-	// todo: For virtual instructions, assumes a single one per fulfillment.
-	//       We'll need new modelling when we get around to batching virtual
-	//       instructions, but we're starting with the easiest implementation.
+	// Virtual instructions are always a single one per fulfillment. When packed
+	// alongside others into a shared transaction, the Solana transaction fields
+	// above are left empty and the batch store links the fulfillment to it.
 	VirtualSignature *string
 	VirtualNonce     *string
 	VirtualBlockhash *string
@@ -277,6 +280,12 @@ func (s Type) String() string {
 		return "no_privacy_withdraw"
 	case CloseEmptyTimelockAccount:
 		return "close_empty_timelock_account"
+	case InitializeLaunchpadCurrency:
+		return "initialize_launchpad_currency"
+	case CreateLaunchpadCurrencyAlt:
+		return "create_launchpad_currency_alt"
+	case InitializeLaunchpadCurrencyVm:
+		return "initialize_launchpad_currency_vm"
 	}
 
 	return "unknown"
@@ -16,9 +16,15 @@ import (
 type store struct {
 	mu      sync.Mutex
 	records []*fulfillment.Record
+	leases  map[uint64]*lease
 	last    uint64
 }
 
+type lease struct {
+	nodeId    string
+	expiresAt time.Time
+}
+
 type ById []*fulfillment.Record
 
 func (a ById) Len() int           { return len(a) }
@@ -28,6 +34,7 @@ func (a ById) Less(i, j int) bool { return a[i].Id < a[j].Id }
 func New() fulfillment.Store {
 	return &store{
 		records: make([]*fulfillment.Record, 0),
+		leases:  make(map[uint64]*lease),
 		last:    0,
 	}
 }
@@ -35,6 +42,7 @@ func New() fulfillment.Store {
 func (s *store) reset() {
 	s.mu.Lock()
 	s.records = make([]*fulfillment.Record, 0)
+	s.leases = make(map[uint64]*lease)
 	s.last = 0
 	s.mu.Unlock()
 }
@@ -217,6 +225,19 @@ func (s *store) filterDisabledActiveScheduling(items []*fulfillment.Record) []*f
 	return res
 }
 
+func (s *store) filterClaimable(items []*fulfillment.Record, nodeId string) []*fulfillment.Record {
+	now := time.Now()
+
+	var res []*fulfillment.Record
+	for _, item := range items {
+		existing, ok := s.leases[item.Id]
+		if !ok || existing.nodeId == nodeId || existing.expiresAt.Before(now) {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
 func (s *store) filterScheduledAfter(items []*fulfillment.Record, intentOrderingIndex uint64, actionOrderingIndex, fulfillmentOrderingIndex uint32) []*fulfillment.Record {
 	var res []*fulfillment.Record
 	for _, item := range items {
@@ -447,6 +468,61 @@ func (s *store) GetAllByState(ctx context.Context, state fulfillment.State, incl
 	return nil, fulfillment.ErrFulfillmentNotFound
 }
 
+func (s *store) ClaimAllByState(ctx context.Context, state fulfillment.State, nodeId string, leaseExpiresAt time.Time, cursor query.Cursor, limit uint64) ([]*fulfillment.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := s.filterDisabledActiveScheduling(s.findByState(state))
+	items = s.filterClaimable(items, nodeId)
+	res := s.filter(items, cursor, limit, query.Ascending)
+	if len(res) == 0 {
+		return nil, fulfillment.ErrFulfillmentNotFound
+	}
+
+	for _, item := range res {
+		s.leases[item.Id] = &lease{
+			nodeId:    nodeId,
+			expiresAt: leaseExpiresAt,
+		}
+	}
+
+	return cloneAll(res), nil
+}
+
+func (s *store) ClaimById(ctx context.Context, id uint64, nodeId string, leaseExpiresAt time.Time) (*fulfillment.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item := s.findById(id)
+	if item == nil {
+		return nil, fulfillment.ErrFulfillmentNotFound
+	}
+
+	items := s.filterDisabledActiveScheduling([]*fulfillment.Record{item})
+	items = s.filterClaimable(items, nodeId)
+	if len(items) == 0 {
+		return nil, fulfillment.ErrFulfillmentNotFound
+	}
+
+	s.leases[item.Id] = &lease{
+		nodeId:    nodeId,
+		expiresAt: leaseExpiresAt,
+	}
+
+	cloned := item.Clone()
+	return &cloned, nil
+}
+
+func (s *store) ReleaseLease(ctx context.Context, id uint64, nodeId string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.leases[id]; ok && existing.nodeId == nodeId {
+		delete(s.leases, id)
+	}
+	return nil
+}
+
 func (s *store) GetAllByIntent(ctx context.Context, intent string, cursor query.Cursor, limit uint64, direction query.Ordering) ([]*fulfillment.Record, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
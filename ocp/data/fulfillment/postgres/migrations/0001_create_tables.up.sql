@@ -0,0 +1,37 @@
+CREATE TABLE ocp__core_fulfillment(
+	id SERIAL NOT NULL PRIMARY KEY,
+
+	intent TEXT NOT NULL,
+	intent_type INTEGER NOT NULL,
+
+	action_id INTEGER NOT NULL,
+	action_type INTEGER NOT NULL,
+
+	fulfillment_type INTEGER NOT NULL,
+	data BYTEA NULL,
+	signature TEXT NULL UNIQUE,
+
+	nonce TEXT NULL,
+	blockhash TEXT NULL,
+
+	virtual_signature TEXT NULL UNIQUE,
+	virtual_nonce TEXT NULL,
+	virtual_blockhash TEXT NULL,
+
+	source TEXT NOT NULL,
+	destination TEXT NULL,
+
+	intent_ordering_index BIGINT NOT NULL,
+	action_ordering_index INTEGER NOT NULL,
+	fulfillment_ordering_index INTEGER NOT NULL,
+
+	disable_active_scheduling BOOL NOT NULL,
+
+	state INTEGER NOT NULL,
+
+	version INTEGER NOT NULL,
+
+	batch_insertion_id INTEGER NOT NULL,
+
+	created_at timestamp with time zone NOT NULL
+);
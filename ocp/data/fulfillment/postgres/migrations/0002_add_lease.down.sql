@@ -0,0 +1,3 @@
+ALTER TABLE ocp__core_fulfillment
+	DROP COLUMN lease_node_id,
+	DROP COLUMN lease_expires_at;
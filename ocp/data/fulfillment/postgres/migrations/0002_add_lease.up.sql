@@ -0,0 +1,3 @@
+ALTER TABLE ocp__core_fulfillment
+	ADD COLUMN lease_node_id TEXT NULL,
+	ADD COLUMN lease_expires_at TIMESTAMP WITH TIME ZONE NULL;
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -463,6 +464,90 @@ func dbGetAllByState(ctx context.Context, db *sqlx.DB, state fulfillment.State,
 	return res, nil
 }
 
+func dbClaimAllByState(ctx context.Context, db *sqlx.DB, state fulfillment.State, nodeId string, leaseExpiresAt time.Time, cursor q.Cursor, limit uint64) ([]*fulfillmentModel, error) {
+	res := []*fulfillmentModel{}
+
+	var start uint64
+	if len(cursor) > 0 {
+		start = cursor.ToUint64()
+	}
+
+	err := pgutil.ExecuteInTx(ctx, db, sql.LevelDefault, func(tx *sqlx.Tx) error {
+		// Rows locked by a concurrent claim are skipped, so nodes claiming at
+		// the same time get disjoint sets of records
+		query := `WITH claimable AS (
+				SELECT id FROM ` + fulfillmentTableName + `
+				WHERE state = $1 AND disable_active_scheduling IS FALSE AND id > $2 AND (lease_node_id IS NULL OR lease_node_id = $3 OR lease_expires_at < $4)
+				ORDER BY id ASC
+				LIMIT $5
+				FOR UPDATE SKIP LOCKED
+			)
+			UPDATE ` + fulfillmentTableName + `
+			SET lease_node_id = $3, lease_expires_at = $6
+			FROM claimable
+			WHERE ` + fulfillmentTableName + `.id = claimable.id
+			RETURNING
+				` + fulfillmentTableName + `.id, intent, intent_type, action_id, action_type, fulfillment_type, data, signature, nonce, blockhash, virtual_signature, virtual_nonce, virtual_blockhash, source, destination, intent_ordering_index, action_ordering_index, fulfillment_ordering_index, disable_active_scheduling, state, version, created_at`
+
+		return tx.SelectContext(
+			ctx,
+			&res,
+			query,
+			state,
+			start,
+			nodeId,
+			time.Now(),
+			limit,
+			leaseExpiresAt,
+		)
+	})
+	if err != nil {
+		return nil, pgutil.CheckNoRows(err, fulfillment.ErrFulfillmentNotFound)
+	}
+
+	if len(res) == 0 {
+		return nil, fulfillment.ErrFulfillmentNotFound
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Id < res[j].Id
+	})
+
+	return res, nil
+}
+
+func dbClaimById(ctx context.Context, db *sqlx.DB, id uint64, nodeId string, leaseExpiresAt time.Time) (*fulfillmentModel, error) {
+	res := &fulfillmentModel{}
+
+	query := `UPDATE ` + fulfillmentTableName + `
+		SET lease_node_id = $2, lease_expires_at = $4
+		WHERE id = $1 AND disable_active_scheduling IS FALSE AND (lease_node_id IS NULL OR lease_node_id = $2 OR lease_expires_at < $3)
+		RETURNING
+			id, intent, intent_type, action_id, action_type, fulfillment_type, data, signature, nonce, blockhash, virtual_signature, virtual_nonce, virtual_blockhash, source, destination, intent_ordering_index, action_ordering_index, fulfillment_ordering_index, disable_active_scheduling, state, version, created_at`
+
+	err := db.QueryRowxContext(
+		ctx,
+		query,
+		id,
+		nodeId,
+		time.Now(),
+		leaseExpiresAt,
+	).StructScan(res)
+	if err != nil {
+		return nil, pgutil.CheckNoRows(err, fulfillment.ErrFulfillmentNotFound)
+	}
+	return res, nil
+}
+
+func dbReleaseLease(ctx context.Context, db *sqlx.DB, id uint64, nodeId string) error {
+	query := `UPDATE ` + fulfillmentTableName + `
+		SET lease_node_id = NULL, lease_expires_at = NULL
+		WHERE id = $1 AND lease_node_id = $2`
+
+	_, err := db.ExecContext(ctx, query, id, nodeId)
+	return err
+}
+
 func dbGetAllByIntent(ctx context.Context, db *sqlx.DB, intent string, cursor q.Cursor, limit uint64, direction q.Ordering) ([]*fulfillmentModel, error) {
 	res := []*fulfillmentModel{}
 
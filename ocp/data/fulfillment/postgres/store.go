@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 
@@ -146,6 +147,35 @@ func (s *store) GetAllByState(ctx context.Context, state fulfillment.State, incl
 	return fulfillments, nil
 }
 
+// ClaimAllByState implements fulfillment.Store.ClaimAllByState
+func (s *store) ClaimAllByState(ctx context.Context, state fulfillment.State, nodeId string, leaseExpiresAt time.Time, cursor query.Cursor, limit uint64) ([]*fulfillment.Record, error) {
+	models, err := dbClaimAllByState(ctx, s.db, state, nodeId, leaseExpiresAt, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	fulfillments := make([]*fulfillment.Record, len(models))
+	for i, model := range models {
+		fulfillments[i] = fromFulfillmentModel(model)
+	}
+
+	return fulfillments, nil
+}
+
+// ClaimById implements fulfillment.Store.ClaimById
+func (s *store) ClaimById(ctx context.Context, id uint64, nodeId string, leaseExpiresAt time.Time) (*fulfillment.Record, error) {
+	model, err := dbClaimById(ctx, s.db, id, nodeId, leaseExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return fromFulfillmentModel(model), nil
+}
+
+// ReleaseLease implements fulfillment.Store.ReleaseLease
+func (s *store) ReleaseLease(ctx context.Context, id uint64, nodeId string) error {
+	return dbReleaseLease(ctx, s.db, id, nodeId)
+}
+
 // GetAllByIntent implements fulfillment.Store.GetAllByIntent
 func (s *store) GetAllByIntent(ctx context.Context, intent string, cursor query.Cursor, limit uint64, direction query.Ordering) ([]*fulfillment.Record, error) {
 	models, err := dbGetAllByIntent(ctx, s.db, intent, cursor, limit, direction)
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"os"
 	"testing"
@@ -11,59 +12,12 @@ import (
 	"github.com/code-payments/ocp-server/ocp/data/fulfillment"
 	"github.com/code-payments/ocp-server/ocp/data/fulfillment/tests"
 
+	"github.com/code-payments/ocp-server/database/postgres/migration"
 	postgrestest "github.com/code-payments/ocp-server/database/postgres/test"
 
 	_ "github.com/jackc/pgx/v4/stdlib"
 )
 
-const (
-	// Used for testing ONLY, the table and migrations are external to this repository
-	tableCreate = `
-		CREATE TABLE ocp__core_fulfillment(
-			id SERIAL NOT NULL PRIMARY KEY,
-
-			intent TEXT NOT NULL,
-			intent_type INTEGER NOT NULL,
-
-			action_id INTEGER NOT NULL,
-			action_type INTEGER NOT NULL,
-
-			fulfillment_type INTEGER NOT NULL,
-			data BYTEA NULL,
-			signature TEXT NULL UNIQUE,
-
-			nonce TEXT NULL,
-			blockhash TEXT NULL,
-
-			virtual_signature TEXT NULL UNIQUE,
-			virtual_nonce TEXT NULL,
-			virtual_blockhash TEXT NULL,
-
-			source TEXT NOT NULL,
-			destination TEXT NULL,
-
-			intent_ordering_index BIGINT NOT NULL,
-			action_ordering_index INTEGER NOT NULL,
-			fulfillment_ordering_index INTEGER NOT NULL,
-
-			disable_active_scheduling BOOL NOT NULL,
-
-			state INTEGER NOT NULL,
-
-			version INTEGER NOT NULL,
-
-			batch_insertion_id INTEGER NOT NULL,
-
-			created_at timestamp with time zone NOT NULL
-		);
-	`
-
-	// Used for testing ONLY, the table and migrations are external to this repository
-	tableDestroy = `
-		DROP TABLE ocp__core_fulfillment;
-	`
-)
-
 var (
 	testStore fulfillment.Store
 	teardown  func()
@@ -116,7 +70,13 @@ func TestFulfillmentPostgresStore(t *testing.T) {
 }
 
 func createTestTables(log *zap.Logger, db *sql.DB) error {
-	_, err := db.Exec(tableCreate)
+	migrations, err := Migrations()
+	if err != nil {
+		log.With(zap.Error(err)).Error("could not load migrations")
+		return err
+	}
+
+	err = migration.Up(context.Background(), db, migrations)
 	if err != nil {
 		log.With(zap.Error(err)).Error("could not create test tables")
 		return err
@@ -125,7 +85,13 @@ func createTestTables(log *zap.Logger, db *sql.DB) error {
 }
 
 func resetTestTables(log *zap.Logger, db *sql.DB) error {
-	_, err := db.Exec(tableDestroy)
+	migrations, err := Migrations()
+	if err != nil {
+		log.With(zap.Error(err)).Error("could not load migrations")
+		return err
+	}
+
+	err = migration.Down(context.Background(), db, migrations)
 	if err != nil {
 		log.With(zap.Error(err)).Error("could not drop test tables")
 		return err
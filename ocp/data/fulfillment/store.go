@@ -2,6 +2,7 @@ package fulfillment
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -57,6 +58,26 @@ type Store interface {
 	// Returns ErrNotFound if no records are found.
 	GetAllByState(ctx context.Context, state State, includeDisabledActiveScheduling bool, cursor query.Cursor, limit uint64, direction query.Ordering) ([]*Record, error)
 
+	// ClaimAllByState leases fulfillment records in a given state to a node,
+	// so that multiple workers can process disjoint sets of records. Records
+	// that are unleased, whose lease has expired, or that are already leased to
+	// the node are claimed in ascending order after the cursor. Records with
+	// active scheduling disabled are never claimed.
+	//
+	// Returns ErrNotFound if no records are claimed.
+	ClaimAllByState(ctx context.Context, state State, nodeId string, leaseExpiresAt time.Time, cursor query.Cursor, limit uint64) ([]*Record, error)
+
+	// ClaimById leases a single fulfillment record to a node, under the same
+	// rules as ClaimAllByState, regardless of its state.
+	//
+	// Returns ErrNotFound if the record doesn't exist or can't be claimed.
+	ClaimById(ctx context.Context, id uint64, nodeId string, leaseExpiresAt time.Time) (*Record, error)
+
+	// ReleaseLease releases the lease held by a node on a fulfillment record,
+	// so it's immediately available to be claimed by other nodes. Releasing a
+	// lease that isn't held by the node is a no-op.
+	ReleaseLease(ctx context.Context, id uint64, nodeId string) error
+
 	// GetAllByIntent returns all fulfillment records for a given intent.
 	//
 	// Returns ErrNotFound if no records are found.
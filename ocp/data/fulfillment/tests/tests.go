@@ -24,6 +24,8 @@ func RunTests(t *testing.T, s fulfillment.Store, teardown func()) {
 		testUpdateHappyPath,
 		testUpdateStaleVersion,
 		testGetAllByState,
+		testClaimAllByState,
+		testClaimById,
 		testGetAllByIntent,
 		testGetAllByAction,
 		testGetCount,
@@ -494,6 +496,151 @@ func testGetAllByState(t *testing.T, s fulfillment.Store) {
 	})
 }
 
+func testClaimAllByState(t *testing.T, s fulfillment.Store) {
+	t.Run("testClaimAllByState", func(t *testing.T) {
+		ctx := context.Background()
+
+		_, err := s.ClaimAllByState(ctx, fulfillment.StateUnknown, "node1", time.Now().Add(time.Minute), query.EmptyCursor, 10)
+		assert.Equal(t, fulfillment.ErrFulfillmentNotFound, err)
+
+		records := []*fulfillment.Record{
+			{Signature: pointer.String("t1"), State: fulfillment.StateUnknown},
+			{Signature: pointer.String("t2"), State: fulfillment.StateUnknown},
+			{Signature: pointer.String("t3"), State: fulfillment.StatePending},
+			{Signature: pointer.String("t4"), State: fulfillment.StateUnknown, DisableActiveScheduling: true},
+			{Signature: pointer.String("t5"), State: fulfillment.StateUnknown},
+			{Signature: pointer.String("t6"), State: fulfillment.StateUnknown},
+			{Signature: pointer.String("t7"), State: fulfillment.StateUnknown},
+		}
+
+		// Fill in required fields that have no relevancy to this test
+		for i, record := range records {
+			record.IntentType = intent.SendPublicPayment
+			record.Intent = fmt.Sprintf("i%d", i+1)
+			record.ActionType = action.NoPrivacyTransfer
+			record.FulfillmentType = fulfillment.NoPrivacyTransferWithAuthority
+			record.Data = []byte(fmt.Sprintf("d%d", i+1))
+			record.Nonce = pointer.String(fmt.Sprintf("n%d", i+1))
+			record.Blockhash = pointer.String(fmt.Sprintf("bh%d", i+1))
+			record.Source = "test_source"
+			record.Destination = pointer.String("test_destination")
+		}
+
+		require.NoError(t, s.PutAll(ctx, records...))
+
+		// Nodes claim disjoint sets of records
+		node1Claimed, err := s.ClaimAllByState(ctx, fulfillment.StateUnknown, "node1", time.Now().Add(time.Minute), query.EmptyCursor, 2)
+		require.NoError(t, err)
+		require.Len(t, node1Claimed, 2)
+		assertEquivalentRecords(t, records[0], node1Claimed[0])
+		assertEquivalentRecords(t, records[1], node1Claimed[1])
+
+		node2Claimed, err := s.ClaimAllByState(ctx, fulfillment.StateUnknown, "node2", time.Now().Add(time.Minute), query.EmptyCursor, 10)
+		require.NoError(t, err)
+		require.Len(t, node2Claimed, 3)
+		assertEquivalentRecords(t, records[4], node2Claimed[0])
+		assertEquivalentRecords(t, records[5], node2Claimed[1])
+		assertEquivalentRecords(t, records[6], node2Claimed[2])
+
+		_, err = s.ClaimAllByState(ctx, fulfillment.StateUnknown, "node3", time.Now().Add(time.Minute), query.EmptyCursor, 10)
+		assert.Equal(t, fulfillment.ErrFulfillmentNotFound, err)
+
+		// Claiming doesn't affect the record's version
+		actual, err := s.GetById(ctx, records[0].Id)
+		require.NoError(t, err)
+		assertEquivalentRecords(t, records[0], actual)
+
+		// A node can reclaim records it already holds a lease on, and page
+		// through them with a cursor
+		node1Claimed, err = s.ClaimAllByState(ctx, fulfillment.StateUnknown, "node1", time.Now().Add(time.Minute), query.ToCursor(records[0].Id), 10)
+		require.NoError(t, err)
+		require.Len(t, node1Claimed, 1)
+		assertEquivalentRecords(t, records[1], node1Claimed[0])
+
+		// Releasing a lease held by another node is a no-op
+		require.NoError(t, s.ReleaseLease(ctx, records[0].Id, "node3"))
+		_, err = s.ClaimAllByState(ctx, fulfillment.StateUnknown, "node3", time.Now().Add(time.Minute), query.EmptyCursor, 10)
+		assert.Equal(t, fulfillment.ErrFulfillmentNotFound, err)
+
+		// Released leases can be claimed by other nodes
+		require.NoError(t, s.ReleaseLease(ctx, records[0].Id, "node1"))
+		node3Claimed, err := s.ClaimAllByState(ctx, fulfillment.StateUnknown, "node3", time.Now().Add(-time.Minute), query.EmptyCursor, 10)
+		require.NoError(t, err)
+		require.Len(t, node3Claimed, 1)
+		assertEquivalentRecords(t, records[0], node3Claimed[0])
+
+		// Expired leases can be claimed by other nodes
+		node2Claimed, err = s.ClaimAllByState(ctx, fulfillment.StateUnknown, "node2", time.Now().Add(time.Minute), query.EmptyCursor, 10)
+		require.NoError(t, err)
+		require.Len(t, node2Claimed, 4)
+		assertEquivalentRecords(t, records[0], node2Claimed[0])
+
+		// Records in other states are claimed independently
+		pendingClaimed, err := s.ClaimAllByState(ctx, fulfillment.StatePending, "node1", time.Now().Add(time.Minute), query.EmptyCursor, 10)
+		require.NoError(t, err)
+		require.Len(t, pendingClaimed, 1)
+		assertEquivalentRecords(t, records[2], pendingClaimed[0])
+	})
+}
+
+func testClaimById(t *testing.T, s fulfillment.Store) {
+	t.Run("testClaimById", func(t *testing.T) {
+		ctx := context.Background()
+
+		_, err := s.ClaimById(ctx, 1, "node1", time.Now().Add(time.Minute))
+		assert.Equal(t, fulfillment.ErrFulfillmentNotFound, err)
+
+		records := []*fulfillment.Record{
+			{Signature: pointer.String("t1"), State: fulfillment.StateUnknown},
+			{Signature: pointer.String("t2"), State: fulfillment.StatePending},
+			{Signature: pointer.String("t3"), State: fulfillment.StateUnknown, DisableActiveScheduling: true},
+		}
+
+		// Fill in required fields that have no relevancy to this test
+		for i, record := range records {
+			record.IntentType = intent.SendPublicPayment
+			record.Intent = fmt.Sprintf("i%d", i+1)
+			record.ActionType = action.NoPrivacyTransfer
+			record.FulfillmentType = fulfillment.NoPrivacyTransferWithAuthority
+			record.Data = []byte(fmt.Sprintf("d%d", i+1))
+			record.Nonce = pointer.String(fmt.Sprintf("n%d", i+1))
+			record.Blockhash = pointer.String(fmt.Sprintf("bh%d", i+1))
+			record.Source = "test_source"
+			record.Destination = pointer.String("test_destination")
+		}
+
+		require.NoError(t, s.PutAll(ctx, records...))
+
+		// Records are claimed regardless of state
+		for _, record := range records[:2] {
+			claimed, err := s.ClaimById(ctx, record.Id, "node1", time.Now().Add(time.Minute))
+			require.NoError(t, err)
+			assertEquivalentRecords(t, record, claimed)
+
+			// The lease holder can reclaim the record
+			_, err = s.ClaimById(ctx, record.Id, "node1", time.Now().Add(time.Minute))
+			require.NoError(t, err)
+
+			_, err = s.ClaimById(ctx, record.Id, "node2", time.Now().Add(time.Minute))
+			assert.Equal(t, fulfillment.ErrFulfillmentNotFound, err)
+		}
+
+		// Records with active scheduling disabled are never claimed
+		_, err = s.ClaimById(ctx, records[2].Id, "node1", time.Now().Add(time.Minute))
+		assert.Equal(t, fulfillment.ErrFulfillmentNotFound, err)
+
+		// Leases are shared with batch claims
+		_, err = s.ClaimAllByState(ctx, fulfillment.StateUnknown, "node2", time.Now().Add(time.Minute), query.EmptyCursor, 10)
+		assert.Equal(t, fulfillment.ErrFulfillmentNotFound, err)
+
+		// Released leases can be claimed by other nodes
+		require.NoError(t, s.ReleaseLease(ctx, records[0].Id, "node1"))
+		claimed, err := s.ClaimById(ctx, records[0].Id, "node2", time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assertEquivalentRecords(t, records[0], claimed)
+	})
+}
+
 func testGetAllByIntent(t *testing.T, s fulfillment.Store) {
 	t.Run("testGetAllByIntent", func(t *testing.T) {
 		ctx := context.Background()
@@ -26,6 +26,7 @@ const (
 	SendPublicPayment
 	ReceivePaymentsPublicly
 	PublicDistribution
+	LaunchCurrency
 )
 
 type Record struct {
@@ -43,6 +44,7 @@ type Record struct {
 	SendPublicPaymentMetadata       *SendPublicPaymentMetadata
 	ReceivePaymentsPubliclyMetadata *ReceivePaymentsPubliclyMetadata
 	PublicDistributionMetadata      *PublicDistributionMetadata
+	LaunchCurrencyMetadata          *LaunchCurrencyMetadata
 
 	State State
 
@@ -97,6 +99,12 @@ type PublicDistributionMetadata struct {
 	UsdMarketValue float64
 }
 
+// LaunchCurrencyMetadata is intentionally empty, since details of the currency
+// being launched are tracked in the currency launch store. The mint is the intent's
+// mint account.
+type LaunchCurrencyMetadata struct {
+}
+
 type Distribution struct {
 	DestinationOwnerAccount string
 	DestinationTokenAccount string
@@ -138,6 +146,12 @@ func (r *Record) Clone() Record {
 		publicDistributionMetadata = &cloned
 	}
 
+	var launchCurrencyMetadata *LaunchCurrencyMetadata
+	if r.LaunchCurrencyMetadata != nil {
+		cloned := r.LaunchCurrencyMetadata.Clone()
+		launchCurrencyMetadata = &cloned
+	}
+
 	return Record{
 		Id: r.Id,
 
@@ -153,6 +167,7 @@ func (r *Record) Clone() Record {
 		SendPublicPaymentMetadata:       sendPublicPaymentMetadata,
 		ReceivePaymentsPubliclyMetadata: receivePaymentsPubliclyMetadata,
 		PublicDistributionMetadata:      publicDistributionMetadata,
+		LaunchCurrencyMetadata:          launchCurrencyMetadata,
 
 		State: r.State,
 
@@ -177,6 +192,7 @@ func (r *Record) CopyTo(dst *Record) {
 	dst.SendPublicPaymentMetadata = r.SendPublicPaymentMetadata
 	dst.ReceivePaymentsPubliclyMetadata = r.ReceivePaymentsPubliclyMetadata
 	dst.PublicDistributionMetadata = r.PublicDistributionMetadata
+	dst.LaunchCurrencyMetadata = r.LaunchCurrencyMetadata
 
 	dst.State = r.State
 
@@ -257,6 +273,17 @@ func (r *Record) Validate() error {
 		}
 	}
 
+	if r.IntentType == LaunchCurrency {
+		if r.LaunchCurrencyMetadata == nil {
+			return errors.New("launch currency metadata must be present")
+		}
+
+		err := r.LaunchCurrencyMetadata.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -467,6 +494,17 @@ func (m *PublicDistributionMetadata) Validate() error {
 	return nil
 }
 
+func (m *LaunchCurrencyMetadata) Clone() LaunchCurrencyMetadata {
+	return LaunchCurrencyMetadata{}
+}
+
+func (m *LaunchCurrencyMetadata) CopyTo(dst *LaunchCurrencyMetadata) {
+}
+
+func (m *LaunchCurrencyMetadata) Validate() error {
+	return nil
+}
+
 func (m *Distribution) Clone() Distribution {
 	return Distribution{
 		DestinationOwnerAccount: m.DestinationOwnerAccount,
@@ -540,6 +578,8 @@ func (t Type) String() string {
 		return "receive_payments_publicly"
 	case PublicDistribution:
 		return "public_distribution"
+	case LaunchCurrency:
+		return "launch_currency"
 	}
 
 	return "unknown"
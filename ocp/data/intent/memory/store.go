@@ -364,3 +364,40 @@ func (s *store) GetTransactedAmountForAntiMoneyLaundering(ctx context.Context, o
 	items = s.filterByWithdrawalFlag(items, false)
 	return sumQuarkAmount(items), sumUsdMarketValue(items), nil
 }
+
+func (s *store) GetTransactedCountForAntiMoneyLaundering(ctx context.Context, owner string, since time.Time) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := s.findByInitiatorOwnerSinceTimestamp(owner, since)
+	items = s.filterByType(items, intent.SendPublicPayment)
+	items = s.filterByState(items, false, intent.StateRevoked)
+	items = s.filterByWithdrawalFlag(items, false)
+	return uint64(len(items)), nil
+}
+
+func (s *store) GetReceivedAmountForAntiMoneyLaundering(ctx context.Context, owner string, since time.Time) (uint64, float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var items []*intent.Record
+	for _, item := range s.records {
+		if item.CreatedAt.Before(since) || item.State == intent.StateRevoked {
+			continue
+		}
+
+		switch item.IntentType {
+		case intent.SendPublicPayment:
+			metadata := item.SendPublicPaymentMetadata
+			if metadata.DestinationOwnerAccount == owner && item.InitiatorOwnerAccount != owner && !metadata.IsWithdrawal {
+				items = append(items, item)
+			}
+		case intent.ReceivePaymentsPublicly:
+			metadata := item.ReceivePaymentsPubliclyMetadata
+			if item.InitiatorOwnerAccount == owner && !metadata.IsReturned && !metadata.IsIssuerVoidingGiftCard {
+				items = append(items, item)
+			}
+		}
+	}
+	return sumQuarkAmount(items), sumUsdMarketValue(items), nil
+}
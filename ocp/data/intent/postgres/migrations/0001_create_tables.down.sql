@@ -0,0 +1,2 @@
+DROP TABLE ocp__core_intent;
+DROP TABLE ocp__core_intentaccountmetadata;
@@ -0,0 +1,49 @@
+CREATE TABLE ocp__core_intent(
+	id SERIAL NOT NULL PRIMARY KEY,
+
+	intent_id TEXT NOT NULL UNIQUE,
+	intent_type INTEGER NOT NULL,
+
+	mint TEXT NULL,
+
+	owner TEXT NOT NULL,
+	source TEXT NULL,
+	destination TEXT NULL,
+	destination_owner TEXT NULL,
+
+	quantity BIGINT NULL CHECK (quantity >= 0),
+
+	exchange_currency VARCHAR(3) NULL,
+	exchange_rate NUMERIC(18, 9) NULL,
+	native_amount NUMERIC(18, 9) NULL,
+	usd_market_value NUMERIC(18, 9) NULL,
+
+	is_withdraw BOOL NOT NULL,
+	is_deposit BOOL NOT NULL,
+	is_remote_send BOOL NOT NULL,
+	is_returned BOOL NOT NULL,
+	is_issuer_voiding_gift_card BOOL NOT NULL,
+
+	state INTEGER NOT NULL,
+
+	version INTEGER NOT NULL,
+
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE TABLE ocp__core_intentaccountmetadata(
+	id SERIAL NOT NULL PRIMARY KEY,
+
+	paging_id BIGINT NOT NULL CHECK (paging_id > 0),
+
+	source TEXT NULL,
+	source_owner TEXT NULL,
+
+	destination TEXT NULL,
+	destination_owner TEXT NULL,
+
+	quantity BIGINT NULL CHECK (quantity >= 0),
+
+	CONSTRAINT ocp__core_intentaccountmetadata__uniq__paging_id__and__source UNIQUE (paging_id, source),
+	CONSTRAINT ocp__core_intentaccountmetadata__uniq__paging_id__and__destination UNIQUE (paging_id, destination)
+);
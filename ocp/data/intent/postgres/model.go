@@ -79,6 +79,7 @@ func toIntentModel(obj *intent.Record) (*intentModel, error) {
 
 	switch obj.IntentType {
 	case intent.OpenAccounts:
+	case intent.LaunchCurrency:
 	case intent.ExternalDeposit:
 		m.DestinationTokenAccount = obj.ExternalDepositMetadata.DestinationTokenAccount
 		m.Quantity = obj.ExternalDepositMetadata.Quantity
@@ -144,6 +145,8 @@ func fromIntentModel(obj *intentModel) *intent.Record {
 	switch record.IntentType {
 	case intent.OpenAccounts:
 		record.OpenAccountsMetadata = &intent.OpenAccountsMetadata{}
+	case intent.LaunchCurrency:
+		record.LaunchCurrencyMetadata = &intent.LaunchCurrencyMetadata{}
 	case intent.ExternalDeposit:
 		record.ExternalDepositMetadata = &intent.ExternalDepositMetadata{
 			DestinationTokenAccount: obj.DestinationTokenAccount,
@@ -555,3 +558,58 @@ func dbGetTransactedAmountForAntiMoneyLaundering(ctx context.Context, db *sqlx.D
 	}
 	return uint64(res.TotalQuarkValue.Int64), res.TotalUsdMarketValue.Float64, nil
 }
+
+func dbGetTransactedCountForAntiMoneyLaundering(ctx context.Context, db *sqlx.DB, owner string, since time.Time) (uint64, error) {
+	var res uint64
+
+	query := `SELECT COUNT(*) FROM ` + intentTableName + `
+		WHERE owner = $1 AND created_at >= $2 AND intent_type = $3 AND state != $4 AND is_withdraw = FALSE
+	`
+	err := db.GetContext(
+		ctx,
+		&res,
+		query,
+		owner,
+		since,
+		intent.SendPublicPayment,
+		intent.StateRevoked,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res, nil
+}
+
+func dbGetReceivedAmountForAntiMoneyLaundering(ctx context.Context, db *sqlx.DB, owner string, since time.Time) (uint64, float64, error) {
+	res := struct {
+		TotalQuarkValue     sql.NullInt64   `db:"total_quark_value"`
+		TotalUsdMarketValue sql.NullFloat64 `db:"total_usd_value"`
+	}{}
+
+	// Payments sent directly to the owner, and payments the owner received
+	// publicly (eg. claimed gift cards)
+	query := `SELECT SUM(quantity) AS total_quark_value, SUM(usd_market_value) AS total_usd_value FROM ` + intentTableName + `
+		WHERE created_at >= $2 AND state != $3 AND (
+			(intent_type = $4 AND destination_owner = $1 AND owner != $1 AND is_withdraw = FALSE) OR
+			(intent_type = $5 AND owner = $1 AND is_returned = FALSE AND is_issuer_voiding_gift_card = FALSE)
+		)
+	`
+	err := db.GetContext(
+		ctx,
+		&res,
+		query,
+		owner,
+		since,
+		intent.StateRevoked,
+		intent.SendPublicPayment,
+		intent.ReceivePaymentsPublicly,
+	)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if !res.TotalQuarkValue.Valid || !res.TotalUsdMarketValue.Valid {
+		return 0, 0, nil
+	}
+	return uint64(res.TotalQuarkValue.Int64), res.TotalUsdMarketValue.Float64, nil
+}
@@ -94,3 +94,15 @@ func (s *store) GetGiftCardClaimedIntent(ctx context.Context, giftCardVault stri
 func (s *store) GetTransactedAmountForAntiMoneyLaundering(ctx context.Context, owner string, since time.Time) (uint64, float64, error) {
 	return dbGetTransactedAmountForAntiMoneyLaundering(ctx, s.db, owner, since)
 }
+
+// GetTransactedCountForAntiMoneyLaundering gets the number of payments made
+// by an owner since a timestamp.
+func (s *store) GetTransactedCountForAntiMoneyLaundering(ctx context.Context, owner string, since time.Time) (uint64, error) {
+	return dbGetTransactedCountForAntiMoneyLaundering(ctx, s.db, owner, since)
+}
+
+// GetReceivedAmountForAntiMoneyLaundering gets the total received core mint quarks and the
+// corresponding USD market value for an owner since a timestamp.
+func (s *store) GetReceivedAmountForAntiMoneyLaundering(ctx context.Context, owner string, since time.Time) (uint64, float64, error) {
+	return dbGetReceivedAmountForAntiMoneyLaundering(ctx, s.db, owner, since)
+}
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"os"
 	"testing"
@@ -11,72 +12,12 @@ import (
 	"github.com/code-payments/ocp-server/ocp/data/intent"
 	"github.com/code-payments/ocp-server/ocp/data/intent/tests"
 
+	"github.com/code-payments/ocp-server/database/postgres/migration"
 	postgrestest "github.com/code-payments/ocp-server/database/postgres/test"
 
 	_ "github.com/jackc/pgx/v4/stdlib"
 )
 
-const (
-	// Used for testing ONLY, the table and migrations are external to this repository
-	tableCreate = `
-		CREATE TABLE ocp__core_intent(
-			id SERIAL NOT NULL PRIMARY KEY,
-
-			intent_id TEXT NOT NULL UNIQUE,
-			intent_type INTEGER NOT NULL,
-
-			mint TEXT NULL,
-
-			owner TEXT NOT NULL,
-			source TEXT NULL,
-			destination TEXT NULL,
-			destination_owner TEXT NULL,
-
-			quantity BIGINT NULL CHECK (quantity >= 0),
-
-			exchange_currency VARCHAR(3) NULL,
-			exchange_rate NUMERIC(18, 9) NULL,
-			native_amount NUMERIC(18, 9) NULL,
-			usd_market_value NUMERIC(18, 9) NULL,
-
-			is_withdraw BOOL NOT NULL,
-			is_deposit BOOL NOT NULL,
-			is_remote_send BOOL NOT NULL,
-			is_returned BOOL NOT NULL,
-			is_issuer_voiding_gift_card BOOL NOT NULL,
-
-			state INTEGER NOT NULL,
-
-			version INTEGER NOT NULL,
-
-			created_at TIMESTAMP WITH TIME ZONE NOT NULL
-		);
-
-		CREATE TABLE ocp__core_intentaccountmetadata(
-			id SERIAL NOT NULL PRIMARY KEY,
-
-			paging_id BIGINT NOT NULL CHECK (paging_id > 0),
-
-			source TEXT NULL,
-			source_owner TEXT NULL,
-
-			destination TEXT NULL,
-			destination_owner TEXT NULL,
-
-			quantity BIGINT NULL CHECK (quantity >= 0),
-
-			CONSTRAINT ocp__core_intentaccountmetadata__uniq__paging_id__and__source UNIQUE (paging_id, source),
-			CONSTRAINT ocp__core_intentaccountmetadata__uniq__paging_id__and__destination UNIQUE (paging_id, destination)
-		)
-	`
-
-	// Used for testing ONLY, the table and migrations are external to this repository
-	tableDestroy = `
-		DROP TABLE ocp__core_intent;
-		DROP TABLE ocp__core_intentaccountmetadata;
-	`
-)
-
 var (
 	testStore intent.Store
 	teardown  func()
@@ -129,7 +70,13 @@ func TestIntentPostgresStore(t *testing.T) {
 }
 
 func createTestTables(log *zap.Logger, db *sql.DB) error {
-	_, err := db.Exec(tableCreate)
+	migrations, err := Migrations()
+	if err != nil {
+		log.With(zap.Error(err)).Error("could not load migrations")
+		return err
+	}
+
+	err = migration.Up(context.Background(), db, migrations)
 	if err != nil {
 		log.With(zap.Error(err)).Error("could not create test tables")
 		return err
@@ -138,7 +85,13 @@ func createTestTables(log *zap.Logger, db *sql.DB) error {
 }
 
 func resetTestTables(log *zap.Logger, db *sql.DB) error {
-	_, err := db.Exec(tableDestroy)
+	migrations, err := Migrations()
+	if err != nil {
+		log.With(zap.Error(err)).Error("could not load migrations")
+		return err
+	}
+
+	err = migration.Down(context.Background(), db, migrations)
 	if err != nil {
 		log.With(zap.Error(err)).Error("could not drop test tables")
 		return err
@@ -39,4 +39,12 @@ type Store interface {
 	// GetTransactedAmountForAntiMoneyLaundering gets the total transacted core mint quarks and the
 	// corresponding USD market value for an owner since a timestamp.
 	GetTransactedAmountForAntiMoneyLaundering(ctx context.Context, owner string, since time.Time) (uint64, float64, error)
+
+	// GetTransactedCountForAntiMoneyLaundering gets the number of payments made
+	// by an owner since a timestamp.
+	GetTransactedCountForAntiMoneyLaundering(ctx context.Context, owner string, since time.Time) (uint64, error)
+
+	// GetReceivedAmountForAntiMoneyLaundering gets the total received core mint quarks and the
+	// corresponding USD market value for an owner since a timestamp.
+	GetReceivedAmountForAntiMoneyLaundering(ctx context.Context, owner string, since time.Time) (uint64, float64, error)
 }
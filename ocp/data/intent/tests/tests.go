@@ -25,6 +25,8 @@ func RunTests(t *testing.T, s intent.Store, teardown func()) {
 		testGetOriginalGiftCardIssuedIntent,
 		testGetGiftCardClaimedIntent,
 		testGetTransactedAmountForAntiMoneyLaundering,
+		testGetTransactedCountForAntiMoneyLaundering,
+		testGetReceivedAmountForAntiMoneyLaundering,
 		testGetByOwner,
 	} {
 		tf(t, s)
@@ -512,6 +514,88 @@ func testGetTransactedAmountForAntiMoneyLaundering(t *testing.T, s intent.Store)
 	})
 }
 
+func testGetTransactedCountForAntiMoneyLaundering(t *testing.T, s intent.Store) {
+	t.Run("testGetTransactedCountForAntiMoneyLaundering", func(t *testing.T) {
+		ctx := context.Background()
+
+		// No intents results in a zero count
+		count, err := s.GetTransactedCountForAntiMoneyLaundering(ctx, "o1", time.Now().Add(-24*time.Hour))
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, count)
+
+		records := []*intent.Record{
+			{IntentId: "t1", IntentType: intent.SendPublicPayment, InitiatorOwnerAccount: "o1", SendPublicPaymentMetadata: &intent.SendPublicPaymentMetadata{DestinationOwnerAccount: "o2", DestinationTokenAccount: "a1", Quantity: 1, ExchangeCurrency: currency.USD, ExchangeRate: 2, NativeAmount: 2, UsdMarketValue: 2}, State: intent.StatePending, MintAccount: "mint", CreatedAt: time.Now().Add(-1 * time.Minute)},
+			{IntentId: "t2", IntentType: intent.SendPublicPayment, InitiatorOwnerAccount: "o1", SendPublicPaymentMetadata: &intent.SendPublicPaymentMetadata{DestinationOwnerAccount: "o3", DestinationTokenAccount: "a2", Quantity: 10, ExchangeCurrency: currency.USD, ExchangeRate: 2, NativeAmount: 20, UsdMarketValue: 20}, State: intent.StateConfirmed, MintAccount: "mint", CreatedAt: time.Now().Add(-3 * time.Minute)},
+			{IntentId: "t3", IntentType: intent.SendPublicPayment, InitiatorOwnerAccount: "o1", SendPublicPaymentMetadata: &intent.SendPublicPaymentMetadata{DestinationOwnerAccount: "o4", DestinationTokenAccount: "a3", Quantity: 100, ExchangeCurrency: currency.USD, ExchangeRate: 2, NativeAmount: 200, UsdMarketValue: 200}, State: intent.StateRevoked, MintAccount: "mint", CreatedAt: time.Now()},
+			{IntentId: "t4", IntentType: intent.SendPublicPayment, InitiatorOwnerAccount: "o1", SendPublicPaymentMetadata: &intent.SendPublicPaymentMetadata{DestinationOwnerAccount: "o5", DestinationTokenAccount: "a4", Quantity: 1000, ExchangeCurrency: currency.USD, ExchangeRate: 2, NativeAmount: 2000, UsdMarketValue: 2000, IsWithdrawal: true}, State: intent.StateConfirmed, MintAccount: "mint", CreatedAt: time.Now()},
+			{IntentId: "t5", IntentType: intent.ReceivePaymentsPublicly, InitiatorOwnerAccount: "o1", ReceivePaymentsPubliclyMetadata: &intent.ReceivePaymentsPubliclyMetadata{Source: "a5", Quantity: 10000, UsdMarketValue: 20000, OriginalExchangeCurrency: currency.USD, OriginalExchangeRate: 2, OriginalNativeAmount: 20000}, State: intent.StateConfirmed, MintAccount: "mint", CreatedAt: time.Now()},
+		}
+
+		for _, record := range records {
+			require.NoError(t, s.Save(ctx, record))
+		}
+
+		// Capture all payments for the owner
+		count, err = s.GetTransactedCountForAntiMoneyLaundering(ctx, "o1", time.Now().Add(-24*time.Hour))
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, count)
+
+		// Capture a subset of payments based on time
+		count, err = s.GetTransactedCountForAntiMoneyLaundering(ctx, "o1", time.Now().Add(-2*time.Minute))
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, count)
+
+		// Capture no payments because the owner mismatches
+		count, err = s.GetTransactedCountForAntiMoneyLaundering(ctx, "o2", time.Now().Add(-24*time.Hour))
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, count)
+	})
+}
+
+func testGetReceivedAmountForAntiMoneyLaundering(t *testing.T, s intent.Store) {
+	t.Run("testGetReceivedAmountForAntiMoneyLaundering", func(t *testing.T) {
+		ctx := context.Background()
+
+		// No intents results in zero received values
+		quarks, usdMarketValue, err := s.GetReceivedAmountForAntiMoneyLaundering(ctx, "o1", time.Now().Add(-24*time.Hour))
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, quarks)
+		assert.EqualValues(t, 0, usdMarketValue)
+
+		records := []*intent.Record{
+			{IntentId: "t1", IntentType: intent.SendPublicPayment, InitiatorOwnerAccount: "o2", SendPublicPaymentMetadata: &intent.SendPublicPaymentMetadata{DestinationOwnerAccount: "o1", DestinationTokenAccount: "a1", Quantity: 1, ExchangeCurrency: currency.USD, ExchangeRate: 2, NativeAmount: 2, UsdMarketValue: 2}, State: intent.StatePending, MintAccount: "mint", CreatedAt: time.Now().Add(-1 * time.Minute)},
+			{IntentId: "t2", IntentType: intent.ReceivePaymentsPublicly, InitiatorOwnerAccount: "o1", ReceivePaymentsPubliclyMetadata: &intent.ReceivePaymentsPubliclyMetadata{Source: "a2", Quantity: 10, UsdMarketValue: 20, IsRemoteSend: true, OriginalExchangeCurrency: currency.USD, OriginalExchangeRate: 2, OriginalNativeAmount: 20}, State: intent.StateConfirmed, MintAccount: "mint", CreatedAt: time.Now().Add(-3 * time.Minute)},
+			{IntentId: "t3", IntentType: intent.SendPublicPayment, InitiatorOwnerAccount: "o3", SendPublicPaymentMetadata: &intent.SendPublicPaymentMetadata{DestinationOwnerAccount: "o1", DestinationTokenAccount: "a1", Quantity: 100, ExchangeCurrency: currency.USD, ExchangeRate: 2, NativeAmount: 200, UsdMarketValue: 200}, State: intent.StateRevoked, MintAccount: "mint", CreatedAt: time.Now()},
+			{IntentId: "t4", IntentType: intent.SendPublicPayment, InitiatorOwnerAccount: "o1", SendPublicPaymentMetadata: &intent.SendPublicPaymentMetadata{DestinationOwnerAccount: "o1", DestinationTokenAccount: "a1", Quantity: 1000, ExchangeCurrency: currency.USD, ExchangeRate: 2, NativeAmount: 2000, UsdMarketValue: 2000}, State: intent.StateConfirmed, MintAccount: "mint", CreatedAt: time.Now()},
+			{IntentId: "t5", IntentType: intent.SendPublicPayment, InitiatorOwnerAccount: "o3", SendPublicPaymentMetadata: &intent.SendPublicPaymentMetadata{DestinationOwnerAccount: "o1", DestinationTokenAccount: "a1", Quantity: 10000, ExchangeCurrency: currency.USD, ExchangeRate: 2, NativeAmount: 20000, UsdMarketValue: 20000, IsWithdrawal: true}, State: intent.StateConfirmed, MintAccount: "mint", CreatedAt: time.Now()},
+			{IntentId: "t6", IntentType: intent.ReceivePaymentsPublicly, InitiatorOwnerAccount: "o1", ReceivePaymentsPubliclyMetadata: &intent.ReceivePaymentsPubliclyMetadata{Source: "a6", Quantity: 100000, UsdMarketValue: 200000, IsRemoteSend: true, IsReturned: true, OriginalExchangeCurrency: currency.USD, OriginalExchangeRate: 2, OriginalNativeAmount: 200000}, State: intent.StateConfirmed, MintAccount: "mint", CreatedAt: time.Now()},
+			{IntentId: "t7", IntentType: intent.ExternalDeposit, InitiatorOwnerAccount: "o1", ExternalDepositMetadata: &intent.ExternalDepositMetadata{DestinationTokenAccount: "a1", Quantity: 1000000, UsdMarketValue: 2000000}, MintAccount: "mint", State: intent.StateConfirmed, CreatedAt: time.Now()},
+		}
+
+		for _, record := range records {
+			require.NoError(t, s.Save(ctx, record))
+		}
+
+		// Capture all received payments for the owner
+		quarks, usdMarketValue, err = s.GetReceivedAmountForAntiMoneyLaundering(ctx, "o1", time.Now().Add(-24*time.Hour))
+		require.NoError(t, err)
+		assert.EqualValues(t, 11, quarks)
+		assert.EqualValues(t, 22, usdMarketValue)
+
+		// Capture a subset of received payments based on time
+		quarks, usdMarketValue, err = s.GetReceivedAmountForAntiMoneyLaundering(ctx, "o1", time.Now().Add(-2*time.Minute))
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, quarks)
+		assert.EqualValues(t, 2, usdMarketValue)
+
+		// Capture no received payments because the owner mismatches
+		quarks, usdMarketValue, err = s.GetReceivedAmountForAntiMoneyLaundering(ctx, "o4", time.Now().Add(-24*time.Hour))
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, quarks)
+		assert.EqualValues(t, 0, usdMarketValue)
+	})
+}
+
 func testGetByOwner(t *testing.T, s intent.Store) {
 	t.Run("testGetByOwner", func(t *testing.T) {
 		ctx := context.Background()
@@ -13,6 +13,7 @@ import (
 	"github.com/code-payments/ocp-server/cache"
 	currency_lib "github.com/code-payments/ocp-server/currency"
 	pg "github.com/code-payments/ocp-server/database/postgres"
+	"github.com/code-payments/ocp-server/database/postgres/migration"
 	"github.com/code-payments/ocp-server/database/query"
 	timelock_token "github.com/code-payments/ocp-server/solana/timelock/v1"
 	"github.com/code-payments/ocp-server/solana/vm"
@@ -23,53 +24,80 @@ import (
 	"github.com/code-payments/ocp-server/ocp/data/account"
 	"github.com/code-payments/ocp-server/ocp/data/action"
 	"github.com/code-payments/ocp-server/ocp/data/balance"
+	"github.com/code-payments/ocp-server/ocp/data/batch"
 	"github.com/code-payments/ocp-server/ocp/data/currency"
 	"github.com/code-payments/ocp-server/ocp/data/deposit"
+	"github.com/code-payments/ocp-server/ocp/data/feeburn"
 	"github.com/code-payments/ocp-server/ocp/data/fulfillment"
 	"github.com/code-payments/ocp-server/ocp/data/intent"
+	"github.com/code-payments/ocp-server/ocp/data/launch"
 	"github.com/code-payments/ocp-server/ocp/data/messaging"
 	"github.com/code-payments/ocp-server/ocp/data/nonce"
+	"github.com/code-payments/ocp-server/ocp/data/paymentrequest"
+	"github.com/code-payments/ocp-server/ocp/data/paymentschedule"
 	"github.com/code-payments/ocp-server/ocp/data/rendezvous"
+	"github.com/code-payments/ocp-server/ocp/data/resolution"
 	"github.com/code-payments/ocp-server/ocp/data/swap"
 	"github.com/code-payments/ocp-server/ocp/data/timelock"
 	"github.com/code-payments/ocp-server/ocp/data/transaction"
 	"github.com/code-payments/ocp-server/ocp/data/vault"
+	vm_operation "github.com/code-payments/ocp-server/ocp/data/vm/operation"
 	vm_ram "github.com/code-payments/ocp-server/ocp/data/vm/ram"
+	vm_registry "github.com/code-payments/ocp-server/ocp/data/vm/registry"
 	vm_storage "github.com/code-payments/ocp-server/ocp/data/vm/storage"
+	"github.com/code-payments/ocp-server/ocp/data/webhook"
 
 	account_memory_client "github.com/code-payments/ocp-server/ocp/data/account/memory"
 	action_memory_client "github.com/code-payments/ocp-server/ocp/data/action/memory"
 	balance_memory_client "github.com/code-payments/ocp-server/ocp/data/balance/memory"
+	batch_memory_client "github.com/code-payments/ocp-server/ocp/data/batch/memory"
 	currency_memory_client "github.com/code-payments/ocp-server/ocp/data/currency/memory"
 	deposit_memory_client "github.com/code-payments/ocp-server/ocp/data/deposit/memory"
+	feeburn_memory_client "github.com/code-payments/ocp-server/ocp/data/feeburn/memory"
 	fulfillment_memory_client "github.com/code-payments/ocp-server/ocp/data/fulfillment/memory"
 	intent_memory_client "github.com/code-payments/ocp-server/ocp/data/intent/memory"
+	launch_memory_client "github.com/code-payments/ocp-server/ocp/data/launch/memory"
 	messaging_memory_client "github.com/code-payments/ocp-server/ocp/data/messaging/memory"
 	nonce_memory_client "github.com/code-payments/ocp-server/ocp/data/nonce/memory"
+	paymentrequest_memory_client "github.com/code-payments/ocp-server/ocp/data/paymentrequest/memory"
+	paymentschedule_memory_client "github.com/code-payments/ocp-server/ocp/data/paymentschedule/memory"
 	rendezvous_memory_client "github.com/code-payments/ocp-server/ocp/data/rendezvous/memory"
+	resolution_memory_client "github.com/code-payments/ocp-server/ocp/data/resolution/memory"
 	swap_memory_client "github.com/code-payments/ocp-server/ocp/data/swap/memory"
 	timelock_memory_client "github.com/code-payments/ocp-server/ocp/data/timelock/memory"
 	transaction_memory_client "github.com/code-payments/ocp-server/ocp/data/transaction/memory"
 	vault_memory_client "github.com/code-payments/ocp-server/ocp/data/vault/memory"
+	vm_operation_memory_client "github.com/code-payments/ocp-server/ocp/data/vm/operation/memory"
 	vm_ram_memory_client "github.com/code-payments/ocp-server/ocp/data/vm/ram/memory"
+	vm_registry_memory_client "github.com/code-payments/ocp-server/ocp/data/vm/registry/memory"
 	vm_storage_memory_client "github.com/code-payments/ocp-server/ocp/data/vm/storage/memory"
+	webhook_memory_client "github.com/code-payments/ocp-server/ocp/data/webhook/memory"
 
 	account_postgres_client "github.com/code-payments/ocp-server/ocp/data/account/postgres"
 	action_postgres_client "github.com/code-payments/ocp-server/ocp/data/action/postgres"
 	balance_postgres_client "github.com/code-payments/ocp-server/ocp/data/balance/postgres"
+	batch_postgres_client "github.com/code-payments/ocp-server/ocp/data/batch/postgres"
 	currency_postgres_client "github.com/code-payments/ocp-server/ocp/data/currency/postgres"
 	deposit_postgres_client "github.com/code-payments/ocp-server/ocp/data/deposit/postgres"
+	feeburn_postgres_client "github.com/code-payments/ocp-server/ocp/data/feeburn/postgres"
 	fulfillment_postgres_client "github.com/code-payments/ocp-server/ocp/data/fulfillment/postgres"
 	intent_postgres_client "github.com/code-payments/ocp-server/ocp/data/intent/postgres"
+	launch_postgres_client "github.com/code-payments/ocp-server/ocp/data/launch/postgres"
 	messaging_postgres_client "github.com/code-payments/ocp-server/ocp/data/messaging/postgres"
 	nonce_postgres_client "github.com/code-payments/ocp-server/ocp/data/nonce/postgres"
+	paymentrequest_postgres_client "github.com/code-payments/ocp-server/ocp/data/paymentrequest/postgres"
+	paymentschedule_postgres_client "github.com/code-payments/ocp-server/ocp/data/paymentschedule/postgres"
 	rendezvous_postgres_client "github.com/code-payments/ocp-server/ocp/data/rendezvous/postgres"
+	resolution_postgres_client "github.com/code-payments/ocp-server/ocp/data/resolution/postgres"
 	swap_postgres_client "github.com/code-payments/ocp-server/ocp/data/swap/postgres"
 	timelock_postgres_client "github.com/code-payments/ocp-server/ocp/data/timelock/postgres"
 	transaction_postgres_client "github.com/code-payments/ocp-server/ocp/data/transaction/postgres"
 	vault_postgres_client "github.com/code-payments/ocp-server/ocp/data/vault/postgres"
+	vm_operation_postgres_client "github.com/code-payments/ocp-server/ocp/data/vm/operation/postgres"
 	vm_ram_postgres_client "github.com/code-payments/ocp-server/ocp/data/vm/ram/postgres"
+	vm_registry_postgres_client "github.com/code-payments/ocp-server/ocp/data/vm/registry/postgres"
 	vm_storage_postgres_client "github.com/code-payments/ocp-server/ocp/data/vm/storage/postgres"
+	webhook_postgres_client "github.com/code-payments/ocp-server/ocp/data/webhook/postgres"
 )
 
 // Cache Constants
@@ -99,7 +127,7 @@ type DatabaseData interface {
 	GetLatestAccountInfosByOwnerAddress(ctx context.Context, address string) (map[string]map[commonpb.AccountType][]*account.Record, error)
 	GetLatestAccountInfoByOwnerAddressAndType(ctx context.Context, address string, accountType commonpb.AccountType) (map[string]*account.Record, error)
 	GetPrioritizedAccountInfosRequiringDepositSync(ctx context.Context, limit uint64) ([]*account.Record, error)
-	GetPrioritizedAccountInfosRequiringAutoReturnCheck(ctx context.Context, maxAge time.Duration, limit uint64) ([]*account.Record, error)
+	GetPrioritizedAccountInfosRequiringAutoReturnCheck(ctx context.Context, expiredBefore time.Time, limit uint64) ([]*account.Record, error)
 	GetAccountInfoCountRequiringDepositSync(ctx context.Context) (uint64, error)
 	GetAccountInfoCountRequiringAutoReturnCheck(ctx context.Context) (uint64, error)
 
@@ -134,6 +162,7 @@ type DatabaseData interface {
 	ImportExchangeRates(ctx context.Context, record *currency.MultiRateRecord) error
 	PutCurrencyMetadata(ctx context.Context, record *currency.MetadataRecord) error
 	GetCurrencyMetadata(ctx context.Context, mint string) (*currency.MetadataRecord, error)
+	GetAllCurrencyMetadata(ctx context.Context) ([]*currency.MetadataRecord, error)
 	PutCurrencyReserve(ctx context.Context, record *currency.ReserveRecord) error
 	GetCurrencyReserveAtTime(ctx context.Context, mint string, t time.Time) (*currency.ReserveRecord, error)
 
@@ -157,6 +186,9 @@ type DatabaseData interface {
 	GetFulfillmentCountByIntent(ctx context.Context, intent string) (uint64, error)
 	GetPendingFulfillmentCountByType(ctx context.Context) (map[fulfillment.Type]uint64, error)
 	GetAllFulfillmentsByState(ctx context.Context, state fulfillment.State, includeDisabledActiveScheduling bool, opts ...query.Option) ([]*fulfillment.Record, error)
+	ClaimFulfillmentsByState(ctx context.Context, state fulfillment.State, nodeId string, leaseExpiresAt time.Time, opts ...query.Option) ([]*fulfillment.Record, error)
+	ClaimFulfillmentById(ctx context.Context, id uint64, nodeId string, leaseExpiresAt time.Time) (*fulfillment.Record, error)
+	ReleaseFulfillmentLease(ctx context.Context, id uint64, nodeId string) error
 	GetAllFulfillmentsByIntent(ctx context.Context, intent string, opts ...query.Option) ([]*fulfillment.Record, error)
 	GetAllFulfillmentsByAction(ctx context.Context, intentId string, actionId uint32) ([]*fulfillment.Record, error)
 	GetFirstSchedulableFulfillmentByAddressAsSource(ctx context.Context, address string) (*fulfillment.Record, error)
@@ -165,6 +197,21 @@ type DatabaseData interface {
 	PutAllFulfillments(ctx context.Context, records ...*fulfillment.Record) error
 	UpdateFulfillment(ctx context.Context, record *fulfillment.Record) error
 
+	// Fulfillment Batches
+	// --------------------------------------------------------------------------------
+	PutFulfillmentBatch(ctx context.Context, record *batch.Record) error
+	UpdateFulfillmentBatch(ctx context.Context, record *batch.Record) error
+	GetFulfillmentBatchById(ctx context.Context, id uint64) (*batch.Record, error)
+	GetFulfillmentBatchBySignature(ctx context.Context, signature string) (*batch.Record, error)
+	GetFulfillmentBatchByFulfillment(ctx context.Context, fulfillmentId uint64) (*batch.Record, error)
+	GetAllFulfillmentBatchesByState(ctx context.Context, state batch.State, opts ...query.Option) ([]*batch.Record, error)
+	GetFulfillmentBatchCountByState(ctx context.Context, state batch.State) (uint64, error)
+
+	// Fulfillment Resolutions
+	// --------------------------------------------------------------------------------
+	PutFulfillmentResolution(ctx context.Context, record *resolution.Record) error
+	GetAllFulfillmentResolutions(ctx context.Context, fulfillmentId uint64) ([]*resolution.Record, error)
+
 	// Intents
 	// --------------------------------------------------------------------------------
 	SaveIntent(ctx context.Context, record *intent.Record) error
@@ -173,6 +220,25 @@ type DatabaseData interface {
 	GetOriginalGiftCardIssuedIntent(ctx context.Context, giftCardVault string) (*intent.Record, error)
 	GetGiftCardClaimedIntent(ctx context.Context, giftCardVault string) (*intent.Record, error)
 	GetTransactedAmountForAntiMoneyLaundering(ctx context.Context, owner string, since time.Time) (uint64, float64, error)
+	GetTransactedCountForAntiMoneyLaundering(ctx context.Context, owner string, since time.Time) (uint64, error)
+	GetReceivedAmountForAntiMoneyLaundering(ctx context.Context, owner string, since time.Time) (uint64, float64, error)
+
+	// Currency Launches
+	// --------------------------------------------------------------------------------
+	PutCurrencyLaunch(ctx context.Context, record *launch.Record) error
+	UpdateCurrencyLaunch(ctx context.Context, record *launch.Record) error
+	GetCurrencyLaunchByIntent(ctx context.Context, intent string) (*launch.Record, error)
+	GetCurrencyLaunchByMint(ctx context.Context, mint string) (*launch.Record, error)
+
+	// Currency Fee Burns
+	// --------------------------------------------------------------------------------
+	PutCurrencyFeeBurn(ctx context.Context, record *feeburn.Record) error
+	UpdateCurrencyFeeBurn(ctx context.Context, record *feeburn.Record) error
+	GetCurrencyFeeBurnBySignature(ctx context.Context, signature string) (*feeburn.Record, error)
+	GetLatestCurrencyFeeBurnByMint(ctx context.Context, mint string) (*feeburn.Record, error)
+	GetAllCurrencyFeeBurnsByState(ctx context.Context, state feeburn.State, opts ...query.Option) ([]*feeburn.Record, error)
+	GetCurrencyFeeBurnCountByState(ctx context.Context, state feeburn.State) (uint64, error)
+	GetTotalCurrencyFeesBurnedByMint(ctx context.Context, mint string) (uint64, error)
 
 	// Messaging
 	// --------------------------------------------------------------------------------
@@ -190,6 +256,22 @@ type DatabaseData interface {
 	BatchClaimAvailableNoncesByPurpose(ctx context.Context, env nonce.Environment, instance string, purpose nonce.Purpose, limit int, nodeID string, minExpireAt, maxExpireAt time.Time) ([]*nonce.Record, error)
 	SaveNonce(ctx context.Context, record *nonce.Record) error
 
+	// Payment Requests
+	// --------------------------------------------------------------------------------
+	PutPaymentRequest(ctx context.Context, record *paymentrequest.Record) error
+	UpdatePaymentRequest(ctx context.Context, record *paymentrequest.Record) error
+	GetPaymentRequest(ctx context.Context, intent string) (*paymentrequest.Record, error)
+	GetAllPaymentRequestsByOwner(ctx context.Context, owner string, opts ...query.Option) ([]*paymentrequest.Record, error)
+
+	// Payment Schedules
+	// --------------------------------------------------------------------------------
+	PutPaymentSchedule(ctx context.Context, record *paymentschedule.Record) error
+	UpdatePaymentSchedule(ctx context.Context, record *paymentschedule.Record) error
+	GetPaymentSchedule(ctx context.Context, scheduleId string) (*paymentschedule.Record, error)
+	GetAllPaymentSchedulesByOwner(ctx context.Context, owner string, opts ...query.Option) ([]*paymentschedule.Record, error)
+	GetDuePaymentSchedules(ctx context.Context, dueBefore time.Time, limit uint64) ([]*paymentschedule.Record, error)
+	GetPaymentScheduleCountByState(ctx context.Context, state paymentschedule.State) (uint64, error)
+
 	// Rendezvous
 	// --------------------------------------------------------------------------------
 	PutRendezvous(ctx context.Context, record *rendezvous.Record) error
@@ -209,6 +291,7 @@ type DatabaseData interface {
 	// Timelocks
 	// --------------------------------------------------------------------------------
 	SaveTimelock(ctx context.Context, record *timelock.Record) error
+	MarkTimelockUnlockRequested(ctx context.Context, vault string, requestedAt time.Time) error
 	GetTimelockByAddress(ctx context.Context, address string) (*timelock.Record, error)
 	GetTimelockByVault(ctx context.Context, vault string) (*timelock.Record, error)
 	GetTimelockByDepositPda(ctx context.Context, depositPda string) (*timelock.Record, error)
@@ -228,7 +311,9 @@ type DatabaseData interface {
 	GetKeyCount(ctx context.Context) (uint64, error)
 	GetKeyCountByState(ctx context.Context, state vault.State) (uint64, error)
 	GetAllKeysByState(ctx context.Context, state vault.State, opts ...query.Option) ([]*vault.Record, error)
+	GetAllKeyMetadataByState(ctx context.Context, state vault.State, opts ...query.Option) ([]*vault.Record, error)
 	SaveKey(ctx context.Context, record *vault.Record) error
+	ReEncryptKey(ctx context.Context, public_key string) error
 
 	// VM RAM
 	// --------------------------------------------------------------------------------
@@ -236,12 +321,40 @@ type DatabaseData interface {
 	FreeVmMemoryByIndex(ctx context.Context, memoryAccount string, index uint16) error
 	FreeVmMemoryByAddress(ctx context.Context, address string) error
 	ReserveVmMemory(ctx context.Context, vm string, accountType vm.VirtualAccountType, address string) (string, uint16, error)
+	GetVmMemoryAccountCount(ctx context.Context, vm string, accountType vm.VirtualAccountType) (uint64, error)
+	GetFreeVmMemoryCapacity(ctx context.Context, vm string, accountType vm.VirtualAccountType) (uint64, error)
+	GetAllVmMemoryAllocations(ctx context.Context, vm string, accountType vm.VirtualAccountType, opts ...query.Option) ([]*vm_ram.Allocation, error)
 
 	// VM Storage
 	// --------------------------------------------------------------------------------
 	InitializeVmStorage(ctx context.Context, record *vm_storage.Record) error
 	FindAnyVmStorageWithAvailableCapacity(ctx context.Context, vm string, purpose vm_storage.Purpose, minCapacity uint64) (*vm_storage.Record, error)
 	ReserveVmStorage(ctx context.Context, vm string, purpose vm_storage.Purpose, address string) (string, error)
+	FreeVmStorageByAddress(ctx context.Context, address string) error
+
+	// VM Operations
+	// --------------------------------------------------------------------------------
+	PutVmOperation(ctx context.Context, record *vm_operation.Record) error
+	UpdateVmOperation(ctx context.Context, record *vm_operation.Record) error
+	GetLatestVmOperationByAddress(ctx context.Context, address string) (*vm_operation.Record, error)
+	GetAllVmOperationsByState(ctx context.Context, state vm_operation.State, opts ...query.Option) ([]*vm_operation.Record, error)
+	GetVmOperationCountByState(ctx context.Context, state vm_operation.State) (uint64, error)
+	GetVmOperationCountByVmTypeAndState(ctx context.Context, vm string, opType vm_operation.Type, state vm_operation.State) (uint64, error)
+
+	// VM Registry
+	// --------------------------------------------------------------------------------
+	RegisterVm(ctx context.Context, record *vm_registry.Record) error
+	GetRegisteredVmByMint(ctx context.Context, mint string) (*vm_registry.Record, error)
+	GetRegisteredVmByAddress(ctx context.Context, vm string) (*vm_registry.Record, error)
+	GetAllRegisteredVms(ctx context.Context) ([]*vm_registry.Record, error)
+
+	// Webhooks
+	// --------------------------------------------------------------------------------
+	PutWebhookEvent(ctx context.Context, record *webhook.Record) error
+	UpdateWebhookEvent(ctx context.Context, record *webhook.Record) error
+	GetWebhookEvent(ctx context.Context, eventId string) (*webhook.Record, error)
+	GetAllWebhookEventsReadyForDelivery(ctx context.Context, before time.Time, limit uint64) ([]*webhook.Record, error)
+	GetWebhookEventCountByState(ctx context.Context, state webhook.State) (uint64, error)
 
 	// ExecuteInTx executes fn with a single DB transaction that is scoped to the call.
 	// This enables more complex transactions that can span many calls across the provider.
@@ -256,19 +369,28 @@ type DatabaseProvider struct {
 	accounts     account.Store
 	actions      action.Store
 	balance      balance.Store
+	batches      batch.Store
 	currencies   currency.Store
 	deposits     deposit.Store
+	feeBurns     feeburn.Store
 	fulfillments fulfillment.Store
 	intents      intent.Store
+	launches     launch.Store
 	messages     messaging.Store
 	nonces       nonce.Store
+	requests     paymentrequest.Store
+	schedules    paymentschedule.Store
 	rendezvous   rendezvous.Store
+	resolutions  resolution.Store
 	swaps        swap.Store
 	timelocks    timelock.Store
 	transactions transaction.Store
 	vault        vault.Store
 	vmRam        vm_ram.Store
 	vmStorage    vm_storage.Store
+	vmOperations vm_operation.Store
+	vmRegistry   vm_registry.Store
+	webhooks     webhook.Store
 
 	exchangeCache cache.Cache
 	timelockCache cache.Cache
@@ -297,23 +419,50 @@ func NewDatabaseProvider(dbConfig *pg.Config) (DatabaseData, error) {
 	db.SetConnMaxIdleTime(time.Hour)
 	db.SetConnMaxLifetime(time.Hour)
 
+	if dbConfig.RunMigrations {
+		migrations, err := AllMigrations()
+		if err != nil {
+			return nil, err
+		}
+
+		err = migration.Up(context.Background(), db, migrations)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	kekProvider, err := vault.NewEnvKekProvider()
+	if err != nil {
+		return nil, err
+	}
+	keyEncrypter := vault.NewEnvelopeKeyEncrypter(kekProvider)
+
 	return &DatabaseProvider{
 		accounts:     account_postgres_client.New(db),
 		actions:      action_postgres_client.New(db),
 		balance:      balance_postgres_client.New(db),
+		batches:      batch_postgres_client.New(db),
 		currencies:   currency_postgres_client.New(db),
 		deposits:     deposit_postgres_client.New(db),
+		feeBurns:     feeburn_postgres_client.New(db),
 		fulfillments: fulfillment_postgres_client.New(db),
 		intents:      intent_postgres_client.New(db),
+		launches:     launch_postgres_client.New(db),
 		messages:     messaging_postgres_client.New(db),
 		nonces:       nonce_postgres_client.New(db),
+		requests:     paymentrequest_postgres_client.New(db),
+		schedules:    paymentschedule_postgres_client.New(db),
 		rendezvous:   rendezvous_postgres_client.New(db),
+		resolutions:  resolution_postgres_client.New(db),
 		swaps:        swap_postgres_client.New(db),
 		timelocks:    timelock_postgres_client.New(db),
 		transactions: transaction_postgres_client.New(db),
-		vault:        vault_postgres_client.New(db),
+		vault:        vault_postgres_client.New(db, keyEncrypter),
 		vmRam:        vm_ram_postgres_client.New(db),
 		vmStorage:    vm_storage_postgres_client.New(db),
+		vmOperations: vm_operation_postgres_client.New(db),
+		vmRegistry:   vm_registry_postgres_client.New(db),
+		webhooks:     webhook_postgres_client.New(db),
 
 		exchangeCache: cache.NewCache(maxExchangeRateCacheBudget),
 		timelockCache: cache.NewCache(maxTimelockCacheBudget),
@@ -323,23 +472,37 @@ func NewDatabaseProvider(dbConfig *pg.Config) (DatabaseData, error) {
 }
 
 func NewTestDatabaseProvider() DatabaseData {
+	testKekProvider, err := vault.NewEnvKekProvider()
+	if err != nil {
+		panic(err)
+	}
+
 	return &DatabaseProvider{
 		accounts:     account_memory_client.New(),
 		actions:      action_memory_client.New(),
 		balance:      balance_memory_client.New(),
+		batches:      batch_memory_client.New(),
 		currencies:   currency_memory_client.New(),
 		deposits:     deposit_memory_client.New(),
+		feeBurns:     feeburn_memory_client.New(),
 		fulfillments: fulfillment_memory_client.New(),
 		intents:      intent_memory_client.New(),
+		launches:     launch_memory_client.New(),
 		messages:     messaging_memory_client.New(),
 		nonces:       nonce_memory_client.New(),
+		requests:     paymentrequest_memory_client.New(),
+		schedules:    paymentschedule_memory_client.New(),
 		rendezvous:   rendezvous_memory_client.New(),
+		resolutions:  resolution_memory_client.New(),
 		swaps:        swap_memory_client.New(),
 		timelocks:    timelock_memory_client.New(),
 		transactions: transaction_memory_client.New(),
-		vault:        vault_memory_client.New(),
+		vault:        vault_memory_client.New(vault.NewEnvelopeKeyEncrypter(testKekProvider)),
 		vmRam:        vm_ram_memory_client.New(),
 		vmStorage:    vm_storage_memory_client.New(),
+		vmOperations: vm_operation_memory_client.New(),
+		vmRegistry:   vm_registry_memory_client.New(),
+		webhooks:     webhook_memory_client.New(),
 
 		exchangeCache: cache.NewCache(maxExchangeRateCacheBudget),
 		timelockCache: nil, // Shouldn't be used for tests
@@ -380,8 +543,8 @@ func (dp *DatabaseProvider) GetLatestAccountInfoByOwnerAddressAndType(ctx contex
 func (dp *DatabaseProvider) GetPrioritizedAccountInfosRequiringDepositSync(ctx context.Context, limit uint64) ([]*account.Record, error) {
 	return dp.accounts.GetPrioritizedRequiringDepositSync(ctx, limit)
 }
-func (dp *DatabaseProvider) GetPrioritizedAccountInfosRequiringAutoReturnCheck(ctx context.Context, maxAge time.Duration, limit uint64) ([]*account.Record, error) {
-	return dp.accounts.GetPrioritizedRequiringAutoReturnCheck(ctx, maxAge, limit)
+func (dp *DatabaseProvider) GetPrioritizedAccountInfosRequiringAutoReturnCheck(ctx context.Context, expiredBefore time.Time, limit uint64) ([]*account.Record, error) {
+	return dp.accounts.GetPrioritizedRequiringAutoReturnCheck(ctx, expiredBefore, limit)
 }
 func (dp *DatabaseProvider) GetAccountInfoCountRequiringDepositSync(ctx context.Context) (uint64, error) {
 	return dp.accounts.CountRequiringDepositSync(ctx)
@@ -509,6 +672,9 @@ func (dp *DatabaseProvider) PutCurrencyMetadata(ctx context.Context, record *cur
 func (dp *DatabaseProvider) GetCurrencyMetadata(ctx context.Context, mint string) (*currency.MetadataRecord, error) {
 	return dp.currencies.GetMetadata(ctx, mint)
 }
+func (dp *DatabaseProvider) GetAllCurrencyMetadata(ctx context.Context) ([]*currency.MetadataRecord, error) {
+	return dp.currencies.GetAllMetadata(ctx)
+}
 func (dp *DatabaseProvider) PutCurrencyReserve(ctx context.Context, record *currency.ReserveRecord) error {
 	return dp.currencies.PutReserveRecord(ctx, record)
 }
@@ -571,6 +737,25 @@ func (dp *DatabaseProvider) GetAllFulfillmentsByState(ctx context.Context, state
 
 	return dp.fulfillments.GetAllByState(ctx, state, includeDisabledActiveScheduling, req.Cursor, req.Limit, req.SortBy)
 }
+func (dp *DatabaseProvider) ClaimFulfillmentsByState(ctx context.Context, state fulfillment.State, nodeId string, leaseExpiresAt time.Time, opts ...query.Option) ([]*fulfillment.Record, error) {
+	req, err := query.DefaultPaginationHandler(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Records are always claimed in ascending order
+	if req.SortBy != query.Ascending {
+		return nil, query.ErrQueryNotSupported
+	}
+
+	return dp.fulfillments.ClaimAllByState(ctx, state, nodeId, leaseExpiresAt, req.Cursor, req.Limit)
+}
+func (dp *DatabaseProvider) ClaimFulfillmentById(ctx context.Context, id uint64, nodeId string, leaseExpiresAt time.Time) (*fulfillment.Record, error) {
+	return dp.fulfillments.ClaimById(ctx, id, nodeId, leaseExpiresAt)
+}
+func (dp *DatabaseProvider) ReleaseFulfillmentLease(ctx context.Context, id uint64, nodeId string) error {
+	return dp.fulfillments.ReleaseLease(ctx, id, nodeId)
+}
 func (dp *DatabaseProvider) GetAllFulfillmentsByIntent(ctx context.Context, intent string, opts ...query.Option) ([]*fulfillment.Record, error) {
 	req, err := query.DefaultPaginationHandler(opts...)
 	if err != nil {
@@ -598,6 +783,43 @@ func (dp *DatabaseProvider) UpdateFulfillment(ctx context.Context, record *fulfi
 	return dp.fulfillments.Update(ctx, record)
 }
 
+// Fulfillment Batches
+// --------------------------------------------------------------------------------
+func (dp *DatabaseProvider) PutFulfillmentBatch(ctx context.Context, record *batch.Record) error {
+	return dp.batches.Put(ctx, record)
+}
+func (dp *DatabaseProvider) UpdateFulfillmentBatch(ctx context.Context, record *batch.Record) error {
+	return dp.batches.Update(ctx, record)
+}
+func (dp *DatabaseProvider) GetFulfillmentBatchById(ctx context.Context, id uint64) (*batch.Record, error) {
+	return dp.batches.GetById(ctx, id)
+}
+func (dp *DatabaseProvider) GetFulfillmentBatchBySignature(ctx context.Context, signature string) (*batch.Record, error) {
+	return dp.batches.GetBySignature(ctx, signature)
+}
+func (dp *DatabaseProvider) GetFulfillmentBatchByFulfillment(ctx context.Context, fulfillmentId uint64) (*batch.Record, error) {
+	return dp.batches.GetByFulfillment(ctx, fulfillmentId)
+}
+func (dp *DatabaseProvider) GetAllFulfillmentBatchesByState(ctx context.Context, state batch.State, opts ...query.Option) ([]*batch.Record, error) {
+	req, err := query.DefaultPaginationHandler(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return dp.batches.GetAllByState(ctx, state, req.Cursor, req.Limit, req.SortBy)
+}
+func (dp *DatabaseProvider) GetFulfillmentBatchCountByState(ctx context.Context, state batch.State) (uint64, error) {
+	return dp.batches.CountByState(ctx, state)
+}
+
+// Fulfillment Resolutions
+// --------------------------------------------------------------------------------
+func (dp *DatabaseProvider) PutFulfillmentResolution(ctx context.Context, record *resolution.Record) error {
+	return dp.resolutions.Put(ctx, record)
+}
+func (dp *DatabaseProvider) GetAllFulfillmentResolutions(ctx context.Context, fulfillmentId uint64) ([]*resolution.Record, error) {
+	return dp.resolutions.GetAllByFulfillment(ctx, fulfillmentId)
+}
+
 // Intents
 // --------------------------------------------------------------------------------
 func (dp *DatabaseProvider) GetIntent(ctx context.Context, intentID string) (*intent.Record, error) {
@@ -623,6 +845,55 @@ func (dp *DatabaseProvider) SaveIntent(ctx context.Context, record *intent.Recor
 func (dp *DatabaseProvider) GetTransactedAmountForAntiMoneyLaundering(ctx context.Context, owner string, since time.Time) (uint64, float64, error) {
 	return dp.intents.GetTransactedAmountForAntiMoneyLaundering(ctx, owner, since)
 }
+func (dp *DatabaseProvider) GetTransactedCountForAntiMoneyLaundering(ctx context.Context, owner string, since time.Time) (uint64, error) {
+	return dp.intents.GetTransactedCountForAntiMoneyLaundering(ctx, owner, since)
+}
+func (dp *DatabaseProvider) GetReceivedAmountForAntiMoneyLaundering(ctx context.Context, owner string, since time.Time) (uint64, float64, error) {
+	return dp.intents.GetReceivedAmountForAntiMoneyLaundering(ctx, owner, since)
+}
+
+// Currency Launches
+// --------------------------------------------------------------------------------
+func (dp *DatabaseProvider) PutCurrencyLaunch(ctx context.Context, record *launch.Record) error {
+	return dp.launches.Put(ctx, record)
+}
+func (dp *DatabaseProvider) UpdateCurrencyLaunch(ctx context.Context, record *launch.Record) error {
+	return dp.launches.Update(ctx, record)
+}
+func (dp *DatabaseProvider) GetCurrencyLaunchByIntent(ctx context.Context, intent string) (*launch.Record, error) {
+	return dp.launches.GetByIntent(ctx, intent)
+}
+func (dp *DatabaseProvider) GetCurrencyLaunchByMint(ctx context.Context, mint string) (*launch.Record, error) {
+	return dp.launches.GetByMint(ctx, mint)
+}
+
+// Currency Fee Burns
+// --------------------------------------------------------------------------------
+func (dp *DatabaseProvider) PutCurrencyFeeBurn(ctx context.Context, record *feeburn.Record) error {
+	return dp.feeBurns.Put(ctx, record)
+}
+func (dp *DatabaseProvider) UpdateCurrencyFeeBurn(ctx context.Context, record *feeburn.Record) error {
+	return dp.feeBurns.Update(ctx, record)
+}
+func (dp *DatabaseProvider) GetCurrencyFeeBurnBySignature(ctx context.Context, signature string) (*feeburn.Record, error) {
+	return dp.feeBurns.GetBySignature(ctx, signature)
+}
+func (dp *DatabaseProvider) GetLatestCurrencyFeeBurnByMint(ctx context.Context, mint string) (*feeburn.Record, error) {
+	return dp.feeBurns.GetLatestByMint(ctx, mint)
+}
+func (dp *DatabaseProvider) GetAllCurrencyFeeBurnsByState(ctx context.Context, state feeburn.State, opts ...query.Option) ([]*feeburn.Record, error) {
+	req, err := query.DefaultPaginationHandler(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return dp.feeBurns.GetAllByState(ctx, state, req.Cursor, req.Limit, req.SortBy)
+}
+func (dp *DatabaseProvider) GetCurrencyFeeBurnCountByState(ctx context.Context, state feeburn.State) (uint64, error) {
+	return dp.feeBurns.CountByState(ctx, state)
+}
+func (dp *DatabaseProvider) GetTotalCurrencyFeesBurnedByMint(ctx context.Context, mint string) (uint64, error) {
+	return dp.feeBurns.GetTotalQuarksBurnedByMint(ctx, mint)
+}
 
 // Messaging
 // --------------------------------------------------------------------------------
@@ -665,6 +936,50 @@ func (dp *DatabaseProvider) SaveNonce(ctx context.Context, record *nonce.Record)
 	return dp.nonces.Save(ctx, record)
 }
 
+// Payment Requests
+// --------------------------------------------------------------------------------
+func (dp *DatabaseProvider) PutPaymentRequest(ctx context.Context, record *paymentrequest.Record) error {
+	return dp.requests.Put(ctx, record)
+}
+func (dp *DatabaseProvider) UpdatePaymentRequest(ctx context.Context, record *paymentrequest.Record) error {
+	return dp.requests.Update(ctx, record)
+}
+func (dp *DatabaseProvider) GetPaymentRequest(ctx context.Context, intent string) (*paymentrequest.Record, error) {
+	return dp.requests.GetByIntent(ctx, intent)
+}
+func (dp *DatabaseProvider) GetAllPaymentRequestsByOwner(ctx context.Context, owner string, opts ...query.Option) ([]*paymentrequest.Record, error) {
+	req, err := query.DefaultPaginationHandler(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return dp.requests.GetAllByOwner(ctx, owner, req.Cursor, req.Limit, req.SortBy)
+}
+
+// Payment Schedules
+// --------------------------------------------------------------------------------
+func (dp *DatabaseProvider) PutPaymentSchedule(ctx context.Context, record *paymentschedule.Record) error {
+	return dp.schedules.Put(ctx, record)
+}
+func (dp *DatabaseProvider) UpdatePaymentSchedule(ctx context.Context, record *paymentschedule.Record) error {
+	return dp.schedules.Update(ctx, record)
+}
+func (dp *DatabaseProvider) GetPaymentSchedule(ctx context.Context, scheduleId string) (*paymentschedule.Record, error) {
+	return dp.schedules.GetByScheduleId(ctx, scheduleId)
+}
+func (dp *DatabaseProvider) GetAllPaymentSchedulesByOwner(ctx context.Context, owner string, opts ...query.Option) ([]*paymentschedule.Record, error) {
+	req, err := query.DefaultPaginationHandler(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return dp.schedules.GetAllByOwner(ctx, owner, req.Cursor, req.Limit, req.SortBy)
+}
+func (dp *DatabaseProvider) GetDuePaymentSchedules(ctx context.Context, dueBefore time.Time, limit uint64) ([]*paymentschedule.Record, error) {
+	return dp.schedules.GetAllDue(ctx, dueBefore, limit)
+}
+func (dp *DatabaseProvider) GetPaymentScheduleCountByState(ctx context.Context, state paymentschedule.State) (uint64, error) {
+	return dp.schedules.CountByState(ctx, state)
+}
+
 // Rendezvous
 // --------------------------------------------------------------------------------
 func (dp *DatabaseProvider) PutRendezvous(ctx context.Context, record *rendezvous.Record) error {
@@ -710,6 +1025,9 @@ func (dp *DatabaseProvider) GetSwapCountByState(ctx context.Context, state swap.
 func (dp *DatabaseProvider) SaveTimelock(ctx context.Context, record *timelock.Record) error {
 	return dp.timelocks.Save(ctx, record)
 }
+func (dp *DatabaseProvider) MarkTimelockUnlockRequested(ctx context.Context, vault string, requestedAt time.Time) error {
+	return dp.timelocks.MarkUnlockRequested(ctx, vault, requestedAt)
+}
 func (dp *DatabaseProvider) GetTimelockByAddress(ctx context.Context, address string) (*timelock.Record, error) {
 	// todo: add caching if this becomes a heavy hitter like GetByVault
 	return dp.timelocks.GetByAddress(ctx, address)
@@ -839,9 +1157,20 @@ func (dp *DatabaseProvider) GetAllKeysByState(ctx context.Context, state vault.S
 
 	return dp.vault.GetAllByState(ctx, state, req.Cursor, req.Limit, req.SortBy)
 }
+func (dp *DatabaseProvider) GetAllKeyMetadataByState(ctx context.Context, state vault.State, opts ...query.Option) ([]*vault.Record, error) {
+	req, err := query.DefaultPaginationHandlerWithLimit(25, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return dp.vault.GetAllMetadataByState(ctx, state, req.Cursor, req.Limit, req.SortBy)
+}
 func (dp *DatabaseProvider) SaveKey(ctx context.Context, record *vault.Record) error {
 	return dp.vault.Save(ctx, record)
 }
+func (dp *DatabaseProvider) ReEncryptKey(ctx context.Context, public_key string) error {
+	return dp.vault.ReEncrypt(ctx, public_key)
+}
 
 // VM RAM
 // --------------------------------------------------------------------------------
@@ -857,6 +1186,19 @@ func (dp *DatabaseProvider) FreeVmMemoryByAddress(ctx context.Context, address s
 func (dp *DatabaseProvider) ReserveVmMemory(ctx context.Context, vm string, accountType vm.VirtualAccountType, address string) (string, uint16, error) {
 	return dp.vmRam.ReserveMemory(ctx, vm, accountType, address)
 }
+func (dp *DatabaseProvider) GetVmMemoryAccountCount(ctx context.Context, vm string, accountType vm.VirtualAccountType) (uint64, error) {
+	return dp.vmRam.CountMemoryAccounts(ctx, vm, accountType)
+}
+func (dp *DatabaseProvider) GetFreeVmMemoryCapacity(ctx context.Context, vm string, accountType vm.VirtualAccountType) (uint64, error) {
+	return dp.vmRam.GetFreeCapacity(ctx, vm, accountType)
+}
+func (dp *DatabaseProvider) GetAllVmMemoryAllocations(ctx context.Context, vm string, accountType vm.VirtualAccountType, opts ...query.Option) ([]*vm_ram.Allocation, error) {
+	req, err := query.DefaultPaginationHandler(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return dp.vmRam.GetAllAllocations(ctx, vm, accountType, req.Cursor, req.Limit)
+}
 
 // VM Storage
 // --------------------------------------------------------------------------------
@@ -869,3 +1211,64 @@ func (dp *DatabaseProvider) FindAnyVmStorageWithAvailableCapacity(ctx context.Co
 func (dp *DatabaseProvider) ReserveVmStorage(ctx context.Context, vm string, purpose vm_storage.Purpose, address string) (string, error) {
 	return dp.vmStorage.ReserveStorage(ctx, vm, purpose, address)
 }
+func (dp *DatabaseProvider) FreeVmStorageByAddress(ctx context.Context, address string) error {
+	return dp.vmStorage.FreeStorageByAddress(ctx, address)
+}
+
+// VM Operations
+// --------------------------------------------------------------------------------
+func (dp *DatabaseProvider) PutVmOperation(ctx context.Context, record *vm_operation.Record) error {
+	return dp.vmOperations.Put(ctx, record)
+}
+func (dp *DatabaseProvider) UpdateVmOperation(ctx context.Context, record *vm_operation.Record) error {
+	return dp.vmOperations.Update(ctx, record)
+}
+func (dp *DatabaseProvider) GetLatestVmOperationByAddress(ctx context.Context, address string) (*vm_operation.Record, error) {
+	return dp.vmOperations.GetLatestByAddress(ctx, address)
+}
+func (dp *DatabaseProvider) GetAllVmOperationsByState(ctx context.Context, state vm_operation.State, opts ...query.Option) ([]*vm_operation.Record, error) {
+	req, err := query.DefaultPaginationHandler(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return dp.vmOperations.GetAllByState(ctx, state, req.Cursor, req.Limit, req.SortBy)
+}
+func (dp *DatabaseProvider) GetVmOperationCountByState(ctx context.Context, state vm_operation.State) (uint64, error) {
+	return dp.vmOperations.CountByState(ctx, state)
+}
+func (dp *DatabaseProvider) GetVmOperationCountByVmTypeAndState(ctx context.Context, vm string, opType vm_operation.Type, state vm_operation.State) (uint64, error) {
+	return dp.vmOperations.CountByVmTypeAndState(ctx, vm, opType, state)
+}
+
+// VM Registry
+// --------------------------------------------------------------------------------
+func (dp *DatabaseProvider) RegisterVm(ctx context.Context, record *vm_registry.Record) error {
+	return dp.vmRegistry.Put(ctx, record)
+}
+func (dp *DatabaseProvider) GetRegisteredVmByMint(ctx context.Context, mint string) (*vm_registry.Record, error) {
+	return dp.vmRegistry.GetByMint(ctx, mint)
+}
+func (dp *DatabaseProvider) GetRegisteredVmByAddress(ctx context.Context, vm string) (*vm_registry.Record, error) {
+	return dp.vmRegistry.GetByVm(ctx, vm)
+}
+func (dp *DatabaseProvider) GetAllRegisteredVms(ctx context.Context) ([]*vm_registry.Record, error) {
+	return dp.vmRegistry.GetAll(ctx)
+}
+
+// Webhooks
+// --------------------------------------------------------------------------------
+func (dp *DatabaseProvider) PutWebhookEvent(ctx context.Context, record *webhook.Record) error {
+	return dp.webhooks.Put(ctx, record)
+}
+func (dp *DatabaseProvider) UpdateWebhookEvent(ctx context.Context, record *webhook.Record) error {
+	return dp.webhooks.Update(ctx, record)
+}
+func (dp *DatabaseProvider) GetWebhookEvent(ctx context.Context, eventId string) (*webhook.Record, error) {
+	return dp.webhooks.GetByEventId(ctx, eventId)
+}
+func (dp *DatabaseProvider) GetAllWebhookEventsReadyForDelivery(ctx context.Context, before time.Time, limit uint64) ([]*webhook.Record, error) {
+	return dp.webhooks.GetAllReadyForDelivery(ctx, before, limit)
+}
+func (dp *DatabaseProvider) GetWebhookEventCountByState(ctx context.Context, state webhook.State) (uint64, error) {
+	return dp.webhooks.CountByState(ctx, state)
+}
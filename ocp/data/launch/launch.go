@@ -0,0 +1,174 @@
+package launch
+
+import (
+	"errors"
+	"time"
+)
+
+type State uint8
+
+const (
+	StateUnknown   State = iota
+	StatePending         // Initialization transactions are being submitted
+	StateFinalized       // Currency metadata has been persisted
+	StateFailed
+)
+
+// Record is a launchpad currency that's in the process of being created via
+// the currency creator program. Addresses and bumps for the currency's PDAs are
+// derived from the authority, name and seed.
+type Record struct {
+	Id uint64
+
+	Intent string
+
+	Name        string
+	Symbol      string
+	Description string
+	ImageUrl    string
+
+	Seed      string
+	Authority string
+	Mint      string
+
+	SellFeeBps uint16
+
+	// Address lookup table for the currency, which is set once it's been
+	// created on the blockchain
+	Alt string
+
+	// Number of times a failed transaction for the launch has been retried
+	RetryAttempts uint32
+
+	CreatedBy string
+
+	State State
+
+	Version uint64
+
+	CreatedAt time.Time
+}
+
+func (r *Record) Validate() error {
+	if len(r.Intent) == 0 {
+		return errors.New("intent is required")
+	}
+
+	if len(r.Name) == 0 {
+		return errors.New("name is required")
+	}
+
+	if len(r.Symbol) == 0 {
+		return errors.New("symbol is required")
+	}
+
+	if len(r.Description) == 0 {
+		return errors.New("description is required")
+	}
+
+	if len(r.ImageUrl) == 0 {
+		return errors.New("image url is required")
+	}
+
+	if len(r.Seed) == 0 {
+		return errors.New("seed is required")
+	}
+
+	if len(r.Authority) == 0 {
+		return errors.New("authority is required")
+	}
+
+	if len(r.Mint) == 0 {
+		return errors.New("mint is required")
+	}
+
+	if len(r.CreatedBy) == 0 {
+		return errors.New("created by is required")
+	}
+
+	if r.State == StateUnknown {
+		return errors.New("state is required")
+	}
+
+	if r.State == StateFinalized && len(r.Alt) == 0 {
+		return errors.New("alt is required when finalized")
+	}
+
+	return nil
+}
+
+func (r *Record) Clone() Record {
+	return Record{
+		Id: r.Id,
+
+		Intent: r.Intent,
+
+		Name:        r.Name,
+		Symbol:      r.Symbol,
+		Description: r.Description,
+		ImageUrl:    r.ImageUrl,
+
+		Seed:      r.Seed,
+		Authority: r.Authority,
+		Mint:      r.Mint,
+
+		SellFeeBps: r.SellFeeBps,
+
+		Alt: r.Alt,
+
+		RetryAttempts: r.RetryAttempts,
+
+		CreatedBy: r.CreatedBy,
+
+		State: r.State,
+
+		Version: r.Version,
+
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+func (r *Record) CopyTo(dst *Record) {
+	dst.Id = r.Id
+
+	dst.Intent = r.Intent
+
+	dst.Name = r.Name
+	dst.Symbol = r.Symbol
+	dst.Description = r.Description
+	dst.ImageUrl = r.ImageUrl
+
+	dst.Seed = r.Seed
+	dst.Authority = r.Authority
+	dst.Mint = r.Mint
+
+	dst.SellFeeBps = r.SellFeeBps
+
+	dst.Alt = r.Alt
+
+	dst.RetryAttempts = r.RetryAttempts
+
+	dst.CreatedBy = r.CreatedBy
+
+	dst.State = r.State
+
+	dst.Version = r.Version
+
+	dst.CreatedAt = r.CreatedAt
+}
+
+func (s State) IsTerminal() bool {
+	return s == StateFinalized || s == StateFailed
+}
+
+func (s State) String() string {
+	switch s {
+	case StatePending:
+		return "pending"
+	case StateFinalized:
+		return "finalized"
+	case StateFailed:
+		return "failed"
+	}
+	return "unknown"
+}
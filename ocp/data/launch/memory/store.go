@@ -0,0 +1,125 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/code-payments/ocp-server/ocp/data/launch"
+)
+
+type store struct {
+	mu      sync.Mutex
+	last    uint64
+	records []*launch.Record
+}
+
+// New returns a new in memory launch.Store
+func New() launch.Store {
+	return &store{}
+}
+
+// Put implements launch.Store.Put
+func (s *store) Put(_ context.Context, record *launch.Record) error {
+	if err := record.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, item := range s.records {
+		if item.Intent == record.Intent || item.Mint == record.Mint {
+			return launch.ErrAlreadyExists
+		}
+
+		if item.Symbol == record.Symbol && item.State != launch.StateFailed && record.State != launch.StateFailed {
+			return launch.ErrAlreadyExists
+		}
+	}
+
+	s.last++
+	record.Id = s.last
+	record.Version = 1
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+
+	cloned := record.Clone()
+	s.records = append(s.records, &cloned)
+
+	return nil
+}
+
+// Update implements launch.Store.Update
+func (s *store) Update(_ context.Context, record *launch.Record) error {
+	if err := record.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item := s.find(func(item *launch.Record) bool { return item.Intent == record.Intent })
+	if item == nil {
+		return launch.ErrNotFound
+	}
+
+	if item.Version != record.Version {
+		return launch.ErrStaleVersion
+	}
+
+	record.Version++
+
+	item.Alt = record.Alt
+	item.RetryAttempts = record.RetryAttempts
+	item.State = record.State
+	item.Version = record.Version
+
+	return nil
+}
+
+// GetByIntent implements launch.Store.GetByIntent
+func (s *store) GetByIntent(_ context.Context, intent string) (*launch.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item := s.find(func(item *launch.Record) bool { return item.Intent == intent })
+	if item == nil {
+		return nil, launch.ErrNotFound
+	}
+
+	cloned := item.Clone()
+	return &cloned, nil
+}
+
+// GetByMint implements launch.Store.GetByMint
+func (s *store) GetByMint(_ context.Context, mint string) (*launch.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item := s.find(func(item *launch.Record) bool { return item.Mint == mint })
+	if item == nil {
+		return nil, launch.ErrNotFound
+	}
+
+	cloned := item.Clone()
+	return &cloned, nil
+}
+
+func (s *store) find(matches func(item *launch.Record) bool) *launch.Record {
+	for _, item := range s.records {
+		if matches(item) {
+			return item
+		}
+	}
+	return nil
+}
+
+func (s *store) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.last = 0
+	s.records = nil
+}
@@ -0,0 +1 @@
+DROP TABLE ocp__core_currencylaunch;
@@ -0,0 +1,32 @@
+CREATE TABLE ocp__core_currencylaunch (
+	id SERIAL NOT NULL PRIMARY KEY,
+
+	intent TEXT NOT NULL,
+
+	name TEXT NOT NULL,
+	symbol TEXT NOT NULL,
+	description TEXT NOT NULL,
+	image_url TEXT NOT NULL,
+
+	seed TEXT NOT NULL,
+	authority TEXT NOT NULL,
+	mint TEXT NOT NULL,
+
+	sell_fee_bps INTEGER NOT NULL,
+
+	alt TEXT NULL,
+
+	created_by TEXT NOT NULL,
+
+	state INTEGER NOT NULL,
+
+	version INTEGER NOT NULL,
+
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	CONSTRAINT ocp__core_currencylaunch__uniq__intent UNIQUE (intent),
+	CONSTRAINT ocp__core_currencylaunch__uniq__mint UNIQUE (mint)
+);
+
+-- Symbols are reserved by any launch that hasn't failed
+CREATE UNIQUE INDEX ocp__core_currencylaunch__uniq__symbol ON ocp__core_currencylaunch (symbol) WHERE state != 3;
@@ -0,0 +1,2 @@
+ALTER TABLE ocp__core_currencylaunch
+	DROP COLUMN retry_attempts;
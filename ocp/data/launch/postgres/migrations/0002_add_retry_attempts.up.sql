@@ -0,0 +1,2 @@
+ALTER TABLE ocp__core_currencylaunch
+	ADD COLUMN retry_attempts INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,211 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	pgutil "github.com/code-payments/ocp-server/database/postgres"
+	"github.com/code-payments/ocp-server/ocp/data/launch"
+)
+
+const (
+	tableName = "ocp__core_currencylaunch"
+
+	allColumns = `id, intent, name, symbol, description, image_url, seed, authority, mint, sell_fee_bps, alt, retry_attempts, created_by, state, version, created_at`
+)
+
+type model struct {
+	Id sql.NullInt64 `db:"id"`
+
+	Intent string `db:"intent"`
+
+	Name        string `db:"name"`
+	Symbol      string `db:"symbol"`
+	Description string `db:"description"`
+	ImageUrl    string `db:"image_url"`
+
+	Seed      string `db:"seed"`
+	Authority string `db:"authority"`
+	Mint      string `db:"mint"`
+
+	SellFeeBps uint16 `db:"sell_fee_bps"`
+
+	Alt sql.NullString `db:"alt"`
+
+	RetryAttempts uint32 `db:"retry_attempts"`
+
+	CreatedBy string `db:"created_by"`
+
+	State uint8 `db:"state"`
+
+	Version uint64 `db:"version"`
+
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func toModel(obj *launch.Record) (*model, error) {
+	if err := obj.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &model{
+		Id: sql.NullInt64{Int64: int64(obj.Id), Valid: obj.Id > 0},
+
+		Intent: obj.Intent,
+
+		Name:        obj.Name,
+		Symbol:      obj.Symbol,
+		Description: obj.Description,
+		ImageUrl:    obj.ImageUrl,
+
+		Seed:      obj.Seed,
+		Authority: obj.Authority,
+		Mint:      obj.Mint,
+
+		SellFeeBps: obj.SellFeeBps,
+
+		Alt: sql.NullString{String: obj.Alt, Valid: len(obj.Alt) > 0},
+
+		RetryAttempts: obj.RetryAttempts,
+
+		CreatedBy: obj.CreatedBy,
+
+		State: uint8(obj.State),
+
+		Version: obj.Version,
+
+		CreatedAt: obj.CreatedAt.UTC(),
+	}, nil
+}
+
+func fromModel(obj *model) *launch.Record {
+	return &launch.Record{
+		Id: uint64(obj.Id.Int64),
+
+		Intent: obj.Intent,
+
+		Name:        obj.Name,
+		Symbol:      obj.Symbol,
+		Description: obj.Description,
+		ImageUrl:    obj.ImageUrl,
+
+		Seed:      obj.Seed,
+		Authority: obj.Authority,
+		Mint:      obj.Mint,
+
+		SellFeeBps: obj.SellFeeBps,
+
+		Alt: obj.Alt.String,
+
+		RetryAttempts: obj.RetryAttempts,
+
+		CreatedBy: obj.CreatedBy,
+
+		State: launch.State(obj.State),
+
+		Version: obj.Version,
+
+		CreatedAt: obj.CreatedAt,
+	}
+}
+
+func (m *model) dbPut(ctx context.Context, db *sqlx.DB) error {
+	return pgutil.ExecuteInTx(ctx, db, sql.LevelDefault, func(tx *sqlx.Tx) error {
+		query := `INSERT INTO ` + tableName + `
+			(intent, name, symbol, description, image_url, seed, authority, mint, sell_fee_bps, alt, retry_attempts, created_by, state, version, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, 1, $14)
+			RETURNING ` + allColumns
+
+		if m.CreatedAt.IsZero() {
+			m.CreatedAt = time.Now().UTC()
+		}
+
+		err := tx.QueryRowxContext(
+			ctx,
+			query,
+			m.Intent,
+			m.Name,
+			m.Symbol,
+			m.Description,
+			m.ImageUrl,
+			m.Seed,
+			m.Authority,
+			m.Mint,
+			m.SellFeeBps,
+			m.Alt,
+			m.RetryAttempts,
+			m.CreatedBy,
+			m.State,
+			m.CreatedAt,
+		).StructScan(m)
+
+		return pgutil.CheckUniqueViolation(err, launch.ErrAlreadyExists)
+	})
+}
+
+func (m *model) dbUpdate(ctx context.Context, db *sqlx.DB) error {
+	return pgutil.ExecuteInTx(ctx, db, sql.LevelDefault, func(tx *sqlx.Tx) error {
+		query := `UPDATE ` + tableName + `
+			SET alt = $3, retry_attempts = $4, state = $5, version = version + 1
+			WHERE intent = $1 AND version = $2
+			RETURNING ` + allColumns
+
+		err := tx.QueryRowxContext(
+			ctx,
+			query,
+			m.Intent,
+			m.Version,
+			m.Alt,
+			m.RetryAttempts,
+			m.State,
+		).StructScan(m)
+		if err == nil {
+			return nil
+		} else if err != sql.ErrNoRows {
+			return err
+		}
+
+		// Distinguish between a missing record and a stale version
+		var count uint64
+		err = tx.GetContext(ctx, &count, `SELECT COUNT(*) FROM `+tableName+` WHERE intent = $1`, m.Intent)
+		if err != nil {
+			return err
+		} else if count == 0 {
+			return launch.ErrNotFound
+		}
+		return launch.ErrStaleVersion
+	})
+}
+
+func dbGetByIntent(ctx context.Context, db *sqlx.DB, intent string) (*model, error) {
+	res := &model{}
+
+	query := `SELECT ` + allColumns + `
+		FROM ` + tableName + `
+		WHERE intent = $1
+		LIMIT 1`
+
+	err := db.GetContext(ctx, res, query, intent)
+	if err != nil {
+		return nil, pgutil.CheckNoRows(err, launch.ErrNotFound)
+	}
+	return res, nil
+}
+
+func dbGetByMint(ctx context.Context, db *sqlx.DB, mint string) (*model, error) {
+	res := &model{}
+
+	query := `SELECT ` + allColumns + `
+		FROM ` + tableName + `
+		WHERE mint = $1
+		LIMIT 1`
+
+	err := db.GetContext(ctx, res, query, mint)
+	if err != nil {
+		return nil, pgutil.CheckNoRows(err, launch.ErrNotFound)
+	}
+	return res, nil
+}
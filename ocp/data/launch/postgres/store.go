@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/code-payments/ocp-server/ocp/data/launch"
+)
+
+type store struct {
+	db *sqlx.DB
+}
+
+// New returns a new postgres launch.Store
+func New(db *sql.DB) launch.Store {
+	return &store{
+		db: sqlx.NewDb(db, "pgx"),
+	}
+}
+
+// Put implements launch.Store.Put
+func (s *store) Put(ctx context.Context, record *launch.Record) error {
+	model, err := toModel(record)
+	if err != nil {
+		return err
+	}
+
+	err = model.dbPut(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	res := fromModel(model)
+	res.CopyTo(record)
+
+	return nil
+}
+
+// Update implements launch.Store.Update
+func (s *store) Update(ctx context.Context, record *launch.Record) error {
+	model, err := toModel(record)
+	if err != nil {
+		return err
+	}
+
+	err = model.dbUpdate(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	res := fromModel(model)
+	res.CopyTo(record)
+
+	return nil
+}
+
+// GetByIntent implements launch.Store.GetByIntent
+func (s *store) GetByIntent(ctx context.Context, intent string) (*launch.Record, error) {
+	model, err := dbGetByIntent(ctx, s.db, intent)
+	if err != nil {
+		return nil, err
+	}
+	return fromModel(model), nil
+}
+
+// GetByMint implements launch.Store.GetByMint
+func (s *store) GetByMint(ctx context.Context, mint string) (*launch.Record, error) {
+	model, err := dbGetByMint(ctx, s.db, mint)
+	if err != nil {
+		return nil, err
+	}
+	return fromModel(model), nil
+}
@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/ory/dockertest/v3"
+	"go.uber.org/zap"
+
+	"github.com/code-payments/ocp-server/ocp/data/launch"
+	"github.com/code-payments/ocp-server/ocp/data/launch/tests"
+
+	"github.com/code-payments/ocp-server/database/postgres/migration"
+	postgrestest "github.com/code-payments/ocp-server/database/postgres/test"
+
+	_ "github.com/jackc/pgx/v4/stdlib"
+)
+
+var (
+	testStore launch.Store
+	teardown  func()
+)
+
+func TestMain(m *testing.M) {
+	log := zap.Must(zap.NewDevelopment())
+
+	testPool, err := dockertest.NewPool("")
+	if err != nil {
+		log.With(zap.Error(err)).Error("Error creating docker pool")
+		os.Exit(1)
+	}
+
+	var cleanUpFunc func()
+	db, cleanUpFunc, err := postgrestest.StartPostgresDB(testPool)
+	if err != nil {
+		log.With(zap.Error(err)).Error("Error starting postgres image")
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	if err := createTestTables(log, db); err != nil {
+		log.With(zap.Error(err)).Error("Error creating test tables")
+		cleanUpFunc()
+		os.Exit(1)
+	}
+
+	testStore = New(db)
+	teardown = func() {
+		if pc := recover(); pc != nil {
+			cleanUpFunc()
+			panic(pc)
+		}
+
+		if err := resetTestTables(log, db); err != nil {
+			log.With(zap.Error(err)).Error("Error resetting test tables")
+			cleanUpFunc()
+			os.Exit(1)
+		}
+	}
+
+	code := m.Run()
+	cleanUpFunc()
+	os.Exit(code)
+}
+
+func TestLaunchPostgresStore(t *testing.T) {
+	tests.RunTests(t, testStore, teardown)
+}
+
+func createTestTables(log *zap.Logger, db *sql.DB) error {
+	migrations, err := Migrations()
+	if err != nil {
+		log.With(zap.Error(err)).Error("could not load migrations")
+		return err
+	}
+
+	err = migration.Up(context.Background(), db, migrations)
+	if err != nil {
+		log.With(zap.Error(err)).Error("could not create test tables")
+		return err
+	}
+	return nil
+}
+
+func resetTestTables(log *zap.Logger, db *sql.DB) error {
+	migrations, err := Migrations()
+	if err != nil {
+		log.With(zap.Error(err)).Error("could not load migrations")
+		return err
+	}
+
+	err = migration.Down(context.Background(), db, migrations)
+	if err != nil {
+		log.With(zap.Error(err)).Error("could not drop test tables")
+		return err
+	}
+
+	return createTestTables(log, db)
+}
@@ -0,0 +1,38 @@
+package launch
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrNotFound      = errors.New("currency launch not found")
+	ErrAlreadyExists = errors.New("currency launch already exists")
+	ErrStaleVersion  = errors.New("currency launch version is stale")
+)
+
+// Store tracks launchpad currencies through their creation on the blockchain
+type Store interface {
+	// Put creates a new currency launch
+	//
+	// Returns ErrAlreadyExists if a launch with the same intent or mint already
+	// exists, or if a launch that hasn't failed already uses the symbol.
+	Put(ctx context.Context, record *Record) error
+
+	// Update updates the ALT, retry attempts and state of an existing currency
+	// launch
+	//
+	// Returns ErrNotFound if the launch doesn't exist, and ErrStaleVersion if
+	// the record was updated since it was last read.
+	Update(ctx context.Context, record *Record) error
+
+	// GetByIntent gets a currency launch by the intent that's creating it
+	//
+	// Returns ErrNotFound if no record is found.
+	GetByIntent(ctx context.Context, intent string) (*Record, error)
+
+	// GetByMint gets a currency launch by the mint being created
+	//
+	// Returns ErrNotFound if no record is found.
+	GetByMint(ctx context.Context, mint string) (*Record, error)
+}
@@ -0,0 +1,151 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/code-payments/ocp-server/ocp/data/launch"
+)
+
+func RunTests(t *testing.T, s launch.Store, teardown func()) {
+	for _, tf := range []func(t *testing.T, s launch.Store){
+		testRoundTrip,
+		testUpdate,
+		testUniqueness,
+	} {
+		tf(t, s)
+		teardown()
+	}
+}
+
+func testRoundTrip(t *testing.T, s launch.Store) {
+	t.Run("testRoundTrip", func(t *testing.T) {
+		ctx := context.Background()
+
+		_, err := s.GetByIntent(ctx, "intent1")
+		assert.Equal(t, launch.ErrNotFound, err)
+
+		_, err = s.GetByMint(ctx, "mint1")
+		assert.Equal(t, launch.ErrNotFound, err)
+
+		expected := newTestRecord("intent1", "mint1", "JFY")
+		cloned := expected.Clone()
+
+		require.NoError(t, s.Put(ctx, expected))
+		assert.True(t, expected.Id > 0)
+		assert.EqualValues(t, 1, expected.Version)
+		assert.False(t, expected.CreatedAt.IsZero())
+		assertEquivalentRecords(t, &cloned, expected)
+
+		actual, err := s.GetByIntent(ctx, "intent1")
+		require.NoError(t, err)
+		assert.Equal(t, expected.Id, actual.Id)
+		assert.Equal(t, expected.Version, actual.Version)
+		assert.Equal(t, expected.CreatedAt.Unix(), actual.CreatedAt.Unix())
+		assertEquivalentRecords(t, expected, actual)
+
+		actual, err = s.GetByMint(ctx, "mint1")
+		require.NoError(t, err)
+		assert.Equal(t, expected.Id, actual.Id)
+		assertEquivalentRecords(t, expected, actual)
+	})
+}
+
+func testUpdate(t *testing.T, s launch.Store) {
+	t.Run("testUpdate", func(t *testing.T) {
+		ctx := context.Background()
+
+		record := newTestRecord("intent1", "mint1", "JFY")
+		assert.Equal(t, launch.ErrNotFound, s.Update(ctx, record))
+
+		require.NoError(t, s.Put(ctx, record))
+
+		stale := record.Clone()
+
+		record.Alt = "alt"
+		require.NoError(t, s.Update(ctx, record))
+		assert.EqualValues(t, 2, record.Version)
+
+		record.RetryAttempts = 2
+		record.State = launch.StateFinalized
+		require.NoError(t, s.Update(ctx, record))
+		assert.EqualValues(t, 3, record.Version)
+
+		stale.State = launch.StateFailed
+		assert.Equal(t, launch.ErrStaleVersion, s.Update(ctx, &stale))
+
+		actual, err := s.GetByIntent(ctx, "intent1")
+		require.NoError(t, err)
+		assertEquivalentRecords(t, record, actual)
+		assert.Equal(t, "alt", actual.Alt)
+		assert.EqualValues(t, 2, actual.RetryAttempts)
+		assert.Equal(t, launch.StateFinalized, actual.State)
+		assert.EqualValues(t, 3, actual.Version)
+
+		// Only the ALT, retry attempts and state are updatable
+		record.Name = "Updated"
+		require.NoError(t, s.Update(ctx, record))
+
+		actual, err = s.GetByIntent(ctx, "intent1")
+		require.NoError(t, err)
+		assert.Equal(t, "Jeffy", actual.Name)
+	})
+}
+
+func testUniqueness(t *testing.T, s launch.Store) {
+	t.Run("testUniqueness", func(t *testing.T) {
+		ctx := context.Background()
+
+		record := newTestRecord("intent1", "mint1", "JFY")
+		require.NoError(t, s.Put(ctx, record))
+
+		assert.Equal(t, launch.ErrAlreadyExists, s.Put(ctx, newTestRecord("intent1", "mint2", "OTHER")))
+		assert.Equal(t, launch.ErrAlreadyExists, s.Put(ctx, newTestRecord("intent2", "mint1", "OTHER")))
+		assert.Equal(t, launch.ErrAlreadyExists, s.Put(ctx, newTestRecord("intent2", "mint2", "JFY")))
+
+		// Symbols are released when a launch fails
+		record.State = launch.StateFailed
+		require.NoError(t, s.Update(ctx, record))
+		require.NoError(t, s.Put(ctx, newTestRecord("intent2", "mint2", "JFY")))
+	})
+}
+
+func newTestRecord(intent, mint, symbol string) *launch.Record {
+	return &launch.Record{
+		Intent: intent,
+
+		Name:        "Jeffy",
+		Symbol:      symbol,
+		Description: "The official currency of Jeffy",
+		ImageUrl:    "https://example.com/jeffy.png",
+
+		Seed:      "seed",
+		Authority: "authority",
+		Mint:      mint,
+
+		SellFeeBps: 100,
+
+		CreatedBy: "owner",
+
+		State: launch.StatePending,
+	}
+}
+
+func assertEquivalentRecords(t *testing.T, obj1, obj2 *launch.Record) {
+	assert.Equal(t, obj1.Intent, obj2.Intent)
+	assert.Equal(t, obj1.Name, obj2.Name)
+	assert.Equal(t, obj1.Symbol, obj2.Symbol)
+	assert.Equal(t, obj1.Description, obj2.Description)
+	assert.Equal(t, obj1.ImageUrl, obj2.ImageUrl)
+	assert.Equal(t, obj1.Seed, obj2.Seed)
+	assert.Equal(t, obj1.Authority, obj2.Authority)
+	assert.Equal(t, obj1.Mint, obj2.Mint)
+	assert.Equal(t, obj1.SellFeeBps, obj2.SellFeeBps)
+	assert.Equal(t, obj1.Alt, obj2.Alt)
+	assert.Equal(t, obj1.RetryAttempts, obj2.RetryAttempts)
+	assert.Equal(t, obj1.CreatedBy, obj2.CreatedBy)
+	assert.Equal(t, obj1.State, obj2.State)
+}
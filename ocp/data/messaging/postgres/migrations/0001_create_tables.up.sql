@@ -0,0 +1,10 @@
+CREATE TABLE ocp__core_message (
+	id SERIAL NOT NULL PRIMARY KEY,
+
+	account TEXT NOT NULL,
+	message_id UUID NOT NULL,
+	message BYTEA NOT NULL,
+	created_at TIMESTAMP WITH TIME ZONE,
+
+	CONSTRAINT ocp__core_message__uniq__account__and__message_id UNIQUE (account, message_id)
+);
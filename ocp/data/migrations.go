@@ -0,0 +1,75 @@
+package data
+
+import (
+	"github.com/code-payments/ocp-server/database/postgres/migration"
+
+	account_postgres_client "github.com/code-payments/ocp-server/ocp/data/account/postgres"
+	action_postgres_client "github.com/code-payments/ocp-server/ocp/data/action/postgres"
+	balance_postgres_client "github.com/code-payments/ocp-server/ocp/data/balance/postgres"
+	batch_postgres_client "github.com/code-payments/ocp-server/ocp/data/batch/postgres"
+	currency_postgres_client "github.com/code-payments/ocp-server/ocp/data/currency/postgres"
+	deposit_postgres_client "github.com/code-payments/ocp-server/ocp/data/deposit/postgres"
+	feeburn_postgres_client "github.com/code-payments/ocp-server/ocp/data/feeburn/postgres"
+	fulfillment_postgres_client "github.com/code-payments/ocp-server/ocp/data/fulfillment/postgres"
+	intent_postgres_client "github.com/code-payments/ocp-server/ocp/data/intent/postgres"
+	launch_postgres_client "github.com/code-payments/ocp-server/ocp/data/launch/postgres"
+	messaging_postgres_client "github.com/code-payments/ocp-server/ocp/data/messaging/postgres"
+	nonce_postgres_client "github.com/code-payments/ocp-server/ocp/data/nonce/postgres"
+	paymentrequest_postgres_client "github.com/code-payments/ocp-server/ocp/data/paymentrequest/postgres"
+	paymentschedule_postgres_client "github.com/code-payments/ocp-server/ocp/data/paymentschedule/postgres"
+	rendezvous_postgres_client "github.com/code-payments/ocp-server/ocp/data/rendezvous/postgres"
+	resolution_postgres_client "github.com/code-payments/ocp-server/ocp/data/resolution/postgres"
+	swap_postgres_client "github.com/code-payments/ocp-server/ocp/data/swap/postgres"
+	timelock_postgres_client "github.com/code-payments/ocp-server/ocp/data/timelock/postgres"
+	transaction_postgres_client "github.com/code-payments/ocp-server/ocp/data/transaction/postgres"
+	vault_postgres_client "github.com/code-payments/ocp-server/ocp/data/vault/postgres"
+	vm_operation_postgres_client "github.com/code-payments/ocp-server/ocp/data/vm/operation/postgres"
+	vm_ram_postgres_client "github.com/code-payments/ocp-server/ocp/data/vm/ram/postgres"
+	vm_registry_postgres_client "github.com/code-payments/ocp-server/ocp/data/vm/registry/postgres"
+	vm_storage_postgres_client "github.com/code-payments/ocp-server/ocp/data/vm/storage/postgres"
+	webhook_postgres_client "github.com/code-payments/ocp-server/ocp/data/webhook/postgres"
+)
+
+// storeMigrations is the order in which each postgres store's migrations are
+// applied. New stores must be appended, so existing databases are migrated in
+// a consistent order.
+var storeMigrations = []func() ([]*migration.Migration, error){
+	account_postgres_client.Migrations,
+	action_postgres_client.Migrations,
+	balance_postgres_client.Migrations,
+	batch_postgres_client.Migrations,
+	currency_postgres_client.Migrations,
+	deposit_postgres_client.Migrations,
+	fulfillment_postgres_client.Migrations,
+	intent_postgres_client.Migrations,
+	messaging_postgres_client.Migrations,
+	nonce_postgres_client.Migrations,
+	rendezvous_postgres_client.Migrations,
+	resolution_postgres_client.Migrations,
+	swap_postgres_client.Migrations,
+	timelock_postgres_client.Migrations,
+	transaction_postgres_client.Migrations,
+	vault_postgres_client.Migrations,
+	vm_ram_postgres_client.Migrations,
+	vm_registry_postgres_client.Migrations,
+	vm_storage_postgres_client.Migrations,
+	webhook_postgres_client.Migrations,
+	launch_postgres_client.Migrations,
+	feeburn_postgres_client.Migrations,
+	vm_operation_postgres_client.Migrations,
+	paymentschedule_postgres_client.Migrations,
+	paymentrequest_postgres_client.Migrations,
+}
+
+// AllMigrations returns the ordered schema migrations for every postgres store
+func AllMigrations() ([]*migration.Migration, error) {
+	var res []*migration.Migration
+	for _, loadMigrations := range storeMigrations {
+		migrations, err := loadMigrations()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, migrations...)
+	}
+	return res, nil
+}
@@ -0,0 +1,35 @@
+package data
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllMigrations(t *testing.T) {
+	migrations, err := AllMigrations()
+	require.NoError(t, err)
+
+	createPattern := regexp.MustCompile(`(?i)CREATE (?:TABLE|INDEX) (ocp__core_[a-z0-9_]+)`)
+	constraintPattern := regexp.MustCompile(`(?i)CONSTRAINT (ocp__core_[a-z0-9_]+)`)
+
+	stores := make(map[string]struct{})
+	objects := make(map[string]string)
+	for _, migration := range migrations {
+		stores[migration.Store] = struct{}{}
+
+		// Tables, indexes and constraints share a namespace in postgres, so
+		// names must be unique across all stores
+		var names [][]string
+		names = append(names, createPattern.FindAllStringSubmatch(migration.Up, -1)...)
+		names = append(names, constraintPattern.FindAllStringSubmatch(migration.Up, -1)...)
+		for _, match := range names {
+			existing, ok := objects[match[1]]
+			assert.False(t, ok, "%s defined in both %s and %s", match[1], existing, migration)
+			objects[match[1]] = migration.String()
+		}
+	}
+	assert.Len(t, stores, len(storeMigrations))
+}
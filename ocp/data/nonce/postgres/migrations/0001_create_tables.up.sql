@@ -0,0 +1,20 @@
+CREATE TABLE ocp__core_nonce(
+	id SERIAL NOT NULL PRIMARY KEY,
+
+	address TEXT NOT NULL UNIQUE,
+	authority TEXT NOT NULL,
+	blockhash TEXT NULL,
+
+	environment INTEGER NOT NULL,
+	environment_instance TEXT NOT NULL,
+
+	purpose INTEGER NOT NULL,
+	state INTEGER NOT NULL,
+
+	signature TEXT NULL,
+
+	claim_node_id TEXT NULL,
+	claim_expires_at BIGINT NULL,
+
+	version BIGINT NOT NULL
+);
@@ -0,0 +1,155 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/code-payments/ocp-server/database/query"
+	"github.com/code-payments/ocp-server/ocp/data/paymentrequest"
+	"github.com/code-payments/ocp-server/pointer"
+)
+
+type ById []*paymentrequest.Record
+
+func (a ById) Len() int           { return len(a) }
+func (a ById) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a ById) Less(i, j int) bool { return a[i].Id < a[j].Id }
+
+type store struct {
+	mu      sync.Mutex
+	last    uint64
+	records []*paymentrequest.Record
+}
+
+// New returns a new in memory paymentrequest.Store
+func New() paymentrequest.Store {
+	return &store{}
+}
+
+// Put implements paymentrequest.Store.Put
+func (s *store) Put(_ context.Context, record *paymentrequest.Record) error {
+	if err := record.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if item := s.find(func(item *paymentrequest.Record) bool { return item.Intent == record.Intent }); item != nil {
+		return paymentrequest.ErrAlreadyExists
+	}
+
+	s.last++
+	record.Id = s.last
+	record.Version = 1
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+
+	cloned := record.Clone()
+	s.records = append(s.records, &cloned)
+
+	return nil
+}
+
+// Update implements paymentrequest.Store.Update
+func (s *store) Update(_ context.Context, record *paymentrequest.Record) error {
+	if err := record.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item := s.find(func(item *paymentrequest.Record) bool { return item.Intent == record.Intent })
+	if item == nil {
+		return paymentrequest.ErrNotFound
+	}
+
+	if item.Version != record.Version {
+		return paymentrequest.ErrStaleVersion
+	}
+
+	record.Version++
+
+	item.State = record.State
+	item.PaidBy = pointer.StringCopy(record.PaidBy)
+	item.PaidAt = pointer.TimeCopy(record.PaidAt)
+	item.Version = record.Version
+
+	return nil
+}
+
+// GetByIntent implements paymentrequest.Store.GetByIntent
+func (s *store) GetByIntent(_ context.Context, intent string) (*paymentrequest.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item := s.find(func(item *paymentrequest.Record) bool { return item.Intent == intent })
+	if item == nil {
+		return nil, paymentrequest.ErrNotFound
+	}
+
+	cloned := item.Clone()
+	return &cloned, nil
+}
+
+// GetAllByOwner implements paymentrequest.Store.GetAllByOwner
+func (s *store) GetAllByOwner(_ context.Context, owner string, cursor query.Cursor, limit uint64, direction query.Ordering) ([]*paymentrequest.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var start uint64
+	if direction == query.Descending {
+		start = s.last + 1
+	}
+	if len(cursor) > 0 {
+		start = cursor.ToUint64()
+	}
+
+	var res []*paymentrequest.Record
+	for _, item := range s.records {
+		if item.Owner != owner {
+			continue
+		}
+
+		if (direction == query.Ascending && item.Id > start) || (direction == query.Descending && item.Id < start) {
+			cloned := item.Clone()
+			res = append(res, &cloned)
+		}
+	}
+
+	if direction == query.Descending {
+		sort.Sort(sort.Reverse(ById(res)))
+	} else {
+		sort.Sort(ById(res))
+	}
+
+	if len(res) > int(limit) {
+		res = res[:limit]
+	}
+
+	if len(res) == 0 {
+		return nil, paymentrequest.ErrNotFound
+	}
+	return res, nil
+}
+
+func (s *store) find(matches func(item *paymentrequest.Record) bool) *paymentrequest.Record {
+	for _, item := range s.records {
+		if matches(item) {
+			return item
+		}
+	}
+	return nil
+}
+
+func (s *store) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.last = 0
+	s.records = nil
+}
@@ -0,0 +1,179 @@
+package paymentrequest
+
+import (
+	"errors"
+	"time"
+
+	"github.com/code-payments/ocp-server/currency"
+	"github.com/code-payments/ocp-server/pointer"
+)
+
+type State uint8
+
+const (
+	StateUnknown State = iota
+	StatePending       // Waiting to be paid, until the request expires
+	StatePaid          // Paid by a confirmed SendPublicPayment intent
+	StateFailed        // The paying intent failed or was revoked, so it must be reissued
+)
+
+// Record is a payment request created by a merchant. It's identified by a
+// rendezvous key, which the payer must use as the ID of the SendPublicPayment
+// intent that pays the request.
+type Record struct {
+	Id uint64
+
+	// The rendezvous public key, which is the ID reserved for the intent that
+	// pays the request
+	Intent string
+
+	Owner string
+	Mint  string
+
+	DestinationTokenAccount string
+
+	// The requested amount, which must be paid exactly
+	ExchangeCurrency currency.Code
+	NativeAmount     float64
+
+	Memo string
+
+	ExpiresAt time.Time
+
+	State State
+
+	// The owner that paid the request, and when it was paid
+	PaidBy *string
+	PaidAt *time.Time
+
+	Version uint64
+
+	CreatedAt time.Time
+}
+
+// IsExpired returns whether the request can no longer be paid because it
+// expired
+func (r *Record) IsExpired() bool {
+	return r.State == StatePending && time.Now().After(r.ExpiresAt)
+}
+
+func (r *Record) Validate() error {
+	if len(r.Intent) == 0 {
+		return errors.New("intent is required")
+	}
+
+	if len(r.Owner) == 0 {
+		return errors.New("owner is required")
+	}
+
+	if len(r.Mint) == 0 {
+		return errors.New("mint is required")
+	}
+
+	if len(r.DestinationTokenAccount) == 0 {
+		return errors.New("destination token account is required")
+	}
+
+	if len(r.ExchangeCurrency) == 0 {
+		return errors.New("exchange currency is required")
+	}
+
+	if r.NativeAmount <= 0 {
+		return errors.New("native amount must be positive")
+	}
+
+	if r.ExpiresAt.IsZero() {
+		return errors.New("expiry is required")
+	}
+
+	switch r.State {
+	case StatePending, StateFailed:
+		if r.PaidBy != nil || r.PaidAt != nil {
+			return errors.New("unpaid requests cannot have payment details")
+		}
+	case StatePaid:
+		if r.PaidBy == nil || len(*r.PaidBy) == 0 {
+			return errors.New("paid by is required for paid requests")
+		}
+
+		if *r.PaidBy == r.Owner {
+			return errors.New("owner cannot pay their own request")
+		}
+
+		if r.PaidAt == nil || r.PaidAt.IsZero() {
+			return errors.New("paid at is required for paid requests")
+		}
+	default:
+		return errors.New("state is required")
+	}
+
+	return nil
+}
+
+func (r *Record) Clone() Record {
+	return Record{
+		Id: r.Id,
+
+		Intent: r.Intent,
+
+		Owner: r.Owner,
+		Mint:  r.Mint,
+
+		DestinationTokenAccount: r.DestinationTokenAccount,
+
+		ExchangeCurrency: r.ExchangeCurrency,
+		NativeAmount:     r.NativeAmount,
+
+		Memo: r.Memo,
+
+		ExpiresAt: r.ExpiresAt,
+
+		State: r.State,
+
+		PaidBy: pointer.StringCopy(r.PaidBy),
+		PaidAt: pointer.TimeCopy(r.PaidAt),
+
+		Version: r.Version,
+
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+func (r *Record) CopyTo(dst *Record) {
+	dst.Id = r.Id
+
+	dst.Intent = r.Intent
+
+	dst.Owner = r.Owner
+	dst.Mint = r.Mint
+
+	dst.DestinationTokenAccount = r.DestinationTokenAccount
+
+	dst.ExchangeCurrency = r.ExchangeCurrency
+	dst.NativeAmount = r.NativeAmount
+
+	dst.Memo = r.Memo
+
+	dst.ExpiresAt = r.ExpiresAt
+
+	dst.State = r.State
+
+	dst.PaidBy = pointer.StringCopy(r.PaidBy)
+	dst.PaidAt = pointer.TimeCopy(r.PaidAt)
+
+	dst.Version = r.Version
+
+	dst.CreatedAt = r.CreatedAt
+}
+
+func (s State) String() string {
+	switch s {
+	case StatePending:
+		return "pending"
+	case StatePaid:
+		return "paid"
+	case StateFailed:
+		return "failed"
+	}
+	return "unknown"
+}
@@ -0,0 +1,30 @@
+CREATE TABLE ocp__core_paymentrequest (
+	id SERIAL NOT NULL PRIMARY KEY,
+
+	intent TEXT NOT NULL,
+
+	owner TEXT NOT NULL,
+	mint TEXT NOT NULL,
+
+	destination_token_account TEXT NOT NULL,
+
+	exchange_currency VARCHAR(3) NOT NULL,
+	native_amount NUMERIC(18, 9) NOT NULL,
+
+	memo TEXT NOT NULL,
+
+	expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	state INTEGER NOT NULL,
+
+	paid_by TEXT NULL,
+	paid_at TIMESTAMP WITH TIME ZONE NULL,
+
+	version INTEGER NOT NULL,
+
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	CONSTRAINT ocp__core_paymentrequest__uniq__intent UNIQUE (intent)
+);
+
+CREATE INDEX ocp__core_paymentrequest__idx__owner ON ocp__core_paymentrequest (owner);
@@ -0,0 +1,224 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/code-payments/ocp-server/currency"
+	pgutil "github.com/code-payments/ocp-server/database/postgres"
+	q "github.com/code-payments/ocp-server/database/query"
+	"github.com/code-payments/ocp-server/ocp/data/paymentrequest"
+	"github.com/code-payments/ocp-server/pointer"
+)
+
+const (
+	tableName = "ocp__core_paymentrequest"
+
+	allColumns = `id, intent, owner, mint, destination_token_account, exchange_currency, native_amount, memo, expires_at, state, paid_by, paid_at, version, created_at`
+)
+
+type model struct {
+	Id sql.NullInt64 `db:"id"`
+
+	Intent string `db:"intent"`
+
+	Owner string `db:"owner"`
+	Mint  string `db:"mint"`
+
+	DestinationTokenAccount string `db:"destination_token_account"`
+
+	ExchangeCurrency string  `db:"exchange_currency"`
+	NativeAmount     float64 `db:"native_amount"`
+
+	Memo string `db:"memo"`
+
+	ExpiresAt time.Time `db:"expires_at"`
+
+	State uint8 `db:"state"`
+
+	PaidBy sql.NullString `db:"paid_by"`
+	PaidAt sql.NullTime   `db:"paid_at"`
+
+	Version uint64 `db:"version"`
+
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func toModel(obj *paymentrequest.Record) (*model, error) {
+	if err := obj.Validate(); err != nil {
+		return nil, err
+	}
+
+	var paidAt sql.NullTime
+	if obj.PaidAt != nil {
+		paidAt.Valid = true
+		paidAt.Time = obj.PaidAt.UTC()
+	}
+
+	return &model{
+		Id: sql.NullInt64{Int64: int64(obj.Id), Valid: obj.Id > 0},
+
+		Intent: obj.Intent,
+
+		Owner: obj.Owner,
+		Mint:  obj.Mint,
+
+		DestinationTokenAccount: obj.DestinationTokenAccount,
+
+		ExchangeCurrency: string(obj.ExchangeCurrency),
+		NativeAmount:     obj.NativeAmount,
+
+		Memo: obj.Memo,
+
+		ExpiresAt: obj.ExpiresAt.UTC(),
+
+		State: uint8(obj.State),
+
+		PaidBy: sql.NullString{String: *pointer.StringOrDefault(obj.PaidBy, ""), Valid: obj.PaidBy != nil},
+		PaidAt: paidAt,
+
+		Version: obj.Version,
+
+		CreatedAt: obj.CreatedAt.UTC(),
+	}, nil
+}
+
+func fromModel(obj *model) *paymentrequest.Record {
+	var paidAt *time.Time
+	if obj.PaidAt.Valid {
+		value := obj.PaidAt.Time
+		paidAt = &value
+	}
+
+	return &paymentrequest.Record{
+		Id: uint64(obj.Id.Int64),
+
+		Intent: obj.Intent,
+
+		Owner: obj.Owner,
+		Mint:  obj.Mint,
+
+		DestinationTokenAccount: obj.DestinationTokenAccount,
+
+		ExchangeCurrency: currency.Code(obj.ExchangeCurrency),
+		NativeAmount:     obj.NativeAmount,
+
+		Memo: obj.Memo,
+
+		ExpiresAt: obj.ExpiresAt,
+
+		State: paymentrequest.State(obj.State),
+
+		PaidBy: pointer.StringIfValid(obj.PaidBy.Valid, obj.PaidBy.String),
+		PaidAt: paidAt,
+
+		Version: obj.Version,
+
+		CreatedAt: obj.CreatedAt,
+	}
+}
+
+func (m *model) dbPut(ctx context.Context, db *sqlx.DB) error {
+	return pgutil.ExecuteInTx(ctx, db, sql.LevelDefault, func(tx *sqlx.Tx) error {
+		query := `INSERT INTO ` + tableName + `
+			(intent, owner, mint, destination_token_account, exchange_currency, native_amount, memo, expires_at, state, paid_by, paid_at, version, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, 1, $12)
+			RETURNING ` + allColumns
+
+		if m.CreatedAt.IsZero() {
+			m.CreatedAt = time.Now().UTC()
+		}
+
+		err := tx.QueryRowxContext(
+			ctx,
+			query,
+			m.Intent,
+			m.Owner,
+			m.Mint,
+			m.DestinationTokenAccount,
+			m.ExchangeCurrency,
+			m.NativeAmount,
+			m.Memo,
+			m.ExpiresAt,
+			m.State,
+			m.PaidBy,
+			m.PaidAt,
+			m.CreatedAt,
+		).StructScan(m)
+
+		return pgutil.CheckUniqueViolation(err, paymentrequest.ErrAlreadyExists)
+	})
+}
+
+func (m *model) dbUpdate(ctx context.Context, db *sqlx.DB) error {
+	return pgutil.ExecuteInTx(ctx, db, sql.LevelDefault, func(tx *sqlx.Tx) error {
+		query := `UPDATE ` + tableName + `
+			SET state = $3, paid_by = $4, paid_at = $5, version = version + 1
+			WHERE intent = $1 AND version = $2
+			RETURNING ` + allColumns
+
+		err := tx.QueryRowxContext(
+			ctx,
+			query,
+			m.Intent,
+			m.Version,
+			m.State,
+			m.PaidBy,
+			m.PaidAt,
+		).StructScan(m)
+		if err == nil {
+			return nil
+		} else if err != sql.ErrNoRows {
+			return err
+		}
+
+		// Distinguish between a missing record and a stale version
+		var count uint64
+		err = tx.GetContext(ctx, &count, `SELECT COUNT(*) FROM `+tableName+` WHERE intent = $1`, m.Intent)
+		if err != nil {
+			return err
+		} else if count == 0 {
+			return paymentrequest.ErrNotFound
+		}
+		return paymentrequest.ErrStaleVersion
+	})
+}
+
+func dbGetByIntent(ctx context.Context, db *sqlx.DB, intent string) (*model, error) {
+	res := &model{}
+
+	query := `SELECT ` + allColumns + `
+		FROM ` + tableName + `
+		WHERE intent = $1
+		LIMIT 1`
+
+	err := db.GetContext(ctx, res, query, intent)
+	if err != nil {
+		return nil, pgutil.CheckNoRows(err, paymentrequest.ErrNotFound)
+	}
+	return res, nil
+}
+
+func dbGetAllByOwner(ctx context.Context, db *sqlx.DB, owner string, cursor q.Cursor, limit uint64, direction q.Ordering) ([]*model, error) {
+	res := []*model{}
+
+	query := `SELECT ` + allColumns + `
+		FROM ` + tableName + `
+		WHERE owner = $1`
+
+	opts := []interface{}{owner}
+	query, opts = q.PaginateQuery(query, opts, cursor, limit, direction)
+
+	err := db.SelectContext(ctx, &res, query, opts...)
+	if err != nil {
+		return nil, pgutil.CheckNoRows(err, paymentrequest.ErrNotFound)
+	}
+
+	if len(res) == 0 {
+		return nil, paymentrequest.ErrNotFound
+	}
+	return res, nil
+}
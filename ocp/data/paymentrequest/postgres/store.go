@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/code-payments/ocp-server/database/query"
+	"github.com/code-payments/ocp-server/ocp/data/paymentrequest"
+)
+
+type store struct {
+	db *sqlx.DB
+}
+
+// New returns a new postgres paymentrequest.Store
+func New(db *sql.DB) paymentrequest.Store {
+	return &store{
+		db: sqlx.NewDb(db, "pgx"),
+	}
+}
+
+// Put implements paymentrequest.Store.Put
+func (s *store) Put(ctx context.Context, record *paymentrequest.Record) error {
+	model, err := toModel(record)
+	if err != nil {
+		return err
+	}
+
+	err = model.dbPut(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	res := fromModel(model)
+	res.CopyTo(record)
+
+	return nil
+}
+
+// Update implements paymentrequest.Store.Update
+func (s *store) Update(ctx context.Context, record *paymentrequest.Record) error {
+	model, err := toModel(record)
+	if err != nil {
+		return err
+	}
+
+	err = model.dbUpdate(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	res := fromModel(model)
+	res.CopyTo(record)
+
+	return nil
+}
+
+// GetByIntent implements paymentrequest.Store.GetByIntent
+func (s *store) GetByIntent(ctx context.Context, intent string) (*paymentrequest.Record, error) {
+	model, err := dbGetByIntent(ctx, s.db, intent)
+	if err != nil {
+		return nil, err
+	}
+	return fromModel(model), nil
+}
+
+// GetAllByOwner implements paymentrequest.Store.GetAllByOwner
+func (s *store) GetAllByOwner(ctx context.Context, owner string, cursor query.Cursor, limit uint64, direction query.Ordering) ([]*paymentrequest.Record, error) {
+	models, err := dbGetAllByOwner(ctx, s.db, owner, cursor, limit, direction)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*paymentrequest.Record, len(models))
+	for i, model := range models {
+		res[i] = fromModel(model)
+	}
+	return res, nil
+}
@@ -0,0 +1,39 @@
+package paymentrequest
+
+import (
+	"context"
+	"errors"
+
+	"github.com/code-payments/ocp-server/database/query"
+)
+
+var (
+	ErrNotFound      = errors.New("payment request not found")
+	ErrAlreadyExists = errors.New("payment request already exists")
+	ErrStaleVersion  = errors.New("payment request version is stale")
+)
+
+// Store tracks payment requests created by merchants
+type Store interface {
+	// Put creates a new payment request
+	//
+	// Returns ErrAlreadyExists if a payment request for the same intent exists.
+	Put(ctx context.Context, record *Record) error
+
+	// Update updates the state and payment details of an existing payment
+	// request
+	//
+	// Returns ErrNotFound if the payment request doesn't exist, and
+	// ErrStaleVersion if the record was updated since it was last read.
+	Update(ctx context.Context, record *Record) error
+
+	// GetByIntent gets a payment request by the intent ID reserved to pay it
+	//
+	// Returns ErrNotFound if no record is found.
+	GetByIntent(ctx context.Context, intent string) (*Record, error)
+
+	// GetAllByOwner gets all payment requests created by an owner
+	//
+	// Returns ErrNotFound if no records are found.
+	GetAllByOwner(ctx context.Context, owner string, cursor query.Cursor, limit uint64, direction query.Ordering) ([]*Record, error)
+}
@@ -0,0 +1,177 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/code-payments/ocp-server/currency"
+	"github.com/code-payments/ocp-server/database/query"
+	"github.com/code-payments/ocp-server/ocp/data/paymentrequest"
+	"github.com/code-payments/ocp-server/pointer"
+)
+
+func RunTests(t *testing.T, s paymentrequest.Store, teardown func()) {
+	for _, tf := range []func(t *testing.T, s paymentrequest.Store){
+		testRoundTrip,
+		testUpdate,
+		testGetAllByOwner,
+	} {
+		tf(t, s)
+		teardown()
+	}
+}
+
+func testRoundTrip(t *testing.T, s paymentrequest.Store) {
+	t.Run("testRoundTrip", func(t *testing.T) {
+		ctx := context.Background()
+
+		_, err := s.GetByIntent(ctx, "intent1")
+		assert.Equal(t, paymentrequest.ErrNotFound, err)
+
+		expected := newTestRecord("intent1", "owner1")
+		cloned := expected.Clone()
+
+		require.NoError(t, s.Put(ctx, expected))
+		assert.True(t, expected.Id > 0)
+		assert.EqualValues(t, 1, expected.Version)
+		assert.False(t, expected.CreatedAt.IsZero())
+		assertEquivalentRecords(t, &cloned, expected)
+
+		actual, err := s.GetByIntent(ctx, "intent1")
+		require.NoError(t, err)
+		assert.Equal(t, expected.Id, actual.Id)
+		assert.Equal(t, expected.Version, actual.Version)
+		assert.Equal(t, expected.CreatedAt.Unix(), actual.CreatedAt.Unix())
+		assertEquivalentRecords(t, expected, actual)
+
+		assert.Equal(t, paymentrequest.ErrAlreadyExists, s.Put(ctx, newTestRecord("intent1", "owner2")))
+	})
+}
+
+func testUpdate(t *testing.T, s paymentrequest.Store) {
+	t.Run("testUpdate", func(t *testing.T) {
+		ctx := context.Background()
+
+		record := newTestRecord("intent1", "owner1")
+		assert.Equal(t, paymentrequest.ErrNotFound, s.Update(ctx, record))
+
+		require.NoError(t, s.Put(ctx, record))
+
+		stale := record.Clone()
+
+		paidAt := time.Now()
+		record.State = paymentrequest.StatePaid
+		record.PaidBy = pointer.String("payer")
+		record.PaidAt = pointer.Time(paidAt)
+		require.NoError(t, s.Update(ctx, record))
+		assert.EqualValues(t, 2, record.Version)
+
+		assert.Equal(t, paymentrequest.ErrStaleVersion, s.Update(ctx, &stale))
+
+		actual, err := s.GetByIntent(ctx, "intent1")
+		require.NoError(t, err)
+		assertEquivalentRecords(t, record, actual)
+		assert.Equal(t, paymentrequest.StatePaid, actual.State)
+		assert.Equal(t, "payer", *actual.PaidBy)
+		assert.Equal(t, paidAt.Unix(), actual.PaidAt.Unix())
+		assert.EqualValues(t, 2, actual.Version)
+
+		// Only the state and payment details are updatable
+		record.DestinationTokenAccount = "other"
+		record.NativeAmount = 1
+		record.Memo = "other"
+		require.NoError(t, s.Update(ctx, record))
+
+		actual, err = s.GetByIntent(ctx, "intent1")
+		require.NoError(t, err)
+		assert.Equal(t, "destination_token_account", actual.DestinationTokenAccount)
+		assert.Equal(t, 12.5, actual.NativeAmount)
+		assert.Equal(t, "coffee", actual.Memo)
+		assert.EqualValues(t, 3, actual.Version)
+	})
+}
+
+func testGetAllByOwner(t *testing.T, s paymentrequest.Store) {
+	t.Run("testGetAllByOwner", func(t *testing.T) {
+		ctx := context.Background()
+
+		_, err := s.GetAllByOwner(ctx, "owner1", query.EmptyCursor, 10, query.Ascending)
+		assert.Equal(t, paymentrequest.ErrNotFound, err)
+
+		var records []*paymentrequest.Record
+		for i := 0; i < 4; i++ {
+			record := newTestRecord(fmt.Sprintf("intent%d", i), "owner1")
+			require.NoError(t, s.Put(ctx, record))
+			records = append(records, record)
+		}
+		require.NoError(t, s.Put(ctx, newTestRecord("other", "owner2")))
+
+		actual, err := s.GetAllByOwner(ctx, "owner1", query.EmptyCursor, 10, query.Ascending)
+		require.NoError(t, err)
+		require.Len(t, actual, 4)
+		for i, record := range records {
+			assert.Equal(t, record.Intent, actual[i].Intent)
+		}
+
+		actual, err = s.GetAllByOwner(ctx, "owner1", query.EmptyCursor, 2, query.Descending)
+		require.NoError(t, err)
+		require.Len(t, actual, 2)
+		assert.Equal(t, records[3].Intent, actual[0].Intent)
+		assert.Equal(t, records[2].Intent, actual[1].Intent)
+
+		actual, err = s.GetAllByOwner(ctx, "owner1", query.ToCursor(records[1].Id), 10, query.Ascending)
+		require.NoError(t, err)
+		require.Len(t, actual, 2)
+		assert.Equal(t, records[2].Intent, actual[0].Intent)
+		assert.Equal(t, records[3].Intent, actual[1].Intent)
+
+		actual, err = s.GetAllByOwner(ctx, "owner2", query.EmptyCursor, 10, query.Ascending)
+		require.NoError(t, err)
+		require.Len(t, actual, 1)
+		assert.Equal(t, "other", actual[0].Intent)
+	})
+}
+
+func newTestRecord(intent, owner string) *paymentrequest.Record {
+	return &paymentrequest.Record{
+		Intent: intent,
+
+		Owner: owner,
+		Mint:  "mint",
+
+		DestinationTokenAccount: "destination_token_account",
+
+		ExchangeCurrency: currency.USD,
+		NativeAmount:     12.5,
+
+		Memo: "coffee",
+
+		ExpiresAt: time.Now().Add(time.Hour),
+
+		State: paymentrequest.StatePending,
+	}
+}
+
+func assertEquivalentRecords(t *testing.T, obj1, obj2 *paymentrequest.Record) {
+	assert.Equal(t, obj1.Intent, obj2.Intent)
+	assert.Equal(t, obj1.Owner, obj2.Owner)
+	assert.Equal(t, obj1.Mint, obj2.Mint)
+	assert.Equal(t, obj1.DestinationTokenAccount, obj2.DestinationTokenAccount)
+	assert.Equal(t, obj1.ExchangeCurrency, obj2.ExchangeCurrency)
+	assert.Equal(t, obj1.NativeAmount, obj2.NativeAmount)
+	assert.Equal(t, obj1.Memo, obj2.Memo)
+	assert.Equal(t, obj1.ExpiresAt.Unix(), obj2.ExpiresAt.Unix())
+	assert.Equal(t, obj1.State, obj2.State)
+	assert.EqualValues(t, obj1.PaidBy, obj2.PaidBy)
+	if obj1.PaidAt == nil {
+		assert.Nil(t, obj2.PaidAt)
+	} else {
+		require.NotNil(t, obj2.PaidAt)
+		assert.Equal(t, obj1.PaidAt.Unix(), obj2.PaidAt.Unix())
+	}
+}
@@ -0,0 +1,208 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/code-payments/ocp-server/database/query"
+	"github.com/code-payments/ocp-server/ocp/data/paymentschedule"
+)
+
+type ById []*paymentschedule.Record
+
+func (a ById) Len() int           { return len(a) }
+func (a ById) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a ById) Less(i, j int) bool { return a[i].Id < a[j].Id }
+
+type store struct {
+	mu      sync.Mutex
+	last    uint64
+	records []*paymentschedule.Record
+}
+
+// New returns a new in memory paymentschedule.Store
+func New() paymentschedule.Store {
+	return &store{}
+}
+
+// Put implements paymentschedule.Store.Put
+func (s *store) Put(_ context.Context, record *paymentschedule.Record) error {
+	if err := record.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if item := s.find(func(item *paymentschedule.Record) bool { return item.ScheduleId == record.ScheduleId }); item != nil {
+		return paymentschedule.ErrAlreadyExists
+	}
+
+	s.last++
+	record.Id = s.last
+	record.Version = 1
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+
+	cloned := record.Clone()
+	s.records = append(s.records, &cloned)
+
+	return nil
+}
+
+// Update implements paymentschedule.Store.Update
+func (s *store) Update(_ context.Context, record *paymentschedule.Record) error {
+	if err := record.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item := s.find(func(item *paymentschedule.Record) bool { return item.ScheduleId == record.ScheduleId })
+	if item == nil {
+		return paymentschedule.ErrNotFound
+	}
+
+	if item.Version != record.Version {
+		return paymentschedule.ErrStaleVersion
+	}
+
+	record.Version++
+
+	cloned := record.Clone()
+	item.NextPaymentAt = cloned.NextPaymentAt
+	item.PaymentsMade = cloned.PaymentsMade
+	item.TotalNativeAmountPaid = cloned.TotalNativeAmountPaid
+	item.MissedAttempts = cloned.MissedAttempts
+	item.RetryAt = cloned.RetryAt
+	item.State = cloned.State
+	item.Version = cloned.Version
+
+	return nil
+}
+
+// GetByScheduleId implements paymentschedule.Store.GetByScheduleId
+func (s *store) GetByScheduleId(_ context.Context, scheduleId string) (*paymentschedule.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item := s.find(func(item *paymentschedule.Record) bool { return item.ScheduleId == scheduleId })
+	if item == nil {
+		return nil, paymentschedule.ErrNotFound
+	}
+
+	cloned := item.Clone()
+	return &cloned, nil
+}
+
+// GetAllByOwner implements paymentschedule.Store.GetAllByOwner
+func (s *store) GetAllByOwner(_ context.Context, owner string, cursor query.Cursor, limit uint64, direction query.Ordering) ([]*paymentschedule.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var start uint64
+	if direction == query.Descending {
+		start = s.last + 1
+	}
+	if len(cursor) > 0 {
+		start = cursor.ToUint64()
+	}
+
+	var res []*paymentschedule.Record
+	for _, item := range s.records {
+		if item.Owner != owner {
+			continue
+		}
+
+		if (direction == query.Ascending && item.Id > start) || (direction == query.Descending && item.Id < start) {
+			cloned := item.Clone()
+			res = append(res, &cloned)
+		}
+	}
+
+	if direction == query.Descending {
+		sort.Sort(sort.Reverse(ById(res)))
+	} else {
+		sort.Sort(ById(res))
+	}
+
+	if len(res) > int(limit) {
+		res = res[:limit]
+	}
+
+	if len(res) == 0 {
+		return nil, paymentschedule.ErrNotFound
+	}
+	return res, nil
+}
+
+// GetAllDue implements paymentschedule.Store.GetAllDue
+func (s *store) GetAllDue(_ context.Context, dueBefore time.Time, limit uint64) ([]*paymentschedule.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var res []*paymentschedule.Record
+	for _, item := range s.records {
+		if item.State != paymentschedule.StateActive || item.NextPaymentAt.After(dueBefore) {
+			continue
+		}
+
+		if item.RetryAt != nil && item.RetryAt.After(dueBefore) {
+			continue
+		}
+
+		cloned := item.Clone()
+		res = append(res, &cloned)
+	}
+
+	if len(res) == 0 {
+		return nil, paymentschedule.ErrNotFound
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].NextPaymentAt.Equal(res[j].NextPaymentAt) {
+			return res[i].Id < res[j].Id
+		}
+		return res[i].NextPaymentAt.Before(res[j].NextPaymentAt)
+	})
+
+	if uint64(len(res)) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
+
+// CountByState implements paymentschedule.Store.CountByState
+func (s *store) CountByState(_ context.Context, state paymentschedule.State) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var res uint64
+	for _, item := range s.records {
+		if item.State == state {
+			res++
+		}
+	}
+	return res, nil
+}
+
+func (s *store) find(matches func(item *paymentschedule.Record) bool) *paymentschedule.Record {
+	for _, item := range s.records {
+		if matches(item) {
+			return item
+		}
+	}
+	return nil
+}
+
+func (s *store) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.last = 0
+	s.records = nil
+}
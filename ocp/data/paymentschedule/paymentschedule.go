@@ -0,0 +1,259 @@
+package paymentschedule
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/code-payments/ocp-server/currency"
+	"github.com/code-payments/ocp-server/pointer"
+)
+
+// Accounts for floating point error when comparing native amounts against the
+// amount cap. Native amounts are persisted with 9 decimal places.
+const nativeAmountTolerance = 0.000000001
+
+type State uint8
+
+const (
+	StateUnknown   State = iota
+	StateActive          // Payments are made as they become due
+	StateCompleted       // All payments were made, or the amount cap was reached
+	StateCancelled       // The owner cancelled the schedule
+	StateFailed          // A due payment couldn't be made
+)
+
+// Record is a payment schedule pre-authorized by an owner. The server makes
+// public payments on the owner's behalf as they become due, either once at a
+// future date or on a recurring interval.
+type Record struct {
+	Id uint64
+
+	ScheduleId string
+
+	Owner string
+	Mint  string
+
+	DestinationOwner        string
+	DestinationTokenAccount string
+
+	// The amount of each payment
+	ExchangeCurrency currency.Code
+	NativeAmount     float64
+
+	// The cap on the total amount paid across all payments, in the exchange
+	// currency
+	MaxTotalNativeAmount float64
+
+	// The time between payments, which is zero for one-time payments
+	Interval time.Duration
+
+	// The maximum number of payments made, which is always one for one-time
+	// payments
+	MaxPayments uint64
+
+	NextPaymentAt time.Time
+
+	PaymentsMade          uint64
+	TotalNativeAmountPaid float64
+
+	// The number of consecutive attempts at the next payment that were missed
+	// for a reason that may resolve itself, like an insufficient balance. It's
+	// reset once the payment is made.
+	MissedAttempts uint64
+
+	// When the next payment is retried after a missed attempt. It's unset when
+	// there is no pending retry.
+	RetryAt *time.Time
+
+	State State
+
+	Version uint64
+
+	CreatedAt time.Time
+}
+
+// IsRecurring returns whether the schedule makes payments on an interval
+func (r *Record) IsRecurring() bool {
+	return r.Interval > 0
+}
+
+// HasRemainingPayments returns whether another payment can be made without
+// exceeding the maximum number of payments or the amount cap
+func (r *Record) HasRemainingPayments() bool {
+	if r.PaymentsMade >= r.MaxPayments {
+		return false
+	}
+	return r.TotalNativeAmountPaid+r.NativeAmount <= r.MaxTotalNativeAmount+nativeAmountTolerance
+}
+
+func (r *Record) Validate() error {
+	if len(r.ScheduleId) == 0 {
+		return errors.New("schedule id is required")
+	}
+
+	if len(r.Owner) == 0 {
+		return errors.New("owner is required")
+	}
+
+	if len(r.Mint) == 0 {
+		return errors.New("mint is required")
+	}
+
+	if len(r.DestinationOwner) == 0 {
+		return errors.New("destination owner is required")
+	}
+
+	if len(r.DestinationTokenAccount) == 0 {
+		return errors.New("destination token account is required")
+	}
+
+	if r.Owner == r.DestinationOwner {
+		return errors.New("owner cannot pay themselves")
+	}
+
+	if len(r.ExchangeCurrency) == 0 {
+		return errors.New("exchange currency is required")
+	}
+
+	if r.NativeAmount <= 0 {
+		return errors.New("native amount must be positive")
+	}
+
+	if r.MaxTotalNativeAmount < r.NativeAmount {
+		return errors.New("max total native amount must be at least the native amount")
+	}
+
+	if r.Interval < 0 {
+		return errors.New("interval cannot be negative")
+	}
+
+	if r.MaxPayments == 0 {
+		return errors.New("max payments must be positive")
+	}
+
+	if r.Interval == 0 && r.MaxPayments != 1 {
+		return errors.New("one-time payment schedules make exactly one payment")
+	}
+
+	if r.NextPaymentAt.IsZero() {
+		return errors.New("next payment time is required")
+	}
+
+	if r.PaymentsMade > r.MaxPayments {
+		return errors.New("payments made exceeds max payments")
+	}
+
+	if r.TotalNativeAmountPaid < 0 {
+		return errors.New("total native amount paid cannot be negative")
+	}
+
+	if r.TotalNativeAmountPaid > r.MaxTotalNativeAmount+nativeAmountTolerance {
+		return errors.New("total native amount paid exceeds max total native amount")
+	}
+
+	if r.State == StateUnknown {
+		return errors.New("state is required")
+	}
+
+	return nil
+}
+
+func (r *Record) Clone() Record {
+	return Record{
+		Id: r.Id,
+
+		ScheduleId: r.ScheduleId,
+
+		Owner: r.Owner,
+		Mint:  r.Mint,
+
+		DestinationOwner:        r.DestinationOwner,
+		DestinationTokenAccount: r.DestinationTokenAccount,
+
+		ExchangeCurrency: r.ExchangeCurrency,
+		NativeAmount:     r.NativeAmount,
+
+		MaxTotalNativeAmount: r.MaxTotalNativeAmount,
+
+		Interval: r.Interval,
+
+		MaxPayments: r.MaxPayments,
+
+		NextPaymentAt: r.NextPaymentAt,
+
+		PaymentsMade:          r.PaymentsMade,
+		TotalNativeAmountPaid: r.TotalNativeAmountPaid,
+
+		MissedAttempts: r.MissedAttempts,
+
+		RetryAt: pointer.TimeCopy(r.RetryAt),
+
+		State: r.State,
+
+		Version: r.Version,
+
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+func (r *Record) CopyTo(dst *Record) {
+	dst.Id = r.Id
+
+	dst.ScheduleId = r.ScheduleId
+
+	dst.Owner = r.Owner
+	dst.Mint = r.Mint
+
+	dst.DestinationOwner = r.DestinationOwner
+	dst.DestinationTokenAccount = r.DestinationTokenAccount
+
+	dst.ExchangeCurrency = r.ExchangeCurrency
+	dst.NativeAmount = r.NativeAmount
+
+	dst.MaxTotalNativeAmount = r.MaxTotalNativeAmount
+
+	dst.Interval = r.Interval
+
+	dst.MaxPayments = r.MaxPayments
+
+	dst.NextPaymentAt = r.NextPaymentAt
+
+	dst.PaymentsMade = r.PaymentsMade
+	dst.TotalNativeAmountPaid = r.TotalNativeAmountPaid
+
+	dst.MissedAttempts = r.MissedAttempts
+
+	dst.RetryAt = r.RetryAt
+
+	dst.State = r.State
+
+	dst.Version = r.Version
+
+	dst.CreatedAt = r.CreatedAt
+}
+
+// GetMessageBin returns the messaging store bin that holds an owner's messages
+// for executed and failed scheduled payments. It never collides with a stream's
+// rendezvous key, which is always a base58 encoded public key.
+func GetMessageBin(owner string) string {
+	return fmt.Sprintf("scheduled-payment:%s", owner)
+}
+
+func (s State) IsTerminal() bool {
+	return s == StateCompleted || s == StateCancelled || s == StateFailed
+}
+
+func (s State) String() string {
+	switch s {
+	case StateActive:
+		return "active"
+	case StateCompleted:
+		return "completed"
+	case StateCancelled:
+		return "cancelled"
+	case StateFailed:
+		return "failed"
+	}
+	return "unknown"
+}
@@ -0,0 +1,37 @@
+CREATE TABLE ocp__core_paymentschedule (
+	id SERIAL NOT NULL PRIMARY KEY,
+
+	schedule_id TEXT NOT NULL,
+
+	owner TEXT NOT NULL,
+	mint TEXT NOT NULL,
+
+	destination_owner TEXT NOT NULL,
+	destination_token_account TEXT NOT NULL,
+
+	exchange_currency VARCHAR(3) NOT NULL,
+	native_amount NUMERIC(18, 9) NOT NULL,
+
+	max_total_native_amount NUMERIC(18, 9) NOT NULL,
+
+	-- In nanoseconds, and zero for one-time payments
+	payment_interval BIGINT NOT NULL,
+
+	max_payments INTEGER NOT NULL,
+
+	next_payment_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	payments_made INTEGER NOT NULL,
+	total_native_amount_paid NUMERIC(18, 9) NOT NULL,
+
+	state INTEGER NOT NULL,
+
+	version INTEGER NOT NULL,
+
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	CONSTRAINT ocp__core_paymentschedule__uniq__schedule_id UNIQUE (schedule_id)
+);
+
+CREATE INDEX ocp__core_paymentschedule__idx__owner ON ocp__core_paymentschedule (owner);
+CREATE INDEX ocp__core_paymentschedule__idx__state_next_payment_at ON ocp__core_paymentschedule (state, next_payment_at);
@@ -0,0 +1,3 @@
+ALTER TABLE ocp__core_paymentschedule
+	DROP COLUMN missed_attempts,
+	DROP COLUMN retry_at;
@@ -0,0 +1,3 @@
+ALTER TABLE ocp__core_paymentschedule
+	ADD COLUMN missed_attempts INTEGER NOT NULL DEFAULT 0,
+	ADD COLUMN retry_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,278 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/code-payments/ocp-server/currency"
+	pgutil "github.com/code-payments/ocp-server/database/postgres"
+	q "github.com/code-payments/ocp-server/database/query"
+	"github.com/code-payments/ocp-server/ocp/data/paymentschedule"
+	"github.com/code-payments/ocp-server/pointer"
+)
+
+const (
+	tableName = "ocp__core_paymentschedule"
+
+	allColumns = `id, schedule_id, owner, mint, destination_owner, destination_token_account, exchange_currency, native_amount, max_total_native_amount, payment_interval, max_payments, next_payment_at, payments_made, total_native_amount_paid, missed_attempts, retry_at, state, version, created_at`
+)
+
+type model struct {
+	Id sql.NullInt64 `db:"id"`
+
+	ScheduleId string `db:"schedule_id"`
+
+	Owner string `db:"owner"`
+	Mint  string `db:"mint"`
+
+	DestinationOwner        string `db:"destination_owner"`
+	DestinationTokenAccount string `db:"destination_token_account"`
+
+	ExchangeCurrency string  `db:"exchange_currency"`
+	NativeAmount     float64 `db:"native_amount"`
+
+	MaxTotalNativeAmount float64 `db:"max_total_native_amount"`
+
+	PaymentInterval int64 `db:"payment_interval"`
+
+	MaxPayments uint64 `db:"max_payments"`
+
+	NextPaymentAt time.Time `db:"next_payment_at"`
+
+	PaymentsMade          uint64  `db:"payments_made"`
+	TotalNativeAmountPaid float64 `db:"total_native_amount_paid"`
+
+	MissedAttempts uint64       `db:"missed_attempts"`
+	RetryAt        sql.NullTime `db:"retry_at"`
+
+	State uint8 `db:"state"`
+
+	Version uint64 `db:"version"`
+
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func toModel(obj *paymentschedule.Record) (*model, error) {
+	if err := obj.Validate(); err != nil {
+		return nil, err
+	}
+
+	var retryAt sql.NullTime
+	if obj.RetryAt != nil {
+		retryAt = sql.NullTime{Time: obj.RetryAt.UTC(), Valid: true}
+	}
+
+	return &model{
+		Id: sql.NullInt64{Int64: int64(obj.Id), Valid: obj.Id > 0},
+
+		ScheduleId: obj.ScheduleId,
+
+		Owner: obj.Owner,
+		Mint:  obj.Mint,
+
+		DestinationOwner:        obj.DestinationOwner,
+		DestinationTokenAccount: obj.DestinationTokenAccount,
+
+		ExchangeCurrency: string(obj.ExchangeCurrency),
+		NativeAmount:     obj.NativeAmount,
+
+		MaxTotalNativeAmount: obj.MaxTotalNativeAmount,
+
+		PaymentInterval: int64(obj.Interval),
+
+		MaxPayments: obj.MaxPayments,
+
+		NextPaymentAt: obj.NextPaymentAt.UTC(),
+
+		PaymentsMade:          obj.PaymentsMade,
+		TotalNativeAmountPaid: obj.TotalNativeAmountPaid,
+
+		MissedAttempts: obj.MissedAttempts,
+		RetryAt:        retryAt,
+
+		State: uint8(obj.State),
+
+		Version: obj.Version,
+
+		CreatedAt: obj.CreatedAt.UTC(),
+	}, nil
+}
+
+func fromModel(obj *model) *paymentschedule.Record {
+	return &paymentschedule.Record{
+		Id: uint64(obj.Id.Int64),
+
+		ScheduleId: obj.ScheduleId,
+
+		Owner: obj.Owner,
+		Mint:  obj.Mint,
+
+		DestinationOwner:        obj.DestinationOwner,
+		DestinationTokenAccount: obj.DestinationTokenAccount,
+
+		ExchangeCurrency: currency.Code(obj.ExchangeCurrency),
+		NativeAmount:     obj.NativeAmount,
+
+		MaxTotalNativeAmount: obj.MaxTotalNativeAmount,
+
+		Interval: time.Duration(obj.PaymentInterval),
+
+		MaxPayments: obj.MaxPayments,
+
+		NextPaymentAt: obj.NextPaymentAt,
+
+		PaymentsMade:          obj.PaymentsMade,
+		TotalNativeAmountPaid: obj.TotalNativeAmountPaid,
+
+		MissedAttempts: obj.MissedAttempts,
+		RetryAt:        pointer.TimeIfValid(obj.RetryAt.Valid, obj.RetryAt.Time),
+
+		State: paymentschedule.State(obj.State),
+
+		Version: obj.Version,
+
+		CreatedAt: obj.CreatedAt,
+	}
+}
+
+func (m *model) dbPut(ctx context.Context, db *sqlx.DB) error {
+	return pgutil.ExecuteInTx(ctx, db, sql.LevelDefault, func(tx *sqlx.Tx) error {
+		query := `INSERT INTO ` + tableName + `
+			(schedule_id, owner, mint, destination_owner, destination_token_account, exchange_currency, native_amount, max_total_native_amount, payment_interval, max_payments, next_payment_at, payments_made, total_native_amount_paid, state, version, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, 1, $15)
+			RETURNING ` + allColumns
+
+		if m.CreatedAt.IsZero() {
+			m.CreatedAt = time.Now().UTC()
+		}
+
+		err := tx.QueryRowxContext(
+			ctx,
+			query,
+			m.ScheduleId,
+			m.Owner,
+			m.Mint,
+			m.DestinationOwner,
+			m.DestinationTokenAccount,
+			m.ExchangeCurrency,
+			m.NativeAmount,
+			m.MaxTotalNativeAmount,
+			m.PaymentInterval,
+			m.MaxPayments,
+			m.NextPaymentAt,
+			m.PaymentsMade,
+			m.TotalNativeAmountPaid,
+			m.State,
+			m.CreatedAt,
+		).StructScan(m)
+
+		return pgutil.CheckUniqueViolation(err, paymentschedule.ErrAlreadyExists)
+	})
+}
+
+func (m *model) dbUpdate(ctx context.Context, db *sqlx.DB) error {
+	return pgutil.ExecuteInTx(ctx, db, sql.LevelDefault, func(tx *sqlx.Tx) error {
+		query := `UPDATE ` + tableName + `
+			SET next_payment_at = $3, payments_made = $4, total_native_amount_paid = $5, missed_attempts = $6, retry_at = $7, state = $8, version = version + 1
+			WHERE schedule_id = $1 AND version = $2
+			RETURNING ` + allColumns
+
+		err := tx.QueryRowxContext(
+			ctx,
+			query,
+			m.ScheduleId,
+			m.Version,
+			m.NextPaymentAt,
+			m.PaymentsMade,
+			m.TotalNativeAmountPaid,
+			m.MissedAttempts,
+			m.RetryAt,
+			m.State,
+		).StructScan(m)
+		if err == nil {
+			return nil
+		} else if err != sql.ErrNoRows {
+			return err
+		}
+
+		// Distinguish between a missing record and a stale version
+		var count uint64
+		err = tx.GetContext(ctx, &count, `SELECT COUNT(*) FROM `+tableName+` WHERE schedule_id = $1`, m.ScheduleId)
+		if err != nil {
+			return err
+		} else if count == 0 {
+			return paymentschedule.ErrNotFound
+		}
+		return paymentschedule.ErrStaleVersion
+	})
+}
+
+func dbGetByScheduleId(ctx context.Context, db *sqlx.DB, scheduleId string) (*model, error) {
+	res := &model{}
+
+	query := `SELECT ` + allColumns + `
+		FROM ` + tableName + `
+		WHERE schedule_id = $1
+		LIMIT 1`
+
+	err := db.GetContext(ctx, res, query, scheduleId)
+	if err != nil {
+		return nil, pgutil.CheckNoRows(err, paymentschedule.ErrNotFound)
+	}
+	return res, nil
+}
+
+func dbGetAllByOwner(ctx context.Context, db *sqlx.DB, owner string, cursor q.Cursor, limit uint64, direction q.Ordering) ([]*model, error) {
+	res := []*model{}
+
+	query := `SELECT ` + allColumns + `
+		FROM ` + tableName + `
+		WHERE owner = $1`
+
+	opts := []interface{}{owner}
+	query, opts = q.PaginateQuery(query, opts, cursor, limit, direction)
+
+	err := db.SelectContext(ctx, &res, query, opts...)
+	if err != nil {
+		return nil, pgutil.CheckNoRows(err, paymentschedule.ErrNotFound)
+	}
+
+	if len(res) == 0 {
+		return nil, paymentschedule.ErrNotFound
+	}
+	return res, nil
+}
+
+func dbGetAllDue(ctx context.Context, db *sqlx.DB, dueBefore time.Time, limit uint64) ([]*model, error) {
+	res := []*model{}
+
+	query := `SELECT ` + allColumns + `
+		FROM ` + tableName + `
+		WHERE state = $1 AND next_payment_at <= $2 AND (retry_at IS NULL OR retry_at <= $2)
+		ORDER BY next_payment_at ASC, id ASC
+		LIMIT $3`
+
+	err := db.SelectContext(ctx, &res, query, paymentschedule.StateActive, dueBefore.UTC(), limit)
+	if err != nil {
+		return nil, pgutil.CheckNoRows(err, paymentschedule.ErrNotFound)
+	}
+	if len(res) == 0 {
+		return nil, paymentschedule.ErrNotFound
+	}
+	return res, nil
+}
+
+func dbCountByState(ctx context.Context, db *sqlx.DB, state paymentschedule.State) (uint64, error) {
+	var res uint64
+
+	query := `SELECT COUNT(*) FROM ` + tableName + ` WHERE state = $1`
+
+	err := db.GetContext(ctx, &res, query, state)
+	if err != nil {
+		return 0, err
+	}
+	return res, nil
+}
@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/code-payments/ocp-server/database/query"
+	"github.com/code-payments/ocp-server/ocp/data/paymentschedule"
+)
+
+type store struct {
+	db *sqlx.DB
+}
+
+// New returns a new postgres paymentschedule.Store
+func New(db *sql.DB) paymentschedule.Store {
+	return &store{
+		db: sqlx.NewDb(db, "pgx"),
+	}
+}
+
+// Put implements paymentschedule.Store.Put
+func (s *store) Put(ctx context.Context, record *paymentschedule.Record) error {
+	model, err := toModel(record)
+	if err != nil {
+		return err
+	}
+
+	err = model.dbPut(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	res := fromModel(model)
+	res.CopyTo(record)
+
+	return nil
+}
+
+// Update implements paymentschedule.Store.Update
+func (s *store) Update(ctx context.Context, record *paymentschedule.Record) error {
+	model, err := toModel(record)
+	if err != nil {
+		return err
+	}
+
+	err = model.dbUpdate(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	res := fromModel(model)
+	res.CopyTo(record)
+
+	return nil
+}
+
+// GetByScheduleId implements paymentschedule.Store.GetByScheduleId
+func (s *store) GetByScheduleId(ctx context.Context, scheduleId string) (*paymentschedule.Record, error) {
+	model, err := dbGetByScheduleId(ctx, s.db, scheduleId)
+	if err != nil {
+		return nil, err
+	}
+	return fromModel(model), nil
+}
+
+// GetAllByOwner implements paymentschedule.Store.GetAllByOwner
+func (s *store) GetAllByOwner(ctx context.Context, owner string, cursor query.Cursor, limit uint64, direction query.Ordering) ([]*paymentschedule.Record, error) {
+	models, err := dbGetAllByOwner(ctx, s.db, owner, cursor, limit, direction)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*paymentschedule.Record, len(models))
+	for i, model := range models {
+		res[i] = fromModel(model)
+	}
+	return res, nil
+}
+
+// GetAllDue implements paymentschedule.Store.GetAllDue
+func (s *store) GetAllDue(ctx context.Context, dueBefore time.Time, limit uint64) ([]*paymentschedule.Record, error) {
+	models, err := dbGetAllDue(ctx, s.db, dueBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*paymentschedule.Record, len(models))
+	for i, model := range models {
+		res[i] = fromModel(model)
+	}
+	return res, nil
+}
+
+// CountByState implements paymentschedule.Store.CountByState
+func (s *store) CountByState(ctx context.Context, state paymentschedule.State) (uint64, error) {
+	return dbCountByState(ctx, s.db, state)
+}
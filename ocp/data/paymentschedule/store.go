@@ -0,0 +1,50 @@
+package paymentschedule
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/code-payments/ocp-server/database/query"
+)
+
+var (
+	ErrNotFound      = errors.New("payment schedule not found")
+	ErrAlreadyExists = errors.New("payment schedule already exists")
+	ErrStaleVersion  = errors.New("payment schedule version is stale")
+)
+
+// Store tracks payment schedules pre-authorized by owners
+type Store interface {
+	// Put creates a new payment schedule
+	//
+	// Returns ErrAlreadyExists if a payment schedule with the same ID exists.
+	Put(ctx context.Context, record *Record) error
+
+	// Update updates the next payment time, payment progress, missed attempts
+	// and state of an existing payment schedule
+	//
+	// Returns ErrNotFound if the payment schedule doesn't exist, and
+	// ErrStaleVersion if the record was updated since it was last read.
+	Update(ctx context.Context, record *Record) error
+
+	// GetByScheduleId gets a payment schedule by its ID
+	//
+	// Returns ErrNotFound if no record is found.
+	GetByScheduleId(ctx context.Context, scheduleId string) (*Record, error)
+
+	// GetAllByOwner gets all payment schedules for an owner
+	//
+	// Returns ErrNotFound if no records are found.
+	GetAllByOwner(ctx context.Context, owner string, cursor query.Cursor, limit uint64, direction query.Ordering) ([]*Record, error)
+
+	// GetAllDue gets active payment schedules with a payment due at or before
+	// the provided time, ordered by when the payment is due. Schedules with a
+	// missed payment are excluded until their retry time has also passed.
+	//
+	// Returns ErrNotFound if no records are found.
+	GetAllDue(ctx context.Context, dueBefore time.Time, limit uint64) ([]*Record, error)
+
+	// CountByState returns the count of payment schedules in a state
+	CountByState(ctx context.Context, state State) (uint64, error)
+}
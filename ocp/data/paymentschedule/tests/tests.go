@@ -0,0 +1,274 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/code-payments/ocp-server/currency"
+	"github.com/code-payments/ocp-server/database/query"
+	"github.com/code-payments/ocp-server/ocp/data/paymentschedule"
+	"github.com/code-payments/ocp-server/pointer"
+)
+
+func RunTests(t *testing.T, s paymentschedule.Store, teardown func()) {
+	for _, tf := range []func(t *testing.T, s paymentschedule.Store){
+		testRoundTrip,
+		testUpdate,
+		testGetAllByOwner,
+		testGetAllDue,
+	} {
+		tf(t, s)
+		teardown()
+	}
+}
+
+func testRoundTrip(t *testing.T, s paymentschedule.Store) {
+	t.Run("testRoundTrip", func(t *testing.T) {
+		ctx := context.Background()
+
+		_, err := s.GetByScheduleId(ctx, "schedule1")
+		assert.Equal(t, paymentschedule.ErrNotFound, err)
+
+		expected := newTestRecord("schedule1", "owner1", time.Now().Add(time.Hour))
+		cloned := expected.Clone()
+
+		require.NoError(t, s.Put(ctx, expected))
+		assert.True(t, expected.Id > 0)
+		assert.EqualValues(t, 1, expected.Version)
+		assert.False(t, expected.CreatedAt.IsZero())
+		assertEquivalentRecords(t, &cloned, expected)
+
+		actual, err := s.GetByScheduleId(ctx, "schedule1")
+		require.NoError(t, err)
+		assert.Equal(t, expected.Id, actual.Id)
+		assert.Equal(t, expected.Version, actual.Version)
+		assert.Equal(t, expected.CreatedAt.Unix(), actual.CreatedAt.Unix())
+		assertEquivalentRecords(t, expected, actual)
+
+		assert.Equal(t, paymentschedule.ErrAlreadyExists, s.Put(ctx, newTestRecord("schedule1", "owner2", time.Now())))
+	})
+}
+
+func testUpdate(t *testing.T, s paymentschedule.Store) {
+	t.Run("testUpdate", func(t *testing.T) {
+		ctx := context.Background()
+
+		record := newTestRecord("schedule1", "owner1", time.Now())
+		assert.Equal(t, paymentschedule.ErrNotFound, s.Update(ctx, record))
+
+		require.NoError(t, s.Put(ctx, record))
+
+		stale := record.Clone()
+
+		nextPaymentAt := record.NextPaymentAt.Add(record.Interval)
+		record.NextPaymentAt = nextPaymentAt
+		record.PaymentsMade = 1
+		record.TotalNativeAmountPaid = 12.5
+		record.MissedAttempts = 2
+		record.RetryAt = pointer.Time(time.Now().Add(time.Hour))
+		require.NoError(t, s.Update(ctx, record))
+		assert.EqualValues(t, 2, record.Version)
+
+		stale.State = paymentschedule.StateCancelled
+		assert.Equal(t, paymentschedule.ErrStaleVersion, s.Update(ctx, &stale))
+
+		actual, err := s.GetByScheduleId(ctx, "schedule1")
+		require.NoError(t, err)
+		assertEquivalentRecords(t, record, actual)
+		assert.Equal(t, nextPaymentAt.Unix(), actual.NextPaymentAt.Unix())
+		assert.EqualValues(t, 1, actual.PaymentsMade)
+		assert.Equal(t, 12.5, actual.TotalNativeAmountPaid)
+		assert.EqualValues(t, 2, actual.MissedAttempts)
+		require.NotNil(t, actual.RetryAt)
+		assert.Equal(t, paymentschedule.StateActive, actual.State)
+		assert.EqualValues(t, 2, actual.Version)
+
+		record.MissedAttempts = 0
+		record.RetryAt = nil
+		require.NoError(t, s.Update(ctx, record))
+
+		actual, err = s.GetByScheduleId(ctx, "schedule1")
+		require.NoError(t, err)
+		assertEquivalentRecords(t, record, actual)
+
+		// Only the next payment time, payment progress and state are updatable
+		record.DestinationTokenAccount = "other"
+		record.NativeAmount = 1
+		record.State = paymentschedule.StateCompleted
+		require.NoError(t, s.Update(ctx, record))
+
+		actual, err = s.GetByScheduleId(ctx, "schedule1")
+		require.NoError(t, err)
+		assert.Equal(t, "destination_token_account", actual.DestinationTokenAccount)
+		assert.Equal(t, 12.5, actual.NativeAmount)
+		assert.Equal(t, paymentschedule.StateCompleted, actual.State)
+	})
+}
+
+func testGetAllByOwner(t *testing.T, s paymentschedule.Store) {
+	t.Run("testGetAllByOwner", func(t *testing.T) {
+		ctx := context.Background()
+
+		_, err := s.GetAllByOwner(ctx, "owner1", query.EmptyCursor, 10, query.Ascending)
+		assert.Equal(t, paymentschedule.ErrNotFound, err)
+
+		var records []*paymentschedule.Record
+		for i := 0; i < 4; i++ {
+			record := newTestRecord(fmt.Sprintf("schedule%d", i), "owner1", time.Now())
+			require.NoError(t, s.Put(ctx, record))
+			records = append(records, record)
+		}
+		require.NoError(t, s.Put(ctx, newTestRecord("other", "owner2", time.Now())))
+
+		actual, err := s.GetAllByOwner(ctx, "owner1", query.EmptyCursor, 10, query.Ascending)
+		require.NoError(t, err)
+		require.Len(t, actual, 4)
+		for i, record := range records {
+			assert.Equal(t, record.ScheduleId, actual[i].ScheduleId)
+		}
+
+		actual, err = s.GetAllByOwner(ctx, "owner1", query.EmptyCursor, 2, query.Descending)
+		require.NoError(t, err)
+		require.Len(t, actual, 2)
+		assert.Equal(t, records[3].ScheduleId, actual[0].ScheduleId)
+		assert.Equal(t, records[2].ScheduleId, actual[1].ScheduleId)
+
+		actual, err = s.GetAllByOwner(ctx, "owner1", query.ToCursor(records[1].Id), 10, query.Ascending)
+		require.NoError(t, err)
+		require.Len(t, actual, 2)
+		assert.Equal(t, records[2].ScheduleId, actual[0].ScheduleId)
+		assert.Equal(t, records[3].ScheduleId, actual[1].ScheduleId)
+
+		actual, err = s.GetAllByOwner(ctx, "owner2", query.EmptyCursor, 10, query.Ascending)
+		require.NoError(t, err)
+		require.Len(t, actual, 1)
+		assert.Equal(t, "other", actual[0].ScheduleId)
+	})
+}
+
+func testGetAllDue(t *testing.T, s paymentschedule.Store) {
+	t.Run("testGetAllDue", func(t *testing.T) {
+		ctx := context.Background()
+
+		now := time.Now()
+
+		_, err := s.GetAllDue(ctx, now, 10)
+		assert.Equal(t, paymentschedule.ErrNotFound, err)
+
+		var records []*paymentschedule.Record
+		for i, nextPaymentAt := range []time.Time{
+			now.Add(-time.Minute),
+			now.Add(-time.Hour),
+			now.Add(time.Minute),
+			now.Add(-time.Second),
+			now.Add(-24 * time.Hour),
+		} {
+			record := newTestRecord(fmt.Sprintf("schedule%d", i), "owner", nextPaymentAt)
+			require.NoError(t, s.Put(ctx, record))
+			records = append(records, record)
+		}
+
+		// Schedules that are no longer active are never due
+		records[4].State = paymentschedule.StateCancelled
+		require.NoError(t, s.Update(ctx, records[4]))
+
+		actual, err := s.GetAllDue(ctx, now, 10)
+		require.NoError(t, err)
+		require.Len(t, actual, 3)
+		assert.Equal(t, records[1].ScheduleId, actual[0].ScheduleId)
+		assert.Equal(t, records[0].ScheduleId, actual[1].ScheduleId)
+		assert.Equal(t, records[3].ScheduleId, actual[2].ScheduleId)
+
+		actual, err = s.GetAllDue(ctx, now, 1)
+		require.NoError(t, err)
+		require.Len(t, actual, 1)
+		assert.Equal(t, records[1].ScheduleId, actual[0].ScheduleId)
+
+		actual, err = s.GetAllDue(ctx, now.Add(time.Hour), 10)
+		require.NoError(t, err)
+		require.Len(t, actual, 4)
+		assert.Equal(t, records[2].ScheduleId, actual[3].ScheduleId)
+
+		_, err = s.GetAllDue(ctx, now.Add(-2*time.Hour), 10)
+		assert.Equal(t, paymentschedule.ErrNotFound, err)
+
+		for state, expected := range map[paymentschedule.State]uint64{
+			paymentschedule.StateActive:    4,
+			paymentschedule.StateCompleted: 0,
+			paymentschedule.StateCancelled: 1,
+			paymentschedule.StateFailed:    0,
+		} {
+			count, err := s.CountByState(ctx, state)
+			require.NoError(t, err)
+			assert.Equal(t, expected, count)
+		}
+
+		// Schedules with a missed payment aren't due until they're retried
+		records[1].MissedAttempts = 1
+		records[1].RetryAt = pointer.Time(now.Add(30 * time.Minute))
+		require.NoError(t, s.Update(ctx, records[1]))
+
+		actual, err = s.GetAllDue(ctx, now, 10)
+		require.NoError(t, err)
+		require.Len(t, actual, 2)
+		assert.Equal(t, records[0].ScheduleId, actual[0].ScheduleId)
+		assert.Equal(t, records[3].ScheduleId, actual[1].ScheduleId)
+
+		actual, err = s.GetAllDue(ctx, now.Add(time.Hour), 10)
+		require.NoError(t, err)
+		require.Len(t, actual, 4)
+		assert.Equal(t, records[1].ScheduleId, actual[0].ScheduleId)
+	})
+}
+
+func newTestRecord(scheduleId, owner string, nextPaymentAt time.Time) *paymentschedule.Record {
+	return &paymentschedule.Record{
+		ScheduleId: scheduleId,
+
+		Owner: owner,
+		Mint:  "mint",
+
+		DestinationOwner:        "destination_owner",
+		DestinationTokenAccount: "destination_token_account",
+
+		ExchangeCurrency: currency.USD,
+		NativeAmount:     12.5,
+
+		MaxTotalNativeAmount: 50,
+
+		Interval: 7 * 24 * time.Hour,
+
+		MaxPayments: 4,
+
+		NextPaymentAt: nextPaymentAt,
+
+		State: paymentschedule.StateActive,
+	}
+}
+
+func assertEquivalentRecords(t *testing.T, obj1, obj2 *paymentschedule.Record) {
+	assert.Equal(t, obj1.ScheduleId, obj2.ScheduleId)
+	assert.Equal(t, obj1.Owner, obj2.Owner)
+	assert.Equal(t, obj1.Mint, obj2.Mint)
+	assert.Equal(t, obj1.DestinationOwner, obj2.DestinationOwner)
+	assert.Equal(t, obj1.DestinationTokenAccount, obj2.DestinationTokenAccount)
+	assert.Equal(t, obj1.ExchangeCurrency, obj2.ExchangeCurrency)
+	assert.Equal(t, obj1.NativeAmount, obj2.NativeAmount)
+	assert.Equal(t, obj1.MaxTotalNativeAmount, obj2.MaxTotalNativeAmount)
+	assert.Equal(t, obj1.Interval, obj2.Interval)
+	assert.Equal(t, obj1.MaxPayments, obj2.MaxPayments)
+	assert.Equal(t, obj1.NextPaymentAt.Unix(), obj2.NextPaymentAt.Unix())
+	assert.Equal(t, obj1.PaymentsMade, obj2.PaymentsMade)
+	assert.Equal(t, obj1.TotalNativeAmountPaid, obj2.TotalNativeAmountPaid)
+	assert.Equal(t, obj1.MissedAttempts, obj2.MissedAttempts)
+	require.Equal(t, obj1.RetryAt == nil, obj2.RetryAt == nil)
+	if obj1.RetryAt != nil {
+		assert.Equal(t, obj1.RetryAt.Unix(), obj2.RetryAt.Unix())
+	}
+	assert.Equal(t, obj1.State, obj2.State)
+}
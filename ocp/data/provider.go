@@ -56,6 +56,7 @@ func NewDataProviderWithoutBlockchain(dbConfig *pg.Config, configProvider Config
 		DatabaseProvider: db.(*DatabaseProvider),
 		WebProvider:      web.(*WebProvider),
 	}
+	provider.WebProvider.lastKnownRates = provider.DatabaseProvider.currencies.GetAllExchangeRates
 
 	return provider, nil
 }
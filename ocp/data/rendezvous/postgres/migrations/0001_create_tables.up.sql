@@ -0,0 +1,10 @@
+CREATE TABLE ocp__core_rendezvous (
+	id SERIAL NOT NULL PRIMARY KEY,
+
+	key TEXT NOT NULL,
+	address TEXT NOT NULL,
+	created_at TIMESTAMP WITH TIME ZONE,
+	expires_at TIMESTAMP WITH TIME ZONE,
+
+	CONSTRAINT ocp__core_treasurypool__uniq__key UNIQUE (key)
+);
@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/code-payments/ocp-server/ocp/data/resolution"
+)
+
+type store struct {
+	mu      sync.Mutex
+	last    uint64
+	records []*resolution.Record
+}
+
+// New returns a new in memory resolution.Store
+func New() resolution.Store {
+	return &store{}
+}
+
+// Put implements resolution.Store.Put
+func (s *store) Put(_ context.Context, record *resolution.Record) error {
+	if err := record.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.last++
+	record.Id = s.last
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+
+	cloned := record.Clone()
+	s.records = append(s.records, &cloned)
+
+	return nil
+}
+
+// GetAllByFulfillment implements resolution.Store.GetAllByFulfillment
+func (s *store) GetAllByFulfillment(_ context.Context, fulfillmentId uint64) ([]*resolution.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var res []*resolution.Record
+	for _, item := range s.records {
+		if item.FulfillmentId == fulfillmentId {
+			cloned := item.Clone()
+			res = append(res, &cloned)
+		}
+	}
+
+	if len(res) == 0 {
+		return nil, resolution.ErrNotFound
+	}
+	return res, nil
+}
+
+func (s *store) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.last = 0
+	s.records = nil
+}
@@ -0,0 +1,19 @@
+CREATE TABLE ocp__core_fulfillmentresolution (
+	id SERIAL NOT NULL PRIMARY KEY,
+
+	fulfillment_id INTEGER NOT NULL,
+	intent TEXT NOT NULL,
+	action_id INTEGER NOT NULL,
+
+	resolution_type INTEGER NOT NULL,
+
+	operator TEXT NOT NULL,
+	reason TEXT NOT NULL,
+
+	previous_state INTEGER NOT NULL,
+	new_state INTEGER NOT NULL,
+
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX ocp__core_fulfillmentresolution__idx__fulfillment_id ON ocp__core_fulfillmentresolution (fulfillment_id);
@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	pgutil "github.com/code-payments/ocp-server/database/postgres"
+	"github.com/code-payments/ocp-server/ocp/data/fulfillment"
+	"github.com/code-payments/ocp-server/ocp/data/resolution"
+)
+
+const (
+	tableName = "ocp__core_fulfillmentresolution"
+
+	allColumns = `id, fulfillment_id, intent, action_id, resolution_type, operator, reason, previous_state, new_state, created_at`
+)
+
+type model struct {
+	Id sql.NullInt64 `db:"id"`
+
+	FulfillmentId uint64 `db:"fulfillment_id"`
+	Intent        string `db:"intent"`
+	ActionId      uint32 `db:"action_id"`
+
+	Type uint8 `db:"resolution_type"`
+
+	Operator string `db:"operator"`
+	Reason   string `db:"reason"`
+
+	PreviousState uint8 `db:"previous_state"`
+	NewState      uint8 `db:"new_state"`
+
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func toModel(obj *resolution.Record) (*model, error) {
+	if err := obj.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &model{
+		Id: sql.NullInt64{Int64: int64(obj.Id), Valid: obj.Id > 0},
+
+		FulfillmentId: obj.FulfillmentId,
+		Intent:        obj.Intent,
+		ActionId:      obj.ActionId,
+
+		Type: uint8(obj.Type),
+
+		Operator: obj.Operator,
+		Reason:   obj.Reason,
+
+		PreviousState: uint8(obj.PreviousState),
+		NewState:      uint8(obj.NewState),
+
+		CreatedAt: obj.CreatedAt.UTC(),
+	}, nil
+}
+
+func fromModel(obj *model) *resolution.Record {
+	return &resolution.Record{
+		Id: uint64(obj.Id.Int64),
+
+		FulfillmentId: obj.FulfillmentId,
+		Intent:        obj.Intent,
+		ActionId:      obj.ActionId,
+
+		Type: resolution.Type(obj.Type),
+
+		Operator: obj.Operator,
+		Reason:   obj.Reason,
+
+		PreviousState: fulfillment.State(obj.PreviousState),
+		NewState:      fulfillment.State(obj.NewState),
+
+		CreatedAt: obj.CreatedAt,
+	}
+}
+
+func (m *model) dbPut(ctx context.Context, db *sqlx.DB) error {
+	return pgutil.ExecuteInTx(ctx, db, sql.LevelDefault, func(tx *sqlx.Tx) error {
+		query := `INSERT INTO ` + tableName + `
+			(fulfillment_id, intent, action_id, resolution_type, operator, reason, previous_state, new_state, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			RETURNING ` + allColumns
+
+		if m.CreatedAt.IsZero() {
+			m.CreatedAt = time.Now().UTC()
+		}
+
+		return tx.QueryRowxContext(
+			ctx,
+			query,
+			m.FulfillmentId,
+			m.Intent,
+			m.ActionId,
+			m.Type,
+			m.Operator,
+			m.Reason,
+			m.PreviousState,
+			m.NewState,
+			m.CreatedAt,
+		).StructScan(m)
+	})
+}
+
+func dbGetAllByFulfillment(ctx context.Context, db *sqlx.DB, fulfillmentId uint64) ([]*model, error) {
+	res := []*model{}
+
+	query := `SELECT ` + allColumns + `
+		FROM ` + tableName + `
+		WHERE fulfillment_id = $1
+		ORDER BY id ASC`
+
+	err := db.SelectContext(ctx, &res, query, fulfillmentId)
+	if err != nil {
+		return nil, pgutil.CheckNoRows(err, resolution.ErrNotFound)
+	}
+	if len(res) == 0 {
+		return nil, resolution.ErrNotFound
+	}
+	return res, nil
+}
@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/code-payments/ocp-server/ocp/data/resolution"
+)
+
+type store struct {
+	db *sqlx.DB
+}
+
+// New returns a new postgres resolution.Store
+func New(db *sql.DB) resolution.Store {
+	return &store{
+		db: sqlx.NewDb(db, "pgx"),
+	}
+}
+
+// Put implements resolution.Store.Put
+func (s *store) Put(ctx context.Context, record *resolution.Record) error {
+	model, err := toModel(record)
+	if err != nil {
+		return err
+	}
+
+	err = model.dbPut(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	res := fromModel(model)
+	res.CopyTo(record)
+
+	return nil
+}
+
+// GetAllByFulfillment implements resolution.Store.GetAllByFulfillment
+func (s *store) GetAllByFulfillment(ctx context.Context, fulfillmentId uint64) ([]*resolution.Record, error) {
+	models, err := dbGetAllByFulfillment(ctx, s.db, fulfillmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*resolution.Record, len(models))
+	for i, model := range models {
+		res[i] = fromModel(model)
+	}
+	return res, nil
+}
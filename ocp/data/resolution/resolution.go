@@ -0,0 +1,110 @@
+package resolution
+
+import (
+	"errors"
+	"time"
+
+	"github.com/code-payments/ocp-server/ocp/data/fulfillment"
+)
+
+type Type uint8
+
+const (
+	TypeUnknown Type = iota
+	TypeRetry
+	TypeRevoke
+	TypeResolve
+)
+
+// Record is an audit log entry for an operator resolving a failed fulfillment
+type Record struct {
+	Id uint64
+
+	FulfillmentId uint64
+	Intent        string
+	ActionId      uint32
+
+	Type Type
+
+	Operator string
+	Reason   string
+
+	PreviousState fulfillment.State
+	NewState      fulfillment.State
+
+	CreatedAt time.Time
+}
+
+func (r *Record) Validate() error {
+	if r.FulfillmentId == 0 {
+		return errors.New("fulfillment id is required")
+	}
+
+	if len(r.Intent) == 0 {
+		return errors.New("intent is required")
+	}
+
+	if r.Type == TypeUnknown {
+		return errors.New("type is required")
+	}
+
+	if len(r.Operator) == 0 {
+		return errors.New("operator is required")
+	}
+
+	if len(r.Reason) == 0 {
+		return errors.New("reason is required")
+	}
+
+	return nil
+}
+
+func (r *Record) Clone() Record {
+	return Record{
+		Id: r.Id,
+
+		FulfillmentId: r.FulfillmentId,
+		Intent:        r.Intent,
+		ActionId:      r.ActionId,
+
+		Type: r.Type,
+
+		Operator: r.Operator,
+		Reason:   r.Reason,
+
+		PreviousState: r.PreviousState,
+		NewState:      r.NewState,
+
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+func (r *Record) CopyTo(dst *Record) {
+	dst.Id = r.Id
+
+	dst.FulfillmentId = r.FulfillmentId
+	dst.Intent = r.Intent
+	dst.ActionId = r.ActionId
+
+	dst.Type = r.Type
+
+	dst.Operator = r.Operator
+	dst.Reason = r.Reason
+
+	dst.PreviousState = r.PreviousState
+	dst.NewState = r.NewState
+
+	dst.CreatedAt = r.CreatedAt
+}
+
+func (t Type) String() string {
+	switch t {
+	case TypeRetry:
+		return "retry"
+	case TypeRevoke:
+		return "revoke"
+	case TypeResolve:
+		return "resolve"
+	}
+	return "unknown"
+}
@@ -0,0 +1,23 @@
+package resolution
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrNotFound = errors.New("resolution not found")
+)
+
+// Store is an append-only audit trail of operator resolutions for failed
+// fulfillments
+type Store interface {
+	// Put creates a new resolution record
+	Put(ctx context.Context, record *Record) error
+
+	// GetAllByFulfillment gets all resolutions for a fulfillment, ordered by
+	// when they were made
+	//
+	// Returns ErrNotFound if no records are found.
+	GetAllByFulfillment(ctx context.Context, fulfillmentId uint64) ([]*Record, error)
+}
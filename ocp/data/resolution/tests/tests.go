@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/code-payments/ocp-server/ocp/data/fulfillment"
+	"github.com/code-payments/ocp-server/ocp/data/resolution"
+)
+
+func RunTests(t *testing.T, s resolution.Store, teardown func()) {
+	for _, tf := range []func(t *testing.T, s resolution.Store){
+		testRoundTrip,
+	} {
+		tf(t, s)
+		teardown()
+	}
+}
+
+func testRoundTrip(t *testing.T, s resolution.Store) {
+	t.Run("testRoundTrip", func(t *testing.T) {
+		ctx := context.Background()
+
+		_, err := s.GetAllByFulfillment(ctx, 1)
+		assert.Equal(t, resolution.ErrNotFound, err)
+
+		var expected []*resolution.Record
+		for i, resolutionType := range []resolution.Type{
+			resolution.TypeRetry,
+			resolution.TypeRevoke,
+			resolution.TypeResolve,
+		} {
+			record := &resolution.Record{
+				FulfillmentId: 1,
+				Intent:        "intent",
+				ActionId:      2,
+				Type:          resolutionType,
+				Operator:      "operator",
+				Reason:        "reason",
+				PreviousState: fulfillment.StateFailed,
+				NewState:      fulfillment.State(i),
+			}
+			cloned := record.Clone()
+
+			require.NoError(t, s.Put(ctx, record))
+			assert.True(t, record.Id > 0)
+			assert.False(t, record.CreatedAt.IsZero())
+
+			assertEquivalentRecords(t, &cloned, record)
+			expected = append(expected, record)
+		}
+
+		require.NoError(t, s.Put(ctx, &resolution.Record{
+			FulfillmentId: 2,
+			Intent:        "intent",
+			Type:          resolution.TypeRetry,
+			Operator:      "operator",
+			Reason:        "reason",
+			PreviousState: fulfillment.StateFailed,
+			NewState:      fulfillment.StateUnknown,
+		}))
+
+		actual, err := s.GetAllByFulfillment(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, actual, len(expected))
+		for i := range expected {
+			assert.Equal(t, expected[i].Id, actual[i].Id)
+			assertEquivalentRecords(t, expected[i], actual[i])
+		}
+
+		actual, err = s.GetAllByFulfillment(ctx, 2)
+		require.NoError(t, err)
+		assert.Len(t, actual, 1)
+
+		_, err = s.GetAllByFulfillment(ctx, 3)
+		assert.Equal(t, resolution.ErrNotFound, err)
+	})
+}
+
+func assertEquivalentRecords(t *testing.T, obj1, obj2 *resolution.Record) {
+	assert.Equal(t, obj1.FulfillmentId, obj2.FulfillmentId)
+	assert.Equal(t, obj1.Intent, obj2.Intent)
+	assert.Equal(t, obj1.ActionId, obj2.ActionId)
+	assert.Equal(t, obj1.Type, obj2.Type)
+	assert.Equal(t, obj1.Operator, obj2.Operator)
+	assert.Equal(t, obj1.Reason, obj2.Reason)
+	assert.Equal(t, obj1.PreviousState, obj2.PreviousState)
+	assert.Equal(t, obj1.NewState, obj2.NewState)
+}
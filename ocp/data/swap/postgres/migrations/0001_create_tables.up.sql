@@ -0,0 +1,27 @@
+CREATE TABLE ocp__core_swap(
+	id SERIAL NOT NULL PRIMARY KEY,
+
+	swap_id TEXT NOT NULL UNIQUE,
+
+	owner TEXT NOT NULL,
+
+	from_mint TEXT NOT NULL,
+	to_mint TEXT NOT NULL,
+	amount BIGINT NULL CHECK (amount > 0),
+
+	funding_id TEXT NOT NULL UNIQUE,
+	funding_source INTEGER NOT NULL,
+
+	nonce TEXT NOT NULL,
+	blockhash TEXT NOT NULL,
+
+	proof_signature TEXT NOT NULL,
+
+	transaction_signature TEXT UNIQUE,
+	transaction_blob BYTEA,
+
+	state INTEGER NOT NULL,
+	version INTEGER NOT NULL,
+
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
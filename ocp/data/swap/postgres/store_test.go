@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"os"
 	"testing"
@@ -11,49 +12,12 @@ import (
 	"github.com/code-payments/ocp-server/ocp/data/swap"
 	"github.com/code-payments/ocp-server/ocp/data/swap/tests"
 
+	"github.com/code-payments/ocp-server/database/postgres/migration"
 	postgrestest "github.com/code-payments/ocp-server/database/postgres/test"
 
 	_ "github.com/jackc/pgx/v4/stdlib"
 )
 
-const (
-	// Used for testing ONLY, the table and migrations are external to this repository
-	tableCreate = `
-		CREATE TABLE ocp__core_swap(
-			id SERIAL NOT NULL PRIMARY KEY,
-
-			swap_id TEXT NOT NULL UNIQUE,
-
-			owner TEXT NOT NULL,
-
-			from_mint TEXT NOT NULL,
-			to_mint TEXT NOT NULL,
-			amount BIGINT NULL CHECK (amount > 0),
-
-			funding_id TEXT NOT NULL UNIQUE,
-			funding_source INTEGER NOT NULL,
-
-			nonce TEXT NOT NULL,
-			blockhash TEXT NOT NULL,
-
-			proof_signature TEXT NOT NULL,
-
-			transaction_signature TEXT UNIQUE,
-			transaction_blob BYTEA,
-
-			state INTEGER NOT NULL,
-			version INTEGER NOT NULL,
-
-			created_at TIMESTAMP WITH TIME ZONE NOT NULL
-		)
-	`
-
-	// Used for testing ONLY, the table and migrations are external to this repository
-	tableDestroy = `
-		DROP TABLE ocp__core_swap;
-	`
-)
-
 var (
 	testStore swap.Store
 	teardown  func()
@@ -106,7 +70,13 @@ func TestSwapPostgresStore(t *testing.T) {
 }
 
 func createTestTables(log *zap.Logger, db *sql.DB) error {
-	_, err := db.Exec(tableCreate)
+	migrations, err := Migrations()
+	if err != nil {
+		log.With(zap.Error(err)).Error("could not load migrations")
+		return err
+	}
+
+	err = migration.Up(context.Background(), db, migrations)
 	if err != nil {
 		log.With(zap.Error(err)).Error("could not create test tables")
 		return err
@@ -115,7 +85,13 @@ func createTestTables(log *zap.Logger, db *sql.DB) error {
 }
 
 func resetTestTables(log *zap.Logger, db *sql.DB) error {
-	_, err := db.Exec(tableDestroy)
+	migrations, err := Migrations()
+	if err != nil {
+		log.With(zap.Error(err)).Error("could not load migrations")
+		return err
+	}
+
+	err = migration.Down(context.Background(), db, migrations)
 	if err != nil {
 		log.With(zap.Error(err)).Error("could not drop test tables")
 		return err
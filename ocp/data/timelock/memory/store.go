@@ -69,6 +69,22 @@ func (s *store) Save(_ context.Context, data *timelock.Record) error {
 	return nil
 }
 
+// MarkUnlockRequested implements timelock.Store.MarkUnlockRequested
+func (s *store) MarkUnlockRequested(_ context.Context, vault string, requestedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item := s.findByVault(vault)
+	if item == nil {
+		return timelock.ErrTimelockNotFound
+	}
+
+	if item.UnlockRequestedAt == nil || requestedAt.Sub(*item.UnlockRequestedAt) >= timelock.UnlockRequestExpiry {
+		item.UnlockRequestedAt = &requestedAt
+	}
+	return nil
+}
+
 // GetByAddress implements timelock.Store.GetByAddress
 func (s *store) GetByAddress(_ context.Context, address string) (*timelock.Record, error) {
 	s.mu.Lock()
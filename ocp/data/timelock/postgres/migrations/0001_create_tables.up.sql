@@ -0,0 +1,28 @@
+CREATE TABLE ocp__core_timelock(
+	id SERIAL NOT NULL PRIMARY KEY,
+
+	address TEXT NOT NULL,
+	bump INTEGER NOT NULL,
+
+	vault_address TEXT NOT NULL,
+	vault_bump INTEGER NOT NULL,
+	vault_owner TEXT NOT NULL,
+	vault_state INTEGER NOT NULL,
+
+	deposit_pda_address TEXT NOT NULL,
+	deposit_pda_bump INTEGER NOT NULL,
+
+	swap_pda_address TEXT NOT NULL,
+	swap_pda_bump INTEGER NOT NULL,
+
+	unlock_at INTEGER,
+
+	block INTEGER NOT NULL,
+
+	last_updated_at TIMESTAMP WITH TIME ZONE,
+
+	CONSTRAINT ocp__core_timelock__uniq__address UNIQUE (address),
+	CONSTRAINT ocp__core_timelock__uniq__vault_address UNIQUE (vault_address),
+	CONSTRAINT ocp__core_timelock__uniq__deposit_pda_address UNIQUE (deposit_pda_address),
+	CONSTRAINT ocp__core_timelock__uniq__swap_pda_address UNIQUE (swap_pda_address)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE ocp__core_timelock
+	DROP COLUMN unlock_requested_at;
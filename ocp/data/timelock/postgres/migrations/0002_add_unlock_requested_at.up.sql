@@ -0,0 +1,2 @@
+ALTER TABLE ocp__core_timelock
+	ADD COLUMN unlock_requested_at TIMESTAMP WITH TIME ZONE NULL;
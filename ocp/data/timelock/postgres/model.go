@@ -36,7 +36,8 @@ type model struct {
 	SwapPdaAddress string `db:"swap_pda_address"`
 	SwapPdaBump    uint   `db:"swap_pda_bump"`
 
-	UnlockAt sql.NullInt64 `db:"unlock_at"`
+	UnlockAt          sql.NullInt64 `db:"unlock_at"`
+	UnlockRequestedAt sql.NullTime  `db:"unlock_requested_at"`
 
 	Block uint64 `db:"block"`
 
@@ -54,6 +55,12 @@ func toModel(obj *timelock.Record) (*model, error) {
 		unlockAt.Int64 = int64(*obj.UnlockAt)
 	}
 
+	var unlockRequestedAt sql.NullTime
+	if obj.UnlockRequestedAt != nil {
+		unlockRequestedAt.Valid = true
+		unlockRequestedAt.Time = obj.UnlockRequestedAt.UTC()
+	}
+
 	return &model{
 		Address: obj.Address,
 		Bump:    uint(obj.Bump),
@@ -69,7 +76,8 @@ func toModel(obj *timelock.Record) (*model, error) {
 		SwapPdaAddress: obj.SwapPdaAddress,
 		SwapPdaBump:    uint(obj.SwapPdaBump),
 
-		UnlockAt: unlockAt,
+		UnlockAt:          unlockAt,
+		UnlockRequestedAt: unlockRequestedAt,
 
 		Block: obj.Block,
 
@@ -84,6 +92,12 @@ func fromModel(obj *model) *timelock.Record {
 		unlockAt = &value
 	}
 
+	var unlockRequestedAt *time.Time
+	if obj.UnlockRequestedAt.Valid {
+		value := obj.UnlockRequestedAt.Time
+		unlockRequestedAt = &value
+	}
+
 	return &timelock.Record{
 		Id: uint64(obj.Id.Int64),
 
@@ -101,7 +115,8 @@ func fromModel(obj *model) *timelock.Record {
 		SwapPdaAddress: obj.SwapPdaAddress,
 		SwapPdaBump:    uint8(obj.SwapPdaBump),
 
-		UnlockAt: unlockAt,
+		UnlockAt:          unlockAt,
+		UnlockRequestedAt: unlockRequestedAt,
 
 		Block: obj.Block,
 
@@ -112,16 +127,16 @@ func fromModel(obj *model) *timelock.Record {
 func (m *model) dbSave(ctx context.Context, db *sqlx.DB) error {
 	return pgutil.ExecuteInTx(ctx, db, sql.LevelDefault, func(tx *sqlx.Tx) error {
 		query := `INSERT INTO ` + tableName + `
-			(address, bump, vault_address, vault_bump, vault_owner, vault_state, deposit_pda_address, deposit_pda_bump, swap_pda_address, swap_pda_bump, unlock_at, block, last_updated_at)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			(address, bump, vault_address, vault_bump, vault_owner, vault_state, deposit_pda_address, deposit_pda_bump, swap_pda_address, swap_pda_bump, unlock_at, unlock_requested_at, block, last_updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 
 			ON CONFLICT (address)
 			DO UPDATE
-				SET vault_state = $6, unlock_at = $11, block = $12, last_updated_at = $13
-				WHERE ` + tableName + `.address = $1 AND ` + tableName + `.vault_address = $3 AND ` + tableName + `.block < $12
+				SET vault_state = $6, unlock_at = $11, block = $13, last_updated_at = $14
+				WHERE ` + tableName + `.address = $1 AND ` + tableName + `.vault_address = $3 AND ` + tableName + `.block < $13
 
 			RETURNING
-				id, address, bump, vault_address, vault_bump, vault_owner, vault_state, deposit_pda_address, deposit_pda_bump, swap_pda_address, swap_pda_bump, unlock_at, block, last_updated_at`
+				id, address, bump, vault_address, vault_bump, vault_owner, vault_state, deposit_pda_address, deposit_pda_bump, swap_pda_address, swap_pda_bump, unlock_at, unlock_requested_at, block, last_updated_at`
 
 		m.LastUpdatedAt = time.Now()
 
@@ -144,6 +159,7 @@ func (m *model) dbSave(ctx context.Context, db *sqlx.DB) error {
 			m.SwapPdaBump,
 
 			m.UnlockAt,
+			m.UnlockRequestedAt,
 
 			m.Block,
 
@@ -154,11 +170,34 @@ func (m *model) dbSave(ctx context.Context, db *sqlx.DB) error {
 	})
 }
 
+func dbMarkUnlockRequested(ctx context.Context, db *sqlx.DB, vault string, requestedAt time.Time) error {
+	return pgutil.ExecuteInTx(ctx, db, sql.LevelDefault, func(tx *sqlx.Tx) error {
+		var id int64
+
+		query := `UPDATE ` + tableName + `
+			SET unlock_requested_at = CASE
+				WHEN unlock_requested_at IS NULL OR unlock_requested_at <= $3 THEN $2
+				ELSE unlock_requested_at
+			END
+			WHERE vault_address = $1
+			RETURNING id`
+
+		err := tx.QueryRowxContext(
+			ctx,
+			query,
+			vault,
+			requestedAt.UTC(),
+			requestedAt.Add(-timelock.UnlockRequestExpiry).UTC(),
+		).Scan(&id)
+		return pgutil.CheckNoRows(err, timelock.ErrTimelockNotFound)
+	})
+}
+
 func dbGetByAddress(ctx context.Context, db *sqlx.DB, address string) (*model, error) {
 	res := &model{}
 
 	query := `SELECT
-		id, address, bump, vault_address, vault_bump, vault_owner, vault_state, deposit_pda_address, deposit_pda_bump, swap_pda_address, swap_pda_bump, unlock_at, block, last_updated_at
+		id, address, bump, vault_address, vault_bump, vault_owner, vault_state, deposit_pda_address, deposit_pda_bump, swap_pda_address, swap_pda_bump, unlock_at, unlock_requested_at, block, last_updated_at
 		FROM ` + tableName + `
 		WHERE address = $1
 		LIMIT 1`
@@ -174,7 +213,7 @@ func dbGetByVault(ctx context.Context, db *sqlx.DB, vault string) (*model, error
 	res := &model{}
 
 	query := `SELECT
-		id, address, bump, vault_address, vault_bump, vault_owner, vault_state, deposit_pda_address, deposit_pda_bump, swap_pda_address, swap_pda_bump, unlock_at, block, last_updated_at
+		id, address, bump, vault_address, vault_bump, vault_owner, vault_state, deposit_pda_address, deposit_pda_bump, swap_pda_address, swap_pda_bump, unlock_at, unlock_requested_at, block, last_updated_at
 		FROM ` + tableName + `
 		WHERE vault_address = $1
 		LIMIT 1`
@@ -195,7 +234,7 @@ func dbGetByVaultBatch(ctx context.Context, db *sqlx.DB, vaults ...string) ([]*m
 	}
 
 	query := fmt.Sprintf(
-		`SELECT id, address, bump, vault_address, vault_bump, vault_owner, vault_state, deposit_pda_address, deposit_pda_bump, swap_pda_address, swap_pda_bump, unlock_at, block, last_updated_at
+		`SELECT id, address, bump, vault_address, vault_bump, vault_owner, vault_state, deposit_pda_address, deposit_pda_bump, swap_pda_address, swap_pda_bump, unlock_at, unlock_requested_at, block, last_updated_at
 		FROM `+tableName+`
 		WHERE vault_address IN (%s)`,
 		strings.Join(individualFilters, ", "),
@@ -215,7 +254,7 @@ func dbGetByDepositPda(ctx context.Context, db *sqlx.DB, depositPda string) (*mo
 	res := &model{}
 
 	query := `SELECT
-		id, address, bump, vault_address, vault_bump, vault_owner, vault_state, deposit_pda_address, deposit_pda_bump, swap_pda_address, swap_pda_bump, unlock_at, block, last_updated_at
+		id, address, bump, vault_address, vault_bump, vault_owner, vault_state, deposit_pda_address, deposit_pda_bump, swap_pda_address, swap_pda_bump, unlock_at, unlock_requested_at, block, last_updated_at
 		FROM ` + tableName + `
 		WHERE deposit_pda_address = $1
 		LIMIT 1`
@@ -231,7 +270,7 @@ func dbGetBySwapPda(ctx context.Context, db *sqlx.DB, swapPda string) (*model, e
 	res := &model{}
 
 	query := `SELECT
-		id, address, bump, vault_address, vault_bump, vault_owner, vault_state, deposit_pda_address, deposit_pda_bump, swap_pda_address, swap_pda_bump, unlock_at, block, last_updated_at
+		id, address, bump, vault_address, vault_bump, vault_owner, vault_state, deposit_pda_address, deposit_pda_bump, swap_pda_address, swap_pda_bump, unlock_at, unlock_requested_at, block, last_updated_at
 		FROM ` + tableName + `
 		WHERE swap_pda_address = $1
 		LIMIT 1`
@@ -247,7 +286,7 @@ func dbGetAllByState(ctx context.Context, db *sqlx.DB, state timelock_token.Time
 	res := []*model{}
 
 	query := `SELECT
-		id, address, bump, vault_address, vault_bump, vault_owner, vault_state, deposit_pda_address, deposit_pda_bump, swap_pda_address, swap_pda_bump, unlock_at, block, last_updated_at
+		id, address, bump, vault_address, vault_bump, vault_owner, vault_state, deposit_pda_address, deposit_pda_bump, swap_pda_address, swap_pda_bump, unlock_at, unlock_requested_at, block, last_updated_at
 		FROM ` + tableName + `
 		WHERE (vault_state = $1)
 	`
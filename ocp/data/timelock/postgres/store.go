@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 
@@ -39,6 +40,11 @@ func (s *store) Save(ctx context.Context, record *timelock.Record) error {
 	return nil
 }
 
+// MarkUnlockRequested implements timelock.Store.MarkUnlockRequested
+func (s *store) MarkUnlockRequested(ctx context.Context, vault string, requestedAt time.Time) error {
+	return dbMarkUnlockRequested(ctx, s.db, vault, requestedAt)
+}
+
 // GetByAddress implements timelock.Store.GetByAddress
 func (s *store) GetByAddress(ctx context.Context, address string) (*timelock.Record, error) {
 	model, err := dbGetByAddress(ctx, s.db, address)
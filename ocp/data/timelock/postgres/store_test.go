@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"os"
 	"testing"
@@ -11,50 +12,12 @@ import (
 	"github.com/code-payments/ocp-server/ocp/data/timelock"
 	"github.com/code-payments/ocp-server/ocp/data/timelock/tests"
 
+	"github.com/code-payments/ocp-server/database/postgres/migration"
 	postgrestest "github.com/code-payments/ocp-server/database/postgres/test"
 
 	_ "github.com/jackc/pgx/v4/stdlib"
 )
 
-const (
-	// Used for testing ONLY, the table and migrations are external to this repository
-	tableCreate = `
-		CREATE TABLE ocp__core_timelock(
-			id SERIAL NOT NULL PRIMARY KEY,
-
-			address TEXT NOT NULL,
-			bump INTEGER NOT NULL,
-
-			vault_address TEXT NOT NULL,
-			vault_bump INTEGER NOT NULL,
-			vault_owner TEXT NOT NULL,
-			vault_state INTEGER NOT NULL,
-
-			deposit_pda_address TEXT NOT NULL,
-			deposit_pda_bump INTEGER NOT NULL,
-
-			swap_pda_address TEXT NOT NULL,
-			swap_pda_bump INTEGER NOT NULL,
-
-			unlock_at INTEGER,
-
-			block INTEGER NOT NULL,
-
-			last_updated_at TIMESTAMP WITH TIME ZONE,
-
-			CONSTRAINT ocp__core_timelock__uniq__address UNIQUE (address),
-			CONSTRAINT ocp__core_timelock__uniq__vault_address UNIQUE (vault_address),
-			CONSTRAINT ocp__core_timelock__uniq__deposit_pda_address UNIQUE (deposit_pda_address),
-			CONSTRAINT ocp__core_timelock__uniq__swap_pda_address UNIQUE (swap_pda_address)
-		);
-	`
-
-	// Used for testing ONLY, the table and migrations are external to this repository
-	tableDestroy = `
-		DROP TABLE ocp__core_timelock;
-	`
-)
-
 var (
 	testStore timelock.Store
 	teardown  func()
@@ -107,7 +70,13 @@ func TestTimelockPostgresStore(t *testing.T) {
 }
 
 func createTestTables(log *zap.Logger, db *sql.DB) error {
-	_, err := db.Exec(tableCreate)
+	migrations, err := Migrations()
+	if err != nil {
+		log.With(zap.Error(err)).Error("could not load migrations")
+		return err
+	}
+
+	err = migration.Up(context.Background(), db, migrations)
 	if err != nil {
 		log.With(zap.Error(err)).Error("could not create test tables")
 		return err
@@ -116,7 +85,13 @@ func createTestTables(log *zap.Logger, db *sql.DB) error {
 }
 
 func resetTestTables(log *zap.Logger, db *sql.DB) error {
-	_, err := db.Exec(tableDestroy)
+	migrations, err := Migrations()
+	if err != nil {
+		log.With(zap.Error(err)).Error("could not load migrations")
+		return err
+	}
+
+	err = migration.Down(context.Background(), db, migrations)
 	if err != nil {
 		log.With(zap.Error(err)).Error("could not drop test tables")
 		return err
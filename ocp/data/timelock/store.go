@@ -2,6 +2,7 @@ package timelock
 
 import (
 	"context"
+	"time"
 
 	"github.com/code-payments/ocp-server/database/query"
 	timelock_token "github.com/code-payments/ocp-server/solana/timelock/v1"
@@ -11,6 +12,10 @@ type Store interface {
 	// Save saves a timelock account's state
 	Save(ctx context.Context, record *Record) error
 
+	// MarkUnlockRequested records the time an owner requested to unlock their
+	// timelock account. It's a no-op when a previous request hasn't expired.
+	MarkUnlockRequested(ctx context.Context, vault string, requestedAt time.Time) error
+
 	// GetByAddress gets a timelock account's state by the state address
 	GetByAddress(ctx context.Context, address string) (*Record, error)
 
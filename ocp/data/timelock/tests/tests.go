@@ -20,6 +20,7 @@ func RunTests(t *testing.T, s timelock.Store, teardown func()) {
 		testBatchedMethods,
 		testGetAllByState,
 		testGetCountByState,
+		testMarkUnlockRequested,
 	} {
 		tf(t, s)
 		teardown()
@@ -295,6 +296,87 @@ func testGetCountByState(t *testing.T, s timelock.Store) {
 	})
 }
 
+func testMarkUnlockRequested(t *testing.T, s timelock.Store) {
+	t.Run("testMarkUnlockRequested", func(t *testing.T) {
+		ctx := context.Background()
+
+		record := &timelock.Record{
+			Address: "state",
+			Bump:    254,
+
+			VaultAddress: "vault",
+			VaultBump:    255,
+			VaultOwner:   "owner",
+			VaultState:   timelock_token.StateLocked,
+
+			DepositPdaAddress: "deposit",
+			DepositPdaBump:    253,
+
+			SwapPdaAddress: "swap",
+			SwapPdaBump:    252,
+
+			Block: 1,
+		}
+
+		assert.Equal(t, timelock.ErrTimelockNotFound, s.MarkUnlockRequested(ctx, record.VaultAddress, time.Now()))
+
+		require.NoError(t, s.Save(ctx, record))
+		assert.False(t, record.IsUnlockRequested())
+
+		expiredAt := time.Now().Add(-2 * timelock.UnlockRequestExpiry)
+		require.NoError(t, s.MarkUnlockRequested(ctx, record.VaultAddress, expiredAt))
+
+		actual, err := s.GetByVault(ctx, record.VaultAddress)
+		require.NoError(t, err)
+		require.NotNil(t, actual.UnlockRequestedAt)
+		assert.Equal(t, expiredAt.Unix(), actual.UnlockRequestedAt.Unix())
+
+		// The request expires when the unlock isn't observed on the blockchain
+
+		assert.False(t, actual.IsUnlockRequested())
+
+		// A new request replaces the expired one
+
+		requestedAt := time.Now().Add(-time.Minute)
+		require.NoError(t, s.MarkUnlockRequested(ctx, record.VaultAddress, requestedAt))
+
+		actual, err = s.GetByVault(ctx, record.VaultAddress)
+		require.NoError(t, err)
+		require.True(t, actual.IsUnlockRequested())
+		assert.Equal(t, requestedAt.Unix(), actual.UnlockRequestedAt.Unix())
+
+		// The original request time is kept while it hasn't expired
+
+		require.NoError(t, s.MarkUnlockRequested(ctx, record.VaultAddress, time.Now()))
+
+		actual, err = s.GetByVault(ctx, record.VaultAddress)
+		require.NoError(t, err)
+		require.True(t, actual.IsUnlockRequested())
+		assert.Equal(t, requestedAt.Unix(), actual.UnlockRequestedAt.Unix())
+
+		// Blockchain state updates don't clear the request
+
+		unlockAt := uint64(time.Now().Unix())
+		record.VaultState = timelock_token.StateWaitingForTimeout
+		record.UnlockAt = &unlockAt
+		record.Block += 1
+		require.NoError(t, s.Save(ctx, record))
+		require.True(t, record.IsUnlockRequested())
+		assert.Equal(t, requestedAt.Unix(), record.UnlockRequestedAt.Unix())
+
+		actual, err = s.GetByVault(ctx, record.VaultAddress)
+		require.NoError(t, err)
+		assertEquivalentRecords(t, record, actual)
+		require.True(t, actual.IsUnlockRequested())
+		assert.Equal(t, requestedAt.Unix(), actual.UnlockRequestedAt.Unix())
+
+		// The request no longer expires once the unlock is observed
+
+		actual.UnlockRequestedAt = &expiredAt
+		assert.True(t, actual.IsUnlockRequested())
+	})
+}
+
 func assertEquivalentRecords(t *testing.T, obj1, obj2 *timelock.Record) {
 	assert.Equal(t, obj1.Address, obj2.Address)
 	assert.Equal(t, obj1.Bump, obj2.Bump)
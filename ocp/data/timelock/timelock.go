@@ -8,6 +8,11 @@ import (
 	timelock_token_v1 "github.com/code-payments/ocp-server/solana/timelock/v1"
 )
 
+// UnlockRequestExpiry is how long an unlock request is honoured without the
+// unlock being observed on the blockchain. It outlasts the lifetime of the
+// blockhash used in the unlock transaction returned to the owner.
+const UnlockRequestExpiry = 5 * time.Minute
+
 var (
 	ErrTimelockNotFound   = errors.New("no records could be found")
 	ErrInvalidTimelock    = errors.New("invalid timelock")
@@ -33,6 +38,11 @@ type Record struct {
 
 	UnlockAt *uint64
 
+	// UnlockRequestedAt is when the owner last requested to unlock the account.
+	// It's set before the unlock is observed on the blockchain, and the request
+	// expires after UnlockRequestExpiry if the unlock never lands.
+	UnlockRequestedAt *time.Time
+
 	Block uint64
 
 	LastUpdatedAt time.Time
@@ -49,6 +59,22 @@ func (r *Record) IsLocked() bool {
 	return r.VaultState == timelock_token_v1.StateLocked
 }
 
+// IsUnlockRequested returns whether the owner has an active unlock request. A
+// request stays active once the unlock is observed on the blockchain, and
+// otherwise expires after UnlockRequestExpiry.
+func (r *Record) IsUnlockRequested() bool {
+	if r.UnlockRequestedAt == nil {
+		return false
+	}
+
+	switch r.VaultState {
+	case timelock_token_v1.StateWaitingForTimeout, timelock_token_v1.StateUnlocked:
+		return true
+	}
+
+	return time.Since(*r.UnlockRequestedAt) < UnlockRequestExpiry
+}
+
 func (r *Record) IsClosed() bool {
 	return r.VaultState == timelock_token_v1.StateClosed
 }
@@ -64,6 +90,12 @@ func (r *Record) Clone() *Record {
 		unlockAt = &value
 	}
 
+	var unlockRequestedAt *time.Time
+	if r.UnlockRequestedAt != nil {
+		value := *r.UnlockRequestedAt
+		unlockRequestedAt = &value
+	}
+
 	return &Record{
 		Id: r.Id,
 
@@ -81,7 +113,8 @@ func (r *Record) Clone() *Record {
 		SwapPdaAddress: r.SwapPdaAddress,
 		SwapPdaBump:    r.SwapPdaBump,
 
-		UnlockAt: unlockAt,
+		UnlockAt:          unlockAt,
+		UnlockRequestedAt: unlockRequestedAt,
 
 		Block: r.Block,
 
@@ -96,6 +129,12 @@ func (r *Record) CopyTo(dst *Record) {
 		unlockAt = &value
 	}
 
+	var unlockRequestedAt *time.Time
+	if r.UnlockRequestedAt != nil {
+		value := *r.UnlockRequestedAt
+		unlockRequestedAt = &value
+	}
+
 	dst.Id = r.Id
 
 	dst.Address = r.Address
@@ -113,6 +152,7 @@ func (r *Record) CopyTo(dst *Record) {
 	dst.SwapPdaBump = r.SwapPdaBump
 
 	dst.UnlockAt = unlockAt
+	dst.UnlockRequestedAt = unlockRequestedAt
 
 	dst.Block = r.Block
 
@@ -0,0 +1,2 @@
+DROP TABLE ocp__core_transaction;
+DROP TABLE ocp__core_transactiontokenbalance;
@@ -0,0 +1,21 @@
+CREATE TABLE ocp__core_transaction (
+	id serial NOT NULL PRIMARY KEY,
+	signature text NOT NULL UNIQUE,
+	block_id int8,
+	block_time timestamptz,
+	raw_data bytea NOT NULL,
+	fee int8,
+	has_errors bool NOT NULL,
+	confirmation_state int NOT NULL default 0,
+	confirmations int,
+	created_at timestamp with time zone NOT NULL
+);
+
+CREATE TABLE ocp__core_transactiontokenbalance (
+	id serial NOT NULL PRIMARY KEY,
+	transaction_id text NOT NULL,
+	account text NOT NULL,
+	pre_balance int8 NOT NULL,
+	post_balance int8 NOT NULL,
+	UNIQUE(transaction_id, account)
+);
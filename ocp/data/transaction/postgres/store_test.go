@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"os"
 	"testing"
@@ -11,6 +12,7 @@ import (
 	"github.com/code-payments/ocp-server/ocp/data/transaction"
 	"github.com/code-payments/ocp-server/ocp/data/transaction/tests"
 
+	"github.com/code-payments/ocp-server/database/postgres/migration"
 	postgrestest "github.com/code-payments/ocp-server/database/postgres/test"
 
 	_ "github.com/jackc/pgx/v4/stdlib"
@@ -21,39 +23,6 @@ var (
 	teardown  func()
 )
 
-const (
-	// Used for testing ONLY, the table and migrations are external to this repository
-	tableCreate = `
-	CREATE TABLE ocp__core_transaction (
-		id serial NOT NULL PRIMARY KEY, 
-		signature text NOT NULL UNIQUE,
-		block_id int8,
-		block_time timestamptz,
-		raw_data bytea NOT NULL,
-		fee int8,
-		has_errors bool NOT NULL,
-		confirmation_state int NOT NULL default 0,
-		confirmations int,
-		created_at timestamp with time zone NOT NULL
-	);
-
-	CREATE TABLE ocp__core_transactiontokenbalance (
-		id serial NOT NULL PRIMARY KEY, 
-		transaction_id text NOT NULL,
-		account text NOT NULL,
-		pre_balance int8 NOT NULL,
-		post_balance int8 NOT NULL,
-		UNIQUE(transaction_id, account)
-	);
-	`
-
-	// Used for testing ONLY, the table and migrations are external to this repository
-	tableDestroy = `
-		DROP TABLE ocp__core_transaction;
-		DROP TABLE ocp__core_transactiontokenbalance;
-	`
-)
-
 func TestMain(m *testing.M) {
 	log := zap.Must(zap.NewDevelopment())
 
@@ -101,7 +70,13 @@ func TestTransactionStore(t *testing.T) {
 }
 
 func createTestTables(log *zap.Logger, db *sql.DB) error {
-	_, err := db.Exec(tableCreate)
+	migrations, err := Migrations()
+	if err != nil {
+		log.With(zap.Error(err)).Error("could not load migrations")
+		return err
+	}
+
+	err = migration.Up(context.Background(), db, migrations)
 	if err != nil {
 		log.With(zap.Error(err)).Error("could not create test tables")
 		return err
@@ -110,7 +85,13 @@ func createTestTables(log *zap.Logger, db *sql.DB) error {
 }
 
 func resetTestTables(log *zap.Logger, db *sql.DB) error {
-	_, err := db.Exec(tableDestroy)
+	migrations, err := Migrations()
+	if err != nil {
+		log.With(zap.Error(err)).Error("could not load migrations")
+		return err
+	}
+
+	err = migration.Down(context.Background(), db, migrations)
 	if err != nil {
 		log.With(zap.Error(err)).Error("could not drop test tables")
 		return err
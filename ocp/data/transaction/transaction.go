@@ -93,11 +93,13 @@ func FromConfirmedTransaction(tx *solana.ConfirmedTransaction) (*Record, error)
 	}
 
 	sig := tx.Transaction.Signature()
+	fee := tx.Meta.Fee
 	res := &Record{
 		Signature:         base58.Encode(sig),
 		Slot:              tx.Slot,
 		Data:              tx.Transaction.Marshal(),
 		HasErrors:         tx.Err != nil,
+		Fee:               &fee,
 		ConfirmationState: ConfirmationFinalized,
 		CreatedAt:         time.Now(),
 	}
@@ -174,3 +176,20 @@ func getTokenBalanceSet(meta *solana.TransactionMeta, accounts []ed25519.PublicK
 
 	return res, nil
 }
+
+func (c Confirmation) String() string {
+	switch c {
+	case ConfirmationUnknown:
+		return "unknown"
+	case ConfirmationPending:
+		return "pending"
+	case ConfirmationConfirmed:
+		return "confirmed"
+	case ConfirmationFinalized:
+		return "finalized"
+	case ConfirmationFailed:
+		return "failed"
+	}
+
+	return "unknown"
+}
@@ -0,0 +1,177 @@
+package vault
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"strconv"
+	"strings"
+
+	"github.com/mr-tron/base58/base58"
+	"github.com/pkg/errors"
+)
+
+const (
+	// LegacyKeyVersion is the key version for private keys stored before
+	// envelope encryption was introduced
+	LegacyKeyVersion uint32 = 0
+
+	envelopePrefix    = "env1"
+	envelopeSeparator = ":"
+
+	dekSize = 32
+)
+
+// KeyEncrypter encrypts and decrypts private keys at rest in the vault
+type KeyEncrypter interface {
+	// Encrypt encrypts a private key under the newest key version. The public
+	// key is bound to the ciphertext.
+	Encrypt(ctx context.Context, plaintext, publicKey string) (string, error)
+
+	// Decrypt decrypts a private key that was encrypted under any known key
+	// version, including the legacy scheme
+	Decrypt(ctx context.Context, ciphertext, publicKey string) (string, error)
+
+	// GetKeyVersion gets the key version a ciphertext was encrypted under
+	GetKeyVersion(ciphertext string) (uint32, error)
+
+	// GetLatestKeyVersion gets the key version that's used for encryption
+	GetLatestKeyVersion(ctx context.Context) (uint32, error)
+}
+
+type envelopeKeyEncrypter struct {
+	kek KeyEncryptionKeyProvider
+}
+
+// NewEnvelopeKeyEncrypter returns a KeyEncrypter that encrypts each private key
+// with a random DEK, which is then wrapped by the newest KEK version.
+//
+// Ciphertexts are encoded as env1:<kek version>:<wrapped dek>:<sealed key>,
+// where binary values are base58 encoded. Anything else is assumed to be a
+// legacy ciphertext.
+func NewEnvelopeKeyEncrypter(kek KeyEncryptionKeyProvider) KeyEncrypter {
+	return &envelopeKeyEncrypter{
+		kek: kek,
+	}
+}
+
+// Encrypt implements KeyEncrypter.Encrypt
+func (e *envelopeKeyEncrypter) Encrypt(ctx context.Context, plaintext, publicKey string) (string, error) {
+	version, err := e.kek.GetLatestVersion(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	dek := make([]byte, dekSize)
+	if _, err := rand.Read(dek); err != nil {
+		return "", err
+	}
+
+	wrapped, err := e.kek.WrapKey(ctx, version, dek)
+	if err != nil {
+		return "", errors.Wrap(err, "error wrapping dek")
+	}
+
+	aesgcm, err := newDekCipher(dek)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aesgcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aesgcm.Seal(nonce, nonce, []byte(plaintext), []byte(publicKey))
+
+	return strings.Join([]string{
+		envelopePrefix,
+		strconv.FormatUint(uint64(version), 10),
+		base58.Encode(wrapped),
+		base58.Encode(sealed),
+	}, envelopeSeparator), nil
+}
+
+// Decrypt implements KeyEncrypter.Decrypt
+func (e *envelopeKeyEncrypter) Decrypt(ctx context.Context, ciphertext, publicKey string) (string, error) {
+	if !isEnvelope(ciphertext) {
+		return legacyDecrypt(ciphertext, publicKey)
+	}
+
+	version, wrapped, sealed, err := parseEnvelope(ciphertext)
+	if err != nil {
+		return "", err
+	}
+
+	dek, err := e.kek.UnwrapKey(ctx, version, wrapped)
+	if err != nil {
+		return "", errors.Wrap(err, "error unwrapping dek")
+	}
+
+	aesgcm, err := newDekCipher(dek)
+	if err != nil {
+		return "", err
+	}
+
+	if len(sealed) < aesgcm.NonceSize() {
+		return "", errors.New("ciphertext is too short")
+	}
+
+	nonce, sealed := sealed[:aesgcm.NonceSize()], sealed[aesgcm.NonceSize():]
+	plaintext, err := aesgcm.Open(nil, nonce, sealed, []byte(publicKey))
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// GetKeyVersion implements KeyEncrypter.GetKeyVersion
+func (e *envelopeKeyEncrypter) GetKeyVersion(ciphertext string) (uint32, error) {
+	if !isEnvelope(ciphertext) {
+		return LegacyKeyVersion, nil
+	}
+
+	version, _, _, err := parseEnvelope(ciphertext)
+	return version, err
+}
+
+// GetLatestKeyVersion implements KeyEncrypter.GetLatestKeyVersion
+func (e *envelopeKeyEncrypter) GetLatestKeyVersion(ctx context.Context) (uint32, error) {
+	return e.kek.GetLatestVersion(ctx)
+}
+
+func isEnvelope(ciphertext string) bool {
+	return strings.HasPrefix(ciphertext, envelopePrefix+envelopeSeparator)
+}
+
+func parseEnvelope(ciphertext string) (uint32, []byte, []byte, error) {
+	parts := strings.Split(ciphertext, envelopeSeparator)
+	if len(parts) != 4 {
+		return 0, nil, nil, errors.New("malformed envelope")
+	}
+
+	version, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return 0, nil, nil, errors.Wrap(err, "malformed envelope key version")
+	}
+
+	wrapped, err := base58.Decode(parts[2])
+	if err != nil {
+		return 0, nil, nil, errors.Wrap(err, "malformed envelope wrapped key")
+	}
+
+	sealed, err := base58.Decode(parts[3])
+	if err != nil {
+		return 0, nil, nil, errors.Wrap(err, "malformed envelope ciphertext")
+	}
+
+	return uint32(version), wrapped, sealed, nil
+}
+
+func newDekCipher(dek []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(dek)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
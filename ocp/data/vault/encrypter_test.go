@@ -0,0 +1,187 @@
+package vault
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mr-tron/base58/base58"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvelopeKeyEncrypter_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	kek, err := NewLocalKekProvider(map[uint32][]byte{1: newRandomKek(t)})
+	require.NoError(t, err)
+	encrypter := NewEnvelopeKeyEncrypter(kek)
+
+	key, err := CreateKey()
+	require.NoError(t, err)
+
+	ciphertext1, err := encrypter.Encrypt(ctx, key.PrivateKey, key.PublicKey)
+	require.NoError(t, err)
+	assert.False(t, strings.Contains(ciphertext1, key.PrivateKey))
+
+	ciphertext2, err := encrypter.Encrypt(ctx, key.PrivateKey, key.PublicKey)
+	require.NoError(t, err)
+	assert.NotEqual(t, ciphertext1, ciphertext2)
+
+	for _, ciphertext := range []string{ciphertext1, ciphertext2} {
+		version, err := encrypter.GetKeyVersion(ciphertext)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, version)
+
+		plaintext, err := encrypter.Decrypt(ctx, ciphertext, key.PublicKey)
+		require.NoError(t, err)
+		assert.Equal(t, key.PrivateKey, plaintext)
+
+		// The ciphertext is bound to the public key
+		_, err = encrypter.Decrypt(ctx, ciphertext, "other")
+		assert.Error(t, err)
+	}
+}
+
+func TestEnvelopeKeyEncrypter_KeyRotation(t *testing.T) {
+	ctx := context.Background()
+
+	kek1 := newRandomKek(t)
+	kek2 := newRandomKek(t)
+
+	kekProviderV1, err := NewLocalKekProvider(map[uint32][]byte{1: kek1})
+	require.NoError(t, err)
+	encrypterV1 := NewEnvelopeKeyEncrypter(kekProviderV1)
+
+	kekProviderV2, err := NewLocalKekProvider(map[uint32][]byte{1: kek1, 2: kek2})
+	require.NoError(t, err)
+	encrypterV2 := NewEnvelopeKeyEncrypter(kekProviderV2)
+
+	latest, err := encrypterV2.GetLatestKeyVersion(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, latest)
+
+	ciphertextV1, err := encrypterV1.Encrypt(ctx, "private_key", "public_key")
+	require.NoError(t, err)
+
+	ciphertextV2, err := encrypterV2.Encrypt(ctx, "private_key", "public_key")
+	require.NoError(t, err)
+
+	version, err := encrypterV2.GetKeyVersion(ciphertextV2)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, version)
+
+	for _, ciphertext := range []string{ciphertextV1, ciphertextV2} {
+		plaintext, err := encrypterV2.Decrypt(ctx, ciphertext, "public_key")
+		require.NoError(t, err)
+		assert.Equal(t, "private_key", plaintext)
+	}
+
+	_, err = encrypterV1.Decrypt(ctx, ciphertextV2, "public_key")
+	assert.ErrorIs(t, err, ErrUnknownKeyVersion)
+
+	// Wrapped DEKs can't be relabeled to a different KEK version
+	parts := strings.Split(ciphertextV2, envelopeSeparator)
+	parts[1] = "1"
+	_, err = encrypterV2.Decrypt(ctx, strings.Join(parts, envelopeSeparator), "public_key")
+	assert.Error(t, err)
+}
+
+func TestEnvelopeKeyEncrypter_Legacy(t *testing.T) {
+	ctx := context.Background()
+
+	kek, err := NewLocalKekProvider(map[uint32][]byte{1: newRandomKek(t)})
+	require.NoError(t, err)
+	encrypter := NewEnvelopeKeyEncrypter(kek)
+
+	key, err := CreateKey()
+	require.NoError(t, err)
+
+	legacyCiphertext := legacyEncrypt(t, key.PrivateKey, key.PublicKey)
+
+	version, err := encrypter.GetKeyVersion(legacyCiphertext)
+	require.NoError(t, err)
+	assert.Equal(t, LegacyKeyVersion, version)
+
+	plaintext, err := encrypter.Decrypt(ctx, legacyCiphertext, key.PublicKey)
+	require.NoError(t, err)
+	assert.Equal(t, key.PrivateKey, plaintext)
+}
+
+func TestLocalKekProvider_Validation(t *testing.T) {
+	_, err := NewLocalKekProvider(nil)
+	assert.Error(t, err)
+
+	_, err = NewLocalKekProvider(map[uint32][]byte{LegacyKeyVersion: newRandomKek(t)})
+	assert.Error(t, err)
+
+	_, err = NewLocalKekProvider(map[uint32][]byte{1: make([]byte, 16)})
+	assert.Error(t, err)
+}
+
+func TestFileKekProvider(t *testing.T) {
+	ctx := context.Background()
+
+	kek1 := newRandomKek(t)
+	kek3 := newRandomKek(t)
+
+	path := filepath.Join(t.TempDir(), "kek.json")
+	contents := `{"1": "` + base58.Encode(kek1) + `", "3": "` + base58.Encode(kek3) + `"}`
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+
+	kek, err := NewFileKekProvider(path)
+	require.NoError(t, err)
+
+	latest, err := kek.GetLatestVersion(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, latest)
+
+	for _, version := range []uint32{1, 3} {
+		wrapped, err := kek.WrapKey(ctx, version, []byte("dek"))
+		require.NoError(t, err)
+
+		unwrapped, err := kek.UnwrapKey(ctx, version, wrapped)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("dek"), unwrapped)
+	}
+
+	_, err = kek.WrapKey(ctx, 2, []byte("dek"))
+	assert.Equal(t, ErrUnknownKeyVersion, err)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"one": "invalid"}`), 0600))
+	_, err = NewFileKekProvider(path)
+	assert.Error(t, err)
+
+	_, err = NewFileKekProvider(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func newRandomKek(t *testing.T) []byte {
+	kek := make([]byte, kekSize)
+	_, err := rand.Read(kek)
+	require.NoError(t, err)
+	return kek
+}
+
+// legacyEncrypt mirrors how private keys were encrypted prior to envelope
+// encryption
+func legacyEncrypt(t *testing.T, plaintext, nonce string) string {
+	key, err := base58.Decode(GetSecret())
+	require.NoError(t, err)
+
+	block, err := aes.NewCipher(key)
+	require.NoError(t, err)
+
+	aesgcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+
+	nh := sha256.New().Sum([]byte(nonce))[:aesgcm.NonceSize()]
+
+	return base58.Encode(aesgcm.Seal(nil, nh, []byte(plaintext), nil))
+}
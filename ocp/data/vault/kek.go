@@ -0,0 +1,181 @@
+package vault
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/mr-tron/base58/base58"
+	"github.com/pkg/errors"
+)
+
+const (
+	kekFileEnv = "VAULT_KEK_FILE"
+
+	kekSize = 32
+)
+
+var (
+	ErrUnknownKeyVersion = errors.New("unknown key version")
+)
+
+// KeyEncryptionKeyProvider manages versioned key encryption keys (KEKs), which
+// wrap the per-record data encryption keys (DEKs) used for envelope encryption.
+// Version 0 is reserved for records stored with the legacy encryption scheme.
+//
+// Implementations may keep KEKs in memory, or delegate wrapping to an external
+// service like a KMS.
+type KeyEncryptionKeyProvider interface {
+	// GetLatestVersion gets the KEK version that's used to wrap new DEKs
+	GetLatestVersion(ctx context.Context) (uint32, error)
+
+	// WrapKey wraps a DEK with the KEK at the provided version
+	WrapKey(ctx context.Context, version uint32, dek []byte) ([]byte, error)
+
+	// UnwrapKey unwraps a DEK with the KEK at the provided version
+	//
+	// Returns ErrUnknownKeyVersion if the version isn't available.
+	UnwrapKey(ctx context.Context, version uint32, wrapped []byte) ([]byte, error)
+}
+
+type localKekProvider struct {
+	keks   map[uint32][]byte
+	latest uint32
+}
+
+// NewLocalKekProvider returns a KeyEncryptionKeyProvider that wraps DEKs in
+// memory with AES-256-GCM using the provided KEKs, keyed by version. The
+// highest version is used to wrap new DEKs.
+func NewLocalKekProvider(keks map[uint32][]byte) (KeyEncryptionKeyProvider, error) {
+	if len(keks) == 0 {
+		return nil, errors.New("at least one kek is required")
+	}
+
+	p := &localKekProvider{
+		keks: make(map[uint32][]byte),
+	}
+	for version, kek := range keks {
+		if version == LegacyKeyVersion {
+			return nil, errors.Errorf("kek version %d is reserved", LegacyKeyVersion)
+		}
+		if len(kek) != kekSize {
+			return nil, errors.Errorf("kek version %d must be %d bytes", version, kekSize)
+		}
+
+		p.keks[version] = append([]byte(nil), kek...)
+		if version > p.latest {
+			p.latest = version
+		}
+	}
+	return p, nil
+}
+
+// NewFileKekProvider returns a local KeyEncryptionKeyProvider with KEKs loaded
+// from a JSON file mapping versions to base58 encoded keys. It's intended for
+// local testing.
+//
+// Example:
+//
+//	{"1": "DpmmM8ruhZ5C26USxxEMBfQGAJPzHen6NxNrfKkyaBTB"}
+func NewFileKekProvider(path string) (KeyEncryptionKeyProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading kek file")
+	}
+
+	var encoded map[string]string
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return nil, errors.Wrap(err, "invalid kek file")
+	}
+
+	keks := make(map[uint32][]byte)
+	for versionString, encodedKek := range encoded {
+		version, err := strconv.ParseUint(versionString, 10, 32)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid kek version %s", versionString)
+		}
+
+		kek, err := base58.Decode(encodedKek)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid kek for version %d", version)
+		}
+
+		keks[uint32(version)] = kek
+	}
+
+	return NewLocalKekProvider(keks)
+}
+
+// NewEnvKekProvider returns a KeyEncryptionKeyProvider configured by the
+// environment. KEKs are loaded from the file at VAULT_KEK_FILE when it's set,
+// otherwise the vault secret is used as the only KEK version.
+func NewEnvKekProvider() (KeyEncryptionKeyProvider, error) {
+	path := os.Getenv(kekFileEnv)
+	if len(path) > 0 {
+		return NewFileKekProvider(path)
+	}
+
+	kek, err := base58.Decode(GetSecret())
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid vault secret")
+	}
+	return NewLocalKekProvider(map[uint32][]byte{1: kek})
+}
+
+// GetLatestVersion implements KeyEncryptionKeyProvider.GetLatestVersion
+func (p *localKekProvider) GetLatestVersion(_ context.Context) (uint32, error) {
+	return p.latest, nil
+}
+
+// WrapKey implements KeyEncryptionKeyProvider.WrapKey
+func (p *localKekProvider) WrapKey(_ context.Context, version uint32, dek []byte) ([]byte, error) {
+	aesgcm, err := p.getCipher(version)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aesgcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aesgcm.Seal(nonce, nonce, dek, getKekAdditionalData(version)), nil
+}
+
+// UnwrapKey implements KeyEncryptionKeyProvider.UnwrapKey
+func (p *localKekProvider) UnwrapKey(_ context.Context, version uint32, wrapped []byte) ([]byte, error) {
+	aesgcm, err := p.getCipher(version)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(wrapped) < aesgcm.NonceSize() {
+		return nil, errors.New("wrapped key is too short")
+	}
+
+	nonce, sealed := wrapped[:aesgcm.NonceSize()], wrapped[aesgcm.NonceSize():]
+	return aesgcm.Open(nil, nonce, sealed, getKekAdditionalData(version))
+}
+
+func (p *localKekProvider) getCipher(version uint32) (cipher.AEAD, error) {
+	kek, ok := p.keks[version]
+	if !ok {
+		return nil, ErrUnknownKeyVersion
+	}
+
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Binds wrapped DEKs to the KEK version, so they can't be relabeled
+func getKekAdditionalData(version uint32) []byte {
+	return []byte(fmt.Sprintf("vault-kek-v%d", version))
+}
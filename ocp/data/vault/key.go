@@ -32,6 +32,10 @@ type Record struct {
 
 	State State
 
+	// KeyVersion is the version of the key the private key is encrypted under
+	// at rest. It's populated by the store.
+	KeyVersion uint32
+
 	CreatedAt time.Time
 }
 
@@ -96,6 +100,7 @@ func (r *Record) Clone() Record {
 		PublicKey:  r.PublicKey,
 		PrivateKey: r.PrivateKey,
 		State:      r.State,
+		KeyVersion: r.KeyVersion,
 		CreatedAt:  r.CreatedAt,
 	}
 }
@@ -105,6 +110,7 @@ func (r *Record) CopyTo(dst *Record) {
 	dst.PublicKey = r.PublicKey
 	dst.PrivateKey = r.PrivateKey
 	dst.State = r.State
+	dst.KeyVersion = r.KeyVersion
 	dst.CreatedAt = r.CreatedAt
 }
 
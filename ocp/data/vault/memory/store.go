@@ -10,10 +10,10 @@ import (
 )
 
 type store struct {
-	mu      sync.Mutex
-	records []*vault.Record
-	last    uint64
-	secret  string
+	mu        sync.Mutex
+	records   []*vault.Record
+	last      uint64
+	encrypter vault.KeyEncrypter
 }
 
 type ById []*vault.Record
@@ -22,11 +22,11 @@ func (a ById) Len() int           { return len(a) }
 func (a ById) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 func (a ById) Less(i, j int) bool { return a[i].Id < a[j].Id }
 
-func New() vault.Store {
+func New(encrypter vault.KeyEncrypter) vault.Store {
 	return &store{
-		records: make([]*vault.Record, 0),
-		last:    0,
-		secret:  vault.GetSecret(),
+		records:   make([]*vault.Record, 0),
+		last:      0,
+		encrypter: encrypter,
 	}
 }
 
@@ -123,18 +123,25 @@ func (s *store) Save(ctx context.Context, data *vault.Record) error {
 	s.last++
 	if item := s.find(data); item != nil {
 		item.State = data.State
+		data.KeyVersion = item.KeyVersion
 	} else {
 		if data.Id == 0 {
 			data.Id = s.last
 		}
 		c := data.Clone()
 
-		val, err := vault.Encrypt(c.PrivateKey, c.PublicKey)
+		val, err := s.encrypter.Encrypt(ctx, c.PrivateKey, c.PublicKey)
 		if err != nil {
 			return err
 		}
 		c.PrivateKey = val
 
+		c.KeyVersion, err = s.encrypter.GetKeyVersion(val)
+		if err != nil {
+			return err
+		}
+		data.KeyVersion = c.KeyVersion
+
 		s.records = append(s.records, &c)
 	}
 
@@ -146,22 +153,38 @@ func (s *store) Get(ctx context.Context, sig string) (*vault.Record, error) {
 	defer s.mu.Unlock()
 
 	if item := s.findPublicKey(sig); item != nil {
+		return s.decrypt(ctx, item)
+	}
 
-		val, err := vault.Decrypt(item.PrivateKey, item.PublicKey)
-		if err != nil {
-			return nil, err
-		}
+	return nil, vault.ErrKeyNotFound
+}
 
-		cloned := item.Clone()
-		cloned.PrivateKey = val
+func (s *store) GetAllByState(ctx context.Context, state vault.State, cursor query.Cursor, limit uint64, direction query.Ordering) ([]*vault.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-		return &cloned, nil
+	if items := s.findByState(state); len(items) > 0 {
+		res := s.filter(items, cursor, limit, direction)
+
+		if len(res) == 0 {
+			return nil, vault.ErrKeyNotFound
+		}
+
+		decrypted := make([]*vault.Record, len(res))
+		for i, item := range res {
+			record, err := s.decrypt(ctx, item)
+			if err != nil {
+				return nil, err
+			}
+			decrypted[i] = record
+		}
+		return decrypted, nil
 	}
 
 	return nil, vault.ErrKeyNotFound
 }
 
-func (s *store) GetAllByState(ctx context.Context, state vault.State, cursor query.Cursor, limit uint64, direction query.Ordering) ([]*vault.Record, error) {
+func (s *store) GetAllMetadataByState(ctx context.Context, state vault.State, cursor query.Cursor, limit uint64, direction query.Ordering) ([]*vault.Record, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -172,8 +195,65 @@ func (s *store) GetAllByState(ctx context.Context, state vault.State, cursor que
 			return nil, vault.ErrKeyNotFound
 		}
 
-		return res, nil
+		metadata := make([]*vault.Record, len(res))
+		for i, item := range res {
+			cloned := item.Clone()
+			cloned.PrivateKey = ""
+			cloned.KeyVersion = 0
+			metadata[i] = &cloned
+		}
+		return metadata, nil
 	}
 
 	return nil, vault.ErrKeyNotFound
 }
+
+func (s *store) ReEncrypt(ctx context.Context, pubkey string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item := s.findPublicKey(pubkey)
+	if item == nil {
+		return vault.ErrKeyNotFound
+	}
+
+	latest, err := s.encrypter.GetLatestKeyVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if item.KeyVersion == latest {
+		return nil
+	}
+
+	plaintext, err := s.encrypter.Decrypt(ctx, item.PrivateKey, item.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	ciphertext, err := s.encrypter.Encrypt(ctx, plaintext, item.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	keyVersion, err := s.encrypter.GetKeyVersion(ciphertext)
+	if err != nil {
+		return err
+	}
+
+	item.PrivateKey = ciphertext
+	item.KeyVersion = keyVersion
+
+	return nil
+}
+
+func (s *store) decrypt(ctx context.Context, item *vault.Record) (*vault.Record, error) {
+	val, err := s.encrypter.Decrypt(ctx, item.PrivateKey, item.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	cloned := item.Clone()
+	cloned.PrivateKey = val
+
+	return &cloned, nil
+}
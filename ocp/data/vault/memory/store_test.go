@@ -3,13 +3,15 @@ package memory
 import (
 	"testing"
 
+	"github.com/code-payments/ocp-server/ocp/data/vault"
 	"github.com/code-payments/ocp-server/ocp/data/vault/tests"
 )
 
 func TestFulfillmentMemoryStore(t *testing.T) {
-	testStore := New()
+	kek := tests.NewKekProvider()
+	testStore := New(vault.NewEnvelopeKeyEncrypter(kek))
 	teardown := func() {
 		testStore.(*store).reset()
 	}
-	tests.RunTests(t, testStore, teardown)
+	tests.RunTests(t, testStore, kek, teardown)
 }
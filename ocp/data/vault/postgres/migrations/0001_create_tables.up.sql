@@ -0,0 +1,10 @@
+CREATE TABLE ocp__core_keyvault(
+	id SERIAL NOT NULL PRIMARY KEY,
+
+	public_key TEXT NOT NULL UNIQUE,
+	private_key TEXT NOT NULL,
+
+	state INTEGER NOT NULL,
+
+	created_at timestamp with time zone NOT NULL
+);
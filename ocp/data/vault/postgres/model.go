@@ -77,6 +77,17 @@ func (m *vaultModel) dbSave(ctx context.Context, db *sqlx.DB) error {
 	return pgutil.CheckNoRows(err, vault.ErrInvalidKey)
 }
 
+// dbUpdatePrivateKey swaps the stored ciphertext, as long as it hasn't been
+// concurrently rewritten
+func dbUpdatePrivateKey(ctx context.Context, db *sqlx.DB, pubkey, prevCiphertext, newCiphertext string) error {
+	query := `UPDATE ` + vaultTableName + `
+		SET private_key = $3
+		WHERE public_key = $1 AND private_key = $2`
+
+	_, err := db.ExecContext(ctx, query, pubkey, prevCiphertext, newCiphertext)
+	return err
+}
+
 func dbGetCount(ctx context.Context, db *sqlx.DB) (uint64, error) {
 	var res uint64
 
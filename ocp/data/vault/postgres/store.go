@@ -10,12 +10,14 @@ import (
 )
 
 type store struct {
-	db *sqlx.DB
+	db        *sqlx.DB
+	encrypter vault.KeyEncrypter
 }
 
-func New(db *sql.DB) vault.Store {
+func New(db *sql.DB, encrypter vault.KeyEncrypter) vault.Store {
 	return &store{
-		db: sqlx.NewDb(db, "pgx"),
+		db:        sqlx.NewDb(db, "pgx"),
+		encrypter: encrypter,
 	}
 }
 
@@ -36,7 +38,7 @@ func (s *store) Save(ctx context.Context, record *vault.Record) error {
 		return err
 	}
 
-	ciphertext, err := vault.Encrypt(record.PrivateKey, record.PublicKey)
+	ciphertext, err := s.encrypter.Encrypt(ctx, record.PrivateKey, record.PublicKey)
 	if err != nil {
 		return err
 	}
@@ -46,9 +48,17 @@ func (s *store) Save(ctx context.Context, record *vault.Record) error {
 	if err != nil {
 		return err
 	}
+
+	// The stored ciphertext is returned, which may be under an older key
+	// version when updating an existing record
+	keyVersion, err := s.encrypter.GetKeyVersion(obj.PrivateKey)
+	if err != nil {
+		return err
+	}
 	obj.PrivateKey = record.PrivateKey
 
 	res := fromKeyModel(obj)
+	res.KeyVersion = keyVersion
 	res.CopyTo(record)
 
 	return nil
@@ -61,13 +71,7 @@ func (s *store) Get(ctx context.Context, pubkey string) (*vault.Record, error) {
 		return nil, err
 	}
 
-	plaintext, err := vault.Decrypt(obj.PrivateKey, obj.PublicKey)
-	if err != nil {
-		return nil, err
-	}
-	obj.PrivateKey = plaintext
-
-	return fromKeyModel(obj), nil
+	return s.decrypt(ctx, obj)
 }
 
 // GetAllByState returns all vault records for a given state.
@@ -81,15 +85,81 @@ func (s *store) GetAllByState(ctx context.Context, state vault.State, cursor que
 
 	keys := make([]*vault.Record, len(models))
 	for i, model := range models {
-
-		plaintext, err := vault.Decrypt(model.PrivateKey, model.PublicKey)
+		keys[i], err = s.decrypt(ctx, model)
 		if err != nil {
 			return nil, err
 		}
-		model.PrivateKey = plaintext
+	}
+
+	return keys, nil
+}
+
+// GetAllMetadataByState returns all vault records for a given state without
+// decrypting their private keys.
+//
+// Returns ErrKeyNotFound if no records are found.
+func (s *store) GetAllMetadataByState(ctx context.Context, state vault.State, cursor query.Cursor, limit uint64, direction query.Ordering) ([]*vault.Record, error) {
+	models, err := dbGetAllByState(ctx, s.db, state, cursor, limit, direction)
+	if err != nil {
+		return nil, err
+	}
 
+	keys := make([]*vault.Record, len(models))
+	for i, model := range models {
+		model.PrivateKey = ""
 		keys[i] = fromKeyModel(model)
 	}
 
 	return keys, nil
 }
+
+// ReEncrypt rewrites the private key for a given pubkey under the newest key
+// version.
+func (s *store) ReEncrypt(ctx context.Context, pubkey string) error {
+	obj, err := dbGetKey(ctx, s.db, pubkey)
+	if err != nil {
+		return err
+	}
+
+	keyVersion, err := s.encrypter.GetKeyVersion(obj.PrivateKey)
+	if err != nil {
+		return err
+	}
+
+	latest, err := s.encrypter.GetLatestKeyVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if keyVersion == latest {
+		return nil
+	}
+
+	plaintext, err := s.encrypter.Decrypt(ctx, obj.PrivateKey, obj.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	ciphertext, err := s.encrypter.Encrypt(ctx, plaintext, obj.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	return dbUpdatePrivateKey(ctx, s.db, obj.PublicKey, obj.PrivateKey, ciphertext)
+}
+
+func (s *store) decrypt(ctx context.Context, obj *vaultModel) (*vault.Record, error) {
+	keyVersion, err := s.encrypter.GetKeyVersion(obj.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := s.encrypter.Decrypt(ctx, obj.PrivateKey, obj.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	obj.PrivateKey = plaintext
+
+	res := fromKeyModel(obj)
+	res.KeyVersion = keyVersion
+	return res, nil
+}
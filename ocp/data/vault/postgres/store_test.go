@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"os"
 	"testing"
@@ -11,34 +12,15 @@ import (
 	"github.com/code-payments/ocp-server/ocp/data/vault"
 	"github.com/code-payments/ocp-server/ocp/data/vault/tests"
 
+	"github.com/code-payments/ocp-server/database/postgres/migration"
 	postgrestest "github.com/code-payments/ocp-server/database/postgres/test"
 
 	_ "github.com/jackc/pgx/v4/stdlib"
 )
 
-const (
-	// Used for testing ONLY, the table and migrations are external to this repository
-	tableCreate = `
-		CREATE TABLE ocp__core_keyvault(
-			id SERIAL NOT NULL PRIMARY KEY,
-
-			public_key TEXT NOT NULL UNIQUE,
-			private_key TEXT NOT NULL,
-
-			state INTEGER NOT NULL,
-
-			created_at timestamp with time zone NOT NULL
-		);
-	`
-
-	// Used for testing ONLY, the table and migrations are external to this repository
-	tableDestroy = `
-		DROP TABLE ocp__core_keyvault;
-	`
-)
-
 var (
 	testStore vault.Store
+	testKek   *tests.KekProvider
 	teardown  func()
 )
 
@@ -65,7 +47,8 @@ func TestMain(m *testing.M) {
 		os.Exit(1)
 	}
 
-	testStore = New(db)
+	testKek = tests.NewKekProvider()
+	testStore = New(db, vault.NewEnvelopeKeyEncrypter(testKek))
 	teardown = func() {
 		if pc := recover(); pc != nil {
 			cleanUpFunc()
@@ -85,11 +68,17 @@ func TestMain(m *testing.M) {
 }
 
 func TestVaultPostgresStore(t *testing.T) {
-	tests.RunTests(t, testStore, teardown)
+	tests.RunTests(t, testStore, testKek, teardown)
 }
 
 func createTestTables(log *zap.Logger, db *sql.DB) error {
-	_, err := db.Exec(tableCreate)
+	migrations, err := Migrations()
+	if err != nil {
+		log.With(zap.Error(err)).Error("could not load migrations")
+		return err
+	}
+
+	err = migration.Up(context.Background(), db, migrations)
 	if err != nil {
 		log.With(zap.Error(err)).Error("could not create test tables")
 		return err
@@ -98,7 +87,13 @@ func createTestTables(log *zap.Logger, db *sql.DB) error {
 }
 
 func resetTestTables(log *zap.Logger, db *sql.DB) error {
-	_, err := db.Exec(tableDestroy)
+	migrations, err := Migrations()
+	if err != nil {
+		log.With(zap.Error(err)).Error("could not load migrations")
+		return err
+	}
+
+	err = migration.Down(context.Background(), db, migrations)
 	if err != nil {
 		log.With(zap.Error(err)).Error("could not drop test tables")
 		return err
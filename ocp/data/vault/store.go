@@ -6,8 +6,9 @@ import (
 	"github.com/code-payments/ocp-server/database/query"
 )
 
-// todo: migrate to AWS KMS or similar
-
+// Store persists vault keys. Private keys are encrypted at rest with a
+// KeyEncrypter, and are always plaintext on records passed in and out of the
+// store.
 type Store interface {
 	// Count returns the total count of keys.
 	Count(ctx context.Context) (uint64, error)
@@ -25,4 +26,17 @@ type Store interface {
 	//
 	// Returns ErrKeyNotFound if no records are found.
 	GetAllByState(ctx context.Context, state State, cursor query.Cursor, limit uint64, direction query.Ordering) ([]*Record, error)
+
+	// GetAllMetadataByState returns all records for a given state without
+	// decrypting them. Private keys and key versions are left unset, so a
+	// record that can't be decrypted doesn't prevent listing the others.
+	//
+	// Returns ErrKeyNotFound if no records are found.
+	GetAllMetadataByState(ctx context.Context, state State, cursor query.Cursor, limit uint64, direction query.Ordering) ([]*Record, error)
+
+	// ReEncrypt rewrites the private key for a given public key under the newest
+	// key version. It's a no-op if the key is already on the newest version.
+	//
+	// Returns ErrKeyNotFound if no record is found.
+	ReEncrypt(ctx context.Context, pubkey string) error
 }
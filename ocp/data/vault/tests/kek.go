@@ -0,0 +1,67 @@
+package tests
+
+import (
+	"context"
+	"crypto/rand"
+	"sync"
+
+	"github.com/code-payments/ocp-server/ocp/data/vault"
+)
+
+// KekProvider is a vault.KeyEncryptionKeyProvider for tests that can rotate to
+// a new KEK version
+type KekProvider struct {
+	mu       sync.RWMutex
+	keks     map[uint32][]byte
+	provider vault.KeyEncryptionKeyProvider
+}
+
+func NewKekProvider() *KekProvider {
+	p := &KekProvider{
+		keks: make(map[uint32][]byte),
+	}
+	p.Rotate()
+	return p
+}
+
+// Rotate adds a new random KEK as the latest version
+func (p *KekProvider) Rotate() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	kek := make([]byte, 32)
+	if _, err := rand.Read(kek); err != nil {
+		panic(err)
+	}
+	p.keks[uint32(len(p.keks)+1)] = kek
+
+	provider, err := vault.NewLocalKekProvider(p.keks)
+	if err != nil {
+		panic(err)
+	}
+	p.provider = provider
+}
+
+// GetLatestVersion implements vault.KeyEncryptionKeyProvider.GetLatestVersion
+func (p *KekProvider) GetLatestVersion(ctx context.Context) (uint32, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.provider.GetLatestVersion(ctx)
+}
+
+// WrapKey implements vault.KeyEncryptionKeyProvider.WrapKey
+func (p *KekProvider) WrapKey(ctx context.Context, version uint32, dek []byte) ([]byte, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.provider.WrapKey(ctx, version, dek)
+}
+
+// UnwrapKey implements vault.KeyEncryptionKeyProvider.UnwrapKey
+func (p *KekProvider) UnwrapKey(ctx context.Context, version uint32, wrapped []byte) ([]byte, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.provider.UnwrapKey(ctx, version, wrapped)
+}
@@ -11,7 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func RunTests(t *testing.T, s vault.Store, teardown func()) {
+func RunTests(t *testing.T, s vault.Store, kek *KekProvider, teardown func()) {
 	for _, tf := range []func(t *testing.T, s vault.Store){
 		testRoundTrip,
 		testUpdate,
@@ -20,6 +20,9 @@ func RunTests(t *testing.T, s vault.Store, teardown func()) {
 		tf(t, s)
 		teardown()
 	}
+
+	testReEncrypt(t, s, kek)
+	teardown()
 }
 
 func testRoundTrip(t *testing.T, s vault.Store) {
@@ -125,6 +128,9 @@ func testGetAllByState(t *testing.T, s vault.Store) {
 	assert.Equal(t, "t3", actual[0].PublicKey)
 	assert.Equal(t, "t4", actual[1].PublicKey)
 	assert.Equal(t, "t5", actual[2].PublicKey)
+	assert.Equal(t, "n3", actual[0].PrivateKey)
+	assert.Equal(t, "n4", actual[1].PrivateKey)
+	assert.Equal(t, "n5", actual[2].PrivateKey)
 
 	// Check items (desc)
 	actual, err = s.GetAllByState(ctx, vault.StateUnknown, query.EmptyCursor, 5, query.Descending)
@@ -182,6 +188,68 @@ func testGetAllByState(t *testing.T, s vault.Store) {
 	require.NoError(t, err)
 	assert.Equal(t, 1, len(actual))
 	assert.Equal(t, "t4", actual[0].PublicKey)
+
+	// Metadata is listed without private keys
+	actual, err = s.GetAllMetadataByState(ctx, vault.StateUnknown, query.ToCursor(3), 5, query.Ascending)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(actual))
+	assert.Equal(t, "t4", actual[0].PublicKey)
+	assert.Equal(t, "t5", actual[1].PublicKey)
+	for _, record := range actual {
+		assert.Empty(t, record.PrivateKey)
+		assert.Equal(t, vault.StateUnknown, record.State)
+	}
+
+	_, err = s.GetAllMetadataByState(ctx, vault.StateAvailable, query.EmptyCursor, 5, query.Ascending)
+	assert.Equal(t, vault.ErrKeyNotFound, err)
+}
+
+func testReEncrypt(t *testing.T, s vault.Store, kek *KekProvider) {
+	ctx := context.Background()
+
+	assert.Equal(t, vault.ErrKeyNotFound, s.ReEncrypt(ctx, "t1"))
+
+	initialVersion, err := kek.GetLatestVersion(ctx)
+	require.NoError(t, err)
+
+	record1 := &vault.Record{PublicKey: "t1", PrivateKey: "n1", State: vault.StateAvailable}
+	require.NoError(t, s.Save(ctx, record1))
+	assert.Equal(t, initialVersion, record1.KeyVersion)
+
+	kek.Rotate()
+
+	actual, err := s.Get(ctx, "t1")
+	require.NoError(t, err)
+	assert.Equal(t, "n1", actual.PrivateKey)
+	assert.Equal(t, initialVersion, actual.KeyVersion)
+
+	record2 := &vault.Record{PublicKey: "t2", PrivateKey: "n2", State: vault.StateAvailable}
+	require.NoError(t, s.Save(ctx, record2))
+	assert.Equal(t, initialVersion+1, record2.KeyVersion)
+
+	// Updating state doesn't re-encrypt
+	record1.State = vault.StateReserved
+	require.NoError(t, s.Save(ctx, record1))
+	assert.Equal(t, initialVersion, record1.KeyVersion)
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, s.ReEncrypt(ctx, "t1"))
+		require.NoError(t, s.ReEncrypt(ctx, "t2"))
+
+		for _, expected := range []*vault.Record{record1, record2} {
+			actual, err := s.Get(ctx, expected.PublicKey)
+			require.NoError(t, err)
+			assert.Equal(t, expected.PrivateKey, actual.PrivateKey)
+			assert.Equal(t, expected.State, actual.State)
+			assert.Equal(t, initialVersion+1, actual.KeyVersion)
+		}
+	}
+
+	actualRecords, err := s.GetAllByState(ctx, vault.StateAvailable, query.EmptyCursor, 5, query.Ascending)
+	require.NoError(t, err)
+	require.Len(t, actualRecords, 1)
+	assert.Equal(t, "n2", actualRecords[0].PrivateKey)
+	assert.Equal(t, initialVersion+1, actualRecords[0].KeyVersion)
 }
 
 func testGetCount(t *testing.T, s vault.Store) {
@@ -0,0 +1,209 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/code-payments/ocp-server/database/query"
+	"github.com/code-payments/ocp-server/ocp/data/vm/operation"
+)
+
+type ById []*operation.Record
+
+func (a ById) Len() int           { return len(a) }
+func (a ById) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a ById) Less(i, j int) bool { return a[i].Id < a[j].Id }
+
+type store struct {
+	mu      sync.Mutex
+	last    uint64
+	records []*operation.Record
+}
+
+// New returns a new in memory vm.operation.Store
+func New() operation.Store {
+	return &store{}
+}
+
+// Put implements vm.operation.Store.Put
+func (s *store) Put(_ context.Context, record *operation.Record) error {
+	if err := record.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, item := range s.records {
+		if len(record.Signature) > 0 && item.Signature == record.Signature {
+			return operation.ErrAlreadyExists
+		}
+
+		if item.Address == record.Address && item.State.IsInFlight() && record.State.IsInFlight() {
+			return operation.ErrAlreadyExists
+		}
+	}
+
+	s.last++
+	record.Id = s.last
+	record.Version = 1
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+
+	cloned := record.Clone()
+	s.records = append(s.records, &cloned)
+
+	return nil
+}
+
+// Update implements vm.operation.Store.Update
+func (s *store) Update(_ context.Context, record *operation.Record) error {
+	if err := record.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item := s.find(func(item *operation.Record) bool { return item.Id == record.Id })
+	if item == nil {
+		return operation.ErrNotFound
+	}
+
+	if item.Version != record.Version {
+		return operation.ErrStaleVersion
+	}
+
+	if len(record.Signature) > 0 {
+		conflict := s.find(func(other *operation.Record) bool {
+			return other.Id != record.Id && other.Signature == record.Signature
+		})
+		if conflict != nil {
+			return operation.ErrAlreadyExists
+		}
+	}
+
+	record.Version++
+
+	cloned := record.Clone()
+	item.Size = cloned.Size
+	item.MemoryAccount = cloned.MemoryAccount
+	item.MemoryIndex = cloned.MemoryIndex
+	item.StorageAccount = cloned.StorageAccount
+	item.Signature = cloned.Signature
+	item.Nonce = cloned.Nonce
+	item.Blockhash = cloned.Blockhash
+	item.TransactionBlob = cloned.TransactionBlob
+	item.State = cloned.State
+	item.Version = cloned.Version
+
+	return nil
+}
+
+// GetLatestByAddress implements vm.operation.Store.GetLatestByAddress
+func (s *store) GetLatestByAddress(_ context.Context, address string) (*operation.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var latest *operation.Record
+	for _, item := range s.records {
+		if item.Address == address && (latest == nil || item.Id > latest.Id) {
+			latest = item
+		}
+	}
+
+	if latest == nil {
+		return nil, operation.ErrNotFound
+	}
+
+	cloned := latest.Clone()
+	return &cloned, nil
+}
+
+// GetAllByState implements vm.operation.Store.GetAllByState
+func (s *store) GetAllByState(_ context.Context, state operation.State, cursor query.Cursor, limit uint64, direction query.Ordering) ([]*operation.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var start uint64
+	if direction == query.Descending {
+		start = s.last + 1
+	}
+	if len(cursor) > 0 {
+		start = cursor.ToUint64()
+	}
+
+	var res []*operation.Record
+	for _, item := range s.records {
+		if item.State != state {
+			continue
+		}
+
+		if (direction == query.Ascending && item.Id > start) || (direction == query.Descending && item.Id < start) {
+			cloned := item.Clone()
+			res = append(res, &cloned)
+		}
+	}
+
+	if direction == query.Descending {
+		sort.Sort(sort.Reverse(ById(res)))
+	} else {
+		sort.Sort(ById(res))
+	}
+
+	if len(res) > int(limit) {
+		res = res[:limit]
+	}
+
+	if len(res) == 0 {
+		return nil, operation.ErrNotFound
+	}
+	return res, nil
+}
+
+// CountByState implements vm.operation.Store.CountByState
+func (s *store) CountByState(_ context.Context, state operation.State) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var count uint64
+	for _, item := range s.records {
+		if item.State == state {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// CountByVmTypeAndState implements vm.operation.Store.CountByVmTypeAndState
+func (s *store) CountByVmTypeAndState(_ context.Context, vm string, opType operation.Type, state operation.State) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var count uint64
+	for _, item := range s.records {
+		if item.Vm == vm && item.Type == opType && item.State == state {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *store) find(matches func(item *operation.Record) bool) *operation.Record {
+	for _, item := range s.records {
+		if matches(item) {
+			return item
+		}
+	}
+	return nil
+}
+
+func (s *store) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.last = 0
+	s.records = nil
+}
@@ -0,0 +1,257 @@
+package operation
+
+import (
+	"errors"
+	"time"
+
+	"github.com/code-payments/ocp-server/solana/vm"
+)
+
+type Type uint8
+
+const (
+	TypeUnknown     Type = iota
+	TypeInitMemory       // Creates a memory account and resizes it to fit all virtual accounts
+	TypeInitStorage      // Creates a storage account for compressed virtual accounts
+	TypeCompress         // Compresses an inactive virtual account from memory into storage
+	TypeDecompress       // Decompresses a virtual account from storage back into memory
+)
+
+type State uint8
+
+const (
+	StateUnknown   State = iota
+	StateRequested       // Operation is waiting for a transaction to be made
+	StatePending         // Transaction is being submitted
+	StateConfirmed       // All transactions were finalized successfully
+	StateFailed          // A transaction was finalized with an error
+)
+
+// Record is an operation that manages the lifecycle of memory and storage
+// accounts in a VM, and the virtual accounts that move between them
+type Record struct {
+	Id uint64
+
+	Vm string
+
+	Type Type
+
+	// The memory or storage account being initialized, or the virtual timelock
+	// vault being compressed or decompressed
+	Address string
+
+	// The owner of the virtual timelock account being compressed or decompressed
+	Owner string
+
+	// Memory and storage account initialization parameters. Memory accounts
+	// are resized over potentially many transactions, and Size tracks the
+	// account data size reached so far.
+	Name              string
+	StoredAccountType vm.VirtualAccountType
+	NumAccounts       uint32
+	Size              uint32
+	TargetSize        uint32
+
+	// The memory and storage locations of a virtual account being compressed
+	// or decompressed. For decompression, these are only available once a
+	// transaction has been made.
+	MemoryAccount  string
+	MemoryIndex    uint16
+	StorageAccount string
+
+	// The latest transaction made for the operation, which is only guaranteed
+	// to be available while the operation is pending
+	Signature string
+	Nonce     string
+	Blockhash string
+
+	// Only available while the transaction is pending
+	TransactionBlob []byte
+
+	State State
+
+	Version uint64
+
+	CreatedAt time.Time
+}
+
+func (r *Record) Validate() error {
+	if len(r.Vm) == 0 {
+		return errors.New("vm is required")
+	}
+
+	if len(r.Address) == 0 {
+		return errors.New("address is required")
+	}
+
+	if r.State == StateUnknown {
+		return errors.New("state is required")
+	}
+
+	hasTransaction := len(r.Signature) > 0 || len(r.Nonce) > 0 || len(r.Blockhash) > 0
+	if hasTransaction && (len(r.Signature) == 0 || len(r.Nonce) == 0 || len(r.Blockhash) == 0) {
+		return errors.New("signature, nonce and blockhash must be set together")
+	}
+
+	if r.State == StateRequested && hasTransaction {
+		return errors.New("transaction cannot be set when requested")
+	}
+
+	if r.State == StatePending && (!hasTransaction || len(r.TransactionBlob) == 0) {
+		return errors.New("transaction is required when pending")
+	}
+
+	switch r.Type {
+	case TypeInitMemory:
+		if len(r.Name) == 0 || len(r.Name) > vm.MaxMemoryAccountNameLength {
+			return errors.New("invalid name")
+		}
+
+		switch r.StoredAccountType {
+		case vm.VirtualAccountTypeDurableNonce, vm.VirtualAccountTypeTimelock:
+		default:
+			return errors.New("invalid stored account type")
+		}
+
+		if r.NumAccounts == 0 {
+			return errors.New("account count is required")
+		}
+
+		if r.TargetSize == 0 {
+			return errors.New("target size is required")
+		}
+
+		if r.Size > r.TargetSize {
+			return errors.New("size exceeds target size")
+		}
+	case TypeInitStorage:
+		if len(r.Name) == 0 || len(r.Name) > vm.MaxStorageAccountNameSize {
+			return errors.New("invalid name")
+		}
+	case TypeCompress, TypeDecompress:
+		if len(r.Owner) == 0 {
+			return errors.New("owner is required")
+		}
+
+		if hasTransaction && (len(r.MemoryAccount) == 0 || len(r.StorageAccount) == 0) {
+			return errors.New("memory and storage accounts are required")
+		}
+	default:
+		return errors.New("invalid type")
+	}
+
+	return nil
+}
+
+func (r *Record) Clone() Record {
+	var transactionBlob []byte
+	if r.TransactionBlob != nil {
+		transactionBlob = make([]byte, len(r.TransactionBlob))
+		copy(transactionBlob, r.TransactionBlob)
+	}
+
+	return Record{
+		Id: r.Id,
+
+		Vm: r.Vm,
+
+		Type: r.Type,
+
+		Address: r.Address,
+
+		Owner: r.Owner,
+
+		Name:              r.Name,
+		StoredAccountType: r.StoredAccountType,
+		NumAccounts:       r.NumAccounts,
+		Size:              r.Size,
+		TargetSize:        r.TargetSize,
+
+		MemoryAccount:  r.MemoryAccount,
+		MemoryIndex:    r.MemoryIndex,
+		StorageAccount: r.StorageAccount,
+
+		Signature: r.Signature,
+		Nonce:     r.Nonce,
+		Blockhash: r.Blockhash,
+
+		TransactionBlob: transactionBlob,
+
+		State: r.State,
+
+		Version: r.Version,
+
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+func (r *Record) CopyTo(dst *Record) {
+	dst.Id = r.Id
+
+	dst.Vm = r.Vm
+
+	dst.Type = r.Type
+
+	dst.Address = r.Address
+
+	dst.Owner = r.Owner
+
+	dst.Name = r.Name
+	dst.StoredAccountType = r.StoredAccountType
+	dst.NumAccounts = r.NumAccounts
+	dst.Size = r.Size
+	dst.TargetSize = r.TargetSize
+
+	dst.MemoryAccount = r.MemoryAccount
+	dst.MemoryIndex = r.MemoryIndex
+	dst.StorageAccount = r.StorageAccount
+
+	dst.Signature = r.Signature
+	dst.Nonce = r.Nonce
+	dst.Blockhash = r.Blockhash
+
+	dst.TransactionBlob = r.TransactionBlob
+
+	dst.State = r.State
+
+	dst.Version = r.Version
+
+	dst.CreatedAt = r.CreatedAt
+}
+
+func (t Type) String() string {
+	switch t {
+	case TypeInitMemory:
+		return "init_memory"
+	case TypeInitStorage:
+		return "init_storage"
+	case TypeCompress:
+		return "compress"
+	case TypeDecompress:
+		return "decompress"
+	}
+	return "unknown"
+}
+
+// IsInFlight returns whether an operation still requires work
+func (s State) IsInFlight() bool {
+	return s == StateRequested || s == StatePending
+}
+
+func (s State) IsTerminal() bool {
+	return s == StateConfirmed || s == StateFailed
+}
+
+func (s State) String() string {
+	switch s {
+	case StateRequested:
+		return "requested"
+	case StatePending:
+		return "pending"
+	case StateConfirmed:
+		return "confirmed"
+	case StateFailed:
+		return "failed"
+	}
+	return "unknown"
+}
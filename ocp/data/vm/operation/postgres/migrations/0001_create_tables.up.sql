@@ -0,0 +1,42 @@
+CREATE TABLE ocp__core_vmoperation (
+	id SERIAL NOT NULL PRIMARY KEY,
+
+	vm TEXT NOT NULL,
+
+	operation_type INTEGER NOT NULL,
+
+	address TEXT NOT NULL,
+
+	owner TEXT NULL,
+
+	name TEXT NULL,
+	stored_account_type INTEGER NOT NULL,
+	num_accounts BIGINT NOT NULL,
+	size BIGINT NOT NULL,
+	target_size BIGINT NOT NULL,
+
+	memory_account TEXT NULL,
+	memory_index INTEGER NOT NULL,
+	storage_account TEXT NULL,
+
+	signature TEXT NULL,
+	nonce TEXT NULL,
+	blockhash TEXT NULL,
+
+	transaction_blob BYTEA NULL,
+
+	state INTEGER NOT NULL,
+
+	version INTEGER NOT NULL,
+
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	CONSTRAINT ocp__core_vmoperation__uniq__signature UNIQUE (signature)
+);
+
+-- At most one operation can be in flight for an address at a time
+CREATE UNIQUE INDEX ocp__core_vmoperation__uniq__in_flight_address ON ocp__core_vmoperation (address) WHERE state IN (1, 2);
+
+CREATE INDEX ocp__core_vmoperation__idx__address ON ocp__core_vmoperation (address);
+CREATE INDEX ocp__core_vmoperation__idx__state ON ocp__core_vmoperation (state);
+CREATE INDEX ocp__core_vmoperation__idx__vm__operation_type__state ON ocp__core_vmoperation (vm, operation_type, state);
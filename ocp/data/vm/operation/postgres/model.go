@@ -0,0 +1,274 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	pgutil "github.com/code-payments/ocp-server/database/postgres"
+	q "github.com/code-payments/ocp-server/database/query"
+	"github.com/code-payments/ocp-server/ocp/data/vm/operation"
+	"github.com/code-payments/ocp-server/solana/vm"
+)
+
+const (
+	tableName = "ocp__core_vmoperation"
+
+	allColumns = `id, vm, operation_type, address, owner, name, stored_account_type, num_accounts, size, target_size, memory_account, memory_index, storage_account, signature, nonce, blockhash, transaction_blob, state, version, created_at`
+)
+
+type model struct {
+	Id sql.NullInt64 `db:"id"`
+
+	Vm string `db:"vm"`
+
+	OperationType uint8 `db:"operation_type"`
+
+	Address string `db:"address"`
+
+	Owner sql.NullString `db:"owner"`
+
+	Name              sql.NullString `db:"name"`
+	StoredAccountType uint8          `db:"stored_account_type"`
+	NumAccounts       uint32         `db:"num_accounts"`
+	Size              uint32         `db:"size"`
+	TargetSize        uint32         `db:"target_size"`
+
+	MemoryAccount  sql.NullString `db:"memory_account"`
+	MemoryIndex    uint16         `db:"memory_index"`
+	StorageAccount sql.NullString `db:"storage_account"`
+
+	Signature sql.NullString `db:"signature"`
+	Nonce     sql.NullString `db:"nonce"`
+	Blockhash sql.NullString `db:"blockhash"`
+
+	TransactionBlob []byte `db:"transaction_blob"`
+
+	State uint8 `db:"state"`
+
+	Version uint64 `db:"version"`
+
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func toModel(obj *operation.Record) (*model, error) {
+	if err := obj.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &model{
+		Id: sql.NullInt64{Int64: int64(obj.Id), Valid: obj.Id > 0},
+
+		Vm: obj.Vm,
+
+		OperationType: uint8(obj.Type),
+
+		Address: obj.Address,
+
+		Owner: toNullString(obj.Owner),
+
+		Name:              toNullString(obj.Name),
+		StoredAccountType: uint8(obj.StoredAccountType),
+		NumAccounts:       obj.NumAccounts,
+		Size:              obj.Size,
+		TargetSize:        obj.TargetSize,
+
+		MemoryAccount:  toNullString(obj.MemoryAccount),
+		MemoryIndex:    obj.MemoryIndex,
+		StorageAccount: toNullString(obj.StorageAccount),
+
+		Signature: toNullString(obj.Signature),
+		Nonce:     toNullString(obj.Nonce),
+		Blockhash: toNullString(obj.Blockhash),
+
+		TransactionBlob: obj.TransactionBlob,
+
+		State: uint8(obj.State),
+
+		Version: obj.Version,
+
+		CreatedAt: obj.CreatedAt.UTC(),
+	}, nil
+}
+
+func fromModel(obj *model) *operation.Record {
+	return &operation.Record{
+		Id: uint64(obj.Id.Int64),
+
+		Vm: obj.Vm,
+
+		Type: operation.Type(obj.OperationType),
+
+		Address: obj.Address,
+
+		Owner: obj.Owner.String,
+
+		Name:              obj.Name.String,
+		StoredAccountType: vm.VirtualAccountType(obj.StoredAccountType),
+		NumAccounts:       obj.NumAccounts,
+		Size:              obj.Size,
+		TargetSize:        obj.TargetSize,
+
+		MemoryAccount:  obj.MemoryAccount.String,
+		MemoryIndex:    obj.MemoryIndex,
+		StorageAccount: obj.StorageAccount.String,
+
+		Signature: obj.Signature.String,
+		Nonce:     obj.Nonce.String,
+		Blockhash: obj.Blockhash.String,
+
+		TransactionBlob: obj.TransactionBlob,
+
+		State: operation.State(obj.State),
+
+		Version: obj.Version,
+
+		CreatedAt: obj.CreatedAt,
+	}
+}
+
+func (m *model) dbPut(ctx context.Context, db *sqlx.DB) error {
+	return pgutil.ExecuteInTx(ctx, db, sql.LevelDefault, func(tx *sqlx.Tx) error {
+		query := `INSERT INTO ` + tableName + `
+			(vm, operation_type, address, owner, name, stored_account_type, num_accounts, size, target_size, memory_account, memory_index, storage_account, signature, nonce, blockhash, transaction_blob, state, version, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, 1, $18)
+			RETURNING ` + allColumns
+
+		if m.CreatedAt.IsZero() {
+			m.CreatedAt = time.Now().UTC()
+		}
+
+		err := tx.QueryRowxContext(
+			ctx,
+			query,
+			m.Vm,
+			m.OperationType,
+			m.Address,
+			m.Owner,
+			m.Name,
+			m.StoredAccountType,
+			m.NumAccounts,
+			m.Size,
+			m.TargetSize,
+			m.MemoryAccount,
+			m.MemoryIndex,
+			m.StorageAccount,
+			m.Signature,
+			m.Nonce,
+			m.Blockhash,
+			m.TransactionBlob,
+			m.State,
+			m.CreatedAt,
+		).StructScan(m)
+
+		return pgutil.CheckUniqueViolation(err, operation.ErrAlreadyExists)
+	})
+}
+
+func (m *model) dbUpdate(ctx context.Context, db *sqlx.DB) error {
+	return pgutil.ExecuteInTx(ctx, db, sql.LevelDefault, func(tx *sqlx.Tx) error {
+		query := `UPDATE ` + tableName + `
+			SET size = $3, memory_account = $4, memory_index = $5, storage_account = $6, signature = $7, nonce = $8, blockhash = $9, transaction_blob = $10, state = $11, version = version + 1
+			WHERE id = $1 AND version = $2
+			RETURNING ` + allColumns
+
+		err := tx.QueryRowxContext(
+			ctx,
+			query,
+			m.Id,
+			m.Version,
+			m.Size,
+			m.MemoryAccount,
+			m.MemoryIndex,
+			m.StorageAccount,
+			m.Signature,
+			m.Nonce,
+			m.Blockhash,
+			m.TransactionBlob,
+			m.State,
+		).StructScan(m)
+		if err == nil {
+			return nil
+		} else if err != sql.ErrNoRows {
+			return pgutil.CheckUniqueViolation(err, operation.ErrAlreadyExists)
+		}
+
+		// Distinguish between a missing record and a stale version
+		var count uint64
+		err = tx.GetContext(ctx, &count, `SELECT COUNT(*) FROM `+tableName+` WHERE id = $1`, m.Id)
+		if err != nil {
+			return err
+		} else if count == 0 {
+			return operation.ErrNotFound
+		}
+		return operation.ErrStaleVersion
+	})
+}
+
+func dbGetLatestByAddress(ctx context.Context, db *sqlx.DB, address string) (*model, error) {
+	res := &model{}
+
+	query := `SELECT ` + allColumns + `
+		FROM ` + tableName + `
+		WHERE address = $1
+		ORDER BY id DESC
+		LIMIT 1`
+
+	err := db.GetContext(ctx, res, query, address)
+	if err != nil {
+		return nil, pgutil.CheckNoRows(err, operation.ErrNotFound)
+	}
+	return res, nil
+}
+
+func dbGetAllByState(ctx context.Context, db *sqlx.DB, state operation.State, cursor q.Cursor, limit uint64, direction q.Ordering) ([]*model, error) {
+	res := []*model{}
+
+	query := `SELECT ` + allColumns + `
+		FROM ` + tableName + `
+		WHERE state = $1`
+
+	opts := []interface{}{state}
+	query, opts = q.PaginateQuery(query, opts, cursor, limit, direction)
+
+	err := db.SelectContext(ctx, &res, query, opts...)
+	if err != nil {
+		return nil, pgutil.CheckNoRows(err, operation.ErrNotFound)
+	}
+
+	if len(res) == 0 {
+		return nil, operation.ErrNotFound
+	}
+	return res, nil
+}
+
+func dbCountByState(ctx context.Context, db *sqlx.DB, state operation.State) (uint64, error) {
+	var res uint64
+
+	query := `SELECT COUNT(*) FROM ` + tableName + ` WHERE state = $1`
+
+	err := db.GetContext(ctx, &res, query, state)
+	if err != nil {
+		return 0, err
+	}
+	return res, nil
+}
+
+func dbCountByVmTypeAndState(ctx context.Context, db *sqlx.DB, vm string, opType operation.Type, state operation.State) (uint64, error) {
+	var res uint64
+
+	query := `SELECT COUNT(*) FROM ` + tableName + `
+		WHERE vm = $1 AND operation_type = $2 AND state = $3`
+
+	err := db.GetContext(ctx, &res, query, vm, opType, state)
+	if err != nil {
+		return 0, err
+	}
+	return res, nil
+}
+
+func toNullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: len(value) > 0}
+}
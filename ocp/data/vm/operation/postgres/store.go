@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/code-payments/ocp-server/database/query"
+	"github.com/code-payments/ocp-server/ocp/data/vm/operation"
+)
+
+type store struct {
+	db *sqlx.DB
+}
+
+// New returns a new postgres vm.operation.Store
+func New(db *sql.DB) operation.Store {
+	return &store{
+		db: sqlx.NewDb(db, "pgx"),
+	}
+}
+
+// Put implements vm.operation.Store.Put
+func (s *store) Put(ctx context.Context, record *operation.Record) error {
+	model, err := toModel(record)
+	if err != nil {
+		return err
+	}
+
+	err = model.dbPut(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	res := fromModel(model)
+	res.CopyTo(record)
+
+	return nil
+}
+
+// Update implements vm.operation.Store.Update
+func (s *store) Update(ctx context.Context, record *operation.Record) error {
+	model, err := toModel(record)
+	if err != nil {
+		return err
+	}
+
+	err = model.dbUpdate(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	res := fromModel(model)
+	res.CopyTo(record)
+
+	return nil
+}
+
+// GetLatestByAddress implements vm.operation.Store.GetLatestByAddress
+func (s *store) GetLatestByAddress(ctx context.Context, address string) (*operation.Record, error) {
+	model, err := dbGetLatestByAddress(ctx, s.db, address)
+	if err != nil {
+		return nil, err
+	}
+	return fromModel(model), nil
+}
+
+// GetAllByState implements vm.operation.Store.GetAllByState
+func (s *store) GetAllByState(ctx context.Context, state operation.State, cursor query.Cursor, limit uint64, direction query.Ordering) ([]*operation.Record, error) {
+	models, err := dbGetAllByState(ctx, s.db, state, cursor, limit, direction)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*operation.Record, len(models))
+	for i, model := range models {
+		res[i] = fromModel(model)
+	}
+	return res, nil
+}
+
+// CountByState implements vm.operation.Store.CountByState
+func (s *store) CountByState(ctx context.Context, state operation.State) (uint64, error) {
+	return dbCountByState(ctx, s.db, state)
+}
+
+// CountByVmTypeAndState implements vm.operation.Store.CountByVmTypeAndState
+func (s *store) CountByVmTypeAndState(ctx context.Context, vm string, opType operation.Type, state operation.State) (uint64, error) {
+	return dbCountByVmTypeAndState(ctx, s.db, vm, opType, state)
+}
@@ -0,0 +1,48 @@
+package operation
+
+import (
+	"context"
+	"errors"
+
+	"github.com/code-payments/ocp-server/database/query"
+)
+
+var (
+	ErrNotFound      = errors.New("vm operation not found")
+	ErrAlreadyExists = errors.New("vm operation already exists")
+	ErrStaleVersion  = errors.New("vm operation version is stale")
+)
+
+// Store tracks operations that manage the lifecycle of VM memory and storage
+// accounts
+type Store interface {
+	// Put creates a new operation
+	//
+	// Returns ErrAlreadyExists if an operation with the same signature exists,
+	// or if the address already has an in flight operation.
+	Put(ctx context.Context, record *Record) error
+
+	// Update updates the size, memory and storage locations, transaction and
+	// state of an existing operation
+	//
+	// Returns ErrNotFound if the operation doesn't exist, and ErrStaleVersion
+	// if the record was updated since it was last read.
+	Update(ctx context.Context, record *Record) error
+
+	// GetLatestByAddress gets the most recently created operation for an address
+	//
+	// Returns ErrNotFound if no record is found.
+	GetLatestByAddress(ctx context.Context, address string) (*Record, error)
+
+	// GetAllByState gets all operations in a state
+	//
+	// Returns ErrNotFound if no records are found.
+	GetAllByState(ctx context.Context, state State, cursor query.Cursor, limit uint64, direction query.Ordering) ([]*Record, error)
+
+	// CountByState returns the count of operations in a state
+	CountByState(ctx context.Context, state State) (uint64, error)
+
+	// CountByVmTypeAndState returns the count of operations of a type in a VM
+	// that are in a state
+	CountByVmTypeAndState(ctx context.Context, vm string, opType Type, state State) (uint64, error)
+}
@@ -0,0 +1,392 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/code-payments/ocp-server/database/query"
+	"github.com/code-payments/ocp-server/ocp/data/vm/operation"
+	"github.com/code-payments/ocp-server/solana/vm"
+)
+
+func RunTests(t *testing.T, s operation.Store, teardown func()) {
+	for _, tf := range []func(t *testing.T, s operation.Store){
+		testRoundTrip,
+		testUpdate,
+		testUniqueness,
+		testGetAllByState,
+		testCountByState,
+		testCountByVmTypeAndState,
+	} {
+		tf(t, s)
+		teardown()
+	}
+}
+
+func testRoundTrip(t *testing.T, s operation.Store) {
+	t.Run("testRoundTrip", func(t *testing.T) {
+		ctx := context.Background()
+
+		_, err := s.GetLatestByAddress(ctx, "memoryaccount1")
+		assert.Equal(t, operation.ErrNotFound, err)
+
+		for _, expected := range []*operation.Record{
+			newInitMemoryRecord("vm1", "memoryaccount1", "signature1"),
+			newInitStorageRecord("vm1", "storageaccount1", "signature2"),
+			newCompressRecord("vm1", "vault1", "signature3"),
+			newDecompressRequestRecord("vm1", "vault2"),
+		} {
+			cloned := expected.Clone()
+
+			require.NoError(t, s.Put(ctx, expected))
+			assert.True(t, expected.Id > 0)
+			assert.EqualValues(t, 1, expected.Version)
+			assert.False(t, expected.CreatedAt.IsZero())
+			assertEquivalentRecords(t, &cloned, expected)
+
+			actual, err := s.GetLatestByAddress(ctx, expected.Address)
+			require.NoError(t, err)
+			assert.Equal(t, expected.Id, actual.Id)
+			assert.Equal(t, expected.Version, actual.Version)
+			assert.Equal(t, expected.CreatedAt.Unix(), actual.CreatedAt.Unix())
+			assertEquivalentRecords(t, expected, actual)
+		}
+
+		compressed, err := s.GetLatestByAddress(ctx, "vault1")
+		require.NoError(t, err)
+		compressed.TransactionBlob = nil
+		compressed.State = operation.StateConfirmed
+		require.NoError(t, s.Update(ctx, compressed))
+
+		latest := newDecompressRequestRecord("vm1", "vault1")
+		require.NoError(t, s.Put(ctx, latest))
+
+		actual, err := s.GetLatestByAddress(ctx, "vault1")
+		require.NoError(t, err)
+		assert.Equal(t, latest.Id, actual.Id)
+		assertEquivalentRecords(t, latest, actual)
+	})
+}
+
+func testUpdate(t *testing.T, s operation.Store) {
+	t.Run("testUpdate", func(t *testing.T) {
+		ctx := context.Background()
+
+		record := newDecompressRequestRecord("vm1", "vault1")
+		record.Id = 1
+		record.Version = 1
+		assert.Equal(t, operation.ErrNotFound, s.Update(ctx, record))
+
+		record.Id = 0
+		require.NoError(t, s.Put(ctx, record))
+
+		stale := record.Clone()
+
+		record.MemoryAccount = "memoryaccount1"
+		record.MemoryIndex = 42
+		record.StorageAccount = "storageaccount1"
+		record.Signature = "signature1"
+		record.Nonce = "nonce1"
+		record.Blockhash = "blockhash1"
+		record.TransactionBlob = []byte("transaction1")
+		record.State = operation.StatePending
+		require.NoError(t, s.Update(ctx, record))
+		assert.EqualValues(t, 2, record.Version)
+
+		stale.State = operation.StateFailed
+		assert.Equal(t, operation.ErrStaleVersion, s.Update(ctx, &stale))
+
+		actual, err := s.GetLatestByAddress(ctx, "vault1")
+		require.NoError(t, err)
+		assertEquivalentRecords(t, record, actual)
+		assert.EqualValues(t, 2, actual.Version)
+
+		// Only the size, locations, transaction and state are updatable
+		record.Owner = "other"
+		record.TransactionBlob = nil
+		record.State = operation.StateConfirmed
+		require.NoError(t, s.Update(ctx, record))
+
+		actual, err = s.GetLatestByAddress(ctx, "vault1")
+		require.NoError(t, err)
+		assert.Equal(t, "owner_vault1", actual.Owner)
+		assert.Empty(t, actual.TransactionBlob)
+		assert.Equal(t, operation.StateConfirmed, actual.State)
+
+		memoryRecord := newInitMemoryRecord("vm1", "memoryaccount1", "signature2")
+		require.NoError(t, s.Put(ctx, memoryRecord))
+
+		memoryRecord.Size = memoryRecord.TargetSize
+		memoryRecord.Signature = "signature3"
+		memoryRecord.Nonce = "nonce3"
+		memoryRecord.Blockhash = "blockhash3"
+		memoryRecord.TransactionBlob = []byte("transaction3")
+		require.NoError(t, s.Update(ctx, memoryRecord))
+
+		actual, err = s.GetLatestByAddress(ctx, "memoryaccount1")
+		require.NoError(t, err)
+		assertEquivalentRecords(t, memoryRecord, actual)
+
+		memoryRecord.Signature = "signature1"
+		assert.Equal(t, operation.ErrAlreadyExists, s.Update(ctx, memoryRecord))
+	})
+}
+
+func testUniqueness(t *testing.T, s operation.Store) {
+	t.Run("testUniqueness", func(t *testing.T) {
+		ctx := context.Background()
+
+		record := newCompressRecord("vm1", "vault1", "signature1")
+		require.NoError(t, s.Put(ctx, record))
+
+		assert.Equal(t, operation.ErrAlreadyExists, s.Put(ctx, newCompressRecord("vm1", "vault2", "signature1")))
+		assert.Equal(t, operation.ErrAlreadyExists, s.Put(ctx, newCompressRecord("vm1", "vault1", "signature2")))
+		assert.Equal(t, operation.ErrAlreadyExists, s.Put(ctx, newDecompressRequestRecord("vm1", "vault1")))
+		require.NoError(t, s.Put(ctx, newCompressRecord("vm1", "vault2", "signature2")))
+
+		// A new operation can be created once the in flight one is finalized
+		record.TransactionBlob = nil
+		record.State = operation.StateFailed
+		require.NoError(t, s.Update(ctx, record))
+
+		require.NoError(t, s.Put(ctx, newDecompressRequestRecord("vm1", "vault1")))
+		assert.Equal(t, operation.ErrAlreadyExists, s.Put(ctx, newDecompressRequestRecord("vm1", "vault1")))
+	})
+}
+
+func testGetAllByState(t *testing.T, s operation.Store) {
+	t.Run("testGetAllByState", func(t *testing.T) {
+		ctx := context.Background()
+
+		_, err := s.GetAllByState(ctx, operation.StatePending, query.EmptyCursor, 10, query.Ascending)
+		assert.Equal(t, operation.ErrNotFound, err)
+
+		var expected []*operation.Record
+		for i := 0; i < 10; i++ {
+			record := newCompressRecord("vm1", fmt.Sprintf("vault%d", i), fmt.Sprintf("signature%d", i))
+			require.NoError(t, s.Put(ctx, record))
+
+			if i%3 == 0 {
+				record.TransactionBlob = nil
+				record.State = operation.StateConfirmed
+				require.NoError(t, s.Update(ctx, record))
+				continue
+			}
+
+			expected = append(expected, record)
+		}
+
+		require.NoError(t, s.Put(ctx, newDecompressRequestRecord("vm1", "vault10")))
+
+		actual, err := s.GetAllByState(ctx, operation.StatePending, query.EmptyCursor, 10, query.Ascending)
+		require.NoError(t, err)
+		require.Len(t, actual, len(expected))
+		for i := range expected {
+			assertEquivalentRecords(t, expected[i], actual[i])
+		}
+
+		actual, err = s.GetAllByState(ctx, operation.StatePending, query.EmptyCursor, 10, query.Descending)
+		require.NoError(t, err)
+		require.Len(t, actual, len(expected))
+		for i := range expected {
+			assertEquivalentRecords(t, expected[len(expected)-1-i], actual[i])
+		}
+
+		actual, err = s.GetAllByState(ctx, operation.StatePending, query.ToCursor(expected[1].Id), 2, query.Ascending)
+		require.NoError(t, err)
+		require.Len(t, actual, 2)
+		assertEquivalentRecords(t, expected[2], actual[0])
+		assertEquivalentRecords(t, expected[3], actual[1])
+
+		actual, err = s.GetAllByState(ctx, operation.StateRequested, query.EmptyCursor, 10, query.Ascending)
+		require.NoError(t, err)
+		require.Len(t, actual, 1)
+		assert.Equal(t, "vault10", actual[0].Address)
+
+		_, err = s.GetAllByState(ctx, operation.StateFailed, query.EmptyCursor, 10, query.Ascending)
+		assert.Equal(t, operation.ErrNotFound, err)
+	})
+}
+
+func testCountByState(t *testing.T, s operation.Store) {
+	t.Run("testCountByState", func(t *testing.T) {
+		ctx := context.Background()
+
+		count, err := s.CountByState(ctx, operation.StatePending)
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, count)
+
+		for i := 0; i < 5; i++ {
+			record := newCompressRecord("vm1", fmt.Sprintf("vault%d", i), fmt.Sprintf("signature%d", i))
+			require.NoError(t, s.Put(ctx, record))
+
+			if i < 2 {
+				record.TransactionBlob = nil
+				record.State = operation.StateFailed
+				require.NoError(t, s.Update(ctx, record))
+			}
+		}
+		require.NoError(t, s.Put(ctx, newInitStorageRecord("vm2", "storageaccount1", "signature5")))
+		require.NoError(t, s.Put(ctx, newDecompressRequestRecord("vm1", "vault5")))
+
+		count, err = s.CountByState(ctx, operation.StatePending)
+		require.NoError(t, err)
+		assert.EqualValues(t, 4, count)
+
+		count, err = s.CountByState(ctx, operation.StateFailed)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, count)
+
+		count, err = s.CountByState(ctx, operation.StateRequested)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, count)
+
+		count, err = s.CountByState(ctx, operation.StateConfirmed)
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, count)
+	})
+}
+
+func testCountByVmTypeAndState(t *testing.T, s operation.Store) {
+	t.Run("testCountByVmTypeAndState", func(t *testing.T) {
+		ctx := context.Background()
+
+		count, err := s.CountByVmTypeAndState(ctx, "vm1", operation.TypeInitMemory, operation.StatePending)
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, count)
+
+		for i := 0; i < 5; i++ {
+			record := newInitMemoryRecord("vm1", fmt.Sprintf("memoryaccount%d", i), fmt.Sprintf("signature%d", i))
+			require.NoError(t, s.Put(ctx, record))
+
+			if i < 2 {
+				record.Size = record.TargetSize
+				record.TransactionBlob = nil
+				record.State = operation.StateConfirmed
+				require.NoError(t, s.Update(ctx, record))
+			}
+		}
+		require.NoError(t, s.Put(ctx, newInitMemoryRecord("vm2", "memoryaccount5", "signature5")))
+		require.NoError(t, s.Put(ctx, newInitStorageRecord("vm1", "storageaccount1", "signature6")))
+
+		count, err = s.CountByVmTypeAndState(ctx, "vm1", operation.TypeInitMemory, operation.StatePending)
+		require.NoError(t, err)
+		assert.EqualValues(t, 3, count)
+
+		count, err = s.CountByVmTypeAndState(ctx, "vm1", operation.TypeInitMemory, operation.StateConfirmed)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, count)
+
+		count, err = s.CountByVmTypeAndState(ctx, "vm2", operation.TypeInitMemory, operation.StatePending)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, count)
+
+		count, err = s.CountByVmTypeAndState(ctx, "vm1", operation.TypeInitStorage, operation.StatePending)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, count)
+
+		count, err = s.CountByVmTypeAndState(ctx, "vm1", operation.TypeCompress, operation.StatePending)
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, count)
+	})
+}
+
+func newInitMemoryRecord(vmAddress, address, signature string) *operation.Record {
+	return &operation.Record{
+		Vm:   vmAddress,
+		Type: operation.TypeInitMemory,
+
+		Address: address,
+
+		Name:              "name_" + address,
+		StoredAccountType: vm.VirtualAccountTypeTimelock,
+		NumAccounts:       32_000,
+		Size:              10_240,
+		TargetSize:        2_496_078,
+
+		Signature: signature,
+		Nonce:     "nonce_" + signature,
+		Blockhash: "blockhash_" + signature,
+
+		TransactionBlob: []byte("transaction_" + signature),
+
+		State: operation.StatePending,
+	}
+}
+
+func newInitStorageRecord(vmAddress, address, signature string) *operation.Record {
+	return &operation.Record{
+		Vm:   vmAddress,
+		Type: operation.TypeInitStorage,
+
+		Address: address,
+
+		Name: "name_" + address,
+
+		Signature: signature,
+		Nonce:     "nonce_" + signature,
+		Blockhash: "blockhash_" + signature,
+
+		TransactionBlob: []byte("transaction_" + signature),
+
+		State: operation.StatePending,
+	}
+}
+
+func newCompressRecord(vmAddress, address, signature string) *operation.Record {
+	return &operation.Record{
+		Vm:   vmAddress,
+		Type: operation.TypeCompress,
+
+		Address: address,
+		Owner:   "owner_" + address,
+
+		MemoryAccount:  "memory_" + address,
+		MemoryIndex:    123,
+		StorageAccount: "storage_" + address,
+
+		Signature: signature,
+		Nonce:     "nonce_" + signature,
+		Blockhash: "blockhash_" + signature,
+
+		TransactionBlob: []byte("transaction_" + signature),
+
+		State: operation.StatePending,
+	}
+}
+
+func newDecompressRequestRecord(vmAddress, address string) *operation.Record {
+	return &operation.Record{
+		Vm:   vmAddress,
+		Type: operation.TypeDecompress,
+
+		Address: address,
+		Owner:   "owner_" + address,
+
+		State: operation.StateRequested,
+	}
+}
+
+func assertEquivalentRecords(t *testing.T, obj1, obj2 *operation.Record) {
+	assert.Equal(t, obj1.Vm, obj2.Vm)
+	assert.Equal(t, obj1.Type, obj2.Type)
+	assert.Equal(t, obj1.Address, obj2.Address)
+	assert.Equal(t, obj1.Owner, obj2.Owner)
+	assert.Equal(t, obj1.Name, obj2.Name)
+	assert.Equal(t, obj1.StoredAccountType, obj2.StoredAccountType)
+	assert.Equal(t, obj1.NumAccounts, obj2.NumAccounts)
+	assert.Equal(t, obj1.Size, obj2.Size)
+	assert.Equal(t, obj1.TargetSize, obj2.TargetSize)
+	assert.Equal(t, obj1.MemoryAccount, obj2.MemoryAccount)
+	assert.Equal(t, obj1.MemoryIndex, obj2.MemoryIndex)
+	assert.Equal(t, obj1.StorageAccount, obj2.StorageAccount)
+	assert.Equal(t, obj1.Signature, obj2.Signature)
+	assert.Equal(t, obj1.Nonce, obj2.Nonce)
+	assert.Equal(t, obj1.Blockhash, obj2.Blockhash)
+	assert.Equal(t, obj1.TransactionBlob, obj2.TransactionBlob)
+	assert.Equal(t, obj1.State, obj2.State)
+}
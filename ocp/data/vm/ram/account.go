@@ -93,3 +93,35 @@ func (r *Record) CopyTo(dst *Record) {
 
 	dst.CreatedAt = r.CreatedAt
 }
+
+// Allocation is a piece of memory in a memory account that's reserved for a
+// virtual account
+type Allocation struct {
+	Id uint64
+
+	Vm string
+
+	MemoryAccount string
+	Index         uint16
+
+	StoredAccountType vm.VirtualAccountType
+	Address           string
+
+	LastUpdatedAt time.Time
+}
+
+func (a *Allocation) Clone() Allocation {
+	return Allocation{
+		Id: a.Id,
+
+		Vm: a.Vm,
+
+		MemoryAccount: a.MemoryAccount,
+		Index:         a.Index,
+
+		StoredAccountType: a.StoredAccountType,
+		Address:           a.Address,
+
+		LastUpdatedAt: a.LastUpdatedAt,
+	}
+}
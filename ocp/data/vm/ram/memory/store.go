@@ -2,28 +2,25 @@ package memory
 
 import (
 	"context"
-	"fmt"
 	"sync"
 	"time"
 
+	"github.com/code-payments/ocp-server/database/query"
 	"github.com/code-payments/ocp-server/ocp/data/vm/ram"
 	"github.com/code-payments/ocp-server/solana/vm"
 )
 
 type store struct {
-	mu                     sync.Mutex
-	last                   uint64
-	records                []*ram.Record
-	reservedAccountIndices map[string]string
-	storedVirtualAccounts  map[string]string
+	mu       sync.Mutex
+	last     uint64
+	lastSlot uint64
+	records  []*ram.Record
+	slots    []*ram.Allocation // Unallocated memory has an empty address
 }
 
 // New returns a new in memory vm.ram.Store
 func New() ram.Store {
-	return &store{
-		reservedAccountIndices: make(map[string]string),
-		storedVirtualAccounts:  make(map[string]string),
-	}
+	return &store{}
 }
 
 // InitializeMemory implements vm.ram.Store.InitializeMemory
@@ -48,6 +45,23 @@ func (s *store) InitializeMemory(_ context.Context, record *ram.Record) error {
 	cloned := record.Clone()
 	s.records = append(s.records, &cloned)
 
+	actualCapacity := ram.GetActualCapcity(record)
+	for i := 0; i < int(actualCapacity); i++ {
+		s.lastSlot++
+		s.slots = append(s.slots, &ram.Allocation{
+			Id: s.lastSlot,
+
+			Vm: record.Vm,
+
+			MemoryAccount: record.Address,
+			Index:         uint16(i),
+
+			StoredAccountType: record.StoredAccountType,
+
+			LastUpdatedAt: record.CreatedAt,
+		})
+	}
+
 	return nil
 }
 
@@ -56,16 +70,15 @@ func (s *store) FreeMemoryByIndex(_ context.Context, memoryAccount string, index
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	reservationKey := getAccountIndexKey(memoryAccount, index)
-	address, ok := s.reservedAccountIndices[reservationKey]
-	if !ok {
-		return ram.ErrNotReserved
+	for _, slot := range s.slots {
+		if slot.MemoryAccount == memoryAccount && slot.Index == index && len(slot.Address) > 0 {
+			slot.Address = ""
+			slot.LastUpdatedAt = time.Now()
+			return nil
+		}
 	}
 
-	delete(s.reservedAccountIndices, reservationKey)
-	delete(s.storedVirtualAccounts, address)
-
-	return nil
+	return ram.ErrNotReserved
 }
 
 // FreeMemoryByAddress implements vm.ram.Store.FreeMemoryByAddress
@@ -73,13 +86,13 @@ func (s *store) FreeMemoryByAddress(_ context.Context, address string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	reservationKey, ok := s.storedVirtualAccounts[address]
-	if !ok {
+	slot := s.findSlotByAddress(address)
+	if slot == nil {
 		return ram.ErrNotReserved
 	}
 
-	delete(s.reservedAccountIndices, reservationKey)
-	delete(s.storedVirtualAccounts, address)
+	slot.Address = ""
+	slot.LastUpdatedAt = time.Now()
 
 	return nil
 }
@@ -89,27 +102,83 @@ func (s *store) ReserveMemory(_ context.Context, vm string, accountType vm.Virtu
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if _, ok := s.storedVirtualAccounts[address]; ok {
+	if slot := s.findSlotByAddress(address); slot != nil {
 		return "", 0, ram.ErrAddressAlreadyReserved
 	}
 
-	items := s.findByVmAndAccountType(vm, accountType)
-	for _, item := range items {
-		actualCapacity := ram.GetActualCapcity(item)
-		for i := 0; i < int(actualCapacity); i++ {
-			reservationKey := getAccountIndexKey(item.Address, uint16(i))
+	for _, slot := range s.slots {
+		if slot.Vm != vm || slot.StoredAccountType != accountType || len(slot.Address) > 0 {
+			continue
+		}
+
+		slot.Address = address
+		slot.LastUpdatedAt = time.Now()
+		return slot.MemoryAccount, slot.Index, nil
+	}
+
+	return "", 0, ram.ErrNoFreeMemory
+}
 
-			if _, ok := s.reservedAccountIndices[reservationKey]; ok {
-				continue
-			}
+// CountMemoryAccounts implements vm.ram.Store.CountMemoryAccounts
+func (s *store) CountMemoryAccounts(_ context.Context, vm string, accountType vm.VirtualAccountType) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-			s.reservedAccountIndices[reservationKey] = address
-			s.storedVirtualAccounts[address] = reservationKey
-			return item.Address, uint16(i), nil
+	var count uint64
+	for _, item := range s.records {
+		if item.Vm == vm && item.StoredAccountType == accountType {
+			count++
 		}
 	}
+	return count, nil
+}
 
-	return "", 0, ram.ErrNoFreeMemory
+// GetFreeCapacity implements vm.ram.Store.GetFreeCapacity
+func (s *store) GetFreeCapacity(_ context.Context, vm string, accountType vm.VirtualAccountType) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var count uint64
+	for _, slot := range s.slots {
+		if slot.Vm == vm && slot.StoredAccountType == accountType && len(slot.Address) == 0 {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// GetAllAllocations implements vm.ram.Store.GetAllAllocations
+func (s *store) GetAllAllocations(_ context.Context, vm string, accountType vm.VirtualAccountType, cursor query.Cursor, limit uint64) ([]*ram.Allocation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var start uint64
+	if len(cursor) > 0 {
+		start = cursor.ToUint64()
+	}
+
+	var res []*ram.Allocation
+	for _, slot := range s.slots {
+		if slot.Vm != vm || slot.StoredAccountType != accountType || len(slot.Address) == 0 {
+			continue
+		}
+
+		if slot.Id <= start {
+			continue
+		}
+
+		cloned := slot.Clone()
+		res = append(res, &cloned)
+
+		if limit > 0 && uint64(len(res)) >= limit {
+			break
+		}
+	}
+
+	if len(res) == 0 {
+		return nil, ram.ErrNoAllocations
+	}
+	return res, nil
 }
 
 func (s *store) find(data *ram.Record) *ram.Record {
@@ -126,31 +195,24 @@ func (s *store) find(data *ram.Record) *ram.Record {
 	return nil
 }
 
-func (s *store) findByVmAndAccountType(vm string, accountType vm.VirtualAccountType) []*ram.Record {
-	var res []*ram.Record
-	for _, item := range s.records {
-		if item.Vm != vm {
-			continue
-		}
+func (s *store) findSlotByAddress(address string) *ram.Allocation {
+	if len(address) == 0 {
+		return nil
+	}
 
-		if item.StoredAccountType != accountType {
-			continue
+	for _, slot := range s.slots {
+		if slot.Address == address {
+			return slot
 		}
-
-		res = append(res, item)
 	}
-	return res
+	return nil
 }
 
 func (s *store) reset() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.last = 0
+	s.lastSlot = 0
 	s.records = nil
-	s.reservedAccountIndices = make(map[string]string)
-	s.storedVirtualAccounts = make(map[string]string)
-}
-
-func getAccountIndexKey(memoryAccount string, index uint16) string {
-	return fmt.Sprintf("%s:%d", memoryAccount, index)
+	s.slots = nil
 }
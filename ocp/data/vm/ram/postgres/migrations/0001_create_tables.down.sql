@@ -0,0 +1,2 @@
+DROP TABLE ocp__core_vmmemoryaccount;
+DROP TABLE ocp__core_vmmemoryallocatedmemory;
@@ -0,0 +1,35 @@
+CREATE TABLE ocp__core_vmmemoryaccount (
+	id SERIAL NOT NULL PRIMARY KEY,
+
+	vm TEXT NOT NULL,
+
+	address TEXT NOT NULL,
+
+	capacity INTEGER NOT NULL,
+	num_sectors INTEGER NOT NULL,
+	num_pages INTEGER NOT NULL,
+	page_size INTEGER NOT NULL,
+
+	stored_account_type INTEGER NOT NULL,
+
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	CONSTRAINT ocp__core_vmmemoryaccount__uniq__address UNIQUE (address)
+);
+
+CREATE TABLE ocp__core_vmmemoryallocatedmemory (
+	id SERIAL NOT NULL PRIMARY KEY,
+
+	vm TEXT NOT NULL,
+
+	memory_account TEXT NOT NULL,
+	index INTEGER NOT NULL,
+	is_allocated BOOL NOT NULL,
+	stored_account_type INTEGER NOT NULL,
+	address TEXT NULL,
+
+	last_updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	CONSTRAINT ocp__core_vmmemoryallocatedmemory__uniq__memory_account__and__index UNIQUE (memory_account, index),
+	CONSTRAINT ocp__core_vmmemoryallocatedmemory__uniq__address UNIQUE (address)
+);
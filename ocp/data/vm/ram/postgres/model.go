@@ -8,6 +8,7 @@ import (
 	"github.com/jmoiron/sqlx"
 
 	pgutil "github.com/code-payments/ocp-server/database/postgres"
+	q "github.com/code-payments/ocp-server/database/query"
 	"github.com/code-payments/ocp-server/ocp/data/vm/ram"
 	"github.com/code-payments/ocp-server/solana/vm"
 )
@@ -88,6 +89,22 @@ func fromAccountModel(obj *accountModel) *ram.Record {
 	}
 }
 
+func fromAllocatedMemoryModel(obj *allocatedMemoryModel) *ram.Allocation {
+	return &ram.Allocation{
+		Id: uint64(obj.Id.Int64),
+
+		Vm: obj.Vm,
+
+		MemoryAccount: obj.MemoryAccount,
+		Index:         obj.Index,
+
+		StoredAccountType: vm.VirtualAccountType(obj.StoredAccountType),
+		Address:           obj.Address.String,
+
+		LastUpdatedAt: obj.LastUpdatedAt,
+	}
+}
+
 func (m *accountModel) dbInitialize(ctx context.Context, db *sqlx.DB) error {
 	return pgutil.ExecuteInTx(ctx, db, sql.LevelDefault, func(tx *sqlx.Tx) error {
 		query1 := `INSERT INTO ` + accountTableName + `
@@ -188,7 +205,7 @@ func dbReserveMemory(ctx context.Context, db *sqlx.DB, vm string, accountType vm
 				SELECT id FROM ` + allocatedMemoryTableName + `
 				WHERE vm = $1 AND stored_account_type = $2 AND NOT is_allocated
 				LIMIT 1
-				FOR UPDATE
+				FOR UPDATE SKIP LOCKED
 			)
 			RETURNING id, vm, memory_account, index, is_allocated, stored_account_type, address, last_updated_at`
 
@@ -211,3 +228,50 @@ func dbReserveMemory(ctx context.Context, db *sqlx.DB, vm string, accountType vm
 	})
 	return memoryAccount, index, err
 }
+
+func dbCountMemoryAccounts(ctx context.Context, db *sqlx.DB, vm string, accountType vm.VirtualAccountType) (uint64, error) {
+	var res uint64
+
+	query := `SELECT COUNT(*) FROM ` + accountTableName + `
+		WHERE vm = $1 AND stored_account_type = $2`
+
+	err := db.GetContext(ctx, &res, query, vm, accountType)
+	if err != nil {
+		return 0, err
+	}
+	return res, nil
+}
+
+func dbGetFreeCapacity(ctx context.Context, db *sqlx.DB, vm string, accountType vm.VirtualAccountType) (uint64, error) {
+	var res uint64
+
+	query := `SELECT COUNT(*) FROM ` + allocatedMemoryTableName + `
+		WHERE vm = $1 AND stored_account_type = $2 AND NOT is_allocated`
+
+	err := db.GetContext(ctx, &res, query, vm, accountType)
+	if err != nil {
+		return 0, err
+	}
+	return res, nil
+}
+
+func dbGetAllAllocations(ctx context.Context, db *sqlx.DB, vm string, accountType vm.VirtualAccountType, cursor q.Cursor, limit uint64) ([]*allocatedMemoryModel, error) {
+	res := []*allocatedMemoryModel{}
+
+	query := `SELECT id, vm, memory_account, index, is_allocated, stored_account_type, address, last_updated_at
+		FROM ` + allocatedMemoryTableName + `
+		WHERE vm = $1 AND stored_account_type = $2 AND is_allocated`
+
+	opts := []interface{}{vm, accountType}
+	query, opts = q.PaginateQuery(query, opts, cursor, limit, q.Ascending)
+
+	err := db.SelectContext(ctx, &res, query, opts...)
+	if err != nil {
+		return nil, pgutil.CheckNoRows(err, ram.ErrNoAllocations)
+	}
+
+	if len(res) == 0 {
+		return nil, ram.ErrNoAllocations
+	}
+	return res, nil
+}
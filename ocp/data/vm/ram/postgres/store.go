@@ -6,6 +6,7 @@ import (
 
 	"github.com/jmoiron/sqlx"
 
+	"github.com/code-payments/ocp-server/database/query"
 	"github.com/code-payments/ocp-server/ocp/data/vm/ram"
 	"github.com/code-payments/ocp-server/solana/vm"
 )
@@ -53,3 +54,27 @@ func (s *store) FreeMemoryByAddress(ctx context.Context, address string) error {
 func (s *store) ReserveMemory(ctx context.Context, vm string, accountType vm.VirtualAccountType, address string) (string, uint16, error) {
 	return dbReserveMemory(ctx, s.db, vm, accountType, address)
 }
+
+// CountMemoryAccounts implements vm.ram.Store.CountMemoryAccounts
+func (s *store) CountMemoryAccounts(ctx context.Context, vm string, accountType vm.VirtualAccountType) (uint64, error) {
+	return dbCountMemoryAccounts(ctx, s.db, vm, accountType)
+}
+
+// GetFreeCapacity implements vm.ram.Store.GetFreeCapacity
+func (s *store) GetFreeCapacity(ctx context.Context, vm string, accountType vm.VirtualAccountType) (uint64, error) {
+	return dbGetFreeCapacity(ctx, s.db, vm, accountType)
+}
+
+// GetAllAllocations implements vm.ram.Store.GetAllAllocations
+func (s *store) GetAllAllocations(ctx context.Context, vm string, accountType vm.VirtualAccountType, cursor query.Cursor, limit uint64) ([]*ram.Allocation, error) {
+	models, err := dbGetAllAllocations(ctx, s.db, vm, accountType, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*ram.Allocation, len(models))
+	for i, model := range models {
+		res[i] = fromAllocatedMemoryModel(model)
+	}
+	return res, nil
+}
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"os"
 	"testing"
@@ -11,6 +12,7 @@ import (
 	"github.com/code-payments/ocp-server/ocp/data/vm/ram"
 	"github.com/code-payments/ocp-server/ocp/data/vm/ram/tests"
 
+	"github.com/code-payments/ocp-server/database/postgres/migration"
 	postgrestest "github.com/code-payments/ocp-server/database/postgres/test"
 
 	_ "github.com/jackc/pgx/v4/stdlib"
@@ -21,53 +23,6 @@ var (
 	teardown  func()
 )
 
-const (
-	// Used for testing ONLY, the table and migrations are external to this repository
-	tableCreate = `
-		CREATE TABLE ocp__core_vmmemoryaccount (
-			id SERIAL NOT NULL PRIMARY KEY,
-
-			vm TEXT NOT NULL,
-
-			address TEXT NOT NULL,
-
-			capacity INTEGER NOT NULL,
-			num_sectors INTEGER NOT NULL,
-			num_pages INTEGER NOT NULL,
-			page_size INTEGER NOT NULL,
-
-			stored_account_type INTEGER NOT NULL,
-
-			created_at TIMESTAMP WITH TIME ZONE NOT NULL,
-
-			CONSTRAINT ocp__core_vmmemoryaccount__uniq__address UNIQUE (address)
-		);
-
-		CREATE TABLE ocp__core_vmmemoryallocatedmemory (
-			id SERIAL NOT NULL PRIMARY KEY,
-
-			vm TEXT NOT NULL,
-
-			memory_account TEXT NOT NULL,
-			index INTEGER NOT NULL,
-			is_allocated BOOL NOT NULL,
-			stored_account_type INTEGER NOT NULL,
-			address TEXT NULL,
-
-			last_updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
-
-			CONSTRAINT ocp__core_vmmemoryallocatedmemory__uniq__memory_account__and__index UNIQUE (memory_account, index),
-			CONSTRAINT ocp__core_vmmemoryallocatedmemory__uniq__address UNIQUE (address)
-		);
-	`
-
-	// Used for testing ONLY, the table and migrations are external to this repository
-	tableDestroy = `
-		DROP TABLE ocp__core_vmmemoryaccount;
-		DROP TABLE ocp__core_vmmemoryallocatedmemory;
-	`
-)
-
 func TestMain(m *testing.M) {
 	log := zap.Must(zap.NewDevelopment())
 
@@ -115,7 +70,13 @@ func TestVmRamPostgresStore(t *testing.T) {
 }
 
 func createTestTables(log *zap.Logger, db *sql.DB) error {
-	_, err := db.Exec(tableCreate)
+	migrations, err := Migrations()
+	if err != nil {
+		log.With(zap.Error(err)).Error("could not load migrations")
+		return err
+	}
+
+	err = migration.Up(context.Background(), db, migrations)
 	if err != nil {
 		log.With(zap.Error(err)).Error("could not create test tables")
 		return err
@@ -124,7 +85,13 @@ func createTestTables(log *zap.Logger, db *sql.DB) error {
 }
 
 func resetTestTables(log *zap.Logger, db *sql.DB) error {
-	_, err := db.Exec(tableDestroy)
+	migrations, err := Migrations()
+	if err != nil {
+		log.With(zap.Error(err)).Error("could not load migrations")
+		return err
+	}
+
+	err = migration.Down(context.Background(), db, migrations)
 	if err != nil {
 		log.With(zap.Error(err)).Error("could not drop test tables")
 		return err
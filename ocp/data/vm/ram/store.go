@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/code-payments/ocp-server/database/query"
 	"github.com/code-payments/ocp-server/solana/vm"
 )
 
@@ -12,6 +13,7 @@ var (
 	ErrNoFreeMemory           = errors.New("no available free memory")
 	ErrNotReserved            = errors.New("memory is not reserved")
 	ErrAddressAlreadyReserved = errors.New("virtual account address already in memory")
+	ErrNoAllocations          = errors.New("no memory allocations found")
 )
 
 // Store implements a basic construct for managing RAM memory. For simplicity,
@@ -19,7 +21,8 @@ var (
 // which eliminates any complexities with parallel transaction execution resulting
 // in allocation errors due to free pages across sectors.
 //
-// Note: A lock outside this implementation is required to resolve any races.
+// Implementations must be safe for concurrent use across processes, such that
+// multiple nodes can reserve memory without any external locking.
 type Store interface {
 	// Initializes a memory account for management
 	InitializeMemory(ctx context.Context, record *Record) error
@@ -32,4 +35,16 @@ type Store interface {
 
 	// ReserveMemory reserves a piece of memory in a VM for the virtual account address
 	ReserveMemory(ctx context.Context, vm string, accountType vm.VirtualAccountType, address string) (string, uint16, error)
+
+	// CountMemoryAccounts counts the number of memory accounts being managed in a VM
+	// for the stored account type
+	CountMemoryAccounts(ctx context.Context, vm string, accountType vm.VirtualAccountType) (uint64, error)
+
+	// GetFreeCapacity gets the number of pieces of free memory across all memory
+	// accounts in a VM for the stored account type
+	GetFreeCapacity(ctx context.Context, vm string, accountType vm.VirtualAccountType) (uint64, error)
+
+	// GetAllAllocations gets reserved pieces of memory in a VM for the stored account
+	// type in ascending order by ID
+	GetAllAllocations(ctx context.Context, vm string, accountType vm.VirtualAccountType, cursor query.Cursor, limit uint64) ([]*Allocation, error)
 }
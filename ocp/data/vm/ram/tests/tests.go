@@ -3,11 +3,13 @@ package tests
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/code-payments/ocp-server/database/query"
 	"github.com/code-payments/ocp-server/ocp/data/vm/ram"
 	"github.com/code-payments/ocp-server/solana/vm"
 )
@@ -15,6 +17,8 @@ import (
 func RunTests(t *testing.T, s ram.Store, teardown func()) {
 	for _, tf := range []func(t *testing.T, s ram.Store){
 		testHappyPath,
+		testConcurrentReservations,
+		testCapacityAndAllocations,
 	} {
 		tf(t, s)
 		teardown()
@@ -109,3 +113,167 @@ func testHappyPath(t *testing.T, s ram.Store) {
 		assert.Equal(t, ram.ErrNoFreeMemory, err)
 	})
 }
+
+func testConcurrentReservations(t *testing.T, s ram.Store) {
+	t.Run("testConcurrentReservations", func(t *testing.T) {
+		ctx := context.Background()
+
+		record := &ram.Record{
+			Vm:                "vm1",
+			Address:           "memoryaccount1",
+			Capacity:          1000,
+			NumSectors:        2,
+			NumPages:          50,
+			PageSize:          uint8(vm.GetVirtualAccountSizeInMemory(vm.VirtualAccountTypeTimelock)),
+			StoredAccountType: vm.VirtualAccountTypeTimelock,
+		}
+		require.NoError(t, s.InitializeMemory(ctx, record))
+
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		reservedIndices := make(map[uint16]struct{})
+		var noFreeMemoryCount int
+		for i := 0; i < 150; i++ {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+
+				memoryAccount, index, err := s.ReserveMemory(ctx, "vm1", vm.VirtualAccountTypeTimelock, fmt.Sprintf("virtualaccount%d", i))
+
+				mu.Lock()
+				defer mu.Unlock()
+
+				if err == ram.ErrNoFreeMemory {
+					noFreeMemoryCount++
+					return
+				}
+
+				require.NoError(t, err)
+				assert.Equal(t, "memoryaccount1", memoryAccount)
+
+				_, ok := reservedIndices[index]
+				assert.False(t, ok)
+				reservedIndices[index] = struct{}{}
+			}(i)
+		}
+		wg.Wait()
+
+		assert.Len(t, reservedIndices, 100)
+		assert.Equal(t, 50, noFreeMemoryCount)
+	})
+}
+
+func testCapacityAndAllocations(t *testing.T, s ram.Store) {
+	t.Run("testCapacityAndAllocations", func(t *testing.T) {
+		ctx := context.Background()
+
+		count, err := s.CountMemoryAccounts(ctx, "vm1", vm.VirtualAccountTypeTimelock)
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, count)
+
+		freeCapacity, err := s.GetFreeCapacity(ctx, "vm1", vm.VirtualAccountTypeTimelock)
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, freeCapacity)
+
+		_, err = s.GetAllAllocations(ctx, "vm1", vm.VirtualAccountTypeTimelock, nil, 10)
+		assert.Equal(t, ram.ErrNoAllocations, err)
+
+		for i := 0; i < 2; i++ {
+			record := &ram.Record{
+				Vm:                "vm1",
+				Address:           fmt.Sprintf("memoryaccount%d", i),
+				Capacity:          10,
+				NumSectors:        1,
+				NumPages:          10,
+				PageSize:          uint8(vm.GetVirtualAccountSizeInMemory(vm.VirtualAccountTypeTimelock)),
+				StoredAccountType: vm.VirtualAccountTypeTimelock,
+			}
+			require.NoError(t, s.InitializeMemory(ctx, record))
+		}
+
+		record := &ram.Record{
+			Vm:                "vm1",
+			Address:           "noncememoryaccount",
+			Capacity:          10,
+			NumSectors:        1,
+			NumPages:          10,
+			PageSize:          uint8(vm.GetVirtualAccountSizeInMemory(vm.VirtualAccountTypeDurableNonce)),
+			StoredAccountType: vm.VirtualAccountTypeDurableNonce,
+		}
+		require.NoError(t, s.InitializeMemory(ctx, record))
+
+		count, err = s.CountMemoryAccounts(ctx, "vm1", vm.VirtualAccountTypeTimelock)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, count)
+
+		count, err = s.CountMemoryAccounts(ctx, "vm1", vm.VirtualAccountTypeDurableNonce)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, count)
+
+		count, err = s.CountMemoryAccounts(ctx, "vm2", vm.VirtualAccountTypeTimelock)
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, count)
+
+		freeCapacity, err = s.GetFreeCapacity(ctx, "vm1", vm.VirtualAccountTypeTimelock)
+		require.NoError(t, err)
+		assert.EqualValues(t, 20, freeCapacity)
+
+		reserved := make(map[string]struct{})
+		for i := 0; i < 15; i++ {
+			address := fmt.Sprintf("virtualaccount%d", i)
+			_, _, err := s.ReserveMemory(ctx, "vm1", vm.VirtualAccountTypeTimelock, address)
+			require.NoError(t, err)
+			reserved[address] = struct{}{}
+		}
+
+		freeCapacity, err = s.GetFreeCapacity(ctx, "vm1", vm.VirtualAccountTypeTimelock)
+		require.NoError(t, err)
+		assert.EqualValues(t, 5, freeCapacity)
+
+		freeCapacity, err = s.GetFreeCapacity(ctx, "vm1", vm.VirtualAccountTypeDurableNonce)
+		require.NoError(t, err)
+		assert.EqualValues(t, 10, freeCapacity)
+
+		require.NoError(t, s.FreeMemoryByAddress(ctx, "virtualaccount7"))
+		delete(reserved, "virtualaccount7")
+
+		freeCapacity, err = s.GetFreeCapacity(ctx, "vm1", vm.VirtualAccountTypeTimelock)
+		require.NoError(t, err)
+		assert.EqualValues(t, 6, freeCapacity)
+
+		var cursor query.Cursor
+		var allocations []*ram.Allocation
+		for {
+			page, err := s.GetAllAllocations(ctx, "vm1", vm.VirtualAccountTypeTimelock, cursor, 4)
+			if err == ram.ErrNoAllocations {
+				break
+			}
+			require.NoError(t, err)
+			assert.True(t, len(page) <= 4)
+
+			allocations = append(allocations, page...)
+			cursor = query.ToCursor(page[len(page)-1].Id)
+		}
+		require.Len(t, allocations, len(reserved))
+
+		for i, allocation := range allocations {
+			if i > 0 {
+				assert.True(t, allocation.Id > allocations[i-1].Id)
+			}
+
+			_, ok := reserved[allocation.Address]
+			assert.True(t, ok)
+			delete(reserved, allocation.Address)
+
+			assert.Equal(t, "vm1", allocation.Vm)
+			assert.Contains(t, []string{"memoryaccount0", "memoryaccount1"}, allocation.MemoryAccount)
+			assert.True(t, allocation.Index < 10)
+			assert.Equal(t, vm.VirtualAccountTypeTimelock, allocation.StoredAccountType)
+			assert.False(t, allocation.LastUpdatedAt.IsZero())
+		}
+
+		_, err = s.GetAllAllocations(ctx, "vm1", vm.VirtualAccountTypeDurableNonce, nil, 10)
+		assert.Equal(t, ram.ErrNoAllocations, err)
+	})
+}
@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/code-payments/ocp-server/ocp/data/vm/registry"
+)
+
+type store struct {
+	mu      sync.Mutex
+	last    uint64
+	records []*registry.Record
+}
+
+// New returns a new in memory vm.registry.Store
+func New() registry.Store {
+	return &store{}
+}
+
+// Put implements vm.registry.Store.Put
+func (s *store) Put(_ context.Context, record *registry.Record) error {
+	if err := record.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, item := range s.records {
+		if item.Mint == record.Mint || item.Vm == record.Vm {
+			return registry.ErrAlreadyExists
+		}
+	}
+
+	s.last++
+	record.Id = s.last
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+
+	cloned := record.Clone()
+	s.records = append(s.records, &cloned)
+
+	return nil
+}
+
+// GetByMint implements vm.registry.Store.GetByMint
+func (s *store) GetByMint(_ context.Context, mint string) (*registry.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, item := range s.records {
+		if item.Mint == mint {
+			cloned := item.Clone()
+			return &cloned, nil
+		}
+	}
+	return nil, registry.ErrNotFound
+}
+
+// GetByVm implements vm.registry.Store.GetByVm
+func (s *store) GetByVm(_ context.Context, vm string) (*registry.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, item := range s.records {
+		if item.Vm == vm {
+			cloned := item.Clone()
+			return &cloned, nil
+		}
+	}
+	return nil, registry.ErrNotFound
+}
+
+// GetAll implements vm.registry.Store.GetAll
+func (s *store) GetAll(_ context.Context) ([]*registry.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.records) == 0 {
+		return nil, registry.ErrNotFound
+	}
+
+	res := make([]*registry.Record, len(s.records))
+	for i, item := range s.records {
+		cloned := item.Clone()
+		res[i] = &cloned
+	}
+	return res, nil
+}
+
+func (s *store) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.last = 0
+	s.records = nil
+}
@@ -0,0 +1,18 @@
+CREATE TABLE ocp__core_vmregistry (
+	id SERIAL NOT NULL PRIMARY KEY,
+
+	mint TEXT NOT NULL,
+
+	vm TEXT NOT NULL,
+	omnibus TEXT NOT NULL,
+	authority TEXT NOT NULL,
+
+	lock_duration_in_days INTEGER NOT NULL,
+
+	alt TEXT NOT NULL,
+
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	CONSTRAINT ocp__core_vmregistry__uniq__mint UNIQUE (mint),
+	CONSTRAINT ocp__core_vmregistry__uniq__vm UNIQUE (vm)
+);
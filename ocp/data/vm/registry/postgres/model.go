@@ -0,0 +1,148 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	pgutil "github.com/code-payments/ocp-server/database/postgres"
+	"github.com/code-payments/ocp-server/ocp/data/vm/registry"
+)
+
+const (
+	tableName = "ocp__core_vmregistry"
+)
+
+type model struct {
+	Id sql.NullInt64 `db:"id"`
+
+	Mint string `db:"mint"`
+
+	Vm        string `db:"vm"`
+	Omnibus   string `db:"omnibus"`
+	Authority string `db:"authority"`
+
+	LockDurationInDays uint8 `db:"lock_duration_in_days"`
+
+	Alt string `db:"alt"`
+
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func toModel(obj *registry.Record) (*model, error) {
+	if err := obj.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &model{
+		Mint: obj.Mint,
+
+		Vm:        obj.Vm,
+		Omnibus:   obj.Omnibus,
+		Authority: obj.Authority,
+
+		LockDurationInDays: obj.LockDurationInDays,
+
+		Alt: obj.Alt,
+
+		CreatedAt: obj.CreatedAt,
+	}, nil
+}
+
+func fromModel(obj *model) *registry.Record {
+	return &registry.Record{
+		Id: uint64(obj.Id.Int64),
+
+		Mint: obj.Mint,
+
+		Vm:        obj.Vm,
+		Omnibus:   obj.Omnibus,
+		Authority: obj.Authority,
+
+		LockDurationInDays: obj.LockDurationInDays,
+
+		Alt: obj.Alt,
+
+		CreatedAt: obj.CreatedAt,
+	}
+}
+
+func (m *model) dbPut(ctx context.Context, db *sqlx.DB) error {
+	return pgutil.ExecuteInTx(ctx, db, sql.LevelDefault, func(tx *sqlx.Tx) error {
+		query := `INSERT INTO ` + tableName + `
+			(mint, vm, omnibus, authority, lock_duration_in_days, alt, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING
+				id, mint, vm, omnibus, authority, lock_duration_in_days, alt, created_at`
+
+		if m.CreatedAt.IsZero() {
+			m.CreatedAt = time.Now()
+		}
+
+		err := tx.QueryRowxContext(
+			ctx,
+			query,
+			m.Mint,
+			m.Vm,
+			m.Omnibus,
+			m.Authority,
+			m.LockDurationInDays,
+			m.Alt,
+			m.CreatedAt,
+		).StructScan(m)
+
+		return pgutil.CheckUniqueViolation(err, registry.ErrAlreadyExists)
+	})
+}
+
+func dbGetByMint(ctx context.Context, db *sqlx.DB, mint string) (*model, error) {
+	res := &model{}
+
+	query := `SELECT
+			id, mint, vm, omnibus, authority, lock_duration_in_days, alt, created_at
+		FROM ` + tableName + `
+		WHERE mint = $1
+		LIMIT 1`
+
+	err := db.GetContext(ctx, res, query, mint)
+	if err != nil {
+		return nil, pgutil.CheckNoRows(err, registry.ErrNotFound)
+	}
+	return res, nil
+}
+
+func dbGetByVm(ctx context.Context, db *sqlx.DB, vm string) (*model, error) {
+	res := &model{}
+
+	query := `SELECT
+			id, mint, vm, omnibus, authority, lock_duration_in_days, alt, created_at
+		FROM ` + tableName + `
+		WHERE vm = $1
+		LIMIT 1`
+
+	err := db.GetContext(ctx, res, query, vm)
+	if err != nil {
+		return nil, pgutil.CheckNoRows(err, registry.ErrNotFound)
+	}
+	return res, nil
+}
+
+func dbGetAll(ctx context.Context, db *sqlx.DB) ([]*model, error) {
+	res := []*model{}
+
+	query := `SELECT
+			id, mint, vm, omnibus, authority, lock_duration_in_days, alt, created_at
+		FROM ` + tableName + `
+		ORDER BY id ASC`
+
+	err := db.SelectContext(ctx, &res, query)
+	if err != nil {
+		return nil, pgutil.CheckNoRows(err, registry.ErrNotFound)
+	}
+	if len(res) == 0 {
+		return nil, registry.ErrNotFound
+	}
+	return res, nil
+}
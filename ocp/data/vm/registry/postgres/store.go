@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/code-payments/ocp-server/ocp/data/vm/registry"
+)
+
+type store struct {
+	db *sqlx.DB
+}
+
+// New returns a new postgres vm.registry.Store
+func New(db *sql.DB) registry.Store {
+	return &store{
+		db: sqlx.NewDb(db, "pgx"),
+	}
+}
+
+// Put implements vm.registry.Store.Put
+func (s *store) Put(ctx context.Context, record *registry.Record) error {
+	model, err := toModel(record)
+	if err != nil {
+		return err
+	}
+
+	err = model.dbPut(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	res := fromModel(model)
+	res.CopyTo(record)
+
+	return nil
+}
+
+// GetByMint implements vm.registry.Store.GetByMint
+func (s *store) GetByMint(ctx context.Context, mint string) (*registry.Record, error) {
+	model, err := dbGetByMint(ctx, s.db, mint)
+	if err != nil {
+		return nil, err
+	}
+	return fromModel(model), nil
+}
+
+// GetByVm implements vm.registry.Store.GetByVm
+func (s *store) GetByVm(ctx context.Context, vm string) (*registry.Record, error) {
+	model, err := dbGetByVm(ctx, s.db, vm)
+	if err != nil {
+		return nil, err
+	}
+	return fromModel(model), nil
+}
+
+// GetAll implements vm.registry.Store.GetAll
+func (s *store) GetAll(ctx context.Context) ([]*registry.Record, error) {
+	models, err := dbGetAll(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*registry.Record, len(models))
+	for i, model := range models {
+		res[i] = fromModel(model)
+	}
+	return res, nil
+}
@@ -0,0 +1,83 @@
+package registry
+
+import (
+	"errors"
+	"time"
+)
+
+// Record maps a mint to the VM that manages its virtual accounts. The VM
+// authority private key is stored in the vault.
+type Record struct {
+	Id uint64
+
+	Mint string
+
+	Vm        string
+	Omnibus   string
+	Authority string
+
+	LockDurationInDays uint8
+
+	// Optional address lookup table for versioned transactions involving the mint
+	Alt string
+
+	CreatedAt time.Time
+}
+
+func (r *Record) Validate() error {
+	if len(r.Mint) == 0 {
+		return errors.New("mint is required")
+	}
+
+	if len(r.Vm) == 0 {
+		return errors.New("vm is required")
+	}
+
+	if len(r.Omnibus) == 0 {
+		return errors.New("omnibus is required")
+	}
+
+	if len(r.Authority) == 0 {
+		return errors.New("authority is required")
+	}
+
+	if r.LockDurationInDays == 0 {
+		return errors.New("lock duration is required")
+	}
+
+	return nil
+}
+
+func (r *Record) Clone() Record {
+	return Record{
+		Id: r.Id,
+
+		Mint: r.Mint,
+
+		Vm:        r.Vm,
+		Omnibus:   r.Omnibus,
+		Authority: r.Authority,
+
+		LockDurationInDays: r.LockDurationInDays,
+
+		Alt: r.Alt,
+
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+func (r *Record) CopyTo(dst *Record) {
+	dst.Id = r.Id
+
+	dst.Mint = r.Mint
+
+	dst.Vm = r.Vm
+	dst.Omnibus = r.Omnibus
+	dst.Authority = r.Authority
+
+	dst.LockDurationInDays = r.LockDurationInDays
+
+	dst.Alt = r.Alt
+
+	dst.CreatedAt = r.CreatedAt
+}
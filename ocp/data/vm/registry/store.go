@@ -0,0 +1,35 @@
+package registry
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrNotFound      = errors.New("vm registry record not found")
+	ErrAlreadyExists = errors.New("vm registry record already exists")
+)
+
+// Store tracks the VM that's used for each supported mint. Records are
+// immutable once created.
+type Store interface {
+	// Put creates a new VM registry record
+	//
+	// Returns ErrAlreadyExists if a record already exists for the mint or VM.
+	Put(ctx context.Context, record *Record) error
+
+	// GetByMint gets the VM registry record for a mint
+	//
+	// Returns ErrNotFound if no record is found.
+	GetByMint(ctx context.Context, mint string) (*Record, error)
+
+	// GetByVm gets the VM registry record for a VM
+	//
+	// Returns ErrNotFound if no record is found.
+	GetByVm(ctx context.Context, vm string) (*Record, error)
+
+	// GetAll gets all VM registry records
+	//
+	// Returns ErrNotFound if no records are found.
+	GetAll(ctx context.Context) ([]*Record, error)
+}
@@ -0,0 +1,98 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/code-payments/ocp-server/ocp/data/vm/registry"
+)
+
+func RunTests(t *testing.T, s registry.Store, teardown func()) {
+	for _, tf := range []func(t *testing.T, s registry.Store){
+		testHappyPath,
+	} {
+		tf(t, s)
+		teardown()
+	}
+}
+
+func testHappyPath(t *testing.T, s registry.Store) {
+	t.Run("testHappyPath", func(t *testing.T) {
+		ctx := context.Background()
+
+		_, err := s.GetByMint(ctx, "mint1")
+		assert.Equal(t, registry.ErrNotFound, err)
+
+		_, err = s.GetByVm(ctx, "vm1")
+		assert.Equal(t, registry.ErrNotFound, err)
+
+		_, err = s.GetAll(ctx)
+		assert.Equal(t, registry.ErrNotFound, err)
+
+		record1 := &registry.Record{
+			Mint:               "mint1",
+			Vm:                 "vm1",
+			Omnibus:            "omnibus1",
+			Authority:          "authority1",
+			LockDurationInDays: 21,
+			Alt:                "alt1",
+		}
+		record2 := &registry.Record{
+			Mint:               "mint2",
+			Vm:                 "vm2",
+			Omnibus:            "omnibus2",
+			Authority:          "authority2",
+			LockDurationInDays: 7,
+		}
+
+		require.NoError(t, s.Put(ctx, record1))
+		assert.EqualValues(t, 1, record1.Id)
+		assert.False(t, record1.CreatedAt.IsZero())
+
+		require.NoError(t, s.Put(ctx, record2))
+		assert.EqualValues(t, 2, record2.Id)
+
+		assert.Equal(t, registry.ErrAlreadyExists, s.Put(ctx, &registry.Record{
+			Mint:               "mint1",
+			Vm:                 "vm3",
+			Omnibus:            "omnibus3",
+			Authority:          "authority3",
+			LockDurationInDays: 21,
+		}))
+		assert.Equal(t, registry.ErrAlreadyExists, s.Put(ctx, &registry.Record{
+			Mint:               "mint3",
+			Vm:                 "vm1",
+			Omnibus:            "omnibus3",
+			Authority:          "authority3",
+			LockDurationInDays: 21,
+		}))
+
+		actual, err := s.GetByMint(ctx, "mint1")
+		require.NoError(t, err)
+		assertEquivalentRecords(t, record1, actual)
+
+		actual, err = s.GetByVm(ctx, "vm2")
+		require.NoError(t, err)
+		assertEquivalentRecords(t, record2, actual)
+
+		allRecords, err := s.GetAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, allRecords, 2)
+		assertEquivalentRecords(t, record1, allRecords[0])
+		assertEquivalentRecords(t, record2, allRecords[1])
+	})
+}
+
+func assertEquivalentRecords(t *testing.T, obj1, obj2 *registry.Record) {
+	assert.Equal(t, obj1.Id, obj2.Id)
+	assert.Equal(t, obj1.Mint, obj2.Mint)
+	assert.Equal(t, obj1.Vm, obj2.Vm)
+	assert.Equal(t, obj1.Omnibus, obj2.Omnibus)
+	assert.Equal(t, obj1.Authority, obj2.Authority)
+	assert.Equal(t, obj1.LockDurationInDays, obj2.LockDurationInDays)
+	assert.Equal(t, obj1.Alt, obj2.Alt)
+	assert.Equal(t, obj1.CreatedAt.Unix(), obj2.CreatedAt.Unix())
+}
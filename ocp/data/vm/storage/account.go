@@ -11,6 +11,7 @@ type Purpose uint8
 const (
 	PurposeUnknown  Purpose = iota
 	PurposeDeletion         // Purely used to "delete" accounts that will never make it back to memory. At capacity, we can throw the entire account away and any DB storage.
+	PurposeArchival         // Used to free memory for inactive accounts that can be decompressed back into memory on demand. Must be retained indefinitely.
 )
 
 type Record struct {
@@ -44,7 +45,9 @@ func (r *Record) Validate() error {
 		return errors.New("available capacity exceeds maximum")
 	}
 
-	if r.Purpose != PurposeDeletion {
+	switch r.Purpose {
+	case PurposeDeletion, PurposeArchival:
+	default:
 		return errors.New("invalid purpose")
 	}
 
@@ -89,6 +89,19 @@ func (s *store) ReserveStorage(_ context.Context, vm string, purpose storage.Pur
 	return selected.Address, nil
 }
 
+// FreeStorageByAddress implements vm.storage.Store.FreeStorageByAddress
+func (s *store) FreeStorageByAddress(_ context.Context, address string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.storedAccounts[address]; !ok {
+		return storage.ErrNotReserved
+	}
+
+	delete(s.storedAccounts, address)
+	return nil
+}
+
 func (s *store) find(data *storage.Record) *storage.Record {
 	for _, item := range s.records {
 		if item.Id == data.Id {
@@ -0,0 +1,2 @@
+DROP TABLE ocp__core_vmstorageaccount;
+DROP TABLE ocp__core_vmstorageallocatedstorage;
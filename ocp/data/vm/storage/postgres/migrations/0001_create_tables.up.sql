@@ -0,0 +1,28 @@
+CREATE TABLE ocp__core_vmstorageaccount (
+	id SERIAL NOT NULL PRIMARY KEY,
+
+	vm TEXT NOT NULL,
+
+	address TEXT NOT NULL,
+
+	levels INTEGER NOT NULL,
+	available_capacity BIGINT NOT NULL CHECK(available_capacity >= 0),
+	purpose INTEGER NOT NULL,
+
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	CONSTRAINT ocp__core_vmstorageaccount__uniq__address UNIQUE (address)
+);
+
+CREATE TABLE ocp__core_vmstorageallocatedstorage (
+	id SERIAL NOT NULL PRIMARY KEY,
+
+	vm TEXT NOT NULL,
+
+	storage_account TEXT NOT NULL,
+	address TEXT NOT NULL,
+
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	CONSTRAINT ocp__core_vmstorageallocatedstorage__uniq__address UNIQUE (address)
+);
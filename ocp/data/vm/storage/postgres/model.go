@@ -3,17 +3,26 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"time"
 
 	"github.com/jmoiron/sqlx"
 
 	pgutil "github.com/code-payments/ocp-server/database/postgres"
 	"github.com/code-payments/ocp-server/ocp/data/vm/storage"
+	"github.com/code-payments/ocp-server/retry"
+	"github.com/code-payments/ocp-server/retry/backoff"
 )
 
 const (
 	accountTableName          = "ocp__core_vmstorageaccount"
 	allocatedStorageTableName = "ocp__core_vmstorageallocatedstorage"
+
+	maxReserveStorageAttempts = 5
+)
+
+var (
+	errLockedStorageExhausted = errors.New("locked storage account was exhausted")
 )
 
 type accountModel struct {
@@ -129,35 +138,92 @@ func dbReserveStorage(ctx context.Context, db *sqlx.DB, vm string, purpose stora
 	err := pgutil.ExecuteInTx(ctx, db, sql.LevelDefault, func(tx *sqlx.Tx) error {
 		var model accountModel
 
-		query1 := `INSERT INTO ` + allocatedStorageTableName + `
-			(vm, storage_account, address, created_at)
-			VALUES ($1, $2, $3, $4)
-			RETURNING id, vm, storage_account, address, created_at`
+		query1 := `SELECT EXISTS (
+				SELECT 1 FROM ` + allocatedStorageTableName + `
+				WHERE address = $1
+			)`
+
+		var isReserved bool
 		err := tx.QueryRowxContext(
 			ctx,
 			query1,
-			vm,
-			model.Address,
 			address,
-			time.Now(),
-		).StructScan(&allocatedStorageModel{})
+		).Scan(&isReserved)
 		if err != nil {
-			return pgutil.CheckUniqueViolation(err, storage.ErrAddressAlreadyReserved)
+			return err
+		} else if isReserved {
+			return storage.ErrAddressAlreadyReserved
 		}
 
+		// Under READ COMMITTED, a transaction blocked on a locked storage account
+		// may find it exhausted once the lock is released, in which case no row
+		// is updated. Retry a bounded number of times as long as some other
+		// storage account still has available capacity.
 		query2 := `UPDATE ` + accountTableName + `
 			SET available_capacity = available_capacity - 1
-			WHERE vm = $1 AND purpose = $2 and available_capacity > 0
+			WHERE id IN (
+				SELECT id FROM ` + accountTableName + `
+				WHERE vm = $1 AND purpose = $2 AND available_capacity > 0
+				LIMIT 1
+				FOR UPDATE
+			)
 			RETURNING id, vm, address, levels, available_capacity, purpose, created_at`
 
+		query3 := `SELECT EXISTS (
+				SELECT 1 FROM ` + accountTableName + `
+				WHERE vm = $1 AND purpose = $2 AND available_capacity > 0
+			)`
+
+		_, err = retry.Retry(
+			func() error {
+				err := tx.QueryRowxContext(
+					ctx,
+					query2,
+					vm,
+					purpose,
+				).StructScan(&model)
+				if err != sql.ErrNoRows {
+					return err
+				}
+
+				var hasAvailableCapacity bool
+				err = tx.QueryRowxContext(
+					ctx,
+					query3,
+					vm,
+					purpose,
+				).Scan(&hasAvailableCapacity)
+				if err != nil {
+					return err
+				} else if !hasAvailableCapacity {
+					return storage.ErrNoFreeStorage
+				}
+				return errLockedStorageExhausted
+			},
+			retry.RetriableErrors(errLockedStorageExhausted),
+			retry.Limit(maxReserveStorageAttempts),
+			retry.Backoff(backoff.BinaryExponential(10*time.Millisecond), 100*time.Millisecond),
+		)
+		if err == errLockedStorageExhausted {
+			return storage.ErrStorageContended
+		} else if err != nil {
+			return err
+		}
+
+		query4 := `INSERT INTO ` + allocatedStorageTableName + `
+			(vm, storage_account, address, created_at)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id, vm, storage_account, address, created_at`
 		err = tx.QueryRowxContext(
 			ctx,
-			query2,
+			query4,
 			vm,
-			purpose,
-		).StructScan(&model)
+			model.Address,
+			address,
+			time.Now(),
+		).StructScan(&allocatedStorageModel{})
 		if err != nil {
-			return pgutil.CheckNoRows(err, storage.ErrNoFreeStorage)
+			return pgutil.CheckUniqueViolation(err, storage.ErrAddressAlreadyReserved)
 		}
 
 		storageAccount = model.Address
@@ -166,3 +232,21 @@ func dbReserveStorage(ctx context.Context, db *sqlx.DB, vm string, purpose stora
 	})
 	return storageAccount, err
 }
+
+func dbFreeStorageByAddress(ctx context.Context, db *sqlx.DB, address string) error {
+	query := `DELETE FROM ` + allocatedStorageTableName + `
+		WHERE address = $1`
+
+	res, err := db.ExecContext(ctx, query, address)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	} else if rowsAffected == 0 {
+		return storage.ErrNotReserved
+	}
+	return nil
+}
@@ -54,3 +54,8 @@ func (s *store) FindAnyWithAvailableCapacity(ctx context.Context, vm string, pur
 func (s *store) ReserveStorage(ctx context.Context, vm string, purpose storage.Purpose, address string) (string, error) {
 	return dbReserveStorage(ctx, s.db, vm, purpose, address)
 }
+
+// FreeStorageByAddress implements vm.storage.Store.FreeStorageByAddress
+func (s *store) FreeStorageByAddress(ctx context.Context, address string) error {
+	return dbFreeStorageByAddress(ctx, s.db, address)
+}
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"os"
 	"testing"
@@ -11,6 +12,7 @@ import (
 	"github.com/code-payments/ocp-server/ocp/data/vm/storage"
 	"github.com/code-payments/ocp-server/ocp/data/vm/storage/tests"
 
+	"github.com/code-payments/ocp-server/database/postgres/migration"
 	postgrestest "github.com/code-payments/ocp-server/database/postgres/test"
 
 	_ "github.com/jackc/pgx/v4/stdlib"
@@ -21,46 +23,6 @@ var (
 	teardown  func()
 )
 
-const (
-	// Used for testing ONLY, the table and migrations are external to this repository
-	tableCreate = `
-		CREATE TABLE ocp__core_vmstorageaccount (
-			id SERIAL NOT NULL PRIMARY KEY,
-
-			vm TEXT NOT NULL,
-
-			address TEXT NOT NULL,
-
-			levels INTEGER NOT NULL,
-			available_capacity BIGINT NOT NULL CHECK(available_capacity >= 0),
-			purpose INTEGER NOT NULL,
-
-			created_at TIMESTAMP WITH TIME ZONE NOT NULL,
-
-			CONSTRAINT ocp__core_vmmemoryaccount__uniq__address UNIQUE (address)
-		);
-
-		CREATE TABLE ocp__core_vmstorageallocatedstorage (
-			id SERIAL NOT NULL PRIMARY KEY,
-
-			vm TEXT NOT NULL,
-
-			storage_account TEXT NOT NULL,
-			address TEXT NOT NULL,
-
-			created_at TIMESTAMP WITH TIME ZONE NOT NULL,
-
-			CONSTRAINT ocp__core_vmmemoryallocatedmemory__uniq__address UNIQUE (address)
-		);
-	`
-
-	// Used for testing ONLY, the table and migrations are external to this repository
-	tableDestroy = `
-		DROP TABLE ocp__core_vmstorageaccount;
-		DROP TABLE ocp__core_vmstorageallocatedstorage;
-	`
-)
-
 func TestMain(m *testing.M) {
 	log := zap.Must(zap.NewDevelopment())
 
@@ -108,7 +70,13 @@ func TestVmStoragePostgresStore(t *testing.T) {
 }
 
 func createTestTables(log *zap.Logger, db *sql.DB) error {
-	_, err := db.Exec(tableCreate)
+	migrations, err := Migrations()
+	if err != nil {
+		log.With(zap.Error(err)).Error("could not load migrations")
+		return err
+	}
+
+	err = migration.Up(context.Background(), db, migrations)
 	if err != nil {
 		log.With(zap.Error(err)).Error("could not create test tables")
 		return err
@@ -117,7 +85,13 @@ func createTestTables(log *zap.Logger, db *sql.DB) error {
 }
 
 func resetTestTables(log *zap.Logger, db *sql.DB) error {
-	_, err := db.Exec(tableDestroy)
+	migrations, err := Migrations()
+	if err != nil {
+		log.With(zap.Error(err)).Error("could not load migrations")
+		return err
+	}
+
+	err = migration.Down(context.Background(), db, migrations)
 	if err != nil {
 		log.With(zap.Error(err)).Error("could not drop test tables")
 		return err
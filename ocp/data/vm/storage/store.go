@@ -11,11 +11,14 @@ var (
 	ErrInvalidInitialCapacity = errors.New("available capacity must be maximum when initializing storage")
 	ErrNoFreeStorage          = errors.New("no available free storage")
 	ErrNotFound               = errors.New("no storage accounts found")
+	ErrNotReserved            = errors.New("storage is not reserved")
+	ErrStorageContended       = errors.New("storage reservation is contended")
 )
 
 // Store implements a basic construct for managing compression storage.
 //
-// Note: A lock outside this implementation is required to resolve any races.
+// Implementations must be safe for concurrent use across processes, such that
+// multiple nodes can reserve storage without any external locking.
 type Store interface {
 	// Initializes a VM storage account for management
 	InitializeStorage(ctx context.Context, record *Record) error
@@ -24,5 +27,13 @@ type Store interface {
 	FindAnyWithAvailableCapacity(ctx context.Context, vm string, purpose Purpose, minCapacity uint64) (*Record, error)
 
 	// ReserveStorage reserves a piece of storage in a VM for the virtual account address
+	//
+	// Returns ErrStorageContended when concurrent reservations prevent one from
+	// being made, in which case it can be retried.
 	ReserveStorage(ctx context.Context, vm string, purpose Purpose, address string) (string, error)
+
+	// FreeStorageByAddress frees the storage reserved for a virtual account address
+	// that's been decompressed back into memory, so it can be compressed again.
+	// Available capacity is never reclaimed, since compressed state is append-only.
+	FreeStorageByAddress(ctx context.Context, address string) error
 }
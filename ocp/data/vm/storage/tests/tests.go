@@ -3,6 +3,7 @@ package tests
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -15,6 +16,8 @@ import (
 func RunTests(t *testing.T, s storage.Store, teardown func()) {
 	for _, tf := range []func(t *testing.T, s storage.Store){
 		testHappyPath,
+		testConcurrentReservations,
+		testFreeStorage,
 	} {
 		tf(t, s)
 		teardown()
@@ -80,6 +83,103 @@ func testHappyPath(t *testing.T, s storage.Store) {
 	})
 }
 
+func testConcurrentReservations(t *testing.T, s storage.Store) {
+	t.Run("testConcurrentReservations", func(t *testing.T) {
+		ctx := context.Background()
+
+		record1 := &storage.Record{
+			Vm:                "vm1",
+			Address:           "storageaccount1",
+			Levels:            4,
+			AvailableCapacity: storage.GetMaxCapacity(4),
+			Purpose:           storage.PurposeDeletion,
+		}
+		record2 := &storage.Record{
+			Vm:                "vm1",
+			Address:           "storageaccount2",
+			Levels:            4,
+			AvailableCapacity: storage.GetMaxCapacity(4),
+			Purpose:           storage.PurposeDeletion,
+		}
+		require.NoError(t, s.InitializeStorage(ctx, record1))
+		require.NoError(t, s.InitializeStorage(ctx, record2))
+
+		totalCapacity := int(2 * storage.GetMaxCapacity(4))
+
+		var wg sync.WaitGroup
+		storageAccounts := make([]string, totalCapacity+10)
+		errs := make([]error, totalCapacity+10)
+		for i := 0; i < totalCapacity+10; i++ {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+
+				storageAccounts[i], errs[i] = s.ReserveStorage(ctx, "vm1", storage.PurposeDeletion, fmt.Sprintf("virtualaccount%d", i))
+			}(i)
+		}
+		wg.Wait()
+
+		reservationsByStorageAccount := make(map[string]int)
+		var noFreeStorageCount int
+		for i, err := range errs {
+			if err == storage.ErrNoFreeStorage {
+				noFreeStorageCount++
+				continue
+			}
+
+			require.NoError(t, err)
+			reservationsByStorageAccount[storageAccounts[i]]++
+		}
+
+		assert.Equal(t, int(storage.GetMaxCapacity(4)), reservationsByStorageAccount["storageaccount1"])
+		assert.Equal(t, int(storage.GetMaxCapacity(4)), reservationsByStorageAccount["storageaccount2"])
+		assert.Equal(t, 10, noFreeStorageCount)
+
+		_, err := s.FindAnyWithAvailableCapacity(ctx, "vm1", storage.PurposeDeletion, 1)
+		assert.Equal(t, storage.ErrNotFound, err)
+	})
+}
+
+func testFreeStorage(t *testing.T, s storage.Store) {
+	t.Run("testFreeStorage", func(t *testing.T) {
+		ctx := context.Background()
+
+		record := &storage.Record{
+			Vm:                "vm1",
+			Address:           "storageaccount1",
+			Levels:            4,
+			AvailableCapacity: storage.GetMaxCapacity(4),
+			Purpose:           storage.PurposeArchival,
+		}
+		require.NoError(t, s.InitializeStorage(ctx, record))
+
+		_, err := s.ReserveStorage(ctx, "vm1", storage.PurposeDeletion, "virtualaccount")
+		assert.Equal(t, storage.ErrNoFreeStorage, err)
+
+		assert.Equal(t, storage.ErrNotReserved, s.FreeStorageByAddress(ctx, "virtualaccount"))
+
+		storageAccount, err := s.ReserveStorage(ctx, "vm1", storage.PurposeArchival, "virtualaccount")
+		require.NoError(t, err)
+		assert.Equal(t, "storageaccount1", storageAccount)
+
+		_, err = s.ReserveStorage(ctx, "vm1", storage.PurposeArchival, "virtualaccount")
+		assert.Equal(t, storage.ErrAddressAlreadyReserved, err)
+
+		require.NoError(t, s.FreeStorageByAddress(ctx, "virtualaccount"))
+		assert.Equal(t, storage.ErrNotReserved, s.FreeStorageByAddress(ctx, "virtualaccount"))
+
+		storageAccount, err = s.ReserveStorage(ctx, "vm1", storage.PurposeArchival, "virtualaccount")
+		require.NoError(t, err)
+		assert.Equal(t, "storageaccount1", storageAccount)
+
+		// Capacity isn't reclaimed when freeing storage
+		actual, err := s.FindAnyWithAvailableCapacity(ctx, "vm1", storage.PurposeArchival, 1)
+		require.NoError(t, err)
+		assert.Equal(t, storage.GetMaxCapacity(4)-2, actual.AvailableCapacity)
+	})
+}
+
 func assertEquivalentRecords(t *testing.T, obj1, obj2 *storage.Record) {
 	assert.Equal(t, obj1.Vm, obj2.Vm)
 	assert.Equal(t, obj1.Address, obj2.Address)
@@ -0,0 +1,152 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/code-payments/ocp-server/ocp/data/webhook"
+)
+
+type store struct {
+	mu      sync.Mutex
+	last    uint64
+	records []*webhook.Record
+}
+
+// New returns a new in memory webhook.Store
+func New() webhook.Store {
+	return &store{}
+}
+
+// Put implements webhook.Store.Put
+func (s *store) Put(_ context.Context, record *webhook.Record) error {
+	if err := record.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if item := s.findByEventId(record.EventId); item != nil {
+		return webhook.ErrAlreadyExists
+	}
+
+	s.last++
+	record.Id = s.last
+	record.Version = 1
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+
+	cloned := record.Clone()
+	s.records = append(s.records, &cloned)
+
+	return nil
+}
+
+// Update implements webhook.Store.Update
+func (s *store) Update(_ context.Context, record *webhook.Record) error {
+	if err := record.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item := s.findByEventId(record.EventId)
+	if item == nil {
+		return webhook.ErrNotFound
+	}
+
+	if item.Version != record.Version {
+		return webhook.ErrStaleVersion
+	}
+
+	record.Version++
+
+	item.State = record.State
+	item.Attempts = record.Attempts
+	item.NextAttemptAt = record.NextAttemptAt
+	item.Version = record.Version
+
+	return nil
+}
+
+// GetByEventId implements webhook.Store.GetByEventId
+func (s *store) GetByEventId(_ context.Context, eventId string) (*webhook.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item := s.findByEventId(eventId)
+	if item == nil {
+		return nil, webhook.ErrNotFound
+	}
+
+	cloned := item.Clone()
+	return &cloned, nil
+}
+
+// GetAllReadyForDelivery implements webhook.Store.GetAllReadyForDelivery
+func (s *store) GetAllReadyForDelivery(_ context.Context, before time.Time, limit uint64) ([]*webhook.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var res []*webhook.Record
+	for _, item := range s.records {
+		if item.State != webhook.StatePending || item.NextAttemptAt.After(before) {
+			continue
+		}
+
+		cloned := item.Clone()
+		res = append(res, &cloned)
+	}
+
+	if len(res) == 0 {
+		return nil, webhook.ErrNotFound
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].NextAttemptAt.Equal(res[j].NextAttemptAt) {
+			return res[i].Id < res[j].Id
+		}
+		return res[i].NextAttemptAt.Before(res[j].NextAttemptAt)
+	})
+
+	if uint64(len(res)) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
+
+// CountByState implements webhook.Store.CountByState
+func (s *store) CountByState(_ context.Context, state webhook.State) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var count uint64
+	for _, item := range s.records {
+		if item.State == state {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *store) findByEventId(eventId string) *webhook.Record {
+	for _, item := range s.records {
+		if item.EventId == eventId {
+			return item
+		}
+	}
+	return nil
+}
+
+func (s *store) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.last = 0
+	s.records = nil
+}
@@ -0,0 +1,20 @@
+CREATE TABLE ocp__core_webhookevent (
+	id SERIAL NOT NULL PRIMARY KEY,
+
+	event_id TEXT NOT NULL,
+	event_type TEXT NOT NULL,
+	payload BYTEA NOT NULL,
+
+	state INTEGER NOT NULL,
+
+	attempts INTEGER NOT NULL,
+	next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	version INTEGER NOT NULL,
+
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+	CONSTRAINT ocp__core_webhookevent__uniq__event_id UNIQUE (event_id)
+);
+
+CREATE INDEX ocp__core_webhookevent__idx__state_next_attempt_at ON ocp__core_webhookevent (state, next_attempt_at);
@@ -0,0 +1,185 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	pgutil "github.com/code-payments/ocp-server/database/postgres"
+	"github.com/code-payments/ocp-server/ocp/data/webhook"
+)
+
+const (
+	tableName = "ocp__core_webhookevent"
+
+	allColumns = `id, event_id, event_type, payload, state, attempts, next_attempt_at, version, created_at`
+)
+
+type model struct {
+	Id sql.NullInt64 `db:"id"`
+
+	EventId   string `db:"event_id"`
+	EventType string `db:"event_type"`
+	Payload   []byte `db:"payload"`
+
+	State uint8 `db:"state"`
+
+	Attempts      uint32    `db:"attempts"`
+	NextAttemptAt time.Time `db:"next_attempt_at"`
+
+	Version uint64 `db:"version"`
+
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func toModel(obj *webhook.Record) (*model, error) {
+	if err := obj.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &model{
+		Id: sql.NullInt64{Int64: int64(obj.Id), Valid: obj.Id > 0},
+
+		EventId:   obj.EventId,
+		EventType: obj.EventType,
+		Payload:   obj.Payload,
+
+		State: uint8(obj.State),
+
+		Attempts:      obj.Attempts,
+		NextAttemptAt: obj.NextAttemptAt.UTC(),
+
+		Version: obj.Version,
+
+		CreatedAt: obj.CreatedAt.UTC(),
+	}, nil
+}
+
+func fromModel(obj *model) *webhook.Record {
+	return &webhook.Record{
+		Id: uint64(obj.Id.Int64),
+
+		EventId:   obj.EventId,
+		EventType: obj.EventType,
+		Payload:   obj.Payload,
+
+		State: webhook.State(obj.State),
+
+		Attempts:      obj.Attempts,
+		NextAttemptAt: obj.NextAttemptAt,
+
+		Version: obj.Version,
+
+		CreatedAt: obj.CreatedAt,
+	}
+}
+
+func (m *model) dbPut(ctx context.Context, db *sqlx.DB) error {
+	return pgutil.ExecuteInTx(ctx, db, sql.LevelDefault, func(tx *sqlx.Tx) error {
+		query := `INSERT INTO ` + tableName + `
+			(event_id, event_type, payload, state, attempts, next_attempt_at, version, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, 1, $7)
+			RETURNING ` + allColumns
+
+		if m.CreatedAt.IsZero() {
+			m.CreatedAt = time.Now().UTC()
+		}
+
+		err := tx.QueryRowxContext(
+			ctx,
+			query,
+			m.EventId,
+			m.EventType,
+			m.Payload,
+			m.State,
+			m.Attempts,
+			m.NextAttemptAt,
+			m.CreatedAt,
+		).StructScan(m)
+
+		return pgutil.CheckUniqueViolation(err, webhook.ErrAlreadyExists)
+	})
+}
+
+func (m *model) dbUpdate(ctx context.Context, db *sqlx.DB) error {
+	return pgutil.ExecuteInTx(ctx, db, sql.LevelDefault, func(tx *sqlx.Tx) error {
+		query := `UPDATE ` + tableName + `
+			SET state = $3, attempts = $4, next_attempt_at = $5, version = version + 1
+			WHERE event_id = $1 AND version = $2
+			RETURNING ` + allColumns
+
+		err := tx.QueryRowxContext(
+			ctx,
+			query,
+			m.EventId,
+			m.Version,
+			m.State,
+			m.Attempts,
+			m.NextAttemptAt,
+		).StructScan(m)
+		if err == nil {
+			return nil
+		} else if err != sql.ErrNoRows {
+			return err
+		}
+
+		// Distinguish between a missing record and a stale version
+		var count uint64
+		err = tx.GetContext(ctx, &count, `SELECT COUNT(*) FROM `+tableName+` WHERE event_id = $1`, m.EventId)
+		if err != nil {
+			return err
+		} else if count == 0 {
+			return webhook.ErrNotFound
+		}
+		return webhook.ErrStaleVersion
+	})
+}
+
+func dbGetByEventId(ctx context.Context, db *sqlx.DB, eventId string) (*model, error) {
+	res := &model{}
+
+	query := `SELECT ` + allColumns + `
+		FROM ` + tableName + `
+		WHERE event_id = $1
+		LIMIT 1`
+
+	err := db.GetContext(ctx, res, query, eventId)
+	if err != nil {
+		return nil, pgutil.CheckNoRows(err, webhook.ErrNotFound)
+	}
+	return res, nil
+}
+
+func dbGetAllReadyForDelivery(ctx context.Context, db *sqlx.DB, before time.Time, limit uint64) ([]*model, error) {
+	res := []*model{}
+
+	query := `SELECT ` + allColumns + `
+		FROM ` + tableName + `
+		WHERE state = $1 AND next_attempt_at <= $2
+		ORDER BY next_attempt_at ASC, id ASC
+		LIMIT $3`
+
+	err := db.SelectContext(ctx, &res, query, webhook.StatePending, before.UTC(), limit)
+	if err != nil {
+		return nil, pgutil.CheckNoRows(err, webhook.ErrNotFound)
+	}
+	if len(res) == 0 {
+		return nil, webhook.ErrNotFound
+	}
+	return res, nil
+}
+
+func dbCountByState(ctx context.Context, db *sqlx.DB, state webhook.State) (uint64, error) {
+	var res uint64
+
+	query := `SELECT COUNT(*) FROM ` + tableName + `
+		WHERE state = $1`
+
+	err := db.GetContext(ctx, &res, query, state)
+	if err != nil {
+		return 0, err
+	}
+	return res, nil
+}
@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/code-payments/ocp-server/ocp/data/webhook"
+)
+
+type store struct {
+	db *sqlx.DB
+}
+
+// New returns a new postgres webhook.Store
+func New(db *sql.DB) webhook.Store {
+	return &store{
+		db: sqlx.NewDb(db, "pgx"),
+	}
+}
+
+// Put implements webhook.Store.Put
+func (s *store) Put(ctx context.Context, record *webhook.Record) error {
+	model, err := toModel(record)
+	if err != nil {
+		return err
+	}
+
+	err = model.dbPut(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	res := fromModel(model)
+	res.CopyTo(record)
+
+	return nil
+}
+
+// Update implements webhook.Store.Update
+func (s *store) Update(ctx context.Context, record *webhook.Record) error {
+	model, err := toModel(record)
+	if err != nil {
+		return err
+	}
+
+	err = model.dbUpdate(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	res := fromModel(model)
+	res.CopyTo(record)
+
+	return nil
+}
+
+// GetByEventId implements webhook.Store.GetByEventId
+func (s *store) GetByEventId(ctx context.Context, eventId string) (*webhook.Record, error) {
+	model, err := dbGetByEventId(ctx, s.db, eventId)
+	if err != nil {
+		return nil, err
+	}
+	return fromModel(model), nil
+}
+
+// GetAllReadyForDelivery implements webhook.Store.GetAllReadyForDelivery
+func (s *store) GetAllReadyForDelivery(ctx context.Context, before time.Time, limit uint64) ([]*webhook.Record, error) {
+	models, err := dbGetAllReadyForDelivery(ctx, s.db, before, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*webhook.Record, len(models))
+	for i, model := range models {
+		res[i] = fromModel(model)
+	}
+	return res, nil
+}
+
+// CountByState implements webhook.Store.CountByState
+func (s *store) CountByState(ctx context.Context, state webhook.State) (uint64, error) {
+	return dbCountByState(ctx, s.db, state)
+}
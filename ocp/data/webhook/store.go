@@ -0,0 +1,41 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrNotFound      = errors.New("webhook event not found")
+	ErrAlreadyExists = errors.New("webhook event already exists")
+	ErrStaleVersion  = errors.New("webhook event version is stale")
+)
+
+// Store is a durable outbox of webhook events
+type Store interface {
+	// Put creates a new webhook event
+	//
+	// Returns ErrAlreadyExists if an event with the same ID already exists.
+	Put(ctx context.Context, record *Record) error
+
+	// Update updates the delivery state of an existing webhook event
+	//
+	// Returns ErrNotFound if the event doesn't exist, and ErrStaleVersion if
+	// the record was updated since it was last read.
+	Update(ctx context.Context, record *Record) error
+
+	// GetByEventId gets a webhook event by its ID
+	//
+	// Returns ErrNotFound if no record is found.
+	GetByEventId(ctx context.Context, eventId string) (*Record, error)
+
+	// GetAllReadyForDelivery gets pending webhook events that are due to be
+	// delivered at or before the provided time, ordered by when they're due
+	//
+	// Returns ErrNotFound if no records are found.
+	GetAllReadyForDelivery(ctx context.Context, before time.Time, limit uint64) ([]*Record, error)
+
+	// CountByState counts the number of webhook events in a state
+	CountByState(ctx context.Context, state State) (uint64, error)
+}
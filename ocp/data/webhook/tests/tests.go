@@ -0,0 +1,166 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/code-payments/ocp-server/ocp/data/webhook"
+)
+
+func RunTests(t *testing.T, s webhook.Store, teardown func()) {
+	for _, tf := range []func(t *testing.T, s webhook.Store){
+		testRoundTrip,
+		testUpdate,
+		testGetAllReadyForDelivery,
+	} {
+		tf(t, s)
+		teardown()
+	}
+}
+
+func testRoundTrip(t *testing.T, s webhook.Store) {
+	t.Run("testRoundTrip", func(t *testing.T) {
+		ctx := context.Background()
+
+		_, err := s.GetByEventId(ctx, "event1")
+		assert.Equal(t, webhook.ErrNotFound, err)
+
+		count, err := s.CountByState(ctx, webhook.StatePending)
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, count)
+
+		expected := &webhook.Record{
+			EventId:       "event1",
+			EventType:     "intent.created",
+			Payload:       []byte(`{"hello":"world"}`),
+			State:         webhook.StatePending,
+			NextAttemptAt: time.Now().Add(time.Minute),
+		}
+		cloned := expected.Clone()
+
+		require.NoError(t, s.Put(ctx, expected))
+		assert.True(t, expected.Id > 0)
+		assert.EqualValues(t, 1, expected.Version)
+		assert.False(t, expected.CreatedAt.IsZero())
+
+		actual, err := s.GetByEventId(ctx, "event1")
+		require.NoError(t, err)
+		assertEquivalentRecords(t, &cloned, actual)
+		assert.Equal(t, expected.Id, actual.Id)
+		assert.EqualValues(t, 1, actual.Version)
+
+		assert.Equal(t, webhook.ErrAlreadyExists, s.Put(ctx, &cloned))
+
+		count, err = s.CountByState(ctx, webhook.StatePending)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, count)
+	})
+}
+
+func testUpdate(t *testing.T, s webhook.Store) {
+	t.Run("testUpdate", func(t *testing.T) {
+		ctx := context.Background()
+
+		record := &webhook.Record{
+			EventId:       "event1",
+			EventType:     "intent.created",
+			Payload:       []byte(`{"hello":"world"}`),
+			State:         webhook.StatePending,
+			NextAttemptAt: time.Now(),
+		}
+		assert.Equal(t, webhook.ErrNotFound, s.Update(ctx, record))
+
+		require.NoError(t, s.Put(ctx, record))
+		stale := record.Clone()
+
+		record.Attempts = 1
+		record.NextAttemptAt = time.Now().Add(time.Hour)
+		require.NoError(t, s.Update(ctx, record))
+		assert.EqualValues(t, 2, record.Version)
+
+		actual, err := s.GetByEventId(ctx, "event1")
+		require.NoError(t, err)
+		assertEquivalentRecords(t, record, actual)
+		assert.EqualValues(t, 2, actual.Version)
+
+		stale.State = webhook.StateDelivered
+		assert.Equal(t, webhook.ErrStaleVersion, s.Update(ctx, &stale))
+
+		record.State = webhook.StateDelivered
+		require.NoError(t, s.Update(ctx, record))
+
+		actual, err = s.GetByEventId(ctx, "event1")
+		require.NoError(t, err)
+		assertEquivalentRecords(t, record, actual)
+
+		count, err := s.CountByState(ctx, webhook.StatePending)
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, count)
+
+		count, err = s.CountByState(ctx, webhook.StateDelivered)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, count)
+	})
+}
+
+func testGetAllReadyForDelivery(t *testing.T, s webhook.Store) {
+	t.Run("testGetAllReadyForDelivery", func(t *testing.T) {
+		ctx := context.Background()
+
+		now := time.Now()
+
+		_, err := s.GetAllReadyForDelivery(ctx, now, 10)
+		assert.Equal(t, webhook.ErrNotFound, err)
+
+		for i, tc := range []struct {
+			state         webhook.State
+			nextAttemptAt time.Time
+		}{
+			{webhook.StatePending, now.Add(-time.Minute)},
+			{webhook.StatePending, now.Add(-time.Hour)},
+			{webhook.StatePending, now.Add(time.Minute)},
+			{webhook.StateDelivered, now.Add(-time.Hour)},
+			{webhook.StateFailed, now.Add(-time.Hour)},
+			{webhook.StatePending, now.Add(-time.Second)},
+		} {
+			require.NoError(t, s.Put(ctx, &webhook.Record{
+				EventId:       fmt.Sprintf("event%d", i),
+				EventType:     "intent.created",
+				Payload:       []byte(`{}`),
+				State:         tc.state,
+				NextAttemptAt: tc.nextAttemptAt,
+			}))
+		}
+
+		actual, err := s.GetAllReadyForDelivery(ctx, now, 10)
+		require.NoError(t, err)
+		require.Len(t, actual, 3)
+		assert.Equal(t, "event1", actual[0].EventId)
+		assert.Equal(t, "event0", actual[1].EventId)
+		assert.Equal(t, "event5", actual[2].EventId)
+
+		actual, err = s.GetAllReadyForDelivery(ctx, now, 2)
+		require.NoError(t, err)
+		require.Len(t, actual, 2)
+		assert.Equal(t, "event1", actual[0].EventId)
+		assert.Equal(t, "event0", actual[1].EventId)
+
+		actual, err = s.GetAllReadyForDelivery(ctx, now.Add(time.Hour), 10)
+		require.NoError(t, err)
+		assert.Len(t, actual, 4)
+	})
+}
+
+func assertEquivalentRecords(t *testing.T, obj1, obj2 *webhook.Record) {
+	assert.Equal(t, obj1.EventId, obj2.EventId)
+	assert.Equal(t, obj1.EventType, obj2.EventType)
+	assert.Equal(t, obj1.Payload, obj2.Payload)
+	assert.Equal(t, obj1.State, obj2.State)
+	assert.Equal(t, obj1.Attempts, obj2.Attempts)
+	assert.Equal(t, obj1.NextAttemptAt.Unix(), obj2.NextAttemptAt.Unix())
+}
@@ -0,0 +1,115 @@
+package webhook
+
+import (
+	"errors"
+	"time"
+)
+
+type State uint8
+
+const (
+	StateUnknown State = iota
+	StatePending
+	StateDelivered
+	StateFailed
+)
+
+// Record is an outbound webhook event in the outbox, which is delivered with
+// at least once semantics
+type Record struct {
+	Id uint64
+
+	// EventId uniquely identifies the event, and is used by receivers to
+	// deduplicate deliveries
+	EventId   string
+	EventType string
+	Payload   []byte
+
+	State State
+
+	Attempts      uint32
+	NextAttemptAt time.Time
+
+	Version uint64
+
+	CreatedAt time.Time
+}
+
+func (r *Record) Validate() error {
+	if len(r.EventId) == 0 {
+		return errors.New("event id is required")
+	}
+
+	if len(r.EventType) == 0 {
+		return errors.New("event type is required")
+	}
+
+	if len(r.Payload) == 0 {
+		return errors.New("payload is required")
+	}
+
+	if r.State == StateUnknown {
+		return errors.New("state is required")
+	}
+
+	if r.NextAttemptAt.IsZero() {
+		return errors.New("next attempt timestamp is required")
+	}
+
+	return nil
+}
+
+func (r *Record) Clone() Record {
+	payload := make([]byte, len(r.Payload))
+	copy(payload, r.Payload)
+
+	return Record{
+		Id: r.Id,
+
+		EventId:   r.EventId,
+		EventType: r.EventType,
+		Payload:   payload,
+
+		State: r.State,
+
+		Attempts:      r.Attempts,
+		NextAttemptAt: r.NextAttemptAt,
+
+		Version: r.Version,
+
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+func (r *Record) CopyTo(dst *Record) {
+	dst.Id = r.Id
+
+	dst.EventId = r.EventId
+	dst.EventType = r.EventType
+	dst.Payload = r.Payload
+
+	dst.State = r.State
+
+	dst.Attempts = r.Attempts
+	dst.NextAttemptAt = r.NextAttemptAt
+
+	dst.Version = r.Version
+
+	dst.CreatedAt = r.CreatedAt
+}
+
+func (s State) IsTerminal() bool {
+	return s == StateDelivered || s == StateFailed
+}
+
+func (s State) String() string {
+	switch s {
+	case StatePending:
+		return "pending"
+	case StateDelivered:
+		return "delivered"
+	case StateFailed:
+		return "failed"
+	}
+	return "unknown"
+}
@@ -0,0 +1,1047 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v3.21.12
+// source: unlock_service.proto
+
+package unlock
+
+import (
+	v1 "github.com/code-payments/ocp-protobuf-api/generated/go/common/v1"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type InitiateUnlockResponse_Result int32
+
+const (
+	InitiateUnlockResponse_OK InitiateUnlockResponse_Result = 0
+	// The timelock account doesn't exist on the blockchain.
+	InitiateUnlockResponse_NOT_FOUND InitiateUnlockResponse_Result = 1
+	// The timelock account is already unlocked.
+	InitiateUnlockResponse_ALREADY_UNLOCKED InitiateUnlockResponse_Result = 2
+)
+
+// Enum value maps for InitiateUnlockResponse_Result.
+var (
+	InitiateUnlockResponse_Result_name = map[int32]string{
+		0: "OK",
+		1: "NOT_FOUND",
+		2: "ALREADY_UNLOCKED",
+	}
+	InitiateUnlockResponse_Result_value = map[string]int32{
+		"OK":               0,
+		"NOT_FOUND":        1,
+		"ALREADY_UNLOCKED": 2,
+	}
+)
+
+func (x InitiateUnlockResponse_Result) Enum() *InitiateUnlockResponse_Result {
+	p := new(InitiateUnlockResponse_Result)
+	*p = x
+	return p
+}
+
+func (x InitiateUnlockResponse_Result) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InitiateUnlockResponse_Result) Descriptor() protoreflect.EnumDescriptor {
+	return file_unlock_service_proto_enumTypes[0].Descriptor()
+}
+
+func (InitiateUnlockResponse_Result) Type() protoreflect.EnumType {
+	return &file_unlock_service_proto_enumTypes[0]
+}
+
+func (x InitiateUnlockResponse_Result) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InitiateUnlockResponse_Result.Descriptor instead.
+func (InitiateUnlockResponse_Result) EnumDescriptor() ([]byte, []int) {
+	return file_unlock_service_proto_rawDescGZIP(), []int{1, 0}
+}
+
+type CompleteUnlockResponse_Result int32
+
+const (
+	CompleteUnlockResponse_OK CompleteUnlockResponse_Result = 0
+	// The timelock account doesn't exist on the blockchain.
+	CompleteUnlockResponse_NOT_FOUND CompleteUnlockResponse_Result = 1
+	// The waiting period hasn't been observed on the blockchain.
+	CompleteUnlockResponse_NOT_INITIATED CompleteUnlockResponse_Result = 2
+	// The waiting period hasn't elapsed.
+	CompleteUnlockResponse_WAITING_FOR_TIMEOUT CompleteUnlockResponse_Result = 3
+	// The timelock account is already unlocked.
+	CompleteUnlockResponse_ALREADY_UNLOCKED CompleteUnlockResponse_Result = 4
+)
+
+// Enum value maps for CompleteUnlockResponse_Result.
+var (
+	CompleteUnlockResponse_Result_name = map[int32]string{
+		0: "OK",
+		1: "NOT_FOUND",
+		2: "NOT_INITIATED",
+		3: "WAITING_FOR_TIMEOUT",
+		4: "ALREADY_UNLOCKED",
+	}
+	CompleteUnlockResponse_Result_value = map[string]int32{
+		"OK":                  0,
+		"NOT_FOUND":           1,
+		"NOT_INITIATED":       2,
+		"WAITING_FOR_TIMEOUT": 3,
+		"ALREADY_UNLOCKED":    4,
+	}
+)
+
+func (x CompleteUnlockResponse_Result) Enum() *CompleteUnlockResponse_Result {
+	p := new(CompleteUnlockResponse_Result)
+	*p = x
+	return p
+}
+
+func (x CompleteUnlockResponse_Result) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CompleteUnlockResponse_Result) Descriptor() protoreflect.EnumDescriptor {
+	return file_unlock_service_proto_enumTypes[1].Descriptor()
+}
+
+func (CompleteUnlockResponse_Result) Type() protoreflect.EnumType {
+	return &file_unlock_service_proto_enumTypes[1]
+}
+
+func (x CompleteUnlockResponse_Result) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CompleteUnlockResponse_Result.Descriptor instead.
+func (CompleteUnlockResponse_Result) EnumDescriptor() ([]byte, []int) {
+	return file_unlock_service_proto_rawDescGZIP(), []int{3, 0}
+}
+
+type CompleteWithdrawResponse_Result int32
+
+const (
+	CompleteWithdrawResponse_OK CompleteWithdrawResponse_Result = 0
+	// The timelock account doesn't exist on the blockchain.
+	CompleteWithdrawResponse_NOT_FOUND CompleteWithdrawResponse_Result = 1
+	// The timelock account isn't unlocked.
+	CompleteWithdrawResponse_NOT_UNLOCKED CompleteWithdrawResponse_Result = 2
+	// There are no funds left to withdraw.
+	CompleteWithdrawResponse_NO_BALANCE CompleteWithdrawResponse_Result = 3
+	// The virtual account is moving between memory and storage, and the
+	// request should be retried.
+	CompleteWithdrawResponse_UNAVAILABLE CompleteWithdrawResponse_Result = 4
+)
+
+// Enum value maps for CompleteWithdrawResponse_Result.
+var (
+	CompleteWithdrawResponse_Result_name = map[int32]string{
+		0: "OK",
+		1: "NOT_FOUND",
+		2: "NOT_UNLOCKED",
+		3: "NO_BALANCE",
+		4: "UNAVAILABLE",
+	}
+	CompleteWithdrawResponse_Result_value = map[string]int32{
+		"OK":           0,
+		"NOT_FOUND":    1,
+		"NOT_UNLOCKED": 2,
+		"NO_BALANCE":   3,
+		"UNAVAILABLE":  4,
+	}
+)
+
+func (x CompleteWithdrawResponse_Result) Enum() *CompleteWithdrawResponse_Result {
+	p := new(CompleteWithdrawResponse_Result)
+	*p = x
+	return p
+}
+
+func (x CompleteWithdrawResponse_Result) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CompleteWithdrawResponse_Result) Descriptor() protoreflect.EnumDescriptor {
+	return file_unlock_service_proto_enumTypes[2].Descriptor()
+}
+
+func (CompleteWithdrawResponse_Result) Type() protoreflect.EnumType {
+	return &file_unlock_service_proto_enumTypes[2]
+}
+
+func (x CompleteWithdrawResponse_Result) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CompleteWithdrawResponse_Result.Descriptor instead.
+func (CompleteWithdrawResponse_Result) EnumDescriptor() ([]byte, []int) {
+	return file_unlock_service_proto_rawDescGZIP(), []int{5, 0}
+}
+
+type GetUnlockStatusResponse_Result int32
+
+const (
+	GetUnlockStatusResponse_OK GetUnlockStatusResponse_Result = 0
+	// The timelock account isn't known.
+	GetUnlockStatusResponse_NOT_FOUND GetUnlockStatusResponse_Result = 1
+)
+
+// Enum value maps for GetUnlockStatusResponse_Result.
+var (
+	GetUnlockStatusResponse_Result_name = map[int32]string{
+		0: "OK",
+		1: "NOT_FOUND",
+	}
+	GetUnlockStatusResponse_Result_value = map[string]int32{
+		"OK":        0,
+		"NOT_FOUND": 1,
+	}
+)
+
+func (x GetUnlockStatusResponse_Result) Enum() *GetUnlockStatusResponse_Result {
+	p := new(GetUnlockStatusResponse_Result)
+	*p = x
+	return p
+}
+
+func (x GetUnlockStatusResponse_Result) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GetUnlockStatusResponse_Result) Descriptor() protoreflect.EnumDescriptor {
+	return file_unlock_service_proto_enumTypes[3].Descriptor()
+}
+
+func (GetUnlockStatusResponse_Result) Type() protoreflect.EnumType {
+	return &file_unlock_service_proto_enumTypes[3]
+}
+
+func (x GetUnlockStatusResponse_Result) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GetUnlockStatusResponse_Result.Descriptor instead.
+func (GetUnlockStatusResponse_Result) EnumDescriptor() ([]byte, []int) {
+	return file_unlock_service_proto_rawDescGZIP(), []int{7, 0}
+}
+
+type UnlockStatus_State int32
+
+const (
+	UnlockStatus_UNKNOWN UnlockStatus_State = 0
+	// The account is locked and managed by the operator.
+	UnlockStatus_LOCKED UnlockStatus_State = 1
+	// The owner requested an unlock, but the waiting period hasn't been
+	// observed on the blockchain.
+	UnlockStatus_REQUESTED UnlockStatus_State = 2
+	// The waiting period has started on the blockchain.
+	UnlockStatus_WAITING_FOR_TIMEOUT UnlockStatus_State = 3
+	// The account is unlocked, and the owner can withdraw funds directly.
+	UnlockStatus_UNLOCKED UnlockStatus_State = 4
+	// The account is closed.
+	UnlockStatus_CLOSED UnlockStatus_State = 5
+)
+
+// Enum value maps for UnlockStatus_State.
+var (
+	UnlockStatus_State_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "LOCKED",
+		2: "REQUESTED",
+		3: "WAITING_FOR_TIMEOUT",
+		4: "UNLOCKED",
+		5: "CLOSED",
+	}
+	UnlockStatus_State_value = map[string]int32{
+		"UNKNOWN":             0,
+		"LOCKED":              1,
+		"REQUESTED":           2,
+		"WAITING_FOR_TIMEOUT": 3,
+		"UNLOCKED":            4,
+		"CLOSED":              5,
+	}
+)
+
+func (x UnlockStatus_State) Enum() *UnlockStatus_State {
+	p := new(UnlockStatus_State)
+	*p = x
+	return p
+}
+
+func (x UnlockStatus_State) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UnlockStatus_State) Descriptor() protoreflect.EnumDescriptor {
+	return file_unlock_service_proto_enumTypes[4].Descriptor()
+}
+
+func (UnlockStatus_State) Type() protoreflect.EnumType {
+	return &file_unlock_service_proto_enumTypes[4]
+}
+
+func (x UnlockStatus_State) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UnlockStatus_State.Descriptor instead.
+func (UnlockStatus_State) EnumDescriptor() ([]byte, []int) {
+	return file_unlock_service_proto_rawDescGZIP(), []int{8, 0}
+}
+
+type InitiateUnlockRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The owner account of the timelock account.
+	Owner *v1.SolanaAccountId `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	// The mint of the timelock account.
+	Mint *v1.SolanaAccountId `protobuf:"bytes,2,opt,name=mint,proto3" json:"mint,omitempty"`
+	// Signature of the request by the owner.
+	Signature     *v1.Signature `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InitiateUnlockRequest) Reset() {
+	*x = InitiateUnlockRequest{}
+	mi := &file_unlock_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InitiateUnlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InitiateUnlockRequest) ProtoMessage() {}
+
+func (x *InitiateUnlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_unlock_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InitiateUnlockRequest.ProtoReflect.Descriptor instead.
+func (*InitiateUnlockRequest) Descriptor() ([]byte, []int) {
+	return file_unlock_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *InitiateUnlockRequest) GetOwner() *v1.SolanaAccountId {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+func (x *InitiateUnlockRequest) GetMint() *v1.SolanaAccountId {
+	if x != nil {
+		return x.Mint
+	}
+	return nil
+}
+
+func (x *InitiateUnlockRequest) GetSignature() *v1.Signature {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type InitiateUnlockResponse struct {
+	state  protoimpl.MessageState        `protogen:"open.v1"`
+	Result InitiateUnlockResponse_Result `protobuf:"varint,1,opt,name=result,proto3,enum=ocp.unlock.v1.InitiateUnlockResponse_Result" json:"result,omitempty"`
+	// The transaction that starts the waiting period, which is signed by the
+	// fee payer and must be signed by the owner before it's submitted. It's not
+	// set when the waiting period was already started on the blockchain.
+	Transaction   *v1.Transaction `protobuf:"bytes,2,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Status        *UnlockStatus   `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InitiateUnlockResponse) Reset() {
+	*x = InitiateUnlockResponse{}
+	mi := &file_unlock_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InitiateUnlockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InitiateUnlockResponse) ProtoMessage() {}
+
+func (x *InitiateUnlockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_unlock_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InitiateUnlockResponse.ProtoReflect.Descriptor instead.
+func (*InitiateUnlockResponse) Descriptor() ([]byte, []int) {
+	return file_unlock_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *InitiateUnlockResponse) GetResult() InitiateUnlockResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return InitiateUnlockResponse_OK
+}
+
+func (x *InitiateUnlockResponse) GetTransaction() *v1.Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *InitiateUnlockResponse) GetStatus() *UnlockStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type CompleteUnlockRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The owner account of the timelock account.
+	Owner *v1.SolanaAccountId `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	// The mint of the timelock account.
+	Mint *v1.SolanaAccountId `protobuf:"bytes,2,opt,name=mint,proto3" json:"mint,omitempty"`
+	// Signature of the request by the owner.
+	Signature     *v1.Signature `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteUnlockRequest) Reset() {
+	*x = CompleteUnlockRequest{}
+	mi := &file_unlock_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteUnlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteUnlockRequest) ProtoMessage() {}
+
+func (x *CompleteUnlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_unlock_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteUnlockRequest.ProtoReflect.Descriptor instead.
+func (*CompleteUnlockRequest) Descriptor() ([]byte, []int) {
+	return file_unlock_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CompleteUnlockRequest) GetOwner() *v1.SolanaAccountId {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+func (x *CompleteUnlockRequest) GetMint() *v1.SolanaAccountId {
+	if x != nil {
+		return x.Mint
+	}
+	return nil
+}
+
+func (x *CompleteUnlockRequest) GetSignature() *v1.Signature {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type CompleteUnlockResponse struct {
+	state  protoimpl.MessageState        `protogen:"open.v1"`
+	Result CompleteUnlockResponse_Result `protobuf:"varint,1,opt,name=result,proto3,enum=ocp.unlock.v1.CompleteUnlockResponse_Result" json:"result,omitempty"`
+	// The transaction that unlocks the account, which is signed by the fee
+	// payer and must be signed by the owner before it's submitted.
+	Transaction   *v1.Transaction `protobuf:"bytes,2,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Status        *UnlockStatus   `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteUnlockResponse) Reset() {
+	*x = CompleteUnlockResponse{}
+	mi := &file_unlock_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteUnlockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteUnlockResponse) ProtoMessage() {}
+
+func (x *CompleteUnlockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_unlock_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteUnlockResponse.ProtoReflect.Descriptor instead.
+func (*CompleteUnlockResponse) Descriptor() ([]byte, []int) {
+	return file_unlock_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CompleteUnlockResponse) GetResult() CompleteUnlockResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return CompleteUnlockResponse_OK
+}
+
+func (x *CompleteUnlockResponse) GetTransaction() *v1.Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *CompleteUnlockResponse) GetStatus() *UnlockStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type CompleteWithdrawRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The owner account of the timelock account.
+	Owner *v1.SolanaAccountId `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	// The mint of the timelock account.
+	Mint *v1.SolanaAccountId `protobuf:"bytes,2,opt,name=mint,proto3" json:"mint,omitempty"`
+	// The token account that receives the withdrawn funds.
+	Destination *v1.SolanaAccountId `protobuf:"bytes,3,opt,name=destination,proto3" json:"destination,omitempty"`
+	// Signature of the request by the owner.
+	Signature     *v1.Signature `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteWithdrawRequest) Reset() {
+	*x = CompleteWithdrawRequest{}
+	mi := &file_unlock_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteWithdrawRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteWithdrawRequest) ProtoMessage() {}
+
+func (x *CompleteWithdrawRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_unlock_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteWithdrawRequest.ProtoReflect.Descriptor instead.
+func (*CompleteWithdrawRequest) Descriptor() ([]byte, []int) {
+	return file_unlock_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CompleteWithdrawRequest) GetOwner() *v1.SolanaAccountId {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+func (x *CompleteWithdrawRequest) GetMint() *v1.SolanaAccountId {
+	if x != nil {
+		return x.Mint
+	}
+	return nil
+}
+
+func (x *CompleteWithdrawRequest) GetDestination() *v1.SolanaAccountId {
+	if x != nil {
+		return x.Destination
+	}
+	return nil
+}
+
+func (x *CompleteWithdrawRequest) GetSignature() *v1.Signature {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type CompleteWithdrawResponse struct {
+	state  protoimpl.MessageState          `protogen:"open.v1"`
+	Result CompleteWithdrawResponse_Result `protobuf:"varint,1,opt,name=result,proto3,enum=ocp.unlock.v1.CompleteWithdrawResponse_Result" json:"result,omitempty"`
+	// The transactions that withdraw funds from the virtual timelock account
+	// and its deposit account. Each is signed by the fee payer and must be
+	// signed by the owner before it's submitted.
+	Transactions  []*v1.Transaction `protobuf:"bytes,2,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	Status        *UnlockStatus     `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteWithdrawResponse) Reset() {
+	*x = CompleteWithdrawResponse{}
+	mi := &file_unlock_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteWithdrawResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteWithdrawResponse) ProtoMessage() {}
+
+func (x *CompleteWithdrawResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_unlock_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteWithdrawResponse.ProtoReflect.Descriptor instead.
+func (*CompleteWithdrawResponse) Descriptor() ([]byte, []int) {
+	return file_unlock_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CompleteWithdrawResponse) GetResult() CompleteWithdrawResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return CompleteWithdrawResponse_OK
+}
+
+func (x *CompleteWithdrawResponse) GetTransactions() []*v1.Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *CompleteWithdrawResponse) GetStatus() *UnlockStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type GetUnlockStatusRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The owner account of the timelock account.
+	Owner *v1.SolanaAccountId `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	// The mint of the timelock account.
+	Mint *v1.SolanaAccountId `protobuf:"bytes,2,opt,name=mint,proto3" json:"mint,omitempty"`
+	// Signature of the request by the owner.
+	Signature     *v1.Signature `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUnlockStatusRequest) Reset() {
+	*x = GetUnlockStatusRequest{}
+	mi := &file_unlock_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUnlockStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUnlockStatusRequest) ProtoMessage() {}
+
+func (x *GetUnlockStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_unlock_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUnlockStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetUnlockStatusRequest) Descriptor() ([]byte, []int) {
+	return file_unlock_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetUnlockStatusRequest) GetOwner() *v1.SolanaAccountId {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+func (x *GetUnlockStatusRequest) GetMint() *v1.SolanaAccountId {
+	if x != nil {
+		return x.Mint
+	}
+	return nil
+}
+
+func (x *GetUnlockStatusRequest) GetSignature() *v1.Signature {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type GetUnlockStatusResponse struct {
+	state         protoimpl.MessageState         `protogen:"open.v1"`
+	Result        GetUnlockStatusResponse_Result `protobuf:"varint,1,opt,name=result,proto3,enum=ocp.unlock.v1.GetUnlockStatusResponse_Result" json:"result,omitempty"`
+	Status        *UnlockStatus                  `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUnlockStatusResponse) Reset() {
+	*x = GetUnlockStatusResponse{}
+	mi := &file_unlock_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUnlockStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUnlockStatusResponse) ProtoMessage() {}
+
+func (x *GetUnlockStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_unlock_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUnlockStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetUnlockStatusResponse) Descriptor() ([]byte, []int) {
+	return file_unlock_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetUnlockStatusResponse) GetResult() GetUnlockStatusResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return GetUnlockStatusResponse_OK
+}
+
+func (x *GetUnlockStatusResponse) GetStatus() *UnlockStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type UnlockStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	State UnlockStatus_State     `protobuf:"varint,1,opt,name=state,proto3,enum=ocp.unlock.v1.UnlockStatus_State" json:"state,omitempty"`
+	// When the owner requested the unlock, if they have.
+	RequestedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
+	// When the waiting period elapses, once it's observed on the blockchain.
+	UnlockAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=unlock_at,json=unlockAt,proto3" json:"unlock_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlockStatus) Reset() {
+	*x = UnlockStatus{}
+	mi := &file_unlock_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlockStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockStatus) ProtoMessage() {}
+
+func (x *UnlockStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_unlock_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockStatus.ProtoReflect.Descriptor instead.
+func (*UnlockStatus) Descriptor() ([]byte, []int) {
+	return file_unlock_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UnlockStatus) GetState() UnlockStatus_State {
+	if x != nil {
+		return x.State
+	}
+	return UnlockStatus_UNKNOWN
+}
+
+func (x *UnlockStatus) GetRequestedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RequestedAt
+	}
+	return nil
+}
+
+func (x *UnlockStatus) GetUnlockAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UnlockAt
+	}
+	return nil
+}
+
+var File_unlock_service_proto protoreflect.FileDescriptor
+
+const file_unlock_service_proto_rawDesc = "" +
+	"\n" +
+	"\x14unlock_service.proto\x12\rocp.unlock.v1\x1a\x15common/v1/model.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb9\x01\n" +
+	"\x15InitiateUnlockRequest\x124\n" +
+	"\x05owner\x18\x01 \x01(\v2\x1e.ocp.common.v1.SolanaAccountIdR\x05owner\x122\n" +
+	"\x04mint\x18\x02 \x01(\v2\x1e.ocp.common.v1.SolanaAccountIdR\x04mint\x126\n" +
+	"\tsignature\x18\x03 \x01(\v2\x18.ocp.common.v1.SignatureR\tsignature\"\x88\x02\n" +
+	"\x16InitiateUnlockResponse\x12D\n" +
+	"\x06result\x18\x01 \x01(\x0e2,.ocp.unlock.v1.InitiateUnlockResponse.ResultR\x06result\x12<\n" +
+	"\vtransaction\x18\x02 \x01(\v2\x1a.ocp.common.v1.TransactionR\vtransaction\x123\n" +
+	"\x06status\x18\x03 \x01(\v2\x1b.ocp.unlock.v1.UnlockStatusR\x06status\"5\n" +
+	"\x06Result\x12\x06\n" +
+	"\x02OK\x10\x00\x12\r\n" +
+	"\tNOT_FOUND\x10\x01\x12\x14\n" +
+	"\x10ALREADY_UNLOCKED\x10\x02\"\xb9\x01\n" +
+	"\x15CompleteUnlockRequest\x124\n" +
+	"\x05owner\x18\x01 \x01(\v2\x1e.ocp.common.v1.SolanaAccountIdR\x05owner\x122\n" +
+	"\x04mint\x18\x02 \x01(\v2\x1e.ocp.common.v1.SolanaAccountIdR\x04mint\x126\n" +
+	"\tsignature\x18\x03 \x01(\v2\x18.ocp.common.v1.SignatureR\tsignature\"\xb4\x02\n" +
+	"\x16CompleteUnlockResponse\x12D\n" +
+	"\x06result\x18\x01 \x01(\x0e2,.ocp.unlock.v1.CompleteUnlockResponse.ResultR\x06result\x12<\n" +
+	"\vtransaction\x18\x02 \x01(\v2\x1a.ocp.common.v1.TransactionR\vtransaction\x123\n" +
+	"\x06status\x18\x03 \x01(\v2\x1b.ocp.unlock.v1.UnlockStatusR\x06status\"a\n" +
+	"\x06Result\x12\x06\n" +
+	"\x02OK\x10\x00\x12\r\n" +
+	"\tNOT_FOUND\x10\x01\x12\x11\n" +
+	"\rNOT_INITIATED\x10\x02\x12\x17\n" +
+	"\x13WAITING_FOR_TIMEOUT\x10\x03\x12\x14\n" +
+	"\x10ALREADY_UNLOCKED\x10\x04\"\xfd\x01\n" +
+	"\x17CompleteWithdrawRequest\x124\n" +
+	"\x05owner\x18\x01 \x01(\v2\x1e.ocp.common.v1.SolanaAccountIdR\x05owner\x122\n" +
+	"\x04mint\x18\x02 \x01(\v2\x1e.ocp.common.v1.SolanaAccountIdR\x04mint\x12@\n" +
+	"\vdestination\x18\x03 \x01(\v2\x1e.ocp.common.v1.SolanaAccountIdR\vdestination\x126\n" +
+	"\tsignature\x18\x04 \x01(\v2\x18.ocp.common.v1.SignatureR\tsignature\"\xab\x02\n" +
+	"\x18CompleteWithdrawResponse\x12F\n" +
+	"\x06result\x18\x01 \x01(\x0e2..ocp.unlock.v1.CompleteWithdrawResponse.ResultR\x06result\x12>\n" +
+	"\ftransactions\x18\x02 \x03(\v2\x1a.ocp.common.v1.TransactionR\ftransactions\x123\n" +
+	"\x06status\x18\x03 \x01(\v2\x1b.ocp.unlock.v1.UnlockStatusR\x06status\"R\n" +
+	"\x06Result\x12\x06\n" +
+	"\x02OK\x10\x00\x12\r\n" +
+	"\tNOT_FOUND\x10\x01\x12\x10\n" +
+	"\fNOT_UNLOCKED\x10\x02\x12\x0e\n" +
+	"\n" +
+	"NO_BALANCE\x10\x03\x12\x0f\n" +
+	"\vUNAVAILABLE\x10\x04\"\xba\x01\n" +
+	"\x16GetUnlockStatusRequest\x124\n" +
+	"\x05owner\x18\x01 \x01(\v2\x1e.ocp.common.v1.SolanaAccountIdR\x05owner\x122\n" +
+	"\x04mint\x18\x02 \x01(\v2\x1e.ocp.common.v1.SolanaAccountIdR\x04mint\x126\n" +
+	"\tsignature\x18\x03 \x01(\v2\x18.ocp.common.v1.SignatureR\tsignature\"\xb6\x01\n" +
+	"\x17GetUnlockStatusResponse\x12E\n" +
+	"\x06result\x18\x01 \x01(\x0e2-.ocp.unlock.v1.GetUnlockStatusResponse.ResultR\x06result\x123\n" +
+	"\x06status\x18\x02 \x01(\v2\x1b.ocp.unlock.v1.UnlockStatusR\x06status\"\x1f\n" +
+	"\x06Result\x12\x06\n" +
+	"\x02OK\x10\x00\x12\r\n" +
+	"\tNOT_FOUND\x10\x01\"\xa3\x02\n" +
+	"\fUnlockStatus\x127\n" +
+	"\x05state\x18\x01 \x01(\x0e2!.ocp.unlock.v1.UnlockStatus.StateR\x05state\x12=\n" +
+	"\frequested_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vrequestedAt\x127\n" +
+	"\tunlock_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bunlockAt\"b\n" +
+	"\x05State\x12\v\n" +
+	"\aUNKNOWN\x10\x00\x12\n" +
+	"\n" +
+	"\x06LOCKED\x10\x01\x12\r\n" +
+	"\tREQUESTED\x10\x02\x12\x17\n" +
+	"\x13WAITING_FOR_TIMEOUT\x10\x03\x12\f\n" +
+	"\bUNLOCKED\x10\x04\x12\n" +
+	"\n" +
+	"\x06CLOSED\x10\x052\x8d\x03\n" +
+	"\x06Unlock\x12]\n" +
+	"\x0eInitiateUnlock\x12$.ocp.unlock.v1.InitiateUnlockRequest\x1a%.ocp.unlock.v1.InitiateUnlockResponse\x12]\n" +
+	"\x0eCompleteUnlock\x12$.ocp.unlock.v1.CompleteUnlockRequest\x1a%.ocp.unlock.v1.CompleteUnlockResponse\x12c\n" +
+	"\x10CompleteWithdraw\x12&.ocp.unlock.v1.CompleteWithdrawRequest\x1a'.ocp.unlock.v1.CompleteWithdrawResponse\x12`\n" +
+	"\x0fGetUnlockStatus\x12%.ocp.unlock.v1.GetUnlockStatusRequest\x1a&.ocp.unlock.v1.GetUnlockStatusResponseB\n" +
+	"Z\b.;unlockb\x06proto3"
+
+var (
+	file_unlock_service_proto_rawDescOnce sync.Once
+	file_unlock_service_proto_rawDescData []byte
+)
+
+func file_unlock_service_proto_rawDescGZIP() []byte {
+	file_unlock_service_proto_rawDescOnce.Do(func() {
+		file_unlock_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_unlock_service_proto_rawDesc), len(file_unlock_service_proto_rawDesc)))
+	})
+	return file_unlock_service_proto_rawDescData
+}
+
+var file_unlock_service_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_unlock_service_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_unlock_service_proto_goTypes = []any{
+	(InitiateUnlockResponse_Result)(0),   // 0: ocp.unlock.v1.InitiateUnlockResponse.Result
+	(CompleteUnlockResponse_Result)(0),   // 1: ocp.unlock.v1.CompleteUnlockResponse.Result
+	(CompleteWithdrawResponse_Result)(0), // 2: ocp.unlock.v1.CompleteWithdrawResponse.Result
+	(GetUnlockStatusResponse_Result)(0),  // 3: ocp.unlock.v1.GetUnlockStatusResponse.Result
+	(UnlockStatus_State)(0),              // 4: ocp.unlock.v1.UnlockStatus.State
+	(*InitiateUnlockRequest)(nil),        // 5: ocp.unlock.v1.InitiateUnlockRequest
+	(*InitiateUnlockResponse)(nil),       // 6: ocp.unlock.v1.InitiateUnlockResponse
+	(*CompleteUnlockRequest)(nil),        // 7: ocp.unlock.v1.CompleteUnlockRequest
+	(*CompleteUnlockResponse)(nil),       // 8: ocp.unlock.v1.CompleteUnlockResponse
+	(*CompleteWithdrawRequest)(nil),      // 9: ocp.unlock.v1.CompleteWithdrawRequest
+	(*CompleteWithdrawResponse)(nil),     // 10: ocp.unlock.v1.CompleteWithdrawResponse
+	(*GetUnlockStatusRequest)(nil),       // 11: ocp.unlock.v1.GetUnlockStatusRequest
+	(*GetUnlockStatusResponse)(nil),      // 12: ocp.unlock.v1.GetUnlockStatusResponse
+	(*UnlockStatus)(nil),                 // 13: ocp.unlock.v1.UnlockStatus
+	(*v1.SolanaAccountId)(nil),           // 14: ocp.common.v1.SolanaAccountId
+	(*v1.Signature)(nil),                 // 15: ocp.common.v1.Signature
+	(*v1.Transaction)(nil),               // 16: ocp.common.v1.Transaction
+	(*timestamppb.Timestamp)(nil),        // 17: google.protobuf.Timestamp
+}
+var file_unlock_service_proto_depIdxs = []int32{
+	14, // 0: ocp.unlock.v1.InitiateUnlockRequest.owner:type_name -> ocp.common.v1.SolanaAccountId
+	14, // 1: ocp.unlock.v1.InitiateUnlockRequest.mint:type_name -> ocp.common.v1.SolanaAccountId
+	15, // 2: ocp.unlock.v1.InitiateUnlockRequest.signature:type_name -> ocp.common.v1.Signature
+	0,  // 3: ocp.unlock.v1.InitiateUnlockResponse.result:type_name -> ocp.unlock.v1.InitiateUnlockResponse.Result
+	16, // 4: ocp.unlock.v1.InitiateUnlockResponse.transaction:type_name -> ocp.common.v1.Transaction
+	13, // 5: ocp.unlock.v1.InitiateUnlockResponse.status:type_name -> ocp.unlock.v1.UnlockStatus
+	14, // 6: ocp.unlock.v1.CompleteUnlockRequest.owner:type_name -> ocp.common.v1.SolanaAccountId
+	14, // 7: ocp.unlock.v1.CompleteUnlockRequest.mint:type_name -> ocp.common.v1.SolanaAccountId
+	15, // 8: ocp.unlock.v1.CompleteUnlockRequest.signature:type_name -> ocp.common.v1.Signature
+	1,  // 9: ocp.unlock.v1.CompleteUnlockResponse.result:type_name -> ocp.unlock.v1.CompleteUnlockResponse.Result
+	16, // 10: ocp.unlock.v1.CompleteUnlockResponse.transaction:type_name -> ocp.common.v1.Transaction
+	13, // 11: ocp.unlock.v1.CompleteUnlockResponse.status:type_name -> ocp.unlock.v1.UnlockStatus
+	14, // 12: ocp.unlock.v1.CompleteWithdrawRequest.owner:type_name -> ocp.common.v1.SolanaAccountId
+	14, // 13: ocp.unlock.v1.CompleteWithdrawRequest.mint:type_name -> ocp.common.v1.SolanaAccountId
+	14, // 14: ocp.unlock.v1.CompleteWithdrawRequest.destination:type_name -> ocp.common.v1.SolanaAccountId
+	15, // 15: ocp.unlock.v1.CompleteWithdrawRequest.signature:type_name -> ocp.common.v1.Signature
+	2,  // 16: ocp.unlock.v1.CompleteWithdrawResponse.result:type_name -> ocp.unlock.v1.CompleteWithdrawResponse.Result
+	16, // 17: ocp.unlock.v1.CompleteWithdrawResponse.transactions:type_name -> ocp.common.v1.Transaction
+	13, // 18: ocp.unlock.v1.CompleteWithdrawResponse.status:type_name -> ocp.unlock.v1.UnlockStatus
+	14, // 19: ocp.unlock.v1.GetUnlockStatusRequest.owner:type_name -> ocp.common.v1.SolanaAccountId
+	14, // 20: ocp.unlock.v1.GetUnlockStatusRequest.mint:type_name -> ocp.common.v1.SolanaAccountId
+	15, // 21: ocp.unlock.v1.GetUnlockStatusRequest.signature:type_name -> ocp.common.v1.Signature
+	3,  // 22: ocp.unlock.v1.GetUnlockStatusResponse.result:type_name -> ocp.unlock.v1.GetUnlockStatusResponse.Result
+	13, // 23: ocp.unlock.v1.GetUnlockStatusResponse.status:type_name -> ocp.unlock.v1.UnlockStatus
+	4,  // 24: ocp.unlock.v1.UnlockStatus.state:type_name -> ocp.unlock.v1.UnlockStatus.State
+	17, // 25: ocp.unlock.v1.UnlockStatus.requested_at:type_name -> google.protobuf.Timestamp
+	17, // 26: ocp.unlock.v1.UnlockStatus.unlock_at:type_name -> google.protobuf.Timestamp
+	5,  // 27: ocp.unlock.v1.Unlock.InitiateUnlock:input_type -> ocp.unlock.v1.InitiateUnlockRequest
+	7,  // 28: ocp.unlock.v1.Unlock.CompleteUnlock:input_type -> ocp.unlock.v1.CompleteUnlockRequest
+	9,  // 29: ocp.unlock.v1.Unlock.CompleteWithdraw:input_type -> ocp.unlock.v1.CompleteWithdrawRequest
+	11, // 30: ocp.unlock.v1.Unlock.GetUnlockStatus:input_type -> ocp.unlock.v1.GetUnlockStatusRequest
+	6,  // 31: ocp.unlock.v1.Unlock.InitiateUnlock:output_type -> ocp.unlock.v1.InitiateUnlockResponse
+	8,  // 32: ocp.unlock.v1.Unlock.CompleteUnlock:output_type -> ocp.unlock.v1.CompleteUnlockResponse
+	10, // 33: ocp.unlock.v1.Unlock.CompleteWithdraw:output_type -> ocp.unlock.v1.CompleteWithdrawResponse
+	12, // 34: ocp.unlock.v1.Unlock.GetUnlockStatus:output_type -> ocp.unlock.v1.GetUnlockStatusResponse
+	31, // [31:35] is the sub-list for method output_type
+	27, // [27:31] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
+}
+
+func init() { file_unlock_service_proto_init() }
+func file_unlock_service_proto_init() {
+	if File_unlock_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_unlock_service_proto_rawDesc), len(file_unlock_service_proto_rawDesc)),
+			NumEnums:      5,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_unlock_service_proto_goTypes,
+		DependencyIndexes: file_unlock_service_proto_depIdxs,
+		EnumInfos:         file_unlock_service_proto_enumTypes,
+		MessageInfos:      file_unlock_service_proto_msgTypes,
+	}.Build()
+	File_unlock_service_proto = out.File
+	file_unlock_service_proto_goTypes = nil
+	file_unlock_service_proto_depIdxs = nil
+}
@@ -30,6 +30,11 @@ var (
 	giftCardCacheByOwner = cache.NewCache(10_000)
 )
 
+type cachedGiftCardResponse struct {
+	resp      *accountpb.GetTokenAccountInfosResponse
+	expiresAt time.Time
+}
+
 type balanceMetadata struct {
 	value  uint64
 	source accountpb.TokenAccountInfo_BalanceSource
@@ -141,11 +146,11 @@ func (s *server) GetTokenAccountInfos(ctx context.Context, req *accountpb.GetTok
 
 	cachedResp, ok := giftCardCacheByOwner.Retrieve(owner.PublicKey().ToBase58())
 	if ok {
-		cachedResp := cachedResp.(*accountpb.GetTokenAccountInfosResponse)
+		cachedResp := cachedResp.(*cachedGiftCardResponse)
 
 		s.updateCachedResponse(cachedResp)
 
-		resp, err := s.addRequestingOwnerMetadata(ctx, cachedResp, requestingOwner)
+		resp, err := s.addRequestingOwnerMetadata(ctx, cachedResp.resp, requestingOwner)
 		if err != nil {
 			log.With(zap.Error(err)).Warn("failure adding requesting owner metadata")
 			return nil, status.Error(codes.Internal, "")
@@ -268,7 +273,10 @@ func (s *server) GetTokenAccountInfos(ctx context.Context, req *accountpb.GetTok
 
 		switch tokenAccountInfo.ClaimState {
 		case accountpb.TokenAccountInfo_CLAIM_STATE_CLAIMED, accountpb.TokenAccountInfo_CLAIM_STATE_EXPIRED:
-			giftCardCacheByOwner.Insert(owner.PublicKey().ToBase58(), resp, 1)
+			giftCardCacheByOwner.Insert(owner.PublicKey().ToBase58(), &cachedGiftCardResponse{
+				resp:      resp,
+				expiresAt: account_worker.GetGiftCardExpiresAt(filteredRecords[0].General),
+			}, 1)
 		}
 	}
 
@@ -390,6 +398,12 @@ func (s *server) getProtoAccountInfo(ctx context.Context, records *common.Accoun
 		default:
 			managementState = accountpb.TokenAccountInfo_MANAGEMENT_STATE_UNKNOWN
 		}
+
+		// The owner requested to unlock the account, but the unlock hasn't been
+		// observed on the blockchain yet
+		if managementState == accountpb.TokenAccountInfo_MANAGEMENT_STATE_LOCKED && records.Timelock.IsUnlockRequested() {
+			managementState = accountpb.TokenAccountInfo_MANAGEMENT_STATE_UNLOCKING
+		}
 	}
 
 	blockchainState := accountpb.TokenAccountInfo_BLOCKCHAIN_STATE_DOES_NOT_EXIST
@@ -438,7 +452,7 @@ func (s *server) getProtoAccountInfo(ctx context.Context, records *common.Accoun
 
 		// Gift cards that are close to the auto-return window are marked as expired in
 		// a consistent manner as SubmitIntent to avoid race conditions with the auto-return.
-		if time.Since(records.General.CreatedAt) >= account_worker.GiftCardExpiry-time.Minute {
+		if !time.Now().Before(account_worker.GetGiftCardExpiresAt(records.General).Add(-time.Minute)) {
 			claimState = accountpb.TokenAccountInfo_CLAIM_STATE_EXPIRED
 		}
 
@@ -527,12 +541,12 @@ func (s *server) addRequestingOwnerMetadata(ctx context.Context, resp *accountpb
 	return cloned, nil
 }
 
-func (s *server) updateCachedResponse(resp *accountpb.GetTokenAccountInfosResponse) {
-	for _, ai := range resp.TokenAccountInfos {
+func (s *server) updateCachedResponse(cached *cachedGiftCardResponse) {
+	for _, ai := range cached.resp.TokenAccountInfos {
 		switch ai.AccountType {
 		case commonpb.AccountType_REMOTE_SEND_GIFT_CARD:
 			// Transition any gift card records to expired if we elapsed the expiry window
-			if time.Since(ai.CreatedAt.AsTime()) >= account_worker.GiftCardExpiry-time.Minute {
+			if !time.Now().Before(cached.expiresAt.Add(-time.Minute)) {
 				ai.ClaimState = accountpb.TokenAccountInfo_CLAIM_STATE_EXPIRED
 				ai.BalanceSource = accountpb.TokenAccountInfo_BALANCE_SOURCE_CACHE
 				ai.Balance = 0
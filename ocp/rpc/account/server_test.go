@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
@@ -528,6 +529,86 @@ func TestGetTokenAccountInfos_RemoteSendGiftCard_HappyPath(t *testing.T) {
 	}
 }
 
+func TestGetTokenAccountInfos_RemoteSendGiftCard_Expiry(t *testing.T) {
+	env, cleanup := setup(t)
+	defer cleanup()
+
+	vmConfig := testutil.NewRandomVmConfig(t, false)
+
+	for _, tc := range []struct {
+		age                time.Duration
+		expiry             time.Duration
+		expectedClaimState accountpb.TokenAccountInfo_ClaimState
+	}{
+		{25 * time.Hour, 24 * time.Hour, accountpb.TokenAccountInfo_CLAIM_STATE_EXPIRED},
+		{24*time.Hour - 30*time.Second, 24 * time.Hour, accountpb.TokenAccountInfo_CLAIM_STATE_EXPIRED},
+		{time.Hour, 24 * time.Hour, accountpb.TokenAccountInfo_CLAIM_STATE_NOT_CLAIMED},
+		{8 * 24 * time.Hour, 30 * 24 * time.Hour, accountpb.TokenAccountInfo_CLAIM_STATE_NOT_CLAIMED},
+	} {
+		giftCardOwnerAccount := testutil.NewRandomAccount(t)
+
+		accountRecords := getDefaultTestAccountRecords(t, giftCardOwnerAccount, giftCardOwnerAccount, vmConfig, 0, commonpb.AccountType_REMOTE_SEND_GIFT_CARD)
+		accountRecords.General.CreatedAt = time.Now().Add(-tc.age)
+		accountRecords.General.GiftCardExpiresAt = pointer.Time(accountRecords.General.CreatedAt.Add(tc.expiry))
+		accountRecords.General.RequiresAutoReturnCheck = true
+		require.NoError(t, env.data.CreateAccountInfo(env.ctx, accountRecords.General))
+
+		accountRecords.Timelock.VaultState = timelock_token_v1.StateLocked
+		accountRecords.Timelock.Block += 1
+		require.NoError(t, env.data.SaveTimelock(env.ctx, accountRecords.Timelock))
+
+		require.NoError(t, env.data.SaveIntent(env.ctx, &intent.Record{
+			IntentId:   testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+			IntentType: intent.SendPublicPayment,
+
+			MintAccount: vmConfig.Mint.PublicKey().ToBase58(),
+
+			InitiatorOwnerAccount: testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+
+			SendPublicPaymentMetadata: &intent.SendPublicPaymentMetadata{
+				DestinationTokenAccount: accountRecords.General.TokenAccount,
+				Quantity:                common.ToCoreMintQuarks(10),
+
+				ExchangeCurrency: currency.CAD,
+				ExchangeRate:     1.23,
+				NativeAmount:     12.3,
+				UsdMarketValue:   24.6,
+
+				IsRemoteSend: true,
+			},
+
+			State: intent.StatePending,
+		}))
+
+		setupCachedBalance(t, env, accountRecords, 42)
+
+		req := &accountpb.GetTokenAccountInfosRequest{
+			Owner: giftCardOwnerAccount.ToProto(),
+		}
+		reqBytes, err := proto.Marshal(req)
+		require.NoError(t, err)
+		req.Signature = &commonpb.Signature{
+			Value: ed25519.Sign(giftCardOwnerAccount.PrivateKey().ToBytes(), reqBytes),
+		}
+
+		// Second call is served from the cache for expired gift cards
+		for i := 0; i < 2; i++ {
+			resp, err := env.client.GetTokenAccountInfos(env.ctx, req)
+			require.NoError(t, err)
+			assert.Equal(t, accountpb.GetTokenAccountInfosResponse_OK, resp.Result)
+
+			accountInfo, ok := resp.TokenAccountInfos[accountRecords.General.TokenAccount]
+			require.True(t, ok)
+			assert.Equal(t, tc.expectedClaimState, accountInfo.ClaimState)
+			if tc.expectedClaimState == accountpb.TokenAccountInfo_CLAIM_STATE_EXPIRED {
+				assert.EqualValues(t, 0, accountInfo.Balance)
+			} else {
+				assert.EqualValues(t, 42, accountInfo.Balance)
+			}
+		}
+	}
+}
+
 func TestGetTokenAccountInfos_BlockchainState(t *testing.T) {
 	env, cleanup := setup(t)
 	defer cleanup()
@@ -579,9 +660,10 @@ func TestGetTokenAccountInfos_ManagementState(t *testing.T) {
 	coreVmConfig := testutil.NewRandomVmConfig(t, true)
 
 	for _, tc := range []struct {
-		timelockState timelock_token_v1.TimelockState
-		block         uint64
-		expected      accountpb.TokenAccountInfo_ManagementState
+		timelockState   timelock_token_v1.TimelockState
+		block           uint64
+		unlockRequested bool
+		expected        accountpb.TokenAccountInfo_ManagementState
 	}{
 		{
 			timelockState: timelock_token_v1.StateUnknown,
@@ -611,6 +693,12 @@ func TestGetTokenAccountInfos_ManagementState(t *testing.T) {
 			block:         5,
 			expected:      accountpb.TokenAccountInfo_MANAGEMENT_STATE_CLOSED,
 		},
+		{
+			timelockState:   timelock_token_v1.StateLocked,
+			block:           6,
+			unlockRequested: true,
+			expected:        accountpb.TokenAccountInfo_MANAGEMENT_STATE_UNLOCKING,
+		},
 	} {
 		ownerAccount := testutil.NewRandomAccount(t)
 
@@ -628,6 +716,9 @@ func TestGetTokenAccountInfos_ManagementState(t *testing.T) {
 		accountRecords.Timelock.Block = tc.block
 		require.NoError(t, env.data.CreateAccountInfo(env.ctx, accountRecords.General))
 		require.NoError(t, env.data.SaveTimelock(env.ctx, accountRecords.Timelock))
+		if tc.unlockRequested {
+			require.NoError(t, env.data.MarkTimelockUnlockRequested(env.ctx, accountRecords.Timelock.VaultAddress, time.Now()))
+		}
 
 		resp, err := env.client.GetTokenAccountInfos(env.ctx, req)
 		require.NoError(t, err)
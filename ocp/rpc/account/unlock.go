@@ -0,0 +1,575 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	indexerpb "github.com/code-payments/code-vm-indexer/generated/indexer/v1"
+	commonpb "github.com/code-payments/ocp-protobuf-api/generated/go/common/v1"
+
+	"github.com/code-payments/ocp-server/grpc/client"
+	auth_util "github.com/code-payments/ocp-server/ocp/auth"
+	"github.com/code-payments/ocp-server/ocp/common"
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/timelock"
+	vm_operation "github.com/code-payments/ocp-server/ocp/data/vm/operation"
+	unlockpb "github.com/code-payments/ocp-server/ocp/rpc/account/api/gen"
+	transaction_util "github.com/code-payments/ocp-server/ocp/transaction"
+	vm_util "github.com/code-payments/ocp-server/ocp/vm"
+	"github.com/code-payments/ocp-server/solana"
+	timelock_token_v1 "github.com/code-payments/ocp-server/solana/timelock/v1"
+	"github.com/code-payments/ocp-server/solana/token"
+	"github.com/code-payments/ocp-server/solana/vm"
+)
+
+var errNoWithdrawableBalance = errors.New("no withdrawable balance")
+
+type unlockServer struct {
+	log             *zap.Logger
+	data            ocp_data.Provider
+	vmIndexerClient indexerpb.IndexerClient
+	auth            *auth_util.RPCSignatureVerifier
+
+	unlockpb.UnimplementedUnlockServer
+}
+
+func NewUnlockServer(log *zap.Logger, data ocp_data.Provider, vmIndexerClient indexerpb.IndexerClient) unlockpb.UnlockServer {
+	return &unlockServer{
+		log:             log,
+		data:            data,
+		vmIndexerClient: vmIndexerClient,
+		auth:            auth_util.NewRPCSignatureVerifier(log, data),
+	}
+}
+
+func (s *unlockServer) InitiateUnlock(ctx context.Context, req *unlockpb.InitiateUnlockRequest) (*unlockpb.InitiateUnlockResponse, error) {
+	log := s.log.With(zap.String("method", "InitiateUnlock"))
+	log = client.InjectLoggingMetadata(ctx, log)
+
+	owner, err := common.NewAccountFromProto(req.Owner)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("invalid owner account")
+		return nil, status.Error(codes.Internal, "")
+	}
+	log = log.With(zap.String("owner_account", owner.PublicKey().ToBase58()))
+
+	mint, err := common.NewAccountFromProto(req.Mint)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("invalid mint account")
+		return nil, status.Error(codes.Internal, "")
+	}
+	log = log.With(zap.String("mint_account", mint.PublicKey().ToBase58()))
+
+	signature := req.Signature
+	req.Signature = nil
+	if err := s.auth.Authenticate(ctx, owner, req, signature); err != nil {
+		return nil, err
+	}
+
+	timelockAccounts, timelockRecord, err := s.getTimelockAccount(ctx, owner, mint)
+	if err == common.ErrUnsupportedMint || err == timelock.ErrTimelockNotFound {
+		return &unlockpb.InitiateUnlockResponse{
+			Result: unlockpb.InitiateUnlockResponse_NOT_FOUND,
+		}, nil
+	} else if err != nil {
+		log.With(zap.Error(err)).Warn("failure getting timelock account")
+		return nil, status.Error(codes.Internal, "")
+	}
+	log = log.With(zap.String("vault", timelockRecord.VaultAddress))
+
+	if !timelockRecord.ExistsOnBlockchain() {
+		return &unlockpb.InitiateUnlockResponse{
+			Result: unlockpb.InitiateUnlockResponse_NOT_FOUND,
+		}, nil
+	} else if timelockRecord.VaultState == timelock_token_v1.StateUnlocked {
+		return &unlockpb.InitiateUnlockResponse{
+			Result: unlockpb.InitiateUnlockResponse_ALREADY_UNLOCKED,
+			Status: toProtoUnlockStatus(timelockRecord),
+		}, nil
+	}
+
+	// The sequencer revokes pending fulfillments out of the account from this
+	// point onwards. The request expires if the returned transaction never lands,
+	// and calling InitiateUnlock again after that renews it.
+	err = s.data.MarkTimelockUnlockRequested(ctx, timelockRecord.VaultAddress, time.Now())
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure marking unlock as requested")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	timelockRecord, err = s.data.GetTimelockByVault(ctx, timelockRecord.VaultAddress)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure refreshing timelock record")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	// The waiting period has already started on the blockchain
+	if timelockRecord.VaultState == timelock_token_v1.StateWaitingForTimeout {
+		return &unlockpb.InitiateUnlockResponse{
+			Result: unlockpb.InitiateUnlockResponse_OK,
+			Status: toProtoUnlockStatus(timelockRecord),
+		}, nil
+	}
+
+	blockhash, err := s.data.GetBlockchainLatestBlockhash(ctx)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure getting latest blockhash")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	txn, err := transaction_util.MakeInitUnlockTransaction(blockhash, timelockAccounts)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure making init unlock transaction")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	protoTxn, err := signAsFeePayer(&txn)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure signing init unlock transaction")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return &unlockpb.InitiateUnlockResponse{
+		Result:      unlockpb.InitiateUnlockResponse_OK,
+		Transaction: protoTxn,
+		Status:      toProtoUnlockStatus(timelockRecord),
+	}, nil
+}
+
+func (s *unlockServer) CompleteUnlock(ctx context.Context, req *unlockpb.CompleteUnlockRequest) (*unlockpb.CompleteUnlockResponse, error) {
+	log := s.log.With(zap.String("method", "CompleteUnlock"))
+	log = client.InjectLoggingMetadata(ctx, log)
+
+	owner, err := common.NewAccountFromProto(req.Owner)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("invalid owner account")
+		return nil, status.Error(codes.Internal, "")
+	}
+	log = log.With(zap.String("owner_account", owner.PublicKey().ToBase58()))
+
+	mint, err := common.NewAccountFromProto(req.Mint)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("invalid mint account")
+		return nil, status.Error(codes.Internal, "")
+	}
+	log = log.With(zap.String("mint_account", mint.PublicKey().ToBase58()))
+
+	signature := req.Signature
+	req.Signature = nil
+	if err := s.auth.Authenticate(ctx, owner, req, signature); err != nil {
+		return nil, err
+	}
+
+	timelockAccounts, timelockRecord, err := s.getTimelockAccount(ctx, owner, mint)
+	if err == common.ErrUnsupportedMint || err == timelock.ErrTimelockNotFound {
+		return &unlockpb.CompleteUnlockResponse{
+			Result: unlockpb.CompleteUnlockResponse_NOT_FOUND,
+		}, nil
+	} else if err != nil {
+		log.With(zap.Error(err)).Warn("failure getting timelock account")
+		return nil, status.Error(codes.Internal, "")
+	}
+	log = log.With(zap.String("vault", timelockRecord.VaultAddress))
+
+	switch timelockRecord.VaultState {
+	case timelock_token_v1.StateWaitingForTimeout:
+	case timelock_token_v1.StateUnlocked:
+		return &unlockpb.CompleteUnlockResponse{
+			Result: unlockpb.CompleteUnlockResponse_ALREADY_UNLOCKED,
+			Status: toProtoUnlockStatus(timelockRecord),
+		}, nil
+	case timelock_token_v1.StateLocked:
+		return &unlockpb.CompleteUnlockResponse{
+			Result: unlockpb.CompleteUnlockResponse_NOT_INITIATED,
+			Status: toProtoUnlockStatus(timelockRecord),
+		}, nil
+	default:
+		return &unlockpb.CompleteUnlockResponse{
+			Result: unlockpb.CompleteUnlockResponse_NOT_FOUND,
+		}, nil
+	}
+
+	if timelockRecord.UnlockAt == nil || time.Now().Unix() < int64(*timelockRecord.UnlockAt) {
+		return &unlockpb.CompleteUnlockResponse{
+			Result: unlockpb.CompleteUnlockResponse_WAITING_FOR_TIMEOUT,
+			Status: toProtoUnlockStatus(timelockRecord),
+		}, nil
+	}
+
+	blockhash, err := s.data.GetBlockchainLatestBlockhash(ctx)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure getting latest blockhash")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	txn, err := transaction_util.MakeUnlockTransaction(blockhash, timelockAccounts)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure making unlock transaction")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	protoTxn, err := signAsFeePayer(&txn)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure signing unlock transaction")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return &unlockpb.CompleteUnlockResponse{
+		Result:      unlockpb.CompleteUnlockResponse_OK,
+		Transaction: protoTxn,
+		Status:      toProtoUnlockStatus(timelockRecord),
+	}, nil
+}
+
+func (s *unlockServer) CompleteWithdraw(ctx context.Context, req *unlockpb.CompleteWithdrawRequest) (*unlockpb.CompleteWithdrawResponse, error) {
+	log := s.log.With(zap.String("method", "CompleteWithdraw"))
+	log = client.InjectLoggingMetadata(ctx, log)
+
+	owner, err := common.NewAccountFromProto(req.Owner)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("invalid owner account")
+		return nil, status.Error(codes.Internal, "")
+	}
+	log = log.With(zap.String("owner_account", owner.PublicKey().ToBase58()))
+
+	mint, err := common.NewAccountFromProto(req.Mint)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("invalid mint account")
+		return nil, status.Error(codes.Internal, "")
+	}
+	log = log.With(zap.String("mint_account", mint.PublicKey().ToBase58()))
+
+	destination, err := common.NewAccountFromProto(req.Destination)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("invalid destination account")
+		return nil, status.Error(codes.Internal, "")
+	}
+	log = log.With(zap.String("destination_account", destination.PublicKey().ToBase58()))
+
+	signature := req.Signature
+	req.Signature = nil
+	if err := s.auth.Authenticate(ctx, owner, req, signature); err != nil {
+		return nil, err
+	}
+
+	timelockAccounts, timelockRecord, err := s.getTimelockAccount(ctx, owner, mint)
+	if err == common.ErrUnsupportedMint || err == timelock.ErrTimelockNotFound {
+		return &unlockpb.CompleteWithdrawResponse{
+			Result: unlockpb.CompleteWithdrawResponse_NOT_FOUND,
+		}, nil
+	} else if err != nil {
+		log.With(zap.Error(err)).Warn("failure getting timelock account")
+		return nil, status.Error(codes.Internal, "")
+	}
+	log = log.With(zap.String("vault", timelockRecord.VaultAddress))
+
+	switch timelockRecord.VaultState {
+	case timelock_token_v1.StateUnlocked:
+	case timelock_token_v1.StateLocked, timelock_token_v1.StateWaitingForTimeout:
+		return &unlockpb.CompleteWithdrawResponse{
+			Result: unlockpb.CompleteWithdrawResponse_NOT_UNLOCKED,
+			Status: toProtoUnlockStatus(timelockRecord),
+		}, nil
+	default:
+		return &unlockpb.CompleteWithdrawResponse{
+			Result: unlockpb.CompleteWithdrawResponse_NOT_FOUND,
+		}, nil
+	}
+
+	// The VM authority attests to the state of compressed accounts, so the
+	// private VM config is required
+	vmConfig, err := common.GetVmConfigForMint(ctx, s.data, mint)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure getting vm config")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	blockhash, err := s.data.GetBlockchainLatestBlockhash(ctx)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure getting latest blockhash")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	var txns []solana.Transaction
+
+	txn, err := s.makeVirtualAccountWithdrawTransaction(ctx, blockhash, vmConfig, timelockAccounts, destination)
+	switch err {
+	case nil:
+		txns = append(txns, txn)
+	case errNoWithdrawableBalance:
+	case vm_util.ErrVirtualAccountCompressed:
+		return &unlockpb.CompleteWithdrawResponse{
+			Result: unlockpb.CompleteWithdrawResponse_UNAVAILABLE,
+			Status: toProtoUnlockStatus(timelockRecord),
+		}, nil
+	default:
+		log.With(zap.Error(err)).Warn("failure making virtual account withdraw transaction")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	txn, err = s.makeDepositWithdrawTransaction(ctx, blockhash, vmConfig, timelockAccounts, destination)
+	switch err {
+	case nil:
+		txns = append(txns, txn)
+	case errNoWithdrawableBalance:
+	default:
+		log.With(zap.Error(err)).Warn("failure making deposit withdraw transaction")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	if len(txns) == 0 {
+		return &unlockpb.CompleteWithdrawResponse{
+			Result: unlockpb.CompleteWithdrawResponse_NO_BALANCE,
+			Status: toProtoUnlockStatus(timelockRecord),
+		}, nil
+	}
+
+	protoTxns := make([]*commonpb.Transaction, len(txns))
+	for i := range txns {
+		protoTxns[i], err = signAsFeePayer(&txns[i])
+		if err != nil {
+			log.With(zap.Error(err)).Warn("failure signing withdraw transaction")
+			return nil, status.Error(codes.Internal, "")
+		}
+	}
+
+	return &unlockpb.CompleteWithdrawResponse{
+		Result:       unlockpb.CompleteWithdrawResponse_OK,
+		Transactions: protoTxns,
+		Status:       toProtoUnlockStatus(timelockRecord),
+	}, nil
+}
+
+func (s *unlockServer) GetUnlockStatus(ctx context.Context, req *unlockpb.GetUnlockStatusRequest) (*unlockpb.GetUnlockStatusResponse, error) {
+	log := s.log.With(zap.String("method", "GetUnlockStatus"))
+	log = client.InjectLoggingMetadata(ctx, log)
+
+	owner, err := common.NewAccountFromProto(req.Owner)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("invalid owner account")
+		return nil, status.Error(codes.Internal, "")
+	}
+	log = log.With(zap.String("owner_account", owner.PublicKey().ToBase58()))
+
+	mint, err := common.NewAccountFromProto(req.Mint)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("invalid mint account")
+		return nil, status.Error(codes.Internal, "")
+	}
+	log = log.With(zap.String("mint_account", mint.PublicKey().ToBase58()))
+
+	signature := req.Signature
+	req.Signature = nil
+	if err := s.auth.Authenticate(ctx, owner, req, signature); err != nil {
+		return nil, err
+	}
+
+	_, timelockRecord, err := s.getTimelockAccount(ctx, owner, mint)
+	if err == common.ErrUnsupportedMint || err == timelock.ErrTimelockNotFound {
+		return &unlockpb.GetUnlockStatusResponse{
+			Result: unlockpb.GetUnlockStatusResponse_NOT_FOUND,
+		}, nil
+	} else if err != nil {
+		log.With(zap.Error(err)).Warn("failure getting timelock account")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return &unlockpb.GetUnlockStatusResponse{
+		Result: unlockpb.GetUnlockStatusResponse_OK,
+		Status: toProtoUnlockStatus(timelockRecord),
+	}, nil
+}
+
+func (s *unlockServer) getTimelockAccount(ctx context.Context, owner, mint *common.Account) (*common.TimelockAccounts, *timelock.Record, error) {
+	vmConfig, err := common.GetPublicVmConfigForMint(ctx, s.data, mint)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	timelockAccounts, err := owner.GetTimelockAccounts(vmConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	timelockRecord, err := s.data.GetTimelockByVault(ctx, timelockAccounts.Vault.PublicKey().ToBase58())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return timelockAccounts, timelockRecord, nil
+}
+
+// makeVirtualAccountWithdrawTransaction makes the transaction that withdraws the
+// virtual timelock account's balance from wherever it lives in the VM. The
+// account is treated as compressed while it's moving between memory and
+// storage, since the transaction would fail once the move lands.
+func (s *unlockServer) makeVirtualAccountWithdrawTransaction(
+	ctx context.Context,
+	blockhash solana.Blockhash,
+	vmConfig *common.VmConfig,
+	timelockAccounts *common.TimelockAccounts,
+	destination *common.Account,
+) (solana.Transaction, error) {
+	latestOperation, err := s.data.GetLatestVmOperationByAddress(ctx, timelockAccounts.Vault.PublicKey().ToBase58())
+	if err == nil && latestOperation.State.IsInFlight() {
+		return solana.Transaction{}, vm_util.ErrVirtualAccountCompressed
+	} else if err != nil && err != vm_operation.ErrNotFound {
+		return solana.Transaction{}, err
+	}
+
+	state, memory, index, err := vm_util.GetVirtualTimelockAccountStateInMemory(ctx, s.vmIndexerClient, vmConfig.Vm, timelockAccounts.VaultOwner)
+	switch err {
+	case nil:
+		if state.Balance == 0 {
+			return solana.Transaction{}, errNoWithdrawableBalance
+		}
+
+		withdrawReceipt, err := getWithdrawReceipt(vmConfig, timelockAccounts, state)
+		if err != nil {
+			return solana.Transaction{}, err
+		}
+
+		return transaction_util.MakeWithdrawFromMemoryTransaction(
+			blockhash,
+			vmConfig,
+			timelockAccounts,
+			memory,
+			index,
+			withdrawReceipt,
+			destination,
+		)
+	case vm_util.ErrVirtualAccountCompressed:
+	case vm_util.ErrVirtualAccountNotFound:
+		return solana.Transaction{}, errNoWithdrawableBalance
+	default:
+		return solana.Transaction{}, err
+	}
+
+	state, storage, proof, err := vm_util.GetVirtualTimelockAccountStateInStorage(ctx, s.vmIndexerClient, vmConfig.Vm, timelockAccounts.VaultOwner)
+	switch err {
+	case nil:
+	case vm_util.ErrVirtualAccountNotCompressed:
+		// The account was decompressed between indexer calls
+		return solana.Transaction{}, vm_util.ErrVirtualAccountCompressed
+	case vm_util.ErrVirtualAccountNotFound:
+		return solana.Transaction{}, errNoWithdrawableBalance
+	default:
+		return solana.Transaction{}, err
+	}
+
+	if state.Balance == 0 {
+		return solana.Transaction{}, errNoWithdrawableBalance
+	}
+
+	withdrawReceipt, err := getWithdrawReceipt(vmConfig, timelockAccounts, state)
+	if err != nil {
+		return solana.Transaction{}, err
+	}
+
+	return transaction_util.MakeWithdrawFromStorageTransaction(
+		blockhash,
+		vmConfig,
+		timelockAccounts,
+		storage,
+		withdrawReceipt,
+		state.Marshal(),
+		proof,
+		destination,
+	)
+}
+
+// makeDepositWithdrawTransaction makes the transaction that withdraws funds
+// sitting in the deposit account that were never moved into the VM
+func (s *unlockServer) makeDepositWithdrawTransaction(
+	ctx context.Context,
+	blockhash solana.Blockhash,
+	vmConfig *common.VmConfig,
+	timelockAccounts *common.TimelockAccounts,
+	destination *common.Account,
+) (solana.Transaction, error) {
+	depositAta, err := s.data.GetBlockchainTokenAccountInfo(
+		ctx,
+		timelockAccounts.VmDepositAccounts.Ata.PublicKey().ToBase58(),
+		vmConfig.Mint.PublicKey().ToBase58(),
+		solana.CommitmentFinalized,
+	)
+	switch err {
+	case nil:
+	case solana.ErrNoAccountInfo, token.ErrAccountNotFound:
+		return solana.Transaction{}, errNoWithdrawableBalance
+	default:
+		return solana.Transaction{}, err
+	}
+
+	if depositAta.Amount == 0 {
+		return solana.Transaction{}, errNoWithdrawableBalance
+	}
+
+	return transaction_util.MakeWithdrawFromDepositTransaction(blockhash, timelockAccounts, destination)
+}
+
+func getWithdrawReceipt(vmConfig *common.VmConfig, timelockAccounts *common.TimelockAccounts, state *vm.VirtualTimelockAccount) (*common.Account, error) {
+	withdrawReceiptAddress, _, err := vm.GetWithdrawReceiptAccountAddress(&vm.GetWithdrawReceiptAccountAddressArgs{
+		UnlockAccount: timelockAccounts.Unlock.PublicKey().ToBytes(),
+		Nonce:         state.Nonce,
+		Vm:            vmConfig.Vm.PublicKey().ToBytes(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return common.NewAccountFromPublicKeyBytes(withdrawReceiptAddress)
+}
+
+// signAsFeePayer signs the transaction as the subsidizer, which pays the fees,
+// leaving the owner's signature to the client
+func signAsFeePayer(txn *solana.Transaction) (*commonpb.Transaction, error) {
+	err := txn.Sign(common.GetSubsidizer().PrivateKey().ToBytes())
+	if err != nil {
+		return nil, err
+	}
+
+	return &commonpb.Transaction{
+		Value: txn.Marshal(),
+	}, nil
+}
+
+func toProtoUnlockStatus(record *timelock.Record) *unlockpb.UnlockStatus {
+	var state unlockpb.UnlockStatus_State
+	switch record.VaultState {
+	case timelock_token_v1.StateLocked:
+		state = unlockpb.UnlockStatus_LOCKED
+	case timelock_token_v1.StateWaitingForTimeout:
+		state = unlockpb.UnlockStatus_WAITING_FOR_TIMEOUT
+	case timelock_token_v1.StateUnlocked:
+		state = unlockpb.UnlockStatus_UNLOCKED
+	case timelock_token_v1.StateClosed:
+		state = unlockpb.UnlockStatus_CLOSED
+	default:
+		state = unlockpb.UnlockStatus_UNKNOWN
+		if record.Block == 0 {
+			// Newly initialized accounts are guaranteed to be locked
+			state = unlockpb.UnlockStatus_LOCKED
+		}
+	}
+
+	if state == unlockpb.UnlockStatus_LOCKED && record.IsUnlockRequested() {
+		state = unlockpb.UnlockStatus_REQUESTED
+	}
+
+	res := &unlockpb.UnlockStatus{
+		State: state,
+	}
+	if record.IsUnlockRequested() {
+		res.RequestedAt = timestamppb.New(*record.UnlockRequestedAt)
+	}
+	if record.UnlockAt != nil {
+		res.UnlockAt = timestamppb.New(time.Unix(int64(*record.UnlockAt), 0))
+	}
+	return res
+}
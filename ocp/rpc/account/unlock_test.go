@@ -0,0 +1,351 @@
+package account
+
+import (
+	"context"
+	"crypto/ed25519"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	commonpb "github.com/code-payments/ocp-protobuf-api/generated/go/common/v1"
+
+	"github.com/code-payments/ocp-server/ocp/common"
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/timelock"
+	unlockpb "github.com/code-payments/ocp-server/ocp/rpc/account/api/gen"
+	timelock_token_v1 "github.com/code-payments/ocp-server/solana/timelock/v1"
+	"github.com/code-payments/ocp-server/testutil"
+)
+
+type unlockTestEnv struct {
+	ctx      context.Context
+	client   unlockpb.UnlockClient
+	data     ocp_data.Provider
+	vmConfig *common.VmConfig
+}
+
+func setupUnlock(t *testing.T) (env unlockTestEnv, cleanup func()) {
+	log := zaptest.NewLogger(t)
+
+	conn, serv, err := testutil.NewServer(log)
+	require.NoError(t, err)
+
+	env.ctx = context.Background()
+	env.client = unlockpb.NewUnlockClient(conn)
+	env.data = ocp_data.NewTestDataProvider()
+	testutil.SetupRandomSubsidizer(t, env.data)
+	env.vmConfig = testutil.NewRandomVmConfig(t, true)
+
+	s := NewUnlockServer(log, env.data, nil)
+
+	serv.RegisterService(func(server *grpc.Server) {
+		unlockpb.RegisterUnlockServer(server, s)
+	})
+
+	cleanup, err = serv.Serve()
+	require.NoError(t, err)
+	return env, cleanup
+}
+
+func TestInitiateUnlock_WaitingPeriodAlreadyStarted(t *testing.T) {
+	env, cleanup := setupUnlock(t)
+	defer cleanup()
+
+	owner := testutil.NewRandomAccount(t)
+
+	resp, err := env.client.InitiateUnlock(env.ctx, env.newInitiateUnlockRequest(t, owner))
+	require.NoError(t, err)
+	assert.Equal(t, unlockpb.InitiateUnlockResponse_NOT_FOUND, resp.Result)
+
+	unlockAt := uint64(time.Now().Add(time.Hour).Unix())
+	timelockRecord := env.setupTimelockAccount(t, owner, timelock_token_v1.StateWaitingForTimeout, &unlockAt)
+
+	resp, err = env.client.InitiateUnlock(env.ctx, env.newInitiateUnlockRequest(t, owner))
+	require.NoError(t, err)
+	assert.Equal(t, unlockpb.InitiateUnlockResponse_OK, resp.Result)
+	assert.Nil(t, resp.Transaction)
+	assert.Equal(t, unlockpb.UnlockStatus_WAITING_FOR_TIMEOUT, resp.Status.State)
+	assert.NotNil(t, resp.Status.RequestedAt)
+	assert.EqualValues(t, unlockAt, resp.Status.UnlockAt.AsTime().Unix())
+
+	actual, err := env.data.GetTimelockByVault(env.ctx, timelockRecord.VaultAddress)
+	require.NoError(t, err)
+	assert.True(t, actual.IsUnlockRequested())
+	assert.False(t, common.IsManagedByCode(env.ctx, actual))
+}
+
+func TestInitiateUnlock_AlreadyUnlocked(t *testing.T) {
+	env, cleanup := setupUnlock(t)
+	defer cleanup()
+
+	owner := testutil.NewRandomAccount(t)
+
+	unlockAt := uint64(time.Now().Add(-time.Hour).Unix())
+	timelockRecord := env.setupTimelockAccount(t, owner, timelock_token_v1.StateUnlocked, &unlockAt)
+
+	resp, err := env.client.InitiateUnlock(env.ctx, env.newInitiateUnlockRequest(t, owner))
+	require.NoError(t, err)
+	assert.Equal(t, unlockpb.InitiateUnlockResponse_ALREADY_UNLOCKED, resp.Result)
+	assert.Nil(t, resp.Transaction)
+	assert.Equal(t, unlockpb.UnlockStatus_UNLOCKED, resp.Status.State)
+
+	actual, err := env.data.GetTimelockByVault(env.ctx, timelockRecord.VaultAddress)
+	require.NoError(t, err)
+	assert.False(t, actual.IsUnlockRequested())
+}
+
+func TestCompleteUnlock_NotReady(t *testing.T) {
+	env, cleanup := setupUnlock(t)
+	defer cleanup()
+
+	owner := testutil.NewRandomAccount(t)
+
+	resp, err := env.client.CompleteUnlock(env.ctx, env.newCompleteUnlockRequest(t, owner))
+	require.NoError(t, err)
+	assert.Equal(t, unlockpb.CompleteUnlockResponse_NOT_FOUND, resp.Result)
+
+	timelockRecord := env.setupTimelockAccount(t, owner, timelock_token_v1.StateLocked, nil)
+
+	resp, err = env.client.CompleteUnlock(env.ctx, env.newCompleteUnlockRequest(t, owner))
+	require.NoError(t, err)
+	assert.Equal(t, unlockpb.CompleteUnlockResponse_NOT_INITIATED, resp.Result)
+	assert.Nil(t, resp.Transaction)
+
+	unlockAt := uint64(time.Now().Add(time.Hour).Unix())
+	timelockRecord.VaultState = timelock_token_v1.StateWaitingForTimeout
+	timelockRecord.UnlockAt = &unlockAt
+	timelockRecord.Block += 1
+	require.NoError(t, env.data.SaveTimelock(env.ctx, timelockRecord))
+
+	resp, err = env.client.CompleteUnlock(env.ctx, env.newCompleteUnlockRequest(t, owner))
+	require.NoError(t, err)
+	assert.Equal(t, unlockpb.CompleteUnlockResponse_WAITING_FOR_TIMEOUT, resp.Result)
+	assert.Nil(t, resp.Transaction)
+	assert.EqualValues(t, unlockAt, resp.Status.UnlockAt.AsTime().Unix())
+
+	timelockRecord.VaultState = timelock_token_v1.StateUnlocked
+	timelockRecord.Block += 1
+	require.NoError(t, env.data.SaveTimelock(env.ctx, timelockRecord))
+
+	resp, err = env.client.CompleteUnlock(env.ctx, env.newCompleteUnlockRequest(t, owner))
+	require.NoError(t, err)
+	assert.Equal(t, unlockpb.CompleteUnlockResponse_ALREADY_UNLOCKED, resp.Result)
+	assert.Nil(t, resp.Transaction)
+}
+
+func TestCompleteWithdraw_NotReady(t *testing.T) {
+	env, cleanup := setupUnlock(t)
+	defer cleanup()
+
+	owner := testutil.NewRandomAccount(t)
+	destination := testutil.NewRandomAccount(t)
+
+	resp, err := env.client.CompleteWithdraw(env.ctx, env.newCompleteWithdrawRequest(t, owner, destination))
+	require.NoError(t, err)
+	assert.Equal(t, unlockpb.CompleteWithdrawResponse_NOT_FOUND, resp.Result)
+
+	timelockRecord := env.setupTimelockAccount(t, owner, timelock_token_v1.StateLocked, nil)
+
+	resp, err = env.client.CompleteWithdraw(env.ctx, env.newCompleteWithdrawRequest(t, owner, destination))
+	require.NoError(t, err)
+	assert.Equal(t, unlockpb.CompleteWithdrawResponse_NOT_UNLOCKED, resp.Result)
+	assert.Empty(t, resp.Transactions)
+	assert.Equal(t, unlockpb.UnlockStatus_LOCKED, resp.Status.State)
+
+	unlockAt := uint64(time.Now().Add(time.Hour).Unix())
+	timelockRecord.VaultState = timelock_token_v1.StateWaitingForTimeout
+	timelockRecord.UnlockAt = &unlockAt
+	timelockRecord.Block += 1
+	require.NoError(t, env.data.SaveTimelock(env.ctx, timelockRecord))
+
+	resp, err = env.client.CompleteWithdraw(env.ctx, env.newCompleteWithdrawRequest(t, owner, destination))
+	require.NoError(t, err)
+	assert.Equal(t, unlockpb.CompleteWithdrawResponse_NOT_UNLOCKED, resp.Result)
+	assert.Empty(t, resp.Transactions)
+	assert.Equal(t, unlockpb.UnlockStatus_WAITING_FOR_TIMEOUT, resp.Status.State)
+
+	timelockRecord.VaultState = timelock_token_v1.StateClosed
+	timelockRecord.Block += 1
+	require.NoError(t, env.data.SaveTimelock(env.ctx, timelockRecord))
+
+	resp, err = env.client.CompleteWithdraw(env.ctx, env.newCompleteWithdrawRequest(t, owner, destination))
+	require.NoError(t, err)
+	assert.Equal(t, unlockpb.CompleteWithdrawResponse_NOT_FOUND, resp.Result)
+	assert.Empty(t, resp.Transactions)
+}
+
+func TestGetUnlockStatus_ExpiredRequest(t *testing.T) {
+	env, cleanup := setupUnlock(t)
+	defer cleanup()
+
+	owner := testutil.NewRandomAccount(t)
+
+	timelockRecord := env.setupTimelockAccount(t, owner, timelock_token_v1.StateLocked, nil)
+
+	requestedAt := time.Now().Add(-2 * timelock.UnlockRequestExpiry)
+	require.NoError(t, env.data.MarkTimelockUnlockRequested(env.ctx, timelockRecord.VaultAddress, requestedAt))
+
+	resp, err := env.client.GetUnlockStatus(env.ctx, env.newGetUnlockStatusRequest(t, owner))
+	require.NoError(t, err)
+	assert.Equal(t, unlockpb.GetUnlockStatusResponse_OK, resp.Result)
+	assert.Equal(t, unlockpb.UnlockStatus_LOCKED, resp.Status.State)
+	assert.Nil(t, resp.Status.RequestedAt)
+
+	actual, err := env.data.GetTimelockByVault(env.ctx, timelockRecord.VaultAddress)
+	require.NoError(t, err)
+	assert.False(t, actual.IsUnlockRequested())
+	assert.True(t, common.IsManagedByCode(env.ctx, actual))
+}
+
+func TestGetUnlockStatus(t *testing.T) {
+	env, cleanup := setupUnlock(t)
+	defer cleanup()
+
+	owner := testutil.NewRandomAccount(t)
+
+	resp, err := env.client.GetUnlockStatus(env.ctx, env.newGetUnlockStatusRequest(t, owner))
+	require.NoError(t, err)
+	assert.Equal(t, unlockpb.GetUnlockStatusResponse_NOT_FOUND, resp.Result)
+
+	timelockRecord := env.setupTimelockAccount(t, owner, timelock_token_v1.StateLocked, nil)
+
+	resp, err = env.client.GetUnlockStatus(env.ctx, env.newGetUnlockStatusRequest(t, owner))
+	require.NoError(t, err)
+	assert.Equal(t, unlockpb.GetUnlockStatusResponse_OK, resp.Result)
+	assert.Equal(t, unlockpb.UnlockStatus_LOCKED, resp.Status.State)
+	assert.Nil(t, resp.Status.RequestedAt)
+	assert.Nil(t, resp.Status.UnlockAt)
+
+	requestedAt := time.Now().Add(-time.Minute)
+	require.NoError(t, env.data.MarkTimelockUnlockRequested(env.ctx, timelockRecord.VaultAddress, requestedAt))
+
+	resp, err = env.client.GetUnlockStatus(env.ctx, env.newGetUnlockStatusRequest(t, owner))
+	require.NoError(t, err)
+	assert.Equal(t, unlockpb.GetUnlockStatusResponse_OK, resp.Result)
+	assert.Equal(t, unlockpb.UnlockStatus_REQUESTED, resp.Status.State)
+	assert.Equal(t, requestedAt.Unix(), resp.Status.RequestedAt.AsTime().Unix())
+	assert.Nil(t, resp.Status.UnlockAt)
+
+	unlockAt := uint64(time.Now().Add(time.Hour).Unix())
+	timelockRecord.VaultState = timelock_token_v1.StateWaitingForTimeout
+	timelockRecord.UnlockAt = &unlockAt
+	timelockRecord.Block += 1
+	require.NoError(t, env.data.SaveTimelock(env.ctx, timelockRecord))
+
+	resp, err = env.client.GetUnlockStatus(env.ctx, env.newGetUnlockStatusRequest(t, owner))
+	require.NoError(t, err)
+	assert.Equal(t, unlockpb.GetUnlockStatusResponse_OK, resp.Result)
+	assert.Equal(t, unlockpb.UnlockStatus_WAITING_FOR_TIMEOUT, resp.Status.State)
+	assert.Equal(t, requestedAt.Unix(), resp.Status.RequestedAt.AsTime().Unix())
+	assert.EqualValues(t, unlockAt, resp.Status.UnlockAt.AsTime().Unix())
+}
+
+func TestUnlock_UnauthenticatedRPC(t *testing.T) {
+	env, cleanup := setupUnlock(t)
+	defer cleanup()
+
+	owner := testutil.NewRandomAccount(t)
+	maliciousAccount := testutil.NewRandomAccount(t)
+
+	env.setupTimelockAccount(t, owner, timelock_token_v1.StateLocked, nil)
+
+	initiateReq := &unlockpb.InitiateUnlockRequest{
+		Owner: owner.ToProto(),
+		Mint:  env.vmConfig.Mint.ToProto(),
+	}
+	initiateReq.Signature = signUnlockRequest(t, maliciousAccount, initiateReq)
+	_, err := env.client.InitiateUnlock(env.ctx, initiateReq)
+	testutil.AssertStatusErrorWithCode(t, err, codes.Unauthenticated)
+
+	completeReq := &unlockpb.CompleteUnlockRequest{
+		Owner: owner.ToProto(),
+		Mint:  env.vmConfig.Mint.ToProto(),
+	}
+	completeReq.Signature = signUnlockRequest(t, maliciousAccount, completeReq)
+	_, err = env.client.CompleteUnlock(env.ctx, completeReq)
+	testutil.AssertStatusErrorWithCode(t, err, codes.Unauthenticated)
+
+	withdrawReq := &unlockpb.CompleteWithdrawRequest{
+		Owner:       owner.ToProto(),
+		Mint:        env.vmConfig.Mint.ToProto(),
+		Destination: testutil.NewRandomAccount(t).ToProto(),
+	}
+	withdrawReq.Signature = signUnlockRequest(t, maliciousAccount, withdrawReq)
+	_, err = env.client.CompleteWithdraw(env.ctx, withdrawReq)
+	testutil.AssertStatusErrorWithCode(t, err, codes.Unauthenticated)
+
+	statusReq := &unlockpb.GetUnlockStatusRequest{
+		Owner: owner.ToProto(),
+		Mint:  env.vmConfig.Mint.ToProto(),
+	}
+	statusReq.Signature = signUnlockRequest(t, maliciousAccount, statusReq)
+	_, err = env.client.GetUnlockStatus(env.ctx, statusReq)
+	testutil.AssertStatusErrorWithCode(t, err, codes.Unauthenticated)
+
+	resp, err := env.client.GetUnlockStatus(env.ctx, env.newGetUnlockStatusRequest(t, owner))
+	require.NoError(t, err)
+	assert.Equal(t, unlockpb.UnlockStatus_LOCKED, resp.Status.State)
+}
+
+func (e *unlockTestEnv) setupTimelockAccount(t *testing.T, owner *common.Account, state timelock_token_v1.TimelockState, unlockAt *uint64) *timelock.Record {
+	timelockAccounts, err := owner.GetTimelockAccounts(e.vmConfig)
+	require.NoError(t, err)
+
+	timelockRecord := timelockAccounts.ToDBRecord()
+	timelockRecord.VaultState = state
+	timelockRecord.UnlockAt = unlockAt
+	timelockRecord.Block = 1
+	require.NoError(t, e.data.SaveTimelock(e.ctx, timelockRecord))
+	return timelockRecord
+}
+
+func (e *unlockTestEnv) newInitiateUnlockRequest(t *testing.T, owner *common.Account) *unlockpb.InitiateUnlockRequest {
+	req := &unlockpb.InitiateUnlockRequest{
+		Owner: owner.ToProto(),
+		Mint:  e.vmConfig.Mint.ToProto(),
+	}
+	req.Signature = signUnlockRequest(t, owner, req)
+	return req
+}
+
+func (e *unlockTestEnv) newCompleteUnlockRequest(t *testing.T, owner *common.Account) *unlockpb.CompleteUnlockRequest {
+	req := &unlockpb.CompleteUnlockRequest{
+		Owner: owner.ToProto(),
+		Mint:  e.vmConfig.Mint.ToProto(),
+	}
+	req.Signature = signUnlockRequest(t, owner, req)
+	return req
+}
+
+func (e *unlockTestEnv) newCompleteWithdrawRequest(t *testing.T, owner, destination *common.Account) *unlockpb.CompleteWithdrawRequest {
+	req := &unlockpb.CompleteWithdrawRequest{
+		Owner:       owner.ToProto(),
+		Mint:        e.vmConfig.Mint.ToProto(),
+		Destination: destination.ToProto(),
+	}
+	req.Signature = signUnlockRequest(t, owner, req)
+	return req
+}
+
+func (e *unlockTestEnv) newGetUnlockStatusRequest(t *testing.T, owner *common.Account) *unlockpb.GetUnlockStatusRequest {
+	req := &unlockpb.GetUnlockStatusRequest{
+		Owner: owner.ToProto(),
+		Mint:  e.vmConfig.Mint.ToProto(),
+	}
+	req.Signature = signUnlockRequest(t, owner, req)
+	return req
+}
+
+func signUnlockRequest(t *testing.T, signer *common.Account, req proto.Message) *commonpb.Signature {
+	reqBytes, err := proto.Marshal(req)
+	require.NoError(t, err)
+	return &commonpb.Signature{
+		Value: ed25519.Sign(signer.PrivateKey().ToBytes(), reqBytes),
+	}
+}
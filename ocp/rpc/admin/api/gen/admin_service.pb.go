@@ -0,0 +1,1552 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v3.21.12
+// source: admin_service.proto
+
+package admin
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Resolution int32
+
+const (
+	Resolution_UNKNOWN_RESOLUTION Resolution = 0
+	// Re-submit the fulfillment in a new transaction.
+	Resolution_RETRY Resolution = 1
+	// Abandon the fulfillment, and any remaining work for its action.
+	Resolution_REVOKE Resolution = 2
+	// Mark the fulfillment as completed by an operator outside the sequencer.
+	Resolution_RESOLVE Resolution = 3
+)
+
+// Enum value maps for Resolution.
+var (
+	Resolution_name = map[int32]string{
+		0: "UNKNOWN_RESOLUTION",
+		1: "RETRY",
+		2: "REVOKE",
+		3: "RESOLVE",
+	}
+	Resolution_value = map[string]int32{
+		"UNKNOWN_RESOLUTION": 0,
+		"RETRY":              1,
+		"REVOKE":             2,
+		"RESOLVE":            3,
+	}
+)
+
+func (x Resolution) Enum() *Resolution {
+	p := new(Resolution)
+	*p = x
+	return p
+}
+
+func (x Resolution) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Resolution) Descriptor() protoreflect.EnumDescriptor {
+	return file_admin_service_proto_enumTypes[0].Descriptor()
+}
+
+func (Resolution) Type() protoreflect.EnumType {
+	return &file_admin_service_proto_enumTypes[0]
+}
+
+func (x Resolution) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Resolution.Descriptor instead.
+func (Resolution) EnumDescriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{0}
+}
+
+type ResolveFailedFulfillmentResponse_Result int32
+
+const (
+	ResolveFailedFulfillmentResponse_OK ResolveFailedFulfillmentResponse_Result = 0
+	// The fulfillment doesn't exist.
+	ResolveFailedFulfillmentResponse_NOT_FOUND ResolveFailedFulfillmentResponse_Result = 1
+	// The fulfillment isn't in the failed state.
+	ResolveFailedFulfillmentResponse_NOT_FAILED ResolveFailedFulfillmentResponse_Result = 2
+	// The resolution can't be applied to the fulfillment.
+	ResolveFailedFulfillmentResponse_UNSUPPORTED ResolveFailedFulfillmentResponse_Result = 3
+)
+
+// Enum value maps for ResolveFailedFulfillmentResponse_Result.
+var (
+	ResolveFailedFulfillmentResponse_Result_name = map[int32]string{
+		0: "OK",
+		1: "NOT_FOUND",
+		2: "NOT_FAILED",
+		3: "UNSUPPORTED",
+	}
+	ResolveFailedFulfillmentResponse_Result_value = map[string]int32{
+		"OK":          0,
+		"NOT_FOUND":   1,
+		"NOT_FAILED":  2,
+		"UNSUPPORTED": 3,
+	}
+)
+
+func (x ResolveFailedFulfillmentResponse_Result) Enum() *ResolveFailedFulfillmentResponse_Result {
+	p := new(ResolveFailedFulfillmentResponse_Result)
+	*p = x
+	return p
+}
+
+func (x ResolveFailedFulfillmentResponse_Result) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ResolveFailedFulfillmentResponse_Result) Descriptor() protoreflect.EnumDescriptor {
+	return file_admin_service_proto_enumTypes[1].Descriptor()
+}
+
+func (ResolveFailedFulfillmentResponse_Result) Type() protoreflect.EnumType {
+	return &file_admin_service_proto_enumTypes[1]
+}
+
+func (x ResolveFailedFulfillmentResponse_Result) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ResolveFailedFulfillmentResponse_Result.Descriptor instead.
+func (ResolveFailedFulfillmentResponse_Result) EnumDescriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{3, 0}
+}
+
+type GetCircuitBreakerStatusResponse_Result int32
+
+const (
+	GetCircuitBreakerStatusResponse_OK GetCircuitBreakerStatusResponse_Result = 0
+	// The fulfillment doesn't exist.
+	GetCircuitBreakerStatusResponse_NOT_FOUND GetCircuitBreakerStatusResponse_Result = 1
+)
+
+// Enum value maps for GetCircuitBreakerStatusResponse_Result.
+var (
+	GetCircuitBreakerStatusResponse_Result_name = map[int32]string{
+		0: "OK",
+		1: "NOT_FOUND",
+	}
+	GetCircuitBreakerStatusResponse_Result_value = map[string]int32{
+		"OK":        0,
+		"NOT_FOUND": 1,
+	}
+)
+
+func (x GetCircuitBreakerStatusResponse_Result) Enum() *GetCircuitBreakerStatusResponse_Result {
+	p := new(GetCircuitBreakerStatusResponse_Result)
+	*p = x
+	return p
+}
+
+func (x GetCircuitBreakerStatusResponse_Result) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GetCircuitBreakerStatusResponse_Result) Descriptor() protoreflect.EnumDescriptor {
+	return file_admin_service_proto_enumTypes[2].Descriptor()
+}
+
+func (GetCircuitBreakerStatusResponse_Result) Type() protoreflect.EnumType {
+	return &file_admin_service_proto_enumTypes[2]
+}
+
+func (x GetCircuitBreakerStatusResponse_Result) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GetCircuitBreakerStatusResponse_Result.Descriptor instead.
+func (GetCircuitBreakerStatusResponse_Result) EnumDescriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{5, 0}
+}
+
+type GetFailedFulfillmentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The fulfillment ID to start after. Zero starts from the beginning.
+	Cursor uint64 `protobuf:"varint,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// The maximum number of fulfillments to return. Zero uses a server default.
+	PageSize      uint32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFailedFulfillmentsRequest) Reset() {
+	*x = GetFailedFulfillmentsRequest{}
+	mi := &file_admin_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFailedFulfillmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFailedFulfillmentsRequest) ProtoMessage() {}
+
+func (x *GetFailedFulfillmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFailedFulfillmentsRequest.ProtoReflect.Descriptor instead.
+func (*GetFailedFulfillmentsRequest) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetFailedFulfillmentsRequest) GetCursor() uint64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+func (x *GetFailedFulfillmentsRequest) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type GetFailedFulfillmentsResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	FailedFulfillments []*FailedFulfillment   `protobuf:"bytes,1,rep,name=failed_fulfillments,json=failedFulfillments,proto3" json:"failed_fulfillments,omitempty"`
+	// The cursor for the next page, or zero if there are no more pages.
+	NextCursor           uint64                `protobuf:"varint,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	GlobalCircuitBreaker *GlobalCircuitBreaker `protobuf:"bytes,3,opt,name=global_circuit_breaker,json=globalCircuitBreaker,proto3" json:"global_circuit_breaker,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *GetFailedFulfillmentsResponse) Reset() {
+	*x = GetFailedFulfillmentsResponse{}
+	mi := &file_admin_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFailedFulfillmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFailedFulfillmentsResponse) ProtoMessage() {}
+
+func (x *GetFailedFulfillmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFailedFulfillmentsResponse.ProtoReflect.Descriptor instead.
+func (*GetFailedFulfillmentsResponse) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetFailedFulfillmentsResponse) GetFailedFulfillments() []*FailedFulfillment {
+	if x != nil {
+		return x.FailedFulfillments
+	}
+	return nil
+}
+
+func (x *GetFailedFulfillmentsResponse) GetNextCursor() uint64 {
+	if x != nil {
+		return x.NextCursor
+	}
+	return 0
+}
+
+func (x *GetFailedFulfillmentsResponse) GetGlobalCircuitBreaker() *GlobalCircuitBreaker {
+	if x != nil {
+		return x.GlobalCircuitBreaker
+	}
+	return nil
+}
+
+type ResolveFailedFulfillmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FulfillmentId uint64                 `protobuf:"varint,1,opt,name=fulfillment_id,json=fulfillmentId,proto3" json:"fulfillment_id,omitempty"`
+	Resolution    Resolution             `protobuf:"varint,2,opt,name=resolution,proto3,enum=ocp.admin.v1.Resolution" json:"resolution,omitempty"`
+	// The operator performing the resolution, for the audit trail.
+	Operator string `protobuf:"bytes,3,opt,name=operator,proto3" json:"operator,omitempty"`
+	// Why the resolution is being made, for the audit trail.
+	Reason        string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveFailedFulfillmentRequest) Reset() {
+	*x = ResolveFailedFulfillmentRequest{}
+	mi := &file_admin_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveFailedFulfillmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveFailedFulfillmentRequest) ProtoMessage() {}
+
+func (x *ResolveFailedFulfillmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveFailedFulfillmentRequest.ProtoReflect.Descriptor instead.
+func (*ResolveFailedFulfillmentRequest) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ResolveFailedFulfillmentRequest) GetFulfillmentId() uint64 {
+	if x != nil {
+		return x.FulfillmentId
+	}
+	return 0
+}
+
+func (x *ResolveFailedFulfillmentRequest) GetResolution() Resolution {
+	if x != nil {
+		return x.Resolution
+	}
+	return Resolution_UNKNOWN_RESOLUTION
+}
+
+func (x *ResolveFailedFulfillmentRequest) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *ResolveFailedFulfillmentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ResolveFailedFulfillmentResponse struct {
+	state  protoimpl.MessageState                  `protogen:"open.v1"`
+	Result ResolveFailedFulfillmentResponse_Result `protobuf:"varint,1,opt,name=result,proto3,enum=ocp.admin.v1.ResolveFailedFulfillmentResponse_Result" json:"result,omitempty"`
+	// The state of the fulfillment after the resolution was applied.
+	Fulfillment *FailedFulfillment `protobuf:"bytes,2,opt,name=fulfillment,proto3" json:"fulfillment,omitempty"`
+	// The re-evaluated circuit breakers that apply to the fulfillment.
+	CircuitBreakerStatus *CircuitBreakerStatus `protobuf:"bytes,3,opt,name=circuit_breaker_status,json=circuitBreakerStatus,proto3" json:"circuit_breaker_status,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ResolveFailedFulfillmentResponse) Reset() {
+	*x = ResolveFailedFulfillmentResponse{}
+	mi := &file_admin_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveFailedFulfillmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveFailedFulfillmentResponse) ProtoMessage() {}
+
+func (x *ResolveFailedFulfillmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveFailedFulfillmentResponse.ProtoReflect.Descriptor instead.
+func (*ResolveFailedFulfillmentResponse) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ResolveFailedFulfillmentResponse) GetResult() ResolveFailedFulfillmentResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return ResolveFailedFulfillmentResponse_OK
+}
+
+func (x *ResolveFailedFulfillmentResponse) GetFulfillment() *FailedFulfillment {
+	if x != nil {
+		return x.Fulfillment
+	}
+	return nil
+}
+
+func (x *ResolveFailedFulfillmentResponse) GetCircuitBreakerStatus() *CircuitBreakerStatus {
+	if x != nil {
+		return x.CircuitBreakerStatus
+	}
+	return nil
+}
+
+type GetCircuitBreakerStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FulfillmentId uint64                 `protobuf:"varint,1,opt,name=fulfillment_id,json=fulfillmentId,proto3" json:"fulfillment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCircuitBreakerStatusRequest) Reset() {
+	*x = GetCircuitBreakerStatusRequest{}
+	mi := &file_admin_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCircuitBreakerStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCircuitBreakerStatusRequest) ProtoMessage() {}
+
+func (x *GetCircuitBreakerStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCircuitBreakerStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetCircuitBreakerStatusRequest) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetCircuitBreakerStatusRequest) GetFulfillmentId() uint64 {
+	if x != nil {
+		return x.FulfillmentId
+	}
+	return 0
+}
+
+type GetCircuitBreakerStatusResponse struct {
+	state                protoimpl.MessageState                 `protogen:"open.v1"`
+	Result               GetCircuitBreakerStatusResponse_Result `protobuf:"varint,1,opt,name=result,proto3,enum=ocp.admin.v1.GetCircuitBreakerStatusResponse_Result" json:"result,omitempty"`
+	CircuitBreakerStatus *CircuitBreakerStatus                  `protobuf:"bytes,2,opt,name=circuit_breaker_status,json=circuitBreakerStatus,proto3" json:"circuit_breaker_status,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *GetCircuitBreakerStatusResponse) Reset() {
+	*x = GetCircuitBreakerStatusResponse{}
+	mi := &file_admin_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCircuitBreakerStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCircuitBreakerStatusResponse) ProtoMessage() {}
+
+func (x *GetCircuitBreakerStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCircuitBreakerStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetCircuitBreakerStatusResponse) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetCircuitBreakerStatusResponse) GetResult() GetCircuitBreakerStatusResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return GetCircuitBreakerStatusResponse_OK
+}
+
+func (x *GetCircuitBreakerStatusResponse) GetCircuitBreakerStatus() *CircuitBreakerStatus {
+	if x != nil {
+		return x.CircuitBreakerStatus
+	}
+	return nil
+}
+
+type GetResolutionHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FulfillmentId uint64                 `protobuf:"varint,1,opt,name=fulfillment_id,json=fulfillmentId,proto3" json:"fulfillment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetResolutionHistoryRequest) Reset() {
+	*x = GetResolutionHistoryRequest{}
+	mi := &file_admin_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetResolutionHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResolutionHistoryRequest) ProtoMessage() {}
+
+func (x *GetResolutionHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResolutionHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetResolutionHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetResolutionHistoryRequest) GetFulfillmentId() uint64 {
+	if x != nil {
+		return x.FulfillmentId
+	}
+	return 0
+}
+
+type GetResolutionHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Resolutions   []*ResolutionRecord    `protobuf:"bytes,1,rep,name=resolutions,proto3" json:"resolutions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetResolutionHistoryResponse) Reset() {
+	*x = GetResolutionHistoryResponse{}
+	mi := &file_admin_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetResolutionHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResolutionHistoryResponse) ProtoMessage() {}
+
+func (x *GetResolutionHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResolutionHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetResolutionHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetResolutionHistoryResponse) GetResolutions() []*ResolutionRecord {
+	if x != nil {
+		return x.Resolutions
+	}
+	return nil
+}
+
+type FailedFulfillment struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Fulfillment *Fulfillment           `protobuf:"bytes,1,opt,name=fulfillment,proto3" json:"fulfillment,omitempty"`
+	Intent      *Intent                `protobuf:"bytes,2,opt,name=intent,proto3" json:"intent,omitempty"`
+	Action      *Action                `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	// Not set if the transaction was never observed on the blockchain.
+	Transaction   *Transaction `protobuf:"bytes,4,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FailedFulfillment) Reset() {
+	*x = FailedFulfillment{}
+	mi := &file_admin_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FailedFulfillment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FailedFulfillment) ProtoMessage() {}
+
+func (x *FailedFulfillment) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FailedFulfillment.ProtoReflect.Descriptor instead.
+func (*FailedFulfillment) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FailedFulfillment) GetFulfillment() *Fulfillment {
+	if x != nil {
+		return x.Fulfillment
+	}
+	return nil
+}
+
+func (x *FailedFulfillment) GetIntent() *Intent {
+	if x != nil {
+		return x.Intent
+	}
+	return nil
+}
+
+func (x *FailedFulfillment) GetAction() *Action {
+	if x != nil {
+		return x.Action
+	}
+	return nil
+}
+
+func (x *FailedFulfillment) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+type Fulfillment struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type             string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	State            string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	Signature        string                 `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	VirtualSignature string                 `protobuf:"bytes,5,opt,name=virtual_signature,json=virtualSignature,proto3" json:"virtual_signature,omitempty"`
+	Source           string                 `protobuf:"bytes,6,opt,name=source,proto3" json:"source,omitempty"`
+	Destination      string                 `protobuf:"bytes,7,opt,name=destination,proto3" json:"destination,omitempty"`
+	// Whether the fulfillment was packed into a shared transaction.
+	IsBatched     bool                   `protobuf:"varint,8,opt,name=is_batched,json=isBatched,proto3" json:"is_batched,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Fulfillment) Reset() {
+	*x = Fulfillment{}
+	mi := &file_admin_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Fulfillment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fulfillment) ProtoMessage() {}
+
+func (x *Fulfillment) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fulfillment.ProtoReflect.Descriptor instead.
+func (*Fulfillment) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Fulfillment) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Fulfillment) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Fulfillment) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Fulfillment) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *Fulfillment) GetVirtualSignature() string {
+	if x != nil {
+		return x.VirtualSignature
+	}
+	return ""
+}
+
+func (x *Fulfillment) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Fulfillment) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *Fulfillment) GetIsBatched() bool {
+	if x != nil {
+		return x.IsBatched
+	}
+	return false
+}
+
+func (x *Fulfillment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type Intent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	State         string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	Owner         string                 `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
+	Mint          string                 `protobuf:"bytes,5,opt,name=mint,proto3" json:"mint,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Intent) Reset() {
+	*x = Intent{}
+	mi := &file_admin_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Intent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Intent) ProtoMessage() {}
+
+func (x *Intent) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Intent.ProtoReflect.Descriptor instead.
+func (*Intent) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Intent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Intent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Intent) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Intent) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *Intent) GetMint() string {
+	if x != nil {
+		return x.Mint
+	}
+	return ""
+}
+
+func (x *Intent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type Action struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	State         string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	Source        string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	Destination   string                 `protobuf:"bytes,5,opt,name=destination,proto3" json:"destination,omitempty"`
+	Quantity      uint64                 `protobuf:"varint,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Action) Reset() {
+	*x = Action{}
+	mi := &file_admin_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Action) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Action) ProtoMessage() {}
+
+func (x *Action) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Action.ProtoReflect.Descriptor instead.
+func (*Action) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Action) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Action) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Action) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Action) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Action) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *Action) GetQuantity() uint64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type Transaction struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Signature         string                 `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	Slot              uint64                 `protobuf:"varint,2,opt,name=slot,proto3" json:"slot,omitempty"`
+	HasErrors         bool                   `protobuf:"varint,3,opt,name=has_errors,json=hasErrors,proto3" json:"has_errors,omitempty"`
+	ConfirmationState string                 `protobuf:"bytes,4,opt,name=confirmation_state,json=confirmationState,proto3" json:"confirmation_state,omitempty"`
+	Fee               uint64                 `protobuf:"varint,5,opt,name=fee,proto3" json:"fee,omitempty"`
+	BlockTime         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=block_time,json=blockTime,proto3" json:"block_time,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_admin_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Transaction) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *Transaction) GetSlot() uint64 {
+	if x != nil {
+		return x.Slot
+	}
+	return 0
+}
+
+func (x *Transaction) GetHasErrors() bool {
+	if x != nil {
+		return x.HasErrors
+	}
+	return false
+}
+
+func (x *Transaction) GetConfirmationState() string {
+	if x != nil {
+		return x.ConfirmationState
+	}
+	return ""
+}
+
+func (x *Transaction) GetFee() uint64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *Transaction) GetBlockTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BlockTime
+	}
+	return nil
+}
+
+type CircuitBreakerStatus struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Global        *GlobalCircuitBreaker   `protobuf:"bytes,1,opt,name=global,proto3" json:"global,omitempty"`
+	Intent        *ScopedCircuitBreaker   `protobuf:"bytes,2,opt,name=intent,proto3" json:"intent,omitempty"`
+	Accounts      []*ScopedCircuitBreaker `protobuf:"bytes,3,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CircuitBreakerStatus) Reset() {
+	*x = CircuitBreakerStatus{}
+	mi := &file_admin_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CircuitBreakerStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CircuitBreakerStatus) ProtoMessage() {}
+
+func (x *CircuitBreakerStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CircuitBreakerStatus.ProtoReflect.Descriptor instead.
+func (*CircuitBreakerStatus) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CircuitBreakerStatus) GetGlobal() *GlobalCircuitBreaker {
+	if x != nil {
+		return x.Global
+	}
+	return nil
+}
+
+func (x *CircuitBreakerStatus) GetIntent() *ScopedCircuitBreaker {
+	if x != nil {
+		return x.Intent
+	}
+	return nil
+}
+
+func (x *CircuitBreakerStatus) GetAccounts() []*ScopedCircuitBreaker {
+	if x != nil {
+		return x.Accounts
+	}
+	return nil
+}
+
+type GlobalCircuitBreaker struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	FailedFulfillments    uint64                 `protobuf:"varint,1,opt,name=failed_fulfillments,json=failedFulfillments,proto3" json:"failed_fulfillments,omitempty"`
+	MaxFailedFulfillments uint64                 `protobuf:"varint,2,opt,name=max_failed_fulfillments,json=maxFailedFulfillments,proto3" json:"max_failed_fulfillments,omitempty"`
+	IsTripped             bool                   `protobuf:"varint,3,opt,name=is_tripped,json=isTripped,proto3" json:"is_tripped,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *GlobalCircuitBreaker) Reset() {
+	*x = GlobalCircuitBreaker{}
+	mi := &file_admin_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GlobalCircuitBreaker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GlobalCircuitBreaker) ProtoMessage() {}
+
+func (x *GlobalCircuitBreaker) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GlobalCircuitBreaker.ProtoReflect.Descriptor instead.
+func (*GlobalCircuitBreaker) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GlobalCircuitBreaker) GetFailedFulfillments() uint64 {
+	if x != nil {
+		return x.FailedFulfillments
+	}
+	return 0
+}
+
+func (x *GlobalCircuitBreaker) GetMaxFailedFulfillments() uint64 {
+	if x != nil {
+		return x.MaxFailedFulfillments
+	}
+	return 0
+}
+
+func (x *GlobalCircuitBreaker) GetIsTripped() bool {
+	if x != nil {
+		return x.IsTripped
+	}
+	return false
+}
+
+type ScopedCircuitBreaker struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The intent ID or account address the circuit breaker applies to.
+	Id                 string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FailedFulfillments uint64 `protobuf:"varint,2,opt,name=failed_fulfillments,json=failedFulfillments,proto3" json:"failed_fulfillments,omitempty"`
+	IsTripped          bool   `protobuf:"varint,3,opt,name=is_tripped,json=isTripped,proto3" json:"is_tripped,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ScopedCircuitBreaker) Reset() {
+	*x = ScopedCircuitBreaker{}
+	mi := &file_admin_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScopedCircuitBreaker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScopedCircuitBreaker) ProtoMessage() {}
+
+func (x *ScopedCircuitBreaker) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScopedCircuitBreaker.ProtoReflect.Descriptor instead.
+func (*ScopedCircuitBreaker) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ScopedCircuitBreaker) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ScopedCircuitBreaker) GetFailedFulfillments() uint64 {
+	if x != nil {
+		return x.FailedFulfillments
+	}
+	return 0
+}
+
+func (x *ScopedCircuitBreaker) GetIsTripped() bool {
+	if x != nil {
+		return x.IsTripped
+	}
+	return false
+}
+
+type ResolutionRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FulfillmentId uint64                 `protobuf:"varint,1,opt,name=fulfillment_id,json=fulfillmentId,proto3" json:"fulfillment_id,omitempty"`
+	Intent        string                 `protobuf:"bytes,2,opt,name=intent,proto3" json:"intent,omitempty"`
+	ActionId      uint32                 `protobuf:"varint,3,opt,name=action_id,json=actionId,proto3" json:"action_id,omitempty"`
+	Resolution    Resolution             `protobuf:"varint,4,opt,name=resolution,proto3,enum=ocp.admin.v1.Resolution" json:"resolution,omitempty"`
+	Operator      string                 `protobuf:"bytes,5,opt,name=operator,proto3" json:"operator,omitempty"`
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	PreviousState string                 `protobuf:"bytes,7,opt,name=previous_state,json=previousState,proto3" json:"previous_state,omitempty"`
+	NewState      string                 `protobuf:"bytes,8,opt,name=new_state,json=newState,proto3" json:"new_state,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolutionRecord) Reset() {
+	*x = ResolutionRecord{}
+	mi := &file_admin_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolutionRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolutionRecord) ProtoMessage() {}
+
+func (x *ResolutionRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolutionRecord.ProtoReflect.Descriptor instead.
+func (*ResolutionRecord) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ResolutionRecord) GetFulfillmentId() uint64 {
+	if x != nil {
+		return x.FulfillmentId
+	}
+	return 0
+}
+
+func (x *ResolutionRecord) GetIntent() string {
+	if x != nil {
+		return x.Intent
+	}
+	return ""
+}
+
+func (x *ResolutionRecord) GetActionId() uint32 {
+	if x != nil {
+		return x.ActionId
+	}
+	return 0
+}
+
+func (x *ResolutionRecord) GetResolution() Resolution {
+	if x != nil {
+		return x.Resolution
+	}
+	return Resolution_UNKNOWN_RESOLUTION
+}
+
+func (x *ResolutionRecord) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *ResolutionRecord) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ResolutionRecord) GetPreviousState() string {
+	if x != nil {
+		return x.PreviousState
+	}
+	return ""
+}
+
+func (x *ResolutionRecord) GetNewState() string {
+	if x != nil {
+		return x.NewState
+	}
+	return ""
+}
+
+func (x *ResolutionRecord) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+var File_admin_service_proto protoreflect.FileDescriptor
+
+const file_admin_service_proto_rawDesc = "" +
+	"\n" +
+	"\x13admin_service.proto\x12\focp.admin.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"S\n" +
+	"\x1cGetFailedFulfillmentsRequest\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\x04R\x06cursor\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\rR\bpageSize\"\xec\x01\n" +
+	"\x1dGetFailedFulfillmentsResponse\x12P\n" +
+	"\x13failed_fulfillments\x18\x01 \x03(\v2\x1f.ocp.admin.v1.FailedFulfillmentR\x12failedFulfillments\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\x04R\n" +
+	"nextCursor\x12X\n" +
+	"\x16global_circuit_breaker\x18\x03 \x01(\v2\".ocp.admin.v1.GlobalCircuitBreakerR\x14globalCircuitBreaker\"\xb6\x01\n" +
+	"\x1fResolveFailedFulfillmentRequest\x12%\n" +
+	"\x0efulfillment_id\x18\x01 \x01(\x04R\rfulfillmentId\x128\n" +
+	"\n" +
+	"resolution\x18\x02 \x01(\x0e2\x18.ocp.admin.v1.ResolutionR\n" +
+	"resolution\x12\x1a\n" +
+	"\boperator\x18\x03 \x01(\tR\boperator\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"\xd0\x02\n" +
+	" ResolveFailedFulfillmentResponse\x12M\n" +
+	"\x06result\x18\x01 \x01(\x0e25.ocp.admin.v1.ResolveFailedFulfillmentResponse.ResultR\x06result\x12A\n" +
+	"\vfulfillment\x18\x02 \x01(\v2\x1f.ocp.admin.v1.FailedFulfillmentR\vfulfillment\x12X\n" +
+	"\x16circuit_breaker_status\x18\x03 \x01(\v2\".ocp.admin.v1.CircuitBreakerStatusR\x14circuitBreakerStatus\"@\n" +
+	"\x06Result\x12\x06\n" +
+	"\x02OK\x10\x00\x12\r\n" +
+	"\tNOT_FOUND\x10\x01\x12\x0e\n" +
+	"\n" +
+	"NOT_FAILED\x10\x02\x12\x0f\n" +
+	"\vUNSUPPORTED\x10\x03\"G\n" +
+	"\x1eGetCircuitBreakerStatusRequest\x12%\n" +
+	"\x0efulfillment_id\x18\x01 \x01(\x04R\rfulfillmentId\"\xea\x01\n" +
+	"\x1fGetCircuitBreakerStatusResponse\x12L\n" +
+	"\x06result\x18\x01 \x01(\x0e24.ocp.admin.v1.GetCircuitBreakerStatusResponse.ResultR\x06result\x12X\n" +
+	"\x16circuit_breaker_status\x18\x02 \x01(\v2\".ocp.admin.v1.CircuitBreakerStatusR\x14circuitBreakerStatus\"\x1f\n" +
+	"\x06Result\x12\x06\n" +
+	"\x02OK\x10\x00\x12\r\n" +
+	"\tNOT_FOUND\x10\x01\"D\n" +
+	"\x1bGetResolutionHistoryRequest\x12%\n" +
+	"\x0efulfillment_id\x18\x01 \x01(\x04R\rfulfillmentId\"`\n" +
+	"\x1cGetResolutionHistoryResponse\x12@\n" +
+	"\vresolutions\x18\x01 \x03(\v2\x1e.ocp.admin.v1.ResolutionRecordR\vresolutions\"\xe9\x01\n" +
+	"\x11FailedFulfillment\x12;\n" +
+	"\vfulfillment\x18\x01 \x01(\v2\x19.ocp.admin.v1.FulfillmentR\vfulfillment\x12,\n" +
+	"\x06intent\x18\x02 \x01(\v2\x14.ocp.admin.v1.IntentR\x06intent\x12,\n" +
+	"\x06action\x18\x03 \x01(\v2\x14.ocp.admin.v1.ActionR\x06action\x12;\n" +
+	"\vtransaction\x18\x04 \x01(\v2\x19.ocp.admin.v1.TransactionR\vtransaction\"\xa6\x02\n" +
+	"\vFulfillment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x12\x1c\n" +
+	"\tsignature\x18\x04 \x01(\tR\tsignature\x12+\n" +
+	"\x11virtual_signature\x18\x05 \x01(\tR\x10virtualSignature\x12\x16\n" +
+	"\x06source\x18\x06 \x01(\tR\x06source\x12 \n" +
+	"\vdestination\x18\a \x01(\tR\vdestination\x12\x1d\n" +
+	"\n" +
+	"is_batched\x18\b \x01(\bR\tisBatched\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xa7\x01\n" +
+	"\x06Intent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x12\x14\n" +
+	"\x05owner\x18\x04 \x01(\tR\x05owner\x12\x12\n" +
+	"\x04mint\x18\x05 \x01(\tR\x04mint\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x98\x01\n" +
+	"\x06Action\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\x12 \n" +
+	"\vdestination\x18\x05 \x01(\tR\vdestination\x12\x1a\n" +
+	"\bquantity\x18\x06 \x01(\x04R\bquantity\"\xda\x01\n" +
+	"\vTransaction\x12\x1c\n" +
+	"\tsignature\x18\x01 \x01(\tR\tsignature\x12\x12\n" +
+	"\x04slot\x18\x02 \x01(\x04R\x04slot\x12\x1d\n" +
+	"\n" +
+	"has_errors\x18\x03 \x01(\bR\thasErrors\x12-\n" +
+	"\x12confirmation_state\x18\x04 \x01(\tR\x11confirmationState\x12\x10\n" +
+	"\x03fee\x18\x05 \x01(\x04R\x03fee\x129\n" +
+	"\n" +
+	"block_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tblockTime\"\xce\x01\n" +
+	"\x14CircuitBreakerStatus\x12:\n" +
+	"\x06global\x18\x01 \x01(\v2\".ocp.admin.v1.GlobalCircuitBreakerR\x06global\x12:\n" +
+	"\x06intent\x18\x02 \x01(\v2\".ocp.admin.v1.ScopedCircuitBreakerR\x06intent\x12>\n" +
+	"\baccounts\x18\x03 \x03(\v2\".ocp.admin.v1.ScopedCircuitBreakerR\baccounts\"\x9e\x01\n" +
+	"\x14GlobalCircuitBreaker\x12/\n" +
+	"\x13failed_fulfillments\x18\x01 \x01(\x04R\x12failedFulfillments\x126\n" +
+	"\x17max_failed_fulfillments\x18\x02 \x01(\x04R\x15maxFailedFulfillments\x12\x1d\n" +
+	"\n" +
+	"is_tripped\x18\x03 \x01(\bR\tisTripped\"v\n" +
+	"\x14ScopedCircuitBreaker\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12/\n" +
+	"\x13failed_fulfillments\x18\x02 \x01(\x04R\x12failedFulfillments\x12\x1d\n" +
+	"\n" +
+	"is_tripped\x18\x03 \x01(\bR\tisTripped\"\xdb\x02\n" +
+	"\x10ResolutionRecord\x12%\n" +
+	"\x0efulfillment_id\x18\x01 \x01(\x04R\rfulfillmentId\x12\x16\n" +
+	"\x06intent\x18\x02 \x01(\tR\x06intent\x12\x1b\n" +
+	"\taction_id\x18\x03 \x01(\rR\bactionId\x128\n" +
+	"\n" +
+	"resolution\x18\x04 \x01(\x0e2\x18.ocp.admin.v1.ResolutionR\n" +
+	"resolution\x12\x1a\n" +
+	"\boperator\x18\x05 \x01(\tR\boperator\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12%\n" +
+	"\x0eprevious_state\x18\a \x01(\tR\rpreviousState\x12\x1b\n" +
+	"\tnew_state\x18\b \x01(\tR\bnewState\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt*H\n" +
+	"\n" +
+	"Resolution\x12\x16\n" +
+	"\x12UNKNOWN_RESOLUTION\x10\x00\x12\t\n" +
+	"\x05RETRY\x10\x01\x12\n" +
+	"\n" +
+	"\x06REVOKE\x10\x02\x12\v\n" +
+	"\aRESOLVE\x10\x032\xdb\x03\n" +
+	"\x05Admin\x12p\n" +
+	"\x15GetFailedFulfillments\x12*.ocp.admin.v1.GetFailedFulfillmentsRequest\x1a+.ocp.admin.v1.GetFailedFulfillmentsResponse\x12y\n" +
+	"\x18ResolveFailedFulfillment\x12-.ocp.admin.v1.ResolveFailedFulfillmentRequest\x1a..ocp.admin.v1.ResolveFailedFulfillmentResponse\x12v\n" +
+	"\x17GetCircuitBreakerStatus\x12,.ocp.admin.v1.GetCircuitBreakerStatusRequest\x1a-.ocp.admin.v1.GetCircuitBreakerStatusResponse\x12m\n" +
+	"\x14GetResolutionHistory\x12).ocp.admin.v1.GetResolutionHistoryRequest\x1a*.ocp.admin.v1.GetResolutionHistoryResponseB\tZ\a.;adminb\x06proto3"
+
+var (
+	file_admin_service_proto_rawDescOnce sync.Once
+	file_admin_service_proto_rawDescData []byte
+)
+
+func file_admin_service_proto_rawDescGZIP() []byte {
+	file_admin_service_proto_rawDescOnce.Do(func() {
+		file_admin_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_admin_service_proto_rawDesc), len(file_admin_service_proto_rawDesc)))
+	})
+	return file_admin_service_proto_rawDescData
+}
+
+var file_admin_service_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_admin_service_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_admin_service_proto_goTypes = []any{
+	(Resolution)(0), // 0: ocp.admin.v1.Resolution
+	(ResolveFailedFulfillmentResponse_Result)(0), // 1: ocp.admin.v1.ResolveFailedFulfillmentResponse.Result
+	(GetCircuitBreakerStatusResponse_Result)(0),  // 2: ocp.admin.v1.GetCircuitBreakerStatusResponse.Result
+	(*GetFailedFulfillmentsRequest)(nil),         // 3: ocp.admin.v1.GetFailedFulfillmentsRequest
+	(*GetFailedFulfillmentsResponse)(nil),        // 4: ocp.admin.v1.GetFailedFulfillmentsResponse
+	(*ResolveFailedFulfillmentRequest)(nil),      // 5: ocp.admin.v1.ResolveFailedFulfillmentRequest
+	(*ResolveFailedFulfillmentResponse)(nil),     // 6: ocp.admin.v1.ResolveFailedFulfillmentResponse
+	(*GetCircuitBreakerStatusRequest)(nil),       // 7: ocp.admin.v1.GetCircuitBreakerStatusRequest
+	(*GetCircuitBreakerStatusResponse)(nil),      // 8: ocp.admin.v1.GetCircuitBreakerStatusResponse
+	(*GetResolutionHistoryRequest)(nil),          // 9: ocp.admin.v1.GetResolutionHistoryRequest
+	(*GetResolutionHistoryResponse)(nil),         // 10: ocp.admin.v1.GetResolutionHistoryResponse
+	(*FailedFulfillment)(nil),                    // 11: ocp.admin.v1.FailedFulfillment
+	(*Fulfillment)(nil),                          // 12: ocp.admin.v1.Fulfillment
+	(*Intent)(nil),                               // 13: ocp.admin.v1.Intent
+	(*Action)(nil),                               // 14: ocp.admin.v1.Action
+	(*Transaction)(nil),                          // 15: ocp.admin.v1.Transaction
+	(*CircuitBreakerStatus)(nil),                 // 16: ocp.admin.v1.CircuitBreakerStatus
+	(*GlobalCircuitBreaker)(nil),                 // 17: ocp.admin.v1.GlobalCircuitBreaker
+	(*ScopedCircuitBreaker)(nil),                 // 18: ocp.admin.v1.ScopedCircuitBreaker
+	(*ResolutionRecord)(nil),                     // 19: ocp.admin.v1.ResolutionRecord
+	(*timestamppb.Timestamp)(nil),                // 20: google.protobuf.Timestamp
+}
+var file_admin_service_proto_depIdxs = []int32{
+	11, // 0: ocp.admin.v1.GetFailedFulfillmentsResponse.failed_fulfillments:type_name -> ocp.admin.v1.FailedFulfillment
+	17, // 1: ocp.admin.v1.GetFailedFulfillmentsResponse.global_circuit_breaker:type_name -> ocp.admin.v1.GlobalCircuitBreaker
+	0,  // 2: ocp.admin.v1.ResolveFailedFulfillmentRequest.resolution:type_name -> ocp.admin.v1.Resolution
+	1,  // 3: ocp.admin.v1.ResolveFailedFulfillmentResponse.result:type_name -> ocp.admin.v1.ResolveFailedFulfillmentResponse.Result
+	11, // 4: ocp.admin.v1.ResolveFailedFulfillmentResponse.fulfillment:type_name -> ocp.admin.v1.FailedFulfillment
+	16, // 5: ocp.admin.v1.ResolveFailedFulfillmentResponse.circuit_breaker_status:type_name -> ocp.admin.v1.CircuitBreakerStatus
+	2,  // 6: ocp.admin.v1.GetCircuitBreakerStatusResponse.result:type_name -> ocp.admin.v1.GetCircuitBreakerStatusResponse.Result
+	16, // 7: ocp.admin.v1.GetCircuitBreakerStatusResponse.circuit_breaker_status:type_name -> ocp.admin.v1.CircuitBreakerStatus
+	19, // 8: ocp.admin.v1.GetResolutionHistoryResponse.resolutions:type_name -> ocp.admin.v1.ResolutionRecord
+	12, // 9: ocp.admin.v1.FailedFulfillment.fulfillment:type_name -> ocp.admin.v1.Fulfillment
+	13, // 10: ocp.admin.v1.FailedFulfillment.intent:type_name -> ocp.admin.v1.Intent
+	14, // 11: ocp.admin.v1.FailedFulfillment.action:type_name -> ocp.admin.v1.Action
+	15, // 12: ocp.admin.v1.FailedFulfillment.transaction:type_name -> ocp.admin.v1.Transaction
+	20, // 13: ocp.admin.v1.Fulfillment.created_at:type_name -> google.protobuf.Timestamp
+	20, // 14: ocp.admin.v1.Intent.created_at:type_name -> google.protobuf.Timestamp
+	20, // 15: ocp.admin.v1.Transaction.block_time:type_name -> google.protobuf.Timestamp
+	17, // 16: ocp.admin.v1.CircuitBreakerStatus.global:type_name -> ocp.admin.v1.GlobalCircuitBreaker
+	18, // 17: ocp.admin.v1.CircuitBreakerStatus.intent:type_name -> ocp.admin.v1.ScopedCircuitBreaker
+	18, // 18: ocp.admin.v1.CircuitBreakerStatus.accounts:type_name -> ocp.admin.v1.ScopedCircuitBreaker
+	0,  // 19: ocp.admin.v1.ResolutionRecord.resolution:type_name -> ocp.admin.v1.Resolution
+	20, // 20: ocp.admin.v1.ResolutionRecord.created_at:type_name -> google.protobuf.Timestamp
+	3,  // 21: ocp.admin.v1.Admin.GetFailedFulfillments:input_type -> ocp.admin.v1.GetFailedFulfillmentsRequest
+	5,  // 22: ocp.admin.v1.Admin.ResolveFailedFulfillment:input_type -> ocp.admin.v1.ResolveFailedFulfillmentRequest
+	7,  // 23: ocp.admin.v1.Admin.GetCircuitBreakerStatus:input_type -> ocp.admin.v1.GetCircuitBreakerStatusRequest
+	9,  // 24: ocp.admin.v1.Admin.GetResolutionHistory:input_type -> ocp.admin.v1.GetResolutionHistoryRequest
+	4,  // 25: ocp.admin.v1.Admin.GetFailedFulfillments:output_type -> ocp.admin.v1.GetFailedFulfillmentsResponse
+	6,  // 26: ocp.admin.v1.Admin.ResolveFailedFulfillment:output_type -> ocp.admin.v1.ResolveFailedFulfillmentResponse
+	8,  // 27: ocp.admin.v1.Admin.GetCircuitBreakerStatus:output_type -> ocp.admin.v1.GetCircuitBreakerStatusResponse
+	10, // 28: ocp.admin.v1.Admin.GetResolutionHistory:output_type -> ocp.admin.v1.GetResolutionHistoryResponse
+	25, // [25:29] is the sub-list for method output_type
+	21, // [21:25] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
+}
+
+func init() { file_admin_service_proto_init() }
+func file_admin_service_proto_init() {
+	if File_admin_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_admin_service_proto_rawDesc), len(file_admin_service_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_admin_service_proto_goTypes,
+		DependencyIndexes: file_admin_service_proto_depIdxs,
+		EnumInfos:         file_admin_service_proto_enumTypes,
+		MessageInfos:      file_admin_service_proto_msgTypes,
+	}.Build()
+	File_admin_service_proto = out.File
+	file_admin_service_proto_goTypes = nil
+	file_admin_service_proto_depIdxs = nil
+}
@@ -0,0 +1,55 @@
+package admin
+
+import (
+	"github.com/code-payments/ocp-server/config"
+	"github.com/code-payments/ocp-server/config/env"
+	"github.com/code-payments/ocp-server/config/memory"
+	"github.com/code-payments/ocp-server/config/wrapper"
+)
+
+const (
+	envConfigPrefix = "ADMIN_SERVICE_"
+
+	ApiKeyConfigEnvName = envConfigPrefix + "API_KEY"
+	defaultApiKey       = "" // Rejects all requests until set
+
+	DefaultPageSizeConfigEnvName = envConfigPrefix + "DEFAULT_PAGE_SIZE"
+	defaultDefaultPageSize       = 100
+
+	MaxPageSizeConfigEnvName = envConfigPrefix + "MAX_PAGE_SIZE"
+	defaultMaxPageSize       = 1000
+)
+
+type conf struct {
+	apiKey          config.String
+	defaultPageSize config.Uint64
+	maxPageSize     config.Uint64
+}
+
+// ConfigProvider defines how config values are pulled
+type ConfigProvider func() *conf
+
+// WithEnvConfigs returns configuration pulled from environment variables
+func WithEnvConfigs() ConfigProvider {
+	return func() *conf {
+		return &conf{
+			apiKey:          env.NewStringConfig(ApiKeyConfigEnvName, defaultApiKey),
+			defaultPageSize: env.NewUint64Config(DefaultPageSizeConfigEnvName, defaultDefaultPageSize),
+			maxPageSize:     env.NewUint64Config(MaxPageSizeConfigEnvName, defaultMaxPageSize),
+		}
+	}
+}
+
+type testOverrides struct {
+	apiKey string
+}
+
+func withManualTestOverrides(overrides *testOverrides) ConfigProvider {
+	return func() *conf {
+		return &conf{
+			apiKey:          wrapper.NewStringConfig(memory.NewConfig(overrides.apiKey), defaultApiKey),
+			defaultPageSize: wrapper.NewUint64Config(memory.NewConfig(defaultDefaultPageSize), defaultDefaultPageSize),
+			maxPageSize:     wrapper.NewUint64Config(memory.NewConfig(defaultMaxPageSize), defaultMaxPageSize),
+		}
+	}
+}
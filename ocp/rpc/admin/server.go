@@ -0,0 +1,466 @@
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/code-payments/ocp-server/database/query"
+	"github.com/code-payments/ocp-server/grpc/client"
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/action"
+	"github.com/code-payments/ocp-server/ocp/data/batch"
+	"github.com/code-payments/ocp-server/ocp/data/fulfillment"
+	"github.com/code-payments/ocp-server/ocp/data/intent"
+	"github.com/code-payments/ocp-server/ocp/data/resolution"
+	"github.com/code-payments/ocp-server/ocp/data/transaction"
+	adminpb "github.com/code-payments/ocp-server/ocp/rpc/admin/api/gen"
+	"github.com/code-payments/ocp-server/ocp/worker/sequencer"
+)
+
+const (
+	apiKeyHeaderName = "admin-api-key"
+)
+
+type server struct {
+	log                     *zap.Logger
+	conf                    *conf
+	data                    ocp_data.Provider
+	sequencerConfigProvider sequencer.ConfigProvider
+
+	adminpb.UnimplementedAdminServer
+}
+
+func NewAdminServer(
+	log *zap.Logger,
+	data ocp_data.Provider,
+	sequencerConfigProvider sequencer.ConfigProvider,
+	configProvider ConfigProvider,
+) adminpb.AdminServer {
+	return &server{
+		log:                     log,
+		conf:                    configProvider(),
+		data:                    data,
+		sequencerConfigProvider: sequencerConfigProvider,
+	}
+}
+
+func (s *server) GetFailedFulfillments(ctx context.Context, req *adminpb.GetFailedFulfillmentsRequest) (*adminpb.GetFailedFulfillmentsResponse, error) {
+	log := s.log.With(zap.String("method", "GetFailedFulfillments"))
+	log = client.InjectLoggingMetadata(ctx, log)
+
+	if err := s.authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	pageSize := uint64(req.PageSize)
+	if pageSize == 0 {
+		pageSize = s.conf.defaultPageSize.Get(ctx)
+	}
+	if pageSize > s.conf.maxPageSize.Get(ctx) {
+		return nil, status.Error(codes.InvalidArgument, "page size exceeds max")
+	}
+
+	opts := []query.Option{query.WithLimit(pageSize)}
+	if req.Cursor > 0 {
+		opts = append(opts, query.WithCursor(query.ToCursor(req.Cursor)))
+	}
+
+	fulfillmentRecords, err := s.data.GetAllFulfillmentsByState(
+		ctx,
+		fulfillment.StateFailed,
+		true, // Failed fulfillments are never actively scheduled, so include everything
+		opts...,
+	)
+	if err != nil && err != fulfillment.ErrFulfillmentNotFound {
+		log.With(zap.Error(err)).Warn("failure getting failed fulfillments")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	var protoFailedFulfillments []*adminpb.FailedFulfillment
+	for _, fulfillmentRecord := range fulfillmentRecords {
+		protoFailedFulfillment, err := s.toFailedFulfillmentProto(ctx, fulfillmentRecord)
+		if err != nil {
+			log.With(zap.Error(err), zap.Uint64("fulfillment", fulfillmentRecord.Id)).Warn("failure getting failed fulfillment details")
+			return nil, status.Error(codes.Internal, "")
+		}
+		protoFailedFulfillments = append(protoFailedFulfillments, protoFailedFulfillment)
+	}
+
+	var nextCursor uint64
+	if uint64(len(fulfillmentRecords)) == pageSize {
+		nextCursor = fulfillmentRecords[len(fulfillmentRecords)-1].Id
+	}
+
+	failedFulfillments, maxFailedFulfillments, isTripped, err := sequencer.GetGlobalCircuitBreakerStatus(ctx, s.data, s.sequencerConfigProvider)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure getting global circuit breaker status")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return &adminpb.GetFailedFulfillmentsResponse{
+		FailedFulfillments: protoFailedFulfillments,
+		NextCursor:         nextCursor,
+		GlobalCircuitBreaker: &adminpb.GlobalCircuitBreaker{
+			FailedFulfillments:    failedFulfillments,
+			MaxFailedFulfillments: maxFailedFulfillments,
+			IsTripped:             isTripped,
+		},
+	}, nil
+}
+
+func (s *server) ResolveFailedFulfillment(ctx context.Context, req *adminpb.ResolveFailedFulfillmentRequest) (*adminpb.ResolveFailedFulfillmentResponse, error) {
+	log := s.log.With(
+		zap.String("method", "ResolveFailedFulfillment"),
+		zap.Uint64("fulfillment", req.FulfillmentId),
+		zap.String("resolution", req.Resolution.String()),
+		zap.String("operator", req.Operator),
+	)
+	log = client.InjectLoggingMetadata(ctx, log)
+
+	if err := s.authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	var resolutionType resolution.Type
+	var resolveFn func(context.Context, ocp_data.Provider, *fulfillment.Record) error
+	switch req.Resolution {
+	case adminpb.Resolution_RETRY:
+		resolutionType = resolution.TypeRetry
+		resolveFn = sequencer.RetryFailedFulfillment
+	case adminpb.Resolution_REVOKE:
+		resolutionType = resolution.TypeRevoke
+		resolveFn = sequencer.RevokeFailedFulfillment
+	case adminpb.Resolution_RESOLVE:
+		resolutionType = resolution.TypeResolve
+		resolveFn = sequencer.ResolveFailedFulfillment
+	default:
+		return nil, status.Error(codes.InvalidArgument, "resolution is required")
+	}
+
+	if len(req.Operator) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "operator is required")
+	}
+	if len(req.Reason) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "reason is required")
+	}
+
+	fulfillmentRecord, err := s.data.GetFulfillmentById(ctx, req.FulfillmentId)
+	if err == fulfillment.ErrFulfillmentNotFound {
+		return &adminpb.ResolveFailedFulfillmentResponse{
+			Result: adminpb.ResolveFailedFulfillmentResponse_NOT_FOUND,
+		}, nil
+	} else if err != nil {
+		log.With(zap.Error(err)).Warn("failure getting fulfillment")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	if fulfillmentRecord.State != fulfillment.StateFailed {
+		return &adminpb.ResolveFailedFulfillmentResponse{
+			Result: adminpb.ResolveFailedFulfillmentResponse_NOT_FAILED,
+		}, nil
+	}
+
+	previousState := fulfillmentRecord.State
+	err = s.data.ExecuteInTx(ctx, sql.LevelDefault, func(ctx context.Context) error {
+		err := resolveFn(ctx, s.data, fulfillmentRecord)
+		if err != nil {
+			return err
+		}
+
+		return s.data.PutFulfillmentResolution(ctx, &resolution.Record{
+			FulfillmentId: fulfillmentRecord.Id,
+			Intent:        fulfillmentRecord.Intent,
+			ActionId:      fulfillmentRecord.ActionId,
+			Type:          resolutionType,
+			Operator:      req.Operator,
+			Reason:        req.Reason,
+			PreviousState: previousState,
+			NewState:      fulfillmentRecord.State,
+		})
+	})
+	switch err {
+	case nil:
+	case sequencer.ErrFulfillmentNotFailed, fulfillment.ErrStaleVersion:
+		return &adminpb.ResolveFailedFulfillmentResponse{
+			Result: adminpb.ResolveFailedFulfillmentResponse_NOT_FAILED,
+		}, nil
+	case sequencer.ErrBatchedFulfillmentNotRetriable, sequencer.ErrInvalidActionStateTransition:
+		return &adminpb.ResolveFailedFulfillmentResponse{
+			Result: adminpb.ResolveFailedFulfillmentResponse_UNSUPPORTED,
+		}, nil
+	default:
+		log.With(zap.Error(err)).Warn("failure resolving fulfillment")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	log.With(zap.String("new_state", fulfillmentRecord.State.String())).Info("resolved failed fulfillment")
+
+	protoFailedFulfillment, err := s.toFailedFulfillmentProto(ctx, fulfillmentRecord)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure getting fulfillment details")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	protoCircuitBreakerStatus, err := s.getCircuitBreakerStatusProto(ctx, fulfillmentRecord)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure getting circuit breaker status")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return &adminpb.ResolveFailedFulfillmentResponse{
+		Result:               adminpb.ResolveFailedFulfillmentResponse_OK,
+		Fulfillment:          protoFailedFulfillment,
+		CircuitBreakerStatus: protoCircuitBreakerStatus,
+	}, nil
+}
+
+func (s *server) GetCircuitBreakerStatus(ctx context.Context, req *adminpb.GetCircuitBreakerStatusRequest) (*adminpb.GetCircuitBreakerStatusResponse, error) {
+	log := s.log.With(
+		zap.String("method", "GetCircuitBreakerStatus"),
+		zap.Uint64("fulfillment", req.FulfillmentId),
+	)
+	log = client.InjectLoggingMetadata(ctx, log)
+
+	if err := s.authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	fulfillmentRecord, err := s.data.GetFulfillmentById(ctx, req.FulfillmentId)
+	if err == fulfillment.ErrFulfillmentNotFound {
+		return &adminpb.GetCircuitBreakerStatusResponse{
+			Result: adminpb.GetCircuitBreakerStatusResponse_NOT_FOUND,
+		}, nil
+	} else if err != nil {
+		log.With(zap.Error(err)).Warn("failure getting fulfillment")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	protoCircuitBreakerStatus, err := s.getCircuitBreakerStatusProto(ctx, fulfillmentRecord)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure getting circuit breaker status")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return &adminpb.GetCircuitBreakerStatusResponse{
+		Result:               adminpb.GetCircuitBreakerStatusResponse_OK,
+		CircuitBreakerStatus: protoCircuitBreakerStatus,
+	}, nil
+}
+
+func (s *server) GetResolutionHistory(ctx context.Context, req *adminpb.GetResolutionHistoryRequest) (*adminpb.GetResolutionHistoryResponse, error) {
+	log := s.log.With(
+		zap.String("method", "GetResolutionHistory"),
+		zap.Uint64("fulfillment", req.FulfillmentId),
+	)
+	log = client.InjectLoggingMetadata(ctx, log)
+
+	if err := s.authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	records, err := s.data.GetAllFulfillmentResolutions(ctx, req.FulfillmentId)
+	if err != nil && err != resolution.ErrNotFound {
+		log.With(zap.Error(err)).Warn("failure getting resolution history")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	var protoResolutions []*adminpb.ResolutionRecord
+	for _, record := range records {
+		protoResolutions = append(protoResolutions, &adminpb.ResolutionRecord{
+			FulfillmentId: record.FulfillmentId,
+			Intent:        record.Intent,
+			ActionId:      record.ActionId,
+			Resolution:    toResolutionProto(record.Type),
+			Operator:      record.Operator,
+			Reason:        record.Reason,
+			PreviousState: record.PreviousState.String(),
+			NewState:      record.NewState.String(),
+			CreatedAt:     timestamppb.New(record.CreatedAt),
+		})
+	}
+
+	return &adminpb.GetResolutionHistoryResponse{
+		Resolutions: protoResolutions,
+	}, nil
+}
+
+// authenticate checks the API key header against the configured key. All
+// requests are rejected when no key is configured.
+func (s *server) authenticate(ctx context.Context) error {
+	expected := s.conf.apiKey.Get(ctx)
+	if len(expected) == 0 {
+		return status.Error(codes.Unauthenticated, "")
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "")
+	}
+
+	values := md.Get(apiKeyHeaderName)
+	if len(values) != 1 {
+		return status.Error(codes.Unauthenticated, "")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(values[0]), []byte(expected)) != 1 {
+		return status.Error(codes.Unauthenticated, "")
+	}
+	return nil
+}
+
+func (s *server) toFailedFulfillmentProto(ctx context.Context, fulfillmentRecord *fulfillment.Record) (*adminpb.FailedFulfillment, error) {
+	intentRecord, err := s.data.GetIntent(ctx, fulfillmentRecord.Intent)
+	if err != nil {
+		return nil, err
+	}
+
+	actionRecord, err := s.data.GetActionById(ctx, fulfillmentRecord.Intent, fulfillmentRecord.ActionId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Batched fulfillments don't own their transaction, so it's found through
+	// the batch, unless the fulfillment was retried with its own transaction
+	// after the batch failed
+	signature := fulfillmentRecord.Signature
+	batchRecord, err := s.data.GetFulfillmentBatchByFulfillment(ctx, fulfillmentRecord.Id)
+	if err == nil {
+		if signature == nil {
+			signature = &batchRecord.Signature
+		}
+	} else if err != batch.ErrNotFound {
+		return nil, err
+	}
+
+	var transactionRecord *transaction.Record
+	if signature != nil {
+		transactionRecord, err = s.data.GetTransaction(ctx, *signature)
+		if err != nil && err != transaction.ErrNotFound {
+			return nil, err
+		}
+	}
+
+	return &adminpb.FailedFulfillment{
+		Fulfillment: toFulfillmentProto(fulfillmentRecord, batchRecord != nil),
+		Intent:      toIntentProto(intentRecord),
+		Action:      toActionProto(actionRecord),
+		Transaction: toTransactionProto(transactionRecord),
+	}, nil
+}
+
+func (s *server) getCircuitBreakerStatusProto(ctx context.Context, fulfillmentRecord *fulfillment.Record) (*adminpb.CircuitBreakerStatus, error) {
+	circuitBreakerStatus, err := sequencer.GetCircuitBreakerStatus(ctx, s.data, s.sequencerConfigProvider, fulfillmentRecord)
+	if err != nil {
+		return nil, err
+	}
+
+	var protoAccounts []*adminpb.ScopedCircuitBreaker
+	for _, account := range circuitBreakerStatus.Accounts {
+		protoAccounts = append(protoAccounts, toScopedCircuitBreakerProto(account))
+	}
+
+	return &adminpb.CircuitBreakerStatus{
+		Global: &adminpb.GlobalCircuitBreaker{
+			FailedFulfillments:    circuitBreakerStatus.GlobalFailedFulfillments,
+			MaxFailedFulfillments: circuitBreakerStatus.MaxGlobalFailedFulfillments,
+			IsTripped:             circuitBreakerStatus.IsGlobalTripped,
+		},
+		Intent:   toScopedCircuitBreakerProto(circuitBreakerStatus.Intent),
+		Accounts: protoAccounts,
+	}, nil
+}
+
+func toFulfillmentProto(record *fulfillment.Record, isBatched bool) *adminpb.Fulfillment {
+	res := &adminpb.Fulfillment{
+		Id:        record.Id,
+		Type:      record.FulfillmentType.String(),
+		State:     record.State.String(),
+		Source:    record.Source,
+		IsBatched: isBatched,
+		CreatedAt: timestamppb.New(record.CreatedAt),
+	}
+	if record.Signature != nil {
+		res.Signature = *record.Signature
+	}
+	if record.VirtualSignature != nil {
+		res.VirtualSignature = *record.VirtualSignature
+	}
+	if record.Destination != nil {
+		res.Destination = *record.Destination
+	}
+	return res
+}
+
+func toIntentProto(record *intent.Record) *adminpb.Intent {
+	return &adminpb.Intent{
+		Id:        record.IntentId,
+		Type:      record.IntentType.String(),
+		State:     record.State.String(),
+		Owner:     record.InitiatorOwnerAccount,
+		Mint:      record.MintAccount,
+		CreatedAt: timestamppb.New(record.CreatedAt),
+	}
+}
+
+func toActionProto(record *action.Record) *adminpb.Action {
+	res := &adminpb.Action{
+		Id:     record.ActionId,
+		Type:   record.ActionType.String(),
+		State:  record.State.String(),
+		Source: record.Source,
+	}
+	if record.Destination != nil {
+		res.Destination = *record.Destination
+	}
+	if record.Quantity != nil {
+		res.Quantity = *record.Quantity
+	}
+	return res
+}
+
+func toTransactionProto(record *transaction.Record) *adminpb.Transaction {
+	if record == nil {
+		return nil
+	}
+
+	res := &adminpb.Transaction{
+		Signature:         record.Signature,
+		Slot:              record.Slot,
+		HasErrors:         record.HasErrors,
+		ConfirmationState: record.ConfirmationState.String(),
+	}
+	if record.Fee != nil {
+		res.Fee = *record.Fee
+	}
+	if !record.BlockTime.IsZero() {
+		res.BlockTime = timestamppb.New(record.BlockTime)
+	}
+	return res
+}
+
+func toScopedCircuitBreakerProto(status sequencer.ScopedCircuitBreakerStatus) *adminpb.ScopedCircuitBreaker {
+	return &adminpb.ScopedCircuitBreaker{
+		Id:                 status.Id,
+		FailedFulfillments: status.FailedFulfillments,
+		IsTripped:          status.IsTripped,
+	}
+}
+
+func toResolutionProto(resolutionType resolution.Type) adminpb.Resolution {
+	switch resolutionType {
+	case resolution.TypeRetry:
+		return adminpb.Resolution_RETRY
+	case resolution.TypeRevoke:
+		return adminpb.Resolution_REVOKE
+	case resolution.TypeResolve:
+		return adminpb.Resolution_RESOLVE
+	}
+	return adminpb.Resolution_UNKNOWN_RESOLUTION
+}
@@ -0,0 +1,296 @@
+package admin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/action"
+	"github.com/code-payments/ocp-server/ocp/data/fulfillment"
+	"github.com/code-payments/ocp-server/ocp/data/intent"
+	"github.com/code-payments/ocp-server/ocp/data/transaction"
+	adminpb "github.com/code-payments/ocp-server/ocp/rpc/admin/api/gen"
+	"github.com/code-payments/ocp-server/ocp/worker/sequencer"
+	"github.com/code-payments/ocp-server/pointer"
+	"github.com/code-payments/ocp-server/testutil"
+)
+
+const testApiKey = "test-api-key"
+
+type testEnv struct {
+	ctx    context.Context
+	client adminpb.AdminClient
+	data   ocp_data.Provider
+}
+
+func setup(t *testing.T) (env testEnv, cleanup func()) {
+	log := zaptest.NewLogger(t)
+
+	conn, serv, err := testutil.NewServer(log)
+	require.NoError(t, err)
+
+	env.ctx = metadata.AppendToOutgoingContext(context.Background(), apiKeyHeaderName, testApiKey)
+	env.client = adminpb.NewAdminClient(conn)
+	env.data = ocp_data.NewTestDataProvider()
+
+	s := NewAdminServer(log, env.data, sequencer.WithEnvConfigs(), withManualTestOverrides(&testOverrides{
+		apiKey: testApiKey,
+	}))
+
+	serv.RegisterService(func(server *grpc.Server) {
+		adminpb.RegisterAdminServer(server, s)
+	})
+
+	cleanup, err = serv.Serve()
+	require.NoError(t, err)
+	return env, cleanup
+}
+
+func TestAuthentication(t *testing.T) {
+	env, cleanup := setup(t)
+	defer cleanup()
+
+	for _, ctx := range []context.Context{
+		context.Background(),
+		metadata.AppendToOutgoingContext(context.Background(), apiKeyHeaderName, "invalid"),
+	} {
+		_, err := env.client.GetFailedFulfillments(ctx, &adminpb.GetFailedFulfillmentsRequest{})
+		testutil.AssertStatusErrorWithCode(t, err, codes.Unauthenticated)
+	}
+
+	_, err := env.client.GetFailedFulfillments(env.ctx, &adminpb.GetFailedFulfillmentsRequest{})
+	require.NoError(t, err)
+}
+
+func TestAuthentication_NoApiKeyConfigured(t *testing.T) {
+	log := zaptest.NewLogger(t)
+
+	conn, serv, err := testutil.NewServer(log)
+	require.NoError(t, err)
+
+	s := NewAdminServer(log, ocp_data.NewTestDataProvider(), sequencer.WithEnvConfigs(), withManualTestOverrides(&testOverrides{}))
+	serv.RegisterService(func(server *grpc.Server) {
+		adminpb.RegisterAdminServer(server, s)
+	})
+
+	cleanup, err := serv.Serve()
+	require.NoError(t, err)
+	defer cleanup()
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), apiKeyHeaderName, "")
+	_, err = adminpb.NewAdminClient(conn).GetFailedFulfillments(ctx, &adminpb.GetFailedFulfillmentsRequest{})
+	testutil.AssertStatusErrorWithCode(t, err, codes.Unauthenticated)
+}
+
+func TestGetFailedFulfillments_Pagination(t *testing.T) {
+	env, cleanup := setup(t)
+	defer cleanup()
+
+	var expected []*fulfillment.Record
+	for i := 0; i < 5; i++ {
+		expected = append(expected, env.createFailedFulfillment(t))
+	}
+
+	var actual []*adminpb.FailedFulfillment
+	var cursor uint64
+	for {
+		resp, err := env.client.GetFailedFulfillments(env.ctx, &adminpb.GetFailedFulfillmentsRequest{
+			Cursor:   cursor,
+			PageSize: 2,
+		})
+		require.NoError(t, err)
+
+		assert.EqualValues(t, len(expected), resp.GlobalCircuitBreaker.FailedFulfillments)
+		assert.EqualValues(t, 10, resp.GlobalCircuitBreaker.MaxFailedFulfillments)
+		assert.False(t, resp.GlobalCircuitBreaker.IsTripped)
+
+		actual = append(actual, resp.FailedFulfillments...)
+		if resp.NextCursor == 0 {
+			break
+		}
+		cursor = resp.NextCursor
+	}
+
+	require.Len(t, actual, len(expected))
+	for i, fulfillmentRecord := range expected {
+		assert.Equal(t, fulfillmentRecord.Id, actual[i].Fulfillment.Id)
+		assert.Equal(t, fulfillment.StateFailed.String(), actual[i].Fulfillment.State)
+		assert.Equal(t, *fulfillmentRecord.Signature, actual[i].Fulfillment.Signature)
+		assert.False(t, actual[i].Fulfillment.IsBatched)
+		assert.Equal(t, fulfillmentRecord.Intent, actual[i].Intent.Id)
+		assert.Equal(t, intent.StateFailed.String(), actual[i].Intent.State)
+		assert.Equal(t, fulfillmentRecord.ActionId, actual[i].Action.Id)
+		assert.Equal(t, action.StateFailed.String(), actual[i].Action.State)
+		require.NotNil(t, actual[i].Transaction)
+		assert.Equal(t, *fulfillmentRecord.Signature, actual[i].Transaction.Signature)
+		assert.True(t, actual[i].Transaction.HasErrors)
+	}
+}
+
+func TestResolveFailedFulfillment_HappyPath(t *testing.T) {
+	for _, tc := range []struct {
+		resolution          adminpb.Resolution
+		expectedFulfillment fulfillment.State
+		expectedAction      action.State
+		expectedIntent      intent.State
+	}{
+		{adminpb.Resolution_RETRY, fulfillment.StateUnknown, action.StatePending, intent.StatePending},
+		{adminpb.Resolution_REVOKE, fulfillment.StateRevoked, action.StateRevoked, intent.StateFailed},
+		{adminpb.Resolution_RESOLVE, fulfillment.StateConfirmed, action.StateConfirmed, intent.StateConfirmed},
+	} {
+		t.Run(tc.resolution.String(), func(t *testing.T) {
+			env, cleanup := setup(t)
+			defer cleanup()
+
+			fulfillmentRecord := env.createFailedFulfillment(t)
+
+			statusResp, err := env.client.GetCircuitBreakerStatus(env.ctx, &adminpb.GetCircuitBreakerStatusRequest{
+				FulfillmentId: fulfillmentRecord.Id,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, adminpb.GetCircuitBreakerStatusResponse_OK, statusResp.Result)
+			assert.True(t, statusResp.CircuitBreakerStatus.Intent.IsTripped)
+			require.Len(t, statusResp.CircuitBreakerStatus.Accounts, 2)
+			for _, account := range statusResp.CircuitBreakerStatus.Accounts {
+				assert.True(t, account.IsTripped)
+			}
+
+			resp, err := env.client.ResolveFailedFulfillment(env.ctx, &adminpb.ResolveFailedFulfillmentRequest{
+				FulfillmentId: fulfillmentRecord.Id,
+				Resolution:    tc.resolution,
+				Operator:      "operator",
+				Reason:        "reason",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, adminpb.ResolveFailedFulfillmentResponse_OK, resp.Result)
+			assert.Equal(t, tc.expectedFulfillment.String(), resp.Fulfillment.Fulfillment.State)
+			assert.Equal(t, tc.expectedAction.String(), resp.Fulfillment.Action.State)
+			assert.Equal(t, tc.expectedIntent.String(), resp.Fulfillment.Intent.State)
+			assert.Zero(t, resp.CircuitBreakerStatus.Global.FailedFulfillments)
+			assert.False(t, resp.CircuitBreakerStatus.Intent.IsTripped)
+			for _, account := range resp.CircuitBreakerStatus.Accounts {
+				assert.False(t, account.IsTripped)
+			}
+
+			historyResp, err := env.client.GetResolutionHistory(env.ctx, &adminpb.GetResolutionHistoryRequest{
+				FulfillmentId: fulfillmentRecord.Id,
+			})
+			require.NoError(t, err)
+			require.Len(t, historyResp.Resolutions, 1)
+			assert.Equal(t, fulfillmentRecord.Id, historyResp.Resolutions[0].FulfillmentId)
+			assert.Equal(t, fulfillmentRecord.Intent, historyResp.Resolutions[0].Intent)
+			assert.Equal(t, tc.resolution, historyResp.Resolutions[0].Resolution)
+			assert.Equal(t, "operator", historyResp.Resolutions[0].Operator)
+			assert.Equal(t, "reason", historyResp.Resolutions[0].Reason)
+			assert.Equal(t, fulfillment.StateFailed.String(), historyResp.Resolutions[0].PreviousState)
+			assert.Equal(t, tc.expectedFulfillment.String(), historyResp.Resolutions[0].NewState)
+
+			resp, err = env.client.ResolveFailedFulfillment(env.ctx, &adminpb.ResolveFailedFulfillmentRequest{
+				FulfillmentId: fulfillmentRecord.Id,
+				Resolution:    tc.resolution,
+				Operator:      "operator",
+				Reason:        "reason",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, adminpb.ResolveFailedFulfillmentResponse_NOT_FAILED, resp.Result)
+		})
+	}
+}
+
+func TestResolveFailedFulfillment_NotFound(t *testing.T) {
+	env, cleanup := setup(t)
+	defer cleanup()
+
+	resp, err := env.client.ResolveFailedFulfillment(env.ctx, &adminpb.ResolveFailedFulfillmentRequest{
+		FulfillmentId: 1,
+		Resolution:    adminpb.Resolution_RETRY,
+		Operator:      "operator",
+		Reason:        "reason",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, adminpb.ResolveFailedFulfillmentResponse_NOT_FOUND, resp.Result)
+
+	statusResp, err := env.client.GetCircuitBreakerStatus(env.ctx, &adminpb.GetCircuitBreakerStatusRequest{
+		FulfillmentId: 1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, adminpb.GetCircuitBreakerStatusResponse_NOT_FOUND, statusResp.Result)
+
+	historyResp, err := env.client.GetResolutionHistory(env.ctx, &adminpb.GetResolutionHistoryRequest{
+		FulfillmentId: 1,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, historyResp.Resolutions)
+}
+
+func TestResolveFailedFulfillment_InvalidArguments(t *testing.T) {
+	env, cleanup := setup(t)
+	defer cleanup()
+
+	fulfillmentRecord := env.createFailedFulfillment(t)
+
+	for _, req := range []*adminpb.ResolveFailedFulfillmentRequest{
+		{FulfillmentId: fulfillmentRecord.Id, Operator: "operator", Reason: "reason"},
+		{FulfillmentId: fulfillmentRecord.Id, Resolution: adminpb.Resolution_RETRY, Reason: "reason"},
+		{FulfillmentId: fulfillmentRecord.Id, Resolution: adminpb.Resolution_RETRY, Operator: "operator"},
+	} {
+		_, err := env.client.ResolveFailedFulfillment(env.ctx, req)
+		testutil.AssertStatusErrorWithCode(t, err, codes.InvalidArgument)
+	}
+
+	actual, err := env.data.GetFulfillmentById(env.ctx, fulfillmentRecord.Id)
+	require.NoError(t, err)
+	assert.Equal(t, fulfillment.StateFailed, actual.State)
+}
+
+func (e *testEnv) createFailedFulfillment(t *testing.T) *fulfillment.Record {
+	intentRecord := &intent.Record{
+		IntentId:              testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+		IntentType:            intent.OpenAccounts,
+		MintAccount:           testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+		InitiatorOwnerAccount: testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+		OpenAccountsMetadata:  &intent.OpenAccountsMetadata{},
+		State:                 intent.StateFailed,
+	}
+	require.NoError(t, e.data.SaveIntent(e.ctx, intentRecord))
+
+	fulfillmentRecord := &fulfillment.Record{
+		Intent:          intentRecord.IntentId,
+		IntentType:      intentRecord.IntentType,
+		ActionId:        0,
+		ActionType:      action.OpenAccount,
+		FulfillmentType: fulfillment.InitializeLockedTimelockAccount,
+		Signature:       pointer.String(testutil.NewRandomAccount(t).PublicKey().ToBase58()),
+		Nonce:           pointer.String(testutil.NewRandomAccount(t).PublicKey().ToBase58()),
+		Blockhash:       pointer.String(testutil.NewRandomAccount(t).PublicKey().ToBase58()),
+		Source:          testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+		Destination:     pointer.String(testutil.NewRandomAccount(t).PublicKey().ToBase58()),
+		State:           fulfillment.StateFailed,
+	}
+	require.NoError(t, e.data.PutAllFulfillments(e.ctx, fulfillmentRecord))
+
+	require.NoError(t, e.data.PutAllActions(e.ctx, &action.Record{
+		Intent:     fulfillmentRecord.Intent,
+		IntentType: fulfillmentRecord.IntentType,
+		ActionId:   fulfillmentRecord.ActionId,
+		ActionType: fulfillmentRecord.ActionType,
+		Source:     fulfillmentRecord.Source,
+		State:      action.StateFailed,
+	}))
+
+	require.NoError(t, e.data.SaveTransaction(e.ctx, &transaction.Record{
+		Signature:         *fulfillmentRecord.Signature,
+		Slot:              12345,
+		HasErrors:         true,
+		ConfirmationState: transaction.ConfirmationFinalized,
+	}))
+
+	return fulfillmentRecord
+}
@@ -0,0 +1,74 @@
+package currency
+
+import (
+	"github.com/code-payments/ocp-server/config"
+	"github.com/code-payments/ocp-server/config/env"
+	"github.com/code-payments/ocp-server/config/memory"
+	"github.com/code-payments/ocp-server/config/wrapper"
+	"github.com/code-payments/ocp-server/solana/currencycreator"
+)
+
+const (
+	envConfigPrefix = "LAUNCHPAD_SERVICE_"
+
+	// The authority must have its private key in the vault, and be funded to
+	// pay rent for the currency accounts it creates
+	AuthorityPublicKeyConfigEnvName = envConfigPrefix + "AUTHORITY_PUBLIC_KEY"
+	defaultAuthorityPublicKey       = "" // Denies all launches until set
+
+	SellFeeBpsConfigEnvName = envConfigPrefix + "SELL_FEE_BPS"
+	defaultSellFeeBps       = currencycreator.DefaultSellFeeBps
+
+	MaxDescriptionLengthConfigEnvName = envConfigPrefix + "MAX_DESCRIPTION_LENGTH"
+	defaultMaxDescriptionLength       = 500
+
+	// Comma-separated list of hosts that images can be served from
+	AllowedImageHostsConfigEnvName = envConfigPrefix + "ALLOWED_IMAGE_HOSTS"
+	defaultAllowedImageHosts       = "" // Allows any host
+
+	// Comma-separated list of owner public keys that can launch currencies
+	AllowedCreatorsConfigEnvName = envConfigPrefix + "ALLOWED_CREATORS"
+	defaultAllowedCreators       = "" // Allows any owner passing antispam checks
+)
+
+type conf struct {
+	authorityPublicKey   config.String
+	sellFeeBps           config.Uint64
+	maxDescriptionLength config.Uint64
+	allowedImageHosts    config.String
+	allowedCreators      config.String
+}
+
+// ConfigProvider defines how config values are pulled
+type ConfigProvider func() *conf
+
+// WithEnvConfigs returns configuration pulled from environment variables
+func WithEnvConfigs() ConfigProvider {
+	return func() *conf {
+		return &conf{
+			authorityPublicKey:   env.NewStringConfig(AuthorityPublicKeyConfigEnvName, defaultAuthorityPublicKey),
+			sellFeeBps:           env.NewUint64Config(SellFeeBpsConfigEnvName, defaultSellFeeBps),
+			maxDescriptionLength: env.NewUint64Config(MaxDescriptionLengthConfigEnvName, defaultMaxDescriptionLength),
+			allowedImageHosts:    env.NewStringConfig(AllowedImageHostsConfigEnvName, defaultAllowedImageHosts),
+			allowedCreators:      env.NewStringConfig(AllowedCreatorsConfigEnvName, defaultAllowedCreators),
+		}
+	}
+}
+
+type testOverrides struct {
+	authorityPublicKey string
+	allowedImageHosts  string
+	allowedCreators    string
+}
+
+func withManualTestOverrides(overrides *testOverrides) ConfigProvider {
+	return func() *conf {
+		return &conf{
+			authorityPublicKey:   wrapper.NewStringConfig(memory.NewConfig(overrides.authorityPublicKey), defaultAuthorityPublicKey),
+			sellFeeBps:           wrapper.NewUint64Config(memory.NewConfig(uint64(defaultSellFeeBps)), defaultSellFeeBps),
+			maxDescriptionLength: wrapper.NewUint64Config(memory.NewConfig(uint64(defaultMaxDescriptionLength)), defaultMaxDescriptionLength),
+			allowedImageHosts:    wrapper.NewStringConfig(memory.NewConfig(overrides.allowedImageHosts), defaultAllowedImageHosts),
+			allowedCreators:      wrapper.NewStringConfig(memory.NewConfig(overrides.allowedCreators), defaultAllowedCreators),
+		}
+	}
+}
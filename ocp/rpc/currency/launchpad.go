@@ -0,0 +1,468 @@
+package currency
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mr-tron/base58"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	commonpb "github.com/code-payments/ocp-protobuf-api/generated/go/common/v1"
+
+	"github.com/code-payments/ocp-server/grpc/client"
+	"github.com/code-payments/ocp-server/ocp/antispam"
+	auth_util "github.com/code-payments/ocp-server/ocp/auth"
+	"github.com/code-payments/ocp-server/ocp/common"
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/action"
+	"github.com/code-payments/ocp-server/ocp/data/currency"
+	"github.com/code-payments/ocp-server/ocp/data/fulfillment"
+	"github.com/code-payments/ocp-server/ocp/data/intent"
+	"github.com/code-payments/ocp-server/ocp/data/launch"
+	"github.com/code-payments/ocp-server/ocp/data/vault"
+	launchpadpb "github.com/code-payments/ocp-server/ocp/rpc/currency/api/gen"
+	"github.com/code-payments/ocp-server/solana/currencycreator"
+)
+
+var (
+	symbolRegex = regexp.MustCompile("^[A-Z0-9]+$")
+)
+
+type launchpadServer struct {
+	log  *zap.Logger
+	conf *conf
+	data ocp_data.Provider
+	auth *auth_util.RPCSignatureVerifier
+
+	antispamGuard *antispam.Guard
+
+	launchpadpb.UnimplementedLaunchpadServer
+}
+
+func NewLaunchpadServer(
+	log *zap.Logger,
+	data ocp_data.Provider,
+	antispamGuard *antispam.Guard,
+	configProvider ConfigProvider,
+) launchpadpb.LaunchpadServer {
+	return &launchpadServer{
+		log:           log,
+		conf:          configProvider(),
+		data:          data,
+		auth:          auth_util.NewRPCSignatureVerifier(log, data),
+		antispamGuard: antispamGuard,
+	}
+}
+
+func (s *launchpadServer) LaunchCurrency(ctx context.Context, req *launchpadpb.LaunchCurrencyRequest) (*launchpadpb.LaunchCurrencyResponse, error) {
+	log := s.log.With(zap.String("method", "LaunchCurrency"))
+	log = client.InjectLoggingMetadata(ctx, log)
+
+	owner, err := common.NewAccountFromProto(req.Owner)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("invalid owner account")
+		return nil, status.Error(codes.Internal, "")
+	}
+	log = log.With(zap.String("owner_account", owner.PublicKey().ToBase58()))
+
+	signature := req.Signature
+	req.Signature = nil
+	if err := s.auth.Authenticate(ctx, owner, req, signature); err != nil {
+		return nil, err
+	}
+
+	log = log.With(
+		zap.String("name", req.Name),
+		zap.String("symbol", req.Symbol),
+	)
+
+	authorityPublicKey := s.conf.authorityPublicKey.Get(ctx)
+	if len(authorityPublicKey) == 0 {
+		return &launchpadpb.LaunchCurrencyResponse{
+			Result: launchpadpb.LaunchCurrencyResponse_DENIED,
+		}, nil
+	}
+
+	if !s.isAllowedCreator(ctx, owner) {
+		return &launchpadpb.LaunchCurrencyResponse{
+			Result: launchpadpb.LaunchCurrencyResponse_DENIED,
+		}, nil
+	}
+
+	allow, err := s.antispamGuard.AllowLaunchCurrency(ctx, owner)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure performing antispam check")
+		return nil, status.Error(codes.Internal, "")
+	} else if !allow {
+		return &launchpadpb.LaunchCurrencyResponse{
+			Result: launchpadpb.LaunchCurrencyResponse_DENIED,
+		}, nil
+	}
+
+	if result := s.validateLaunchRequest(ctx, req); result != launchpadpb.LaunchCurrencyResponse_OK {
+		log.With(zap.String("result", result.String())).Debug("invalid launch request")
+		return &launchpadpb.LaunchCurrencyResponse{
+			Result: result,
+		}, nil
+	}
+
+	isSymbolTaken, err := s.isSymbolTaken(ctx, req.Symbol)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure checking symbol availability")
+		return nil, status.Error(codes.Internal, "")
+	} else if isSymbolTaken {
+		return &launchpadpb.LaunchCurrencyResponse{
+			Result: launchpadpb.LaunchCurrencyResponse_SYMBOL_TAKEN,
+		}, nil
+	}
+
+	vaultRecord, err := s.data.GetKey(ctx, authorityPublicKey)
+	if err == vault.ErrKeyNotFound {
+		log.Warn("launch authority private key is not in the vault")
+		return nil, status.Error(codes.Internal, "")
+	} else if err != nil {
+		log.With(zap.Error(err)).Warn("failure getting launch authority key")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	authority, err := common.NewAccountFromPrivateKeyString(vaultRecord.PrivateKey)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("invalid launch authority key")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	seed, err := common.NewRandomAccount()
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure generating seed")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	currencyAccounts, err := common.DeriveLaunchpadCurrencyAccounts(authority, req.Name, seed)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure deriving currency accounts")
+		return nil, status.Error(codes.Internal, "")
+	}
+	mint := currencyAccounts.Mint.PublicKey().ToBase58()
+	log = log.With(zap.String("mint", mint))
+
+	intentRecord := &intent.Record{
+		IntentId:   GetLaunchIntentId(mint),
+		IntentType: intent.LaunchCurrency,
+
+		LaunchCurrencyMetadata: &intent.LaunchCurrencyMetadata{},
+
+		MintAccount: mint,
+
+		InitiatorOwnerAccount: owner.PublicKey().ToBase58(),
+
+		State: intent.StatePending,
+
+		CreatedAt: time.Now(),
+	}
+
+	launchRecord := &launch.Record{
+		Intent: intentRecord.IntentId,
+
+		Name:        req.Name,
+		Symbol:      req.Symbol,
+		Description: req.Description,
+		ImageUrl:    req.ImageUrl,
+
+		Seed:      seed.PublicKey().ToBase58(),
+		Authority: authority.PublicKey().ToBase58(),
+		Mint:      mint,
+
+		SellFeeBps: uint16(s.conf.sellFeeBps.Get(ctx)),
+
+		CreatedBy: owner.PublicKey().ToBase58(),
+
+		State: launch.StatePending,
+
+		CreatedAt: time.Now(),
+	}
+
+	// The currency is initialized before its ALT and VM are created, since
+	// they reference the currency's accounts
+	var actionRecords []*action.Record
+	var fulfillmentRecords []*fulfillment.Record
+	for i, actionType := range []action.Type{
+		action.InitializeLaunchpadCurrency,
+		action.CreateLaunchpadCurrencyAlt,
+		action.InitializeLaunchpadCurrencyVm,
+	} {
+		actionRecord := &action.Record{
+			Intent:     intentRecord.IntentId,
+			IntentType: intentRecord.IntentType,
+
+			ActionId:   uint32(i),
+			ActionType: actionType,
+
+			Source: mint,
+
+			State: action.StatePending,
+
+			CreatedAt: time.Now(),
+		}
+		actionRecords = append(actionRecords, actionRecord)
+
+		var fulfillmentType fulfillment.Type
+		switch actionType {
+		case action.InitializeLaunchpadCurrency:
+			fulfillmentType = fulfillment.InitializeLaunchpadCurrency
+		case action.CreateLaunchpadCurrencyAlt:
+			fulfillmentType = fulfillment.CreateLaunchpadCurrencyAlt
+		case action.InitializeLaunchpadCurrencyVm:
+			fulfillmentType = fulfillment.InitializeLaunchpadCurrencyVm
+		}
+
+		fulfillmentRecords = append(fulfillmentRecords, &fulfillment.Record{
+			Intent:     intentRecord.IntentId,
+			IntentType: intentRecord.IntentType,
+
+			ActionId:   actionRecord.ActionId,
+			ActionType: actionRecord.ActionType,
+
+			FulfillmentType: fulfillmentType,
+
+			Source: mint,
+
+			DisableActiveScheduling: false,
+
+			// IntentOrderingIndex unknown until intent record is saved
+			ActionOrderingIndex:      actionRecord.ActionId,
+			FulfillmentOrderingIndex: 0,
+
+			State: fulfillment.StateUnknown,
+
+			CreatedAt: time.Now(),
+		})
+	}
+
+	err = s.data.ExecuteInTx(ctx, sql.LevelDefault, func(ctx context.Context) error {
+		err := s.data.PutCurrencyLaunch(ctx, launchRecord)
+		if err != nil {
+			return err
+		}
+
+		err = s.data.SaveIntent(ctx, intentRecord)
+		if err != nil {
+			return err
+		}
+
+		err = s.data.PutAllActions(ctx, actionRecords...)
+		if err != nil {
+			return err
+		}
+
+		for _, fulfillmentRecord := range fulfillmentRecords {
+			fulfillmentRecord.IntentOrderingIndex = intentRecord.Id
+		}
+		return s.data.PutAllFulfillments(ctx, fulfillmentRecords...)
+	})
+	if err == launch.ErrAlreadyExists {
+		// The seed is random, so this can only be a concurrent launch with
+		// the same symbol
+		return &launchpadpb.LaunchCurrencyResponse{
+			Result: launchpadpb.LaunchCurrencyResponse_SYMBOL_TAKEN,
+		}, nil
+	} else if err != nil {
+		log.With(zap.Error(err)).Warn("failure creating currency launch")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	log.Info("currency launch started")
+
+	protoLaunch, err := toLaunchProto(launchRecord)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure converting launch to proto")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return &launchpadpb.LaunchCurrencyResponse{
+		Result: launchpadpb.LaunchCurrencyResponse_OK,
+		Launch: protoLaunch,
+	}, nil
+}
+
+func (s *launchpadServer) GetLaunch(ctx context.Context, req *launchpadpb.GetLaunchRequest) (*launchpadpb.GetLaunchResponse, error) {
+	log := s.log.With(zap.String("method", "GetLaunch"))
+	log = client.InjectLoggingMetadata(ctx, log)
+
+	mint, err := common.NewAccountFromProto(req.Mint)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("invalid mint account")
+		return nil, status.Error(codes.Internal, "")
+	}
+	log = log.With(zap.String("mint", mint.PublicKey().ToBase58()))
+
+	launchRecord, err := s.data.GetCurrencyLaunchByMint(ctx, mint.PublicKey().ToBase58())
+	if err == launch.ErrNotFound {
+		return &launchpadpb.GetLaunchResponse{
+			Result: launchpadpb.GetLaunchResponse_NOT_FOUND,
+		}, nil
+	} else if err != nil {
+		log.With(zap.Error(err)).Warn("failure getting launch record")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	protoLaunch, err := toLaunchProto(launchRecord)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure converting launch to proto")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return &launchpadpb.GetLaunchResponse{
+		Result: launchpadpb.GetLaunchResponse_OK,
+		Launch: protoLaunch,
+	}, nil
+}
+
+func (s *launchpadServer) validateLaunchRequest(ctx context.Context, req *launchpadpb.LaunchCurrencyRequest) launchpadpb.LaunchCurrencyResponse_Result {
+	// Names are part of the mint PDA seeds, so they're limited by the size of
+	// the fixed string stored in the currency config
+	if len(req.Name) == 0 || len(req.Name) > currencycreator.MaxCurrencyConfigAccountNameLength {
+		return launchpadpb.LaunchCurrencyResponse_INVALID_NAME
+	}
+	if !utf8.ValidString(req.Name) || strings.TrimSpace(req.Name) != req.Name {
+		return launchpadpb.LaunchCurrencyResponse_INVALID_NAME
+	}
+
+	if len(req.Symbol) > currencycreator.MaxCurrencyConfigAccountSymbolLength || !symbolRegex.MatchString(req.Symbol) {
+		return launchpadpb.LaunchCurrencyResponse_INVALID_SYMBOL
+	}
+
+	if len(req.Description) == 0 || uint64(len(req.Description)) > s.conf.maxDescriptionLength.Get(ctx) || !utf8.ValidString(req.Description) {
+		return launchpadpb.LaunchCurrencyResponse_INVALID_DESCRIPTION
+	}
+
+	imageUrl, err := url.Parse(req.ImageUrl)
+	if err != nil || imageUrl.Scheme != "https" || len(imageUrl.Hostname()) == 0 {
+		return launchpadpb.LaunchCurrencyResponse_INVALID_IMAGE_URL
+	}
+
+	allowedImageHosts := s.conf.allowedImageHosts.Get(ctx)
+	if len(allowedImageHosts) > 0 {
+		var isAllowedHost bool
+		for _, allowedHost := range strings.Split(allowedImageHosts, ",") {
+			if strings.EqualFold(strings.TrimSpace(allowedHost), imageUrl.Hostname()) {
+				isAllowedHost = true
+				break
+			}
+		}
+
+		if !isAllowedHost {
+			return launchpadpb.LaunchCurrencyResponse_INVALID_IMAGE_URL
+		}
+	}
+
+	return launchpadpb.LaunchCurrencyResponse_OK
+}
+
+func (s *launchpadServer) isAllowedCreator(ctx context.Context, owner *common.Account) bool {
+	allowedCreators := s.conf.allowedCreators.Get(ctx)
+	if len(allowedCreators) == 0 {
+		return true
+	}
+
+	for _, allowedCreator := range strings.Split(allowedCreators, ",") {
+		if strings.TrimSpace(allowedCreator) == owner.PublicKey().ToBase58() {
+			return true
+		}
+	}
+	return false
+}
+
+// isSymbolTaken checks whether the symbol is used by an existing currency.
+// Symbols of in-progress launches are enforced by the launch store.
+func (s *launchpadServer) isSymbolTaken(ctx context.Context, symbol string) (bool, error) {
+	if strings.EqualFold(string(common.CoreMintSymbol), symbol) {
+		return true, nil
+	}
+
+	metadataRecords, err := s.data.GetAllCurrencyMetadata(ctx)
+	if err == currency.ErrNotFound {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	for _, metadataRecord := range metadataRecords {
+		if strings.EqualFold(metadataRecord.Symbol, symbol) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetLaunchIntentId gets the deterministic intent ID for launching the
+// currency with the provided mint
+func GetLaunchIntentId(mint string) string {
+	hashed := sha256.Sum256([]byte("launch-" + mint))
+	return base58.Encode(hashed[:])
+}
+
+func toLaunchProto(record *launch.Record) (*launchpadpb.Launch, error) {
+	decodedIntentId, err := base58.Decode(record.Intent)
+	if err != nil {
+		return nil, err
+	}
+
+	mint, err := common.NewAccountFromPublicKeyString(record.Mint)
+	if err != nil {
+		return nil, err
+	}
+
+	createdBy, err := common.NewAccountFromPublicKeyString(record.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	var alt *commonpb.SolanaAccountId
+	if len(record.Alt) > 0 {
+		altAccount, err := common.NewAccountFromPublicKeyString(record.Alt)
+		if err != nil {
+			return nil, err
+		}
+		alt = altAccount.ToProto()
+	}
+
+	var state launchpadpb.Launch_State
+	switch record.State {
+	case launch.StatePending:
+		state = launchpadpb.Launch_PENDING
+	case launch.StateFinalized:
+		state = launchpadpb.Launch_FINALIZED
+	case launch.StateFailed:
+		state = launchpadpb.Launch_FAILED
+	default:
+		state = launchpadpb.Launch_UNKNOWN
+	}
+
+	return &launchpadpb.Launch{
+		IntentId: &commonpb.IntentId{Value: decodedIntentId},
+
+		Mint: mint.ToProto(),
+
+		Name:        record.Name,
+		Symbol:      record.Symbol,
+		Description: record.Description,
+		ImageUrl:    record.ImageUrl,
+
+		State: state,
+
+		Alt: alt,
+
+		CreatedBy: createdBy.ToProto(),
+
+		CreatedAt: timestamppb.New(record.CreatedAt),
+	}, nil
+}
@@ -0,0 +1,303 @@
+package currency
+
+import (
+	"context"
+	"crypto/ed25519"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	commonpb "github.com/code-payments/ocp-protobuf-api/generated/go/common/v1"
+
+	"github.com/code-payments/ocp-server/ocp/antispam"
+	"github.com/code-payments/ocp-server/ocp/common"
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/action"
+	"github.com/code-payments/ocp-server/ocp/data/fulfillment"
+	"github.com/code-payments/ocp-server/ocp/data/intent"
+	"github.com/code-payments/ocp-server/ocp/data/launch"
+	"github.com/code-payments/ocp-server/ocp/data/vault"
+	launchpadpb "github.com/code-payments/ocp-server/ocp/rpc/currency/api/gen"
+	"github.com/code-payments/ocp-server/testutil"
+)
+
+type launchpadTestEnv struct {
+	ctx       context.Context
+	client    launchpadpb.LaunchpadClient
+	data      ocp_data.Provider
+	authority *common.Account
+}
+
+func setupLaunchpad(t *testing.T, configure func(overrides *testOverrides)) (env launchpadTestEnv, cleanup func()) {
+	return setupLaunchpadWithAntispam(t, antispam.NewAllowEverything(), configure)
+}
+
+func setupLaunchpadWithAntispam(t *testing.T, integration antispam.Integration, configure func(overrides *testOverrides)) (env launchpadTestEnv, cleanup func()) {
+	log := zaptest.NewLogger(t)
+
+	conn, serv, err := testutil.NewServer(log)
+	require.NoError(t, err)
+
+	env.ctx = context.Background()
+	env.client = launchpadpb.NewLaunchpadClient(conn)
+	env.data = ocp_data.NewTestDataProvider()
+	env.authority = testutil.NewRandomAccount(t)
+
+	require.NoError(t, env.data.SaveKey(env.ctx, &vault.Record{
+		PublicKey:  env.authority.PublicKey().ToBase58(),
+		PrivateKey: env.authority.PrivateKey().ToBase58(),
+		State:      vault.StateAvailable,
+		CreatedAt:  time.Now(),
+	}))
+
+	overrides := &testOverrides{
+		authorityPublicKey: env.authority.PublicKey().ToBase58(),
+	}
+	if configure != nil {
+		configure(overrides)
+	}
+
+	s := NewLaunchpadServer(log, env.data, antispam.NewGuard(integration), withManualTestOverrides(overrides))
+
+	serv.RegisterService(func(server *grpc.Server) {
+		launchpadpb.RegisterLaunchpadServer(server, s)
+	})
+
+	cleanup, err = serv.Serve()
+	require.NoError(t, err)
+	return env, cleanup
+}
+
+func TestLaunchCurrency_HappyPath(t *testing.T) {
+	env, cleanup := setupLaunchpad(t, nil)
+	defer cleanup()
+
+	owner := testutil.NewRandomAccount(t)
+
+	resp := env.launchCurrency(t, owner, newTestLaunchCurrencyRequest("JFY"))
+	require.Equal(t, launchpadpb.LaunchCurrencyResponse_OK, resp.Result)
+	require.NotNil(t, resp.Launch)
+
+	mint, err := common.NewAccountFromProto(resp.Launch.Mint)
+	require.NoError(t, err)
+	assert.Equal(t, launchpadpb.Launch_PENDING, resp.Launch.State)
+	assert.Equal(t, "JFY", resp.Launch.Symbol)
+	assert.Nil(t, resp.Launch.Alt)
+	assert.Equal(t, owner.PublicKey().ToBytes(), resp.Launch.CreatedBy.Value)
+
+	launchRecord, err := env.data.GetCurrencyLaunchByMint(env.ctx, mint.PublicKey().ToBase58())
+	require.NoError(t, err)
+	assert.Equal(t, GetLaunchIntentId(mint.PublicKey().ToBase58()), launchRecord.Intent)
+	assert.Equal(t, env.authority.PublicKey().ToBase58(), launchRecord.Authority)
+	assert.Equal(t, owner.PublicKey().ToBase58(), launchRecord.CreatedBy)
+	assert.EqualValues(t, defaultSellFeeBps, launchRecord.SellFeeBps)
+	assert.Equal(t, launch.StatePending, launchRecord.State)
+
+	seed, err := common.NewAccountFromPublicKeyString(launchRecord.Seed)
+	require.NoError(t, err)
+	currencyAccounts, err := common.DeriveLaunchpadCurrencyAccounts(env.authority, launchRecord.Name, seed)
+	require.NoError(t, err)
+	assert.Equal(t, currencyAccounts.Mint.PublicKey().ToBase58(), launchRecord.Mint)
+
+	intentRecord, err := env.data.GetIntent(env.ctx, launchRecord.Intent)
+	require.NoError(t, err)
+	assert.Equal(t, intent.LaunchCurrency, intentRecord.IntentType)
+	assert.Equal(t, intent.StatePending, intentRecord.State)
+	assert.Equal(t, launchRecord.Mint, intentRecord.MintAccount)
+	assert.Equal(t, owner.PublicKey().ToBase58(), intentRecord.InitiatorOwnerAccount)
+	assert.NotNil(t, intentRecord.LaunchCurrencyMetadata)
+
+	actionRecords, err := env.data.GetAllActionsByIntent(env.ctx, launchRecord.Intent)
+	require.NoError(t, err)
+	require.Len(t, actionRecords, 3)
+	assert.Equal(t, action.InitializeLaunchpadCurrency, actionRecords[0].ActionType)
+	assert.Equal(t, action.CreateLaunchpadCurrencyAlt, actionRecords[1].ActionType)
+	assert.Equal(t, action.InitializeLaunchpadCurrencyVm, actionRecords[2].ActionType)
+
+	fulfillmentRecords, err := env.data.GetAllFulfillmentsByIntent(env.ctx, launchRecord.Intent)
+	require.NoError(t, err)
+	require.Len(t, fulfillmentRecords, 3)
+	assert.Equal(t, fulfillment.InitializeLaunchpadCurrency, fulfillmentRecords[0].FulfillmentType)
+	assert.Equal(t, fulfillment.CreateLaunchpadCurrencyAlt, fulfillmentRecords[1].FulfillmentType)
+	assert.Equal(t, fulfillment.InitializeLaunchpadCurrencyVm, fulfillmentRecords[2].FulfillmentType)
+	for i, fulfillmentRecord := range fulfillmentRecords {
+		assert.EqualValues(t, i, fulfillmentRecord.ActionId)
+		assert.Equal(t, intentRecord.Id, fulfillmentRecord.IntentOrderingIndex)
+		assert.Equal(t, launchRecord.Mint, fulfillmentRecord.Source)
+		assert.Equal(t, fulfillment.StateUnknown, fulfillmentRecord.State)
+		assert.Nil(t, fulfillmentRecord.Signature)
+	}
+
+	getResp, err := env.client.GetLaunch(env.ctx, &launchpadpb.GetLaunchRequest{
+		Mint: mint.ToProto(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, launchpadpb.GetLaunchResponse_OK, getResp.Result)
+	assert.True(t, proto.Equal(resp.Launch, getResp.Launch))
+
+	// The symbol is reserved by the in-progress launch
+	resp = env.launchCurrency(t, testutil.NewRandomAccount(t), newTestLaunchCurrencyRequest("JFY"))
+	assert.Equal(t, launchpadpb.LaunchCurrencyResponse_SYMBOL_TAKEN, resp.Result)
+
+	// The symbol is released when the launch fails
+	launchRecord.State = launch.StateFailed
+	require.NoError(t, env.data.UpdateCurrencyLaunch(env.ctx, launchRecord))
+
+	resp = env.launchCurrency(t, testutil.NewRandomAccount(t), newTestLaunchCurrencyRequest("JFY"))
+	assert.Equal(t, launchpadpb.LaunchCurrencyResponse_OK, resp.Result)
+}
+
+func TestLaunchCurrency_Validation(t *testing.T) {
+	env, cleanup := setupLaunchpad(t, nil)
+	defer cleanup()
+
+	owner := testutil.NewRandomAccount(t)
+
+	for _, tc := range []struct {
+		mutate   func(req *launchpadpb.LaunchCurrencyRequest)
+		expected launchpadpb.LaunchCurrencyResponse_Result
+	}{
+		{func(req *launchpadpb.LaunchCurrencyRequest) { req.Name = "" }, launchpadpb.LaunchCurrencyResponse_INVALID_NAME},
+		{func(req *launchpadpb.LaunchCurrencyRequest) { req.Name = strings.Repeat("a", 33) }, launchpadpb.LaunchCurrencyResponse_INVALID_NAME},
+		{func(req *launchpadpb.LaunchCurrencyRequest) { req.Name = " Jeffy" }, launchpadpb.LaunchCurrencyResponse_INVALID_NAME},
+		{func(req *launchpadpb.LaunchCurrencyRequest) { req.Symbol = "" }, launchpadpb.LaunchCurrencyResponse_INVALID_SYMBOL},
+		{func(req *launchpadpb.LaunchCurrencyRequest) { req.Symbol = "jfy" }, launchpadpb.LaunchCurrencyResponse_INVALID_SYMBOL},
+		{func(req *launchpadpb.LaunchCurrencyRequest) { req.Symbol = "JFY$" }, launchpadpb.LaunchCurrencyResponse_INVALID_SYMBOL},
+		{func(req *launchpadpb.LaunchCurrencyRequest) { req.Symbol = "ABCDEFGHI" }, launchpadpb.LaunchCurrencyResponse_INVALID_SYMBOL},
+		{func(req *launchpadpb.LaunchCurrencyRequest) { req.Description = "" }, launchpadpb.LaunchCurrencyResponse_INVALID_DESCRIPTION},
+		{func(req *launchpadpb.LaunchCurrencyRequest) {
+			req.Description = strings.Repeat("a", defaultMaxDescriptionLength+1)
+		}, launchpadpb.LaunchCurrencyResponse_INVALID_DESCRIPTION},
+		{func(req *launchpadpb.LaunchCurrencyRequest) { req.ImageUrl = "" }, launchpadpb.LaunchCurrencyResponse_INVALID_IMAGE_URL},
+		{func(req *launchpadpb.LaunchCurrencyRequest) { req.ImageUrl = "http://example.com/jeffy.png" }, launchpadpb.LaunchCurrencyResponse_INVALID_IMAGE_URL},
+		{func(req *launchpadpb.LaunchCurrencyRequest) { req.ImageUrl = "https:///jeffy.png" }, launchpadpb.LaunchCurrencyResponse_INVALID_IMAGE_URL},
+		{func(req *launchpadpb.LaunchCurrencyRequest) {
+			req.Symbol = strings.ToUpper(string(common.CoreMintSymbol))
+		}, launchpadpb.LaunchCurrencyResponse_SYMBOL_TAKEN},
+	} {
+		req := newTestLaunchCurrencyRequest("JFY")
+		tc.mutate(req)
+
+		resp := env.launchCurrency(t, owner, req)
+		assert.Equal(t, tc.expected, resp.Result)
+		assert.Nil(t, resp.Launch)
+	}
+}
+
+func TestLaunchCurrency_AllowedImageHosts(t *testing.T) {
+	env, cleanup := setupLaunchpad(t, func(overrides *testOverrides) {
+		overrides.allowedImageHosts = "images.example.com, cdn.example.com"
+	})
+	defer cleanup()
+
+	owner := testutil.NewRandomAccount(t)
+
+	req := newTestLaunchCurrencyRequest("JFY")
+	req.ImageUrl = "https://example.com/jeffy.png"
+	resp := env.launchCurrency(t, owner, req)
+	assert.Equal(t, launchpadpb.LaunchCurrencyResponse_INVALID_IMAGE_URL, resp.Result)
+
+	req = newTestLaunchCurrencyRequest("JFY")
+	req.ImageUrl = "https://cdn.example.com/jeffy.png"
+	resp = env.launchCurrency(t, owner, req)
+	assert.Equal(t, launchpadpb.LaunchCurrencyResponse_OK, resp.Result)
+}
+
+func TestLaunchCurrency_Disabled(t *testing.T) {
+	env, cleanup := setupLaunchpad(t, func(overrides *testOverrides) {
+		overrides.authorityPublicKey = ""
+	})
+	defer cleanup()
+
+	resp := env.launchCurrency(t, testutil.NewRandomAccount(t), newTestLaunchCurrencyRequest("JFY"))
+	assert.Equal(t, launchpadpb.LaunchCurrencyResponse_DENIED, resp.Result)
+}
+
+func TestLaunchCurrency_CreatorNotAllowed(t *testing.T) {
+	allowedOwner := testutil.NewRandomAccount(t)
+
+	env, cleanup := setupLaunchpad(t, func(overrides *testOverrides) {
+		overrides.allowedCreators = allowedOwner.PublicKey().ToBase58()
+	})
+	defer cleanup()
+
+	resp := env.launchCurrency(t, testutil.NewRandomAccount(t), newTestLaunchCurrencyRequest("JFY"))
+	assert.Equal(t, launchpadpb.LaunchCurrencyResponse_DENIED, resp.Result)
+
+	resp = env.launchCurrency(t, allowedOwner, newTestLaunchCurrencyRequest("JFY"))
+	assert.Equal(t, launchpadpb.LaunchCurrencyResponse_OK, resp.Result)
+}
+
+func TestLaunchCurrency_AntispamDenied(t *testing.T) {
+	env, cleanup := setupLaunchpadWithAntispam(t, &denyLaunchCurrencyIntegration{antispam.NewAllowEverything()}, nil)
+	defer cleanup()
+
+	resp := env.launchCurrency(t, testutil.NewRandomAccount(t), newTestLaunchCurrencyRequest("JFY"))
+	assert.Equal(t, launchpadpb.LaunchCurrencyResponse_DENIED, resp.Result)
+}
+
+func TestLaunchCurrency_Unauthenticated(t *testing.T) {
+	env, cleanup := setupLaunchpad(t, nil)
+	defer cleanup()
+
+	req := newTestLaunchCurrencyRequest("JFY")
+	req.Owner = testutil.NewRandomAccount(t).ToProto()
+	reqBytes, err := proto.Marshal(req)
+	require.NoError(t, err)
+	req.Signature = &commonpb.Signature{
+		Value: ed25519.Sign(testutil.NewRandomAccount(t).PrivateKey().ToBytes(), reqBytes),
+	}
+
+	_, err = env.client.LaunchCurrency(env.ctx, req)
+	testutil.AssertStatusErrorWithCode(t, err, codes.Unauthenticated)
+}
+
+func TestGetLaunch_NotFound(t *testing.T) {
+	env, cleanup := setupLaunchpad(t, nil)
+	defer cleanup()
+
+	resp, err := env.client.GetLaunch(env.ctx, &launchpadpb.GetLaunchRequest{
+		Mint: testutil.NewRandomAccount(t).ToProto(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, launchpadpb.GetLaunchResponse_NOT_FOUND, resp.Result)
+	assert.Nil(t, resp.Launch)
+}
+
+func (e *launchpadTestEnv) launchCurrency(t *testing.T, owner *common.Account, req *launchpadpb.LaunchCurrencyRequest) *launchpadpb.LaunchCurrencyResponse {
+	req.Owner = owner.ToProto()
+	req.Signature = nil
+	reqBytes, err := proto.Marshal(req)
+	require.NoError(t, err)
+	req.Signature = &commonpb.Signature{
+		Value: ed25519.Sign(owner.PrivateKey().ToBytes(), reqBytes),
+	}
+
+	resp, err := e.client.LaunchCurrency(e.ctx, req)
+	require.NoError(t, err)
+	return resp
+}
+
+func newTestLaunchCurrencyRequest(symbol string) *launchpadpb.LaunchCurrencyRequest {
+	return &launchpadpb.LaunchCurrencyRequest{
+		Name:        "Jeffy",
+		Symbol:      symbol,
+		Description: "The official currency of Jeffy",
+		ImageUrl:    "https://example.com/jeffy.png",
+	}
+}
+
+type denyLaunchCurrencyIntegration struct {
+	antispam.Integration
+}
+
+func (i *denyLaunchCurrencyIntegration) AllowLaunchCurrency(ctx context.Context, owner *common.Account) (bool, string, error) {
+	return false, "denied for testing", nil
+}
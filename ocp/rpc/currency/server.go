@@ -19,7 +19,6 @@ import (
 	currency_util "github.com/code-payments/ocp-server/ocp/currency"
 	ocp_data "github.com/code-payments/ocp-server/ocp/data"
 	"github.com/code-payments/ocp-server/ocp/data/currency"
-	timelock_token "github.com/code-payments/ocp-server/solana/timelock/v1"
 )
 
 type currencyServer struct {
@@ -84,7 +83,7 @@ func (s *currencyServer) GetMints(ctx context.Context, req *currencypb.GetMintsR
 		var protoMetadata *currencypb.Mint
 		switch mintAccount.PublicKey().ToBase58() {
 		case common.CoreMintAccount.PublicKey().ToBase58():
-			vmConfig, err := common.GetVmConfigForMint(ctx, s.data, common.CoreMintAccount)
+			vmConfig, err := common.GetPublicVmConfigForMint(ctx, s.data, common.CoreMintAccount)
 			if err != nil {
 				log.With(zap.Error(err)).Warn("failure getting vm config")
 				return nil, status.Error(codes.Internal, "")
@@ -101,7 +100,7 @@ func (s *currencyServer) GetMints(ctx context.Context, req *currencypb.GetMintsR
 					Vm:                 vmConfig.Vm.ToProto(),
 					Omnibus:            vmConfig.Omnibus.ToProto(),
 					Authority:          vmConfig.Authority.ToProto(),
-					LockDurationInDays: uint32(timelock_token.DefaultNumDaysLocked),
+					LockDurationInDays: uint32(vmConfig.LockDurationInDays),
 				},
 				CreatedAt: timestamppb.New(time.Time{}),
 			}
@@ -120,7 +119,7 @@ func (s *currencyServer) GetMints(ctx context.Context, req *currencypb.GetMintsR
 				return nil, status.Error(codes.Internal, "")
 			}
 
-			vmConfig, err := common.GetVmConfigForMint(ctx, s.data, mintAccount)
+			vmConfig, err := common.GetPublicVmConfigForMint(ctx, s.data, mintAccount)
 			if err != nil {
 				log.With(zap.Error(err)).Warn("failure getting vm config")
 				return nil, status.Error(codes.Internal, "")
@@ -168,7 +167,7 @@ func (s *currencyServer) GetMints(ctx context.Context, req *currencypb.GetMintsR
 					Vm:                 vmConfig.Vm.ToProto(),
 					Omnibus:            vmConfig.Omnibus.ToProto(),
 					Authority:          vmConfig.Authority.ToProto(),
-					LockDurationInDays: uint32(timelock_token.DefaultNumDaysLocked),
+					LockDurationInDays: uint32(vmConfig.LockDurationInDays),
 				},
 				LaunchpadMetadata: &currencypb.LaunchpadMetadata{
 					CurrencyConfig:    currencyConfigAccount.ToProto(),
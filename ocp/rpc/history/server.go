@@ -0,0 +1,320 @@
+package history
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mr-tron/base58"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	commonpb "github.com/code-payments/ocp-protobuf-api/generated/go/common/v1"
+	transactionpb "github.com/code-payments/ocp-protobuf-api/generated/go/transaction/v1"
+
+	"github.com/code-payments/ocp-server/currency"
+	"github.com/code-payments/ocp-server/database/query"
+	"github.com/code-payments/ocp-server/grpc/client"
+	auth_util "github.com/code-payments/ocp-server/ocp/auth"
+	"github.com/code-payments/ocp-server/ocp/common"
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/action"
+	"github.com/code-payments/ocp-server/ocp/data/intent"
+	"github.com/code-payments/ocp-server/ocp/data/swap"
+	historypb "github.com/code-payments/ocp-server/ocp/rpc/history/api/gen"
+)
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+type server struct {
+	log  *zap.Logger
+	data ocp_data.Provider
+	auth *auth_util.RPCSignatureVerifier
+
+	historypb.UnimplementedHistoryServer
+}
+
+func NewHistoryServer(log *zap.Logger, data ocp_data.Provider) historypb.HistoryServer {
+	return &server{
+		log:  log,
+		data: data,
+		auth: auth_util.NewRPCSignatureVerifier(log, data),
+	}
+}
+
+func (s *server) GetPaymentHistory(ctx context.Context, req *historypb.GetPaymentHistoryRequest) (*historypb.GetPaymentHistoryResponse, error) {
+	log := s.log.With(zap.String("method", "GetPaymentHistory"))
+	log = client.InjectLoggingMetadata(ctx, log)
+
+	owner, err := common.NewAccountFromProto(req.Owner)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("invalid owner account")
+		return nil, status.Error(codes.Internal, "")
+	}
+	log = log.With(zap.String("owner_account", owner.PublicKey().ToBase58()))
+
+	signature := req.Signature
+	req.Signature = nil
+	if err := s.auth.Authenticate(ctx, owner, req, signature); err != nil {
+		return nil, err
+	}
+
+	pageSize := uint64(req.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		return nil, status.Error(codes.InvalidArgument, "page size exceeds max")
+	}
+
+	direction := query.Descending
+	if req.Direction == historypb.GetPaymentHistoryRequest_ASC {
+		direction = query.Ascending
+	}
+
+	opts := []query.Option{
+		query.WithLimit(pageSize),
+		query.WithDirection(direction),
+	}
+	if len(req.Cursor) > 0 {
+		if len(req.Cursor) != 8 {
+			return nil, status.Error(codes.InvalidArgument, "invalid cursor")
+		}
+		opts = append(opts, query.WithCursor(req.Cursor))
+	}
+
+	intentRecords, err := s.data.GetAllIntentsByOwner(ctx, owner.PublicKey().ToBase58(), opts...)
+	if err != nil && err != intent.ErrIntentNotFound {
+		log.With(zap.Error(err)).Warn("failure getting intent records")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	var items []*historypb.PaymentHistoryItem
+	for _, intentRecord := range intentRecords {
+		item, err := s.toPaymentHistoryItem(ctx, owner, intentRecord)
+		if err != nil {
+			log.With(zap.Error(err), zap.String("intent", intentRecord.IntentId)).Warn("failure getting payment history item")
+			return nil, status.Error(codes.Internal, "")
+		}
+
+		if item != nil {
+			items = append(items, item)
+		}
+	}
+
+	// Intents that aren't payments are skipped, so the page is based on the
+	// number of intents scanned rather than items returned
+	var nextCursor []byte
+	if uint64(len(intentRecords)) == pageSize {
+		nextCursor = query.ToCursor(intentRecords[len(intentRecords)-1].Id)
+	}
+
+	return &historypb.GetPaymentHistoryResponse{
+		Result:     historypb.GetPaymentHistoryResponse_OK,
+		Items:      items,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// toPaymentHistoryItem converts an intent into a payment history item from the
+// perspective of the owner. A nil item is returned for intents that aren't
+// payments.
+func (s *server) toPaymentHistoryItem(ctx context.Context, owner *common.Account, intentRecord *intent.Record) (*historypb.PaymentHistoryItem, error) {
+	ownerAddress := owner.PublicKey().ToBase58()
+	isInitiator := intentRecord.InitiatorOwnerAccount == ownerAddress
+
+	decodedIntentId, err := base58.Decode(intentRecord.IntentId)
+	if err != nil {
+		return nil, err
+	}
+
+	mintAccount, err := common.NewAccountFromPublicKeyString(intentRecord.MintAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	item := &historypb.PaymentHistoryItem{
+		IntentId:  &commonpb.IntentId{Value: decodedIntentId},
+		State:     toStateProto(intentRecord.State),
+		Mint:      mintAccount.ToProto(),
+		CreatedAt: timestamppb.New(intentRecord.CreatedAt),
+	}
+
+	var counterparty string
+	switch intentRecord.IntentType {
+	case intent.ExternalDeposit:
+		metadata := intentRecord.ExternalDepositMetadata
+
+		item.Type = historypb.PaymentHistoryItem_DEPOSIT
+		item.Quarks = metadata.Quantity
+		item.UsdMarketValue = metadata.UsdMarketValue
+	case intent.SendPublicPayment:
+		metadata := intentRecord.SendPublicPaymentMetadata
+
+		item.Quarks = metadata.Quantity
+		item.UsdMarketValue = metadata.UsdMarketValue
+		item.ExchangeData = toExchangeDataProto(mintAccount, metadata.ExchangeCurrency, metadata.ExchangeRate, metadata.NativeAmount, metadata.Quantity)
+
+		if isInitiator {
+			swapRecord, err := s.data.GetSwapByFundingId(ctx, intentRecord.IntentId)
+			if err != nil && err != swap.ErrNotFound {
+				return nil, err
+			}
+
+			switch {
+			case swapRecord != nil:
+				item.Swap, err = toSwapMetadataProto(swapRecord)
+				if err != nil {
+					return nil, err
+				}
+
+				item.Type = historypb.PaymentHistoryItem_SELL
+				if swapRecord.FromMint == common.CoreMintAccount.PublicKey().ToBase58() {
+					item.Type = historypb.PaymentHistoryItem_BUY
+				}
+			case metadata.IsWithdrawal:
+				item.Type = historypb.PaymentHistoryItem_WITHDRAWAL
+				counterparty = metadata.DestinationOwnerAccount
+			case metadata.IsRemoteSend:
+				item.Type = historypb.PaymentHistoryItem_GIFT_CARD_ISSUED
+			default:
+				item.Type = historypb.PaymentHistoryItem_SENT
+				counterparty = metadata.DestinationOwnerAccount
+			}
+
+			item.FeeQuarks, err = s.getFeeQuarks(ctx, intentRecord.IntentId)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			item.Type = historypb.PaymentHistoryItem_RECEIVED
+			counterparty = intentRecord.InitiatorOwnerAccount
+		}
+	case intent.ReceivePaymentsPublicly:
+		metadata := intentRecord.ReceivePaymentsPubliclyMetadata
+
+		item.Quarks = metadata.Quantity
+		item.UsdMarketValue = metadata.UsdMarketValue
+		item.ExchangeData = toExchangeDataProto(mintAccount, metadata.OriginalExchangeCurrency, metadata.OriginalExchangeRate, metadata.OriginalNativeAmount, metadata.Quantity)
+
+		switch {
+		case metadata.IsReturned, metadata.IsIssuerVoidingGiftCard:
+			item.Type = historypb.PaymentHistoryItem_GIFT_CARD_RETURNED
+		case metadata.IsRemoteSend:
+			item.Type = historypb.PaymentHistoryItem_GIFT_CARD_CLAIMED
+
+			giftCardIssuedIntentRecord, err := s.data.GetOriginalGiftCardIssuedIntent(ctx, metadata.Source)
+			if err == nil {
+				counterparty = giftCardIssuedIntentRecord.InitiatorOwnerAccount
+			} else if err != intent.ErrIntentNotFound {
+				return nil, err
+			}
+		default:
+			item.Type = historypb.PaymentHistoryItem_RECEIVED
+		}
+	case intent.PublicDistribution:
+		metadata := intentRecord.PublicDistributionMetadata
+
+		if isInitiator {
+			item.Type = historypb.PaymentHistoryItem_SENT
+			item.Quarks = metadata.Quantity
+			item.UsdMarketValue = metadata.UsdMarketValue
+		} else {
+			item.Type = historypb.PaymentHistoryItem_RECEIVED
+			counterparty = intentRecord.InitiatorOwnerAccount
+
+			for _, distribution := range metadata.Distributions {
+				if distribution.DestinationOwnerAccount == ownerAddress {
+					item.Quarks += distribution.Quantity
+				}
+			}
+			if metadata.Quantity > 0 {
+				item.UsdMarketValue = metadata.UsdMarketValue * float64(item.Quarks) / float64(metadata.Quantity)
+			}
+		}
+	default:
+		return nil, nil
+	}
+
+	if len(counterparty) > 0 && counterparty != ownerAddress {
+		counterpartyAccount, err := common.NewAccountFromPublicKeyString(counterparty)
+		if err != nil {
+			return nil, err
+		}
+		item.Counterparty = counterpartyAccount.ToProto()
+	}
+
+	return item, nil
+}
+
+func (s *server) getFeeQuarks(ctx context.Context, intentId string) (uint64, error) {
+	actionRecords, err := s.data.GetAllActionsByIntent(ctx, intentId)
+	if err == action.ErrActionNotFound {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	var res uint64
+	for _, actionRecord := range actionRecords {
+		if actionRecord.FeeType != nil && actionRecord.Quantity != nil {
+			res += *actionRecord.Quantity
+		}
+	}
+	return res, nil
+}
+
+func toStateProto(state intent.State) historypb.PaymentHistoryItem_State {
+	switch state {
+	case intent.StateUnknown, intent.StatePending:
+		return historypb.PaymentHistoryItem_PENDING
+	case intent.StateConfirmed:
+		return historypb.PaymentHistoryItem_CONFIRMED
+	case intent.StateFailed, intent.StateRevoked:
+		return historypb.PaymentHistoryItem_FAILED
+	}
+	return historypb.PaymentHistoryItem_UNKNOWN_STATE
+}
+
+func toExchangeDataProto(mint *common.Account, exchangeCurrency currency.Code, exchangeRate, nativeAmount float64, quarks uint64) *transactionpb.ExchangeData {
+	if len(exchangeCurrency) == 0 {
+		return nil
+	}
+
+	return &transactionpb.ExchangeData{
+		Currency:     strings.ToLower(string(exchangeCurrency)),
+		ExchangeRate: exchangeRate,
+		NativeAmount: nativeAmount,
+		Quarks:       quarks,
+		Mint:         mint.ToProto(),
+	}
+}
+
+func toSwapMetadataProto(record *swap.Record) (*historypb.SwapMetadata, error) {
+	decodedSwapId, err := base58.Decode(record.SwapId)
+	if err != nil {
+		return nil, err
+	}
+
+	fromMint, err := common.NewAccountFromPublicKeyString(record.FromMint)
+	if err != nil {
+		return nil, err
+	}
+
+	toMint, err := common.NewAccountFromPublicKeyString(record.ToMint)
+	if err != nil {
+		return nil, err
+	}
+
+	return &historypb.SwapMetadata{
+		SwapId:      &commonpb.SwapId{Value: decodedSwapId},
+		FromMint:    fromMint.ToProto(),
+		ToMint:      toMint.ToProto(),
+		Amount:      record.Amount,
+		IsFinalized: record.State == swap.StateFinalized,
+	}, nil
+}
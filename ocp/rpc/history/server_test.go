@@ -0,0 +1,341 @@
+package history
+
+import (
+	"context"
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/mr-tron/base58"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	commonpb "github.com/code-payments/ocp-protobuf-api/generated/go/common/v1"
+	transactionpb "github.com/code-payments/ocp-protobuf-api/generated/go/transaction/v1"
+
+	"github.com/code-payments/ocp-server/currency"
+	"github.com/code-payments/ocp-server/ocp/common"
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/action"
+	"github.com/code-payments/ocp-server/ocp/data/intent"
+	"github.com/code-payments/ocp-server/ocp/data/swap"
+	historypb "github.com/code-payments/ocp-server/ocp/rpc/history/api/gen"
+	"github.com/code-payments/ocp-server/pointer"
+	"github.com/code-payments/ocp-server/testutil"
+)
+
+type testEnv struct {
+	ctx    context.Context
+	client historypb.HistoryClient
+	data   ocp_data.Provider
+}
+
+func setup(t *testing.T) (env testEnv, cleanup func()) {
+	log := zaptest.NewLogger(t)
+
+	conn, serv, err := testutil.NewServer(log)
+	require.NoError(t, err)
+
+	env.ctx = context.Background()
+	env.client = historypb.NewHistoryClient(conn)
+	env.data = ocp_data.NewTestDataProvider()
+
+	s := NewHistoryServer(log, env.data)
+
+	serv.RegisterService(func(server *grpc.Server) {
+		historypb.RegisterHistoryServer(server, s)
+	})
+
+	cleanup, err = serv.Serve()
+	require.NoError(t, err)
+	return env, cleanup
+}
+
+func TestGetPaymentHistory_HappyPath(t *testing.T) {
+	env, cleanup := setup(t)
+	defer cleanup()
+
+	owner := testutil.NewRandomAccount(t)
+	otherOwner := testutil.NewRandomAccount(t)
+	otherMint := testutil.NewRandomAccount(t)
+
+	resp := env.getPaymentHistory(t, owner, nil, 0, historypb.GetPaymentHistoryRequest_DESC)
+	assert.Equal(t, historypb.GetPaymentHistoryResponse_OK, resp.Result)
+	assert.Empty(t, resp.Items)
+	assert.Empty(t, resp.NextCursor)
+
+	env.saveIntent(t, &intent.Record{
+		IntentType:            intent.OpenAccounts,
+		InitiatorOwnerAccount: owner.PublicKey().ToBase58(),
+		OpenAccountsMetadata:  &intent.OpenAccountsMetadata{},
+	})
+	depositIntent := env.saveIntent(t, &intent.Record{
+		IntentType:            intent.ExternalDeposit,
+		InitiatorOwnerAccount: owner.PublicKey().ToBase58(),
+		ExternalDepositMetadata: &intent.ExternalDepositMetadata{
+			DestinationTokenAccount: "destination",
+			Quantity:                common.ToCoreMintQuarks(100),
+			UsdMarketValue:          100,
+		},
+	})
+	sentIntent := env.saveIntent(t, &intent.Record{
+		IntentType:                intent.SendPublicPayment,
+		InitiatorOwnerAccount:     owner.PublicKey().ToBase58(),
+		SendPublicPaymentMetadata: newSendPublicPaymentMetadata(t, otherOwner.PublicKey().ToBase58(), 10),
+	})
+	require.NoError(t, env.data.PutAllActions(env.ctx, &action.Record{
+		Intent:     sentIntent.IntentId,
+		IntentType: sentIntent.IntentType,
+		ActionId:   1,
+		ActionType: action.NoPrivacyTransfer,
+		Source:     "source",
+		Quantity:   pointer.Uint64(common.ToCoreMintQuarks(1)),
+		FeeType:    transactionpb.FeePaymentAction_CREATE_ON_SEND_WITHDRAWAL.Enum(),
+		State:      action.StatePending,
+	}))
+	receivedIntent := env.saveIntent(t, &intent.Record{
+		IntentType:                intent.SendPublicPayment,
+		InitiatorOwnerAccount:     otherOwner.PublicKey().ToBase58(),
+		SendPublicPaymentMetadata: newSendPublicPaymentMetadata(t, owner.PublicKey().ToBase58(), 5),
+	})
+	giftCardIssuedMetadata := newSendPublicPaymentMetadata(t, testutil.NewRandomAccount(t).PublicKey().ToBase58(), 20)
+	giftCardIssuedMetadata.IsRemoteSend = true
+	giftCardIssuedIntent := env.saveIntent(t, &intent.Record{
+		IntentType:                intent.SendPublicPayment,
+		InitiatorOwnerAccount:     owner.PublicKey().ToBase58(),
+		SendPublicPaymentMetadata: giftCardIssuedMetadata,
+	})
+	otherGiftCardIssuedMetadata := newSendPublicPaymentMetadata(t, testutil.NewRandomAccount(t).PublicKey().ToBase58(), 30)
+	otherGiftCardIssuedMetadata.IsRemoteSend = true
+	env.saveIntent(t, &intent.Record{
+		IntentType:                intent.SendPublicPayment,
+		InitiatorOwnerAccount:     otherOwner.PublicKey().ToBase58(),
+		SendPublicPaymentMetadata: otherGiftCardIssuedMetadata,
+	})
+	giftCardClaimedIntent := env.saveIntent(t, &intent.Record{
+		IntentType:                      intent.ReceivePaymentsPublicly,
+		InitiatorOwnerAccount:           owner.PublicKey().ToBase58(),
+		ReceivePaymentsPubliclyMetadata: newReceivePaymentsPubliclyMetadata(otherGiftCardIssuedMetadata, false),
+	})
+	giftCardReturnedIntent := env.saveIntent(t, &intent.Record{
+		IntentType:                      intent.ReceivePaymentsPublicly,
+		InitiatorOwnerAccount:           owner.PublicKey().ToBase58(),
+		ReceivePaymentsPubliclyMetadata: newReceivePaymentsPubliclyMetadata(giftCardIssuedMetadata, true),
+	})
+	withdrawalMetadata := newSendPublicPaymentMetadata(t, "", 40)
+	withdrawalMetadata.IsWithdrawal = true
+	withdrawalIntent := env.saveIntent(t, &intent.Record{
+		IntentType:                intent.SendPublicPayment,
+		InitiatorOwnerAccount:     owner.PublicKey().ToBase58(),
+		SendPublicPaymentMetadata: withdrawalMetadata,
+	})
+	buyIntent := env.saveIntent(t, &intent.Record{
+		IntentType:                intent.SendPublicPayment,
+		InitiatorOwnerAccount:     owner.PublicKey().ToBase58(),
+		SendPublicPaymentMetadata: newSendPublicPaymentMetadata(t, owner.PublicKey().ToBase58(), 50),
+	})
+	swapRecord := &swap.Record{
+		SwapId:         testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+		Owner:          owner.PublicKey().ToBase58(),
+		FromMint:       common.CoreMintAccount.PublicKey().ToBase58(),
+		ToMint:         otherMint.PublicKey().ToBase58(),
+		Amount:         common.ToCoreMintQuarks(50),
+		FundingId:      buyIntent.IntentId,
+		FundingSource:  swap.FundingSourceSubmitIntent,
+		Nonce:          "nonce",
+		Blockhash:      "blockhash",
+		ProofSignature: "proof",
+		State:          swap.StateFinalized,
+	}
+	require.NoError(t, env.data.SaveSwap(env.ctx, swapRecord))
+	distributionIntent := env.saveIntent(t, &intent.Record{
+		IntentType:            intent.PublicDistribution,
+		InitiatorOwnerAccount: otherOwner.PublicKey().ToBase58(),
+		PublicDistributionMetadata: &intent.PublicDistributionMetadata{
+			Source: "source",
+			Distributions: []*intent.Distribution{
+				{DestinationOwnerAccount: owner.PublicKey().ToBase58(), DestinationTokenAccount: "destination1", Quantity: common.ToCoreMintQuarks(3)},
+				{DestinationOwnerAccount: otherOwner.PublicKey().ToBase58(), DestinationTokenAccount: "destination2", Quantity: common.ToCoreMintQuarks(1)},
+			},
+			Quantity:       common.ToCoreMintQuarks(4),
+			UsdMarketValue: 4,
+		},
+	})
+
+	expected := []struct {
+		intentRecord *intent.Record
+		itemType     historypb.PaymentHistoryItem_Type
+		quarks       uint64
+		counterparty *common.Account
+	}{
+		{depositIntent, historypb.PaymentHistoryItem_DEPOSIT, common.ToCoreMintQuarks(100), nil},
+		{sentIntent, historypb.PaymentHistoryItem_SENT, common.ToCoreMintQuarks(10), otherOwner},
+		{receivedIntent, historypb.PaymentHistoryItem_RECEIVED, common.ToCoreMintQuarks(5), otherOwner},
+		{giftCardIssuedIntent, historypb.PaymentHistoryItem_GIFT_CARD_ISSUED, common.ToCoreMintQuarks(20), nil},
+		{giftCardClaimedIntent, historypb.PaymentHistoryItem_GIFT_CARD_CLAIMED, common.ToCoreMintQuarks(30), otherOwner},
+		{giftCardReturnedIntent, historypb.PaymentHistoryItem_GIFT_CARD_RETURNED, common.ToCoreMintQuarks(20), nil},
+		{withdrawalIntent, historypb.PaymentHistoryItem_WITHDRAWAL, common.ToCoreMintQuarks(40), nil},
+		{buyIntent, historypb.PaymentHistoryItem_BUY, common.ToCoreMintQuarks(50), nil},
+		{distributionIntent, historypb.PaymentHistoryItem_RECEIVED, common.ToCoreMintQuarks(3), otherOwner},
+	}
+
+	for _, direction := range []historypb.GetPaymentHistoryRequest_Direction{
+		historypb.GetPaymentHistoryRequest_ASC,
+		historypb.GetPaymentHistoryRequest_DESC,
+	} {
+		var actual []*historypb.PaymentHistoryItem
+		var cursor []byte
+		for {
+			resp := env.getPaymentHistory(t, owner, cursor, 3, direction)
+			actual = append(actual, resp.Items...)
+			if len(resp.NextCursor) == 0 {
+				break
+			}
+			cursor = resp.NextCursor
+		}
+
+		require.Len(t, actual, len(expected))
+		for i := range expected {
+			expectedItem := expected[i]
+			if direction == historypb.GetPaymentHistoryRequest_DESC {
+				expectedItem = expected[len(expected)-1-i]
+			}
+
+			assert.Equal(t, expectedItem.intentRecord.IntentId, base58.Encode(actual[i].IntentId.Value))
+			assert.Equal(t, expectedItem.itemType, actual[i].Type)
+			assert.Equal(t, historypb.PaymentHistoryItem_CONFIRMED, actual[i].State)
+			assert.Equal(t, expectedItem.quarks, actual[i].Quarks)
+			assert.Equal(t, common.CoreMintAccount.PublicKey().ToBytes(), actual[i].Mint.Value)
+			if expectedItem.counterparty != nil {
+				require.NotNil(t, actual[i].Counterparty)
+				assert.Equal(t, expectedItem.counterparty.PublicKey().ToBytes(), actual[i].Counterparty.Value)
+			} else {
+				assert.Nil(t, actual[i].Counterparty)
+			}
+
+			switch expectedItem.itemType {
+			case historypb.PaymentHistoryItem_DEPOSIT:
+				assert.Nil(t, actual[i].ExchangeData)
+			case historypb.PaymentHistoryItem_RECEIVED:
+				if expectedItem.intentRecord.IntentType == intent.PublicDistribution {
+					assert.Nil(t, actual[i].ExchangeData)
+					assert.EqualValues(t, 3, actual[i].UsdMarketValue)
+					break
+				}
+				fallthrough
+			default:
+				require.NotNil(t, actual[i].ExchangeData)
+				assert.Equal(t, "usd", actual[i].ExchangeData.Currency)
+				assert.Equal(t, expectedItem.quarks, actual[i].ExchangeData.Quarks)
+			}
+
+			if expectedItem.itemType == historypb.PaymentHistoryItem_SENT {
+				assert.Equal(t, common.ToCoreMintQuarks(1), actual[i].FeeQuarks)
+			} else {
+				assert.Zero(t, actual[i].FeeQuarks)
+			}
+
+			if expectedItem.itemType == historypb.PaymentHistoryItem_BUY {
+				require.NotNil(t, actual[i].Swap)
+				assert.Equal(t, swapRecord.SwapId, base58.Encode(actual[i].Swap.SwapId.Value))
+				assert.Equal(t, otherMint.PublicKey().ToBytes(), actual[i].Swap.ToMint.Value)
+				assert.True(t, actual[i].Swap.IsFinalized)
+			} else {
+				assert.Nil(t, actual[i].Swap)
+			}
+		}
+	}
+}
+
+func TestGetPaymentHistory_Unauthenticated(t *testing.T) {
+	env, cleanup := setup(t)
+	defer cleanup()
+
+	owner := testutil.NewRandomAccount(t)
+
+	req := &historypb.GetPaymentHistoryRequest{
+		Owner: owner.ToProto(),
+	}
+	reqBytes, err := proto.Marshal(req)
+	require.NoError(t, err)
+	req.Signature = &commonpb.Signature{
+		Value: ed25519.Sign(testutil.NewRandomAccount(t).PrivateKey().ToBytes(), reqBytes),
+	}
+
+	_, err = env.client.GetPaymentHistory(env.ctx, req)
+	testutil.AssertStatusErrorWithCode(t, err, codes.Unauthenticated)
+}
+
+func TestGetPaymentHistory_InvalidCursor(t *testing.T) {
+	env, cleanup := setup(t)
+	defer cleanup()
+
+	owner := testutil.NewRandomAccount(t)
+
+	req := &historypb.GetPaymentHistoryRequest{
+		Owner:  owner.ToProto(),
+		Cursor: []byte{1, 2, 3},
+	}
+	reqBytes, err := proto.Marshal(req)
+	require.NoError(t, err)
+	req.Signature = &commonpb.Signature{
+		Value: ed25519.Sign(owner.PrivateKey().ToBytes(), reqBytes),
+	}
+
+	_, err = env.client.GetPaymentHistory(env.ctx, req)
+	testutil.AssertStatusErrorWithCode(t, err, codes.InvalidArgument)
+}
+
+func (e *testEnv) getPaymentHistory(t *testing.T, owner *common.Account, cursor []byte, pageSize uint32, direction historypb.GetPaymentHistoryRequest_Direction) *historypb.GetPaymentHistoryResponse {
+	req := &historypb.GetPaymentHistoryRequest{
+		Owner:     owner.ToProto(),
+		Cursor:    cursor,
+		PageSize:  pageSize,
+		Direction: direction,
+	}
+	reqBytes, err := proto.Marshal(req)
+	require.NoError(t, err)
+	req.Signature = &commonpb.Signature{
+		Value: ed25519.Sign(owner.PrivateKey().ToBytes(), reqBytes),
+	}
+
+	resp, err := e.client.GetPaymentHistory(e.ctx, req)
+	require.NoError(t, err)
+	return resp
+}
+
+func (e *testEnv) saveIntent(t *testing.T, record *intent.Record) *intent.Record {
+	record.IntentId = testutil.NewRandomAccount(t).PublicKey().ToBase58()
+	record.MintAccount = common.CoreMintAccount.PublicKey().ToBase58()
+	record.State = intent.StateConfirmed
+	require.NoError(t, e.data.SaveIntent(e.ctx, record))
+	return record
+}
+
+func newSendPublicPaymentMetadata(t *testing.T, destinationOwner string, units uint64) *intent.SendPublicPaymentMetadata {
+	return &intent.SendPublicPaymentMetadata{
+		DestinationOwnerAccount: destinationOwner,
+		DestinationTokenAccount: testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+		Quantity:                common.ToCoreMintQuarks(units),
+		ExchangeCurrency:        currency.USD,
+		ExchangeRate:            1.0,
+		NativeAmount:            float64(units),
+		UsdMarketValue:          float64(units),
+	}
+}
+
+func newReceivePaymentsPubliclyMetadata(giftCardIssuedMetadata *intent.SendPublicPaymentMetadata, isReturned bool) *intent.ReceivePaymentsPubliclyMetadata {
+	return &intent.ReceivePaymentsPubliclyMetadata{
+		Source:                   giftCardIssuedMetadata.DestinationTokenAccount,
+		Quantity:                 giftCardIssuedMetadata.Quantity,
+		IsRemoteSend:             true,
+		IsReturned:               isReturned,
+		OriginalExchangeCurrency: giftCardIssuedMetadata.ExchangeCurrency,
+		OriginalExchangeRate:     giftCardIssuedMetadata.ExchangeRate,
+		OriginalNativeAmount:     giftCardIssuedMetadata.NativeAmount,
+		UsdMarketValue:           giftCardIssuedMetadata.UsdMarketValue,
+	}
+}
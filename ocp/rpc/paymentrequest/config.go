@@ -0,0 +1,52 @@
+package paymentrequest
+
+import (
+	"time"
+
+	"github.com/code-payments/ocp-server/config"
+	"github.com/code-payments/ocp-server/config/env"
+	"github.com/code-payments/ocp-server/config/memory"
+	"github.com/code-payments/ocp-server/config/wrapper"
+)
+
+const (
+	envConfigPrefix = "PAYMENT_REQUEST_SERVICE_"
+
+	MaxMemoLengthConfigEnvName = envConfigPrefix + "MAX_MEMO_LENGTH"
+	defaultMaxMemoLength       = 140
+
+	MaxTimeToExpiryConfigEnvName = envConfigPrefix + "MAX_TIME_TO_EXPIRY"
+	defaultMaxTimeToExpiry       = 30 * 24 * time.Hour
+)
+
+type conf struct {
+	maxMemoLength   config.Uint64
+	maxTimeToExpiry config.Duration
+}
+
+// ConfigProvider defines how config values are pulled
+type ConfigProvider func() *conf
+
+// WithEnvConfigs returns configuration pulled from environment variables
+func WithEnvConfigs() ConfigProvider {
+	return func() *conf {
+		return &conf{
+			maxMemoLength:   env.NewUint64Config(MaxMemoLengthConfigEnvName, defaultMaxMemoLength),
+			maxTimeToExpiry: env.NewDurationConfig(MaxTimeToExpiryConfigEnvName, defaultMaxTimeToExpiry),
+		}
+	}
+}
+
+type testOverrides struct {
+	maxMemoLength   uint64
+	maxTimeToExpiry time.Duration
+}
+
+func withManualTestOverrides(overrides *testOverrides) ConfigProvider {
+	return func() *conf {
+		return &conf{
+			maxMemoLength:   wrapper.NewUint64Config(memory.NewConfig(overrides.maxMemoLength), defaultMaxMemoLength),
+			maxTimeToExpiry: wrapper.NewDurationConfig(memory.NewConfig(overrides.maxTimeToExpiry), defaultMaxTimeToExpiry),
+		}
+	}
+}
@@ -0,0 +1,352 @@
+package paymentrequest
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	commonpb "github.com/code-payments/ocp-protobuf-api/generated/go/common/v1"
+
+	currency_lib "github.com/code-payments/ocp-server/currency"
+	"github.com/code-payments/ocp-server/grpc/client"
+	auth_util "github.com/code-payments/ocp-server/ocp/auth"
+	"github.com/code-payments/ocp-server/ocp/common"
+	currency_util "github.com/code-payments/ocp-server/ocp/currency"
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/account"
+	"github.com/code-payments/ocp-server/ocp/data/intent"
+	"github.com/code-payments/ocp-server/ocp/data/paymentrequest"
+	"github.com/code-payments/ocp-server/ocp/data/swap"
+	paymentrequestpb "github.com/code-payments/ocp-server/ocp/rpc/paymentrequest/api/gen"
+)
+
+type server struct {
+	log  *zap.Logger
+	conf *conf
+	data ocp_data.Provider
+	auth *auth_util.RPCSignatureVerifier
+
+	paymentrequestpb.UnimplementedPaymentRequestServer
+}
+
+func NewPaymentRequestServer(log *zap.Logger, data ocp_data.Provider, configProvider ConfigProvider) paymentrequestpb.PaymentRequestServer {
+	return &server{
+		log:  log,
+		conf: configProvider(),
+		data: data,
+		auth: auth_util.NewRPCSignatureVerifier(log, data),
+	}
+}
+
+func (s *server) CreatePaymentRequest(ctx context.Context, req *paymentrequestpb.CreatePaymentRequestRequest) (*paymentrequestpb.CreatePaymentRequestResponse, error) {
+	log := s.log.With(zap.String("method", "CreatePaymentRequest"))
+	log = client.InjectLoggingMetadata(ctx, log)
+
+	owner, err := common.NewAccountFromProto(req.Owner)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("invalid owner account")
+		return nil, status.Error(codes.Internal, "")
+	}
+	log = log.With(zap.String("owner_account", owner.PublicKey().ToBase58()))
+
+	rendezvousKey, err := common.NewAccountFromProto(req.RendezvousKey)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("invalid rendezvous key")
+		return nil, status.Error(codes.Internal, "")
+	}
+	log = log.With(zap.String("rendezvous_key", rendezvousKey.PublicKey().ToBase58()))
+
+	mint, err := common.NewAccountFromProto(req.Mint)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("invalid mint account")
+		return nil, status.Error(codes.Internal, "")
+	}
+	log = log.With(zap.String("mint_account", mint.PublicKey().ToBase58()))
+
+	destination, err := common.NewAccountFromProto(req.Destination)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("invalid destination account")
+		return nil, status.Error(codes.Internal, "")
+	}
+	log = log.With(zap.String("destination_account", destination.PublicKey().ToBase58()))
+
+	signature := req.Signature
+	rendezvousSignature := req.RendezvousSignature
+	req.Signature = nil
+	req.RendezvousSignature = nil
+	if err := s.auth.Authenticate(ctx, owner, req, signature); err != nil {
+		return nil, err
+	}
+	if err := s.auth.Authenticate(ctx, rendezvousKey, req, rendezvousSignature); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.data.GetPaymentRequest(ctx, rendezvousKey.PublicKey().ToBase58())
+	switch err {
+	case nil:
+		if existing.Owner != owner.PublicKey().ToBase58() {
+			return &paymentrequestpb.CreatePaymentRequestResponse{
+				Result: paymentrequestpb.CreatePaymentRequestResponse_ALREADY_EXISTS,
+			}, nil
+		}
+		return &paymentrequestpb.CreatePaymentRequestResponse{
+			Result:         paymentrequestpb.CreatePaymentRequestResponse_OK,
+			PaymentRequest: toProtoPaymentRequest(existing),
+		}, nil
+	case paymentrequest.ErrNotFound:
+	default:
+		log.With(zap.Error(err)).Warn("failure getting existing payment request")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	// The rendezvous key is used as an intent ID, so it can't already be used
+	// or reserved by another intent
+	isReserved, err := s.isIntentIdUsedOrReserved(ctx, rendezvousKey.PublicKey().ToBase58())
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure checking intent id availability")
+		return nil, status.Error(codes.Internal, "")
+	} else if isReserved {
+		return &paymentrequestpb.CreatePaymentRequestResponse{
+			Result: paymentrequestpb.CreatePaymentRequestResponse_ALREADY_EXISTS,
+		}, nil
+	}
+
+	isValid, err := s.validatePaymentRequest(ctx, req, mint)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure validating payment request")
+		return nil, status.Error(codes.Internal, "")
+	} else if !isValid {
+		return &paymentrequestpb.CreatePaymentRequestResponse{
+			Result: paymentrequestpb.CreatePaymentRequestResponse_INVALID_REQUEST,
+		}, nil
+	}
+
+	// Payments are made to the merchant's primary account
+	accountInfoRecord, err := s.data.GetAccountInfoByTokenAddress(ctx, destination.PublicKey().ToBase58())
+	if err == account.ErrAccountInfoNotFound {
+		return &paymentrequestpb.CreatePaymentRequestResponse{
+			Result: paymentrequestpb.CreatePaymentRequestResponse_INVALID_DESTINATION,
+		}, nil
+	} else if err != nil {
+		log.With(zap.Error(err)).Warn("failure getting destination account info record")
+		return nil, status.Error(codes.Internal, "")
+	}
+	if accountInfoRecord.AccountType != commonpb.AccountType_PRIMARY ||
+		accountInfoRecord.OwnerAccount != owner.PublicKey().ToBase58() ||
+		accountInfoRecord.MintAccount != mint.PublicKey().ToBase58() {
+		return &paymentrequestpb.CreatePaymentRequestResponse{
+			Result: paymentrequestpb.CreatePaymentRequestResponse_INVALID_DESTINATION,
+		}, nil
+	}
+
+	record := &paymentrequest.Record{
+		Intent: rendezvousKey.PublicKey().ToBase58(),
+
+		Owner: owner.PublicKey().ToBase58(),
+		Mint:  mint.PublicKey().ToBase58(),
+
+		DestinationTokenAccount: destination.PublicKey().ToBase58(),
+
+		ExchangeCurrency: currency_lib.Code(strings.ToLower(req.Currency)),
+		NativeAmount:     req.NativeAmount,
+
+		Memo: req.Memo,
+
+		ExpiresAt: req.ExpiresAt.AsTime(),
+
+		State: paymentrequest.StatePending,
+
+		CreatedAt: time.Now(),
+	}
+
+	err = s.data.PutPaymentRequest(ctx, record)
+	if err == paymentrequest.ErrAlreadyExists {
+		return &paymentrequestpb.CreatePaymentRequestResponse{
+			Result: paymentrequestpb.CreatePaymentRequestResponse_ALREADY_EXISTS,
+		}, nil
+	} else if err != nil {
+		log.With(zap.Error(err)).Warn("failure saving payment request")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return &paymentrequestpb.CreatePaymentRequestResponse{
+		Result:         paymentrequestpb.CreatePaymentRequestResponse_OK,
+		PaymentRequest: toProtoPaymentRequest(record),
+	}, nil
+}
+
+func (s *server) GetPaymentRequest(ctx context.Context, req *paymentrequestpb.GetPaymentRequestRequest) (*paymentrequestpb.GetPaymentRequestResponse, error) {
+	log := s.log.With(zap.String("method", "GetPaymentRequest"))
+	log = client.InjectLoggingMetadata(ctx, log)
+
+	rendezvousKey, err := common.NewAccountFromProto(req.RendezvousKey)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("invalid rendezvous key")
+		return nil, status.Error(codes.Internal, "")
+	}
+	log = log.With(zap.String("rendezvous_key", rendezvousKey.PublicKey().ToBase58()))
+
+	signature := req.Signature
+	req.Signature = nil
+	if err := s.auth.Authenticate(ctx, rendezvousKey, req, signature); err != nil {
+		return nil, err
+	}
+
+	record, err := s.data.GetPaymentRequest(ctx, rendezvousKey.PublicKey().ToBase58())
+	if err == paymentrequest.ErrNotFound {
+		return &paymentrequestpb.GetPaymentRequestResponse{
+			Result: paymentrequestpb.GetPaymentRequestResponse_NOT_FOUND,
+		}, nil
+	} else if err != nil {
+		log.With(zap.Error(err)).Warn("failure getting payment request")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return &paymentrequestpb.GetPaymentRequestResponse{
+		Result:         paymentrequestpb.GetPaymentRequestResponse_OK,
+		PaymentRequest: toProtoPaymentRequest(record),
+	}, nil
+}
+
+func (s *server) GetPaymentRequests(ctx context.Context, req *paymentrequestpb.GetPaymentRequestsRequest) (*paymentrequestpb.GetPaymentRequestsResponse, error) {
+	log := s.log.With(zap.String("method", "GetPaymentRequests"))
+	log = client.InjectLoggingMetadata(ctx, log)
+
+	owner, err := common.NewAccountFromProto(req.Owner)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("invalid owner account")
+		return nil, status.Error(codes.Internal, "")
+	}
+	log = log.With(zap.String("owner_account", owner.PublicKey().ToBase58()))
+
+	signature := req.Signature
+	req.Signature = nil
+	if err := s.auth.Authenticate(ctx, owner, req, signature); err != nil {
+		return nil, err
+	}
+
+	records, err := s.data.GetAllPaymentRequestsByOwner(ctx, owner.PublicKey().ToBase58())
+	if err == paymentrequest.ErrNotFound {
+		return &paymentrequestpb.GetPaymentRequestsResponse{
+			Result: paymentrequestpb.GetPaymentRequestsResponse_NOT_FOUND,
+		}, nil
+	} else if err != nil {
+		log.With(zap.Error(err)).Warn("failure getting payment requests")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	protoPaymentRequests := make([]*paymentrequestpb.PaymentRequestInfo, len(records))
+	for i, record := range records {
+		protoPaymentRequests[i] = toProtoPaymentRequest(record)
+	}
+
+	return &paymentrequestpb.GetPaymentRequestsResponse{
+		Result:          paymentrequestpb.GetPaymentRequestsResponse_OK,
+		PaymentRequests: protoPaymentRequests,
+	}, nil
+}
+
+// validatePaymentRequest validates the requested payment against configured
+// limits. The requested amount must be payable in a single intent.
+func (s *server) validatePaymentRequest(ctx context.Context, req *paymentrequestpb.CreatePaymentRequestRequest, mint *common.Account) (bool, error) {
+	isSupportedMint, err := common.IsSupportedMint(ctx, s.data, mint)
+	if err != nil {
+		return false, err
+	} else if !isSupportedMint {
+		return false, nil
+	}
+
+	sendLimit, ok := currency_util.SendLimits[currency_lib.Code(strings.ToLower(req.Currency))]
+	if !ok {
+		return false, nil
+	}
+
+	if req.NativeAmount <= 0 || req.NativeAmount > sendLimit.PerTransaction {
+		return false, nil
+	}
+
+	if uint64(len(req.Memo)) > s.conf.maxMemoLength.Get(ctx) {
+		return false, nil
+	}
+
+	if req.ExpiresAt == nil {
+		return false, nil
+	}
+	if err := req.ExpiresAt.CheckValid(); err != nil {
+		return false, nil
+	}
+
+	now := time.Now()
+	expiresAt := req.ExpiresAt.AsTime()
+	if !expiresAt.After(now) || expiresAt.After(now.Add(s.conf.maxTimeToExpiry.Get(ctx))) {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (s *server) isIntentIdUsedOrReserved(ctx context.Context, intentId string) (bool, error) {
+	_, err := s.data.GetIntent(ctx, intentId)
+	if err == nil {
+		return true, nil
+	} else if err != intent.ErrIntentNotFound {
+		return false, err
+	}
+
+	_, err = s.data.GetSwapByFundingId(ctx, intentId)
+	if err == nil {
+		return true, nil
+	} else if err != swap.ErrNotFound {
+		return false, err
+	}
+
+	return false, nil
+}
+
+func toProtoPaymentRequest(record *paymentrequest.Record) *paymentrequestpb.PaymentRequestInfo {
+	rendezvousKey, _ := common.NewAccountFromPublicKeyString(record.Intent)
+	owner, _ := common.NewAccountFromPublicKeyString(record.Owner)
+	mint, _ := common.NewAccountFromPublicKeyString(record.Mint)
+	destination, _ := common.NewAccountFromPublicKeyString(record.DestinationTokenAccount)
+
+	var state paymentrequestpb.PaymentRequestInfo_State
+	switch record.State {
+	case paymentrequest.StatePending:
+		state = paymentrequestpb.PaymentRequestInfo_PENDING
+		if record.IsExpired() {
+			state = paymentrequestpb.PaymentRequestInfo_EXPIRED
+		}
+	case paymentrequest.StatePaid:
+		state = paymentrequestpb.PaymentRequestInfo_PAID
+	case paymentrequest.StateFailed:
+		state = paymentrequestpb.PaymentRequestInfo_FAILED
+	}
+
+	protoPaymentRequest := &paymentrequestpb.PaymentRequestInfo{
+		RendezvousKey: rendezvousKey.ToProto(),
+		Owner:         owner.ToProto(),
+		Mint:          mint.ToProto(),
+		Destination:   destination.ToProto(),
+		Currency:      string(record.ExchangeCurrency),
+		NativeAmount:  record.NativeAmount,
+		Memo:          record.Memo,
+		ExpiresAt:     timestamppb.New(record.ExpiresAt),
+		State:         state,
+		CreatedAt:     timestamppb.New(record.CreatedAt),
+	}
+
+	if record.PaidBy != nil {
+		paidBy, _ := common.NewAccountFromPublicKeyString(*record.PaidBy)
+		protoPaymentRequest.PaidBy = paidBy.ToProto()
+	}
+	if record.PaidAt != nil {
+		protoPaymentRequest.PaidAt = timestamppb.New(*record.PaidAt)
+	}
+
+	return protoPaymentRequest
+}
@@ -0,0 +1,393 @@
+package paymentrequest
+
+import (
+	"context"
+	"crypto/ed25519"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	commonpb "github.com/code-payments/ocp-protobuf-api/generated/go/common/v1"
+
+	currency_lib "github.com/code-payments/ocp-server/currency"
+	"github.com/code-payments/ocp-server/ocp/common"
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/account"
+	"github.com/code-payments/ocp-server/ocp/data/paymentrequest"
+	paymentrequestpb "github.com/code-payments/ocp-server/ocp/rpc/paymentrequest/api/gen"
+	"github.com/code-payments/ocp-server/pointer"
+	"github.com/code-payments/ocp-server/testutil"
+)
+
+type testEnv struct {
+	ctx    context.Context
+	client paymentrequestpb.PaymentRequestClient
+	data   ocp_data.Provider
+}
+
+func setup(t *testing.T) (env testEnv, cleanup func()) {
+	log := zaptest.NewLogger(t)
+
+	conn, serv, err := testutil.NewServer(log)
+	require.NoError(t, err)
+
+	env.ctx = context.Background()
+	env.client = paymentrequestpb.NewPaymentRequestClient(conn)
+	env.data = ocp_data.NewTestDataProvider()
+	testutil.SetupRandomSubsidizer(t, env.data)
+
+	s := NewPaymentRequestServer(log, env.data, withManualTestOverrides(&testOverrides{
+		maxMemoLength:   16,
+		maxTimeToExpiry: 24 * time.Hour,
+	}))
+
+	serv.RegisterService(func(server *grpc.Server) {
+		paymentrequestpb.RegisterPaymentRequestServer(server, s)
+	})
+
+	cleanup, err = serv.Serve()
+	require.NoError(t, err)
+	return env, cleanup
+}
+
+func TestCreatePaymentRequest_HappyPath(t *testing.T) {
+	env, cleanup := setup(t)
+	defer cleanup()
+
+	owner := testutil.NewRandomAccount(t)
+	destination := env.setupPrimaryAccount(t, owner)
+	rendezvousKey := testutil.NewRandomAccount(t)
+
+	expiresAt := time.Now().Add(time.Hour)
+	req := env.newCreatePaymentRequestRequest(t, owner, rendezvousKey, destination, 12.5, expiresAt)
+	resp, err := env.client.CreatePaymentRequest(env.ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, paymentrequestpb.CreatePaymentRequestResponse_OK, resp.Result)
+	assert.Equal(t, rendezvousKey.PublicKey().ToBytes(), resp.PaymentRequest.RendezvousKey.Value)
+	assert.Equal(t, owner.PublicKey().ToBytes(), resp.PaymentRequest.Owner.Value)
+	assert.Equal(t, common.CoreMintAccount.PublicKey().ToBytes(), resp.PaymentRequest.Mint.Value)
+	assert.Equal(t, destination.PublicKey().ToBytes(), resp.PaymentRequest.Destination.Value)
+	assert.Equal(t, "usd", resp.PaymentRequest.Currency)
+	assert.EqualValues(t, 12.5, resp.PaymentRequest.NativeAmount)
+	assert.Equal(t, "coffee", resp.PaymentRequest.Memo)
+	assert.Equal(t, expiresAt.Unix(), resp.PaymentRequest.ExpiresAt.AsTime().Unix())
+	assert.Equal(t, paymentrequestpb.PaymentRequestInfo_PENDING, resp.PaymentRequest.State)
+	assert.Nil(t, resp.PaymentRequest.PaidBy)
+	assert.Nil(t, resp.PaymentRequest.PaidAt)
+
+	record, err := env.data.GetPaymentRequest(env.ctx, rendezvousKey.PublicKey().ToBase58())
+	require.NoError(t, err)
+	assert.Equal(t, owner.PublicKey().ToBase58(), record.Owner)
+	assert.Equal(t, common.CoreMintAccount.PublicKey().ToBase58(), record.Mint)
+	assert.Equal(t, destination.PublicKey().ToBase58(), record.DestinationTokenAccount)
+	assert.Equal(t, currency_lib.USD, record.ExchangeCurrency)
+	assert.EqualValues(t, 12.5, record.NativeAmount)
+	assert.Equal(t, paymentrequest.StatePending, record.State)
+
+	// Creation is idempotent
+	resp, err = env.client.CreatePaymentRequest(env.ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, paymentrequestpb.CreatePaymentRequestResponse_OK, resp.Result)
+
+	// Rendezvous keys can't be reused by other owners
+	otherOwner := testutil.NewRandomAccount(t)
+	otherDestination := env.setupPrimaryAccount(t, otherOwner)
+	otherReq := env.newCreatePaymentRequestRequest(t, otherOwner, rendezvousKey, otherDestination, 12.5, expiresAt)
+	resp, err = env.client.CreatePaymentRequest(env.ctx, otherReq)
+	require.NoError(t, err)
+	assert.Equal(t, paymentrequestpb.CreatePaymentRequestResponse_ALREADY_EXISTS, resp.Result)
+}
+
+func TestCreatePaymentRequest_InvalidRequest(t *testing.T) {
+	env, cleanup := setup(t)
+	defer cleanup()
+
+	owner := testutil.NewRandomAccount(t)
+	destination := env.setupPrimaryAccount(t, owner)
+
+	for _, tc := range []struct {
+		name   string
+		modify func(req *paymentrequestpb.CreatePaymentRequestRequest)
+	}{
+		{"unsupported mint", func(req *paymentrequestpb.CreatePaymentRequestRequest) {
+			req.Mint = testutil.NewRandomAccount(t).ToProto()
+		}},
+		{"unsupported currency", func(req *paymentrequestpb.CreatePaymentRequestRequest) { req.Currency = "xyz" }},
+		{"zero amount", func(req *paymentrequestpb.CreatePaymentRequestRequest) { req.NativeAmount = 0 }},
+		{"negative amount", func(req *paymentrequestpb.CreatePaymentRequestRequest) { req.NativeAmount = -1 }},
+		{"amount exceeds send limit", func(req *paymentrequestpb.CreatePaymentRequestRequest) { req.NativeAmount = 1_000_000 }},
+		{"memo too long", func(req *paymentrequestpb.CreatePaymentRequestRequest) { req.Memo = "a very long memo for a coffee" }},
+		{"missing expiry", func(req *paymentrequestpb.CreatePaymentRequestRequest) { req.ExpiresAt = nil }},
+		{"expiry in the past", func(req *paymentrequestpb.CreatePaymentRequestRequest) {
+			req.ExpiresAt = timestamppb.New(time.Now().Add(-time.Minute))
+		}},
+		{"expiry too far in the future", func(req *paymentrequestpb.CreatePaymentRequestRequest) {
+			req.ExpiresAt = timestamppb.New(time.Now().Add(25 * time.Hour))
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rendezvousKey := testutil.NewRandomAccount(t)
+
+			req := env.newCreatePaymentRequestRequest(t, owner, rendezvousKey, destination, 10, time.Now().Add(time.Hour))
+			tc.modify(req)
+			signCreateRequest(t, owner, rendezvousKey, req)
+
+			resp, err := env.client.CreatePaymentRequest(env.ctx, req)
+			require.NoError(t, err)
+			assert.Equal(t, paymentrequestpb.CreatePaymentRequestResponse_INVALID_REQUEST, resp.Result)
+
+			_, err = env.data.GetPaymentRequest(env.ctx, rendezvousKey.PublicKey().ToBase58())
+			assert.Equal(t, paymentrequest.ErrNotFound, err)
+		})
+	}
+}
+
+func TestCreatePaymentRequest_InvalidDestination(t *testing.T) {
+	env, cleanup := setup(t)
+	defer cleanup()
+
+	owner := testutil.NewRandomAccount(t)
+	env.setupPrimaryAccount(t, owner)
+
+	// Unknown destination
+	req := env.newCreatePaymentRequestRequest(t, owner, testutil.NewRandomAccount(t), testutil.NewRandomAccount(t), 10, time.Now().Add(time.Hour))
+	resp, err := env.client.CreatePaymentRequest(env.ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, paymentrequestpb.CreatePaymentRequestResponse_INVALID_DESTINATION, resp.Result)
+
+	// Destination owned by someone else
+	otherDestination := env.setupPrimaryAccount(t, testutil.NewRandomAccount(t))
+	req = env.newCreatePaymentRequestRequest(t, owner, testutil.NewRandomAccount(t), otherDestination, 10, time.Now().Add(time.Hour))
+	resp, err = env.client.CreatePaymentRequest(env.ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, paymentrequestpb.CreatePaymentRequestResponse_INVALID_DESTINATION, resp.Result)
+}
+
+func TestGetPaymentRequest(t *testing.T) {
+	env, cleanup := setup(t)
+	defer cleanup()
+
+	owner := testutil.NewRandomAccount(t)
+	destination := env.setupPrimaryAccount(t, owner)
+	rendezvousKey := testutil.NewRandomAccount(t)
+
+	resp, err := env.client.GetPaymentRequest(env.ctx, env.newGetPaymentRequestRequest(t, rendezvousKey))
+	require.NoError(t, err)
+	assert.Equal(t, paymentrequestpb.GetPaymentRequestResponse_NOT_FOUND, resp.Result)
+
+	createReq := env.newCreatePaymentRequestRequest(t, owner, rendezvousKey, destination, 10, time.Now().Add(time.Hour))
+	createResp, err := env.client.CreatePaymentRequest(env.ctx, createReq)
+	require.NoError(t, err)
+	require.Equal(t, paymentrequestpb.CreatePaymentRequestResponse_OK, createResp.Result)
+
+	resp, err = env.client.GetPaymentRequest(env.ctx, env.newGetPaymentRequestRequest(t, rendezvousKey))
+	require.NoError(t, err)
+	assert.Equal(t, paymentrequestpb.GetPaymentRequestResponse_OK, resp.Result)
+	assert.True(t, proto.Equal(createResp.PaymentRequest, resp.PaymentRequest))
+
+	// Payment details are reflected once the request is paid
+	payer := testutil.NewRandomAccount(t)
+	record, err := env.data.GetPaymentRequest(env.ctx, rendezvousKey.PublicKey().ToBase58())
+	require.NoError(t, err)
+	record.State = paymentrequest.StatePaid
+	record.PaidBy = pointer.String(payer.PublicKey().ToBase58())
+	record.PaidAt = pointer.Time(time.Now())
+	require.NoError(t, env.data.UpdatePaymentRequest(env.ctx, record))
+
+	resp, err = env.client.GetPaymentRequest(env.ctx, env.newGetPaymentRequestRequest(t, rendezvousKey))
+	require.NoError(t, err)
+	assert.Equal(t, paymentrequestpb.GetPaymentRequestResponse_OK, resp.Result)
+	assert.Equal(t, paymentrequestpb.PaymentRequestInfo_PAID, resp.PaymentRequest.State)
+	assert.Equal(t, payer.PublicKey().ToBytes(), resp.PaymentRequest.PaidBy.Value)
+	assert.Equal(t, record.PaidAt.Unix(), resp.PaymentRequest.PaidAt.AsTime().Unix())
+}
+
+func TestGetPaymentRequest_Expired(t *testing.T) {
+	env, cleanup := setup(t)
+	defer cleanup()
+
+	owner := testutil.NewRandomAccount(t)
+	destination := env.setupPrimaryAccount(t, owner)
+	rendezvousKey := testutil.NewRandomAccount(t)
+
+	require.NoError(t, env.data.PutPaymentRequest(env.ctx, &paymentrequest.Record{
+		Intent:                  rendezvousKey.PublicKey().ToBase58(),
+		Owner:                   owner.PublicKey().ToBase58(),
+		Mint:                    common.CoreMintAccount.PublicKey().ToBase58(),
+		DestinationTokenAccount: destination.PublicKey().ToBase58(),
+		ExchangeCurrency:        currency_lib.USD,
+		NativeAmount:            10,
+		ExpiresAt:               time.Now().Add(-time.Minute),
+		State:                   paymentrequest.StatePending,
+		CreatedAt:               time.Now().Add(-time.Hour),
+	}))
+
+	resp, err := env.client.GetPaymentRequest(env.ctx, env.newGetPaymentRequestRequest(t, rendezvousKey))
+	require.NoError(t, err)
+	assert.Equal(t, paymentrequestpb.GetPaymentRequestResponse_OK, resp.Result)
+	assert.Equal(t, paymentrequestpb.PaymentRequestInfo_EXPIRED, resp.PaymentRequest.State)
+}
+
+func TestGetPaymentRequest_Failed(t *testing.T) {
+	env, cleanup := setup(t)
+	defer cleanup()
+
+	owner := testutil.NewRandomAccount(t)
+	destination := env.setupPrimaryAccount(t, owner)
+	rendezvousKey := testutil.NewRandomAccount(t)
+
+	require.NoError(t, env.data.PutPaymentRequest(env.ctx, &paymentrequest.Record{
+		Intent:                  rendezvousKey.PublicKey().ToBase58(),
+		Owner:                   owner.PublicKey().ToBase58(),
+		Mint:                    common.CoreMintAccount.PublicKey().ToBase58(),
+		DestinationTokenAccount: destination.PublicKey().ToBase58(),
+		ExchangeCurrency:        currency_lib.USD,
+		NativeAmount:            10,
+		ExpiresAt:               time.Now().Add(time.Hour),
+		State:                   paymentrequest.StateFailed,
+		CreatedAt:               time.Now(),
+	}))
+
+	resp, err := env.client.GetPaymentRequest(env.ctx, env.newGetPaymentRequestRequest(t, rendezvousKey))
+	require.NoError(t, err)
+	assert.Equal(t, paymentrequestpb.GetPaymentRequestResponse_OK, resp.Result)
+	assert.Equal(t, paymentrequestpb.PaymentRequestInfo_FAILED, resp.PaymentRequest.State)
+	assert.Nil(t, resp.PaymentRequest.PaidBy)
+	assert.Nil(t, resp.PaymentRequest.PaidAt)
+}
+
+func TestGetPaymentRequests(t *testing.T) {
+	env, cleanup := setup(t)
+	defer cleanup()
+
+	owner := testutil.NewRandomAccount(t)
+	destination := env.setupPrimaryAccount(t, owner)
+
+	resp, err := env.client.GetPaymentRequests(env.ctx, env.newGetPaymentRequestsRequest(t, owner))
+	require.NoError(t, err)
+	assert.Equal(t, paymentrequestpb.GetPaymentRequestsResponse_NOT_FOUND, resp.Result)
+
+	var expected [][]byte
+	for i := 0; i < 3; i++ {
+		rendezvousKey := testutil.NewRandomAccount(t)
+		req := env.newCreatePaymentRequestRequest(t, owner, rendezvousKey, destination, 10, time.Now().Add(time.Hour))
+		createResp, err := env.client.CreatePaymentRequest(env.ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, paymentrequestpb.CreatePaymentRequestResponse_OK, createResp.Result)
+		expected = append(expected, rendezvousKey.PublicKey().ToBytes())
+	}
+
+	resp, err = env.client.GetPaymentRequests(env.ctx, env.newGetPaymentRequestsRequest(t, owner))
+	require.NoError(t, err)
+	assert.Equal(t, paymentrequestpb.GetPaymentRequestsResponse_OK, resp.Result)
+	require.Len(t, resp.PaymentRequests, len(expected))
+	for i, paymentRequest := range resp.PaymentRequests {
+		assert.Equal(t, expected[i], paymentRequest.RendezvousKey.Value)
+	}
+}
+
+func TestPaymentRequest_UnauthenticatedRPC(t *testing.T) {
+	env, cleanup := setup(t)
+	defer cleanup()
+
+	owner := testutil.NewRandomAccount(t)
+	destination := env.setupPrimaryAccount(t, owner)
+	rendezvousKey := testutil.NewRandomAccount(t)
+	maliciousAccount := testutil.NewRandomAccount(t)
+
+	createReq := env.newCreatePaymentRequestRequest(t, owner, rendezvousKey, destination, 10, time.Now().Add(time.Hour))
+	signCreateRequest(t, maliciousAccount, rendezvousKey, createReq)
+	_, err := env.client.CreatePaymentRequest(env.ctx, createReq)
+	testutil.AssertStatusErrorWithCode(t, err, codes.Unauthenticated)
+
+	createReq = env.newCreatePaymentRequestRequest(t, owner, rendezvousKey, destination, 10, time.Now().Add(time.Hour))
+	signCreateRequest(t, owner, maliciousAccount, createReq)
+	_, err = env.client.CreatePaymentRequest(env.ctx, createReq)
+	testutil.AssertStatusErrorWithCode(t, err, codes.Unauthenticated)
+
+	getReq := &paymentrequestpb.GetPaymentRequestRequest{
+		RendezvousKey: rendezvousKey.ToProto(),
+	}
+	getReq.Signature = signRequest(t, maliciousAccount, getReq)
+	_, err = env.client.GetPaymentRequest(env.ctx, getReq)
+	testutil.AssertStatusErrorWithCode(t, err, codes.Unauthenticated)
+
+	getAllReq := &paymentrequestpb.GetPaymentRequestsRequest{
+		Owner: owner.ToProto(),
+	}
+	getAllReq.Signature = signRequest(t, maliciousAccount, getAllReq)
+	_, err = env.client.GetPaymentRequests(env.ctx, getAllReq)
+	testutil.AssertStatusErrorWithCode(t, err, codes.Unauthenticated)
+}
+
+func (e *testEnv) setupPrimaryAccount(t *testing.T, owner *common.Account) *common.Account {
+	vmConfig, err := common.GetVmConfigForMint(e.ctx, e.data, common.CoreMintAccount)
+	require.NoError(t, err)
+
+	timelockAccounts, err := owner.GetTimelockAccounts(vmConfig)
+	require.NoError(t, err)
+
+	require.NoError(t, e.data.CreateAccountInfo(e.ctx, &account.Record{
+		OwnerAccount:     owner.PublicKey().ToBase58(),
+		AuthorityAccount: owner.PublicKey().ToBase58(),
+		TokenAccount:     timelockAccounts.Vault.PublicKey().ToBase58(),
+		MintAccount:      common.CoreMintAccount.PublicKey().ToBase58(),
+		AccountType:      commonpb.AccountType_PRIMARY,
+	}))
+	return timelockAccounts.Vault
+}
+
+func (e *testEnv) newCreatePaymentRequestRequest(t *testing.T, owner, rendezvousKey, destination *common.Account, nativeAmount float64, expiresAt time.Time) *paymentrequestpb.CreatePaymentRequestRequest {
+	req := &paymentrequestpb.CreatePaymentRequestRequest{
+		Owner:         owner.ToProto(),
+		RendezvousKey: rendezvousKey.ToProto(),
+		Mint:          common.CoreMintAccount.ToProto(),
+		Destination:   destination.ToProto(),
+		Currency:      "usd",
+		NativeAmount:  nativeAmount,
+		Memo:          "coffee",
+		ExpiresAt:     timestamppb.New(expiresAt),
+	}
+	signCreateRequest(t, owner, rendezvousKey, req)
+	return req
+}
+
+func (e *testEnv) newGetPaymentRequestRequest(t *testing.T, rendezvousKey *common.Account) *paymentrequestpb.GetPaymentRequestRequest {
+	req := &paymentrequestpb.GetPaymentRequestRequest{
+		RendezvousKey: rendezvousKey.ToProto(),
+	}
+	req.Signature = signRequest(t, rendezvousKey, req)
+	return req
+}
+
+func (e *testEnv) newGetPaymentRequestsRequest(t *testing.T, owner *common.Account) *paymentrequestpb.GetPaymentRequestsRequest {
+	req := &paymentrequestpb.GetPaymentRequestsRequest{
+		Owner: owner.ToProto(),
+	}
+	req.Signature = signRequest(t, owner, req)
+	return req
+}
+
+func signCreateRequest(t *testing.T, owner, rendezvousKey *common.Account, req *paymentrequestpb.CreatePaymentRequestRequest) {
+	req.Signature = nil
+	req.RendezvousSignature = nil
+	signature := signRequest(t, owner, req)
+	rendezvousSignature := signRequest(t, rendezvousKey, req)
+	req.Signature = signature
+	req.RendezvousSignature = rendezvousSignature
+}
+
+func signRequest(t *testing.T, signer *common.Account, req proto.Message) *commonpb.Signature {
+	reqBytes, err := proto.Marshal(req)
+	require.NoError(t, err)
+	return &commonpb.Signature{
+		Value: ed25519.Sign(signer.PrivateKey().ToBytes(), reqBytes),
+	}
+}
@@ -0,0 +1,1395 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v3.21.12
+// source: payment_schedule_service.proto
+
+package paymentschedule
+
+import (
+	v1 "github.com/code-payments/ocp-protobuf-api/generated/go/common/v1"
+	v11 "github.com/code-payments/ocp-protobuf-api/generated/go/messaging/v1"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreatePaymentScheduleResponse_Result int32
+
+const (
+	CreatePaymentScheduleResponse_OK CreatePaymentScheduleResponse_Result = 0
+	// The server can't make payments on the owner's behalf because it
+	// doesn't hold the owner's key.
+	CreatePaymentScheduleResponse_DENIED CreatePaymentScheduleResponse_Result = 1
+	// The schedule parameters are invalid or outside of allowed limits.
+	CreatePaymentScheduleResponse_INVALID_SCHEDULE CreatePaymentScheduleResponse_Result = 2
+	// The destination owner can't receive payments.
+	CreatePaymentScheduleResponse_INVALID_DESTINATION CreatePaymentScheduleResponse_Result = 3
+	// A different schedule already exists with the provided ID.
+	CreatePaymentScheduleResponse_ALREADY_EXISTS CreatePaymentScheduleResponse_Result = 4
+)
+
+// Enum value maps for CreatePaymentScheduleResponse_Result.
+var (
+	CreatePaymentScheduleResponse_Result_name = map[int32]string{
+		0: "OK",
+		1: "DENIED",
+		2: "INVALID_SCHEDULE",
+		3: "INVALID_DESTINATION",
+		4: "ALREADY_EXISTS",
+	}
+	CreatePaymentScheduleResponse_Result_value = map[string]int32{
+		"OK":                  0,
+		"DENIED":              1,
+		"INVALID_SCHEDULE":    2,
+		"INVALID_DESTINATION": 3,
+		"ALREADY_EXISTS":      4,
+	}
+)
+
+func (x CreatePaymentScheduleResponse_Result) Enum() *CreatePaymentScheduleResponse_Result {
+	p := new(CreatePaymentScheduleResponse_Result)
+	*p = x
+	return p
+}
+
+func (x CreatePaymentScheduleResponse_Result) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CreatePaymentScheduleResponse_Result) Descriptor() protoreflect.EnumDescriptor {
+	return file_payment_schedule_service_proto_enumTypes[0].Descriptor()
+}
+
+func (CreatePaymentScheduleResponse_Result) Type() protoreflect.EnumType {
+	return &file_payment_schedule_service_proto_enumTypes[0]
+}
+
+func (x CreatePaymentScheduleResponse_Result) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CreatePaymentScheduleResponse_Result.Descriptor instead.
+func (CreatePaymentScheduleResponse_Result) EnumDescriptor() ([]byte, []int) {
+	return file_payment_schedule_service_proto_rawDescGZIP(), []int{1, 0}
+}
+
+type CancelPaymentScheduleResponse_Result int32
+
+const (
+	CancelPaymentScheduleResponse_OK CancelPaymentScheduleResponse_Result = 0
+	// The schedule doesn't exist for the owner.
+	CancelPaymentScheduleResponse_NOT_FOUND CancelPaymentScheduleResponse_Result = 1
+	// The schedule is no longer active.
+	CancelPaymentScheduleResponse_NOT_ACTIVE CancelPaymentScheduleResponse_Result = 2
+)
+
+// Enum value maps for CancelPaymentScheduleResponse_Result.
+var (
+	CancelPaymentScheduleResponse_Result_name = map[int32]string{
+		0: "OK",
+		1: "NOT_FOUND",
+		2: "NOT_ACTIVE",
+	}
+	CancelPaymentScheduleResponse_Result_value = map[string]int32{
+		"OK":         0,
+		"NOT_FOUND":  1,
+		"NOT_ACTIVE": 2,
+	}
+)
+
+func (x CancelPaymentScheduleResponse_Result) Enum() *CancelPaymentScheduleResponse_Result {
+	p := new(CancelPaymentScheduleResponse_Result)
+	*p = x
+	return p
+}
+
+func (x CancelPaymentScheduleResponse_Result) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CancelPaymentScheduleResponse_Result) Descriptor() protoreflect.EnumDescriptor {
+	return file_payment_schedule_service_proto_enumTypes[1].Descriptor()
+}
+
+func (CancelPaymentScheduleResponse_Result) Type() protoreflect.EnumType {
+	return &file_payment_schedule_service_proto_enumTypes[1]
+}
+
+func (x CancelPaymentScheduleResponse_Result) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CancelPaymentScheduleResponse_Result.Descriptor instead.
+func (CancelPaymentScheduleResponse_Result) EnumDescriptor() ([]byte, []int) {
+	return file_payment_schedule_service_proto_rawDescGZIP(), []int{3, 0}
+}
+
+type GetPaymentSchedulesResponse_Result int32
+
+const (
+	GetPaymentSchedulesResponse_OK GetPaymentSchedulesResponse_Result = 0
+	// The owner has no payment schedules.
+	GetPaymentSchedulesResponse_NOT_FOUND GetPaymentSchedulesResponse_Result = 1
+)
+
+// Enum value maps for GetPaymentSchedulesResponse_Result.
+var (
+	GetPaymentSchedulesResponse_Result_name = map[int32]string{
+		0: "OK",
+		1: "NOT_FOUND",
+	}
+	GetPaymentSchedulesResponse_Result_value = map[string]int32{
+		"OK":        0,
+		"NOT_FOUND": 1,
+	}
+)
+
+func (x GetPaymentSchedulesResponse_Result) Enum() *GetPaymentSchedulesResponse_Result {
+	p := new(GetPaymentSchedulesResponse_Result)
+	*p = x
+	return p
+}
+
+func (x GetPaymentSchedulesResponse_Result) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GetPaymentSchedulesResponse_Result) Descriptor() protoreflect.EnumDescriptor {
+	return file_payment_schedule_service_proto_enumTypes[2].Descriptor()
+}
+
+func (GetPaymentSchedulesResponse_Result) Type() protoreflect.EnumType {
+	return &file_payment_schedule_service_proto_enumTypes[2]
+}
+
+func (x GetPaymentSchedulesResponse_Result) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GetPaymentSchedulesResponse_Result.Descriptor instead.
+func (GetPaymentSchedulesResponse_Result) EnumDescriptor() ([]byte, []int) {
+	return file_payment_schedule_service_proto_rawDescGZIP(), []int{5, 0}
+}
+
+type PaymentScheduleInfo_State int32
+
+const (
+	PaymentScheduleInfo_UNKNOWN PaymentScheduleInfo_State = 0
+	// Payments are made as they become due.
+	PaymentScheduleInfo_ACTIVE PaymentScheduleInfo_State = 1
+	// All payments were made, or the amount cap was reached.
+	PaymentScheduleInfo_COMPLETED PaymentScheduleInfo_State = 2
+	// The owner cancelled the schedule.
+	PaymentScheduleInfo_CANCELLED PaymentScheduleInfo_State = 3
+	// A payment couldn't be made, and no further payments will be
+	// attempted.
+	PaymentScheduleInfo_FAILED PaymentScheduleInfo_State = 4
+)
+
+// Enum value maps for PaymentScheduleInfo_State.
+var (
+	PaymentScheduleInfo_State_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "ACTIVE",
+		2: "COMPLETED",
+		3: "CANCELLED",
+		4: "FAILED",
+	}
+	PaymentScheduleInfo_State_value = map[string]int32{
+		"UNKNOWN":   0,
+		"ACTIVE":    1,
+		"COMPLETED": 2,
+		"CANCELLED": 3,
+		"FAILED":    4,
+	}
+)
+
+func (x PaymentScheduleInfo_State) Enum() *PaymentScheduleInfo_State {
+	p := new(PaymentScheduleInfo_State)
+	*p = x
+	return p
+}
+
+func (x PaymentScheduleInfo_State) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PaymentScheduleInfo_State) Descriptor() protoreflect.EnumDescriptor {
+	return file_payment_schedule_service_proto_enumTypes[3].Descriptor()
+}
+
+func (PaymentScheduleInfo_State) Type() protoreflect.EnumType {
+	return &file_payment_schedule_service_proto_enumTypes[3]
+}
+
+func (x PaymentScheduleInfo_State) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PaymentScheduleInfo_State.Descriptor instead.
+func (PaymentScheduleInfo_State) EnumDescriptor() ([]byte, []int) {
+	return file_payment_schedule_service_proto_rawDescGZIP(), []int{11, 0}
+}
+
+type ScheduledPaymentMessage_Kind int32
+
+const (
+	ScheduledPaymentMessage_UNKNOWN ScheduledPaymentMessage_Kind = 0
+	// The payment was made.
+	ScheduledPaymentMessage_EXECUTED ScheduledPaymentMessage_Kind = 1
+	// The payment couldn't be made, and the schedule failed.
+	ScheduledPaymentMessage_FAILED ScheduledPaymentMessage_Kind = 2
+)
+
+// Enum value maps for ScheduledPaymentMessage_Kind.
+var (
+	ScheduledPaymentMessage_Kind_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "EXECUTED",
+		2: "FAILED",
+	}
+	ScheduledPaymentMessage_Kind_value = map[string]int32{
+		"UNKNOWN":  0,
+		"EXECUTED": 1,
+		"FAILED":   2,
+	}
+)
+
+func (x ScheduledPaymentMessage_Kind) Enum() *ScheduledPaymentMessage_Kind {
+	p := new(ScheduledPaymentMessage_Kind)
+	*p = x
+	return p
+}
+
+func (x ScheduledPaymentMessage_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ScheduledPaymentMessage_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_payment_schedule_service_proto_enumTypes[4].Descriptor()
+}
+
+func (ScheduledPaymentMessage_Kind) Type() protoreflect.EnumType {
+	return &file_payment_schedule_service_proto_enumTypes[4]
+}
+
+func (x ScheduledPaymentMessage_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ScheduledPaymentMessage_Kind.Descriptor instead.
+func (ScheduledPaymentMessage_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_payment_schedule_service_proto_rawDescGZIP(), []int{12, 0}
+}
+
+type CreatePaymentScheduleRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The owner account making the payments.
+	Owner *v1.SolanaAccountId `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	// A client-generated ID for the schedule, which makes creation idempotent.
+	ScheduleId *ScheduleId `protobuf:"bytes,2,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	// The owner account receiving the payments. Payments are made to its core
+	// mint primary account.
+	DestinationOwner *v1.SolanaAccountId `protobuf:"bytes,3,opt,name=destination_owner,json=destinationOwner,proto3" json:"destination_owner,omitempty"`
+	// The currency that payment amounts are denominated in.
+	Currency string `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	// The amount of each payment in the provided currency.
+	NativeAmount float64 `protobuf:"fixed64,5,opt,name=native_amount,json=nativeAmount,proto3" json:"native_amount,omitempty"`
+	// The maximum total amount paid over the lifetime of the schedule in the
+	// provided currency.
+	MaxTotalNativeAmount float64 `protobuf:"fixed64,6,opt,name=max_total_native_amount,json=maxTotalNativeAmount,proto3" json:"max_total_native_amount,omitempty"`
+	// When the first payment is due.
+	FirstPaymentAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=first_payment_at,json=firstPaymentAt,proto3" json:"first_payment_at,omitempty"`
+	// The interval between payments. It must be unset for a one-time payment.
+	Interval *durationpb.Duration `protobuf:"bytes,8,opt,name=interval,proto3" json:"interval,omitempty"`
+	// The maximum number of payments. It must be 1 for a one-time payment.
+	MaxPayments uint32 `protobuf:"varint,9,opt,name=max_payments,json=maxPayments,proto3" json:"max_payments,omitempty"`
+	// Signature of the request by the owner.
+	Signature     *v1.Signature `protobuf:"bytes,10,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePaymentScheduleRequest) Reset() {
+	*x = CreatePaymentScheduleRequest{}
+	mi := &file_payment_schedule_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePaymentScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePaymentScheduleRequest) ProtoMessage() {}
+
+func (x *CreatePaymentScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_schedule_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePaymentScheduleRequest.ProtoReflect.Descriptor instead.
+func (*CreatePaymentScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_payment_schedule_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreatePaymentScheduleRequest) GetOwner() *v1.SolanaAccountId {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+func (x *CreatePaymentScheduleRequest) GetScheduleId() *ScheduleId {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return nil
+}
+
+func (x *CreatePaymentScheduleRequest) GetDestinationOwner() *v1.SolanaAccountId {
+	if x != nil {
+		return x.DestinationOwner
+	}
+	return nil
+}
+
+func (x *CreatePaymentScheduleRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreatePaymentScheduleRequest) GetNativeAmount() float64 {
+	if x != nil {
+		return x.NativeAmount
+	}
+	return 0
+}
+
+func (x *CreatePaymentScheduleRequest) GetMaxTotalNativeAmount() float64 {
+	if x != nil {
+		return x.MaxTotalNativeAmount
+	}
+	return 0
+}
+
+func (x *CreatePaymentScheduleRequest) GetFirstPaymentAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstPaymentAt
+	}
+	return nil
+}
+
+func (x *CreatePaymentScheduleRequest) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *CreatePaymentScheduleRequest) GetMaxPayments() uint32 {
+	if x != nil {
+		return x.MaxPayments
+	}
+	return 0
+}
+
+func (x *CreatePaymentScheduleRequest) GetSignature() *v1.Signature {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type CreatePaymentScheduleResponse struct {
+	state         protoimpl.MessageState               `protogen:"open.v1"`
+	Result        CreatePaymentScheduleResponse_Result `protobuf:"varint,1,opt,name=result,proto3,enum=ocp.paymentschedule.v1.CreatePaymentScheduleResponse_Result" json:"result,omitempty"`
+	Schedule      *PaymentScheduleInfo                 `protobuf:"bytes,2,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePaymentScheduleResponse) Reset() {
+	*x = CreatePaymentScheduleResponse{}
+	mi := &file_payment_schedule_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePaymentScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePaymentScheduleResponse) ProtoMessage() {}
+
+func (x *CreatePaymentScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_schedule_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePaymentScheduleResponse.ProtoReflect.Descriptor instead.
+func (*CreatePaymentScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_payment_schedule_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreatePaymentScheduleResponse) GetResult() CreatePaymentScheduleResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return CreatePaymentScheduleResponse_OK
+}
+
+func (x *CreatePaymentScheduleResponse) GetSchedule() *PaymentScheduleInfo {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+type CancelPaymentScheduleRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The owner account making the payments.
+	Owner      *v1.SolanaAccountId `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	ScheduleId *ScheduleId         `protobuf:"bytes,2,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	// Signature of the request by the owner.
+	Signature     *v1.Signature `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelPaymentScheduleRequest) Reset() {
+	*x = CancelPaymentScheduleRequest{}
+	mi := &file_payment_schedule_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelPaymentScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelPaymentScheduleRequest) ProtoMessage() {}
+
+func (x *CancelPaymentScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_schedule_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelPaymentScheduleRequest.ProtoReflect.Descriptor instead.
+func (*CancelPaymentScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_payment_schedule_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CancelPaymentScheduleRequest) GetOwner() *v1.SolanaAccountId {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+func (x *CancelPaymentScheduleRequest) GetScheduleId() *ScheduleId {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return nil
+}
+
+func (x *CancelPaymentScheduleRequest) GetSignature() *v1.Signature {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type CancelPaymentScheduleResponse struct {
+	state         protoimpl.MessageState               `protogen:"open.v1"`
+	Result        CancelPaymentScheduleResponse_Result `protobuf:"varint,1,opt,name=result,proto3,enum=ocp.paymentschedule.v1.CancelPaymentScheduleResponse_Result" json:"result,omitempty"`
+	Schedule      *PaymentScheduleInfo                 `protobuf:"bytes,2,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelPaymentScheduleResponse) Reset() {
+	*x = CancelPaymentScheduleResponse{}
+	mi := &file_payment_schedule_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelPaymentScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelPaymentScheduleResponse) ProtoMessage() {}
+
+func (x *CancelPaymentScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_schedule_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelPaymentScheduleResponse.ProtoReflect.Descriptor instead.
+func (*CancelPaymentScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_payment_schedule_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CancelPaymentScheduleResponse) GetResult() CancelPaymentScheduleResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return CancelPaymentScheduleResponse_OK
+}
+
+func (x *CancelPaymentScheduleResponse) GetSchedule() *PaymentScheduleInfo {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+type GetPaymentSchedulesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The owner account making the payments.
+	Owner *v1.SolanaAccountId `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	// Signature of the request by the owner.
+	Signature     *v1.Signature `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPaymentSchedulesRequest) Reset() {
+	*x = GetPaymentSchedulesRequest{}
+	mi := &file_payment_schedule_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPaymentSchedulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPaymentSchedulesRequest) ProtoMessage() {}
+
+func (x *GetPaymentSchedulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_schedule_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPaymentSchedulesRequest.ProtoReflect.Descriptor instead.
+func (*GetPaymentSchedulesRequest) Descriptor() ([]byte, []int) {
+	return file_payment_schedule_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetPaymentSchedulesRequest) GetOwner() *v1.SolanaAccountId {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+func (x *GetPaymentSchedulesRequest) GetSignature() *v1.Signature {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type GetPaymentSchedulesResponse struct {
+	state         protoimpl.MessageState             `protogen:"open.v1"`
+	Result        GetPaymentSchedulesResponse_Result `protobuf:"varint,1,opt,name=result,proto3,enum=ocp.paymentschedule.v1.GetPaymentSchedulesResponse_Result" json:"result,omitempty"`
+	Schedules     []*PaymentScheduleInfo             `protobuf:"bytes,2,rep,name=schedules,proto3" json:"schedules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPaymentSchedulesResponse) Reset() {
+	*x = GetPaymentSchedulesResponse{}
+	mi := &file_payment_schedule_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPaymentSchedulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPaymentSchedulesResponse) ProtoMessage() {}
+
+func (x *GetPaymentSchedulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_schedule_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPaymentSchedulesResponse.ProtoReflect.Descriptor instead.
+func (*GetPaymentSchedulesResponse) Descriptor() ([]byte, []int) {
+	return file_payment_schedule_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetPaymentSchedulesResponse) GetResult() GetPaymentSchedulesResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return GetPaymentSchedulesResponse_OK
+}
+
+func (x *GetPaymentSchedulesResponse) GetSchedules() []*PaymentScheduleInfo {
+	if x != nil {
+		return x.Schedules
+	}
+	return nil
+}
+
+type PollScheduledPaymentMessagesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The owner account making the payments.
+	Owner *v1.SolanaAccountId `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	// Signature of the request by the owner.
+	Signature     *v1.Signature `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollScheduledPaymentMessagesRequest) Reset() {
+	*x = PollScheduledPaymentMessagesRequest{}
+	mi := &file_payment_schedule_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollScheduledPaymentMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollScheduledPaymentMessagesRequest) ProtoMessage() {}
+
+func (x *PollScheduledPaymentMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_schedule_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollScheduledPaymentMessagesRequest.ProtoReflect.Descriptor instead.
+func (*PollScheduledPaymentMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_payment_schedule_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PollScheduledPaymentMessagesRequest) GetOwner() *v1.SolanaAccountId {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+func (x *PollScheduledPaymentMessagesRequest) GetSignature() *v1.Signature {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type PollScheduledPaymentMessagesResponse struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Messages      []*ScheduledPaymentMessage `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollScheduledPaymentMessagesResponse) Reset() {
+	*x = PollScheduledPaymentMessagesResponse{}
+	mi := &file_payment_schedule_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollScheduledPaymentMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollScheduledPaymentMessagesResponse) ProtoMessage() {}
+
+func (x *PollScheduledPaymentMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_schedule_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollScheduledPaymentMessagesResponse.ProtoReflect.Descriptor instead.
+func (*PollScheduledPaymentMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_payment_schedule_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PollScheduledPaymentMessagesResponse) GetMessages() []*ScheduledPaymentMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+type AckScheduledPaymentMessagesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The owner account making the payments.
+	Owner      *v1.SolanaAccountId `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	MessageIds []*v11.MessageId    `protobuf:"bytes,2,rep,name=message_ids,json=messageIds,proto3" json:"message_ids,omitempty"`
+	// Signature of the request by the owner.
+	Signature     *v1.Signature `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AckScheduledPaymentMessagesRequest) Reset() {
+	*x = AckScheduledPaymentMessagesRequest{}
+	mi := &file_payment_schedule_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AckScheduledPaymentMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckScheduledPaymentMessagesRequest) ProtoMessage() {}
+
+func (x *AckScheduledPaymentMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_schedule_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckScheduledPaymentMessagesRequest.ProtoReflect.Descriptor instead.
+func (*AckScheduledPaymentMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_payment_schedule_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AckScheduledPaymentMessagesRequest) GetOwner() *v1.SolanaAccountId {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+func (x *AckScheduledPaymentMessagesRequest) GetMessageIds() []*v11.MessageId {
+	if x != nil {
+		return x.MessageIds
+	}
+	return nil
+}
+
+func (x *AckScheduledPaymentMessagesRequest) GetSignature() *v1.Signature {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type AckScheduledPaymentMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AckScheduledPaymentMessagesResponse) Reset() {
+	*x = AckScheduledPaymentMessagesResponse{}
+	mi := &file_payment_schedule_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AckScheduledPaymentMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckScheduledPaymentMessagesResponse) ProtoMessage() {}
+
+func (x *AckScheduledPaymentMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_schedule_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckScheduledPaymentMessagesResponse.ProtoReflect.Descriptor instead.
+func (*AckScheduledPaymentMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_payment_schedule_service_proto_rawDescGZIP(), []int{9}
+}
+
+type ScheduleId struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         []byte                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleId) Reset() {
+	*x = ScheduleId{}
+	mi := &file_payment_schedule_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleId) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleId) ProtoMessage() {}
+
+func (x *ScheduleId) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_schedule_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleId.ProtoReflect.Descriptor instead.
+func (*ScheduleId) Descriptor() ([]byte, []int) {
+	return file_payment_schedule_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ScheduleId) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type PaymentScheduleInfo struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	ScheduleId           *ScheduleId            `protobuf:"bytes,1,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	DestinationOwner     *v1.SolanaAccountId    `protobuf:"bytes,2,opt,name=destination_owner,json=destinationOwner,proto3" json:"destination_owner,omitempty"`
+	Currency             string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	NativeAmount         float64                `protobuf:"fixed64,4,opt,name=native_amount,json=nativeAmount,proto3" json:"native_amount,omitempty"`
+	MaxTotalNativeAmount float64                `protobuf:"fixed64,5,opt,name=max_total_native_amount,json=maxTotalNativeAmount,proto3" json:"max_total_native_amount,omitempty"`
+	// Unset for a one-time payment.
+	Interval    *durationpb.Duration `protobuf:"bytes,6,opt,name=interval,proto3" json:"interval,omitempty"`
+	MaxPayments uint32               `protobuf:"varint,7,opt,name=max_payments,json=maxPayments,proto3" json:"max_payments,omitempty"`
+	// When the next payment is due. It's meaningless once the schedule is no
+	// longer active.
+	NextPaymentAt         *timestamppb.Timestamp    `protobuf:"bytes,8,opt,name=next_payment_at,json=nextPaymentAt,proto3" json:"next_payment_at,omitempty"`
+	PaymentsMade          uint32                    `protobuf:"varint,9,opt,name=payments_made,json=paymentsMade,proto3" json:"payments_made,omitempty"`
+	TotalNativeAmountPaid float64                   `protobuf:"fixed64,10,opt,name=total_native_amount_paid,json=totalNativeAmountPaid,proto3" json:"total_native_amount_paid,omitempty"`
+	State                 PaymentScheduleInfo_State `protobuf:"varint,11,opt,name=state,proto3,enum=ocp.paymentschedule.v1.PaymentScheduleInfo_State" json:"state,omitempty"`
+	CreatedAt             *timestamppb.Timestamp    `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *PaymentScheduleInfo) Reset() {
+	*x = PaymentScheduleInfo{}
+	mi := &file_payment_schedule_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentScheduleInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentScheduleInfo) ProtoMessage() {}
+
+func (x *PaymentScheduleInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_schedule_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentScheduleInfo.ProtoReflect.Descriptor instead.
+func (*PaymentScheduleInfo) Descriptor() ([]byte, []int) {
+	return file_payment_schedule_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PaymentScheduleInfo) GetScheduleId() *ScheduleId {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return nil
+}
+
+func (x *PaymentScheduleInfo) GetDestinationOwner() *v1.SolanaAccountId {
+	if x != nil {
+		return x.DestinationOwner
+	}
+	return nil
+}
+
+func (x *PaymentScheduleInfo) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *PaymentScheduleInfo) GetNativeAmount() float64 {
+	if x != nil {
+		return x.NativeAmount
+	}
+	return 0
+}
+
+func (x *PaymentScheduleInfo) GetMaxTotalNativeAmount() float64 {
+	if x != nil {
+		return x.MaxTotalNativeAmount
+	}
+	return 0
+}
+
+func (x *PaymentScheduleInfo) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *PaymentScheduleInfo) GetMaxPayments() uint32 {
+	if x != nil {
+		return x.MaxPayments
+	}
+	return 0
+}
+
+func (x *PaymentScheduleInfo) GetNextPaymentAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextPaymentAt
+	}
+	return nil
+}
+
+func (x *PaymentScheduleInfo) GetPaymentsMade() uint32 {
+	if x != nil {
+		return x.PaymentsMade
+	}
+	return 0
+}
+
+func (x *PaymentScheduleInfo) GetTotalNativeAmountPaid() float64 {
+	if x != nil {
+		return x.TotalNativeAmountPaid
+	}
+	return 0
+}
+
+func (x *PaymentScheduleInfo) GetState() PaymentScheduleInfo_State {
+	if x != nil {
+		return x.State
+	}
+	return PaymentScheduleInfo_UNKNOWN
+}
+
+func (x *PaymentScheduleInfo) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// ScheduledPaymentMessage notifies an owner that a payment in one of their
+// schedules was executed, or failed and stopped all future payments.
+type ScheduledPaymentMessage struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         *v11.MessageId         `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ScheduleId *ScheduleId            `protobuf:"bytes,2,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	// The index of the payment within the schedule, starting at zero.
+	PaymentIndex uint32 `protobuf:"varint,3,opt,name=payment_index,json=paymentIndex,proto3" json:"payment_index,omitempty"`
+	// The ID of the intent for the payment. It's only created for executed
+	// payments.
+	IntentId *v1.IntentId                 `protobuf:"bytes,4,opt,name=intent_id,json=intentId,proto3" json:"intent_id,omitempty"`
+	Kind     ScheduledPaymentMessage_Kind `protobuf:"varint,5,opt,name=kind,proto3,enum=ocp.paymentschedule.v1.ScheduledPaymentMessage_Kind" json:"kind,omitempty"`
+	// Why the payment couldn't be made. It's only set for failed payments.
+	FailureReason string                 `protobuf:"bytes,6,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduledPaymentMessage) Reset() {
+	*x = ScheduledPaymentMessage{}
+	mi := &file_payment_schedule_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduledPaymentMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledPaymentMessage) ProtoMessage() {}
+
+func (x *ScheduledPaymentMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_schedule_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledPaymentMessage.ProtoReflect.Descriptor instead.
+func (*ScheduledPaymentMessage) Descriptor() ([]byte, []int) {
+	return file_payment_schedule_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ScheduledPaymentMessage) GetId() *v11.MessageId {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *ScheduledPaymentMessage) GetScheduleId() *ScheduleId {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return nil
+}
+
+func (x *ScheduledPaymentMessage) GetPaymentIndex() uint32 {
+	if x != nil {
+		return x.PaymentIndex
+	}
+	return 0
+}
+
+func (x *ScheduledPaymentMessage) GetIntentId() *v1.IntentId {
+	if x != nil {
+		return x.IntentId
+	}
+	return nil
+}
+
+func (x *ScheduledPaymentMessage) GetKind() ScheduledPaymentMessage_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return ScheduledPaymentMessage_UNKNOWN
+}
+
+func (x *ScheduledPaymentMessage) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *ScheduledPaymentMessage) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+var File_payment_schedule_service_proto protoreflect.FileDescriptor
+
+const file_payment_schedule_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1epayment_schedule_service.proto\x12\x16ocp.paymentschedule.v1\x1a\x15common/v1/model.proto\x1a$messaging/v1/messaging_service.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb6\x04\n" +
+	"\x1cCreatePaymentScheduleRequest\x124\n" +
+	"\x05owner\x18\x01 \x01(\v2\x1e.ocp.common.v1.SolanaAccountIdR\x05owner\x12C\n" +
+	"\vschedule_id\x18\x02 \x01(\v2\".ocp.paymentschedule.v1.ScheduleIdR\n" +
+	"scheduleId\x12K\n" +
+	"\x11destination_owner\x18\x03 \x01(\v2\x1e.ocp.common.v1.SolanaAccountIdR\x10destinationOwner\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12#\n" +
+	"\rnative_amount\x18\x05 \x01(\x01R\fnativeAmount\x125\n" +
+	"\x17max_total_native_amount\x18\x06 \x01(\x01R\x14maxTotalNativeAmount\x12D\n" +
+	"\x10first_payment_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x0efirstPaymentAt\x125\n" +
+	"\binterval\x18\b \x01(\v2\x19.google.protobuf.DurationR\binterval\x12!\n" +
+	"\fmax_payments\x18\t \x01(\rR\vmaxPayments\x126\n" +
+	"\tsignature\x18\n" +
+	" \x01(\v2\x18.ocp.common.v1.SignatureR\tsignature\"\x9f\x02\n" +
+	"\x1dCreatePaymentScheduleResponse\x12T\n" +
+	"\x06result\x18\x01 \x01(\x0e2<.ocp.paymentschedule.v1.CreatePaymentScheduleResponse.ResultR\x06result\x12G\n" +
+	"\bschedule\x18\x02 \x01(\v2+.ocp.paymentschedule.v1.PaymentScheduleInfoR\bschedule\"_\n" +
+	"\x06Result\x12\x06\n" +
+	"\x02OK\x10\x00\x12\n" +
+	"\n" +
+	"\x06DENIED\x10\x01\x12\x14\n" +
+	"\x10INVALID_SCHEDULE\x10\x02\x12\x17\n" +
+	"\x13INVALID_DESTINATION\x10\x03\x12\x12\n" +
+	"\x0eALREADY_EXISTS\x10\x04\"\xd1\x01\n" +
+	"\x1cCancelPaymentScheduleRequest\x124\n" +
+	"\x05owner\x18\x01 \x01(\v2\x1e.ocp.common.v1.SolanaAccountIdR\x05owner\x12C\n" +
+	"\vschedule_id\x18\x02 \x01(\v2\".ocp.paymentschedule.v1.ScheduleIdR\n" +
+	"scheduleId\x126\n" +
+	"\tsignature\x18\x03 \x01(\v2\x18.ocp.common.v1.SignatureR\tsignature\"\xef\x01\n" +
+	"\x1dCancelPaymentScheduleResponse\x12T\n" +
+	"\x06result\x18\x01 \x01(\x0e2<.ocp.paymentschedule.v1.CancelPaymentScheduleResponse.ResultR\x06result\x12G\n" +
+	"\bschedule\x18\x02 \x01(\v2+.ocp.paymentschedule.v1.PaymentScheduleInfoR\bschedule\"/\n" +
+	"\x06Result\x12\x06\n" +
+	"\x02OK\x10\x00\x12\r\n" +
+	"\tNOT_FOUND\x10\x01\x12\x0e\n" +
+	"\n" +
+	"NOT_ACTIVE\x10\x02\"\x8a\x01\n" +
+	"\x1aGetPaymentSchedulesRequest\x124\n" +
+	"\x05owner\x18\x01 \x01(\v2\x1e.ocp.common.v1.SolanaAccountIdR\x05owner\x126\n" +
+	"\tsignature\x18\x02 \x01(\v2\x18.ocp.common.v1.SignatureR\tsignature\"\xdd\x01\n" +
+	"\x1bGetPaymentSchedulesResponse\x12R\n" +
+	"\x06result\x18\x01 \x01(\x0e2:.ocp.paymentschedule.v1.GetPaymentSchedulesResponse.ResultR\x06result\x12I\n" +
+	"\tschedules\x18\x02 \x03(\v2+.ocp.paymentschedule.v1.PaymentScheduleInfoR\tschedules\"\x1f\n" +
+	"\x06Result\x12\x06\n" +
+	"\x02OK\x10\x00\x12\r\n" +
+	"\tNOT_FOUND\x10\x01\"\x93\x01\n" +
+	"#PollScheduledPaymentMessagesRequest\x124\n" +
+	"\x05owner\x18\x01 \x01(\v2\x1e.ocp.common.v1.SolanaAccountIdR\x05owner\x126\n" +
+	"\tsignature\x18\x02 \x01(\v2\x18.ocp.common.v1.SignatureR\tsignature\"s\n" +
+	"$PollScheduledPaymentMessagesResponse\x12K\n" +
+	"\bmessages\x18\x01 \x03(\v2/.ocp.paymentschedule.v1.ScheduledPaymentMessageR\bmessages\"\xd0\x01\n" +
+	"\"AckScheduledPaymentMessagesRequest\x124\n" +
+	"\x05owner\x18\x01 \x01(\v2\x1e.ocp.common.v1.SolanaAccountIdR\x05owner\x12<\n" +
+	"\vmessage_ids\x18\x02 \x03(\v2\x1b.ocp.messaging.v1.MessageIdR\n" +
+	"messageIds\x126\n" +
+	"\tsignature\x18\x03 \x01(\v2\x18.ocp.common.v1.SignatureR\tsignature\"%\n" +
+	"#AckScheduledPaymentMessagesResponse\"\"\n" +
+	"\n" +
+	"ScheduleId\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\fR\x05value\"\xeb\x05\n" +
+	"\x13PaymentScheduleInfo\x12C\n" +
+	"\vschedule_id\x18\x01 \x01(\v2\".ocp.paymentschedule.v1.ScheduleIdR\n" +
+	"scheduleId\x12K\n" +
+	"\x11destination_owner\x18\x02 \x01(\v2\x1e.ocp.common.v1.SolanaAccountIdR\x10destinationOwner\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12#\n" +
+	"\rnative_amount\x18\x04 \x01(\x01R\fnativeAmount\x125\n" +
+	"\x17max_total_native_amount\x18\x05 \x01(\x01R\x14maxTotalNativeAmount\x125\n" +
+	"\binterval\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\binterval\x12!\n" +
+	"\fmax_payments\x18\a \x01(\rR\vmaxPayments\x12B\n" +
+	"\x0fnext_payment_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\rnextPaymentAt\x12#\n" +
+	"\rpayments_made\x18\t \x01(\rR\fpaymentsMade\x127\n" +
+	"\x18total_native_amount_paid\x18\n" +
+	" \x01(\x01R\x15totalNativeAmountPaid\x12G\n" +
+	"\x05state\x18\v \x01(\x0e21.ocp.paymentschedule.v1.PaymentScheduleInfo.StateR\x05state\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"J\n" +
+	"\x05State\x12\v\n" +
+	"\aUNKNOWN\x10\x00\x12\n" +
+	"\n" +
+	"\x06ACTIVE\x10\x01\x12\r\n" +
+	"\tCOMPLETED\x10\x02\x12\r\n" +
+	"\tCANCELLED\x10\x03\x12\n" +
+	"\n" +
+	"\x06FAILED\x10\x04\"\xc1\x03\n" +
+	"\x17ScheduledPaymentMessage\x12+\n" +
+	"\x02id\x18\x01 \x01(\v2\x1b.ocp.messaging.v1.MessageIdR\x02id\x12C\n" +
+	"\vschedule_id\x18\x02 \x01(\v2\".ocp.paymentschedule.v1.ScheduleIdR\n" +
+	"scheduleId\x12#\n" +
+	"\rpayment_index\x18\x03 \x01(\rR\fpaymentIndex\x124\n" +
+	"\tintent_id\x18\x04 \x01(\v2\x17.ocp.common.v1.IntentIdR\bintentId\x12H\n" +
+	"\x04kind\x18\x05 \x01(\x0e24.ocp.paymentschedule.v1.ScheduledPaymentMessage.KindR\x04kind\x12%\n" +
+	"\x0efailure_reason\x18\x06 \x01(\tR\rfailureReason\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"-\n" +
+	"\x04Kind\x12\v\n" +
+	"\aUNKNOWN\x10\x00\x12\f\n" +
+	"\bEXECUTED\x10\x01\x12\n" +
+	"\n" +
+	"\x06FAILED\x10\x022\xd4\x05\n" +
+	"\x0fPaymentSchedule\x12\x84\x01\n" +
+	"\x15CreatePaymentSchedule\x124.ocp.paymentschedule.v1.CreatePaymentScheduleRequest\x1a5.ocp.paymentschedule.v1.CreatePaymentScheduleResponse\x12\x84\x01\n" +
+	"\x15CancelPaymentSchedule\x124.ocp.paymentschedule.v1.CancelPaymentScheduleRequest\x1a5.ocp.paymentschedule.v1.CancelPaymentScheduleResponse\x12~\n" +
+	"\x13GetPaymentSchedules\x122.ocp.paymentschedule.v1.GetPaymentSchedulesRequest\x1a3.ocp.paymentschedule.v1.GetPaymentSchedulesResponse\x12\x99\x01\n" +
+	"\x1cPollScheduledPaymentMessages\x12;.ocp.paymentschedule.v1.PollScheduledPaymentMessagesRequest\x1a<.ocp.paymentschedule.v1.PollScheduledPaymentMessagesResponse\x12\x96\x01\n" +
+	"\x1bAckScheduledPaymentMessages\x12:.ocp.paymentschedule.v1.AckScheduledPaymentMessagesRequest\x1a;.ocp.paymentschedule.v1.AckScheduledPaymentMessagesResponseB\x13Z\x11.;paymentscheduleb\x06proto3"
+
+var (
+	file_payment_schedule_service_proto_rawDescOnce sync.Once
+	file_payment_schedule_service_proto_rawDescData []byte
+)
+
+func file_payment_schedule_service_proto_rawDescGZIP() []byte {
+	file_payment_schedule_service_proto_rawDescOnce.Do(func() {
+		file_payment_schedule_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_payment_schedule_service_proto_rawDesc), len(file_payment_schedule_service_proto_rawDesc)))
+	})
+	return file_payment_schedule_service_proto_rawDescData
+}
+
+var file_payment_schedule_service_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_payment_schedule_service_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_payment_schedule_service_proto_goTypes = []any{
+	(CreatePaymentScheduleResponse_Result)(0),    // 0: ocp.paymentschedule.v1.CreatePaymentScheduleResponse.Result
+	(CancelPaymentScheduleResponse_Result)(0),    // 1: ocp.paymentschedule.v1.CancelPaymentScheduleResponse.Result
+	(GetPaymentSchedulesResponse_Result)(0),      // 2: ocp.paymentschedule.v1.GetPaymentSchedulesResponse.Result
+	(PaymentScheduleInfo_State)(0),               // 3: ocp.paymentschedule.v1.PaymentScheduleInfo.State
+	(ScheduledPaymentMessage_Kind)(0),            // 4: ocp.paymentschedule.v1.ScheduledPaymentMessage.Kind
+	(*CreatePaymentScheduleRequest)(nil),         // 5: ocp.paymentschedule.v1.CreatePaymentScheduleRequest
+	(*CreatePaymentScheduleResponse)(nil),        // 6: ocp.paymentschedule.v1.CreatePaymentScheduleResponse
+	(*CancelPaymentScheduleRequest)(nil),         // 7: ocp.paymentschedule.v1.CancelPaymentScheduleRequest
+	(*CancelPaymentScheduleResponse)(nil),        // 8: ocp.paymentschedule.v1.CancelPaymentScheduleResponse
+	(*GetPaymentSchedulesRequest)(nil),           // 9: ocp.paymentschedule.v1.GetPaymentSchedulesRequest
+	(*GetPaymentSchedulesResponse)(nil),          // 10: ocp.paymentschedule.v1.GetPaymentSchedulesResponse
+	(*PollScheduledPaymentMessagesRequest)(nil),  // 11: ocp.paymentschedule.v1.PollScheduledPaymentMessagesRequest
+	(*PollScheduledPaymentMessagesResponse)(nil), // 12: ocp.paymentschedule.v1.PollScheduledPaymentMessagesResponse
+	(*AckScheduledPaymentMessagesRequest)(nil),   // 13: ocp.paymentschedule.v1.AckScheduledPaymentMessagesRequest
+	(*AckScheduledPaymentMessagesResponse)(nil),  // 14: ocp.paymentschedule.v1.AckScheduledPaymentMessagesResponse
+	(*ScheduleId)(nil),                           // 15: ocp.paymentschedule.v1.ScheduleId
+	(*PaymentScheduleInfo)(nil),                  // 16: ocp.paymentschedule.v1.PaymentScheduleInfo
+	(*ScheduledPaymentMessage)(nil),              // 17: ocp.paymentschedule.v1.ScheduledPaymentMessage
+	(*v1.SolanaAccountId)(nil),                   // 18: ocp.common.v1.SolanaAccountId
+	(*timestamppb.Timestamp)(nil),                // 19: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),                  // 20: google.protobuf.Duration
+	(*v1.Signature)(nil),                         // 21: ocp.common.v1.Signature
+	(*v11.MessageId)(nil),                        // 22: ocp.messaging.v1.MessageId
+	(*v1.IntentId)(nil),                          // 23: ocp.common.v1.IntentId
+}
+var file_payment_schedule_service_proto_depIdxs = []int32{
+	18, // 0: ocp.paymentschedule.v1.CreatePaymentScheduleRequest.owner:type_name -> ocp.common.v1.SolanaAccountId
+	15, // 1: ocp.paymentschedule.v1.CreatePaymentScheduleRequest.schedule_id:type_name -> ocp.paymentschedule.v1.ScheduleId
+	18, // 2: ocp.paymentschedule.v1.CreatePaymentScheduleRequest.destination_owner:type_name -> ocp.common.v1.SolanaAccountId
+	19, // 3: ocp.paymentschedule.v1.CreatePaymentScheduleRequest.first_payment_at:type_name -> google.protobuf.Timestamp
+	20, // 4: ocp.paymentschedule.v1.CreatePaymentScheduleRequest.interval:type_name -> google.protobuf.Duration
+	21, // 5: ocp.paymentschedule.v1.CreatePaymentScheduleRequest.signature:type_name -> ocp.common.v1.Signature
+	0,  // 6: ocp.paymentschedule.v1.CreatePaymentScheduleResponse.result:type_name -> ocp.paymentschedule.v1.CreatePaymentScheduleResponse.Result
+	16, // 7: ocp.paymentschedule.v1.CreatePaymentScheduleResponse.schedule:type_name -> ocp.paymentschedule.v1.PaymentScheduleInfo
+	18, // 8: ocp.paymentschedule.v1.CancelPaymentScheduleRequest.owner:type_name -> ocp.common.v1.SolanaAccountId
+	15, // 9: ocp.paymentschedule.v1.CancelPaymentScheduleRequest.schedule_id:type_name -> ocp.paymentschedule.v1.ScheduleId
+	21, // 10: ocp.paymentschedule.v1.CancelPaymentScheduleRequest.signature:type_name -> ocp.common.v1.Signature
+	1,  // 11: ocp.paymentschedule.v1.CancelPaymentScheduleResponse.result:type_name -> ocp.paymentschedule.v1.CancelPaymentScheduleResponse.Result
+	16, // 12: ocp.paymentschedule.v1.CancelPaymentScheduleResponse.schedule:type_name -> ocp.paymentschedule.v1.PaymentScheduleInfo
+	18, // 13: ocp.paymentschedule.v1.GetPaymentSchedulesRequest.owner:type_name -> ocp.common.v1.SolanaAccountId
+	21, // 14: ocp.paymentschedule.v1.GetPaymentSchedulesRequest.signature:type_name -> ocp.common.v1.Signature
+	2,  // 15: ocp.paymentschedule.v1.GetPaymentSchedulesResponse.result:type_name -> ocp.paymentschedule.v1.GetPaymentSchedulesResponse.Result
+	16, // 16: ocp.paymentschedule.v1.GetPaymentSchedulesResponse.schedules:type_name -> ocp.paymentschedule.v1.PaymentScheduleInfo
+	18, // 17: ocp.paymentschedule.v1.PollScheduledPaymentMessagesRequest.owner:type_name -> ocp.common.v1.SolanaAccountId
+	21, // 18: ocp.paymentschedule.v1.PollScheduledPaymentMessagesRequest.signature:type_name -> ocp.common.v1.Signature
+	17, // 19: ocp.paymentschedule.v1.PollScheduledPaymentMessagesResponse.messages:type_name -> ocp.paymentschedule.v1.ScheduledPaymentMessage
+	18, // 20: ocp.paymentschedule.v1.AckScheduledPaymentMessagesRequest.owner:type_name -> ocp.common.v1.SolanaAccountId
+	22, // 21: ocp.paymentschedule.v1.AckScheduledPaymentMessagesRequest.message_ids:type_name -> ocp.messaging.v1.MessageId
+	21, // 22: ocp.paymentschedule.v1.AckScheduledPaymentMessagesRequest.signature:type_name -> ocp.common.v1.Signature
+	15, // 23: ocp.paymentschedule.v1.PaymentScheduleInfo.schedule_id:type_name -> ocp.paymentschedule.v1.ScheduleId
+	18, // 24: ocp.paymentschedule.v1.PaymentScheduleInfo.destination_owner:type_name -> ocp.common.v1.SolanaAccountId
+	20, // 25: ocp.paymentschedule.v1.PaymentScheduleInfo.interval:type_name -> google.protobuf.Duration
+	19, // 26: ocp.paymentschedule.v1.PaymentScheduleInfo.next_payment_at:type_name -> google.protobuf.Timestamp
+	3,  // 27: ocp.paymentschedule.v1.PaymentScheduleInfo.state:type_name -> ocp.paymentschedule.v1.PaymentScheduleInfo.State
+	19, // 28: ocp.paymentschedule.v1.PaymentScheduleInfo.created_at:type_name -> google.protobuf.Timestamp
+	22, // 29: ocp.paymentschedule.v1.ScheduledPaymentMessage.id:type_name -> ocp.messaging.v1.MessageId
+	15, // 30: ocp.paymentschedule.v1.ScheduledPaymentMessage.schedule_id:type_name -> ocp.paymentschedule.v1.ScheduleId
+	23, // 31: ocp.paymentschedule.v1.ScheduledPaymentMessage.intent_id:type_name -> ocp.common.v1.IntentId
+	4,  // 32: ocp.paymentschedule.v1.ScheduledPaymentMessage.kind:type_name -> ocp.paymentschedule.v1.ScheduledPaymentMessage.Kind
+	19, // 33: ocp.paymentschedule.v1.ScheduledPaymentMessage.created_at:type_name -> google.protobuf.Timestamp
+	5,  // 34: ocp.paymentschedule.v1.PaymentSchedule.CreatePaymentSchedule:input_type -> ocp.paymentschedule.v1.CreatePaymentScheduleRequest
+	7,  // 35: ocp.paymentschedule.v1.PaymentSchedule.CancelPaymentSchedule:input_type -> ocp.paymentschedule.v1.CancelPaymentScheduleRequest
+	9,  // 36: ocp.paymentschedule.v1.PaymentSchedule.GetPaymentSchedules:input_type -> ocp.paymentschedule.v1.GetPaymentSchedulesRequest
+	11, // 37: ocp.paymentschedule.v1.PaymentSchedule.PollScheduledPaymentMessages:input_type -> ocp.paymentschedule.v1.PollScheduledPaymentMessagesRequest
+	13, // 38: ocp.paymentschedule.v1.PaymentSchedule.AckScheduledPaymentMessages:input_type -> ocp.paymentschedule.v1.AckScheduledPaymentMessagesRequest
+	6,  // 39: ocp.paymentschedule.v1.PaymentSchedule.CreatePaymentSchedule:output_type -> ocp.paymentschedule.v1.CreatePaymentScheduleResponse
+	8,  // 40: ocp.paymentschedule.v1.PaymentSchedule.CancelPaymentSchedule:output_type -> ocp.paymentschedule.v1.CancelPaymentScheduleResponse
+	10, // 41: ocp.paymentschedule.v1.PaymentSchedule.GetPaymentSchedules:output_type -> ocp.paymentschedule.v1.GetPaymentSchedulesResponse
+	12, // 42: ocp.paymentschedule.v1.PaymentSchedule.PollScheduledPaymentMessages:output_type -> ocp.paymentschedule.v1.PollScheduledPaymentMessagesResponse
+	14, // 43: ocp.paymentschedule.v1.PaymentSchedule.AckScheduledPaymentMessages:output_type -> ocp.paymentschedule.v1.AckScheduledPaymentMessagesResponse
+	39, // [39:44] is the sub-list for method output_type
+	34, // [34:39] is the sub-list for method input_type
+	34, // [34:34] is the sub-list for extension type_name
+	34, // [34:34] is the sub-list for extension extendee
+	0,  // [0:34] is the sub-list for field type_name
+}
+
+func init() { file_payment_schedule_service_proto_init() }
+func file_payment_schedule_service_proto_init() {
+	if File_payment_schedule_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_payment_schedule_service_proto_rawDesc), len(file_payment_schedule_service_proto_rawDesc)),
+			NumEnums:      5,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_payment_schedule_service_proto_goTypes,
+		DependencyIndexes: file_payment_schedule_service_proto_depIdxs,
+		EnumInfos:         file_payment_schedule_service_proto_enumTypes,
+		MessageInfos:      file_payment_schedule_service_proto_msgTypes,
+	}.Build()
+	File_payment_schedule_service_proto = out.File
+	file_payment_schedule_service_proto_goTypes = nil
+	file_payment_schedule_service_proto_depIdxs = nil
+}
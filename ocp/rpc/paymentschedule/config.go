@@ -0,0 +1,59 @@
+package paymentschedule
+
+import (
+	"time"
+
+	"github.com/code-payments/ocp-server/config"
+	"github.com/code-payments/ocp-server/config/env"
+	"github.com/code-payments/ocp-server/config/memory"
+	"github.com/code-payments/ocp-server/config/wrapper"
+)
+
+const (
+	envConfigPrefix = "PAYMENT_SCHEDULE_SERVICE_"
+
+	MinIntervalConfigEnvName = envConfigPrefix + "MIN_INTERVAL"
+	defaultMinInterval       = time.Hour
+
+	MaxPaymentsConfigEnvName = envConfigPrefix + "MAX_PAYMENTS"
+	defaultMaxPayments       = 1000
+
+	MaxFirstPaymentDelayConfigEnvName = envConfigPrefix + "MAX_FIRST_PAYMENT_DELAY"
+	defaultMaxFirstPaymentDelay       = 365 * 24 * time.Hour
+)
+
+type conf struct {
+	minInterval          config.Duration
+	maxPayments          config.Uint64
+	maxFirstPaymentDelay config.Duration
+}
+
+// ConfigProvider defines how config values are pulled
+type ConfigProvider func() *conf
+
+// WithEnvConfigs returns configuration pulled from environment variables
+func WithEnvConfigs() ConfigProvider {
+	return func() *conf {
+		return &conf{
+			minInterval:          env.NewDurationConfig(MinIntervalConfigEnvName, defaultMinInterval),
+			maxPayments:          env.NewUint64Config(MaxPaymentsConfigEnvName, defaultMaxPayments),
+			maxFirstPaymentDelay: env.NewDurationConfig(MaxFirstPaymentDelayConfigEnvName, defaultMaxFirstPaymentDelay),
+		}
+	}
+}
+
+type testOverrides struct {
+	minInterval          time.Duration
+	maxPayments          uint64
+	maxFirstPaymentDelay time.Duration
+}
+
+func withManualTestOverrides(overrides *testOverrides) ConfigProvider {
+	return func() *conf {
+		return &conf{
+			minInterval:          wrapper.NewDurationConfig(memory.NewConfig(overrides.minInterval), defaultMinInterval),
+			maxPayments:          wrapper.NewUint64Config(memory.NewConfig(overrides.maxPayments), defaultMaxPayments),
+			maxFirstPaymentDelay: wrapper.NewDurationConfig(memory.NewConfig(overrides.maxFirstPaymentDelay), defaultMaxFirstPaymentDelay),
+		}
+	}
+}
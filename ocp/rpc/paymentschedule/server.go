@@ -0,0 +1,455 @@
+package paymentschedule
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mr-tron/base58"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	commonpb "github.com/code-payments/ocp-protobuf-api/generated/go/common/v1"
+
+	currency_lib "github.com/code-payments/ocp-server/currency"
+	"github.com/code-payments/ocp-server/grpc/client"
+	auth_util "github.com/code-payments/ocp-server/ocp/auth"
+	"github.com/code-payments/ocp-server/ocp/common"
+	currency_util "github.com/code-payments/ocp-server/ocp/currency"
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/account"
+	"github.com/code-payments/ocp-server/ocp/data/paymentschedule"
+	"github.com/code-payments/ocp-server/ocp/data/vault"
+	paymentschedulepb "github.com/code-payments/ocp-server/ocp/rpc/paymentschedule/api/gen"
+)
+
+// The maximum number of messages returned by a single poll
+const maxPolledMessages = 256
+
+type server struct {
+	log  *zap.Logger
+	conf *conf
+	data ocp_data.Provider
+	auth *auth_util.RPCSignatureVerifier
+
+	paymentschedulepb.UnimplementedPaymentScheduleServer
+}
+
+func NewPaymentScheduleServer(log *zap.Logger, data ocp_data.Provider, configProvider ConfigProvider) paymentschedulepb.PaymentScheduleServer {
+	return &server{
+		log:  log,
+		conf: configProvider(),
+		data: data,
+		auth: auth_util.NewRPCSignatureVerifier(log, data),
+	}
+}
+
+func (s *server) CreatePaymentSchedule(ctx context.Context, req *paymentschedulepb.CreatePaymentScheduleRequest) (*paymentschedulepb.CreatePaymentScheduleResponse, error) {
+	log := s.log.With(zap.String("method", "CreatePaymentSchedule"))
+	log = client.InjectLoggingMetadata(ctx, log)
+
+	owner, err := common.NewAccountFromProto(req.Owner)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("invalid owner account")
+		return nil, status.Error(codes.Internal, "")
+	}
+	log = log.With(zap.String("owner_account", owner.PublicKey().ToBase58()))
+
+	destinationOwner, err := common.NewAccountFromProto(req.DestinationOwner)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("invalid destination owner account")
+		return nil, status.Error(codes.Internal, "")
+	}
+	log = log.With(zap.String("destination_owner_account", destinationOwner.PublicKey().ToBase58()))
+
+	signature := req.Signature
+	req.Signature = nil
+	if err := s.auth.Authenticate(ctx, owner, req, signature); err != nil {
+		return nil, err
+	}
+
+	if len(req.ScheduleId.GetValue()) != 32 {
+		return &paymentschedulepb.CreatePaymentScheduleResponse{
+			Result: paymentschedulepb.CreatePaymentScheduleResponse_INVALID_SCHEDULE,
+		}, nil
+	}
+	scheduleId := base58.Encode(req.ScheduleId.Value)
+	log = log.With(zap.String("schedule", scheduleId))
+
+	existing, err := s.data.GetPaymentSchedule(ctx, scheduleId)
+	switch err {
+	case nil:
+		if existing.Owner != owner.PublicKey().ToBase58() {
+			return &paymentschedulepb.CreatePaymentScheduleResponse{
+				Result: paymentschedulepb.CreatePaymentScheduleResponse_ALREADY_EXISTS,
+			}, nil
+		}
+		return &paymentschedulepb.CreatePaymentScheduleResponse{
+			Result:   paymentschedulepb.CreatePaymentScheduleResponse_OK,
+			Schedule: toProtoPaymentSchedule(existing),
+		}, nil
+	case paymentschedule.ErrNotFound:
+	default:
+		log.With(zap.Error(err)).Warn("failure getting existing payment schedule")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	// Payments are made on the owner's behalf, so the server must be able to
+	// sign for them. This limits schedules to server-custodied owners until
+	// owners can pre-sign future payments.
+	_, err = s.data.GetKey(ctx, owner.PublicKey().ToBase58())
+	if err == vault.ErrKeyNotFound {
+		return &paymentschedulepb.CreatePaymentScheduleResponse{
+			Result: paymentschedulepb.CreatePaymentScheduleResponse_DENIED,
+		}, nil
+	} else if err != nil {
+		log.With(zap.Error(err)).Warn("failure getting owner key")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	nextPaymentAt, ok, err := s.validateSchedule(ctx, req)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure validating payment schedule")
+		return nil, status.Error(codes.Internal, "")
+	} else if !ok {
+		return &paymentschedulepb.CreatePaymentScheduleResponse{
+			Result: paymentschedulepb.CreatePaymentScheduleResponse_INVALID_SCHEDULE,
+		}, nil
+	}
+
+	if owner.PublicKey().ToBase58() == destinationOwner.PublicKey().ToBase58() {
+		return &paymentschedulepb.CreatePaymentScheduleResponse{
+			Result: paymentschedulepb.CreatePaymentScheduleResponse_INVALID_DESTINATION,
+		}, nil
+	}
+
+	// Payments are made to the destination owner's primary account
+	primaryAccountInfoRecordsByMint, err := s.data.GetLatestAccountInfoByOwnerAddressAndType(ctx, destinationOwner.PublicKey().ToBase58(), commonpb.AccountType_PRIMARY)
+	if err == account.ErrAccountInfoNotFound {
+		return &paymentschedulepb.CreatePaymentScheduleResponse{
+			Result: paymentschedulepb.CreatePaymentScheduleResponse_INVALID_DESTINATION,
+		}, nil
+	} else if err != nil {
+		log.With(zap.Error(err)).Warn("failure getting destination primary account info record")
+		return nil, status.Error(codes.Internal, "")
+	}
+	coreMintPrimaryAccountInfoRecord, ok := primaryAccountInfoRecordsByMint[common.CoreMintAccount.PublicKey().ToBase58()]
+	if !ok {
+		return &paymentschedulepb.CreatePaymentScheduleResponse{
+			Result: paymentschedulepb.CreatePaymentScheduleResponse_INVALID_DESTINATION,
+		}, nil
+	}
+
+	record := &paymentschedule.Record{
+		ScheduleId: scheduleId,
+
+		Owner: owner.PublicKey().ToBase58(),
+		Mint:  common.CoreMintAccount.PublicKey().ToBase58(),
+
+		DestinationOwner:        destinationOwner.PublicKey().ToBase58(),
+		DestinationTokenAccount: coreMintPrimaryAccountInfoRecord.TokenAccount,
+
+		ExchangeCurrency: currency_lib.Code(strings.ToLower(req.Currency)),
+		NativeAmount:     req.NativeAmount,
+
+		MaxTotalNativeAmount: req.MaxTotalNativeAmount,
+
+		Interval: req.Interval.AsDuration(),
+
+		MaxPayments: uint64(req.MaxPayments),
+
+		NextPaymentAt: nextPaymentAt,
+
+		State: paymentschedule.StateActive,
+
+		CreatedAt: time.Now(),
+	}
+	if err := record.Validate(); err != nil {
+		log.With(zap.Error(err)).Info("invalid payment schedule")
+		return &paymentschedulepb.CreatePaymentScheduleResponse{
+			Result: paymentschedulepb.CreatePaymentScheduleResponse_INVALID_SCHEDULE,
+		}, nil
+	}
+
+	err = s.data.PutPaymentSchedule(ctx, record)
+	if err == paymentschedule.ErrAlreadyExists {
+		return &paymentschedulepb.CreatePaymentScheduleResponse{
+			Result: paymentschedulepb.CreatePaymentScheduleResponse_ALREADY_EXISTS,
+		}, nil
+	} else if err != nil {
+		log.With(zap.Error(err)).Warn("failure saving payment schedule")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return &paymentschedulepb.CreatePaymentScheduleResponse{
+		Result:   paymentschedulepb.CreatePaymentScheduleResponse_OK,
+		Schedule: toProtoPaymentSchedule(record),
+	}, nil
+}
+
+func (s *server) CancelPaymentSchedule(ctx context.Context, req *paymentschedulepb.CancelPaymentScheduleRequest) (*paymentschedulepb.CancelPaymentScheduleResponse, error) {
+	log := s.log.With(zap.String("method", "CancelPaymentSchedule"))
+	log = client.InjectLoggingMetadata(ctx, log)
+
+	owner, err := common.NewAccountFromProto(req.Owner)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("invalid owner account")
+		return nil, status.Error(codes.Internal, "")
+	}
+	log = log.With(zap.String("owner_account", owner.PublicKey().ToBase58()))
+
+	signature := req.Signature
+	req.Signature = nil
+	if err := s.auth.Authenticate(ctx, owner, req, signature); err != nil {
+		return nil, err
+	}
+
+	scheduleId := base58.Encode(req.ScheduleId.GetValue())
+	log = log.With(zap.String("schedule", scheduleId))
+
+	record, err := s.data.GetPaymentSchedule(ctx, scheduleId)
+	if err == paymentschedule.ErrNotFound {
+		return &paymentschedulepb.CancelPaymentScheduleResponse{
+			Result: paymentschedulepb.CancelPaymentScheduleResponse_NOT_FOUND,
+		}, nil
+	} else if err != nil {
+		log.With(zap.Error(err)).Warn("failure getting payment schedule")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	if record.Owner != owner.PublicKey().ToBase58() {
+		return &paymentschedulepb.CancelPaymentScheduleResponse{
+			Result: paymentschedulepb.CancelPaymentScheduleResponse_NOT_FOUND,
+		}, nil
+	}
+
+	if record.State != paymentschedule.StateActive {
+		return &paymentschedulepb.CancelPaymentScheduleResponse{
+			Result:   paymentschedulepb.CancelPaymentScheduleResponse_NOT_ACTIVE,
+			Schedule: toProtoPaymentSchedule(record),
+		}, nil
+	}
+
+	// A stale version means the worker made a payment concurrently, and the
+	// client can safely retry
+	record.State = paymentschedule.StateCancelled
+	err = s.data.UpdatePaymentSchedule(ctx, record)
+	if err == paymentschedule.ErrStaleVersion {
+		return nil, status.Error(codes.Aborted, "payment schedule was updated concurrently")
+	} else if err != nil {
+		log.With(zap.Error(err)).Warn("failure cancelling payment schedule")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return &paymentschedulepb.CancelPaymentScheduleResponse{
+		Result:   paymentschedulepb.CancelPaymentScheduleResponse_OK,
+		Schedule: toProtoPaymentSchedule(record),
+	}, nil
+}
+
+func (s *server) GetPaymentSchedules(ctx context.Context, req *paymentschedulepb.GetPaymentSchedulesRequest) (*paymentschedulepb.GetPaymentSchedulesResponse, error) {
+	log := s.log.With(zap.String("method", "GetPaymentSchedules"))
+	log = client.InjectLoggingMetadata(ctx, log)
+
+	owner, err := common.NewAccountFromProto(req.Owner)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("invalid owner account")
+		return nil, status.Error(codes.Internal, "")
+	}
+	log = log.With(zap.String("owner_account", owner.PublicKey().ToBase58()))
+
+	signature := req.Signature
+	req.Signature = nil
+	if err := s.auth.Authenticate(ctx, owner, req, signature); err != nil {
+		return nil, err
+	}
+
+	records, err := s.data.GetAllPaymentSchedulesByOwner(ctx, owner.PublicKey().ToBase58())
+	if err == paymentschedule.ErrNotFound {
+		return &paymentschedulepb.GetPaymentSchedulesResponse{
+			Result: paymentschedulepb.GetPaymentSchedulesResponse_NOT_FOUND,
+		}, nil
+	} else if err != nil {
+		log.With(zap.Error(err)).Warn("failure getting payment schedules")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	protoSchedules := make([]*paymentschedulepb.PaymentScheduleInfo, len(records))
+	for i, record := range records {
+		protoSchedules[i] = toProtoPaymentSchedule(record)
+	}
+
+	return &paymentschedulepb.GetPaymentSchedulesResponse{
+		Result:    paymentschedulepb.GetPaymentSchedulesResponse_OK,
+		Schedules: protoSchedules,
+	}, nil
+}
+
+func (s *server) PollScheduledPaymentMessages(ctx context.Context, req *paymentschedulepb.PollScheduledPaymentMessagesRequest) (*paymentschedulepb.PollScheduledPaymentMessagesResponse, error) {
+	log := s.log.With(zap.String("method", "PollScheduledPaymentMessages"))
+	log = client.InjectLoggingMetadata(ctx, log)
+
+	owner, err := common.NewAccountFromProto(req.Owner)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("invalid owner account")
+		return nil, status.Error(codes.Internal, "")
+	}
+	log = log.With(zap.String("owner_account", owner.PublicKey().ToBase58()))
+
+	signature := req.Signature
+	req.Signature = nil
+	if err := s.auth.Authenticate(ctx, owner, req, signature); err != nil {
+		return nil, err
+	}
+
+	records, err := s.data.GetMessages(ctx, paymentschedule.GetMessageBin(owner.PublicKey().ToBase58()))
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure getting undelivered messages")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	var messages []*paymentschedulepb.ScheduledPaymentMessage
+	for _, record := range records {
+		var message paymentschedulepb.ScheduledPaymentMessage
+		if err := proto.Unmarshal(record.Message, &message); err != nil {
+			log.With(zap.Error(err)).Warn("failure unmarshalling message bytes")
+			return nil, status.Error(codes.Internal, "")
+		}
+		messages = append(messages, &message)
+
+		// Owners need to ack messages before more are returned
+		if len(messages) >= maxPolledMessages {
+			break
+		}
+	}
+
+	return &paymentschedulepb.PollScheduledPaymentMessagesResponse{
+		Messages: messages,
+	}, nil
+}
+
+func (s *server) AckScheduledPaymentMessages(ctx context.Context, req *paymentschedulepb.AckScheduledPaymentMessagesRequest) (*paymentschedulepb.AckScheduledPaymentMessagesResponse, error) {
+	log := s.log.With(zap.String("method", "AckScheduledPaymentMessages"))
+	log = client.InjectLoggingMetadata(ctx, log)
+
+	owner, err := common.NewAccountFromProto(req.Owner)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("invalid owner account")
+		return nil, status.Error(codes.Internal, "")
+	}
+	log = log.With(zap.String("owner_account", owner.PublicKey().ToBase58()))
+
+	signature := req.Signature
+	req.Signature = nil
+	if err := s.auth.Authenticate(ctx, owner, req, signature); err != nil {
+		return nil, err
+	}
+
+	bin := paymentschedule.GetMessageBin(owner.PublicKey().ToBase58())
+	for _, protoMessageId := range req.MessageIds {
+		messageId, err := uuid.FromBytes(protoMessageId.Value)
+		if err != nil {
+			log.With(zap.Error(err)).Warn("invalid message id")
+			return nil, status.Error(codes.InvalidArgument, "invalid message id")
+		}
+
+		err = s.data.DeleteMessage(ctx, bin, messageId)
+		if err != nil {
+			log.With(zap.Error(err)).Warn("failure deleting message")
+			return nil, status.Error(codes.Internal, "")
+		}
+	}
+
+	return &paymentschedulepb.AckScheduledPaymentMessagesResponse{}, nil
+}
+
+// validateSchedule validates the schedule parameters against configured limits,
+// and returns when the first payment is due. Payments scheduled in the past are
+// due immediately.
+func (s *server) validateSchedule(ctx context.Context, req *paymentschedulepb.CreatePaymentScheduleRequest) (time.Time, bool, error) {
+	if _, ok := currency_util.SendLimits[currency_lib.Code(strings.ToLower(req.Currency))]; !ok {
+		return time.Time{}, false, nil
+	}
+
+	if req.NativeAmount <= 0 || req.MaxTotalNativeAmount < req.NativeAmount {
+		return time.Time{}, false, nil
+	}
+
+	if req.Interval != nil {
+		if err := req.Interval.CheckValid(); err != nil {
+			return time.Time{}, false, nil
+		}
+	}
+
+	interval := req.Interval.AsDuration()
+	switch {
+	case interval == 0:
+		if req.MaxPayments != 1 {
+			return time.Time{}, false, nil
+		}
+	case interval < s.conf.minInterval.Get(ctx):
+		return time.Time{}, false, nil
+	case req.MaxPayments == 0 || uint64(req.MaxPayments) > s.conf.maxPayments.Get(ctx):
+		return time.Time{}, false, nil
+	}
+
+	if req.FirstPaymentAt == nil {
+		return time.Time{}, false, nil
+	}
+	if err := req.FirstPaymentAt.CheckValid(); err != nil {
+		return time.Time{}, false, nil
+	}
+
+	now := time.Now()
+	firstPaymentAt := req.FirstPaymentAt.AsTime()
+	if firstPaymentAt.After(now.Add(s.conf.maxFirstPaymentDelay.Get(ctx))) {
+		return time.Time{}, false, nil
+	}
+	if firstPaymentAt.Before(now) {
+		firstPaymentAt = now
+	}
+
+	return firstPaymentAt, true, nil
+}
+
+func toProtoPaymentSchedule(record *paymentschedule.Record) *paymentschedulepb.PaymentScheduleInfo {
+	scheduleId, _ := base58.Decode(record.ScheduleId)
+	destinationOwner, _ := common.NewAccountFromPublicKeyString(record.DestinationOwner)
+
+	var interval *durationpb.Duration
+	if record.IsRecurring() {
+		interval = durationpb.New(record.Interval)
+	}
+
+	var state paymentschedulepb.PaymentScheduleInfo_State
+	switch record.State {
+	case paymentschedule.StateActive:
+		state = paymentschedulepb.PaymentScheduleInfo_ACTIVE
+	case paymentschedule.StateCompleted:
+		state = paymentschedulepb.PaymentScheduleInfo_COMPLETED
+	case paymentschedule.StateCancelled:
+		state = paymentschedulepb.PaymentScheduleInfo_CANCELLED
+	case paymentschedule.StateFailed:
+		state = paymentschedulepb.PaymentScheduleInfo_FAILED
+	}
+
+	return &paymentschedulepb.PaymentScheduleInfo{
+		ScheduleId:            &paymentschedulepb.ScheduleId{Value: scheduleId},
+		DestinationOwner:      destinationOwner.ToProto(),
+		Currency:              string(record.ExchangeCurrency),
+		NativeAmount:          record.NativeAmount,
+		MaxTotalNativeAmount:  record.MaxTotalNativeAmount,
+		Interval:              interval,
+		MaxPayments:           uint32(record.MaxPayments),
+		NextPaymentAt:         timestamppb.New(record.NextPaymentAt),
+		PaymentsMade:          uint32(record.PaymentsMade),
+		TotalNativeAmountPaid: record.TotalNativeAmountPaid,
+		State:                 state,
+		CreatedAt:             timestamppb.New(record.CreatedAt),
+	}
+}
@@ -0,0 +1,487 @@
+package paymentschedule
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/uuid"
+	"github.com/mr-tron/base58"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	commonpb "github.com/code-payments/ocp-protobuf-api/generated/go/common/v1"
+	messagingpb "github.com/code-payments/ocp-protobuf-api/generated/go/messaging/v1"
+
+	currency_lib "github.com/code-payments/ocp-server/currency"
+	"github.com/code-payments/ocp-server/ocp/common"
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/account"
+	"github.com/code-payments/ocp-server/ocp/data/messaging"
+	"github.com/code-payments/ocp-server/ocp/data/paymentschedule"
+	"github.com/code-payments/ocp-server/ocp/data/vault"
+	paymentschedulepb "github.com/code-payments/ocp-server/ocp/rpc/paymentschedule/api/gen"
+	"github.com/code-payments/ocp-server/testutil"
+)
+
+type testEnv struct {
+	ctx    context.Context
+	client paymentschedulepb.PaymentScheduleClient
+	data   ocp_data.Provider
+}
+
+func setup(t *testing.T) (env testEnv, cleanup func()) {
+	log := zaptest.NewLogger(t)
+
+	conn, serv, err := testutil.NewServer(log)
+	require.NoError(t, err)
+
+	env.ctx = context.Background()
+	env.client = paymentschedulepb.NewPaymentScheduleClient(conn)
+	env.data = ocp_data.NewTestDataProvider()
+	testutil.SetupRandomSubsidizer(t, env.data)
+
+	s := NewPaymentScheduleServer(log, env.data, withManualTestOverrides(&testOverrides{
+		minInterval:          time.Hour,
+		maxPayments:          10,
+		maxFirstPaymentDelay: 30 * 24 * time.Hour,
+	}))
+
+	serv.RegisterService(func(server *grpc.Server) {
+		paymentschedulepb.RegisterPaymentScheduleServer(server, s)
+	})
+
+	cleanup, err = serv.Serve()
+	require.NoError(t, err)
+	return env, cleanup
+}
+
+func TestCreatePaymentSchedule_HappyPath(t *testing.T) {
+	env, cleanup := setup(t)
+	defer cleanup()
+
+	owner := env.setupPayer(t)
+	destinationOwner := testutil.NewRandomAccount(t)
+	destination := env.setupPrimaryAccount(t, destinationOwner)
+
+	firstPaymentAt := time.Now().Add(24 * time.Hour)
+	req := env.newCreatePaymentScheduleRequest(t, owner, destinationOwner, 10, 100, 7*24*time.Hour, 5, firstPaymentAt)
+	resp, err := env.client.CreatePaymentSchedule(env.ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, paymentschedulepb.CreatePaymentScheduleResponse_OK, resp.Result)
+	assert.Equal(t, req.ScheduleId.Value, resp.Schedule.ScheduleId.Value)
+	assert.Equal(t, destinationOwner.PublicKey().ToBytes(), resp.Schedule.DestinationOwner.Value)
+	assert.Equal(t, "usd", resp.Schedule.Currency)
+	assert.EqualValues(t, 10, resp.Schedule.NativeAmount)
+	assert.EqualValues(t, 100, resp.Schedule.MaxTotalNativeAmount)
+	assert.Equal(t, 7*24*time.Hour, resp.Schedule.Interval.AsDuration())
+	assert.EqualValues(t, 5, resp.Schedule.MaxPayments)
+	assert.Equal(t, firstPaymentAt.Unix(), resp.Schedule.NextPaymentAt.AsTime().Unix())
+	assert.Equal(t, paymentschedulepb.PaymentScheduleInfo_ACTIVE, resp.Schedule.State)
+
+	record, err := env.data.GetPaymentSchedule(env.ctx, base58.Encode(req.ScheduleId.Value))
+	require.NoError(t, err)
+	assert.Equal(t, owner.PublicKey().ToBase58(), record.Owner)
+	assert.Equal(t, common.CoreMintAccount.PublicKey().ToBase58(), record.Mint)
+	assert.Equal(t, destinationOwner.PublicKey().ToBase58(), record.DestinationOwner)
+	assert.Equal(t, destination.PublicKey().ToBase58(), record.DestinationTokenAccount)
+	assert.Equal(t, currency_lib.USD, record.ExchangeCurrency)
+	assert.Equal(t, paymentschedule.StateActive, record.State)
+
+	// Creation is idempotent
+	resp, err = env.client.CreatePaymentSchedule(env.ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, paymentschedulepb.CreatePaymentScheduleResponse_OK, resp.Result)
+
+	// Schedule IDs can't be reused by other owners
+	otherOwner := env.setupPayer(t)
+	otherReq := env.newCreatePaymentScheduleRequest(t, otherOwner, destinationOwner, 10, 100, 7*24*time.Hour, 5, firstPaymentAt)
+	otherReq.ScheduleId = req.ScheduleId
+	otherReq.Signature = nil
+	otherReq.Signature = signRequest(t, otherOwner, otherReq)
+	resp, err = env.client.CreatePaymentSchedule(env.ctx, otherReq)
+	require.NoError(t, err)
+	assert.Equal(t, paymentschedulepb.CreatePaymentScheduleResponse_ALREADY_EXISTS, resp.Result)
+}
+
+func TestCreatePaymentSchedule_OneTimeInThePast(t *testing.T) {
+	env, cleanup := setup(t)
+	defer cleanup()
+
+	owner := env.setupPayer(t)
+	destinationOwner := testutil.NewRandomAccount(t)
+	env.setupPrimaryAccount(t, destinationOwner)
+
+	start := time.Now()
+	req := env.newCreatePaymentScheduleRequest(t, owner, destinationOwner, 10, 10, 0, 1, time.Now().Add(-time.Hour))
+	resp, err := env.client.CreatePaymentSchedule(env.ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, paymentschedulepb.CreatePaymentScheduleResponse_OK, resp.Result)
+	assert.Nil(t, resp.Schedule.Interval)
+	assert.False(t, resp.Schedule.NextPaymentAt.AsTime().Before(start.Truncate(time.Second)))
+}
+
+func TestCreatePaymentSchedule_Denied(t *testing.T) {
+	env, cleanup := setup(t)
+	defer cleanup()
+
+	owner := testutil.NewRandomAccount(t)
+	destinationOwner := testutil.NewRandomAccount(t)
+	env.setupPrimaryAccount(t, destinationOwner)
+
+	req := env.newCreatePaymentScheduleRequest(t, owner, destinationOwner, 10, 10, 0, 1, time.Now().Add(time.Hour))
+	resp, err := env.client.CreatePaymentSchedule(env.ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, paymentschedulepb.CreatePaymentScheduleResponse_DENIED, resp.Result)
+
+	_, err = env.data.GetPaymentSchedule(env.ctx, base58.Encode(req.ScheduleId.Value))
+	assert.Equal(t, paymentschedule.ErrNotFound, err)
+}
+
+func TestCreatePaymentSchedule_InvalidSchedule(t *testing.T) {
+	env, cleanup := setup(t)
+	defer cleanup()
+
+	owner := env.setupPayer(t)
+	destinationOwner := testutil.NewRandomAccount(t)
+	env.setupPrimaryAccount(t, destinationOwner)
+
+	for _, tc := range []struct {
+		name   string
+		modify func(req *paymentschedulepb.CreatePaymentScheduleRequest)
+	}{
+		{"invalid schedule id", func(req *paymentschedulepb.CreatePaymentScheduleRequest) {
+			req.ScheduleId.Value = req.ScheduleId.Value[:16]
+		}},
+		{"unsupported currency", func(req *paymentschedulepb.CreatePaymentScheduleRequest) { req.Currency = "xyz" }},
+		{"zero amount", func(req *paymentschedulepb.CreatePaymentScheduleRequest) { req.NativeAmount = 0 }},
+		{"amount exceeds cap", func(req *paymentschedulepb.CreatePaymentScheduleRequest) { req.MaxTotalNativeAmount = 5 }},
+		{"interval too short", func(req *paymentschedulepb.CreatePaymentScheduleRequest) {
+			req.Interval = durationpb.New(time.Minute)
+		}},
+		{"zero max payments", func(req *paymentschedulepb.CreatePaymentScheduleRequest) { req.MaxPayments = 0 }},
+		{"too many payments", func(req *paymentschedulepb.CreatePaymentScheduleRequest) { req.MaxPayments = 11 }},
+		{"one-time with multiple payments", func(req *paymentschedulepb.CreatePaymentScheduleRequest) { req.Interval = nil }},
+		{"missing first payment time", func(req *paymentschedulepb.CreatePaymentScheduleRequest) { req.FirstPaymentAt = nil }},
+		{"first payment too far in the future", func(req *paymentschedulepb.CreatePaymentScheduleRequest) {
+			req.FirstPaymentAt = timestamppb.New(time.Now().Add(31 * 24 * time.Hour))
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := env.newCreatePaymentScheduleRequest(t, owner, destinationOwner, 10, 100, 24*time.Hour, 5, time.Now().Add(time.Hour))
+			tc.modify(req)
+			req.Signature = nil
+			req.Signature = signRequest(t, owner, req)
+
+			resp, err := env.client.CreatePaymentSchedule(env.ctx, req)
+			require.NoError(t, err)
+			assert.Equal(t, paymentschedulepb.CreatePaymentScheduleResponse_INVALID_SCHEDULE, resp.Result)
+		})
+	}
+}
+
+func TestCreatePaymentSchedule_InvalidDestination(t *testing.T) {
+	env, cleanup := setup(t)
+	defer cleanup()
+
+	owner := env.setupPayer(t)
+
+	req := env.newCreatePaymentScheduleRequest(t, owner, testutil.NewRandomAccount(t), 10, 10, 0, 1, time.Now().Add(time.Hour))
+	resp, err := env.client.CreatePaymentSchedule(env.ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, paymentschedulepb.CreatePaymentScheduleResponse_INVALID_DESTINATION, resp.Result)
+
+	env.setupPrimaryAccount(t, owner)
+	req = env.newCreatePaymentScheduleRequest(t, owner, owner, 10, 10, 0, 1, time.Now().Add(time.Hour))
+	resp, err = env.client.CreatePaymentSchedule(env.ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, paymentschedulepb.CreatePaymentScheduleResponse_INVALID_DESTINATION, resp.Result)
+}
+
+func TestCancelPaymentSchedule(t *testing.T) {
+	env, cleanup := setup(t)
+	defer cleanup()
+
+	owner := env.setupPayer(t)
+	destinationOwner := testutil.NewRandomAccount(t)
+	env.setupPrimaryAccount(t, destinationOwner)
+
+	createReq := env.newCreatePaymentScheduleRequest(t, owner, destinationOwner, 10, 100, 24*time.Hour, 5, time.Now().Add(time.Hour))
+
+	resp, err := env.client.CancelPaymentSchedule(env.ctx, env.newCancelPaymentScheduleRequest(t, owner, createReq.ScheduleId))
+	require.NoError(t, err)
+	assert.Equal(t, paymentschedulepb.CancelPaymentScheduleResponse_NOT_FOUND, resp.Result)
+
+	createResp, err := env.client.CreatePaymentSchedule(env.ctx, createReq)
+	require.NoError(t, err)
+	require.Equal(t, paymentschedulepb.CreatePaymentScheduleResponse_OK, createResp.Result)
+
+	otherOwner := env.setupPayer(t)
+	resp, err = env.client.CancelPaymentSchedule(env.ctx, env.newCancelPaymentScheduleRequest(t, otherOwner, createReq.ScheduleId))
+	require.NoError(t, err)
+	assert.Equal(t, paymentschedulepb.CancelPaymentScheduleResponse_NOT_FOUND, resp.Result)
+
+	resp, err = env.client.CancelPaymentSchedule(env.ctx, env.newCancelPaymentScheduleRequest(t, owner, createReq.ScheduleId))
+	require.NoError(t, err)
+	assert.Equal(t, paymentschedulepb.CancelPaymentScheduleResponse_OK, resp.Result)
+	assert.Equal(t, paymentschedulepb.PaymentScheduleInfo_CANCELLED, resp.Schedule.State)
+
+	record, err := env.data.GetPaymentSchedule(env.ctx, base58.Encode(createReq.ScheduleId.Value))
+	require.NoError(t, err)
+	assert.Equal(t, paymentschedule.StateCancelled, record.State)
+
+	resp, err = env.client.CancelPaymentSchedule(env.ctx, env.newCancelPaymentScheduleRequest(t, owner, createReq.ScheduleId))
+	require.NoError(t, err)
+	assert.Equal(t, paymentschedulepb.CancelPaymentScheduleResponse_NOT_ACTIVE, resp.Result)
+	assert.Equal(t, paymentschedulepb.PaymentScheduleInfo_CANCELLED, resp.Schedule.State)
+}
+
+func TestGetPaymentSchedules(t *testing.T) {
+	env, cleanup := setup(t)
+	defer cleanup()
+
+	owner := env.setupPayer(t)
+	destinationOwner := testutil.NewRandomAccount(t)
+	env.setupPrimaryAccount(t, destinationOwner)
+
+	resp, err := env.client.GetPaymentSchedules(env.ctx, env.newGetPaymentSchedulesRequest(t, owner))
+	require.NoError(t, err)
+	assert.Equal(t, paymentschedulepb.GetPaymentSchedulesResponse_NOT_FOUND, resp.Result)
+
+	var expected [][]byte
+	for i := 0; i < 3; i++ {
+		req := env.newCreatePaymentScheduleRequest(t, owner, destinationOwner, 10, 100, 24*time.Hour, 5, time.Now().Add(time.Hour))
+		createResp, err := env.client.CreatePaymentSchedule(env.ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, paymentschedulepb.CreatePaymentScheduleResponse_OK, createResp.Result)
+		expected = append(expected, req.ScheduleId.Value)
+	}
+
+	resp, err = env.client.GetPaymentSchedules(env.ctx, env.newGetPaymentSchedulesRequest(t, owner))
+	require.NoError(t, err)
+	assert.Equal(t, paymentschedulepb.GetPaymentSchedulesResponse_OK, resp.Result)
+	require.Len(t, resp.Schedules, len(expected))
+	for i, schedule := range resp.Schedules {
+		assert.Equal(t, expected[i], schedule.ScheduleId.Value)
+	}
+}
+
+func TestPollAndAckScheduledPaymentMessages(t *testing.T) {
+	env, cleanup := setup(t)
+	defer cleanup()
+
+	owner := env.setupPayer(t)
+	otherOwner := env.setupPayer(t)
+
+	resp, err := env.client.PollScheduledPaymentMessages(env.ctx, env.newPollScheduledPaymentMessagesRequest(t, owner))
+	require.NoError(t, err)
+	assert.Empty(t, resp.Messages)
+
+	var expected []*paymentschedulepb.ScheduledPaymentMessage
+	for i := 0; i < 3; i++ {
+		expected = append(expected, env.saveScheduledPaymentMessage(t, owner, uint32(i)))
+	}
+	env.saveScheduledPaymentMessage(t, otherOwner, 0)
+
+	resp, err = env.client.PollScheduledPaymentMessages(env.ctx, env.newPollScheduledPaymentMessagesRequest(t, owner))
+	require.NoError(t, err)
+	require.Len(t, resp.Messages, len(expected))
+	for i, message := range resp.Messages {
+		assert.True(t, proto.Equal(expected[i], message))
+	}
+
+	ackResp, err := env.client.AckScheduledPaymentMessages(env.ctx, env.newAckScheduledPaymentMessagesRequest(t, owner, expected[0].Id, expected[2].Id))
+	require.NoError(t, err)
+	assert.NotNil(t, ackResp)
+
+	resp, err = env.client.PollScheduledPaymentMessages(env.ctx, env.newPollScheduledPaymentMessagesRequest(t, owner))
+	require.NoError(t, err)
+	require.Len(t, resp.Messages, 1)
+	assert.True(t, proto.Equal(expected[1], resp.Messages[0]))
+
+	// Acks are idempotent, and don't affect messages for other owners
+	_, err = env.client.AckScheduledPaymentMessages(env.ctx, env.newAckScheduledPaymentMessagesRequest(t, otherOwner, expected[0].Id, expected[1].Id))
+	require.NoError(t, err)
+
+	resp, err = env.client.PollScheduledPaymentMessages(env.ctx, env.newPollScheduledPaymentMessagesRequest(t, owner))
+	require.NoError(t, err)
+	require.Len(t, resp.Messages, 1)
+
+	resp, err = env.client.PollScheduledPaymentMessages(env.ctx, env.newPollScheduledPaymentMessagesRequest(t, otherOwner))
+	require.NoError(t, err)
+	require.Len(t, resp.Messages, 1)
+}
+
+func TestPaymentSchedule_UnauthenticatedRPC(t *testing.T) {
+	env, cleanup := setup(t)
+	defer cleanup()
+
+	owner := env.setupPayer(t)
+	maliciousAccount := testutil.NewRandomAccount(t)
+	destinationOwner := testutil.NewRandomAccount(t)
+	env.setupPrimaryAccount(t, destinationOwner)
+
+	createReq := env.newCreatePaymentScheduleRequest(t, owner, destinationOwner, 10, 10, 0, 1, time.Now().Add(time.Hour))
+	createReq.Signature = nil
+	createReq.Signature = signRequest(t, maliciousAccount, createReq)
+	_, err := env.client.CreatePaymentSchedule(env.ctx, createReq)
+	testutil.AssertStatusErrorWithCode(t, err, codes.Unauthenticated)
+
+	cancelReq := &paymentschedulepb.CancelPaymentScheduleRequest{
+		Owner:      owner.ToProto(),
+		ScheduleId: createReq.ScheduleId,
+	}
+	cancelReq.Signature = signRequest(t, maliciousAccount, cancelReq)
+	_, err = env.client.CancelPaymentSchedule(env.ctx, cancelReq)
+	testutil.AssertStatusErrorWithCode(t, err, codes.Unauthenticated)
+
+	getReq := &paymentschedulepb.GetPaymentSchedulesRequest{
+		Owner: owner.ToProto(),
+	}
+	getReq.Signature = signRequest(t, maliciousAccount, getReq)
+	_, err = env.client.GetPaymentSchedules(env.ctx, getReq)
+	testutil.AssertStatusErrorWithCode(t, err, codes.Unauthenticated)
+
+	pollReq := &paymentschedulepb.PollScheduledPaymentMessagesRequest{
+		Owner: owner.ToProto(),
+	}
+	pollReq.Signature = signRequest(t, maliciousAccount, pollReq)
+	_, err = env.client.PollScheduledPaymentMessages(env.ctx, pollReq)
+	testutil.AssertStatusErrorWithCode(t, err, codes.Unauthenticated)
+
+	ackReq := &paymentschedulepb.AckScheduledPaymentMessagesRequest{
+		Owner: owner.ToProto(),
+	}
+	ackReq.Signature = signRequest(t, maliciousAccount, ackReq)
+	_, err = env.client.AckScheduledPaymentMessages(env.ctx, ackReq)
+	testutil.AssertStatusErrorWithCode(t, err, codes.Unauthenticated)
+}
+
+// setupPayer creates an owner whose key is held by the server
+func (e *testEnv) setupPayer(t *testing.T) *common.Account {
+	vaultRecord, err := vault.CreateKey()
+	require.NoError(t, err)
+	require.NoError(t, e.data.SaveKey(e.ctx, vaultRecord))
+
+	owner, err := common.NewAccountFromPrivateKeyString(vaultRecord.PrivateKey)
+	require.NoError(t, err)
+	return owner
+}
+
+func (e *testEnv) setupPrimaryAccount(t *testing.T, owner *common.Account) *common.Account {
+	vmConfig, err := common.GetVmConfigForMint(e.ctx, e.data, common.CoreMintAccount)
+	require.NoError(t, err)
+
+	timelockAccounts, err := owner.GetTimelockAccounts(vmConfig)
+	require.NoError(t, err)
+
+	require.NoError(t, e.data.CreateAccountInfo(e.ctx, &account.Record{
+		OwnerAccount:     owner.PublicKey().ToBase58(),
+		AuthorityAccount: owner.PublicKey().ToBase58(),
+		TokenAccount:     timelockAccounts.Vault.PublicKey().ToBase58(),
+		MintAccount:      common.CoreMintAccount.PublicKey().ToBase58(),
+		AccountType:      commonpb.AccountType_PRIMARY,
+	}))
+	return timelockAccounts.Vault
+}
+
+func (e *testEnv) newCreatePaymentScheduleRequest(t *testing.T, owner, destinationOwner *common.Account, nativeAmount, maxTotalNativeAmount float64, interval time.Duration, maxPayments uint32, firstPaymentAt time.Time) *paymentschedulepb.CreatePaymentScheduleRequest {
+	scheduleId := make([]byte, 32)
+	rand.Read(scheduleId)
+
+	var protoInterval *durationpb.Duration
+	if interval > 0 {
+		protoInterval = durationpb.New(interval)
+	}
+
+	req := &paymentschedulepb.CreatePaymentScheduleRequest{
+		Owner:                owner.ToProto(),
+		ScheduleId:           &paymentschedulepb.ScheduleId{Value: scheduleId},
+		DestinationOwner:     destinationOwner.ToProto(),
+		Currency:             "usd",
+		NativeAmount:         nativeAmount,
+		MaxTotalNativeAmount: maxTotalNativeAmount,
+		FirstPaymentAt:       timestamppb.New(firstPaymentAt),
+		Interval:             protoInterval,
+		MaxPayments:          maxPayments,
+	}
+	req.Signature = signRequest(t, owner, req)
+	return req
+}
+
+func (e *testEnv) newCancelPaymentScheduleRequest(t *testing.T, owner *common.Account, scheduleId *paymentschedulepb.ScheduleId) *paymentschedulepb.CancelPaymentScheduleRequest {
+	req := &paymentschedulepb.CancelPaymentScheduleRequest{
+		Owner:      owner.ToProto(),
+		ScheduleId: scheduleId,
+	}
+	req.Signature = signRequest(t, owner, req)
+	return req
+}
+
+func (e *testEnv) newGetPaymentSchedulesRequest(t *testing.T, owner *common.Account) *paymentschedulepb.GetPaymentSchedulesRequest {
+	req := &paymentschedulepb.GetPaymentSchedulesRequest{
+		Owner: owner.ToProto(),
+	}
+	req.Signature = signRequest(t, owner, req)
+	return req
+}
+
+func (e *testEnv) newPollScheduledPaymentMessagesRequest(t *testing.T, owner *common.Account) *paymentschedulepb.PollScheduledPaymentMessagesRequest {
+	req := &paymentschedulepb.PollScheduledPaymentMessagesRequest{
+		Owner: owner.ToProto(),
+	}
+	req.Signature = signRequest(t, owner, req)
+	return req
+}
+
+func (e *testEnv) newAckScheduledPaymentMessagesRequest(t *testing.T, owner *common.Account, messageIds ...*messagingpb.MessageId) *paymentschedulepb.AckScheduledPaymentMessagesRequest {
+	req := &paymentschedulepb.AckScheduledPaymentMessagesRequest{
+		Owner:      owner.ToProto(),
+		MessageIds: messageIds,
+	}
+	req.Signature = signRequest(t, owner, req)
+	return req
+}
+
+func (e *testEnv) saveScheduledPaymentMessage(t *testing.T, owner *common.Account, paymentIndex uint32) *paymentschedulepb.ScheduledPaymentMessage {
+	scheduleId := make([]byte, 32)
+	rand.Read(scheduleId)
+
+	id := uuid.New()
+	idBytes, _ := id.MarshalBinary()
+
+	message := &paymentschedulepb.ScheduledPaymentMessage{
+		Id:            &messagingpb.MessageId{Value: idBytes},
+		ScheduleId:    &paymentschedulepb.ScheduleId{Value: scheduleId},
+		PaymentIndex:  paymentIndex,
+		Kind:          paymentschedulepb.ScheduledPaymentMessage_FAILED,
+		FailureReason: "payer has insufficient balance",
+		CreatedAt:     timestamppb.Now(),
+	}
+
+	messageBytes, err := proto.Marshal(message)
+	require.NoError(t, err)
+
+	require.NoError(t, e.data.CreateMessage(e.ctx, &messaging.Record{
+		Account:   paymentschedule.GetMessageBin(owner.PublicKey().ToBase58()),
+		MessageID: id,
+		Message:   messageBytes,
+	}))
+	return message
+}
+
+func signRequest(t *testing.T, signer *common.Account, req proto.Message) *commonpb.Signature {
+	reqBytes, err := proto.Marshal(req)
+	require.NoError(t, err)
+	return &commonpb.Signature{
+		Value: ed25519.Sign(signer.PrivateKey().ToBytes(), reqBytes),
+	}
+}
@@ -86,7 +86,7 @@ type OpenAccountActionHandler struct {
 	unsavedTimelockRecord    *timelock.Record
 }
 
-func NewOpenAccountActionHandler(ctx context.Context, data ocp_data.Provider, protoAction *transactionpb.OpenAccountAction, protoMetadata *transactionpb.Metadata) (CreateActionHandler, error) {
+func NewOpenAccountActionHandler(ctx context.Context, conf *conf, data ocp_data.Provider, giftCardExpiryByIssuer map[string]time.Duration, requestedGiftCardExpiry *time.Duration, intentRecord *intent.Record, protoAction *transactionpb.OpenAccountAction, protoMetadata *transactionpb.Metadata) (CreateActionHandler, error) {
 	mint, err := common.GetBackwardsCompatMint(protoAction.Mint)
 	if err != nil {
 		return nil, err
@@ -124,6 +124,11 @@ func NewOpenAccountActionHandler(ctx context.Context, data ocp_data.Provider, pr
 		RequiresAutoReturnCheck: protoAction.AccountType == commonpb.AccountType_REMOTE_SEND_GIFT_CARD,
 	}
 
+	if protoAction.AccountType == commonpb.AccountType_REMOTE_SEND_GIFT_CARD {
+		giftCardExpiry := getGiftCardExpiry(ctx, conf, giftCardExpiryByIssuer, intentRecord.InitiatorOwnerAccount, requestedGiftCardExpiry)
+		unsavedAccountInfoRecord.GiftCardExpiresAt = pointer.Time(time.Now().Add(giftCardExpiry))
+	}
+
 	unsavedTimelockRecord := timelockAccounts.ToDBRecord()
 
 	return &OpenAccountActionHandler{
@@ -7,6 +7,7 @@ import (
 	"github.com/code-payments/ocp-server/config/env"
 	"github.com/code-payments/ocp-server/config/memory"
 	"github.com/code-payments/ocp-server/config/wrapper"
+	account_worker "github.com/code-payments/ocp-server/ocp/worker/account"
 )
 
 const (
@@ -41,6 +42,20 @@ const (
 
 	MaxAirdropUsdValueEnvName = envConfigPrefix + "MAX_AIRDROP_USD_VALUE"
 	defaultMaxAirdropUsdValue = 1.0
+
+	GiftCardDefaultExpiryEnvName = envConfigPrefix + "GIFT_CARD_DEFAULT_EXPIRY"
+	defaultGiftCardDefaultExpiry = account_worker.DefaultGiftCardExpiry
+
+	GiftCardMinExpiryEnvName = envConfigPrefix + "GIFT_CARD_MIN_EXPIRY"
+	defaultGiftCardMinExpiry = time.Hour
+
+	GiftCardMaxExpiryEnvName = envConfigPrefix + "GIFT_CARD_MAX_EXPIRY"
+	defaultGiftCardMaxExpiry = 30 * 24 * time.Hour
+
+	// Comma-separated list of <issuer owner public key>=<duration> pairs, used
+	// when the issuing intent doesn't request an expiry
+	GiftCardExpiryByIssuerEnvName = envConfigPrefix + "GIFT_CARD_EXPIRY_BY_ISSUER"
+	defaultGiftCardExpiryByIssuer = ""
 )
 
 type conf struct {
@@ -57,6 +72,10 @@ type conf struct {
 	enableAirdrops               config.Bool
 	airdropperOwnerPublicKey     config.String
 	maxAirdropUsdValue           config.Float64
+	giftCardDefaultExpiry        config.Duration
+	giftCardMinExpiry            config.Duration
+	giftCardMaxExpiry            config.Duration
+	giftCardExpiryByIssuer       config.String
 }
 
 // ConfigProvider defines how config values are pulled
@@ -79,6 +98,10 @@ func WithEnvConfigs() ConfigProvider {
 			enableAirdrops:               env.NewBoolConfig(EnableAirdropsConfigEnvName, defaultEnableAirdrops),
 			airdropperOwnerPublicKey:     env.NewStringConfig(AirdropperOwnerPublicKeyEnvName, defaultAirdropperOwnerPublicKey),
 			maxAirdropUsdValue:           env.NewFloat64Config(MaxAirdropUsdValueEnvName, defaultMaxAirdropUsdValue),
+			giftCardDefaultExpiry:        env.NewDurationConfig(GiftCardDefaultExpiryEnvName, defaultGiftCardDefaultExpiry),
+			giftCardMinExpiry:            env.NewDurationConfig(GiftCardMinExpiryEnvName, defaultGiftCardMinExpiry),
+			giftCardMaxExpiry:            env.NewDurationConfig(GiftCardMaxExpiryEnvName, defaultGiftCardMaxExpiry),
+			giftCardExpiryByIssuer:       env.NewStringConfig(GiftCardExpiryByIssuerEnvName, defaultGiftCardExpiryByIssuer),
 		}
 	}
 }
@@ -90,6 +113,7 @@ type testOverrides struct {
 	enableAirdrops             bool
 	clientReceiveTimeout       time.Duration
 	feeCollectorOwnerPublicKey string
+	giftCardExpiryByIssuer     string
 }
 
 func withManualTestOverrides(overrides *testOverrides) ConfigProvider {
@@ -107,6 +131,10 @@ func withManualTestOverrides(overrides *testOverrides) ConfigProvider {
 			enableAirdrops:               wrapper.NewBoolConfig(memory.NewConfig(overrides.enableAirdrops), false),
 			airdropperOwnerPublicKey:     wrapper.NewStringConfig(memory.NewConfig(defaultAirdropperOwnerPublicKey), defaultAirdropperOwnerPublicKey),
 			maxAirdropUsdValue:           wrapper.NewFloat64Config(memory.NewConfig(defaultMaxAirdropUsdValue), defaultMaxAirdropUsdValue),
+			giftCardDefaultExpiry:        wrapper.NewDurationConfig(memory.NewConfig(defaultGiftCardDefaultExpiry), defaultGiftCardDefaultExpiry),
+			giftCardMinExpiry:            wrapper.NewDurationConfig(memory.NewConfig(defaultGiftCardMinExpiry), defaultGiftCardMinExpiry),
+			giftCardMaxExpiry:            wrapper.NewDurationConfig(memory.NewConfig(defaultGiftCardMaxExpiry), defaultGiftCardMaxExpiry),
+			giftCardExpiryByIssuer:       wrapper.NewStringConfig(memory.NewConfig(overrides.giftCardExpiryByIssuer), defaultGiftCardExpiryByIssuer),
 		}
 	}
 }
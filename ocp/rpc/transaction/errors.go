@@ -14,6 +14,7 @@ import (
 
 	"github.com/code-payments/ocp-server/ocp/data/intent"
 	"github.com/code-payments/ocp-server/ocp/transaction"
+	vm_util "github.com/code-payments/ocp-server/ocp/vm"
 	"github.com/code-payments/ocp-server/solana"
 	"github.com/code-payments/ocp-server/solana/vm"
 )
@@ -387,6 +388,9 @@ func handleSwapError(streamer transactionpb.Transaction_SwapServer, err error) e
 		return status.Error(codes.DeadlineExceeded, err.Error())
 	case context.Canceled:
 		return status.Error(codes.Canceled, err.Error())
+	case vm_util.ErrVirtualAccountCompressed:
+		// Decompression was requested, so the client can retry shortly
+		return status.Error(codes.Unavailable, err.Error())
 	}
 	return status.Error(codes.Internal, "rpc server failure")
 }
@@ -2,23 +2,105 @@ package transaction
 
 import (
 	"context"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	commonpb "github.com/code-payments/ocp-protobuf-api/generated/go/common/v1"
 	transactionpb "github.com/code-payments/ocp-protobuf-api/generated/go/transaction/v1"
 
 	"github.com/code-payments/ocp-server/grpc/client"
+	auth_util "github.com/code-payments/ocp-server/ocp/auth"
 	"github.com/code-payments/ocp-server/ocp/balance"
 	"github.com/code-payments/ocp-server/ocp/common"
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
 	"github.com/code-payments/ocp-server/ocp/data/account"
 	"github.com/code-payments/ocp-server/ocp/data/action"
+	giftcardpb "github.com/code-payments/ocp-server/ocp/rpc/transaction/api/gen"
 	account_worker "github.com/code-payments/ocp-server/ocp/worker/account"
 )
 
+const (
+	// GiftCardExpiryHeaderName is the SubmitIntent header an issuer sets to
+	// request the expiry, in seconds, of a gift card opened by the intent. The
+	// intent metadata is defined in the external ocp-protobuf-api module, which
+	// has no field for it.
+	GiftCardExpiryHeaderName = "gift-card-expiry-seconds"
+)
+
+type giftCardServer struct {
+	log  *zap.Logger
+	data ocp_data.Provider
+	auth *auth_util.RPCSignatureVerifier
+
+	giftcardpb.UnimplementedGiftCardServer
+}
+
+// NewGiftCardServer returns a server for gift card details that can't be added
+// to TokenAccountInfo, which is defined in the external ocp-protobuf-api module.
+func NewGiftCardServer(log *zap.Logger, data ocp_data.Provider) giftcardpb.GiftCardServer {
+	return &giftCardServer{
+		log:  log,
+		data: data,
+		auth: auth_util.NewRPCSignatureVerifier(log, data),
+	}
+}
+
+func (s *giftCardServer) GetGiftCardExpiry(ctx context.Context, req *giftcardpb.GetGiftCardExpiryRequest) (*giftcardpb.GetGiftCardExpiryResponse, error) {
+	log := s.log.With(zap.String("method", "GetGiftCardExpiry"))
+	log = client.InjectLoggingMetadata(ctx, log)
+
+	giftCardVault, err := common.NewAccountFromProto(req.GiftCardVault)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("invalid gift card vault account")
+		return nil, status.Error(codes.Internal, "")
+	}
+	log = log.With(zap.String("gift_card_vault_account", giftCardVault.PublicKey().ToBase58()))
+
+	owner, err := common.NewAccountFromProto(req.Owner)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("invalid owner account")
+		return nil, status.Error(codes.Internal, "")
+	}
+	log = log.With(zap.String("owner_account", owner.PublicKey().ToBase58()))
+
+	signature := req.Signature
+	req.Signature = nil
+	if err := s.auth.Authenticate(ctx, owner, req, signature); err != nil {
+		return nil, err
+	}
+
+	accountInfoRecord, err := s.data.GetAccountInfoByTokenAddress(ctx, giftCardVault.PublicKey().ToBase58())
+	switch err {
+	case nil:
+		if accountInfoRecord.AccountType != commonpb.AccountType_REMOTE_SEND_GIFT_CARD || accountInfoRecord.OwnerAccount != owner.PublicKey().ToBase58() {
+			return &giftcardpb.GetGiftCardExpiryResponse{
+				Result: giftcardpb.GetGiftCardExpiryResponse_NOT_FOUND,
+			}, nil
+		}
+	case account.ErrAccountInfoNotFound:
+		return &giftcardpb.GetGiftCardExpiryResponse{
+			Result: giftcardpb.GetGiftCardExpiryResponse_NOT_FOUND,
+		}, nil
+	default:
+		log.With(zap.Error(err)).Warn("failure getting gift card account info")
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return &giftcardpb.GetGiftCardExpiryResponse{
+		Result:    giftcardpb.GetGiftCardExpiryResponse_OK,
+		ExpiresAt: timestamppb.New(account_worker.GetGiftCardExpiresAt(accountInfoRecord)),
+	}, nil
+}
+
 func (s *transactionServer) VoidGiftCard(ctx context.Context, req *transactionpb.VoidGiftCardRequest) (*transactionpb.VoidGiftCardResponse, error) {
 	log := s.log.With(zap.String("method", "VoidGiftCard"))
 	log = client.InjectLoggingMetadata(ctx, log)
@@ -70,7 +152,7 @@ func (s *transactionServer) VoidGiftCard(ctx context.Context, req *transactionpb
 		}, nil
 	}
 
-	if time.Since(accountInfoRecord.CreatedAt) >= account_worker.GiftCardExpiry {
+	if !time.Now().Before(account_worker.GetGiftCardExpiresAt(accountInfoRecord)) {
 		return &transactionpb.VoidGiftCardResponse{
 			Result: transactionpb.VoidGiftCardResponse_OK,
 		}, nil
@@ -94,7 +176,7 @@ func (s *transactionServer) VoidGiftCard(ctx context.Context, req *transactionpb
 			return nil, status.Error(codes.Internal, "")
 		}
 
-		vmConfig, err := common.GetVmConfigForMint(ctx, s.data, mintAccount)
+		vmConfig, err := common.GetPublicVmConfigForMint(ctx, s.data, mintAccount)
 		if err != nil {
 			log.With(zap.Error(err)).Warn("failure getting vm config")
 			return nil, status.Error(codes.Internal, "")
@@ -133,3 +215,84 @@ func (s *transactionServer) VoidGiftCard(ctx context.Context, req *transactionpb
 		Result: transactionpb.VoidGiftCardResponse_OK,
 	}, nil
 }
+
+// getGiftCardExpiry gets the expiry for a new gift card issued by the provided
+// owner, which is bounded by the server's min and max expiry. The expiry
+// requested in the issuing intent takes precedence, and the issuer's configured
+// expiry is only a default for when none was requested.
+func getGiftCardExpiry(ctx context.Context, conf *conf, expiryByIssuer map[string]time.Duration, issuer string, requestedExpiry *time.Duration) time.Duration {
+	expiry := conf.giftCardDefaultExpiry.Get(ctx)
+	if requestedExpiry != nil {
+		expiry = *requestedExpiry
+	} else if issuerExpiry, ok := expiryByIssuer[issuer]; ok {
+		expiry = issuerExpiry
+	}
+
+	minExpiry := conf.giftCardMinExpiry.Get(ctx)
+	maxExpiry := conf.giftCardMaxExpiry.Get(ctx)
+	if expiry < minExpiry {
+		expiry = minExpiry
+	}
+	if expiry > maxExpiry {
+		expiry = maxExpiry
+	}
+	return expiry
+}
+
+// getRequestedGiftCardExpiry gets the gift card expiry requested by the issuer
+// of an intent via the GiftCardExpiryHeaderName header, if one was provided.
+func getRequestedGiftCardExpiry(ctx context.Context) (*time.Duration, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+
+	values := md.Get(GiftCardExpiryHeaderName)
+	if len(values) == 0 {
+		return nil, nil
+	} else if len(values) > 1 {
+		return nil, NewIntentValidationErrorf("multiple %s headers provided", GiftCardExpiryHeaderName)
+	}
+
+	seconds, err := strconv.ParseInt(strings.TrimSpace(values[0]), 10, 64)
+	if err != nil || seconds <= 0 || seconds > int64(math.MaxInt64/time.Second) {
+		return nil, NewIntentValidationErrorf("%s header must be a positive number of seconds", GiftCardExpiryHeaderName)
+	}
+
+	expiry := time.Duration(seconds) * time.Second
+	return &expiry, nil
+}
+
+// parseGiftCardExpiryByIssuer parses a comma-separated list of
+// <issuer owner public key>=<duration> pairs. It's done once when the server is
+// created, so an invalid value fails startup rather than gift card issuance.
+func parseGiftCardExpiryByIssuer(value string) (map[string]time.Duration, error) {
+	res := make(map[string]time.Duration)
+
+	value = strings.TrimSpace(value)
+	if len(value) == 0 {
+		return res, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		parts := strings.Split(strings.TrimSpace(pair), "=")
+		if len(parts) != 2 {
+			return nil, errors.Errorf("invalid gift card expiry by issuer pair: %s", pair)
+		}
+
+		issuer, err := common.NewAccountFromPublicKeyString(parts[0])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid gift card issuer: %s", parts[0])
+		}
+
+		expiry, err := time.ParseDuration(parts[1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid gift card expiry for issuer %s", parts[0])
+		} else if expiry <= 0 {
+			return nil, errors.Errorf("gift card expiry for issuer %s must be positive", parts[0])
+		}
+
+		res[issuer.PublicKey().ToBase58()] = expiry
+	}
+	return res, nil
+}
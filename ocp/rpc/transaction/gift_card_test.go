@@ -0,0 +1,239 @@
+package transaction
+
+import (
+	"context"
+	"crypto/ed25519"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+
+	commonpb "github.com/code-payments/ocp-protobuf-api/generated/go/common/v1"
+
+	"github.com/code-payments/ocp-server/ocp/common"
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/account"
+	giftcardpb "github.com/code-payments/ocp-server/ocp/rpc/transaction/api/gen"
+	"github.com/code-payments/ocp-server/pointer"
+	"github.com/code-payments/ocp-server/testutil"
+)
+
+type giftCardTestEnv struct {
+	ctx    context.Context
+	client giftcardpb.GiftCardClient
+	data   ocp_data.Provider
+}
+
+func setupGiftCard(t *testing.T) (env giftCardTestEnv, cleanup func()) {
+	log := zaptest.NewLogger(t)
+
+	conn, serv, err := testutil.NewServer(log)
+	require.NoError(t, err)
+
+	env.ctx = context.Background()
+	env.client = giftcardpb.NewGiftCardClient(conn)
+	env.data = ocp_data.NewTestDataProvider()
+
+	s := NewGiftCardServer(log, env.data)
+
+	serv.RegisterService(func(server *grpc.Server) {
+		giftcardpb.RegisterGiftCardServer(server, s)
+	})
+
+	cleanup, err = serv.Serve()
+	require.NoError(t, err)
+	return env, cleanup
+}
+
+func TestGetGiftCardExpiry_HappyPath(t *testing.T) {
+	env, cleanup := setupGiftCard(t)
+	defer cleanup()
+
+	expiresAt := time.Now().Add(24 * time.Hour)
+	giftCardOwner, giftCardVault := env.createAccount(t, commonpb.AccountType_REMOTE_SEND_GIFT_CARD, &expiresAt)
+
+	resp := env.getGiftCardExpiry(t, giftCardOwner, giftCardVault)
+	assert.Equal(t, giftcardpb.GetGiftCardExpiryResponse_OK, resp.Result)
+	assert.Equal(t, expiresAt.Unix(), resp.ExpiresAt.AsTime().Unix())
+}
+
+func TestGetGiftCardExpiry_NotFound(t *testing.T) {
+	env, cleanup := setupGiftCard(t)
+	defer cleanup()
+
+	owner := testutil.NewRandomAccount(t)
+	resp := env.getGiftCardExpiry(t, owner, testutil.NewRandomAccount(t))
+	assert.Equal(t, giftcardpb.GetGiftCardExpiryResponse_NOT_FOUND, resp.Result)
+	assert.Nil(t, resp.ExpiresAt)
+}
+
+func TestGetGiftCardExpiry_NotGiftCard(t *testing.T) {
+	env, cleanup := setupGiftCard(t)
+	defer cleanup()
+
+	owner, primaryVault := env.createAccount(t, commonpb.AccountType_PRIMARY, nil)
+
+	resp := env.getGiftCardExpiry(t, owner, primaryVault)
+	assert.Equal(t, giftcardpb.GetGiftCardExpiryResponse_NOT_FOUND, resp.Result)
+	assert.Nil(t, resp.ExpiresAt)
+}
+
+func TestGetGiftCardExpiry_NotGiftCardOwner(t *testing.T) {
+	env, cleanup := setupGiftCard(t)
+	defer cleanup()
+
+	expiresAt := time.Now().Add(24 * time.Hour)
+	_, giftCardVault := env.createAccount(t, commonpb.AccountType_REMOTE_SEND_GIFT_CARD, &expiresAt)
+
+	resp := env.getGiftCardExpiry(t, testutil.NewRandomAccount(t), giftCardVault)
+	assert.Equal(t, giftcardpb.GetGiftCardExpiryResponse_NOT_FOUND, resp.Result)
+	assert.Nil(t, resp.ExpiresAt)
+}
+
+func TestGetGiftCardExpiry_Unauthenticated(t *testing.T) {
+	env, cleanup := setupGiftCard(t)
+	defer cleanup()
+
+	expiresAt := time.Now().Add(24 * time.Hour)
+	giftCardOwner, giftCardVault := env.createAccount(t, commonpb.AccountType_REMOTE_SEND_GIFT_CARD, &expiresAt)
+
+	req := &giftcardpb.GetGiftCardExpiryRequest{
+		GiftCardVault: giftCardVault.ToProto(),
+		Owner:         giftCardOwner.ToProto(),
+	}
+	reqBytes, err := proto.Marshal(req)
+	require.NoError(t, err)
+	req.Signature = &commonpb.Signature{
+		Value: ed25519.Sign(testutil.NewRandomAccount(t).PrivateKey().ToBytes(), reqBytes),
+	}
+
+	_, err = env.client.GetGiftCardExpiry(env.ctx, req)
+	testutil.AssertStatusErrorWithCode(t, err, codes.Unauthenticated)
+}
+
+func TestParseGiftCardExpiryByIssuer(t *testing.T) {
+	issuer1 := testutil.NewRandomAccount(t).PublicKey().ToBase58()
+	issuer2 := testutil.NewRandomAccount(t).PublicKey().ToBase58()
+
+	expiryByIssuer, err := parseGiftCardExpiryByIssuer("")
+	require.NoError(t, err)
+	assert.Empty(t, expiryByIssuer)
+
+	expiryByIssuer, err = parseGiftCardExpiryByIssuer(issuer1 + "=24h, " + issuer2 + "=720h")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{
+		issuer1: 24 * time.Hour,
+		issuer2: 720 * time.Hour,
+	}, expiryByIssuer)
+
+	for _, invalid := range []string{
+		issuer1,
+		issuer1 + "=24h=1h",
+		"invalid=24h",
+		issuer1 + "=invalid",
+		issuer1 + "=0s",
+		issuer1 + "=-1h",
+	} {
+		_, err = parseGiftCardExpiryByIssuer(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestGetGiftCardExpiry_IssuerPolicy(t *testing.T) {
+	ctx := context.Background()
+	conf := withManualTestOverrides(&testOverrides{})()
+
+	issuer := testutil.NewRandomAccount(t).PublicKey().ToBase58()
+	otherIssuer := testutil.NewRandomAccount(t).PublicKey().ToBase58()
+	expiryByIssuer := map[string]time.Duration{
+		issuer: 24 * time.Hour,
+	}
+
+	durationPtr := func(value time.Duration) *time.Duration {
+		return &value
+	}
+
+	for _, tc := range []struct {
+		issuer    string
+		requested *time.Duration
+		expected  time.Duration
+	}{
+		{otherIssuer, nil, defaultGiftCardDefaultExpiry},
+		{issuer, nil, 24 * time.Hour},
+		{issuer, durationPtr(72 * time.Hour), 72 * time.Hour},
+		{otherIssuer, durationPtr(72 * time.Hour), 72 * time.Hour},
+		{issuer, durationPtr(time.Minute), defaultGiftCardMinExpiry},
+		{issuer, durationPtr(365 * 24 * time.Hour), defaultGiftCardMaxExpiry},
+	} {
+		assert.Equal(t, tc.expected, getGiftCardExpiry(ctx, conf, expiryByIssuer, tc.issuer, tc.requested))
+	}
+}
+
+func TestGetRequestedGiftCardExpiry(t *testing.T) {
+	expiry, err := getRequestedGiftCardExpiry(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, expiry)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(GiftCardExpiryHeaderName, "86400"))
+	expiry, err = getRequestedGiftCardExpiry(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, expiry)
+	assert.Equal(t, 24*time.Hour, *expiry)
+
+	for _, invalid := range []string{
+		"",
+		"0",
+		"-1",
+		"24h",
+		"99999999999999999999",
+	} {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(GiftCardExpiryHeaderName, invalid))
+		_, err = getRequestedGiftCardExpiry(ctx)
+		assert.Error(t, err, invalid)
+	}
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(GiftCardExpiryHeaderName, "3600", GiftCardExpiryHeaderName, "7200"))
+	_, err = getRequestedGiftCardExpiry(ctx)
+	assert.Error(t, err)
+}
+
+func (e *giftCardTestEnv) createAccount(t *testing.T, accountType commonpb.AccountType, giftCardExpiresAt *time.Time) (*common.Account, *common.Account) {
+	owner := testutil.NewRandomAccount(t)
+	vault := testutil.NewRandomAccount(t)
+
+	record := &account.Record{
+		OwnerAccount:     owner.PublicKey().ToBase58(),
+		AuthorityAccount: owner.PublicKey().ToBase58(),
+		TokenAccount:     vault.PublicKey().ToBase58(),
+		MintAccount:      common.CoreMintAccount.PublicKey().ToBase58(),
+		AccountType:      accountType,
+	}
+	if giftCardExpiresAt != nil {
+		record.GiftCardExpiresAt = pointer.Time(*giftCardExpiresAt)
+	}
+	require.NoError(t, e.data.CreateAccountInfo(e.ctx, record))
+
+	return owner, vault
+}
+
+func (e *giftCardTestEnv) getGiftCardExpiry(t *testing.T, owner, giftCardVault *common.Account) *giftcardpb.GetGiftCardExpiryResponse {
+	req := &giftcardpb.GetGiftCardExpiryRequest{
+		GiftCardVault: giftCardVault.ToProto(),
+		Owner:         owner.ToProto(),
+	}
+	reqBytes, err := proto.Marshal(req)
+	require.NoError(t, err)
+	req.Signature = &commonpb.Signature{
+		Value: ed25519.Sign(owner.PrivateKey().ToBytes(), reqBytes),
+	}
+
+	resp, err := e.client.GetGiftCardExpiry(e.ctx, req)
+	require.NoError(t, err)
+	return resp
+}
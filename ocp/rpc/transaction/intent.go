@@ -29,6 +29,7 @@ import (
 	"github.com/code-payments/ocp-server/ocp/data/intent"
 	"github.com/code-payments/ocp-server/ocp/data/nonce"
 	"github.com/code-payments/ocp-server/ocp/transaction"
+	"github.com/code-payments/ocp-server/ocp/webhook"
 	"github.com/code-payments/ocp-server/pointer"
 	"github.com/code-payments/ocp-server/protoutil"
 	"github.com/code-payments/ocp-server/solana"
@@ -88,6 +89,11 @@ func (s *transactionServer) SubmitIntent(streamer transactionpb.Transaction_Subm
 		}
 	}
 
+	requestedGiftCardExpiry, err := getRequestedGiftCardExpiry(ctx)
+	if err != nil {
+		return handleSubmitIntentError(ctx, streamer, intentRecord, err)
+	}
+
 	marshalled, err := proto.Marshal(submitActionsReq)
 	if err == nil {
 		log = log.With(zap.String("submit_actions_data_dump", base64.URLEncoding.EncodeToString(marshalled)))
@@ -340,7 +346,7 @@ func (s *transactionServer) SubmitIntent(streamer transactionpb.Transaction_Subm
 		case *transactionpb.Action_OpenAccount:
 			log = log.With(zap.String("action_type", "open_account"))
 			actionType = action.OpenAccount
-			actionHandler, err = NewOpenAccountActionHandler(ctx, s.data, typed.OpenAccount, submitActionsReq.Metadata)
+			actionHandler, err = NewOpenAccountActionHandler(ctx, s.conf, s.data, s.giftCardExpiryByIssuer, requestedGiftCardExpiry, intentRecord, typed.OpenAccount, submitActionsReq.Metadata)
 		case *transactionpb.Action_NoPrivacyTransfer:
 			log = log.With(zap.String("action_type", "no_privacy_transfer"))
 			actionType = action.NoPrivacyTransfer
@@ -594,6 +600,12 @@ func (s *transactionServer) SubmitIntent(streamer transactionpb.Transaction_Subm
 			return err
 		}
 
+		err = webhook.Enqueue(ctx, s.data, webhook.NewIntentEvent(webhook.EventTypeIntentCreated, intentRecord))
+		if err != nil {
+			log.With(zap.Error(err)).Warn("failure enqueueing intent created webhook event")
+			return err
+		}
+
 		// Save additional state related to the intent
 		err = intentHandler.OnCommitToDB(ctx)
 		if err != nil {
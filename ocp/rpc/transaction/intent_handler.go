@@ -22,6 +22,7 @@ import (
 	"github.com/code-payments/ocp-server/ocp/data/account"
 	"github.com/code-payments/ocp-server/ocp/data/action"
 	"github.com/code-payments/ocp-server/ocp/data/intent"
+	"github.com/code-payments/ocp-server/ocp/data/paymentrequest"
 	"github.com/code-payments/ocp-server/ocp/data/swap"
 	"github.com/code-payments/ocp-server/ocp/data/timelock"
 	account_worker "github.com/code-payments/ocp-server/ocp/worker/account"
@@ -240,6 +241,11 @@ func (h *OpenAccountsIntentHandler) AllowCreation(ctx context.Context, intentRec
 	//
 
 	_, err = validateSwapFunding(ctx, h.data, intentRecord)
+	if err != nil {
+		return err
+	}
+
+	_, err = validatePaymentRequestFulfillment(ctx, h.data, intentRecord)
 	return err
 }
 
@@ -609,6 +615,11 @@ func (h *SendPublicPaymentIntentHandler) AllowCreation(ctx context.Context, inte
 		return err
 	}
 
+	_, err = validatePaymentRequestFulfillment(ctx, h.data, intentRecord)
+	if err != nil {
+		return err
+	}
+
 	//
 	// Part 8: Validate the individual actions
 	//
@@ -1128,6 +1139,11 @@ func (h *ReceivePaymentsPubliclyIntentHandler) AllowCreation(ctx context.Context
 		return err
 	}
 
+	_, err = validatePaymentRequestFulfillment(ctx, h.data, intentRecord)
+	if err != nil {
+		return err
+	}
+
 	//
 	// Part 8: Validate the individual actions
 	//
@@ -1449,6 +1465,11 @@ func (h *PublicDistributionIntentHandler) AllowCreation(ctx context.Context, int
 		return err
 	}
 
+	_, err = validatePaymentRequestFulfillment(ctx, h.data, intentRecord)
+	if err != nil {
+		return err
+	}
+
 	//
 	// Part 6: Validate actions
 	//
@@ -1956,7 +1977,7 @@ func validateClaimedGiftCard(ctx context.Context, data ocp_data.Provider, giftCa
 	// Part 6: Are we within the threshold for auto-return back to the issuer?
 	//
 
-	if time.Since(accountInfoRecord.CreatedAt) >= account_worker.GiftCardExpiry-time.Minute {
+	if !time.Now().Before(account_worker.GetGiftCardExpiresAt(accountInfoRecord).Add(-time.Minute)) {
 		return NewStaleStateError("gift card is expired")
 	}
 
@@ -2005,6 +2026,54 @@ func validateDistributedPool(ctx context.Context, data ocp_data.Provider, poolVa
 	return nil
 }
 
+// validatePaymentRequestFulfillment validates an intent whose ID is reserved to
+// pay a merchant's payment request. The intent must be a public payment of
+// exactly the requested amount to the requested destination.
+func validatePaymentRequestFulfillment(ctx context.Context, data ocp_data.Provider, intentRecord *intent.Record) (*paymentrequest.Record, error) {
+	paymentRequestRecord, err := data.GetPaymentRequest(ctx, intentRecord.IntentId)
+	if err == paymentrequest.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if intentRecord.IntentType != intent.SendPublicPayment {
+		return nil, NewIntentDeniedError("intent id reserved to pay a payment request")
+	}
+
+	if paymentRequestRecord.State != paymentrequest.StatePending {
+		return nil, NewIntentDeniedErrorf("payment request state is %s", paymentRequestRecord.State)
+	}
+
+	if paymentRequestRecord.IsExpired() {
+		return nil, NewIntentDeniedError("payment request has expired")
+	}
+
+	if intentRecord.InitiatorOwnerAccount == paymentRequestRecord.Owner {
+		return nil, NewIntentDeniedError("cannot pay your own payment request")
+	}
+
+	metadata := intentRecord.SendPublicPaymentMetadata
+
+	if metadata.IsWithdrawal || metadata.IsRemoteSend {
+		return nil, NewIntentValidationError("payment request must be paid directly to its destination")
+	}
+
+	if intentRecord.MintAccount != paymentRequestRecord.Mint {
+		return nil, NewIntentValidationErrorf("must pay payment request with %s mint", paymentRequestRecord.Mint)
+	}
+
+	if metadata.DestinationTokenAccount != paymentRequestRecord.DestinationTokenAccount {
+		return nil, NewIntentValidationErrorf("must pay payment request to %s", paymentRequestRecord.DestinationTokenAccount)
+	}
+
+	if metadata.ExchangeCurrency != paymentRequestRecord.ExchangeCurrency || metadata.NativeAmount != paymentRequestRecord.NativeAmount {
+		return nil, NewIntentValidationErrorf("must pay payment request amount of %v %s", paymentRequestRecord.NativeAmount, paymentRequestRecord.ExchangeCurrency)
+	}
+
+	return paymentRequestRecord, nil
+}
+
 func validateSwapFunding(ctx context.Context, data ocp_data.Provider, intentRecord *intent.Record) (*swap.Record, error) {
 	swapRecord, err := data.GetSwapByFundingId(ctx, intentRecord.IntentId)
 	if err != nil && err != swap.ErrNotFound {
@@ -2094,7 +2163,7 @@ func validateTimelockUnlockStateDoesntExist(ctx context.Context, data ocp_data.P
 		return err
 	}
 
-	vmConfig, err := common.GetVmConfigForMint(ctx, data, mintAccount)
+	vmConfig, err := common.GetPublicVmConfigForMint(ctx, data, mintAccount)
 	if err != nil {
 		return err
 	}
@@ -2153,7 +2222,7 @@ func getExpectedTimelockVaultFromProtoAccounts(ctx context.Context, data ocp_dat
 		return nil, err
 	}
 
-	vmConfig, err := common.GetVmConfigForMint(ctx, data, mintAccount)
+	vmConfig, err := common.GetPublicVmConfigForMint(ctx, data, mintAccount)
 	if err != nil {
 		return nil, err
 	}
@@ -241,7 +241,7 @@ func LocalSimulation(ctx context.Context, data ocp_data.Provider, actions []*tra
 		}
 
 		// Validate authorities and respective derived timelock vault accounts match.
-		vmConfig, err := common.GetVmConfigForMint(ctx, data, mint)
+		vmConfig, err := common.GetPublicVmConfigForMint(ctx, data, mint)
 		if err == common.ErrUnsupportedMint {
 			return nil, NewActionValidationError(action, "mint account must be the core mint or a launchpad currency")
 		} else if err != nil {
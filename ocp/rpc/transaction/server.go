@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -45,6 +46,8 @@ type transactionServer struct {
 
 	feeCollector *common.Account
 
+	giftCardExpiryByIssuer map[string]time.Duration
+
 	transactionpb.UnimplementedTransactionServer
 }
 
@@ -114,6 +117,11 @@ func NewTransactionServer(
 		return nil, err
 	}
 
+	s.giftCardExpiryByIssuer, err = parseGiftCardExpiryByIssuer(s.conf.giftCardExpiryByIssuer.Get(ctx))
+	if err != nil {
+		return nil, err
+	}
+
 	airdropper := s.conf.airdropperOwnerPublicKey.Get(ctx)
 	if len(airdropper) > 0 && airdropper != defaultAirdropperOwnerPublicKey {
 		err := s.loadAirdropper(ctx)
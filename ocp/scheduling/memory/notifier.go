@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/code-payments/ocp-server/ocp/scheduling"
+)
+
+const (
+	subscriberQueueSize = 1024
+)
+
+type notifier struct {
+	mu          sync.Mutex
+	subscribers map[chan *scheduling.Hint]struct{}
+}
+
+// New returns a new in process scheduling.Notifier, which only delivers hints
+// to subscribers within the same process
+func New() scheduling.Notifier {
+	return &notifier{
+		subscribers: make(map[chan *scheduling.Hint]struct{}),
+	}
+}
+
+// Notify implements scheduling.Notifier.Notify
+func (n *notifier) Notify(_ context.Context, hints ...*scheduling.Hint) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for subscriber := range n.subscribers {
+		for _, hint := range hints {
+			cloned := *hint
+
+			select {
+			case subscriber <- &cloned:
+			default:
+			}
+		}
+	}
+	return nil
+}
+
+// Subscribe implements scheduling.Notifier.Subscribe
+func (n *notifier) Subscribe(ctx context.Context) (<-chan *scheduling.Hint, error) {
+	subscriber := make(chan *scheduling.Hint, subscriberQueueSize)
+
+	n.mu.Lock()
+	n.subscribers[subscriber] = struct{}{}
+	n.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		n.mu.Lock()
+		delete(n.subscribers, subscriber)
+		close(subscriber)
+		n.mu.Unlock()
+	}()
+
+	return subscriber, nil
+}
@@ -0,0 +1,11 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/code-payments/ocp-server/ocp/scheduling/tests"
+)
+
+func TestSchedulingMemoryNotifier(t *testing.T) {
+	tests.RunTests(t, New())
+}
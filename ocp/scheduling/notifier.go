@@ -0,0 +1,51 @@
+package scheduling
+
+import (
+	"context"
+
+	"github.com/code-payments/ocp-server/ocp/data/fulfillment"
+)
+
+// Hint tells the sequencer that the next fulfillment for an account, after
+// the position defined by the ordering indices, may now be schedulable
+type Hint struct {
+	Address                  string `json:"address"`
+	IntentOrderingIndex      uint64 `json:"intent_ordering_index"`
+	ActionOrderingIndex      uint32 `json:"action_ordering_index"`
+	FulfillmentOrderingIndex uint32 `json:"fulfillment_ordering_index"`
+}
+
+// Notifier is a bus that distributes scheduling hints to sequencers, so they
+// can be woken up for work without waiting on their next poll.
+//
+// Delivery is best effort. Hints may be dropped when subscribers fall behind
+// or are disconnected, in which case the fulfillment is picked up by polling.
+type Notifier interface {
+	// Notify publishes hints to all current subscribers
+	Notify(ctx context.Context, hints ...*Hint) error
+
+	// Subscribe returns a channel of hints published after the call. The
+	// channel is closed when the provided context is cancelled.
+	Subscribe(ctx context.Context) (<-chan *Hint, error)
+}
+
+// GetHintsForConfirmedFulfillment returns hints for the accounts involved in a
+// fulfillment that was just confirmed, whose next fulfillments may be waiting
+// on it.
+func GetHintsForConfirmedFulfillment(record *fulfillment.Record) []*Hint {
+	addresses := []string{record.Source}
+	if record.Destination != nil && *record.Destination != record.Source {
+		addresses = append(addresses, *record.Destination)
+	}
+
+	hints := make([]*Hint, len(addresses))
+	for i, address := range addresses {
+		hints[i] = &Hint{
+			Address:                  address,
+			IntentOrderingIndex:      record.IntentOrderingIndex,
+			ActionOrderingIndex:      record.ActionOrderingIndex,
+			FulfillmentOrderingIndex: record.FulfillmentOrderingIndex,
+		}
+	}
+	return hints
+}
@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/code-payments/ocp-server/ocp/scheduling"
+)
+
+const (
+	channelName = "ocp__core_fulfillment_scheduling"
+
+	subscriberQueueSize = 1024
+	reconnectDelay      = time.Second
+)
+
+type notifier struct {
+	log        *zap.Logger
+	db         *sqlx.DB
+	connString string
+}
+
+// New returns a new scheduling.Notifier backed by Postgres LISTEN/NOTIFY, which
+// delivers hints to subscribers across all nodes sharing the database.
+//
+// Hints are published over the provided pool. Each subscriber holds a dedicated
+// connection, opened with the connection string, which can't be pooled while
+// listening.
+func New(log *zap.Logger, db *sql.DB, connString string) scheduling.Notifier {
+	return &notifier{
+		log:        log,
+		db:         sqlx.NewDb(db, "pgx"),
+		connString: connString,
+	}
+}
+
+// Notify implements scheduling.Notifier.Notify
+func (n *notifier) Notify(ctx context.Context, hints ...*scheduling.Hint) error {
+	for _, hint := range hints {
+		payload, err := json.Marshal(hint)
+		if err != nil {
+			return errors.Wrap(err, "error marshalling hint")
+		}
+
+		_, err = n.db.ExecContext(ctx, `SELECT pg_notify($1, $2)`, channelName, string(payload))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Subscribe implements scheduling.Notifier.Subscribe
+func (n *notifier) Subscribe(ctx context.Context) (<-chan *scheduling.Hint, error) {
+	conn, err := n.listen(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriber := make(chan *scheduling.Hint, subscriberQueueSize)
+
+	go func() {
+		defer close(subscriber)
+
+		for {
+			notification, err := conn.WaitForNotification(ctx)
+			if ctx.Err() != nil {
+				conn.Close(context.Background())
+				return
+			} else if err != nil {
+				n.log.With(zap.Error(err)).Warn("failure waiting for notification, reconnecting")
+				conn.Close(context.Background())
+
+				// Hints published while disconnected are lost, and picked up
+				// by polling instead
+				conn = n.reconnect(ctx)
+				if conn == nil {
+					return
+				}
+				continue
+			}
+
+			var hint scheduling.Hint
+			err = json.Unmarshal([]byte(notification.Payload), &hint)
+			if err != nil {
+				n.log.With(zap.Error(err)).Warn("failure unmarshalling hint")
+				continue
+			}
+
+			select {
+			case subscriber <- &hint:
+			default:
+			}
+		}
+	}()
+
+	return subscriber, nil
+}
+
+func (n *notifier) listen(ctx context.Context) (*pgx.Conn, error) {
+	conn, err := pgx.Connect(ctx, n.connString)
+	if err != nil {
+		return nil, errors.Wrap(err, "error connecting to database")
+	}
+
+	_, err = conn.Exec(ctx, "LISTEN "+channelName)
+	if err != nil {
+		conn.Close(context.Background())
+		return nil, errors.Wrap(err, "error listening to channel")
+	}
+
+	return conn, nil
+}
+
+func (n *notifier) reconnect(ctx context.Context) *pgx.Conn {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(reconnectDelay):
+		}
+
+		conn, err := n.listen(ctx)
+		if err == nil {
+			return conn
+		}
+		n.log.With(zap.Error(err)).Warn("failure reconnecting listener")
+	}
+}
@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ory/dockertest/v3"
+	"go.uber.org/zap"
+
+	"github.com/code-payments/ocp-server/ocp/scheduling"
+	"github.com/code-payments/ocp-server/ocp/scheduling/tests"
+
+	postgrestest "github.com/code-payments/ocp-server/database/postgres/test"
+
+	_ "github.com/jackc/pgx/v4/stdlib"
+)
+
+var (
+	testNotifier scheduling.Notifier
+)
+
+func TestMain(m *testing.M) {
+	log := zap.Must(zap.NewDevelopment())
+
+	testPool, err := dockertest.NewPool("")
+	if err != nil {
+		log.With(zap.Error(err)).Error("Error creating docker pool")
+		os.Exit(1)
+	}
+
+	db, connString, cleanUpFunc, err := postgrestest.StartPostgresDBWithConnectionString(testPool)
+	if err != nil {
+		log.With(zap.Error(err)).Error("Error starting postgres image")
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	testNotifier = New(log, db, connString)
+
+	code := m.Run()
+	cleanUpFunc()
+	os.Exit(code)
+}
+
+func TestSchedulingPostgresNotifier(t *testing.T) {
+	tests.RunTests(t, testNotifier)
+}
@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/code-payments/ocp-server/ocp/scheduling"
+)
+
+func RunTests(t *testing.T, n scheduling.Notifier) {
+	for _, tf := range []func(t *testing.T, n scheduling.Notifier){
+		testFanOut,
+		testUnsubscribe,
+	} {
+		tf(t, n)
+	}
+}
+
+func testFanOut(t *testing.T, n scheduling.Notifier) {
+	t.Run("testFanOut", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// Nothing is delivered without subscribers
+		require.NoError(t, n.Notify(ctx, &scheduling.Hint{Address: "unobserved"}))
+
+		var subscribers []<-chan *scheduling.Hint
+		for i := 0; i < 3; i++ {
+			subscriber, err := n.Subscribe(ctx)
+			require.NoError(t, err)
+			subscribers = append(subscribers, subscriber)
+		}
+
+		expected := []*scheduling.Hint{
+			{Address: "a1", IntentOrderingIndex: 1, ActionOrderingIndex: 2, FulfillmentOrderingIndex: 3},
+			{Address: "a2", IntentOrderingIndex: 4, ActionOrderingIndex: 5, FulfillmentOrderingIndex: 6},
+		}
+		require.NoError(t, n.Notify(ctx, expected...))
+
+		for _, subscriber := range subscribers {
+			for _, hint := range expected {
+				select {
+				case actual := <-subscriber:
+					assert.Equal(t, hint, actual)
+				case <-time.After(5 * time.Second):
+					require.Fail(t, "timed out waiting for hint")
+				}
+			}
+		}
+	})
+}
+
+func testUnsubscribe(t *testing.T, n scheduling.Notifier) {
+	t.Run("testUnsubscribe", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		subscriber, err := n.Subscribe(ctx)
+		require.NoError(t, err)
+
+		cancel()
+
+		select {
+		case _, ok := <-subscriber:
+			assert.False(t, ok)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "timed out waiting for subscription to close")
+		}
+
+		require.NoError(t, n.Notify(context.Background(), &scheduling.Hint{Address: "a1"}))
+	})
+}
@@ -0,0 +1,330 @@
+package subsidizer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/code-payments/ocp-server/ocp/common"
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/feeburn"
+	"github.com/code-payments/ocp-server/ocp/data/nonce"
+	"github.com/code-payments/ocp-server/ocp/data/swap"
+	vm_operation "github.com/code-payments/ocp-server/ocp/data/vm/operation"
+)
+
+var (
+	// Swap states where the subsidizer will pay for a transaction that hasn't
+	// been finalized yet
+	inFlightSwapStates = []swap.State{
+		swap.StateFunded,
+		swap.StateSubmitting,
+		swap.StateCancelling,
+	}
+)
+
+// Snapshot is a point in time view of the subsidizer's budget
+type Snapshot struct {
+	// Balance is the subsidizer's current balance
+	Balance uint64
+
+	// Committed is the estimated cost of in flight work that's been persisted,
+	// like pending fulfillments, swaps and nonce accounts being created
+	Committed uint64
+
+	// Reserved is the estimated cost of work that's been approved, but might
+	// not yet be reflected in Committed
+	Reserved uint64
+
+	// MinBalance is the balance the subsidizer must not drop below
+	MinBalance uint64
+
+	// Available is the amount that can still be reserved
+	Available uint64
+
+	// SpendRate is the observed spend in lamports per second, or zero when
+	// there's no recent spend
+	SpendRate float64
+
+	// Runway is the projected time until Available is depleted at the current
+	// SpendRate, or zero when it can't be projected
+	Runway time.Duration
+}
+
+type reservation struct {
+	lamports  uint64
+	expiresAt time.Time
+}
+
+type spend struct {
+	lamports uint64
+	at       time.Time
+}
+
+// Budget tracks the lamports the subsidizer is expected to spend, so work is
+// only approved when the subsidizer can pay for it.
+//
+// Approved work is reserved in memory until it's reflected in the DB, which
+// guarantees concurrent callers sharing a Budget can't collectively overspend.
+// Reservations expire after a configured TTL, by which point they're expected
+// to be counted as committed. Callers that don't follow through on approved
+// work should release the reservation.
+//
+// Fees are estimated from the fees observed in recently confirmed transactions,
+// falling back to a static worst case until there are observations.
+type Budget struct {
+	conf *conf
+	data ocp_data.Provider
+
+	mu           sync.Mutex
+	reservations map[string]*reservation
+	feesByType   map[Expense][]uint64
+	spends       []*spend
+}
+
+// NewBudget returns a new subsidizer Budget. A single Budget should be shared
+// by everything in the process that spends the subsidizer's balance.
+func NewBudget(data ocp_data.Provider, configProvider ConfigProvider) *Budget {
+	return &Budget{
+		conf:         configProvider(),
+		data:         data,
+		reservations: make(map[string]*reservation),
+		feesByType:   make(map[Expense][]uint64),
+	}
+}
+
+// Reserve reserves the estimated cost of an expense under the provided ID.
+// Reserving an ID again replaces the existing reservation.
+//
+// Returns common.ErrSubsidizerRequiresFunding if the reservation would bring
+// the subsidizer's balance below the minimum.
+func (b *Budget) Reserve(ctx context.Context, id string, expense Expense) error {
+	balance, committed, err := b.getBalanceAndCommitted(ctx)
+	if err != nil {
+		return err
+	}
+
+	minBalance := b.conf.minBalance.Get(ctx)
+	ttl := b.conf.reservationTtl.Get(ctx)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.pruneReservations(now)
+
+	cost := b.estimateCost(expense)
+
+	var reserved uint64
+	for existingId, existing := range b.reservations {
+		if existingId != id {
+			reserved += existing.lamports
+		}
+	}
+
+	if getAvailable(balance, committed+reserved+cost, minBalance) == 0 {
+		return common.ErrSubsidizerRequiresFunding
+	}
+
+	b.reservations[id] = &reservation{
+		lamports:  cost,
+		expiresAt: now.Add(ttl),
+	}
+	return nil
+}
+
+// Release releases a reservation for work that won't be done
+func (b *Budget) Release(id string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.reservations, id)
+}
+
+// ObserveFee records the fee paid by the subsidizer for an expense in a
+// confirmed transaction
+func (b *Budget) ObserveFee(ctx context.Context, expense Expense, fee uint64) {
+	sampleSize := int(b.conf.feeSampleSize.Get(ctx))
+	window := b.conf.spendRateWindow.Get(ctx)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+
+	fees := append(b.feesByType[expense], fee)
+	if len(fees) > sampleSize {
+		fees = fees[len(fees)-sampleSize:]
+	}
+	b.feesByType[expense] = fees
+
+	b.spends = append(b.spends, &spend{
+		lamports: getStaticCost(expense).rent + fee,
+		at:       now,
+	})
+	b.pruneSpends(now, window)
+}
+
+// EstimateCost estimates the number of lamports the subsidizer will pay for an
+// expense
+func (b *Budget) EstimateCost(expense Expense) uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.estimateCost(expense)
+}
+
+// GetSnapshot gets a point in time view of the subsidizer's budget
+func (b *Budget) GetSnapshot(ctx context.Context) (*Snapshot, error) {
+	balance, committed, err := b.getBalanceAndCommitted(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	minBalance := b.conf.minBalance.Get(ctx)
+	window := b.conf.spendRateWindow.Get(ctx)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.pruneReservations(now)
+	b.pruneSpends(now, window)
+
+	var reserved uint64
+	for _, existing := range b.reservations {
+		reserved += existing.lamports
+	}
+
+	var spent uint64
+	for _, s := range b.spends {
+		spent += s.lamports
+	}
+
+	snapshot := &Snapshot{
+		Balance:    balance,
+		Committed:  committed,
+		Reserved:   reserved,
+		MinBalance: minBalance,
+		Available:  getAvailable(balance, committed+reserved, minBalance),
+	}
+
+	if spent > 0 && window > 0 {
+		snapshot.SpendRate = float64(spent) / window.Seconds()
+		snapshot.Runway = time.Duration(float64(snapshot.Available) / snapshot.SpendRate * float64(time.Second))
+	}
+
+	return snapshot, nil
+}
+
+func (b *Budget) getBalanceAndCommitted(ctx context.Context) (uint64, uint64, error) {
+	balance, err := common.GetCurrentSubsidizerBalance(ctx, b.data)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	committed, err := b.estimateCommitted(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return balance, committed, nil
+}
+
+// estimateCommitted estimates the number of lamports that will be used by in
+// flight work that's been persisted to the DB
+func (b *Budget) estimateCommitted(ctx context.Context) (uint64, error) {
+	var committed uint64
+
+	pendingFulfillmentsByType, err := b.data.GetPendingFulfillmentCountByType(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for fulfillmentType, count := range pendingFulfillmentsByType {
+		committed += count * b.EstimateCost(FulfillmentExpense(fulfillmentType))
+	}
+
+	var inFlightSwaps uint64
+	for _, state := range inFlightSwapStates {
+		count, err := b.data.GetSwapCountByState(ctx, state)
+		if err != nil {
+			return 0, err
+		}
+		inFlightSwaps += count
+	}
+	committed += inFlightSwaps * b.EstimateCost(ExpenseSwap)
+
+	numNoncesBeingCreated, err := b.data.GetNonceCountByState(ctx, nonce.EnvironmentSolana, nonce.EnvironmentInstanceSolanaMainnet, nonce.StateUnknown)
+	if err != nil {
+		return 0, err
+	}
+	committed += numNoncesBeingCreated * b.EstimateCost(ExpenseCreateNonceAccount)
+
+	numPendingFeeBurns, err := b.data.GetCurrencyFeeBurnCountByState(ctx, feeburn.StatePending)
+	if err != nil {
+		return 0, err
+	}
+	committed += numPendingFeeBurns * b.EstimateCost(ExpenseBurnFees)
+
+	numPendingVmOperations, err := b.data.GetVmOperationCountByState(ctx, vm_operation.StatePending)
+	if err != nil {
+		return 0, err
+	}
+	committed += numPendingVmOperations * b.EstimateCost(ExpenseVmOperation)
+
+	return committed, nil
+}
+
+// estimateCost uses the worst recently observed fee, since underestimating is
+// far more costly than temporarily pausing work.
+//
+// Assumes the lock is held.
+func (b *Budget) estimateCost(expense Expense) uint64 {
+	static := getStaticCost(expense)
+
+	fees, ok := b.feesByType[expense]
+	if !ok || len(fees) == 0 {
+		return static.rent + static.defaultFee
+	}
+
+	var maxFee uint64
+	for _, fee := range fees {
+		if fee > maxFee {
+			maxFee = fee
+		}
+	}
+	return static.rent + maxFee
+}
+
+// Assumes the lock is held
+func (b *Budget) pruneReservations(now time.Time) {
+	for id, existing := range b.reservations {
+		if now.After(existing.expiresAt) {
+			delete(b.reservations, id)
+		}
+	}
+}
+
+// Assumes the lock is held
+func (b *Budget) pruneSpends(now time.Time, window time.Duration) {
+	var i int
+	for i < len(b.spends) && now.Sub(b.spends[i].at) > window {
+		i++
+	}
+	b.spends = b.spends[i:]
+}
+
+func getStaticCost(expense Expense) staticCost {
+	static, ok := staticCostsByExpense[expense]
+	if !ok {
+		return staticCost{defaultFee: unknownExpenseDefaultFee}
+	}
+	return static
+}
+
+func getAvailable(balance, used, minBalance uint64) uint64 {
+	if used >= balance || balance-used <= minBalance {
+		return 0
+	}
+	return balance - used - minBalance
+}
@@ -0,0 +1,259 @@
+package subsidizer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/code-payments/ocp-server/ocp/common"
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/action"
+	"github.com/code-payments/ocp-server/ocp/data/fulfillment"
+	"github.com/code-payments/ocp-server/ocp/data/intent"
+	"github.com/code-payments/ocp-server/ocp/data/nonce"
+	"github.com/code-payments/ocp-server/ocp/data/swap"
+	"github.com/code-payments/ocp-server/pointer"
+	"github.com/code-payments/ocp-server/solana"
+)
+
+func TestBudget_EstimateCommitted(t *testing.T) {
+	env := setup(t, &testOverrides{})
+
+	fulfillmentRecords := []*fulfillment.Record{
+		// These records are included in fee calculation
+		{IntentType: intent.SendPublicPayment, ActionType: action.NoPrivacyTransfer, FulfillmentType: fulfillment.NoPrivacyTransferWithAuthority, State: fulfillment.StatePending, Intent: "i1", Data: []byte("txn"), Nonce: pointer.String("n1"), Blockhash: pointer.String("bh1"), Signature: pointer.String("s1"), Source: "source", Destination: pointer.String("destination")},
+		{IntentType: intent.SendPublicPayment, ActionType: action.NoPrivacyTransfer, FulfillmentType: fulfillment.NoPrivacyTransferWithAuthority, State: fulfillment.StatePending, Intent: "i2", Data: []byte("txn"), Nonce: pointer.String("n2"), Blockhash: pointer.String("bh2"), Signature: pointer.String("s2"), Source: "source", Destination: pointer.String("destination")},
+		{IntentType: intent.OpenAccounts, ActionType: action.OpenAccount, FulfillmentType: fulfillment.InitializeLockedTimelockAccount, State: fulfillment.StatePending, Intent: "i3", Data: []byte("txn"), Nonce: pointer.String("n3"), Blockhash: pointer.String("bh3"), Signature: pointer.String("s3"), Source: "source"},
+
+		// These records aren't included in fee calculation
+		{IntentType: intent.SendPublicPayment, ActionType: action.NoPrivacyTransfer, FulfillmentType: fulfillment.NoPrivacyTransferWithAuthority, State: fulfillment.StateUnknown, Intent: "i4", Data: []byte("txn"), Nonce: pointer.String("n4"), Blockhash: pointer.String("bh4"), Signature: pointer.String("s4"), Source: "source", Destination: pointer.String("destination")},
+		{IntentType: intent.SendPublicPayment, ActionType: action.NoPrivacyTransfer, FulfillmentType: fulfillment.NoPrivacyTransferWithAuthority, State: fulfillment.StateFailed, Intent: "i5", Data: []byte("txn"), Nonce: pointer.String("n5"), Blockhash: pointer.String("bh5"), Signature: pointer.String("s5"), Source: "source", Destination: pointer.String("destination")},
+		{IntentType: intent.SendPublicPayment, ActionType: action.NoPrivacyTransfer, FulfillmentType: fulfillment.NoPrivacyTransferWithAuthority, State: fulfillment.StateRevoked, Intent: "i6", Data: []byte("txn"), Nonce: pointer.String("n6"), Blockhash: pointer.String("bh6"), Signature: pointer.String("s6"), Source: "source", Destination: pointer.String("destination")},
+		{IntentType: intent.OpenAccounts, ActionType: action.OpenAccount, FulfillmentType: fulfillment.InitializeLockedTimelockAccount, State: fulfillment.StateConfirmed, Intent: "i7", Data: []byte("txn"), Nonce: pointer.String("n7"), Blockhash: pointer.String("bh7"), Signature: pointer.String("s7"), Source: "source"},
+	}
+	require.NoError(t, env.data.PutAllFulfillments(env.ctx, fulfillmentRecords...))
+
+	nonceRecords := []*nonce.Record{
+		// These records are included in fee calculation
+		{Address: "n1", State: nonce.StateUnknown},
+		{Address: "n2", State: nonce.StateUnknown},
+		{Address: "n3", State: nonce.StateUnknown},
+
+		// Theese records aren't included in fee calculation
+		{Address: "n4", State: nonce.StateInvalid},
+		{Address: "n5", State: nonce.StateReserved, Blockhash: "bh5"},
+		{Address: "n6", State: nonce.StateReleased, Blockhash: "bh6"},
+	}
+	for _, nonceRecord := range nonceRecords {
+		nonceRecord.Authority = "code"
+		nonceRecord.Environment = nonce.EnvironmentSolana
+		nonceRecord.EnvironmentInstance = nonce.EnvironmentInstanceSolanaMainnet
+		nonceRecord.Purpose = nonce.PurposeClientIntent
+		require.NoError(t, env.data.SaveNonce(env.ctx, nonceRecord))
+	}
+
+	for i, state := range []swap.State{
+		// These records are included in fee calculation
+		swap.StateFunded,
+		swap.StateSubmitting,
+		swap.StateCancelling,
+
+		// These records aren't included in fee calculation
+		swap.StateCreated,
+		swap.StateFunding,
+		swap.StateFinalized,
+		swap.StateFailed,
+		swap.StateCancelled,
+	} {
+		require.NoError(t, env.data.SaveSwap(env.ctx, &swap.Record{
+			SwapId:         fmt.Sprintf("swap%d", i),
+			Owner:          "owner",
+			FromMint:       "from",
+			ToMint:         "to",
+			Amount:         1,
+			FundingId:      fmt.Sprintf("funding%d", i),
+			FundingSource:  swap.FundingSourceSubmitIntent,
+			Nonce:          fmt.Sprintf("swapnonce%d", i),
+			Blockhash:      "bh",
+			ProofSignature: "proof",
+			State:          state,
+		}))
+	}
+
+	committed, err := env.budget.estimateCommitted(env.ctx)
+	require.NoError(t, err)
+	assert.EqualValues(
+		t,
+		3*getDefaultCost(ExpenseCreateNonceAccount)+
+			2*getDefaultCost(FulfillmentExpense(fulfillment.NoPrivacyTransferWithAuthority))+
+			getDefaultCost(FulfillmentExpense(fulfillment.InitializeLockedTimelockAccount))+
+			3*getDefaultCost(ExpenseSwap),
+		committed,
+	)
+}
+
+func TestBudget_EstimateCost_ObservedFees(t *testing.T) {
+	env := setup(t, &testOverrides{
+		feeSampleSize: 3,
+	})
+
+	expense := FulfillmentExpense(fulfillment.NoPrivacyTransferWithAuthority)
+	rent := staticCostsByExpense[expense].rent
+
+	assert.EqualValues(t, getDefaultCost(expense), env.budget.EstimateCost(expense))
+
+	for _, fee := range []uint64{100_000, 5_000, 6_000} {
+		env.budget.ObserveFee(env.ctx, expense, fee)
+	}
+	assert.EqualValues(t, rent+100_000, env.budget.EstimateCost(expense))
+
+	// The highest fee falls outside the sample
+	env.budget.ObserveFee(env.ctx, expense, 5_000)
+	assert.EqualValues(t, rent+6_000, env.budget.EstimateCost(expense))
+
+	// Other expenses are unaffected
+	assert.EqualValues(t, getDefaultCost(ExpenseSwap), env.budget.EstimateCost(ExpenseSwap))
+}
+
+func TestBudget_Reserve(t *testing.T) {
+	env := setup(t, &testOverrides{
+		minBalance:     1_000_000,
+		reservationTtl: time.Minute,
+	})
+
+	cost := env.budget.EstimateCost(ExpenseSwap)
+	env.balance = 1_000_000 + 3*cost
+
+	require.NoError(t, env.budget.Reserve(env.ctx, "id1", ExpenseSwap))
+	require.NoError(t, env.budget.Reserve(env.ctx, "id2", ExpenseSwap))
+
+	// Reserving an existing ID doesn't double count
+	require.NoError(t, env.budget.Reserve(env.ctx, "id2", ExpenseSwap))
+
+	// The last reservation would bring the balance to the minimum
+	assert.Equal(t, common.ErrSubsidizerRequiresFunding, env.budget.Reserve(env.ctx, "id3", ExpenseSwap))
+
+	snapshot, err := env.budget.GetSnapshot(env.ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2*cost, snapshot.Reserved)
+	assert.EqualValues(t, cost, snapshot.Available)
+
+	env.budget.Release("id1")
+	require.NoError(t, env.budget.Reserve(env.ctx, "id3", ExpenseSwap))
+}
+
+func TestBudget_Reserve_Concurrent(t *testing.T) {
+	env := setup(t, &testOverrides{
+		reservationTtl: time.Minute,
+	})
+
+	cost := env.budget.EstimateCost(ExpenseSwap)
+	env.balance = 10*cost + 1
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var approved int
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			err := env.budget.Reserve(env.ctx, fmt.Sprintf("id%d", i), ExpenseSwap)
+			if err == common.ErrSubsidizerRequiresFunding {
+				return
+			}
+			require.NoError(t, err)
+
+			mu.Lock()
+			approved++
+			mu.Unlock()
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 10, approved)
+}
+
+func TestBudget_Reserve_Expiry(t *testing.T) {
+	env := setup(t, &testOverrides{
+		reservationTtl: 50 * time.Millisecond,
+	})
+
+	cost := env.budget.EstimateCost(ExpenseSwap)
+	env.balance = cost + 1
+
+	require.NoError(t, env.budget.Reserve(env.ctx, "id1", ExpenseSwap))
+	assert.Equal(t, common.ErrSubsidizerRequiresFunding, env.budget.Reserve(env.ctx, "id2", ExpenseSwap))
+
+	time.Sleep(100 * time.Millisecond)
+
+	require.NoError(t, env.budget.Reserve(env.ctx, "id2", ExpenseSwap))
+}
+
+func TestBudget_GetSnapshot_Runway(t *testing.T) {
+	env := setup(t, &testOverrides{
+		minBalance:      1_000,
+		spendRateWindow: time.Hour,
+	})
+
+	env.balance = 3_601_000
+
+	snapshot, err := env.budget.GetSnapshot(env.ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3_600_000, snapshot.Available)
+	assert.Zero(t, snapshot.SpendRate)
+	assert.Zero(t, snapshot.Runway)
+
+	env.budget.ObserveFee(env.ctx, ExpenseSwap, 1_800)
+	env.budget.ObserveFee(env.ctx, ExpenseSwap, 1_800)
+
+	snapshot, err = env.budget.GetSnapshot(env.ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, snapshot.SpendRate)
+	assert.Equal(t, time.Duration(3_600_000)*time.Second, snapshot.Runway)
+}
+
+type testEnv struct {
+	ctx     context.Context
+	data    *testDataProvider
+	budget  *Budget
+	balance uint64
+}
+
+func setup(t *testing.T, overrides *testOverrides) *testEnv {
+	ctx := context.Background()
+
+	subsidizer, err := common.NewRandomAccount()
+	require.NoError(t, err)
+	require.NoError(t, common.InjectTestSubsidizer(ctx, ocp_data.NewTestDataProvider(), subsidizer))
+
+	env := &testEnv{
+		ctx: ctx,
+	}
+	env.data = &testDataProvider{
+		Provider: ocp_data.NewTestDataProvider(),
+		env:      env,
+	}
+	env.budget = NewBudget(env.data, withManualTestOverrides(overrides))
+	return env
+}
+
+func getDefaultCost(expense Expense) uint64 {
+	static := staticCostsByExpense[expense]
+	return static.rent + static.defaultFee
+}
+
+type testDataProvider struct {
+	ocp_data.Provider
+
+	env *testEnv
+}
+
+func (p *testDataProvider) GetBlockchainAccountInfo(_ context.Context, _ string, _ solana.Commitment) (*solana.AccountInfo, error) {
+	return &solana.AccountInfo{Lamports: p.env.balance}, nil
+}
@@ -0,0 +1,66 @@
+package subsidizer
+
+import (
+	"time"
+
+	"github.com/code-payments/ocp-server/config"
+	"github.com/code-payments/ocp-server/config/env"
+	"github.com/code-payments/ocp-server/config/memory"
+	"github.com/code-payments/ocp-server/config/wrapper"
+)
+
+const (
+	envConfigPrefix = "SUBSIDIZER_BUDGET_"
+
+	MinBalanceConfigEnvName = envConfigPrefix + "MIN_BALANCE"
+	defaultMinBalance       = 10_000_000_000 // 10 SOL
+
+	ReservationTtlConfigEnvName = envConfigPrefix + "RESERVATION_TTL"
+	defaultReservationTtl       = time.Minute
+
+	FeeSampleSizeConfigEnvName = envConfigPrefix + "FEE_SAMPLE_SIZE"
+	defaultFeeSampleSize       = 100
+
+	SpendRateWindowConfigEnvName = envConfigPrefix + "SPEND_RATE_WINDOW"
+	defaultSpendRateWindow       = time.Hour
+)
+
+type conf struct {
+	minBalance      config.Uint64
+	reservationTtl  config.Duration
+	feeSampleSize   config.Uint64
+	spendRateWindow config.Duration
+}
+
+// ConfigProvider defines how config values are pulled
+type ConfigProvider func() *conf
+
+// WithEnvConfigs returns configuration pulled from environment variables
+func WithEnvConfigs() ConfigProvider {
+	return func() *conf {
+		return &conf{
+			minBalance:      env.NewUint64Config(MinBalanceConfigEnvName, defaultMinBalance),
+			reservationTtl:  env.NewDurationConfig(ReservationTtlConfigEnvName, defaultReservationTtl),
+			feeSampleSize:   env.NewUint64Config(FeeSampleSizeConfigEnvName, defaultFeeSampleSize),
+			spendRateWindow: env.NewDurationConfig(SpendRateWindowConfigEnvName, defaultSpendRateWindow),
+		}
+	}
+}
+
+type testOverrides struct {
+	minBalance      uint64
+	reservationTtl  time.Duration
+	feeSampleSize   uint64
+	spendRateWindow time.Duration
+}
+
+func withManualTestOverrides(overrides *testOverrides) ConfigProvider {
+	return func() *conf {
+		return &conf{
+			minBalance:      wrapper.NewUint64Config(memory.NewConfig(overrides.minBalance), defaultMinBalance),
+			reservationTtl:  wrapper.NewDurationConfig(memory.NewConfig(overrides.reservationTtl), defaultReservationTtl),
+			feeSampleSize:   wrapper.NewUint64Config(memory.NewConfig(overrides.feeSampleSize), defaultFeeSampleSize),
+			spendRateWindow: wrapper.NewDurationConfig(memory.NewConfig(overrides.spendRateWindow), defaultSpendRateWindow),
+		}
+	}
+}
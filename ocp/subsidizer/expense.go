@@ -0,0 +1,45 @@
+package subsidizer
+
+import (
+	"github.com/code-payments/ocp-server/ocp/data/fulfillment"
+)
+
+// Expense is a type of transaction that's paid for by the subsidizer
+type Expense string
+
+const (
+	ExpenseSwap               Expense = "swap"
+	ExpenseCreateNonceAccount Expense = "create_nonce_account"
+	ExpenseBurnFees           Expense = "burn_fees"
+	ExpenseVmOperation        Expense = "vm_operation"
+)
+
+// FulfillmentExpense gets the Expense for a fulfillment type
+func FulfillmentExpense(fulfillmentType fulfillment.Type) Expense {
+	return Expense("fulfillment_" + fulfillmentType.String())
+}
+
+// staticCost is the cost of an expense that's known ahead of time. Rent is
+// deterministic, while fees are only a worst case estimate used until fees
+// have been observed from confirmed transactions.
+type staticCost struct {
+	rent       uint64
+	defaultFee uint64
+}
+
+// todo: doesn't consider external deposits
+var (
+	staticCostsByExpense = map[Expense]staticCost{
+		FulfillmentExpense(fulfillment.InitializeLockedTimelockAccount): {defaultFee: 5050},
+		FulfillmentExpense(fulfillment.NoPrivacyTransferWithAuthority):  {rent: 203928, defaultFee: 5125},
+		FulfillmentExpense(fulfillment.NoPrivacyWithdraw):               {defaultFee: 5100},
+		FulfillmentExpense(fulfillment.CloseEmptyTimelockAccount):       {defaultFee: 5100},
+		ExpenseSwap:               {defaultFee: 20_000},
+		ExpenseCreateNonceAccount: {rent: 1_447_680, defaultFee: 10_100},
+		ExpenseBurnFees:           {defaultFee: 5_050},
+		ExpenseVmOperation:        {defaultFee: 10_100},
+	}
+
+	// Used for any expense without a known static cost
+	unknownExpenseDefaultFee uint64 = 20_000
+)
@@ -8,6 +8,7 @@ import (
 
 	"github.com/code-payments/ocp-server/ocp/common"
 	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	vm_registry "github.com/code-payments/ocp-server/ocp/data/vm/registry"
 	"github.com/code-payments/ocp-server/solana"
 	"github.com/code-payments/ocp-server/solana/system"
 )
@@ -20,12 +21,21 @@ func GetAltForMint(ctx context.Context, data ocp_data.Provider, mint *common.Acc
 		return solana.AddressLookupTable{}, err
 	}
 
-	account, err := common.NewAccountFromPublicKeyString(metadataRecord.Alt)
+	// Prefer the ALT tracked alongside the mint's VM, when one is registered
+	altAddress := metadataRecord.Alt
+	registryRecord, err := data.GetRegisteredVmByMint(ctx, mint.PublicKey().ToBase58())
+	if err == nil && len(registryRecord.Alt) > 0 {
+		altAddress = registryRecord.Alt
+	} else if err != nil && err != vm_registry.ErrNotFound {
+		return solana.AddressLookupTable{}, err
+	}
+
+	account, err := common.NewAccountFromPublicKeyString(altAddress)
 	if err != nil {
 		return solana.AddressLookupTable{}, err
 	}
 
-	vmConfig, err := common.GetVmConfigForMint(ctx, data, mint)
+	vmConfig, err := common.GetPublicVmConfigForMint(ctx, data, mint)
 	if err != nil {
 		return solana.AddressLookupTable{}, err
 	}
@@ -260,6 +260,7 @@ type LocalNoncePool struct {
 
 	workerCtx       context.Context
 	cancelWorkerCtx context.CancelFunc
+	workers         sync.WaitGroup
 
 	mu       sync.RWMutex
 	freeList []*Nonce
@@ -314,6 +315,7 @@ func NewLocalNoncePool(
 		return nil, err
 	}
 
+	np.workers.Add(3)
 	go np.refreshPool()
 	go np.refreshNonces()
 	go np.metricsPoller()
@@ -378,14 +380,20 @@ func (np *LocalNoncePool) Close() error {
 	log := np.log.With(zap.String("method", "Close"))
 
 	np.mu.Lock()
-	defer np.mu.Unlock()
-
 	if np.isClosed {
+		np.mu.Unlock()
 		return nil
 	}
-
 	np.isClosed = true
 	np.cancelWorkerCtx()
+	np.mu.Unlock()
+
+	// Wait for background workers, so nothing is loaded into the free list
+	// after it's been released
+	np.workers.Wait()
+
+	np.mu.Lock()
+	defer np.mu.Unlock()
 
 	ctx, cancel := context.WithTimeout(context.Background(), np.opts.shutdownGracePeriod)
 	defer cancel()
@@ -467,6 +475,8 @@ func (np *LocalNoncePool) load(ctx context.Context, limit int) (int, error) {
 }
 
 func (np *LocalNoncePool) refreshPool() {
+	defer np.workers.Done()
+
 	log := np.log.With(zap.String("method", "refreshPool"))
 
 	for {
@@ -500,6 +510,8 @@ func (np *LocalNoncePool) refreshPool() {
 }
 
 func (np *LocalNoncePool) refreshNonces() {
+	defer np.workers.Done()
+
 	for {
 		select {
 		case <-np.workerCtx.Done():
@@ -567,6 +579,8 @@ func (np *LocalNoncePool) refreshNoncesNow() {
 }
 
 func (np *LocalNoncePool) metricsPoller() {
+	defer np.workers.Done()
+
 	for {
 		select {
 		case <-np.workerCtx.Done():
@@ -8,14 +8,14 @@ import (
 
 	"github.com/code-payments/ocp-server/ocp/common"
 	"github.com/code-payments/ocp-server/solana"
+	address_lookup_table "github.com/code-payments/ocp-server/solana/addresslookuptable"
 	compute_budget "github.com/code-payments/ocp-server/solana/computebudget"
+	"github.com/code-payments/ocp-server/solana/currencycreator"
 	"github.com/code-payments/ocp-server/solana/token"
 	"github.com/code-payments/ocp-server/solana/vm"
 )
 
-// todo: The argument sizes are blowing out of proportion, though there's likely
-//       a larger refactor going to happen anyways when we support batching of
-//       many virtual instructions into a single Solana transaction.
+// todo: The argument sizes are blowing out of proportion
 
 // MakeNoncedTransaction makes a transaction that's backed by a nonce. The returned
 // transaction is not signed.
@@ -38,6 +38,17 @@ func MakeNoncedTransaction(nonce *Nonce, instructions ...solana.Instruction) (so
 	return txn, nil
 }
 
+// MakeAdvanceNonceTransaction makes a transaction that only advances the nonce,
+// which invalidates any other transaction signed with its current blockhash.
+// The returned transaction is not signed.
+func MakeAdvanceNonceTransaction(nonce *Nonce) (solana.Transaction, error) {
+	instructions := []solana.Instruction{
+		compute_budget.SetComputeUnitPrice(1_000),
+		compute_budget.SetComputeUnitLimit(10_000),
+	}
+	return MakeNoncedTransaction(nonce, instructions...)
+}
+
 func MakeOpenAccountTransaction(
 	nonce *Nonce,
 
@@ -104,6 +115,422 @@ func MakeCompressAccountTransaction(
 	return MakeNoncedTransaction(nonce, instructions...)
 }
 
+func MakeDecompressAccountTransaction(
+	nonce *Nonce,
+
+	vmConfig *common.VmConfig,
+
+	memory *common.Account,
+	accountIndex uint16,
+
+	storage *common.Account,
+
+	// Only required for virtual timelock accounts
+	unlockPda *common.Account,
+	withdrawReceipt *common.Account,
+
+	virtualAccountType vm.VirtualAccountType,
+	virtualAccountState []byte,
+	proof vm.HashArray,
+) (solana.Transaction, error) {
+	hasher := sha256.New()
+	hasher.Write(virtualAccountState)
+	hashedVirtualAccountState := hasher.Sum(nil)
+
+	signature := ed25519.Sign(vmConfig.Authority.PrivateKey().ToBytes(), hashedVirtualAccountState)
+
+	packedVirtualAccount := append([]byte{byte(virtualAccountType)}, virtualAccountState...)
+
+	accounts := &vm.DecompressInstructionAccounts{
+		VmAuthority: vmConfig.Authority.PublicKey().ToBytes(),
+		Vm:          vmConfig.Vm.PublicKey().ToBytes(),
+		VmMemory:    memory.PublicKey().ToBytes(),
+		VmStorage:   storage.PublicKey().ToBytes(),
+	}
+	if unlockPda != nil {
+		unlockPdaPublicKey := ed25519.PublicKey(unlockPda.PublicKey().ToBytes())
+		accounts.UnlockPda = &unlockPdaPublicKey
+	}
+	if withdrawReceipt != nil {
+		withdrawReceiptPublicKey := ed25519.PublicKey(withdrawReceipt.PublicKey().ToBytes())
+		accounts.WithdrawReceipt = &withdrawReceiptPublicKey
+	}
+
+	decompressInstruction := vm.NewDecompressInstruction(
+		accounts,
+		&vm.DecompressInstructionArgs{
+			AccountIndex: accountIndex,
+			PackedVa:     packedVirtualAccount,
+			Proof:        proof,
+			Signature:    vm.Signature(signature),
+		},
+	)
+
+	instructions := []solana.Instruction{
+		compute_budget.SetComputeUnitPrice(1_000),
+		compute_budget.SetComputeUnitLimit(400_000),
+		decompressInstruction,
+	}
+	return MakeNoncedTransaction(nonce, instructions...)
+}
+
+// MakeInitMemoryTransaction makes a transaction that creates a memory account,
+// followed by resizing it to each of the provided account data sizes in order.
+// Memory accounts can only grow by a bounded amount per instruction, so large
+// accounts must continue to be resized with MakeResizeMemoryTransaction.
+func MakeInitMemoryTransaction(
+	nonce *Nonce,
+
+	vmConfig *common.VmConfig,
+
+	name string,
+	numAccounts uint32,
+	accountSize uint16,
+
+	resizes ...uint32,
+) (solana.Transaction, error) {
+	memoryAddress, memoryBump, err := vm.GetMemoryAccountAddress(&vm.GetMemoryAccountAddressArgs{
+		Name: name,
+		Vm:   vmConfig.Vm.PublicKey().ToBytes(),
+	})
+	if err != nil {
+		return solana.Transaction{}, err
+	}
+
+	memory, err := common.NewAccountFromPublicKeyBytes(memoryAddress)
+	if err != nil {
+		return solana.Transaction{}, err
+	}
+
+	instructions := []solana.Instruction{
+		compute_budget.SetComputeUnitPrice(1_000),
+		compute_budget.SetComputeUnitLimit(50_000 + 10_000*uint32(len(resizes))),
+		vm.NewInitMemoryInstruction(
+			&vm.InitMemoryInstructionAccounts{
+				VmAuthority: vmConfig.Authority.PublicKey().ToBytes(),
+				Vm:          vmConfig.Vm.PublicKey().ToBytes(),
+				VmMemory:    memory.PublicKey().ToBytes(),
+			},
+			&vm.InitMemoryInstructionArgs{
+				Name:         name,
+				NumAccounts:  numAccounts,
+				AccountSize:  accountSize,
+				VmMemoryBump: memoryBump,
+			},
+		),
+	}
+	instructions = append(instructions, makeResizeMemoryInstructions(vmConfig, memory, resizes...)...)
+	return MakeNoncedTransaction(nonce, instructions...)
+}
+
+// MakeResizeMemoryTransaction makes a transaction that resizes a memory account
+// to each of the provided account data sizes in order
+func MakeResizeMemoryTransaction(
+	nonce *Nonce,
+
+	vmConfig *common.VmConfig,
+
+	memory *common.Account,
+
+	resizes ...uint32,
+) (solana.Transaction, error) {
+	if len(resizes) == 0 {
+		return solana.Transaction{}, errors.New("no resizes provided")
+	}
+
+	instructions := []solana.Instruction{
+		compute_budget.SetComputeUnitPrice(1_000),
+		compute_budget.SetComputeUnitLimit(10_000 * uint32(len(resizes))),
+	}
+	instructions = append(instructions, makeResizeMemoryInstructions(vmConfig, memory, resizes...)...)
+	return MakeNoncedTransaction(nonce, instructions...)
+}
+
+func makeResizeMemoryInstructions(vmConfig *common.VmConfig, memory *common.Account, resizes ...uint32) []solana.Instruction {
+	instructions := make([]solana.Instruction, len(resizes))
+	for i, resize := range resizes {
+		instructions[i] = vm.NewResizeMemoryInstruction(
+			&vm.ResizeMemoryInstructionAccounts{
+				VmAuthority: vmConfig.Authority.PublicKey().ToBytes(),
+				Vm:          vmConfig.Vm.PublicKey().ToBytes(),
+				VmMemory:    memory.PublicKey().ToBytes(),
+			},
+			&vm.ResizeMemoryInstructionArgs{
+				AccountSize: resize,
+			},
+		)
+	}
+	return instructions
+}
+
+func MakeInitStorageTransaction(
+	nonce *Nonce,
+
+	vmConfig *common.VmConfig,
+
+	name string,
+) (solana.Transaction, error) {
+	storageAddress, storageBump, err := vm.GetStorageAccountAddress(&vm.GetMemoryAccountAddressArgs{
+		Name: name,
+		Vm:   vmConfig.Vm.PublicKey().ToBytes(),
+	})
+	if err != nil {
+		return solana.Transaction{}, err
+	}
+
+	instructions := []solana.Instruction{
+		compute_budget.SetComputeUnitPrice(1_000),
+		compute_budget.SetComputeUnitLimit(100_000),
+		vm.NewInitStorageInstruction(
+			&vm.InitStorageInstructionAccounts{
+				VmAuthority: vmConfig.Authority.PublicKey().ToBytes(),
+				Vm:          vmConfig.Vm.PublicKey().ToBytes(),
+				VmStorage:   storageAddress,
+			},
+			&vm.InitStorageInstructionArgs{
+				Name:          name,
+				VmStorageBump: storageBump,
+			},
+		),
+	}
+	return MakeNoncedTransaction(nonce, instructions...)
+}
+
+// MakeInitUnlockTransaction makes a transaction that starts the waiting period
+// to unlock a timelock account. It's backed by a recent blockhash, since the
+// owner signs and submits it. The returned transaction is not signed.
+func MakeInitUnlockTransaction(
+	blockhash solana.Blockhash,
+
+	timelockAccounts *common.TimelockAccounts,
+) (solana.Transaction, error) {
+	payer := common.GetSubsidizer()
+
+	instructions := []solana.Instruction{
+		compute_budget.SetComputeUnitPrice(1_000),
+		compute_budget.SetComputeUnitLimit(50_000),
+		vm.NewInitUnlockInstruction(
+			&vm.InitUnlockInstructionAccounts{
+				AccountOwner: timelockAccounts.VaultOwner.PublicKey().ToBytes(),
+				Payer:        payer.PublicKey().ToBytes(),
+				Vm:           timelockAccounts.Vm.PublicKey().ToBytes(),
+				UnlockState:  timelockAccounts.Unlock.PublicKey().ToBytes(),
+			},
+			&vm.InitUnlockInstructionArgs{},
+		),
+	}
+
+	txn := solana.NewLegacyTransaction(payer.PublicKey().ToBytes(), instructions...)
+	txn.SetBlockhash(blockhash)
+	return txn, nil
+}
+
+// MakeUnlockTransaction makes a transaction that unlocks a timelock account
+// after its waiting period has elapsed. It's backed by a recent blockhash, since
+// the owner signs and submits it. The returned transaction is not signed.
+func MakeUnlockTransaction(
+	blockhash solana.Blockhash,
+
+	timelockAccounts *common.TimelockAccounts,
+) (solana.Transaction, error) {
+	payer := common.GetSubsidizer()
+
+	instructions := []solana.Instruction{
+		compute_budget.SetComputeUnitPrice(1_000),
+		compute_budget.SetComputeUnitLimit(50_000),
+		vm.NewUnlockInstruction(
+			&vm.UnlockInstructionAccounts{
+				AccountOwner: timelockAccounts.VaultOwner.PublicKey().ToBytes(),
+				Payer:        payer.PublicKey().ToBytes(),
+				Vm:           timelockAccounts.Vm.PublicKey().ToBytes(),
+				UnlockState:  timelockAccounts.Unlock.PublicKey().ToBytes(),
+			},
+			&vm.UnlockInstructionArgs{},
+		),
+	}
+
+	txn := solana.NewLegacyTransaction(payer.PublicKey().ToBytes(), instructions...)
+	txn.SetBlockhash(blockhash)
+	return txn, nil
+}
+
+// MakeWithdrawFromMemoryTransaction makes a transaction that withdraws the full
+// balance of an unlocked virtual timelock account in memory to the destination
+// token account. It's backed by a recent blockhash, since the owner signs and
+// submits it. The returned transaction is not signed.
+func MakeWithdrawFromMemoryTransaction(
+	blockhash solana.Blockhash,
+
+	vmConfig *common.VmConfig,
+	timelockAccounts *common.TimelockAccounts,
+
+	memory *common.Account,
+	accountIndex uint16,
+	withdrawReceipt *common.Account,
+
+	destination *common.Account,
+) (solana.Transaction, error) {
+	omnibusPublicKey := ed25519.PublicKey(vmConfig.Omnibus.PublicKey().ToBytes())
+	memoryPublicKey := ed25519.PublicKey(memory.PublicKey().ToBytes())
+	withdrawReceiptPublicKey := ed25519.PublicKey(withdrawReceipt.PublicKey().ToBytes())
+
+	return makeWithdrawTransaction(
+		blockhash,
+		timelockAccounts,
+		&vm.WithdrawInstructionAccounts{
+			VmOmnibus:       &omnibusPublicKey,
+			VmMemory:        &memoryPublicKey,
+			WithdrawReceipt: &withdrawReceiptPublicKey,
+		},
+		&vm.WithdrawInstructionArgs{
+			Variant:      vm.WithdrawVariantFromMemory,
+			AccountIndex: accountIndex,
+		},
+		destination,
+	)
+}
+
+// MakeWithdrawFromStorageTransaction makes a transaction that withdraws the full
+// balance of an unlocked virtual timelock account that's been compressed into
+// storage. The VM authority attests to the compressed state, so the provided VM
+// config must include its private key. It's backed by a recent blockhash, since
+// the owner signs and submits it. The returned transaction is not signed.
+func MakeWithdrawFromStorageTransaction(
+	blockhash solana.Blockhash,
+
+	vmConfig *common.VmConfig,
+	timelockAccounts *common.TimelockAccounts,
+
+	storage *common.Account,
+	withdrawReceipt *common.Account,
+	virtualAccountState []byte,
+	proof vm.HashArray,
+
+	destination *common.Account,
+) (solana.Transaction, error) {
+	hasher := sha256.New()
+	hasher.Write(virtualAccountState)
+	hashedVirtualAccountState := hasher.Sum(nil)
+
+	signature := ed25519.Sign(vmConfig.Authority.PrivateKey().ToBytes(), hashedVirtualAccountState)
+
+	packedVirtualAccount := append([]byte{byte(vm.VirtualAccountTypeTimelock)}, virtualAccountState...)
+
+	omnibusPublicKey := ed25519.PublicKey(vmConfig.Omnibus.PublicKey().ToBytes())
+	storagePublicKey := ed25519.PublicKey(storage.PublicKey().ToBytes())
+	withdrawReceiptPublicKey := ed25519.PublicKey(withdrawReceipt.PublicKey().ToBytes())
+
+	return makeWithdrawTransaction(
+		blockhash,
+		timelockAccounts,
+		&vm.WithdrawInstructionAccounts{
+			VmOmnibus:       &omnibusPublicKey,
+			VmStorage:       &storagePublicKey,
+			WithdrawReceipt: &withdrawReceiptPublicKey,
+		},
+		&vm.WithdrawInstructionArgs{
+			Variant:   vm.WithdrawVariantFromStorage,
+			PackedVa:  packedVirtualAccount,
+			Proof:     proof,
+			Signature: vm.Signature(signature),
+		},
+		destination,
+	)
+}
+
+// MakeWithdrawFromDepositTransaction makes a transaction that withdraws the
+// balance of an unlocked timelock account's deposit PDA that was never moved
+// into the VM. It's backed by a recent blockhash, since the owner signs and
+// submits it. The returned transaction is not signed.
+func MakeWithdrawFromDepositTransaction(
+	blockhash solana.Blockhash,
+
+	timelockAccounts *common.TimelockAccounts,
+
+	destination *common.Account,
+) (solana.Transaction, error) {
+	depositPdaPublicKey := ed25519.PublicKey(timelockAccounts.VmDepositAccounts.Pda.PublicKey().ToBytes())
+	depositAtaPublicKey := ed25519.PublicKey(timelockAccounts.VmDepositAccounts.Ata.PublicKey().ToBytes())
+
+	return makeWithdrawTransaction(
+		blockhash,
+		timelockAccounts,
+		&vm.WithdrawInstructionAccounts{
+			DepositPda: &depositPdaPublicKey,
+			DepositAta: &depositAtaPublicKey,
+		},
+		&vm.WithdrawInstructionArgs{
+			Variant: vm.WithdrawVariantFromDeposit,
+			Bump:    timelockAccounts.VmDepositAccounts.PdaBump,
+		},
+		destination,
+	)
+}
+
+func makeWithdrawTransaction(
+	blockhash solana.Blockhash,
+
+	timelockAccounts *common.TimelockAccounts,
+
+	accounts *vm.WithdrawInstructionAccounts,
+	args *vm.WithdrawInstructionArgs,
+
+	destination *common.Account,
+) (solana.Transaction, error) {
+	payer := common.GetSubsidizer()
+
+	accounts.Depositor = timelockAccounts.VaultOwner.PublicKey().ToBytes()
+	accounts.Payer = payer.PublicKey().ToBytes()
+	accounts.Vm = timelockAccounts.Vm.PublicKey().ToBytes()
+	accounts.UnlockPda = timelockAccounts.Unlock.PublicKey().ToBytes()
+	accounts.ExternalAddress = destination.PublicKey().ToBytes()
+
+	instructions := []solana.Instruction{
+		compute_budget.SetComputeUnitPrice(1_000),
+		compute_budget.SetComputeUnitLimit(400_000),
+		vm.NewWithdrawInstruction(accounts, args),
+	}
+
+	txn := solana.NewLegacyTransaction(payer.PublicKey().ToBytes(), instructions...)
+	txn.SetBlockhash(blockhash)
+	return txn, nil
+}
+
+// BatchableInstructions are the instructions that execute a single virtual
+// instruction, which can be packed alongside others for the same VM into one
+// nonced transaction.
+type BatchableInstructions struct {
+	Vm           *common.Account
+	ComputeUnits uint32
+	Instructions []solana.Instruction
+}
+
+// MakeBatchedTransaction makes a nonced transaction that executes all of the
+// provided virtual instructions in order. The returned transaction is not signed.
+func MakeBatchedTransaction(nonce *Nonce, batchables ...*BatchableInstructions) (solana.Transaction, error) {
+	if len(batchables) == 0 {
+		return solana.Transaction{}, errors.New("no batchable instructions provided")
+	}
+
+	var computeLimit uint32
+	var batchedInstructions []solana.Instruction
+	for _, batchable := range batchables {
+		if !bytes.Equal(batchable.Vm.PublicKey().ToBytes(), batchables[0].Vm.PublicKey().ToBytes()) {
+			return solana.Transaction{}, errors.New("vm mismatch")
+		}
+
+		computeLimit += batchable.ComputeUnits
+		batchedInstructions = append(batchedInstructions, batchable.Instructions...)
+	}
+
+	instructions := []solana.Instruction{
+		compute_budget.SetComputeUnitPrice(1_000),
+		compute_budget.SetComputeUnitLimit(computeLimit),
+	}
+	instructions = append(instructions, batchedInstructions...)
+	return MakeNoncedTransaction(nonce, instructions...)
+}
+
 func MakeInternalWithdrawTransaction(
 	nonce *Nonce,
 
@@ -120,10 +547,40 @@ func MakeInternalWithdrawTransaction(
 	destinationMemory *common.Account,
 	destinationIndex uint16,
 ) (solana.Transaction, error) {
-	mergedMemoryBanks, err := MergeMemoryBanks(nonceMemory, sourceMemory, destinationMemory)
+	batchable, err := MakeInternalWithdrawInstructions(
+		vmConfig,
+		virtualSignature,
+		nonceMemory,
+		nonceIndex,
+		sourceMemory,
+		sourceIndex,
+		destinationMemory,
+		destinationIndex,
+	)
 	if err != nil {
 		return solana.Transaction{}, err
 	}
+	return MakeBatchedTransaction(nonce, batchable)
+}
+
+func MakeInternalWithdrawInstructions(
+	vmConfig *common.VmConfig,
+
+	virtualSignature solana.Signature,
+
+	nonceMemory *common.Account,
+	nonceIndex uint16,
+
+	sourceMemory *common.Account,
+	sourceIndex uint16,
+
+	destinationMemory *common.Account,
+	destinationIndex uint16,
+) (*BatchableInstructions, error) {
+	mergedMemoryBanks, err := MergeMemoryBanks(nonceMemory, sourceMemory, destinationMemory)
+	if err != nil {
+		return nil, err
+	}
 
 	vixn := vm.NewWithdrawVirtualInstruction(&vm.WithdrawVirtualInstructionArgs{
 		Signature: vm.Signature(virtualSignature),
@@ -145,12 +602,11 @@ func MakeInternalWithdrawTransaction(
 		},
 	)
 
-	instructions := []solana.Instruction{
-		compute_budget.SetComputeUnitPrice(1_000),
-		compute_budget.SetComputeUnitLimit(100_000),
-		execInstruction,
-	}
-	return MakeNoncedTransaction(nonce, instructions...)
+	return &BatchableInstructions{
+		Vm:           vmConfig.Vm,
+		ComputeUnits: 100_000,
+		Instructions: []solana.Instruction{execInstruction},
+	}, nil
 }
 
 func MakeExternalWithdrawTransaction(
@@ -168,10 +624,38 @@ func MakeExternalWithdrawTransaction(
 
 	externalDestination *common.Account,
 ) (solana.Transaction, error) {
-	mergedMemoryBanks, err := MergeMemoryBanks(nonceMemory, sourceMemory)
+	batchable, err := MakeExternalWithdrawInstructions(
+		vmConfig,
+		virtualSignature,
+		nonceMemory,
+		nonceIndex,
+		sourceMemory,
+		sourceIndex,
+		externalDestination,
+	)
 	if err != nil {
 		return solana.Transaction{}, err
 	}
+	return MakeBatchedTransaction(nonce, batchable)
+}
+
+func MakeExternalWithdrawInstructions(
+	vmConfig *common.VmConfig,
+
+	virtualSignature solana.Signature,
+
+	nonceMemory *common.Account,
+	nonceIndex uint16,
+
+	sourceMemory *common.Account,
+	sourceIndex uint16,
+
+	externalDestination *common.Account,
+) (*BatchableInstructions, error) {
+	mergedMemoryBanks, err := MergeMemoryBanks(nonceMemory, sourceMemory)
+	if err != nil {
+		return nil, err
+	}
 
 	vmOmnibusPublicKeyBytes := ed25519.PublicKey(vmConfig.Omnibus.PublicKey().ToBytes())
 
@@ -198,12 +682,11 @@ func MakeExternalWithdrawTransaction(
 		},
 	)
 
-	instructions := []solana.Instruction{
-		compute_budget.SetComputeUnitPrice(1_000),
-		compute_budget.SetComputeUnitLimit(100_000),
-		execInstruction,
-	}
-	return MakeNoncedTransaction(nonce, instructions...)
+	return &BatchableInstructions{
+		Vm:           vmConfig.Vm,
+		ComputeUnits: 100_000,
+		Instructions: []solana.Instruction{execInstruction},
+	}, nil
 }
 
 func MakeInternalTransferWithAuthorityTransaction(
@@ -224,10 +707,43 @@ func MakeInternalTransferWithAuthorityTransaction(
 
 	quarks uint64,
 ) (solana.Transaction, error) {
-	mergedMemoryBanks, err := MergeMemoryBanks(nonceMemory, sourceMemory, destinationMemory)
+	batchable, err := MakeInternalTransferWithAuthorityInstructions(
+		vmConfig,
+		virtualSignature,
+		nonceMemory,
+		nonceIndex,
+		sourceMemory,
+		sourceIndex,
+		destinationMemory,
+		destinationIndex,
+		quarks,
+	)
 	if err != nil {
 		return solana.Transaction{}, err
 	}
+	return MakeBatchedTransaction(nonce, batchable)
+}
+
+func MakeInternalTransferWithAuthorityInstructions(
+	vmConfig *common.VmConfig,
+
+	virtualSignature solana.Signature,
+
+	nonceMemory *common.Account,
+	nonceIndex uint16,
+
+	sourceMemory *common.Account,
+	sourceIndex uint16,
+
+	destinationMemory *common.Account,
+	destinationIndex uint16,
+
+	quarks uint64,
+) (*BatchableInstructions, error) {
+	mergedMemoryBanks, err := MergeMemoryBanks(nonceMemory, sourceMemory, destinationMemory)
+	if err != nil {
+		return nil, err
+	}
 
 	vixn := vm.NewTransferVirtualInstruction(&vm.TransferVirtualInstructionArgs{
 		Amount:    quarks,
@@ -250,12 +766,11 @@ func MakeInternalTransferWithAuthorityTransaction(
 		},
 	)
 
-	instructions := []solana.Instruction{
-		compute_budget.SetComputeUnitPrice(1_000),
-		compute_budget.SetComputeUnitLimit(100_000),
-		execInstruction,
-	}
-	return MakeNoncedTransaction(nonce, instructions...)
+	return &BatchableInstructions{
+		Vm:           vmConfig.Vm,
+		ComputeUnits: 100_000,
+		Instructions: []solana.Instruction{execInstruction},
+	}, nil
 }
 
 func MakeExternalTransferWithAuthorityTransaction(
@@ -278,10 +793,47 @@ func MakeExternalTransferWithAuthorityTransaction(
 	mint *common.Account,
 	quarks uint64,
 ) (solana.Transaction, error) {
-	mergedMemoryBanks, err := MergeMemoryBanks(nonceMemory, sourceMemory)
+	batchable, err := MakeExternalTransferWithAuthorityInstructions(
+		vmConfig,
+		virtualSignature,
+		nonceMemory,
+		nonceIndex,
+		sourceMemory,
+		sourceIndex,
+		externalDestinationOwner,
+		externalDestination,
+		isCreateOnSend,
+		mint,
+		quarks,
+	)
 	if err != nil {
 		return solana.Transaction{}, err
 	}
+	return MakeBatchedTransaction(nonce, batchable)
+}
+
+func MakeExternalTransferWithAuthorityInstructions(
+	vmConfig *common.VmConfig,
+
+	virtualSignature solana.Signature,
+
+	nonceMemory *common.Account,
+	nonceIndex uint16,
+
+	sourceMemory *common.Account,
+	sourceIndex uint16,
+
+	externalDestinationOwner *common.Account,
+	externalDestination *common.Account,
+
+	isCreateOnSend bool,
+	mint *common.Account,
+	quarks uint64,
+) (*BatchableInstructions, error) {
+	mergedMemoryBanks, err := MergeMemoryBanks(nonceMemory, sourceMemory)
+	if err != nil {
+		return nil, err
+	}
 
 	externalAddressPublicKeyBytes := ed25519.PublicKey(externalDestination.PublicKey().ToBytes())
 
@@ -314,13 +866,10 @@ func MakeExternalTransferWithAuthorityTransaction(
 		computeLimit = 125_000
 	}
 
-	instructions := []solana.Instruction{
-		compute_budget.SetComputeUnitPrice(1_000),
-		compute_budget.SetComputeUnitLimit(uint32(computeLimit)),
-	}
+	var instructions []solana.Instruction
 	if isCreateOnSend {
 		if externalDestinationOwner == nil {
-			return solana.Transaction{}, errors.New("destination owner is required")
+			return nil, errors.New("destination owner is required")
 		}
 
 		createIdempotentInstruction, ata, err := token.CreateAssociatedTokenAccountIdempotent(
@@ -329,14 +878,199 @@ func MakeExternalTransferWithAuthorityTransaction(
 			mint.PublicKey().ToBytes(),
 		)
 		if err != nil {
-			return solana.Transaction{}, err
+			return nil, err
 		} else if !bytes.Equal(externalDestination.PublicKey().ToBytes(), ata) {
-			return solana.Transaction{}, errors.New("invalid destination owner")
+			return nil, errors.New("invalid destination owner")
 		}
 
 		instructions = append(instructions, createIdempotentInstruction)
 	}
 	instructions = append(instructions, execInstruction)
+
+	return &BatchableInstructions{
+		Vm:           vmConfig.Vm,
+		ComputeUnits: uint32(computeLimit),
+		Instructions: instructions,
+	}, nil
+}
+
+func MakeInitializeLaunchpadCurrencyTransaction(
+	nonce *Nonce,
+
+	authority *common.Account,
+
+	name string,
+	symbol string,
+	seed *common.Account,
+	sellFeeBps uint16,
+
+	currencyAccounts *common.LaunchpadCurrencyAccounts,
+) (solana.Transaction, error) {
+	metadataAddress, _, err := currencycreator.GetMetadataAddress(&currencycreator.GetMetadataAddressArgs{
+		Mint: currencyAccounts.Mint.PublicKey().ToBytes(),
+	})
+	if err != nil {
+		return solana.Transaction{}, err
+	}
+
+	initializeCurrencyInstruction := currencycreator.NewInitializeCurrencyInstruction(
+		&currencycreator.InitializeCurrencyInstructionAccounts{
+			Authority: authority.PublicKey().ToBytes(),
+			Mint:      currencyAccounts.Mint.PublicKey().ToBytes(),
+			Currency:  currencyAccounts.CurrencyConfig.PublicKey().ToBytes(),
+		},
+		&currencycreator.InitializeCurrencyInstructionArgs{
+			Name:     name,
+			Symbol:   symbol,
+			Seed:     seed.PublicKey().ToBytes(),
+			Bump:     currencyAccounts.CurrencyConfigBump,
+			MintBump: currencyAccounts.MintBump,
+		},
+	)
+
+	initializePoolInstruction := currencycreator.NewInitializePoolInstruction(
+		&currencycreator.InitializePoolInstructionAccounts{
+			Authority:   authority.PublicKey().ToBytes(),
+			Currency:    currencyAccounts.CurrencyConfig.PublicKey().ToBytes(),
+			TargetMint:  currencyAccounts.Mint.PublicKey().ToBytes(),
+			BaseMint:    common.CoreMintAccount.PublicKey().ToBytes(),
+			Pool:        currencyAccounts.LiquidityPool.PublicKey().ToBytes(),
+			VaultTarget: currencyAccounts.VaultMint.PublicKey().ToBytes(),
+			VaultBase:   currencyAccounts.VaultBase.PublicKey().ToBytes(),
+		},
+		&currencycreator.InitializePoolInstructionArgs{
+			SellFee:         sellFeeBps,
+			Bump:            currencyAccounts.LiquidityPoolBump,
+			VaultTargetBump: currencyAccounts.VaultMintBump,
+			VaultBaseBump:   currencyAccounts.VaultBaseBump,
+		},
+	)
+
+	initializeMetadataInstruction := currencycreator.NewInitializeMetadataInstruction(
+		&currencycreator.InitializeMetadataInstructionAccounts{
+			Authority: authority.PublicKey().ToBytes(),
+			Mint:      currencyAccounts.Mint.PublicKey().ToBytes(),
+			Currency:  currencyAccounts.CurrencyConfig.PublicKey().ToBytes(),
+			Metadata:  metadataAddress,
+		},
+		&currencycreator.InitializeMetadataInstructionArgs{},
+	)
+
+	instructions := []solana.Instruction{
+		compute_budget.SetComputeUnitPrice(1_000),
+		compute_budget.SetComputeUnitLimit(400_000),
+		initializeCurrencyInstruction,
+		initializePoolInstruction,
+		initializeMetadataInstruction,
+	}
+	return MakeNoncedTransaction(nonce, instructions...)
+}
+
+func MakeCreateAltTransaction(
+	nonce *Nonce,
+
+	authority *common.Account,
+
+	alt *common.Account,
+	altBump uint8,
+	recentSlot uint64,
+
+	addresses ...ed25519.PublicKey,
+) (solana.Transaction, error) {
+	if len(addresses) == 0 {
+		return solana.Transaction{}, errors.New("no addresses provided")
+	}
+
+	payer := common.GetSubsidizer()
+
+	instructions := []solana.Instruction{
+		compute_budget.SetComputeUnitPrice(1_000),
+		compute_budget.SetComputeUnitLimit(50_000),
+		address_lookup_table.Create(
+			alt.PublicKey().ToBytes(),
+			authority.PublicKey().ToBytes(),
+			payer.PublicKey().ToBytes(),
+			recentSlot,
+			altBump,
+		),
+		address_lookup_table.Extend(
+			alt.PublicKey().ToBytes(),
+			authority.PublicKey().ToBytes(),
+			payer.PublicKey().ToBytes(),
+			addresses...,
+		),
+	}
+	return MakeNoncedTransaction(nonce, instructions...)
+}
+
+// MakeInitVmTransaction makes a transaction that initializes the VM, and its
+// omnibus account, described by the provided VM config
+func MakeInitVmTransaction(
+	nonce *Nonce,
+
+	vmConfig *common.VmConfig,
+) (solana.Transaction, error) {
+	vmAddress, vmBump, err := vm.GetVmAddress(&vm.GetVmAddressArgs{
+		Mint:         vmConfig.Mint.PublicKey().ToBytes(),
+		VmAuthority:  vmConfig.Authority.PublicKey().ToBytes(),
+		LockDuration: vmConfig.LockDurationInDays,
+	})
+	if err != nil {
+		return solana.Transaction{}, err
+	}
+	if !bytes.Equal(vmAddress, vmConfig.Vm.PublicKey().ToBytes()) {
+		return solana.Transaction{}, errors.New("unexpected vm address")
+	}
+
+	omnibusAddress, omnibusBump, err := vm.GetVmObnibusAddress(&vm.GetVmObnibusAddressArgs{
+		Vm: vmAddress,
+	})
+	if err != nil {
+		return solana.Transaction{}, err
+	}
+	if !bytes.Equal(omnibusAddress, vmConfig.Omnibus.PublicKey().ToBytes()) {
+		return solana.Transaction{}, errors.New("unexpected vm omnibus address")
+	}
+
+	instructions := []solana.Instruction{
+		compute_budget.SetComputeUnitPrice(1_000),
+		compute_budget.SetComputeUnitLimit(50_000),
+		vm.NewInitVmInstruction(
+			&vm.InitVmInstructionAccounts{
+				VmAuthority: vmConfig.Authority.PublicKey().ToBytes(),
+				Vm:          vmAddress,
+				VmOmnibus:   omnibusAddress,
+				Mint:        vmConfig.Mint.PublicKey().ToBytes(),
+			},
+			&vm.InitVmInstructionArgs{
+				LockDuration:  vmConfig.LockDurationInDays,
+				VmBump:        vmBump,
+				VmOmnibusBump: omnibusBump,
+			},
+		),
+	}
+	return MakeNoncedTransaction(nonce, instructions...)
+}
+
+func MakeBurnFeesTransaction(
+	nonce *Nonce,
+
+	liquidityPool *common.Account,
+	vaultBase *common.Account,
+) (solana.Transaction, error) {
+	instructions := []solana.Instruction{
+		compute_budget.SetComputeUnitPrice(1_000),
+		compute_budget.SetComputeUnitLimit(50_000),
+		currencycreator.NewBurnFeesInstruction(
+			&currencycreator.BurnFeesInstructionAccounts{
+				Payer:     common.GetSubsidizer().PublicKey().ToBytes(),
+				Pool:      liquidityPool.PublicKey().ToBytes(),
+				BaseMint:  common.CoreMintAccount.PublicKey().ToBytes(),
+				VaultBase: vaultBase.PublicKey().ToBytes(),
+			},
+			&currencycreator.BurnFeesInstructionArgs{},
+		),
+	}
 	return MakeNoncedTransaction(nonce, instructions...)
 }
 
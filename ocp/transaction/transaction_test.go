@@ -10,6 +10,7 @@ import (
 	"github.com/code-payments/ocp-server/ocp/common"
 	ocp_data "github.com/code-payments/ocp-server/ocp/data"
 	"github.com/code-payments/ocp-server/solana"
+	compute_budget "github.com/code-payments/ocp-server/solana/computebudget"
 	"github.com/code-payments/ocp-server/solana/system"
 	"github.com/code-payments/ocp-server/solana/token"
 	"github.com/code-payments/ocp-server/testutil"
@@ -89,6 +90,82 @@ func TestTransaction_MakeNoncedTransaction_NoInstructions(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestTransaction_MakeBatchedTransaction_HappyPath(t *testing.T) {
+	subsidizer := testutil.SetupRandomSubsidizer(t, ocp_data.NewTestDataProvider())
+
+	vm := testutil.NewRandomAccount(t)
+
+	nonce := &Nonce{
+		Account: testutil.NewRandomAccount(t),
+	}
+
+	var batchables []*BatchableInstructions
+	for i := range 3 {
+		batchables = append(batchables, &BatchableInstructions{
+			Vm:           vm,
+			ComputeUnits: uint32(i+1) * 10_000,
+			Instructions: []solana.Instruction{
+				token.Transfer(
+					testutil.NewRandomAccount(t).PublicKey().ToBytes(),
+					testutil.NewRandomAccount(t).PublicKey().ToBytes(),
+					testutil.NewRandomAccount(t).PublicKey().ToBytes(),
+					uint64(i+1),
+				),
+			},
+		})
+	}
+
+	txn, err := MakeBatchedTransaction(nonce, batchables...)
+	require.NoError(t, err)
+
+	assert.EqualValues(t, txn.Message.Accounts[0], subsidizer.PublicKey().ToBytes())
+
+	require.Len(t, txn.Message.Instructions, 6)
+
+	_, err = system.DecompileAdvanceNonce(txn.Message, 0)
+	require.NoError(t, err)
+
+	computeUnitPrice, err := compute_budget.DecompileSetComputeUnitPriceIxnData(txn.Message.Instructions[1].Data)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1_000, computeUnitPrice)
+
+	computeUnitLimit, err := compute_budget.DecompileSetComputeUnitLimitIxnData(txn.Message.Instructions[2].Data)
+	require.NoError(t, err)
+	assert.EqualValues(t, 60_000, computeUnitLimit)
+
+	for i := range batchables {
+		actual, err := token.DecompileTransfer(txn.Message, i+3)
+		require.NoError(t, err)
+		assert.EqualValues(t, i+1, actual.Amount)
+	}
+}
+
+func TestTransaction_MakeBatchedTransaction_Validation(t *testing.T) {
+	testutil.SetupRandomSubsidizer(t, ocp_data.NewTestDataProvider())
+
+	nonce := &Nonce{
+		Account: testutil.NewRandomAccount(t),
+	}
+
+	_, err := MakeBatchedTransaction(nonce)
+	assert.Error(t, err)
+
+	_, err = MakeBatchedTransaction(
+		nonce,
+		&BatchableInstructions{
+			Vm:           testutil.NewRandomAccount(t),
+			ComputeUnits: 100_000,
+			Instructions: []solana.Instruction{system.AdvanceNonce(testutil.NewRandomAccount(t).PublicKey().ToBytes(), testutil.NewRandomAccount(t).PublicKey().ToBytes())},
+		},
+		&BatchableInstructions{
+			Vm:           testutil.NewRandomAccount(t),
+			ComputeUnits: 100_000,
+			Instructions: []solana.Instruction{system.AdvanceNonce(testutil.NewRandomAccount(t).PublicKey().ToBytes(), testutil.NewRandomAccount(t).PublicKey().ToBytes())},
+		},
+	)
+	assert.Error(t, err)
+}
+
 func TestVmTransaction_MergedMemoryBanks_HappyPath(t *testing.T) {
 	account1 := testutil.NewRandomAccount(t)
 	account2 := testutil.NewRandomAccount(t)
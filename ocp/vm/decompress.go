@@ -0,0 +1,92 @@
+package vm
+
+import (
+	"context"
+
+	indexerpb "github.com/code-payments/code-vm-indexer/generated/indexer/v1"
+
+	"github.com/code-payments/ocp-server/ocp/common"
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	vm_operation "github.com/code-payments/ocp-server/ocp/data/vm/operation"
+	vm_program "github.com/code-payments/ocp-server/solana/vm"
+)
+
+// RequestVirtualTimelockAccountDecompression requests that a compressed virtual
+// timelock account be decompressed back into memory. The request is fulfilled
+// asynchronously by the VM memory manager, and it's safe to call this method
+// multiple times.
+func RequestVirtualTimelockAccountDecompression(ctx context.Context, data ocp_data.Provider, vmConfig *common.VmConfig, owner *common.Account) error {
+	timelockAccounts, err := owner.GetTimelockAccounts(vmConfig)
+	if err != nil {
+		return err
+	}
+
+	err = data.PutVmOperation(ctx, &vm_operation.Record{
+		Vm: vmConfig.Vm.PublicKey().ToBase58(),
+
+		Type: vm_operation.TypeDecompress,
+
+		Address: timelockAccounts.Vault.PublicKey().ToBase58(),
+
+		Owner: owner.PublicKey().ToBase58(),
+
+		StoredAccountType: vm_program.VirtualAccountTypeTimelock,
+
+		State: vm_operation.StateRequested,
+	})
+	if err == vm_operation.ErrAlreadyExists {
+		return nil
+	}
+	return err
+}
+
+// GetVirtualTimelockAccountStateInMemoryOrRequestDecompression is like
+// GetVirtualTimelockAccountStateInMemory, but additionally requests the account
+// be decompressed when it's been compressed for being idle. ErrVirtualAccountCompressed
+// is still returned, and callers should retry once the account is back in memory.
+//
+// Accounts being compressed are treated as compressed, since any transaction
+// using their memory location would fail once the compression lands.
+func GetVirtualTimelockAccountStateInMemoryOrRequestDecompression(ctx context.Context, data ocp_data.Provider, vmIndexerClient indexerpb.IndexerClient, vmConfig *common.VmConfig, owner *common.Account) (*vm_program.VirtualTimelockAccount, *common.Account, uint16, error) {
+	isCompressing, err := isVirtualTimelockAccountCompressionInFlight(ctx, data, vmConfig, owner)
+	if err != nil {
+		return nil, nil, 0, err
+	} else if isCompressing {
+		return nil, nil, 0, ErrVirtualAccountCompressed
+	}
+
+	state, memory, index, err := GetVirtualTimelockAccountStateInMemory(ctx, vmIndexerClient, vmConfig.Vm, owner)
+	if err == ErrVirtualAccountCompressed {
+		requestErr := RequestVirtualTimelockAccountDecompression(ctx, data, vmConfig, owner)
+		if requestErr != nil {
+			return nil, nil, 0, requestErr
+		}
+	}
+	return state, memory, index, err
+}
+
+// GetVirtualTimelockAccountLocationInMemoryOrRequestDecompression is like
+// GetVirtualTimelockAccountLocationInMemory, but additionally requests the
+// account be decompressed when it's been compressed for being idle.
+func GetVirtualTimelockAccountLocationInMemoryOrRequestDecompression(ctx context.Context, data ocp_data.Provider, vmIndexerClient indexerpb.IndexerClient, vmConfig *common.VmConfig, owner *common.Account) (*common.Account, uint16, error) {
+	_, memory, index, err := GetVirtualTimelockAccountStateInMemoryOrRequestDecompression(ctx, data, vmIndexerClient, vmConfig, owner)
+	if err != nil {
+		return nil, 0, err
+	}
+	return memory, index, nil
+}
+
+func isVirtualTimelockAccountCompressionInFlight(ctx context.Context, data ocp_data.Provider, vmConfig *common.VmConfig, owner *common.Account) (bool, error) {
+	timelockAccounts, err := owner.GetTimelockAccounts(vmConfig)
+	if err != nil {
+		return false, err
+	}
+
+	latestOperation, err := data.GetLatestVmOperationByAddress(ctx, timelockAccounts.Vault.PublicKey().ToBase58())
+	if err == vm_operation.ErrNotFound {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return latestOperation.Type == vm_operation.TypeCompress && latestOperation.State.IsInFlight(), nil
+}
@@ -14,8 +14,21 @@ import (
 	vm_program "github.com/code-payments/ocp-server/solana/vm"
 )
 
+var (
+	// ErrVirtualAccountCompressed indicates the virtual account isn't in memory,
+	// and must be decompressed before it can be used
+	ErrVirtualAccountCompressed = errors.New("virtual account is compressed")
+
+	// ErrVirtualAccountNotCompressed indicates the virtual account is in memory
+	ErrVirtualAccountNotCompressed = errors.New("virtual account is not compressed")
+
+	// ErrVirtualAccountNotFound indicates the virtual account isn't in memory or
+	// storage, either because it was never initialized or it's been withdrawn
+	ErrVirtualAccountNotFound = errors.New("virtual account not found")
+)
+
 func EnsureVirtualTimelockAccountIsInitialized(ctx context.Context, data ocp_data.Provider, vmIndexerClient indexerpb.IndexerClient, mint, owner *common.Account, waitForInitialization bool) error {
-	vmConfig, err := common.GetVmConfigForMint(ctx, data, mint)
+	vmConfig, err := common.GetPublicVmConfigForMint(ctx, data, mint)
 	if err != nil {
 		return err
 	}
@@ -54,6 +67,11 @@ func EnsureVirtualTimelockAccountIsInitialized(ctx context.Context, data ocp_dat
 		_, _, err := GetVirtualTimelockAccountLocationInMemory(ctx, vmIndexerClient, vmConfig.Vm, owner)
 		if err == nil {
 			return nil
+		} else if err == ErrVirtualAccountCompressed {
+			err = RequestVirtualTimelockAccountDecompression(ctx, data, vmConfig, owner)
+			if err != nil {
+				return err
+			}
 		}
 
 		time.Sleep(time.Second)
@@ -69,14 +87,18 @@ func GetVirtualTimelockAccountStateInMemory(ctx context.Context, vmIndexerClient
 	})
 	if err != nil {
 		return nil, nil, 0, err
+	} else if resp.Result == indexerpb.GetVirtualTimelockAccountsResponse_NOT_FOUND {
+		return nil, nil, 0, ErrVirtualAccountNotFound
 	} else if resp.Result != indexerpb.GetVirtualTimelockAccountsResponse_OK {
 		return nil, nil, 0, errors.Errorf("received rpc result %s", resp.Result.String())
 	}
 
-	if len(resp.Items) > 1 {
+	if len(resp.Items) == 0 {
+		return nil, nil, 0, ErrVirtualAccountNotFound
+	} else if len(resp.Items) > 1 {
 		return nil, nil, 0, errors.New("multiple results returned")
 	} else if resp.Items[0].Storage.GetMemory() == nil {
-		return nil, nil, 0, errors.New("account is compressed")
+		return nil, nil, 0, ErrVirtualAccountCompressed
 	}
 
 	protoMemory := resp.Items[0].Storage.GetMemory()
@@ -85,20 +107,52 @@ func GetVirtualTimelockAccountStateInMemory(ctx context.Context, vmIndexerClient
 		return nil, nil, 0, err
 	}
 
-	protoAccount := resp.Items[0].Account
-	state := vm_program.VirtualTimelockAccount{
-		Owner: protoAccount.Owner.Value,
-		Nonce: vm_program.Hash(protoAccount.Nonce.Value),
+	return toVirtualTimelockAccountState(resp.Items[0].Account), memory, uint16(protoMemory.Index), nil
+}
 
-		TokenBump:    uint8(protoAccount.TokenBump),
-		UnlockBump:   uint8(protoAccount.UnlockBump),
-		WithdrawBump: uint8(protoAccount.WithdrawBump),
+// GetVirtualTimelockAccountStateInStorage gets the state of a compressed virtual
+// timelock account, along with the storage account and proof required to
+// decompress it
+func GetVirtualTimelockAccountStateInStorage(ctx context.Context, vmIndexerClient indexerpb.IndexerClient, vm, owner *common.Account) (*vm_program.VirtualTimelockAccount, *common.Account, vm_program.HashArray, error) {
+	resp, err := vmIndexerClient.GetVirtualTimelockAccounts(ctx, &indexerpb.GetVirtualTimelockAccountsRequest{
+		VmAccount: &indexerpb.Address{Value: vm.PublicKey().ToBytes()},
+		Owner:     &indexerpb.Address{Value: owner.PublicKey().ToBytes()},
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	} else if resp.Result == indexerpb.GetVirtualTimelockAccountsResponse_NOT_FOUND {
+		return nil, nil, nil, ErrVirtualAccountNotFound
+	} else if resp.Result != indexerpb.GetVirtualTimelockAccountsResponse_OK {
+		return nil, nil, nil, errors.Errorf("received rpc result %s", resp.Result.String())
+	}
 
-		Balance: protoAccount.Balance,
-		Bump:    uint8(protoAccount.Bump),
+	if len(resp.Items) == 0 {
+		return nil, nil, nil, ErrVirtualAccountNotFound
+	} else if len(resp.Items) > 1 {
+		return nil, nil, nil, errors.New("multiple results returned")
+	} else if resp.Items[0].Storage.GetCompressed() == nil {
+		return nil, nil, nil, ErrVirtualAccountNotCompressed
 	}
 
-	return &state, memory, uint16(protoMemory.Index), nil
+	protoCompressed := resp.Items[0].Storage.GetCompressed()
+	storage, err := common.NewAccountFromPublicKeyBytes(protoCompressed.Account.Value)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if protoCompressed.Proof == nil {
+		return nil, nil, nil, errors.New("proof not provided")
+	}
+
+	proof := make(vm_program.HashArray, len(protoCompressed.Proof.Proof))
+	for i, protoHash := range protoCompressed.Proof.Proof {
+		if len(protoHash.Value) != vm_program.HashSize {
+			return nil, nil, nil, errors.New("invalid proof hash")
+		}
+		proof[i] = vm_program.Hash(protoHash.Value)
+	}
+
+	return toVirtualTimelockAccountState(resp.Items[0].Account), storage, proof, nil
 }
 
 func GetVirtualTimelockAccountLocationInMemory(ctx context.Context, vmIndexerClient indexerpb.IndexerClient, vm, owner *common.Account) (*common.Account, uint16, error) {
@@ -122,7 +176,7 @@ func GetVirtualDurableNonceAccountStateInMemory(ctx context.Context, vmIndexerCl
 
 	protoMemory := resp.Item.Storage.GetMemory()
 	if protoMemory == nil {
-		return nil, nil, 0, errors.New("account is compressed")
+		return nil, nil, 0, ErrVirtualAccountCompressed
 	}
 
 	memory, err := common.NewAccountFromPublicKeyBytes(protoMemory.Account.Value)
@@ -147,6 +201,20 @@ func GetVirtualDurableNonceAccountLocationInMemory(ctx context.Context, vmIndexe
 	return memory, memoryIndex, nil
 }
 
+func toVirtualTimelockAccountState(protoAccount *indexerpb.VirtualTimelockAccount) *vm_program.VirtualTimelockAccount {
+	return &vm_program.VirtualTimelockAccount{
+		Owner: protoAccount.Owner.Value,
+		Nonce: vm_program.Hash(protoAccount.Nonce.Value),
+
+		TokenBump:    uint8(protoAccount.TokenBump),
+		UnlockBump:   uint8(protoAccount.UnlockBump),
+		WithdrawBump: uint8(protoAccount.WithdrawBump),
+
+		Balance: protoAccount.Balance,
+		Bump:    uint8(protoAccount.Bump),
+	}
+}
+
 func markFulfillmentAsActivelyScheduled(ctx context.Context, data ocp_data.Provider, fulfillmentRecord *fulfillment.Record) error {
 	if fulfillmentRecord.Id == 0 {
 		return nil
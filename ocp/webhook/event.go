@@ -0,0 +1,188 @@
+package webhook
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/code-payments/ocp-server/ocp/data/intent"
+	"github.com/code-payments/ocp-server/ocp/data/paymentrequest"
+	"github.com/code-payments/ocp-server/ocp/data/paymentschedule"
+	"github.com/code-payments/ocp-server/ocp/data/swap"
+)
+
+// EventType is the type of an outbound webhook event
+type EventType string
+
+const (
+	EventTypeIntentCreated        EventType = "intent.created"
+	EventTypeIntentConfirmed      EventType = "intent.confirmed"
+	EventTypeIntentFailed         EventType = "intent.failed"
+	EventTypeIntentRevoked        EventType = "intent.revoked"
+	EventTypeSwapFinalized        EventType = "swap.finalized"
+	EventTypeSwapCancelled        EventType = "swap.cancelled"
+	EventTypeDepositReceived      EventType = "deposit.received"
+	EventTypeGiftCardAutoReturned EventType = "gift_card.auto_returned"
+
+	EventTypeScheduledPaymentExecuted EventType = "scheduled_payment.executed"
+	EventTypeScheduledPaymentFailed   EventType = "scheduled_payment.failed"
+
+	EventTypePaymentRequestPaid   EventType = "payment_request.paid"
+	EventTypePaymentRequestFailed EventType = "payment_request.failed"
+)
+
+// Event is the JSON body delivered to webhook receivers
+type Event struct {
+	// Id uniquely identifies the event. Events are delivered at least once, so
+	// receivers should use it to deduplicate deliveries.
+	Id        string      `json:"id"`
+	Type      EventType   `json:"type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+type IntentEventData struct {
+	IntentId   string `json:"intent_id"`
+	IntentType string `json:"intent_type"`
+	Mint       string `json:"mint"`
+	Owner      string `json:"owner"`
+	State      string `json:"state"`
+}
+
+type SwapEventData struct {
+	SwapId   string `json:"swap_id"`
+	Owner    string `json:"owner"`
+	FromMint string `json:"from_mint"`
+	ToMint   string `json:"to_mint"`
+	Amount   uint64 `json:"amount"`
+}
+
+type DepositEventData struct {
+	Signature      string  `json:"signature"`
+	Owner          string  `json:"owner"`
+	Mint           string  `json:"mint"`
+	Destination    string  `json:"destination"`
+	Quantity       uint64  `json:"quantity"`
+	UsdMarketValue float64 `json:"usd_market_value"`
+}
+
+type GiftCardEventData struct {
+	GiftCardVault    string `json:"gift_card_vault"`
+	IssuedIntentId   string `json:"issued_intent_id"`
+	ReturnedIntentId string `json:"returned_intent_id"`
+	Owner            string `json:"owner"`
+	IsVoidedByUser   bool   `json:"is_voided_by_user"`
+}
+
+type ScheduledPaymentEventData struct {
+	ScheduleId       string  `json:"schedule_id"`
+	IntentId         string  `json:"intent_id"`
+	Owner            string  `json:"owner"`
+	DestinationOwner string  `json:"destination_owner"`
+	Currency         string  `json:"currency"`
+	NativeAmount     float64 `json:"native_amount"`
+	PaymentIndex     uint64  `json:"payment_index"`
+	State            string  `json:"state"`
+	FailureReason    string  `json:"failure_reason,omitempty"`
+}
+
+type PaymentRequestEventData struct {
+	IntentId     string  `json:"intent_id"`
+	Owner        string  `json:"owner"`
+	Payer        string  `json:"payer"`
+	Mint         string  `json:"mint"`
+	Destination  string  `json:"destination"`
+	Currency     string  `json:"currency"`
+	NativeAmount float64 `json:"native_amount"`
+	Memo         string  `json:"memo"`
+}
+
+// NewIntentEvent returns a new event for an intent state change
+func NewIntentEvent(eventType EventType, record *intent.Record) *Event {
+	return newEvent(eventType, record.IntentId, &IntentEventData{
+		IntentId:   record.IntentId,
+		IntentType: record.IntentType.String(),
+		Mint:       record.MintAccount,
+		Owner:      record.InitiatorOwnerAccount,
+		State:      record.State.String(),
+	})
+}
+
+// NewSwapEvent returns a new event for a swap state change
+func NewSwapEvent(eventType EventType, record *swap.Record) *Event {
+	return newEvent(eventType, record.SwapId, &SwapEventData{
+		SwapId:   record.SwapId,
+		Owner:    record.Owner,
+		FromMint: record.FromMint,
+		ToMint:   record.ToMint,
+		Amount:   record.Amount,
+	})
+}
+
+// NewDepositReceivedEvent returns a new event for an external deposit into a
+// user's account
+func NewDepositReceivedEvent(data *DepositEventData) *Event {
+	return newEvent(EventTypeDepositReceived, data.Signature+":"+data.Destination, data)
+}
+
+// NewGiftCardAutoReturnedEvent returns a new event for a gift card that's been
+// returned to its issuer
+func NewGiftCardAutoReturnedEvent(data *GiftCardEventData) *Event {
+	return newEvent(EventTypeGiftCardAutoReturned, data.GiftCardVault, data)
+}
+
+// NewScheduledPaymentEvent returns a new event for a payment made, or that
+// failed to be made, on an owner's behalf as part of a payment schedule
+func NewScheduledPaymentEvent(eventType EventType, record *paymentschedule.Record, intentId string, paymentIndex uint64, failureReason string) *Event {
+	return newEvent(eventType, fmt.Sprintf("%s:%d", record.ScheduleId, paymentIndex), &ScheduledPaymentEventData{
+		ScheduleId:       record.ScheduleId,
+		IntentId:         intentId,
+		Owner:            record.Owner,
+		DestinationOwner: record.DestinationOwner,
+		Currency:         string(record.ExchangeCurrency),
+		NativeAmount:     record.NativeAmount,
+		PaymentIndex:     paymentIndex,
+		State:            record.State.String(),
+		FailureReason:    failureReason,
+	})
+}
+
+// NewPaymentRequestPaidEvent returns a new event for a merchant's payment
+// request that's been paid
+func NewPaymentRequestPaidEvent(record *paymentrequest.Record) *Event {
+	return newPaymentRequestEvent(EventTypePaymentRequestPaid, record)
+}
+
+// NewPaymentRequestFailedEvent returns a new event for a merchant's payment
+// request whose payment failed, which the merchant must reissue
+func NewPaymentRequestFailedEvent(record *paymentrequest.Record) *Event {
+	return newPaymentRequestEvent(EventTypePaymentRequestFailed, record)
+}
+
+func newPaymentRequestEvent(eventType EventType, record *paymentrequest.Record) *Event {
+	var payer string
+	if record.PaidBy != nil {
+		payer = *record.PaidBy
+	}
+
+	return newEvent(eventType, record.Intent, &PaymentRequestEventData{
+		IntentId:     record.Intent,
+		Owner:        record.Owner,
+		Payer:        payer,
+		Mint:         record.Mint,
+		Destination:  record.DestinationTokenAccount,
+		Currency:     string(record.ExchangeCurrency),
+		NativeAmount: record.NativeAmount,
+		Memo:         record.Memo,
+	})
+}
+
+// Event IDs are derived from the source record, so emitting the same event
+// more than once is idempotent
+func newEvent(eventType EventType, sourceId string, data interface{}) *Event {
+	return &Event{
+		Id:        string(eventType) + ":" + sourceId,
+		Type:      eventType,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	}
+}
@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/code-payments/ocp-server/config"
+	"github.com/code-payments/ocp-server/config/env"
+	"github.com/code-payments/ocp-server/config/memory"
+	"github.com/code-payments/ocp-server/config/wrapper"
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/webhook"
+)
+
+const (
+	EnabledConfigEnvName = "WEBHOOK_ENABLED"
+	defaultEnabled       = false
+)
+
+var (
+	enabled config.Bool = env.NewBoolConfig(EnabledConfigEnvName, defaultEnabled)
+)
+
+// Enqueue durably records an event in the webhook outbox, where it will be
+// picked up by the webhook worker for delivery. It's a no-op when webhooks
+// aren't enabled, or the event was already enqueued.
+//
+// Call this within the same DB transaction as the state change that triggered
+// the event when possible, so events are never lost or emitted for changes
+// that were rolled back.
+func Enqueue(ctx context.Context, data ocp_data.DatabaseData, event *Event) error {
+	if !enabled.Get(ctx) {
+		return nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "error marshalling webhook event")
+	}
+
+	err = data.PutWebhookEvent(ctx, &webhook.Record{
+		EventId:       event.Id,
+		EventType:     string(event.Type),
+		Payload:       payload,
+		State:         webhook.StatePending,
+		NextAttemptAt: event.CreatedAt,
+	})
+	if err == webhook.ErrAlreadyExists {
+		return nil
+	}
+	return err
+}
+
+// InjectTestEnabled overrides whether webhooks are enabled for testing
+// purposes. Do not call this in a production setting.
+func InjectTestEnabled(isEnabled bool) {
+	enabled = wrapper.NewBoolConfig(memory.NewConfig(isEnabled), defaultEnabled)
+}
@@ -0,0 +1,66 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/code-payments/ocp-server/config/memory"
+	"github.com/code-payments/ocp-server/config/wrapper"
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/intent"
+	"github.com/code-payments/ocp-server/ocp/data/webhook"
+)
+
+func TestEnqueue(t *testing.T) {
+	ctx := context.Background()
+	data := ocp_data.NewTestDataProvider()
+
+	intentRecord := &intent.Record{
+		IntentId:              "intent",
+		IntentType:            intent.SendPublicPayment,
+		MintAccount:           "mint",
+		InitiatorOwnerAccount: "owner",
+		State:                 intent.StateConfirmed,
+	}
+	event := NewIntentEvent(EventTypeIntentConfirmed, intentRecord)
+	assert.Equal(t, "intent.confirmed:intent", event.Id)
+
+	// Disabled by default
+	require.NoError(t, Enqueue(ctx, data, event))
+	_, err := data.GetWebhookEvent(ctx, event.Id)
+	assert.Equal(t, webhook.ErrNotFound, err)
+
+	enabled = wrapper.NewBoolConfig(memory.NewConfig(true), defaultEnabled)
+	defer func() {
+		enabled = wrapper.NewBoolConfig(memory.NewConfig(false), defaultEnabled)
+	}()
+
+	require.NoError(t, Enqueue(ctx, data, event))
+
+	// Enqueuing the same event is idempotent
+	require.NoError(t, Enqueue(ctx, data, NewIntentEvent(EventTypeIntentConfirmed, intentRecord)))
+
+	record, err := data.GetWebhookEvent(ctx, event.Id)
+	require.NoError(t, err)
+	assert.Equal(t, string(EventTypeIntentConfirmed), record.EventType)
+	assert.Equal(t, webhook.StatePending, record.State)
+	assert.EqualValues(t, 0, record.Attempts)
+
+	var actual struct {
+		Id   string          `json:"id"`
+		Type EventType       `json:"type"`
+		Data IntentEventData `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(record.Payload, &actual))
+	assert.Equal(t, event.Id, actual.Id)
+	assert.Equal(t, EventTypeIntentConfirmed, actual.Type)
+	assert.Equal(t, "intent", actual.Data.IntentId)
+	assert.Equal(t, intent.SendPublicPayment.String(), actual.Data.IntentType)
+	assert.Equal(t, "mint", actual.Data.Mint)
+	assert.Equal(t, "owner", actual.Data.Owner)
+	assert.Equal(t, intent.StateConfirmed.String(), actual.Data.State)
+}
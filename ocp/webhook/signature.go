@@ -0,0 +1,71 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	EventIdHeader   = "Ocp-Webhook-Id"
+	TimestampHeader = "Ocp-Webhook-Timestamp"
+	SignatureHeader = "Ocp-Webhook-Signature"
+
+	signatureVersion = "v1"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrExpiredTimestamp = errors.New("webhook timestamp is outside the tolerance")
+)
+
+// Sign signs a webhook delivery with HMAC-SHA256 over the event ID, the unix
+// timestamp of the delivery attempt and the body. The returned value is sent
+// in the SignatureHeader.
+func Sign(secret []byte, eventId string, timestamp time.Time, body []byte) string {
+	return signatureVersion + "=" + hex.EncodeToString(computeSignature(secret, eventId, timestamp.Unix(), body))
+}
+
+// Verify verifies the headers and body of a webhook delivery. Deliveries with a
+// timestamp further than tolerance from now are rejected to limit replays.
+func Verify(secret []byte, eventId, timestampHeader, signatureHeader string, body []byte, tolerance time.Duration) error {
+	timestamp, err := strconv.ParseInt(timestampHeader, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "invalid webhook timestamp")
+	}
+
+	delta := time.Since(time.Unix(timestamp, 0))
+	if delta < -tolerance || delta > tolerance {
+		return ErrExpiredTimestamp
+	}
+
+	encoded, ok := strings.CutPrefix(signatureHeader, signatureVersion+"=")
+	if !ok {
+		return ErrInvalidSignature
+	}
+
+	signature, err := hex.DecodeString(encoded)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	if !hmac.Equal(signature, computeSignature(secret, eventId, timestamp, body)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func computeSignature(secret []byte, eventId string, timestamp int64, body []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(eventId))
+	mac.Write([]byte("."))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,35 @@
+package webhook
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	secret := []byte("secret")
+	body := []byte(`{"id":"event"}`)
+	now := time.Now()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	signature := Sign(secret, "event", now, body)
+	require.NoError(t, Verify(secret, "event", timestamp, signature, body, time.Minute))
+
+	assert.Equal(t, ErrInvalidSignature, Verify([]byte("other"), "event", timestamp, signature, body, time.Minute))
+	assert.Equal(t, ErrInvalidSignature, Verify(secret, "other", timestamp, signature, body, time.Minute))
+	assert.Equal(t, ErrInvalidSignature, Verify(secret, "event", timestamp, signature, []byte(`{"id":"other"}`), time.Minute))
+	assert.Equal(t, ErrInvalidSignature, Verify(secret, "event", timestamp, signature[3:], body, time.Minute))
+	assert.Equal(t, ErrInvalidSignature, Verify(secret, "event", timestamp, "v1=zz", body, time.Minute))
+
+	// The timestamp is covered by the signature
+	later := strconv.FormatInt(now.Unix()+1, 10)
+	assert.Equal(t, ErrInvalidSignature, Verify(secret, "event", later, signature, body, time.Minute))
+
+	// Stale deliveries are rejected
+	old := now.Add(-time.Hour)
+	signature = Sign(secret, "event", old, body)
+	assert.Equal(t, ErrExpiredTimestamp, Verify(secret, "event", strconv.FormatInt(old.Unix(), 10), signature, body, time.Minute))
+}
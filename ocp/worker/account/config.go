@@ -3,6 +3,8 @@ package account
 import (
 	"github.com/code-payments/ocp-server/config"
 	"github.com/code-payments/ocp-server/config/env"
+	"github.com/code-payments/ocp-server/config/memory"
+	"github.com/code-payments/ocp-server/config/wrapper"
 )
 
 const (
@@ -10,10 +12,18 @@ const (
 
 	AirdropperOwnerPublicKeyEnvName = envConfigPrefix + "AIRDROPPER_OWNER_PUBLIC_KEY"
 	defaultAirdropperOwnerPublicKey = "invalid" // Ensure something valid is set
+
+	GiftCardAutoReturnBatchSizeEnvName = envConfigPrefix + "GIFT_CARD_AUTO_RETURN_BATCH_SIZE"
+	defaultGiftCardAutoReturnBatchSize = 32
+
+	GiftCardAutoReturnConcurrencyEnvName = envConfigPrefix + "GIFT_CARD_AUTO_RETURN_CONCURRENCY"
+	defaultGiftCardAutoReturnConcurrency = 32
 )
 
 type conf struct {
-	airdropperOwnerPublicKey config.String
+	airdropperOwnerPublicKey      config.String
+	giftCardAutoReturnBatchSize   config.Uint64
+	giftCardAutoReturnConcurrency config.Uint64
 }
 
 // ConfigProvider defines how config values are pulled
@@ -23,7 +33,24 @@ type ConfigProvider func() *conf
 func WithEnvConfigs() ConfigProvider {
 	return func() *conf {
 		return &conf{
-			airdropperOwnerPublicKey: env.NewStringConfig(AirdropperOwnerPublicKeyEnvName, defaultAirdropperOwnerPublicKey),
+			airdropperOwnerPublicKey:      env.NewStringConfig(AirdropperOwnerPublicKeyEnvName, defaultAirdropperOwnerPublicKey),
+			giftCardAutoReturnBatchSize:   env.NewUint64Config(GiftCardAutoReturnBatchSizeEnvName, defaultGiftCardAutoReturnBatchSize),
+			giftCardAutoReturnConcurrency: env.NewUint64Config(GiftCardAutoReturnConcurrencyEnvName, defaultGiftCardAutoReturnConcurrency),
+		}
+	}
+}
+
+type testOverrides struct {
+	giftCardAutoReturnBatchSize   uint64
+	giftCardAutoReturnConcurrency uint64
+}
+
+func withManualTestOverrides(overrides *testOverrides) ConfigProvider {
+	return func() *conf {
+		return &conf{
+			airdropperOwnerPublicKey:      wrapper.NewStringConfig(memory.NewConfig(defaultAirdropperOwnerPublicKey), defaultAirdropperOwnerPublicKey),
+			giftCardAutoReturnBatchSize:   wrapper.NewUint64Config(memory.NewConfig(overrides.giftCardAutoReturnBatchSize), defaultGiftCardAutoReturnBatchSize),
+			giftCardAutoReturnConcurrency: wrapper.NewUint64Config(memory.NewConfig(overrides.giftCardAutoReturnConcurrency), defaultGiftCardAutoReturnConcurrency),
 		}
 	}
 }
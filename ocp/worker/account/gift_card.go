@@ -23,15 +23,28 @@ import (
 	"github.com/code-payments/ocp-server/ocp/data/action"
 	"github.com/code-payments/ocp-server/ocp/data/fulfillment"
 	"github.com/code-payments/ocp-server/ocp/data/intent"
+	"github.com/code-payments/ocp-server/ocp/webhook"
 	"github.com/code-payments/ocp-server/pointer"
 	"github.com/code-payments/ocp-server/retry"
 )
 
 const (
 	giftCardAutoReturnIntentPrefix = "auto-return-gc-"
-	GiftCardExpiry                 = 7 * 24 * time.Hour
+
+	// DefaultGiftCardExpiry is the expiry for gift cards that weren't issued
+	// with an explicit one
+	DefaultGiftCardExpiry = 7 * 24 * time.Hour
 )
 
+// GetGiftCardExpiresAt returns when a gift card expires and becomes eligible
+// for auto-return back to the issuer
+func GetGiftCardExpiresAt(record *account.Record) time.Time {
+	if record.GiftCardExpiresAt != nil {
+		return *record.GiftCardExpiresAt
+	}
+	return record.CreatedAt.Add(DefaultGiftCardExpiry)
+}
+
 func (p *runtime) giftCardAutoReturnWorker(runtimeCtx context.Context, interval time.Duration) error {
 	delay := interval
 
@@ -44,8 +57,11 @@ func (p *runtime) giftCardAutoReturnWorker(runtimeCtx context.Context, interval
 			defer trace.End()
 			tracedCtx := metrics.NewContext(runtimeCtx, trace)
 
-			// todo: configurable batch size
-			records, err := p.data.GetPrioritizedAccountInfosRequiringAutoReturnCheck(tracedCtx, GiftCardExpiry, 32)
+			records, err := p.data.GetPrioritizedAccountInfosRequiringAutoReturnCheck(
+				tracedCtx,
+				time.Now(),
+				p.conf.giftCardAutoReturnBatchSize.Get(tracedCtx),
+			)
 			if err == account.ErrAccountInfoNotFound {
 				return nil
 			} else if err != nil {
@@ -53,12 +69,22 @@ func (p *runtime) giftCardAutoReturnWorker(runtimeCtx context.Context, interval
 				return err
 			}
 
+			concurrency := p.conf.giftCardAutoReturnConcurrency.Get(tracedCtx)
+			if concurrency == 0 || concurrency > uint64(len(records)) {
+				concurrency = uint64(len(records))
+			}
+			sem := make(chan struct{}, concurrency)
+
 			var wg sync.WaitGroup
 			for _, record := range records {
 				wg.Add(1)
+				sem <- struct{}{}
 
 				go func(record *account.Record) {
-					defer wg.Done()
+					defer func() {
+						<-sem
+						wg.Done()
+					}()
 
 					err := p.maybeInitiateGiftCardAutoReturn(tracedCtx, record)
 					if err != nil {
@@ -120,7 +146,7 @@ func (p *runtime) maybeInitiateGiftCardAutoReturn(ctx context.Context, accountIn
 	//
 	// Note: Without distributed locks, we assume SubmitIntent uses expiry - delta
 	//       to ensure race conditions aren't possible
-	if time.Since(accountInfoRecord.CreatedAt) < GiftCardExpiry {
+	if time.Now().Before(GetGiftCardExpiresAt(accountInfoRecord)) {
 		log.Debug("skipping gift card that hasn't hit the expiry window")
 		return nil
 	}
@@ -175,6 +201,17 @@ func InitiateProcessToAutoReturnGiftCard(ctx context.Context, data ocp_data.Prov
 			return err
 		}
 
+		err = webhook.Enqueue(ctx, data, webhook.NewGiftCardAutoReturnedEvent(&webhook.GiftCardEventData{
+			GiftCardVault:    giftCardVaultAccount.PublicKey().ToBase58(),
+			IssuedIntentId:   giftCardIssuedIntent.IntentId,
+			ReturnedIntentId: getAutoReturnIntentId(giftCardIssuedIntent.IntentId),
+			Owner:            giftCardIssuedIntent.InitiatorOwnerAccount,
+			IsVoidedByUser:   isVoidedByUser,
+		}))
+		if err != nil {
+			return err
+		}
+
 		// We need to update pre-sorting because auto-return fulfillments are always
 		// inserted at the very last spot in the line.
 		//
@@ -14,28 +14,43 @@ import (
 func TestGiftCardAutoReturn_ExpiryWindow(t *testing.T) {
 	for _, tc := range []struct {
 		creationTs     time.Time
+		expiry         time.Duration
 		isAutoReturned bool
 	}{
 		{
 			creationTs:     time.Now(),
+			expiry:         DefaultGiftCardExpiry,
 			isAutoReturned: false,
 		},
 		{
-			creationTs:     time.Now().Add(-GiftCardExpiry + time.Minute),
+			creationTs:     time.Now().Add(-DefaultGiftCardExpiry + time.Minute),
+			expiry:         DefaultGiftCardExpiry,
 			isAutoReturned: false,
 		},
 		{
-			creationTs:     time.Now().Add(-GiftCardExpiry),
+			creationTs:     time.Now().Add(-DefaultGiftCardExpiry),
+			expiry:         DefaultGiftCardExpiry,
 			isAutoReturned: true,
 		},
 		{
-			creationTs:     time.Now().Add(-GiftCardExpiry - time.Minute),
+			creationTs:     time.Now().Add(-DefaultGiftCardExpiry - time.Minute),
+			expiry:         DefaultGiftCardExpiry,
 			isAutoReturned: true,
 		},
+		{
+			creationTs:     time.Now().Add(-25 * time.Hour),
+			expiry:         24 * time.Hour,
+			isAutoReturned: true,
+		},
+		{
+			creationTs:     time.Now().Add(-DefaultGiftCardExpiry - time.Minute),
+			expiry:         30 * 24 * time.Hour,
+			isAutoReturned: false,
+		},
 	} {
 		env := setup(t)
 
-		giftCard := env.generateRandomGiftCard(t, tc.creationTs)
+		giftCard := env.generateRandomGiftCard(t, tc.creationTs, tc.expiry)
 
 		require.NoError(t, env.runtime.maybeInitiateGiftCardAutoReturn(env.ctx, giftCard.accountInfoRecord))
 
@@ -50,10 +65,10 @@ func TestGiftCardAutoReturn_ExpiryWindow(t *testing.T) {
 func TestGiftCardAutoReturn_AlreadyClaimed(t *testing.T) {
 	env := setup(t)
 
-	giftCard1 := env.generateRandomGiftCard(t, time.Now())
+	giftCard1 := env.generateRandomGiftCard(t, time.Now(), DefaultGiftCardExpiry)
 	env.simulateGiftCardBeingClaimed(t, giftCard1)
 
-	giftCard2 := env.generateRandomGiftCard(t, time.Now().Add(-GiftCardExpiry-24*time.Hour))
+	giftCard2 := env.generateRandomGiftCard(t, time.Now().Add(-DefaultGiftCardExpiry-24*time.Hour), DefaultGiftCardExpiry)
 	env.simulateGiftCardBeingClaimed(t, giftCard2)
 
 	require.NoError(t, env.runtime.maybeInitiateGiftCardAutoReturn(env.ctx, giftCard1.accountInfoRecord))
@@ -63,6 +78,17 @@ func TestGiftCardAutoReturn_AlreadyClaimed(t *testing.T) {
 	env.assertGiftCardNotAutoReturned(t, giftCard2, true)
 }
 
+func TestGetGiftCardExpiresAt(t *testing.T) {
+	env := setup(t)
+
+	creationTs := time.Now().Add(-time.Hour)
+	giftCard := env.generateRandomGiftCard(t, creationTs, 24*time.Hour)
+	assert.Equal(t, creationTs.Add(24*time.Hour).Unix(), GetGiftCardExpiresAt(giftCard.accountInfoRecord).Unix())
+
+	giftCard.accountInfoRecord.GiftCardExpiresAt = nil
+	assert.Equal(t, creationTs.Add(DefaultGiftCardExpiry).Unix(), GetGiftCardExpiresAt(giftCard.accountInfoRecord).Unix())
+}
+
 func TestGiftCardAutoReturn_IntentId(t *testing.T) {
 	intentId1 := testutil.NewRandomAccount(t).PublicKey().ToBase58()
 	intentId2 := testutil.NewRandomAccount(t).PublicKey().ToBase58()
@@ -13,6 +13,9 @@ const (
 	giftCardWorkerEventName = "GiftCardWorkerPollingCheck"
 
 	airdropperBalanceEventName = "AirdropperBalancePollingCheck"
+
+	giftCardQueueSizeGaugeName = "GiftCardAutoReturnQueueSize"
+	airdropperBalanceGaugeName = "AirdropperBalanceQuarks"
 )
 
 func (p *runtime) metricsGaugeWorker(ctx context.Context) error {
@@ -39,6 +42,7 @@ func (p *runtime) recordBackupQueueStatusPollingEvent(ctx context.Context) {
 		metrics.RecordEvent(ctx, giftCardWorkerEventName, map[string]interface{}{
 			"queue_size": count,
 		})
+		metrics.RecordGauge(ctx, giftCardQueueSizeGaugeName, float64(count), nil)
 	}
 }
 
@@ -54,5 +58,8 @@ func (p *runtime) recordAidropAccountBalance(ctx context.Context) {
 			"quarks":          quarks,
 			"quarks_per_unit": config.CoreMintQuarksPerUnit,
 		})
+		metrics.RecordGauge(ctx, airdropperBalanceGaugeName, float64(quarks), map[string]string{
+			"owner": p.airdropper.VaultOwner.PublicKey().ToBase58(),
+		})
 	}
 }
@@ -56,11 +56,11 @@ func setup(t *testing.T) *testEnv {
 	return &testEnv{
 		ctx:     context.Background(),
 		data:    data,
-		runtime: New(log, data, WithEnvConfigs()).(*runtime),
+		runtime: New(log, data, withManualTestOverrides(&testOverrides{})).(*runtime),
 	}
 }
 
-func (e *testEnv) generateRandomGiftCard(t *testing.T, creationTs time.Time) *testGiftCard {
+func (e *testEnv) generateRandomGiftCard(t *testing.T, creationTs time.Time, expiry time.Duration) *testGiftCard {
 	authority := testutil.NewRandomAccount(t)
 
 	vmConfig := testutil.NewRandomVmConfig(t, true)
@@ -77,6 +77,7 @@ func (e *testEnv) generateRandomGiftCard(t *testing.T, creationTs time.Time) *te
 		AccountType: commonpb.AccountType_REMOTE_SEND_GIFT_CARD,
 
 		RequiresAutoReturnCheck: true,
+		GiftCardExpiresAt:       pointer.Time(creationTs.Add(expiry)),
 
 		CreatedAt: creationTs,
 	}
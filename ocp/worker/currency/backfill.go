@@ -0,0 +1,206 @@
+package currency
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	currency_lib "github.com/code-payments/ocp-server/currency"
+	"github.com/code-payments/ocp-server/database/query"
+	"github.com/code-payments/ocp-server/metrics"
+	currency_util "github.com/code-payments/ocp-server/ocp/currency"
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/currency"
+	"github.com/code-payments/ocp-server/ocp/worker"
+	"github.com/code-payments/ocp-server/retry"
+	"github.com/code-payments/ocp-server/retry/backoff"
+)
+
+const (
+	exchangeRateBackfillEventName = "ExchangeRateBackfillPollingCheck"
+
+	exchangeRateCoverageGaugeName         = "ExchangeRateCoverageRatio"
+	exchangeRateMissingIntervalsGaugeName = "ExchangeRateMissingIntervals"
+	exchangeRateBackfilledCountName       = "ExchangeRateIntervalsBackfilled"
+)
+
+type backfillRuntime struct {
+	log  *zap.Logger
+	conf *conf
+	data ocp_data.Provider
+}
+
+// NewExchangeRateBackfillRuntime returns a runtime that scans stored exchange
+// rates for missing update intervals and backfills them from external providers
+func NewExchangeRateBackfillRuntime(log *zap.Logger, data ocp_data.Provider, configProvider ConfigProvider) worker.Runtime {
+	return &backfillRuntime{
+		log:  log,
+		conf: configProvider(),
+		data: data,
+	}
+}
+
+func (p *backfillRuntime) Start(runtimeCtx context.Context, interval time.Duration) error {
+	for {
+		_, err := retry.Retry(
+			func() error {
+				p.log.Debug("backfilling exchange rates")
+
+				provider := runtimeCtx.Value(metrics.ProviderContextKey).(metrics.Provider)
+				trace := provider.StartTrace("currency_exchange_rate_backfill_runtime")
+				defer trace.End()
+				tracedCtx := metrics.NewContext(runtimeCtx, trace)
+
+				err := p.BackfillExchangeRates(tracedCtx)
+				if err != nil {
+					trace.OnError(err)
+					p.log.With(zap.Error(err)).Warn("failed to backfill exchange rates")
+				}
+
+				return err
+			},
+			retry.NonRetriableErrors(context.Canceled),
+			retry.BackoffWithJitter(backoff.BinaryExponential(time.Second), interval, 0.1),
+		)
+		if err != nil {
+			if err != context.Canceled {
+				// Should not happen since only non-retriable error is context.Canceled
+				p.log.With(zap.Error(err)).Warn("unexpected error when backfilling exchange rates")
+			}
+
+			return err
+		}
+
+		select {
+		case <-runtimeCtx.Done():
+			return runtimeCtx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// BackfillExchangeRates finds update intervals within the lookback window that
+// have no exchange rates, and backfills the most recent ones from external
+// providers. Backfilled rates are stored at the start of their interval.
+func (p *backfillRuntime) BackfillExchangeRates(ctx context.Context) error {
+	log := p.log.With(zap.String("method", "BackfillExchangeRates"))
+
+	missing, expected, err := p.getMissingIntervals(ctx)
+	if err != nil {
+		return errors.Wrap(err, "error getting missing exchange rate intervals")
+	}
+
+	maxIntervals := int(p.conf.backfillMaxIntervalsPerRun.Get(ctx))
+
+	var backfilled, failed int
+	var lastErr error
+	for _, intervalStart := range missing {
+		if backfilled+failed >= maxIntervals {
+			break
+		}
+
+		log := log.With(zap.Time("interval_start", intervalStart))
+
+		err := p.backfillInterval(ctx, intervalStart)
+		if err != nil {
+			log.With(zap.Error(err)).Warn("failure backfilling exchange rate interval")
+			lastErr = err
+			failed++
+			continue
+		}
+
+		log.Debug("backfilled exchange rate interval")
+		backfilled++
+	}
+
+	remaining := len(missing) - backfilled
+	coverage := 1.0
+	if expected > 0 {
+		coverage = float64(expected-remaining) / float64(expected)
+	}
+
+	metrics.RecordEvent(ctx, exchangeRateBackfillEventName, map[string]interface{}{
+		"expected_intervals":   expected,
+		"missing_intervals":    len(missing),
+		"backfilled_intervals": backfilled,
+		"failed_intervals":     failed,
+	})
+	metrics.RecordGauge(ctx, exchangeRateCoverageGaugeName, coverage, nil)
+	metrics.RecordGauge(ctx, exchangeRateMissingIntervalsGaugeName, float64(remaining), nil)
+	if backfilled > 0 {
+		metrics.RecordCount(ctx, exchangeRateBackfilledCountName, uint64(backfilled))
+	}
+
+	// Only fail when nothing could be backfilled, which likely indicates the
+	// providers are unavailable and we should back off
+	if failed > 0 && backfilled == 0 {
+		return lastErr
+	}
+	return nil
+}
+
+// getMissingIntervals gets the start of all completed update intervals within
+// the lookback window that have no stored exchange rates, ordered from most to
+// least recent, along with the total number of expected intervals.
+func (p *backfillRuntime) getMissingIntervals(ctx context.Context) ([]time.Time, int, error) {
+	updateInterval := currency_util.GetExchangeRateUpdateInterval()
+
+	end := currency_util.GetLatestExchangeRateTime()
+	lastIntervalStart := currency_util.GetExchangeRateIntervalStart(end)
+	firstIntervalStart := currency_util.GetExchangeRateIntervalStart(end.Add(-p.conf.backfillLookback.Get(ctx)))
+
+	// USD is always part of a stored set of exchange rates, so it's used to
+	// determine coverage. History is queried a day at a time to bound result sizes.
+	covered := make(map[int64]struct{})
+	for start := firstIntervalStart; !start.After(end); start = start.Add(24 * time.Hour) {
+		windowEnd := start.Add(24*time.Hour - time.Second)
+		if windowEnd.After(end) {
+			windowEnd = end
+		}
+
+		records, err := p.data.GetExchangeRateHistory(
+			ctx,
+			currency_lib.USD,
+			query.WithStartTime(start),
+			query.WithEndTime(windowEnd),
+			query.WithInterval(query.IntervalRaw),
+		)
+		if err == currency.ErrNotFound {
+			continue
+		} else if err != nil {
+			return nil, 0, err
+		}
+
+		for _, record := range records {
+			covered[currency_util.GetExchangeRateIntervalStart(record.Time).Unix()] = struct{}{}
+		}
+	}
+
+	var missing []time.Time
+	var expected int
+	for intervalStart := lastIntervalStart; !intervalStart.Before(firstIntervalStart); intervalStart = intervalStart.Add(-updateInterval) {
+		expected++
+
+		if _, ok := covered[intervalStart.Unix()]; !ok {
+			missing = append(missing, intervalStart)
+		}
+	}
+	return missing, expected, nil
+}
+
+func (p *backfillRuntime) backfillInterval(ctx context.Context, intervalStart time.Time) error {
+	record, err := p.data.GetPastExchangeRatesFromExternalProviders(ctx, intervalStart)
+	if err != nil {
+		return errors.Wrap(err, "error getting past exchange rates")
+	}
+
+	record.Time = intervalStart
+
+	err = p.data.ImportExchangeRates(ctx, record)
+	if err == currency.ErrExists {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,118 @@
+package currency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	currency_lib "github.com/code-payments/ocp-server/currency"
+	"github.com/code-payments/ocp-server/currency/fixture"
+	"github.com/code-payments/ocp-server/database/query"
+	"github.com/code-payments/ocp-server/ocp/config"
+	currency_util "github.com/code-payments/ocp-server/ocp/currency"
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/currency"
+)
+
+func TestBackfillExchangeRates_FillsMissingIntervals(t *testing.T) {
+	env := setupBackfillTestEnv(t, 2*time.Hour, 3)
+
+	updateInterval := currency_util.GetExchangeRateUpdateInterval()
+	lastIntervalStart := currency_util.GetExchangeRateIntervalStart(currency_util.GetLatestExchangeRateTime())
+
+	// Existing rates within an interval count towards its coverage
+	existing := []time.Time{
+		lastIntervalStart.Add(time.Minute),
+		lastIntervalStart.Add(-2 * updateInterval).Add(5 * time.Minute),
+	}
+	for _, ts := range existing {
+		require.NoError(t, env.data.ImportExchangeRates(env.ctx, &currency.MultiRateRecord{
+			Time:  ts,
+			Rates: map[string]float64{"usd": 1.0, "cad": 1.3},
+		}))
+	}
+
+	missing, expected, err := env.runtime.getMissingIntervals(env.ctx)
+	require.NoError(t, err)
+	require.Len(t, missing, expected-len(existing))
+	assert.Equal(t, lastIntervalStart.Add(-updateInterval), missing[0])
+
+	for i := 0; i < expected; i++ {
+		require.NoError(t, env.runtime.BackfillExchangeRates(env.ctx))
+	}
+
+	missing, _, err = env.runtime.getMissingIntervals(env.ctx)
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+
+	backfilled, err := env.data.GetExchangeRate(env.ctx, currency_lib.CAD, lastIntervalStart.Add(-1))
+	require.NoError(t, err)
+	assert.Equal(t, lastIntervalStart.Add(-updateInterval).Unix(), backfilled.Time.Unix())
+	assert.Equal(t, 1.35, backfilled.Rate)
+}
+
+func TestBackfillExchangeRates_ProviderFailure(t *testing.T) {
+	env := setupBackfillTestEnv(t, time.Hour, 3)
+
+	env.usdRates.SetError(errors.New("unavailable"))
+	assert.Error(t, env.runtime.BackfillExchangeRates(env.ctx))
+
+	_, err := env.data.GetExchangeRateHistory(
+		env.ctx,
+		currency_lib.USD,
+		query.WithStartTime(time.Now().Add(-2*time.Hour)),
+	)
+	assert.Equal(t, currency.ErrNotFound, err)
+
+	env.usdRates.SetError(nil)
+	assert.NoError(t, env.runtime.BackfillExchangeRates(env.ctx))
+
+	records, err := env.data.GetExchangeRateHistory(
+		env.ctx,
+		currency_lib.USD,
+		query.WithStartTime(time.Now().Add(-2*time.Hour)),
+	)
+	require.NoError(t, err)
+	assert.Len(t, records, 3)
+}
+
+type backfillTestEnv struct {
+	ctx      context.Context
+	data     ocp_data.Provider
+	usdRates *fixture.Client
+	runtime  *backfillRuntime
+}
+
+func setupBackfillTestEnv(t *testing.T, lookback time.Duration, maxIntervalsPerRun uint64) *backfillTestEnv {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	coreMintRates := fixture.NewClient()
+	usdRates := fixture.NewClient()
+	for _, day := range []time.Time{today.Add(-24 * time.Hour), today} {
+		coreMintRates.AddHistoricalRates(string(config.CoreMintSymbol), map[string]float64{"usd": 1.0}, day)
+		usdRates.AddHistoricalRates("usd", map[string]float64{"usd": 1.0, "cad": 1.35}, day)
+	}
+
+	data := &ocp_data.DataProvider{
+		DatabaseProvider: ocp_data.NewTestDatabaseProvider().(*ocp_data.DatabaseProvider),
+		WebProvider:      ocp_data.NewTestWebProvider(coreMintRates, usdRates).(*ocp_data.WebProvider),
+	}
+
+	runtime := NewExchangeRateBackfillRuntime(zap.NewNop(), data, withManualTestOverrides(&testOverrides{
+		backfillLookback:           lookback,
+		backfillMaxIntervalsPerRun: maxIntervalsPerRun,
+	})).(*backfillRuntime)
+
+	return &backfillTestEnv{
+		ctx:      context.Background(),
+		data:     data,
+		usdRates: usdRates,
+		runtime:  runtime,
+	}
+}
@@ -0,0 +1,84 @@
+package currency
+
+import (
+	"time"
+
+	"github.com/code-payments/ocp-server/config"
+	"github.com/code-payments/ocp-server/config/env"
+	"github.com/code-payments/ocp-server/config/memory"
+	"github.com/code-payments/ocp-server/config/wrapper"
+)
+
+const (
+	envConfigPrefix = "CURRENCY_RUNTIME_"
+
+	BackfillLookbackConfigEnvName = envConfigPrefix + "BACKFILL_LOOKBACK"
+	defaultBackfillLookback       = 7 * 24 * time.Hour
+
+	BackfillMaxIntervalsPerRunConfigEnvName = envConfigPrefix + "BACKFILL_MAX_INTERVALS_PER_RUN"
+	defaultBackfillMaxIntervalsPerRun       = 16
+
+	// Minimum sell fees, in core mint quarks, that must accumulate in a
+	// liquidity pool before they're burned
+	FeeBurnMinQuarksConfigEnvName = envConfigPrefix + "FEE_BURN_MIN_QUARKS"
+	defaultFeeBurnMinQuarks       = 1_000_000 // 1 USDF
+
+	FeeBurnBatchSizeConfigEnvName = envConfigPrefix + "FEE_BURN_BATCH_SIZE"
+	defaultFeeBurnBatchSize       = 100
+
+	DisableFeeBurnSubmissionConfigEnvName = envConfigPrefix + "DISABLE_FEE_BURN_SUBMISSION"
+	defaultDisableFeeBurnSubmission       = false
+
+	// Time after which a fee burn transaction that hasn't landed is abandoned,
+	// so a new fee burn can be made for the currency
+	FeeBurnSubmissionTimeoutConfigEnvName = envConfigPrefix + "FEE_BURN_SUBMISSION_TIMEOUT"
+	defaultFeeBurnSubmissionTimeout       = 10 * time.Minute
+)
+
+type conf struct {
+	backfillLookback           config.Duration
+	backfillMaxIntervalsPerRun config.Uint64
+	feeBurnMinQuarks           config.Uint64
+	feeBurnBatchSize           config.Uint64
+	disableFeeBurnSubmission   config.Bool
+	feeBurnSubmissionTimeout   config.Duration
+}
+
+// ConfigProvider defines how config values are pulled
+type ConfigProvider func() *conf
+
+// WithEnvConfigs returns configuration pulled from environment variables
+func WithEnvConfigs() ConfigProvider {
+	return func() *conf {
+		return &conf{
+			backfillLookback:           env.NewDurationConfig(BackfillLookbackConfigEnvName, defaultBackfillLookback),
+			backfillMaxIntervalsPerRun: env.NewUint64Config(BackfillMaxIntervalsPerRunConfigEnvName, defaultBackfillMaxIntervalsPerRun),
+			feeBurnMinQuarks:           env.NewUint64Config(FeeBurnMinQuarksConfigEnvName, defaultFeeBurnMinQuarks),
+			feeBurnBatchSize:           env.NewUint64Config(FeeBurnBatchSizeConfigEnvName, defaultFeeBurnBatchSize),
+			disableFeeBurnSubmission:   env.NewBoolConfig(DisableFeeBurnSubmissionConfigEnvName, defaultDisableFeeBurnSubmission),
+			feeBurnSubmissionTimeout:   env.NewDurationConfig(FeeBurnSubmissionTimeoutConfigEnvName, defaultFeeBurnSubmissionTimeout),
+		}
+	}
+}
+
+type testOverrides struct {
+	backfillLookback           time.Duration
+	backfillMaxIntervalsPerRun uint64
+	feeBurnMinQuarks           uint64
+	feeBurnBatchSize           uint64
+	disableFeeBurnSubmission   bool
+	feeBurnSubmissionTimeout   time.Duration
+}
+
+func withManualTestOverrides(overrides *testOverrides) ConfigProvider {
+	return func() *conf {
+		return &conf{
+			backfillLookback:           wrapper.NewDurationConfig(memory.NewConfig(overrides.backfillLookback), defaultBackfillLookback),
+			backfillMaxIntervalsPerRun: wrapper.NewUint64Config(memory.NewConfig(overrides.backfillMaxIntervalsPerRun), defaultBackfillMaxIntervalsPerRun),
+			feeBurnMinQuarks:           wrapper.NewUint64Config(memory.NewConfig(overrides.feeBurnMinQuarks), defaultFeeBurnMinQuarks),
+			feeBurnBatchSize:           wrapper.NewUint64Config(memory.NewConfig(overrides.feeBurnBatchSize), defaultFeeBurnBatchSize),
+			disableFeeBurnSubmission:   wrapper.NewBoolConfig(memory.NewConfig(overrides.disableFeeBurnSubmission), defaultDisableFeeBurnSubmission),
+			feeBurnSubmissionTimeout:   wrapper.NewDurationConfig(memory.NewConfig(overrides.feeBurnSubmissionTimeout), defaultFeeBurnSubmissionTimeout),
+		}
+	}
+}
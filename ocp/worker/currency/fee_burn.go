@@ -0,0 +1,584 @@
+package currency
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/mr-tron/base58"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/code-payments/ocp-server/database/query"
+	"github.com/code-payments/ocp-server/metrics"
+	"github.com/code-payments/ocp-server/ocp/common"
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/currency"
+	"github.com/code-payments/ocp-server/ocp/data/feeburn"
+	"github.com/code-payments/ocp-server/ocp/data/nonce"
+	"github.com/code-payments/ocp-server/ocp/subsidizer"
+	transaction_util "github.com/code-payments/ocp-server/ocp/transaction"
+	"github.com/code-payments/ocp-server/ocp/worker"
+	"github.com/code-payments/ocp-server/retry"
+	"github.com/code-payments/ocp-server/retry/backoff"
+	"github.com/code-payments/ocp-server/solana"
+	"github.com/code-payments/ocp-server/solana/currencycreator"
+)
+
+const (
+	feeBurnPollingCheckEventName = "CurrencyFeeBurnPollingCheck"
+	feeBurnSubmittedEventName    = "CurrencyFeeBurnSubmitted"
+	feeBurnFinalizedEventName    = "CurrencyFeeBurnFinalized"
+	feeBurnAbandonedEventName    = "CurrencyFeeBurnAbandoned"
+)
+
+type feeBurnRuntime struct {
+	log       *zap.Logger
+	conf      *conf
+	data      ocp_data.Provider
+	noncePool *transaction_util.LocalNoncePool
+	budget    *subsidizer.Budget
+}
+
+// NewFeeBurnRuntime returns a runtime that burns the sell fees accumulated in
+// launchpad currency liquidity pools using transactions backed by the internal
+// server process nonce pool
+func NewFeeBurnRuntime(log *zap.Logger, data ocp_data.Provider, noncePool *transaction_util.LocalNoncePool, budget *subsidizer.Budget, configProvider ConfigProvider) (worker.Runtime, error) {
+	if err := noncePool.Validate(nonce.EnvironmentSolana, nonce.EnvironmentInstanceSolanaMainnet, nonce.PurposeInternalServerProcess); err != nil {
+		return nil, err
+	}
+
+	return &feeBurnRuntime{
+		log:       log,
+		conf:      configProvider(),
+		data:      data,
+		noncePool: noncePool,
+		budget:    budget,
+	}, nil
+}
+
+func (p *feeBurnRuntime) Start(runtimeCtx context.Context, interval time.Duration) error {
+	for {
+		_, err := retry.Retry(
+			func() error {
+				p.log.Debug("burning currency fees")
+
+				provider := runtimeCtx.Value(metrics.ProviderContextKey).(metrics.Provider)
+				trace := provider.StartTrace("currency_fee_burn_runtime")
+				defer trace.End()
+				tracedCtx := metrics.NewContext(runtimeCtx, trace)
+
+				processErr := p.ProcessPendingFeeBurns(tracedCtx)
+				if processErr != nil {
+					trace.OnError(processErr)
+					p.log.With(zap.Error(processErr)).Warn("failed to process pending fee burns")
+				}
+
+				burnErr := p.BurnAllLaunchpadCurrencyFees(tracedCtx)
+				if burnErr != nil {
+					trace.OnError(burnErr)
+					p.log.With(zap.Error(burnErr)).Warn("failed to burn currency fees")
+				}
+
+				if processErr != nil {
+					return processErr
+				}
+				return burnErr
+			},
+			retry.NonRetriableErrors(context.Canceled),
+			retry.BackoffWithJitter(backoff.BinaryExponential(time.Second), interval, 0.1),
+		)
+		if err != nil {
+			if err != context.Canceled {
+				// Should not happen since only non-retriable error is context.Canceled
+				p.log.With(zap.Error(err)).Warn("unexpected error when burning currency fees")
+			}
+
+			return err
+		}
+
+		select {
+		case <-runtimeCtx.Done():
+			return runtimeCtx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// ProcessPendingFeeBurns finalizes fee burns whose transactions have been
+// finalized on the blockchain, abandons those past the submission deadline,
+// and resubmits the transactions for the rest
+func (p *feeBurnRuntime) ProcessPendingFeeBurns(ctx context.Context) error {
+	log := p.log.With(zap.String("method", "ProcessPendingFeeBurns"))
+
+	batchSize := p.conf.feeBurnBatchSize.Get(ctx)
+
+	var cursor query.Cursor
+	var failed int
+	for {
+		records, err := p.data.GetAllCurrencyFeeBurnsByState(
+			ctx,
+			feeburn.StatePending,
+			query.WithCursor(cursor),
+			query.WithDirection(query.Ascending),
+			query.WithLimit(batchSize),
+		)
+		if err == feeburn.ErrNotFound {
+			break
+		} else if err != nil {
+			return errors.Wrap(err, "error getting pending fee burns")
+		}
+
+		for _, record := range records {
+			log := log.With(
+				zap.String("mint", record.Mint),
+				zap.String("signature", record.Signature),
+			)
+
+			err = p.processPendingFeeBurn(ctx, record)
+			if err != nil {
+				log.With(zap.Error(err)).Warn("failure processing pending fee burn")
+				failed++
+			}
+		}
+
+		if uint64(len(records)) < batchSize {
+			break
+		}
+		cursor = query.ToCursor(records[len(records)-1].Id)
+	}
+
+	if failed > 0 {
+		return errors.Errorf("failed to process %d pending fee burns", failed)
+	}
+	return nil
+}
+
+func (p *feeBurnRuntime) processPendingFeeBurn(ctx context.Context, record *feeburn.Record) error {
+	finalizedTxn, err := p.data.GetBlockchainTransaction(ctx, record.Signature, solana.CommitmentFinalized)
+	if err != nil && err != solana.ErrSignatureNotFound {
+		return errors.Wrap(err, "error getting finalized transaction")
+	}
+
+	if finalizedTxn != nil {
+		p.budget.ObserveFee(ctx, subsidizer.ExpenseBurnFees, finalizedTxn.Meta.Fee)
+
+		if finalizedTxn.Err != nil || finalizedTxn.Meta.Err != nil {
+			return p.markFeeBurnFinalized(ctx, record, feeburn.StateFailed, 0, finalizedTxn.Meta.Fee)
+		}
+
+		quarksBurned, err := p.getQuarksBurned(ctx, record)
+		if err != nil {
+			return errors.Wrap(err, "error getting quarks burned")
+		}
+		return p.markFeeBurnFinalized(ctx, record, feeburn.StateConfirmed, quarksBurned, finalizedTxn.Meta.Fee)
+	}
+
+	// The transaction is abandoned if it hasn't landed by the submission
+	// deadline, which is likely due to it being rejected before reaching the
+	// blockchain
+	if time.Since(record.CreatedAt) > p.conf.feeBurnSubmissionTimeout.Get(ctx) {
+		return p.abandonFeeBurn(ctx, record)
+	}
+
+	// Otherwise, continually retry submitting the transaction
+
+	return p.submitTransaction(ctx, record)
+}
+
+// abandonFeeBurn fails a fee burn whose transaction never landed, which allows
+// a new fee burn for the currency. Its nonce is advanced by a separate transaction,
+// so the abandoned transaction can never land, and released with that
+// transaction's signature for the nonce worker to refresh.
+func (p *feeBurnRuntime) abandonFeeBurn(ctx context.Context, record *feeburn.Record) error {
+	nonceAccount, err := common.NewAccountFromPublicKeyString(record.Nonce)
+	if err != nil {
+		return err
+	}
+
+	decodedBlockhash, err := base58.Decode(record.Blockhash)
+	if err != nil {
+		return err
+	}
+	var blockhash solana.Blockhash
+	copy(blockhash[:], decodedBlockhash)
+
+	txn, err := transaction_util.MakeAdvanceNonceTransaction(&transaction_util.Nonce{
+		Account:   nonceAccount,
+		Blockhash: blockhash,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = txn.Sign(common.GetSubsidizer().PrivateKey().ToBytes())
+	if err != nil {
+		return err
+	}
+
+	advanceSignature := base58.Encode(txn.Signature())
+
+	err = p.data.ExecuteInTx(ctx, sql.LevelDefault, func(ctx context.Context) error {
+		if record.State != feeburn.StatePending {
+			return errors.New("invalid fee burn state")
+		}
+
+		nonceRecord, err := p.data.GetNonce(ctx, record.Nonce)
+		if err != nil {
+			return err
+		}
+
+		if nonceRecord.Signature != record.Signature {
+			return errors.New("unexpected nonce signature")
+		}
+
+		if nonceRecord.Blockhash != record.Blockhash {
+			return errors.New("unexpected nonce blockhash")
+		}
+
+		if nonceRecord.State != nonce.StateReserved {
+			return errors.New("unexpected nonce state")
+		}
+
+		nonceRecord.Signature = advanceSignature
+		nonceRecord.State = nonce.StateReleased
+		err = p.data.SaveNonce(ctx, nonceRecord)
+		if err != nil {
+			return err
+		}
+
+		record.Quarks = 0
+		record.TransactionBlob = nil
+		record.State = feeburn.StateFailed
+		return p.data.UpdateCurrencyFeeBurn(ctx, record)
+	})
+	if err != nil {
+		return err
+	}
+
+	metrics.RecordEvent(ctx, feeBurnAbandonedEventName, map[string]interface{}{
+		"mint":      record.Mint,
+		"signature": record.Signature,
+	})
+
+	// The nonce remains released, and unusable, until the advance transaction
+	// is observed on the blockchain
+	_, err = p.data.SubmitBlockchainTransaction(ctx, &txn)
+	if err != nil {
+		p.log.With(
+			zap.Error(err),
+			zap.String("mint", record.Mint),
+			zap.String("signature", advanceSignature),
+		).Warn("failure submitting nonce advance transaction")
+	}
+	return nil
+}
+
+// BurnAllLaunchpadCurrencyFees submits a fee burn for every launchpad currency
+// whose liquidity pool has accumulated at least the minimum fees to burn. At
+// most one fee burn is in flight per currency.
+func (p *feeBurnRuntime) BurnAllLaunchpadCurrencyFees(ctx context.Context) error {
+	log := p.log.With(zap.String("method", "BurnAllLaunchpadCurrencyFees"))
+
+	metadataRecords, err := p.data.GetAllCurrencyMetadata(ctx)
+	if err == currency.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "error getting currency metadata")
+	}
+
+	var eligibleRecords []*currency.MetadataRecord
+	for _, metadataRecord := range metadataRecords {
+		latestFeeBurn, err := p.data.GetLatestCurrencyFeeBurnByMint(ctx, metadataRecord.Mint)
+		if err == nil && latestFeeBurn.State == feeburn.StatePending {
+			continue
+		} else if err != nil && err != feeburn.ErrNotFound {
+			return errors.Wrap(err, "error getting latest fee burn")
+		}
+
+		eligibleRecords = append(eligibleRecords, metadataRecord)
+	}
+
+	if len(eligibleRecords) == 0 {
+		return nil
+	}
+
+	poolAccounts := make([]string, len(eligibleRecords))
+	for i, metadataRecord := range eligibleRecords {
+		poolAccounts[i] = metadataRecord.LiquidityPool
+	}
+
+	accountInfos, err := p.data.GetBlockchainMultipleAccountInfos(ctx, poolAccounts, solana.CommitmentFinalized)
+	if err != nil {
+		return errors.Wrap(err, "error getting liquidity pool accounts")
+	}
+
+	minQuarks := p.conf.feeBurnMinQuarks.Get(ctx)
+
+	var submitted, failed int
+	var totalFeesAccumulated uint64
+	for i, metadataRecord := range eligibleRecords {
+		log := log.With(zap.String("mint", metadataRecord.Mint))
+
+		feesAccumulated, err := getAccumulatedFees(metadataRecord, accountInfos[i])
+		if err != nil {
+			log.With(zap.Error(err)).Warn("failure getting accumulated fees")
+			failed++
+			continue
+		}
+
+		totalFeesAccumulated += feesAccumulated
+
+		if feesAccumulated == 0 || feesAccumulated < minQuarks {
+			continue
+		}
+
+		err = p.burnFees(ctx, metadataRecord, feesAccumulated)
+		if err == common.ErrSubsidizerRequiresFunding {
+			log.Warn("subsidizer requires funding to burn fees")
+			failed++
+			break
+		} else if err != nil {
+			log.With(zap.Error(err)).Warn("failure burning fees")
+			failed++
+			continue
+		}
+
+		submitted++
+	}
+
+	metrics.RecordEvent(ctx, feeBurnPollingCheckEventName, map[string]interface{}{
+		"currency_count":         len(eligibleRecords),
+		"submitted_count":        submitted,
+		"failed_count":           failed,
+		"total_fees_accumulated": totalFeesAccumulated,
+	})
+
+	if failed > 0 {
+		return errors.Errorf("failed to burn fees for %d of %d currencies", failed, len(eligibleRecords))
+	}
+	return nil
+}
+
+func (p *feeBurnRuntime) burnFees(ctx context.Context, metadataRecord *currency.MetadataRecord, feesAccumulated uint64) (err error) {
+	liquidityPool, err := common.NewAccountFromPublicKeyString(metadataRecord.LiquidityPool)
+	if err != nil {
+		return err
+	}
+
+	vaultBase, err := common.NewAccountFromPublicKeyString(metadataRecord.VaultCore)
+	if err != nil {
+		return err
+	}
+
+	// There's at most one fee burn being created per currency, and it's counted
+	// as committed by the budget once it's saved, so a single reservation per
+	// currency suffices.
+	reservationId := "burn_fees:" + metadataRecord.Mint
+	err = p.budget.Reserve(ctx, reservationId, subsidizer.ExpenseBurnFees)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			p.budget.Release(reservationId)
+		}
+	}()
+
+	selectedNonce, err := p.noncePool.GetNonce(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		selectedNonce.ReleaseIfNotReserved(ctx)
+	}()
+
+	txn, err := transaction_util.MakeBurnFeesTransaction(selectedNonce, liquidityPool, vaultBase)
+	if err != nil {
+		return err
+	}
+
+	err = txn.Sign(common.GetSubsidizer().PrivateKey().ToBytes())
+	if err != nil {
+		return err
+	}
+
+	record := &feeburn.Record{
+		Mint:          metadataRecord.Mint,
+		LiquidityPool: metadataRecord.LiquidityPool,
+
+		Quarks: feesAccumulated,
+
+		Signature: base58.Encode(txn.Signature()),
+		Nonce:     selectedNonce.Account.PublicKey().ToBase58(),
+		Blockhash: base58.Encode(selectedNonce.Blockhash[:]),
+
+		TransactionBlob: txn.Marshal(),
+
+		State: feeburn.StatePending,
+	}
+
+	err = p.data.ExecuteInTx(ctx, sql.LevelDefault, func(ctx context.Context) error {
+		err := selectedNonce.MarkReservedWithSignature(ctx, record.Signature)
+		if err != nil {
+			return err
+		}
+
+		return p.data.PutCurrencyFeeBurn(ctx, record)
+	})
+	if err != nil {
+		return err
+	}
+
+	metrics.RecordEvent(ctx, feeBurnSubmittedEventName, map[string]interface{}{
+		"mint":      record.Mint,
+		"signature": record.Signature,
+		"quarks":    record.Quarks,
+	})
+
+	// Failures are retried when pending fee burns are processed
+	submitErr := p.submitTransaction(ctx, record)
+	if submitErr != nil {
+		p.log.With(
+			zap.Error(submitErr),
+			zap.String("mint", record.Mint),
+			zap.String("signature", record.Signature),
+		).Warn("failure submitting fee burn transaction")
+	}
+	return nil
+}
+
+func (p *feeBurnRuntime) submitTransaction(ctx context.Context, record *feeburn.Record) error {
+	if p.conf.disableFeeBurnSubmission.Get(ctx) {
+		return nil
+	}
+
+	var txn solana.Transaction
+	err := txn.Unmarshal(record.TransactionBlob)
+	if err != nil {
+		return errors.Wrap(err, "error unmarshalling transaction")
+	}
+
+	if base58.Encode(txn.Signature()) != record.Signature {
+		return errors.New("unexpected transaction signature")
+	}
+
+	_, err = p.data.SubmitBlockchainTransaction(ctx, &txn)
+	if err != nil {
+		return errors.Wrap(err, "error submitting transaction")
+	}
+	return nil
+}
+
+func (p *feeBurnRuntime) getQuarksBurned(ctx context.Context, record *feeburn.Record) (uint64, error) {
+	metadataRecord, err := p.data.GetCurrencyMetadata(ctx, record.Mint)
+	if err != nil {
+		return 0, err
+	}
+
+	vaultBase, err := common.NewAccountFromPublicKeyString(metadataRecord.VaultCore)
+	if err != nil {
+		return 0, err
+	}
+
+	tokenBalances, err := p.data.GetBlockchainTransactionTokenBalances(ctx, record.Signature)
+	if err != nil {
+		return 0, err
+	}
+
+	deltaQuarks, err := transaction_util.GetDeltaQuarksFromTokenBalances(vaultBase, tokenBalances)
+	if err != nil {
+		return 0, err
+	}
+	if deltaQuarks > 0 {
+		return 0, errors.New("core mint vault balance increased")
+	}
+	return uint64(-deltaQuarks), nil
+}
+
+func (p *feeBurnRuntime) markFeeBurnFinalized(ctx context.Context, record *feeburn.Record, state feeburn.State, quarksBurned, fee uint64) error {
+	err := p.data.ExecuteInTx(ctx, sql.LevelDefault, func(ctx context.Context) error {
+		if record.State != feeburn.StatePending {
+			return errors.New("invalid fee burn state")
+		}
+
+		nonceRecord, err := p.data.GetNonce(ctx, record.Nonce)
+		if err != nil {
+			return err
+		}
+
+		if nonceRecord.Signature != record.Signature {
+			return errors.New("unexpected nonce signature")
+		}
+
+		if nonceRecord.Blockhash != record.Blockhash {
+			return errors.New("unexpected nonce blockhash")
+		}
+
+		if nonceRecord.State != nonce.StateReserved {
+			return errors.New("unexpected nonce state")
+		}
+
+		nonceRecord.State = nonce.StateReleased
+		err = p.data.SaveNonce(ctx, nonceRecord)
+		if err != nil {
+			return err
+		}
+
+		record.Quarks = quarksBurned
+		record.TransactionBlob = nil
+		record.Fee = fee
+		record.State = state
+		return p.data.UpdateCurrencyFeeBurn(ctx, record)
+	})
+	if err != nil {
+		return err
+	}
+
+	metrics.RecordEvent(ctx, feeBurnFinalizedEventName, map[string]interface{}{
+		"mint":      record.Mint,
+		"signature": record.Signature,
+		"state":     record.State.String(),
+		"quarks":    record.Quarks,
+		"fee":       record.Fee,
+	})
+
+	return nil
+}
+
+// getAccumulatedFees gets the sell fees, in core mint quarks, accumulated in a
+// launchpad currency's liquidity pool
+func getAccumulatedFees(metadataRecord *currency.MetadataRecord, poolAccountInfo *solana.AccountInfo) (uint64, error) {
+	if poolAccountInfo == nil {
+		return 0, errors.New("liquidity pool account not found")
+	}
+
+	var poolAccount currencycreator.LiquidityPoolAccount
+	err := poolAccount.Unmarshal(poolAccountInfo.Data)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid liquidity pool account")
+	}
+
+	mintAccount, err := common.NewAccountFromPublicKeyString(metadataRecord.Mint)
+	if err != nil {
+		return 0, err
+	}
+	if !bytes.Equal(poolAccount.TargetMint, mintAccount.PublicKey().ToBytes()) {
+		return 0, errors.New("liquidity pool has an unexpected target mint")
+	}
+
+	if !bytes.Equal(poolAccount.BaseMint, common.CoreMintAccount.PublicKey().ToBytes()) {
+		return 0, errors.New("liquidity pool has an unexpected base mint")
+	}
+
+	vaultBaseAccount, err := common.NewAccountFromPublicKeyString(metadataRecord.VaultCore)
+	if err != nil {
+		return 0, err
+	}
+	if !bytes.Equal(poolAccount.VaultBase, vaultBaseAccount.PublicKey().ToBytes()) {
+		return 0, errors.New("liquidity pool has an unexpected base vault")
+	}
+
+	return poolAccount.FeesAccumulated, nil
+}
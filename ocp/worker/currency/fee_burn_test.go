@@ -0,0 +1,97 @@
+package currency
+
+import (
+	"crypto/ed25519"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/code-payments/ocp-server/ocp/common"
+	"github.com/code-payments/ocp-server/ocp/data/currency"
+	"github.com/code-payments/ocp-server/solana"
+	"github.com/code-payments/ocp-server/solana/currencycreator"
+	"github.com/code-payments/ocp-server/testutil"
+)
+
+func TestGetAccumulatedFees(t *testing.T) {
+	mintAccount := testutil.NewRandomAccount(t)
+	vaultBaseAccount := testutil.NewRandomAccount(t)
+
+	metadataRecord := &currency.MetadataRecord{
+		Mint:      mintAccount.PublicKey().ToBase58(),
+		VaultCore: vaultBaseAccount.PublicKey().ToBase58(),
+	}
+
+	poolAccount := &currencycreator.LiquidityPoolAccount{
+		Authority:       testutil.NewRandomAccount(t).PublicKey().ToBytes(),
+		Currency:        testutil.NewRandomAccount(t).PublicKey().ToBytes(),
+		TargetMint:      mintAccount.PublicKey().ToBytes(),
+		BaseMint:        common.CoreMintAccount.PublicKey().ToBytes(),
+		VaultTarget:     testutil.NewRandomAccount(t).PublicKey().ToBytes(),
+		VaultBase:       vaultBaseAccount.PublicKey().ToBytes(),
+		FeesAccumulated: 12_345,
+		SellFee:         currencycreator.DefaultSellFeeBps,
+	}
+
+	fees, err := getAccumulatedFees(metadataRecord, &solana.AccountInfo{Data: marshalLiquidityPoolAccount(poolAccount)})
+	require.NoError(t, err)
+	assert.EqualValues(t, 12_345, fees)
+
+	_, err = getAccumulatedFees(metadataRecord, nil)
+	assert.Error(t, err)
+
+	_, err = getAccumulatedFees(metadataRecord, &solana.AccountInfo{Data: []byte{1, 2, 3}})
+	assert.Error(t, err)
+
+	for _, corrupt := range []func(poolAccount *currencycreator.LiquidityPoolAccount){
+		func(poolAccount *currencycreator.LiquidityPoolAccount) {
+			poolAccount.TargetMint = testutil.NewRandomAccount(t).PublicKey().ToBytes()
+		},
+		func(poolAccount *currencycreator.LiquidityPoolAccount) {
+			poolAccount.BaseMint = testutil.NewRandomAccount(t).PublicKey().ToBytes()
+		},
+		func(poolAccount *currencycreator.LiquidityPoolAccount) {
+			poolAccount.VaultBase = testutil.NewRandomAccount(t).PublicKey().ToBytes()
+		},
+	} {
+		corrupted := *poolAccount
+		corrupt(&corrupted)
+
+		_, err = getAccumulatedFees(metadataRecord, &solana.AccountInfo{Data: marshalLiquidityPoolAccount(&corrupted)})
+		assert.Error(t, err)
+	}
+}
+
+func marshalLiquidityPoolAccount(poolAccount *currencycreator.LiquidityPoolAccount) []byte {
+	data := make([]byte, currencycreator.LiquidityPoolAccountSize)
+
+	var offset int
+	copy(data[offset:], currencycreator.LiquidityPoolAccountDiscriminator)
+	offset += len(currencycreator.LiquidityPoolAccountDiscriminator)
+
+	for _, key := range []ed25519.PublicKey{
+		poolAccount.Authority,
+		poolAccount.Currency,
+		poolAccount.TargetMint,
+		poolAccount.BaseMint,
+		poolAccount.VaultTarget,
+		poolAccount.VaultBase,
+	} {
+		copy(data[offset:], key)
+		offset += ed25519.PublicKeySize
+	}
+
+	binary.LittleEndian.PutUint64(data[offset:], poolAccount.FeesAccumulated)
+	offset += 8
+
+	binary.LittleEndian.PutUint16(data[offset:], poolAccount.SellFee)
+	offset += 2
+
+	data[offset] = poolAccount.Bump
+	data[offset+1] = poolAccount.VaultTargetBump
+	data[offset+2] = poolAccount.VaultBaseBump
+
+	return data
+}
@@ -1,13 +1,16 @@
 package currency
 
 import (
+	"bytes"
 	"context"
 	"time"
 
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
 	"github.com/code-payments/ocp-server/metrics"
 	"github.com/code-payments/ocp-server/ocp/common"
+	currency_util "github.com/code-payments/ocp-server/ocp/currency"
 	ocp_data "github.com/code-payments/ocp-server/ocp/data"
 	"github.com/code-payments/ocp-server/ocp/data/currency"
 	"github.com/code-payments/ocp-server/ocp/worker"
@@ -18,6 +21,10 @@ import (
 	"github.com/code-payments/ocp-server/solana/token"
 )
 
+const (
+	reserveUpdateEventName = "CurrencyReserveUpdate"
+)
+
 type reserveRuntime struct {
 	log  *zap.Logger
 	data ocp_data.Provider
@@ -34,7 +41,7 @@ func (p *reserveRuntime) Start(runtimeCtx context.Context, interval time.Duratio
 	for {
 		_, err := retry.Retry(
 			func() error {
-				p.log.Debug("updating exchange rates")
+				p.log.Debug("updating currency reserves")
 
 				provider := runtimeCtx.Value(metrics.ProviderContextKey).(metrics.Provider)
 				trace := provider.StartTrace("currency_reserve_runtime")
@@ -69,30 +76,94 @@ func (p *reserveRuntime) Start(runtimeCtx context.Context, interval time.Duratio
 	}
 }
 
-// todo: Don't hardcode Jeffy and other Flipcash currencies
+// UpdateAllLaunchpadCurrencyReserves records the reserve state of every
+// launchpad currency. Reserves are recorded once per exchange rate update
+// interval at the start of the interval, which aligns them with the times used
+// to lookup exchange rates. Exchange rates for launchpad currencies are derived
+// from the reserve state on the bonding curve when they're needed.
 func (p *reserveRuntime) UpdateAllLaunchpadCurrencyReserves(ctx context.Context) error {
-	err1 := func() error {
-		jeffyMintAccount, _ := common.NewAccountFromPublicKeyString("todo")
-		jeffyVaultAccount, _ := common.NewAccountFromPublicKeyString("todo")
+	log := p.log.With(zap.String("method", "UpdateAllLaunchpadCurrencyReserves"))
+
+	metadataRecords, err := p.data.GetAllCurrencyMetadata(ctx)
+	if err == currency.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "error getting currency metadata")
+	}
+
+	now := time.Now()
+	intervalStart := currency_util.GetExchangeRateIntervalStart(now)
+
+	vaultAccounts := make([]string, len(metadataRecords))
+	for i, metadataRecord := range metadataRecords {
+		vaultAccounts[i] = metadataRecord.VaultMint
+	}
 
-		var tokenAccount token.Account
-		ai, err := p.data.GetBlockchainAccountInfo(ctx, jeffyVaultAccount.PublicKey().ToBase58(), solana.CommitmentFinalized)
+	accountInfos, err := p.data.GetBlockchainMultipleAccountInfos(ctx, vaultAccounts, solana.CommitmentFinalized)
+	if err != nil {
+		return errors.Wrap(err, "error getting mint vault accounts")
+	}
+
+	var recorded, failed int
+	for i, metadataRecord := range metadataRecords {
+		log := log.With(zap.String("mint", metadataRecord.Mint))
+
+		reserveRecord, err := toReserveRecord(metadataRecord, accountInfos[i], intervalStart)
 		if err != nil {
-			return err
+			log.With(zap.Error(err)).Warn("failure computing currency reserve")
+			failed++
+			continue
 		}
-		tokenAccount.Unmarshal(ai.Data)
-		jeffyVaultBalance := tokenAccount.Amount
-
-		return p.data.PutCurrencyReserve(ctx, &currency.ReserveRecord{
-			Mint:              jeffyMintAccount.PublicKey().ToBase58(),
-			SupplyFromBonding: currencycreator.DefaultMintMaxQuarkSupply - jeffyVaultBalance,
-			Time:              time.Now(),
-		})
-	}()
-
-	if err1 != nil {
-		return err1
+
+		// The reserve may already be recorded for the current interval
+		err = p.data.PutCurrencyReserve(ctx, reserveRecord)
+		if err != nil && err != currency.ErrExists {
+			log.With(zap.Error(err)).Warn("failure saving currency reserve")
+			failed++
+			continue
+		}
+
+		recorded++
 	}
 
+	metrics.RecordEvent(ctx, reserveUpdateEventName, map[string]interface{}{
+		"currency_count": len(metadataRecords),
+		"recorded_count": recorded,
+		"failed_count":   failed,
+	})
+
+	if failed > 0 {
+		return errors.Errorf("failed to update %d of %d currency reserves", failed, len(metadataRecords))
+	}
 	return nil
 }
+
+func toReserveRecord(metadataRecord *currency.MetadataRecord, vaultAccountInfo *solana.AccountInfo, t time.Time) (*currency.ReserveRecord, error) {
+	if vaultAccountInfo == nil {
+		return nil, errors.New("mint vault account not found")
+	}
+
+	var vaultAccount token.Account
+	if !vaultAccount.Unmarshal(vaultAccountInfo.Data) {
+		return nil, errors.New("invalid mint vault token account")
+	}
+
+	mintAccount, err := common.NewAccountFromPublicKeyString(metadataRecord.Mint)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(vaultAccount.Mint, mintAccount.PublicKey().ToBytes()) {
+		return nil, errors.New("mint vault token account has an unexpected mint")
+	}
+
+	if vaultAccount.Amount > currencycreator.DefaultMintMaxQuarkSupply {
+		return nil, errors.New("mint vault balance exceeds max supply")
+	}
+	supplyFromBonding := currencycreator.DefaultMintMaxQuarkSupply - vaultAccount.Amount
+
+	return &currency.ReserveRecord{
+		Mint:              metadataRecord.Mint,
+		SupplyFromBonding: supplyFromBonding,
+		Time:              t,
+	}, nil
+}
@@ -0,0 +1,50 @@
+package currency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/code-payments/ocp-server/ocp/data/currency"
+	"github.com/code-payments/ocp-server/solana"
+	"github.com/code-payments/ocp-server/solana/currencycreator"
+	"github.com/code-payments/ocp-server/solana/token"
+	"github.com/code-payments/ocp-server/testutil"
+)
+
+func TestToReserveRecord(t *testing.T) {
+	now := time.Now()
+	mintAccount := testutil.NewRandomAccount(t)
+	otherMintAccount := testutil.NewRandomAccount(t)
+
+	metadataRecord := &currency.MetadataRecord{
+		Mint: mintAccount.PublicKey().ToBase58(),
+	}
+
+	vaultBalance := uint64(currencycreator.DefaultMintMaxQuarkSupply - 12_345)
+	vaultAccount := &token.Account{
+		Mint:   mintAccount.PublicKey().ToBytes(),
+		Owner:  testutil.NewRandomAccount(t).PublicKey().ToBytes(),
+		Amount: vaultBalance,
+		State:  token.AccountStateInitialized,
+	}
+
+	reserveRecord, err := toReserveRecord(metadataRecord, &solana.AccountInfo{Data: vaultAccount.Marshal()}, now)
+	require.NoError(t, err)
+	assert.Equal(t, metadataRecord.Mint, reserveRecord.Mint)
+	assert.EqualValues(t, 12_345, reserveRecord.SupplyFromBonding)
+	assert.Equal(t, now, reserveRecord.Time)
+	assert.NoError(t, reserveRecord.Validate())
+
+	_, err = toReserveRecord(metadataRecord, nil, now)
+	assert.Error(t, err)
+
+	_, err = toReserveRecord(metadataRecord, &solana.AccountInfo{Data: []byte{1, 2, 3}}, now)
+	assert.Error(t, err)
+
+	vaultAccount.Mint = otherMintAccount.PublicKey().ToBytes()
+	_, err = toReserveRecord(metadataRecord, &solana.AccountInfo{Data: vaultAccount.Marshal()}, now)
+	assert.Error(t, err)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/code-payments/ocp-server/ocp/common"
 	"github.com/code-payments/ocp-server/ocp/data/account"
 	"github.com/code-payments/ocp-server/ocp/data/timelock"
+	"github.com/code-payments/ocp-server/ocp/worker"
 	timelock_token "github.com/code-payments/ocp-server/solana/timelock/v1"
 )
 
@@ -21,7 +22,7 @@ import (
 // the case? Real time updates. Backup workers likely won't be able to guarantee
 // real time (or near real time) updates at scale.
 
-func (p *runtime) backupTimelockStateWorker(runtimeCtx context.Context, state timelock_token.TimelockState, interval time.Duration) error {
+func (p *runtime) backupTimelockStateWorker(runtimeCtx context.Context, interval time.Duration, states ...timelock_token.TimelockState) error {
 	log := p.log.With(zap.String("method", "backupTimelockStateWorker"))
 	log.Debug("worker started")
 
@@ -36,69 +37,43 @@ func (p *runtime) backupTimelockStateWorker(runtimeCtx context.Context, state ti
 		log.Debug("worker stopped")
 	}()
 
-	start := time.Now()
-	cursor := query.EmptyCursor
-	delay := 0 * time.Second // Initially no delay, so we can run right after a deploy
-	for {
-		select {
-		case <-time.After(delay):
-			batchStart := time.Now()
-
-			func() {
-				provider := runtimeCtx.Value(metrics.ProviderContextKey).(metrics.Provider)
-				trace := provider.StartTrace("geyser_consumer_runtime__backup_timelock_state_worker")
-				defer trace.End()
-				tracedCtx := metrics.NewContext(runtimeCtx, trace)
-
-				timelockRecords, err := p.data.GetAllTimelocksByState(
-					tracedCtx,
-					state,
-					query.WithDirection(query.Ascending),
-					query.WithCursor(cursor),
-					query.WithLimit(256),
-				)
-				if err == timelock.ErrTimelockNotFound {
-					p.metricStatusLock.Lock()
-					duration := time.Since(start)
-					if p.backupTimelockStateWorkerDuration == nil || *p.backupTimelockStateWorkerDuration < duration {
-						p.backupTimelockStateWorkerDuration = &duration
-					}
-					p.metricStatusLock.Unlock()
-
-					start = time.Now()
-					cursor = query.EmptyCursor
-					return
-				} else if err != nil {
-					log.With(zap.Error(err)).Warn("failed to get timelock records")
-					return
-				}
-
-				var wg sync.WaitGroup
-				for _, timelockRecord := range timelockRecords {
-					wg.Add(1)
-
-					go func(timelockRecord *timelock.Record) {
-						defer wg.Done()
-
-						log := log.With(zap.String("timelock", timelockRecord.Address))
-
-						err := updateTimelockAccountRecord(tracedCtx, p.data, timelockRecord)
-						if err != nil {
-							log.With(zap.Error(err)).Warn("failed to update timelock account")
-						}
-					}(timelockRecord)
-				}
-
-				wg.Wait()
-
-				cursor = query.ToCursor(timelockRecords[len(timelockRecords)-1].Id)
-			}()
-
-			delay = interval - time.Since(batchStart)
-		case <-runtimeCtx.Done():
-			return runtimeCtx.Err()
+	handlers := make(map[timelock_token.TimelockState]worker.RecordHandler[*timelock.Record])
+	for _, state := range states {
+		handlers[state] = func(ctx context.Context, timelockRecord *timelock.Record) error {
+			err := updateTimelockAccountRecord(ctx, p.data, timelockRecord)
+			if err != nil {
+				log.With(zap.Error(err), zap.String("timelock", timelockRecord.Address)).Warn("failed to update timelock account")
+			}
+			return err
 		}
 	}
+
+	stateMachine := worker.NewStateMachine(log, worker.StateMachineConfig[timelock_token.TimelockState, *timelock.Record]{
+		Name:   "geyser_consumer_runtime__backup_timelock_state_worker",
+		Source: p.data.GetAllTimelocksByState,
+		RecordId: func(record *timelock.Record) uint64 {
+			return record.Id
+		},
+		Handlers: handlers,
+		BatchSize: func(_ context.Context) uint64 {
+			return 256
+		},
+		Concurrency:   p.conf.backupTimelockWorkerConcurrency.Get,
+		Direction:     query.Ascending,
+		NotFoundError: timelock.ErrTimelockNotFound,
+
+		// Initially no delay, so we can run right after a deploy
+		PollOnStart: true,
+
+		OnSweepComplete: func(_ context.Context, _ timelock_token.TimelockState, elapsed time.Duration) {
+			p.metricStatusLock.Lock()
+			if p.backupTimelockStateWorkerDuration == nil || *p.backupTimelockStateWorkerDuration < elapsed {
+				p.backupTimelockStateWorkerDuration = &elapsed
+			}
+			p.metricStatusLock.Unlock()
+		},
+	})
+	return stateMachine.Start(runtimeCtx, interval)
 }
 
 func (p *runtime) backupExternalDepositWorker(runtimeCtx context.Context, interval time.Duration) error {
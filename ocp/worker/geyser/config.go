@@ -25,6 +25,9 @@ const (
 	BackupTimelockWorkerIntervalConfigEnvName = envConfigPrefix + "BACKUP_TIMELOCK_WORKER_INTERVAL"
 	defaultBackupTimelockWorkerInterval       = time.Second
 
+	BackupTimelockWorkerConcurrencyConfigEnvName = envConfigPrefix + "BACKUP_TIMELOCK_WORKER_CONCURRENCY"
+	defaultBackupTimelockWorkerConcurrency       = 64
+
 	BackupExternalDepositWorkerIntervalConfigEnvName = envConfigPrefix + "BACKUP_EXTERNAL_DEPOSIT_WORKER_INTERVAL"
 	defaultBackupExternalDepositWorkerInterval       = time.Second
 )
@@ -38,7 +41,8 @@ type conf struct {
 
 	backupExternalDepositWorkerInterval config.Duration
 
-	backupTimelockWorkerInterval config.Duration
+	backupTimelockWorkerInterval    config.Duration
+	backupTimelockWorkerConcurrency config.Uint64
 }
 
 // ConfigProvider defines how config values are pulled
@@ -56,7 +60,8 @@ func WithEnvConfigs() ConfigProvider {
 
 			backupExternalDepositWorkerInterval: env.NewDurationConfig(BackupExternalDepositWorkerIntervalConfigEnvName, defaultBackupExternalDepositWorkerInterval),
 
-			backupTimelockWorkerInterval: env.NewDurationConfig(BackupTimelockWorkerIntervalConfigEnvName, defaultBackupTimelockWorkerInterval),
+			backupTimelockWorkerInterval:    env.NewDurationConfig(BackupTimelockWorkerIntervalConfigEnvName, defaultBackupTimelockWorkerInterval),
+			backupTimelockWorkerConcurrency: env.NewUint64Config(BackupTimelockWorkerConcurrencyConfigEnvName, defaultBackupTimelockWorkerConcurrency),
 		}
 	}
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/code-payments/ocp-server/ocp/data/transaction"
 	transaction_util "github.com/code-payments/ocp-server/ocp/transaction"
 	vm_util "github.com/code-payments/ocp-server/ocp/vm"
+	"github.com/code-payments/ocp-server/ocp/webhook"
 	"github.com/code-payments/ocp-server/retry"
 	"github.com/code-payments/ocp-server/solana"
 	compute_budget "github.com/code-payments/ocp-server/solana/computebudget"
@@ -105,7 +106,7 @@ func initiateExternalDepositIntoVm(ctx context.Context, data ocp_data.Provider,
 		return errors.Wrap(err, "error ensuring vta is initialized")
 	}
 
-	memoryAccount, memoryIndex, err := vm_util.GetVirtualTimelockAccountLocationInMemory(ctx, vmIndexerClient, vmConfig.Vm, userAuthority)
+	memoryAccount, memoryIndex, err := vm_util.GetVirtualTimelockAccountLocationInMemoryOrRequestDecompression(ctx, data, vmIndexerClient, vmConfig, userAuthority)
 	if err != nil {
 		return errors.Wrap(err, "error getting vta location in memory")
 	}
@@ -360,6 +361,18 @@ func processPotentialExternalDepositIntoVm(ctx context.Context, data ocp_data.Pr
 				return errors.Wrap(err, "error saving external deposit record")
 			}
 
+			err = webhook.Enqueue(ctx, data, webhook.NewDepositReceivedEvent(&webhook.DepositEventData{
+				Signature:      signature,
+				Owner:          ownerAccount.PublicKey().ToBase58(),
+				Mint:           mint.PublicKey().ToBase58(),
+				Destination:    userVirtualTimelockVaultAccount.PublicKey().ToBase58(),
+				Quantity:       uint64(deltaQuarksIntoOmnibus),
+				UsdMarketValue: usdMarketValue,
+			}))
+			if err != nil {
+				return errors.Wrap(err, "error enqueueing deposit received webhook event")
+			}
+
 			return nil
 		})
 		if err != nil {
@@ -19,6 +19,14 @@ const (
 	slotUpdateWorkerName      = "SlotUpdate"
 	externalDepositWorkerName = "ExternalDeposit"
 	timelockStateWorkerName   = "TimelockState"
+
+	subscriptionActiveGaugeName   = "GeyserSubscriptionActive"
+	slotsBehindGaugeName          = "GeyserSlotsBehind"
+	eventWorkerActiveGaugeName    = "GeyserWorkerActiveCount"
+	eventQueueSizeGaugeName       = "GeyserQueueSize"
+	backupWorkerActiveGaugeName   = "GeyserBackupWorkerActive"
+	backupWorkerDurationGaugeName = "GeyserBackupWorkerSweepSeconds"
+	backupQueueSizeGaugeName      = "GeyserBackupQueueSize"
 )
 
 func (p *runtime) metricsGaugeWorker(ctx context.Context) error {
@@ -52,6 +60,12 @@ func (p *runtime) recordSubscriptionStatusPollingEvent(ctx context.Context) {
 		"event_type": programUpdateWorkerName,
 		"is_active":  p.programUpdateSubscriptionStatus,
 	})
+	metrics.RecordGauge(ctx, subscriptionActiveGaugeName, boolToGaugeValue(p.programUpdateSubscriptionStatus), map[string]string{
+		"event_type": programUpdateWorkerName,
+	})
+	metrics.RecordGauge(ctx, subscriptionActiveGaugeName, boolToGaugeValue(p.slotUpdateSubscriptionStatus), map[string]string{
+		"event_type": slotUpdateWorkerName,
+	})
 
 	var slotsBehind uint64
 	if currentFinalizedSlot > p.highestObservedFinalizedSlot {
@@ -66,6 +80,7 @@ func (p *runtime) recordSubscriptionStatusPollingEvent(ctx context.Context) {
 		kvPairs["highest_observed_slot"] = p.highestObservedFinalizedSlot
 		if currentFinalizedSlot > 0 {
 			kvPairs["slots_behind"] = slotsBehind
+			metrics.RecordGauge(ctx, slotsBehindGaugeName, float64(slotsBehind), nil)
 		}
 	}
 	metrics.RecordEvent(ctx, subscriptionStatusEventName, kvPairs)
@@ -91,6 +106,9 @@ func (p *runtime) recordEventWorkerStatusPollingEvent(ctx context.Context) {
 		"total_count":      len(p.programUpdateWorkerMetrics),
 		"events_processed": eventsProcessed,
 	})
+	metrics.RecordGauge(ctx, eventWorkerActiveGaugeName, float64(numActive), map[string]string{
+		"event_type": programUpdateWorkerName,
+	})
 }
 
 func (p *runtime) recordEventQueueStatusPollingEvent(ctx context.Context) {
@@ -99,6 +117,9 @@ func (p *runtime) recordEventQueueStatusPollingEvent(ctx context.Context) {
 		"current_size": len(p.programUpdatesChan),
 		"max_size":     p.conf.programUpdateQueueSize.Get(ctx),
 	})
+	metrics.RecordGauge(ctx, eventQueueSizeGaugeName, float64(len(p.programUpdatesChan)), map[string]string{
+		"event_type": programUpdateWorkerName,
+	})
 }
 
 func (p *runtime) recordBackupWorkerStatusPollingEvent(ctx context.Context) {
@@ -112,14 +133,23 @@ func (p *runtime) recordBackupWorkerStatusPollingEvent(ctx context.Context) {
 	if p.backupTimelockStateWorkerDuration != nil {
 		inSeconds := *p.backupTimelockStateWorkerDuration / time.Second
 		timelockMetrics["duration_s"] = int(inSeconds)
+		metrics.RecordGauge(ctx, backupWorkerDurationGaugeName, p.backupTimelockStateWorkerDuration.Seconds(), map[string]string{
+			"worker_type": timelockStateWorkerName,
+		})
 		p.backupTimelockStateWorkerDuration = nil
 	}
 	metrics.RecordEvent(ctx, backupWorkerStatusEventName, timelockMetrics)
+	metrics.RecordGauge(ctx, backupWorkerActiveGaugeName, boolToGaugeValue(p.backupTimelockStateWorkerStatus), map[string]string{
+		"worker_type": timelockStateWorkerName,
+	})
 
 	metrics.RecordEvent(ctx, backupWorkerStatusEventName, map[string]interface{}{
 		"worker_type": externalDepositWorkerName,
 		"is_active":   p.backupExternalDepositWorkerStatus,
 	})
+	metrics.RecordGauge(ctx, backupWorkerActiveGaugeName, boolToGaugeValue(p.backupExternalDepositWorkerStatus), map[string]string{
+		"worker_type": externalDepositWorkerName,
+	})
 }
 
 func (p *runtime) recordBackupQueueStatusPollingEvent(ctx context.Context) {
@@ -132,4 +162,14 @@ func (p *runtime) recordBackupQueueStatusPollingEvent(ctx context.Context) {
 		"worker_type":  externalDepositWorkerName,
 		"current_size": count,
 	})
+	metrics.RecordGauge(ctx, backupQueueSizeGaugeName, float64(count), map[string]string{
+		"worker_type": externalDepositWorkerName,
+	})
+}
+
+func boolToGaugeValue(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
 }
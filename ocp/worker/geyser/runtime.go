@@ -63,13 +63,7 @@ func New(log *zap.Logger, data ocp_data.Provider, vmIndexerClient indexerpb.Inde
 func (p *runtime) Start(ctx context.Context, _ time.Duration) error {
 	// Start backup workers to catch missed events
 	go func() {
-		err := p.backupTimelockStateWorker(ctx, timelock_token.StateLocked, p.conf.backupTimelockWorkerInterval.Get(ctx))
-		if err != nil && err != context.Canceled {
-			p.log.With(zap.Error(err)).Warn("timelock backup worker terminated unexpectedly")
-		}
-	}()
-	go func() {
-		err := p.backupTimelockStateWorker(ctx, timelock_token.StateUnknown, p.conf.backupTimelockWorkerInterval.Get(ctx))
+		err := p.backupTimelockStateWorker(ctx, p.conf.backupTimelockWorkerInterval.Get(ctx), timelock_token.StateLocked, timelock_token.StateUnknown)
 		if err != nil && err != context.Canceled {
 			p.log.With(zap.Error(err)).Warn("timelock backup worker terminated unexpectedly")
 		}
@@ -51,7 +51,7 @@ func getTimelockUnlockState(ctx context.Context, data ocp_data.Provider, timeloc
 		return nil, 0, err
 	}
 
-	vmConfig, err := common.GetVmConfigForMint(ctx, data, mintAccount)
+	vmConfig, err := common.GetPublicVmConfigForMint(ctx, data, mintAccount)
 	if err != nil {
 		return nil, 0, err
 	}
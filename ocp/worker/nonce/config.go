@@ -16,12 +16,16 @@ const (
 
 	clientSwapNoncePoolSizeConfigEnvName = envConfigPrefix + "CLIENT_SWAP_NONCE_POOL_SIZE"
 	defaultClientSwapNoncePoolSize       = 1000
+
+	workerConcurrencyConfigEnvName = envConfigPrefix + "WORKER_CONCURRENCY"
+	defaultWorkerConcurrency       = 32
 )
 
 type conf struct {
 	solanaMainnetNoncePubkeyPrefix   config.String
 	onDemandTransactionNoncePoolSize config.Uint64
 	clientSwapNoncePoolSize          config.Uint64
+	workerConcurrency                config.Uint64
 }
 
 // ConfigProvider defines how config values are pulled
@@ -34,6 +38,7 @@ func WithEnvConfigs() ConfigProvider {
 			solanaMainnetNoncePubkeyPrefix:   env.NewStringConfig(solanaMainnetNoncePubkeyPrefixConfigEnvName, defaultSolanaMainnetNoncePubkeyPrefix),
 			onDemandTransactionNoncePoolSize: env.NewUint64Config(onDemandTransactiontNoncePoolSizeConfigEnvName, defaultOnDemandTransactionNoncePoolSize),
 			clientSwapNoncePoolSize:          env.NewUint64Config(clientSwapNoncePoolSizeConfigEnvName, defaultClientSwapNoncePoolSize),
+			workerConcurrency:                env.NewUint64Config(workerConcurrencyConfigEnvName, defaultWorkerConcurrency),
 		}
 	}
 }
@@ -12,6 +12,8 @@ import (
 
 const (
 	nonceCountCheckEventName = "NonceCountPollingCheck"
+
+	nonceCountGaugeName = "NonceCount"
 )
 
 func (p *runtime) metricsGaugeWorker(ctx context.Context) error {
@@ -64,10 +66,17 @@ func (p *runtime) metricsGaugeWorker(ctx context.Context) error {
 }
 
 func recordNonceCountEvent(ctx context.Context, env nonce.Environment, instance string, state nonce.State, useCase nonce.Purpose, count uint64) {
+	pool := fmt.Sprintf("%s:%s", env.String(), instance)
+
 	metrics.RecordEvent(ctx, nonceCountCheckEventName, map[string]interface{}{
-		"pool":     fmt.Sprintf("%s:%s", env.String(), instance),
+		"pool":     pool,
 		"use_case": useCase.String(),
 		"state":    state.String(),
 		"count":    count,
 	})
+	metrics.RecordGauge(ctx, nonceCountGaugeName, float64(count), map[string]string{
+		"pool":     pool,
+		"use_case": useCase.String(),
+		"state":    state.String(),
+	})
 }
@@ -2,17 +2,15 @@ package nonce
 
 import (
 	"context"
-	"sync"
-	"time"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
 	"github.com/code-payments/ocp-server/database/query"
-	"github.com/code-payments/ocp-server/metrics"
 	"github.com/code-payments/ocp-server/ocp/data/nonce"
 	"github.com/code-payments/ocp-server/ocp/data/transaction"
-	"github.com/code-payments/ocp-server/retry"
+	"github.com/code-payments/ocp-server/ocp/subsidizer"
+	"github.com/code-payments/ocp-server/ocp/worker"
 	"github.com/code-payments/ocp-server/solana"
 )
 
@@ -22,62 +20,27 @@ const (
 	nonceBatchSize = 100
 )
 
-func (p *runtime) worker(runtimeCtx context.Context, env nonce.Environment, instance string, state nonce.State, interval time.Duration) error {
-	var cursor query.Cursor
-	delay := interval
-
-	err := retry.Loop(
-		func() (err error) {
-			time.Sleep(delay)
-
-			provider := runtimeCtx.Value(metrics.ProviderContextKey).(metrics.Provider)
-			trace := provider.StartTrace("nonce_runtime__handle_" + state.String())
-			defer trace.End()
-			tracedCtx := metrics.NewContext(runtimeCtx, trace)
-
-			// Get a batch of nonce records in similar state (e.g. newly created, released, reserved, etc...)
-			items, err := p.data.GetAllNonceByState(
-				tracedCtx,
-				env,
-				instance,
-				state,
-				query.WithLimit(nonceBatchSize),
-				query.WithCursor(cursor),
-			)
-			if err != nil {
-				cursor = query.EmptyCursor
-				return err
-			}
-
-			// Process the batch of nonce accounts in parallel
-			var wg sync.WaitGroup
-			for _, item := range items {
-				wg.Add(1)
-
-				go func(record *nonce.Record) {
-					defer wg.Done()
-
-					err := p.handle(tracedCtx, record)
-					if err != nil {
-						trace.OnError(err)
-					}
-				}(item)
-			}
-			wg.Wait()
-
-			// Update cursor to point to the next set of nonce accounts
-			if len(items) > 0 {
-				cursor = query.ToCursor(items[len(items)-1].Id)
-			} else {
-				cursor = query.EmptyCursor
-			}
+func (p *runtime) newStateMachine(env nonce.Environment, instance string, states ...nonce.State) *worker.StateMachine[nonce.State, *nonce.Record] {
+	handlers := make(map[nonce.State]worker.RecordHandler[*nonce.Record])
+	for _, state := range states {
+		handlers[state] = p.handle
+	}
 
-			return nil
+	return worker.NewStateMachine(p.log.With(zap.String("env", env.String()), zap.String("instance", instance)), worker.StateMachineConfig[nonce.State, *nonce.Record]{
+		Name: "nonce_runtime",
+		Source: func(ctx context.Context, state nonce.State, opts ...query.Option) ([]*nonce.Record, error) {
+			return p.data.GetAllNonceByState(ctx, env, instance, state, opts...)
 		},
-		retry.NonRetriableErrors(context.Canceled),
-	)
-
-	return err
+		RecordId: func(record *nonce.Record) uint64 {
+			return record.Id
+		},
+		Handlers: handlers,
+		BatchSize: func(_ context.Context) uint64 {
+			return nonceBatchSize
+		},
+		Concurrency:   p.conf.workerConcurrency.Get,
+		NotFoundError: nonce.ErrNonceNotFound,
+	})
 }
 
 func (p *runtime) handle(ctx context.Context, record *nonce.Record) error {
@@ -196,6 +159,10 @@ func (p *runtime) handleUnknown(ctx context.Context, record *nonce.Record) error
 	}
 
 	if tx.ConfirmationState == transaction.ConfirmationFinalized {
+		if tx.Fee != nil {
+			p.budget.ObserveFee(ctx, subsidizer.ExpenseCreateNonceAccount, *tx.Fee)
+		}
+
 		return p.markReleased(ctx, record)
 	}
 
@@ -227,7 +194,18 @@ func (p *runtime) handleReleased(ctx context.Context, record *nonce.Record) erro
 			return err
 		}
 
-		txn, err = p.getTransaction(ctx, *fulfillmentRecord.Signature)
+		// Batched fulfillments don't own a transaction, and instead share the
+		// one submitted for their batch
+		signature := fulfillmentRecord.Signature
+		if signature == nil {
+			batchRecord, err := p.data.GetFulfillmentBatchByFulfillment(ctx, fulfillmentRecord.Id)
+			if err != nil {
+				return err
+			}
+			signature = &batchRecord.Signature
+		}
+
+		txn, err = p.getTransaction(ctx, *signature)
 		if err != nil {
 			return err
 		}
@@ -2,7 +2,7 @@ package nonce
 
 import (
 	"context"
-	"fmt"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -13,6 +13,7 @@ import (
 	"github.com/code-payments/ocp-server/ocp/common"
 	ocp_data "github.com/code-payments/ocp-server/ocp/data"
 	"github.com/code-payments/ocp-server/ocp/data/nonce"
+	"github.com/code-payments/ocp-server/ocp/subsidizer"
 	"github.com/code-payments/ocp-server/ocp/worker"
 )
 
@@ -27,16 +28,18 @@ type runtime struct {
 	conf            *conf
 	data            ocp_data.Provider
 	vmIndexerClient indexerpb.IndexerClient
+	budget          *subsidizer.Budget
 
 	rent uint64
 }
 
-func New(log *zap.Logger, data ocp_data.Provider, vmIndexerClient indexerpb.IndexerClient, configProvider ConfigProvider) worker.Runtime {
+func New(log *zap.Logger, data ocp_data.Provider, vmIndexerClient indexerpb.IndexerClient, budget *subsidizer.Budget, configProvider ConfigProvider) worker.Runtime {
 	return &runtime{
 		log:             log,
 		conf:            configProvider(),
 		data:            data,
 		vmIndexerClient: vmIndexerClient,
+		budget:          budget,
 	}
 }
 
@@ -49,19 +52,16 @@ func (p *runtime) Start(ctx context.Context, interval time.Duration) error {
 	go p.generateNonceAccountsOnSolanaMainnet(ctx, nonce.PurposeOnDemandTransaction, p.conf.onDemandTransactionNoncePoolSize.Get(ctx))
 	go p.generateNonceAccountsOnSolanaMainnet(ctx, nonce.PurposeClientSwap, p.conf.clientSwapNoncePoolSize.Get(ctx))
 
-	// Setup workers to watch for nonce state changes on the Solana side
-	for _, state := range []nonce.State{
-		nonce.StateUnknown,
-		nonce.StateReleased,
-	} {
-		go func(state nonce.State) {
-
-			err := p.worker(ctx, nonce.EnvironmentSolana, nonce.EnvironmentInstanceSolanaMainnet, state, interval)
-			if err != nil && err != context.Canceled {
-				p.log.With(zap.Error(err)).Warn(fmt.Sprintf("nonce processing loop terminated unexpectedly for env %s, instance %s, state %d", nonce.EnvironmentSolana, nonce.EnvironmentInstanceSolanaMainnet, state))
-			}
+	go func() {
+		err := p.metricsGaugeWorker(ctx)
+		if err != nil && err != context.Canceled {
+			p.log.With(zap.Error(err)).Warn("nonce metrics gauge loop terminated unexpectedly")
+		}
+	}()
 
-		}(state)
+	// Setup workers to watch for nonce state changes on the Solana side
+	stateMachines := []*worker.StateMachine[nonce.State, *nonce.Record]{
+		p.newStateMachine(nonce.EnvironmentSolana, nonce.EnvironmentInstanceSolanaMainnet, nonce.StateUnknown, nonce.StateReleased),
 	}
 
 	// Setup workers to watch for nonce state changes on the VM side
@@ -70,29 +70,20 @@ func (p *runtime) Start(ctx context.Context, interval time.Duration) error {
 	for _, vm := range []string{
 		common.CoreMintVmAccount.PublicKey().ToBase58(),
 	} {
-		for _, state := range []nonce.State{
-			nonce.StateReleased,
-		} {
-			go func(vm string, state nonce.State) {
-
-				err := p.worker(ctx, nonce.EnvironmentVm, vm, state, interval)
-				if err != nil && err != context.Canceled {
-					p.log.With(zap.Error(err)).Warn(fmt.Sprintf("nonce processing loop terminated unexpectedly for env %s, instance %s, state %d", nonce.EnvironmentVm, vm, state))
-				}
-
-			}(vm, state)
-		}
+		stateMachines = append(stateMachines, p.newStateMachine(nonce.EnvironmentVm, vm, nonce.StateReleased))
 	}
 
-	go func() {
-		err := p.metricsGaugeWorker(ctx)
-		if err != nil && err != context.Canceled {
-			p.log.With(zap.Error(err)).Warn("nonce metrics gauge loop terminated unexpectedly")
-		}
-	}()
+	var wg sync.WaitGroup
+	for _, stateMachine := range stateMachines {
+		wg.Add(1)
 
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
+		go func(stateMachine *worker.StateMachine[nonce.State, *nonce.Record]) {
+			defer wg.Done()
+
+			stateMachine.Start(ctx, interval)
+		}(stateMachine)
 	}
+	wg.Wait()
+
+	return ctx.Err()
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/code-payments/ocp-server/ocp/data/nonce"
 	"github.com/code-payments/ocp-server/ocp/data/transaction"
 	"github.com/code-payments/ocp-server/ocp/data/vault"
+	"github.com/code-payments/ocp-server/ocp/subsidizer"
 	"github.com/code-payments/ocp-server/solana"
 	compute_budget "github.com/code-payments/ocp-server/solana/computebudget"
 	"github.com/code-payments/ocp-server/solana/system"
@@ -96,11 +97,20 @@ func (p *runtime) getRentAmount(ctx context.Context) (uint64, error) {
 	return p.rent, nil
 }
 
-func (p *runtime) createSolanaMainnetNonce(ctx context.Context, purpose nonce.Purpose) (*nonce.Record, error) {
-	err := common.EnforceMinimumSubsidizerBalance(ctx, p.data)
+func (p *runtime) createSolanaMainnetNonce(ctx context.Context, purpose nonce.Purpose) (_ *nonce.Record, err error) {
+	// Nonces are created one at a time per purpose, and the previous nonce is
+	// counted as committed by the budget once it's saved, so a single
+	// reservation per purpose suffices.
+	reservationId := "create_nonce_account:" + purpose.String()
+	err = p.budget.Reserve(ctx, reservationId, subsidizer.ExpenseCreateNonceAccount)
 	if err != nil {
 		return nil, err
 	}
+	defer func() {
+		if err != nil {
+			p.budget.Release(reservationId)
+		}
+	}()
 
 	key, err := p.getVaultKey(ctx)
 	if err != nil {
@@ -0,0 +1,66 @@
+package paymentschedule
+
+import (
+	"time"
+
+	"github.com/code-payments/ocp-server/config"
+	"github.com/code-payments/ocp-server/config/env"
+	"github.com/code-payments/ocp-server/config/memory"
+	"github.com/code-payments/ocp-server/config/wrapper"
+)
+
+const (
+	envConfigPrefix = "PAYMENT_SCHEDULE_RUNTIME_"
+
+	BatchSizeConfigEnvName = envConfigPrefix + "BATCH_SIZE"
+	defaultBatchSize       = 32
+
+	ConcurrencyConfigEnvName = envConfigPrefix + "CONCURRENCY"
+	defaultConcurrency       = 32
+
+	MaxMissedAttemptsConfigEnvName = envConfigPrefix + "MAX_MISSED_ATTEMPTS"
+	defaultMaxMissedAttempts       = 5
+
+	MissedAttemptRetryDelayConfigEnvName = envConfigPrefix + "MISSED_ATTEMPT_RETRY_DELAY"
+	defaultMissedAttemptRetryDelay       = time.Hour
+)
+
+type conf struct {
+	batchSize               config.Uint64
+	concurrency             config.Uint64
+	maxMissedAttempts       config.Uint64
+	missedAttemptRetryDelay config.Duration
+}
+
+// ConfigProvider defines how config values are pulled
+type ConfigProvider func() *conf
+
+// WithEnvConfigs returns configuration pulled from environment variables
+func WithEnvConfigs() ConfigProvider {
+	return func() *conf {
+		return &conf{
+			batchSize:               env.NewUint64Config(BatchSizeConfigEnvName, defaultBatchSize),
+			concurrency:             env.NewUint64Config(ConcurrencyConfigEnvName, defaultConcurrency),
+			maxMissedAttempts:       env.NewUint64Config(MaxMissedAttemptsConfigEnvName, defaultMaxMissedAttempts),
+			missedAttemptRetryDelay: env.NewDurationConfig(MissedAttemptRetryDelayConfigEnvName, defaultMissedAttemptRetryDelay),
+		}
+	}
+}
+
+type testOverrides struct {
+	batchSize               uint64
+	concurrency             uint64
+	maxMissedAttempts       uint64
+	missedAttemptRetryDelay time.Duration
+}
+
+func withManualTestOverrides(overrides *testOverrides) ConfigProvider {
+	return func() *conf {
+		return &conf{
+			batchSize:               wrapper.NewUint64Config(memory.NewConfig(overrides.batchSize), defaultBatchSize),
+			concurrency:             wrapper.NewUint64Config(memory.NewConfig(overrides.concurrency), defaultConcurrency),
+			maxMissedAttempts:       wrapper.NewUint64Config(memory.NewConfig(overrides.maxMissedAttempts), defaultMaxMissedAttempts),
+			missedAttemptRetryDelay: wrapper.NewDurationConfig(memory.NewConfig(overrides.missedAttemptRetryDelay), defaultMissedAttemptRetryDelay),
+		}
+	}
+}
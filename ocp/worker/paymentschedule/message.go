@@ -0,0 +1,74 @@
+package paymentschedule
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mr-tron/base58"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	commonpb "github.com/code-payments/ocp-protobuf-api/generated/go/common/v1"
+	messagingpb "github.com/code-payments/ocp-protobuf-api/generated/go/messaging/v1"
+
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/messaging"
+	"github.com/code-payments/ocp-server/ocp/data/paymentschedule"
+	paymentschedulepb "github.com/code-payments/ocp-server/ocp/rpc/paymentschedule/api/gen"
+)
+
+// saveScheduledPaymentMessage saves a message for an executed or failed payment
+// to the owner's bin in the messaging store, where it's held until the owner
+// polls for and acknowledges it
+func saveScheduledPaymentMessage(
+	ctx context.Context,
+	data ocp_data.Provider,
+	kind paymentschedulepb.ScheduledPaymentMessage_Kind,
+	record *paymentschedule.Record,
+	paymentIndex uint64,
+	failureReason string,
+) error {
+	scheduleIdBytes, err := base58.Decode(record.ScheduleId)
+	if err != nil {
+		return err
+	}
+
+	id := uuid.New()
+	idBytes, _ := id.MarshalBinary()
+
+	message := &paymentschedulepb.ScheduledPaymentMessage{
+		Id: &messagingpb.MessageId{
+			Value: idBytes,
+		},
+		ScheduleId: &paymentschedulepb.ScheduleId{
+			Value: scheduleIdBytes,
+		},
+		PaymentIndex:  uint32(paymentIndex),
+		Kind:          kind,
+		FailureReason: failureReason,
+		CreatedAt:     timestamppb.New(time.Now()),
+	}
+
+	// Intents are only created for payments that were made
+	if kind == paymentschedulepb.ScheduledPaymentMessage_EXECUTED {
+		intentIdBytes, err := base58.Decode(GetScheduledPaymentIntentId(record.ScheduleId, paymentIndex))
+		if err != nil {
+			return err
+		}
+		message.IntentId = &commonpb.IntentId{
+			Value: intentIdBytes,
+		}
+	}
+
+	messageBytes, err := proto.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	return data.CreateMessage(ctx, &messaging.Record{
+		Account:   paymentschedule.GetMessageBin(record.Owner),
+		MessageID: id,
+		Message:   messageBytes,
+	})
+}
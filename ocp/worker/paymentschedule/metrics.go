@@ -0,0 +1,60 @@
+package paymentschedule
+
+import (
+	"context"
+	"time"
+
+	"github.com/code-payments/ocp-server/metrics"
+	"github.com/code-payments/ocp-server/ocp/data/paymentschedule"
+)
+
+const (
+	paymentScheduleCountEventName = "PaymentScheduleCountPollingCheck"
+	scheduledPaymentEventName     = "ScheduledPayment"
+)
+
+func (p *runtime) metricsGaugeWorker(ctx context.Context) error {
+	delay := time.Second
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+			start := time.Now()
+
+			for _, state := range []paymentschedule.State{
+				paymentschedule.StateActive,
+				paymentschedule.StateFailed,
+			} {
+				count, err := p.data.GetPaymentScheduleCountByState(ctx, state)
+				if err != nil {
+					continue
+				}
+				recordPaymentScheduleCountEvent(ctx, state, count)
+			}
+
+			delay = time.Second - time.Since(start)
+		}
+	}
+}
+
+func recordPaymentScheduleCountEvent(ctx context.Context, state paymentschedule.State, count uint64) {
+	metrics.RecordEvent(ctx, paymentScheduleCountEventName, map[string]interface{}{
+		"count": count,
+		"state": state.String(),
+	})
+}
+
+func recordScheduledPaymentEvent(ctx context.Context, record *paymentschedule.Record, err error) {
+	kvs := map[string]interface{}{
+		"currency":      string(record.ExchangeCurrency),
+		"native_amount": record.NativeAmount,
+		"is_recurring":  record.IsRecurring(),
+		"success":       err == nil,
+	}
+	if err != nil {
+		kvs["error"] = err.Error()
+	}
+	metrics.RecordEvent(ctx, scheduledPaymentEventName, kvs)
+}
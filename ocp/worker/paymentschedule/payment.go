@@ -0,0 +1,487 @@
+package paymentschedule
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mr-tron/base58"
+	"go.uber.org/zap"
+
+	currency_lib "github.com/code-payments/ocp-server/currency"
+	"github.com/code-payments/ocp-server/metrics"
+	"github.com/code-payments/ocp-server/ocp/balance"
+	"github.com/code-payments/ocp-server/ocp/common"
+	currency_util "github.com/code-payments/ocp-server/ocp/currency"
+	"github.com/code-payments/ocp-server/ocp/data/action"
+	"github.com/code-payments/ocp-server/ocp/data/fulfillment"
+	"github.com/code-payments/ocp-server/ocp/data/intent"
+	"github.com/code-payments/ocp-server/ocp/data/paymentschedule"
+	"github.com/code-payments/ocp-server/ocp/data/vault"
+	paymentschedulepb "github.com/code-payments/ocp-server/ocp/rpc/paymentschedule/api/gen"
+	"github.com/code-payments/ocp-server/ocp/webhook"
+	"github.com/code-payments/ocp-server/pointer"
+	"github.com/code-payments/ocp-server/retry"
+	"github.com/code-payments/ocp-server/retry/backoff"
+	"github.com/code-payments/ocp-server/solana/vm"
+)
+
+// Reasons a due payment can't be made. Payments missed due to an insufficient
+// balance or the antispam guard are retried with backoff, and the payment
+// schedule fails once too many attempts are missed. All other reasons cause the
+// payment schedule to fail immediately.
+var (
+	ErrPayerNotAuthorized  = errors.New("server cannot sign on behalf of the payer")
+	ErrPayerNotManaged     = errors.New("payer account isn't managed by the server")
+	ErrUnsupportedMint     = errors.New("payment schedule mint isn't supported")
+	ErrInsufficientBalance = errors.New("payer has insufficient balance")
+	ErrTooManyPayments     = errors.New("payment denied by antispam guard")
+	ErrTransactionLimit    = errors.New("payment exceeds transaction limits")
+)
+
+func (p *runtime) paymentWorker(runtimeCtx context.Context, interval time.Duration) error {
+	delay := interval
+
+	err := retry.Loop(
+		func() (err error) {
+			time.Sleep(delay)
+
+			provider := runtimeCtx.Value(metrics.ProviderContextKey).(metrics.Provider)
+			trace := provider.StartTrace("payment_schedule_runtime__handle_due_payments")
+			defer trace.End()
+			tracedCtx := metrics.NewContext(runtimeCtx, trace)
+
+			err = p.processDuePayments(tracedCtx)
+			if err != nil {
+				trace.OnError(err)
+			}
+			return err
+		},
+		retry.NonRetriableErrors(context.Canceled),
+	)
+
+	return err
+}
+
+// processDuePayments makes a single payment for each payment schedule that has
+// one due. Recurring schedules that have fallen behind catch up one payment at
+// a time across runs.
+func (p *runtime) processDuePayments(ctx context.Context) error {
+	records, err := p.data.GetDuePaymentSchedules(
+		ctx,
+		time.Now(),
+		p.conf.batchSize.Get(ctx),
+	)
+	if err == paymentschedule.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	concurrency := p.conf.concurrency.Get(ctx)
+	if concurrency == 0 || concurrency > uint64(len(records)) {
+		concurrency = uint64(len(records))
+	}
+	sem := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for _, record := range records {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(record *paymentschedule.Record) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			err := p.processDuePayment(ctx, record)
+			if err != nil {
+				p.log.With(
+					zap.Error(err),
+					zap.String("schedule", record.ScheduleId),
+				).Warn("failure processing due payment")
+			}
+		}(record)
+	}
+	wg.Wait()
+
+	return nil
+}
+
+func (p *runtime) processDuePayment(ctx context.Context, record *paymentschedule.Record) error {
+	log := p.log.With(
+		zap.String("method", "processDuePayment"),
+		zap.String("schedule", record.ScheduleId),
+		zap.String("owner", record.Owner),
+		zap.Uint64("payment_index", record.PaymentsMade),
+	)
+
+	if record.State != paymentschedule.StateActive {
+		return nil
+	}
+
+	if time.Now().Before(record.NextPaymentAt) {
+		log.Debug("skipping payment schedule without a payment due")
+		return nil
+	}
+
+	// The amount cap was reached before the maximum number of payments was made
+	if !record.HasRemainingPayments() {
+		record.State = paymentschedule.StateCompleted
+		return p.data.UpdatePaymentSchedule(ctx, record)
+	}
+
+	err := p.makeScheduledPayment(ctx, record)
+	recordScheduledPaymentEvent(ctx, record, err)
+	switch err {
+	case nil:
+		log.Debug("made scheduled payment")
+		return nil
+	case ErrInsufficientBalance, ErrTooManyPayments:
+		if record.MissedAttempts+1 < p.conf.maxMissedAttempts.Get(ctx) {
+			log.With(zap.Error(err)).Info("scheduled payment missed, retrying later")
+			return p.markPaymentMissed(ctx, record)
+		}
+		log.With(zap.Error(err)).Info("scheduled payment missed too many times, failing payment schedule")
+		return p.markPaymentScheduleFailed(ctx, record, err)
+	case ErrPayerNotAuthorized, ErrPayerNotManaged, ErrUnsupportedMint, ErrTransactionLimit:
+		log.With(zap.Error(err)).Info("scheduled payment cannot be made, failing payment schedule")
+		return p.markPaymentScheduleFailed(ctx, record, err)
+	default:
+		log.With(zap.Error(err)).Warn("failure making scheduled payment")
+		return err
+	}
+}
+
+// makeScheduledPayment creates a public payment intent on the owner's behalf
+// for the next payment in the schedule, and advances the schedule within the
+// same DB transaction. The intent ID is derived from the payment, so a payment
+// is never made twice.
+func (p *runtime) makeScheduledPayment(ctx context.Context, record *paymentschedule.Record) error {
+	paymentIndex := record.PaymentsMade
+	intentId := GetScheduledPaymentIntentId(record.ScheduleId, paymentIndex)
+
+	log := p.log.With(
+		zap.String("method", "makeScheduledPayment"),
+		zap.String("schedule", record.ScheduleId),
+		zap.String("intent", intentId),
+	)
+
+	if record.Mint != common.CoreMintAccount.PublicKey().ToBase58() {
+		return ErrUnsupportedMint
+	}
+
+	// The server can only make payments from accounts whose owner keys it holds
+	vaultRecord, err := p.data.GetKey(ctx, record.Owner)
+	if err == vault.ErrKeyNotFound {
+		return ErrPayerNotAuthorized
+	} else if err != nil {
+		log.With(zap.Error(err)).Warn("failure getting payer key")
+		return err
+	}
+
+	owner, err := common.NewAccountFromPrivateKeyString(vaultRecord.PrivateKey)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("invalid payer key")
+		return err
+	}
+
+	vmConfig, err := common.GetVmConfigForMint(ctx, p.data, common.CoreMintAccount)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure getting vm config")
+		return err
+	}
+
+	source, err := owner.GetTimelockAccounts(vmConfig)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure getting payer timelock accounts")
+		return err
+	}
+
+	destination, err := common.NewAccountFromPublicKeyString(record.DestinationTokenAccount)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("invalid destination account")
+		return err
+	}
+
+	var additionalQuarks uint64
+	switch record.ExchangeCurrency {
+	case currency_lib.USD:
+		if !common.IsCoreMintUsdStableCoin() {
+			additionalQuarks = 1
+		}
+	default:
+		additionalQuarks = 1
+	}
+
+	exchangeRateRecord, err := p.data.GetExchangeRate(ctx, record.ExchangeCurrency, currency_util.GetLatestExchangeRateTime())
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure getting exchange rate")
+		return err
+	}
+
+	coreMintAmount := record.NativeAmount / exchangeRateRecord.Rate
+	quarkAmount := uint64(coreMintAmount*float64(common.CoreMintQuarksPerUnit)) + additionalQuarks
+
+	usdMarketValue, _, err := currency_util.CalculateUsdMarketValue(ctx, p.data, common.CoreMintAccount, quarkAmount, currency_util.GetLatestExchangeRateTime())
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure calculating usd market value")
+		return err
+	}
+
+	intentRecord := &intent.Record{
+		IntentId:   intentId,
+		IntentType: intent.SendPublicPayment,
+
+		SendPublicPaymentMetadata: &intent.SendPublicPaymentMetadata{
+			DestinationOwnerAccount: record.DestinationOwner,
+			DestinationTokenAccount: destination.PublicKey().ToBase58(),
+			Quantity:                quarkAmount,
+
+			ExchangeCurrency: record.ExchangeCurrency,
+			ExchangeRate:     exchangeRateRecord.Rate,
+			NativeAmount:     record.NativeAmount,
+			UsdMarketValue:   usdMarketValue,
+
+			IsWithdrawal: false,
+		},
+
+		MintAccount: common.CoreMintAccount.PublicKey().ToBase58(),
+
+		InitiatorOwnerAccount: owner.PublicKey().ToBase58(),
+
+		State: intent.StatePending,
+
+		CreatedAt: time.Now(),
+	}
+
+	// Apply the same guards as an owner-submitted public payment
+	allow, err := p.antispamGuard.AllowSendPayment(ctx, owner, destination, true)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure performing antispam check")
+		return err
+	} else if !allow {
+		return ErrTooManyPayments
+	}
+
+	allow, err = p.amlGuard.AllowMoneyMovement(ctx, intentRecord)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure performing aml check")
+		return err
+	} else if !allow {
+		return ErrTransactionLimit
+	}
+
+	balanceLock, err := balance.GetOptimisticVersionLock(ctx, p.data, source.Vault)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure getting balance lock")
+		return err
+	}
+
+	sourceBalance, err := balance.CalculateFromCache(ctx, p.data, source.Vault)
+	if err == balance.ErrNotManagedByCode {
+		return ErrPayerNotManaged
+	} else if err != nil {
+		log.With(zap.Error(err)).Warn("failure getting payer balance")
+		return err
+	} else if sourceBalance < quarkAmount {
+		return ErrInsufficientBalance
+	}
+
+	selectedNonce, err := p.noncePool.GetNonce(ctx)
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure selecting available nonce")
+		return err
+	}
+	defer func() {
+		selectedNonce.ReleaseIfNotReserved(ctx)
+	}()
+
+	vixnHash := vm.GetCompactTransferMessage(&vm.GetCompactTransferMessageArgs{
+		Source:       source.Vault.PublicKey().ToBytes(),
+		Destination:  destination.PublicKey().ToBytes(),
+		Amount:       quarkAmount,
+		NonceAddress: selectedNonce.Account.PublicKey().ToBytes(),
+		NonceValue:   vm.Hash(selectedNonce.Blockhash),
+	})
+	virtualSig := ed25519.Sign(owner.PrivateKey().ToBytes(), vixnHash[:])
+
+	actionRecord := &action.Record{
+		Intent:     intentRecord.IntentId,
+		IntentType: intentRecord.IntentType,
+
+		ActionId:   0,
+		ActionType: action.NoPrivacyTransfer,
+
+		Source:      source.Vault.PublicKey().ToBase58(),
+		Destination: pointer.String(destination.PublicKey().ToBase58()),
+
+		Quantity: pointer.Uint64(quarkAmount),
+
+		State: action.StatePending,
+
+		CreatedAt: time.Now(),
+	}
+
+	fulfillmentRecord := &fulfillment.Record{
+		Intent:     intentRecord.IntentId,
+		IntentType: intentRecord.IntentType,
+
+		ActionId:   actionRecord.ActionId,
+		ActionType: actionRecord.ActionType,
+
+		FulfillmentType: fulfillment.NoPrivacyTransferWithAuthority,
+
+		VirtualNonce:     pointer.String(selectedNonce.Account.PublicKey().ToBase58()),
+		VirtualBlockhash: pointer.String(base58.Encode(selectedNonce.Blockhash[:])),
+		VirtualSignature: pointer.String(base58.Encode(virtualSig)),
+
+		Source:      actionRecord.Source,
+		Destination: pointer.StringCopy(actionRecord.Destination),
+
+		DisableActiveScheduling: false,
+
+		// IntentOrderingIndex unknown until intent record is saved
+		ActionOrderingIndex:      0,
+		FulfillmentOrderingIndex: 0,
+
+		State: fulfillment.StateUnknown,
+
+		CreatedAt: time.Now(),
+	}
+
+	updated := record.Clone()
+	advancePaymentSchedule(&updated)
+
+	err = p.data.ExecuteInTx(ctx, sql.LevelDefault, func(ctx context.Context) error {
+		err := p.data.SaveIntent(ctx, intentRecord)
+		if err != nil {
+			return err
+		}
+
+		err = p.data.PutAllActions(ctx, actionRecord)
+		if err != nil {
+			return err
+		}
+
+		fulfillmentRecord.IntentOrderingIndex = intentRecord.Id
+		err = p.data.PutAllFulfillments(ctx, fulfillmentRecord)
+		if err != nil {
+			return err
+		}
+
+		err = selectedNonce.MarkReservedWithSignature(ctx, *fulfillmentRecord.VirtualSignature)
+		if err != nil {
+			return err
+		}
+
+		err = balanceLock.OnNewBalanceVersion(ctx, p.data)
+		if err != nil {
+			return err
+		}
+
+		err = p.data.UpdatePaymentSchedule(ctx, &updated)
+		if err != nil {
+			return err
+		}
+
+		err = webhook.Enqueue(ctx, p.data, webhook.NewIntentEvent(webhook.EventTypeIntentCreated, intentRecord))
+		if err != nil {
+			return err
+		}
+
+		err = webhook.Enqueue(ctx, p.data, webhook.NewScheduledPaymentEvent(webhook.EventTypeScheduledPaymentExecuted, &updated, intentId, paymentIndex, ""))
+		if err != nil {
+			return err
+		}
+
+		return saveScheduledPaymentMessage(ctx, p.data, paymentschedulepb.ScheduledPaymentMessage_EXECUTED, &updated, paymentIndex, "")
+	})
+	if err != nil {
+		log.With(zap.Error(err)).Warn("failure creating scheduled payment intent")
+		return err
+	}
+
+	updated.CopyTo(record)
+	return nil
+}
+
+// markPaymentMissed records a missed attempt at the next payment, which is
+// retried after an exponentially increasing delay
+func (p *runtime) markPaymentMissed(ctx context.Context, record *paymentschedule.Record) error {
+	updated := record.Clone()
+	updated.MissedAttempts++
+	retryDelay := backoff.BinaryExponential(p.conf.missedAttemptRetryDelay.Get(ctx))(uint(updated.MissedAttempts))
+	updated.RetryAt = pointer.Time(time.Now().Add(retryDelay))
+
+	err := p.data.UpdatePaymentSchedule(ctx, &updated)
+	if err != nil {
+		return err
+	}
+
+	updated.CopyTo(record)
+	return nil
+}
+
+// markPaymentScheduleFailed stops all future payments in a schedule after a due
+// payment couldn't be made, and notifies the owner via webhook and message
+func (p *runtime) markPaymentScheduleFailed(ctx context.Context, record *paymentschedule.Record, reason error) error {
+	paymentIndex := record.PaymentsMade
+	intentId := GetScheduledPaymentIntentId(record.ScheduleId, paymentIndex)
+
+	updated := record.Clone()
+	updated.State = paymentschedule.StateFailed
+
+	err := p.data.ExecuteInTx(ctx, sql.LevelDefault, func(ctx context.Context) error {
+		err := p.data.UpdatePaymentSchedule(ctx, &updated)
+		if err != nil {
+			return err
+		}
+
+		err = webhook.Enqueue(ctx, p.data, webhook.NewScheduledPaymentEvent(webhook.EventTypeScheduledPaymentFailed, &updated, intentId, paymentIndex, reason.Error()))
+		if err != nil {
+			return err
+		}
+
+		return saveScheduledPaymentMessage(ctx, p.data, paymentschedulepb.ScheduledPaymentMessage_FAILED, &updated, paymentIndex, reason.Error())
+	})
+	if err != nil {
+		return err
+	}
+
+	updated.CopyTo(record)
+	return nil
+}
+
+// advancePaymentSchedule records a payment that was made, and completes the
+// schedule once no further payments can be made. Recurring payments stay
+// anchored to the original schedule, regardless of when they're actually made.
+func advancePaymentSchedule(record *paymentschedule.Record) {
+	record.PaymentsMade++
+	record.TotalNativeAmountPaid += record.NativeAmount
+
+	record.MissedAttempts = 0
+	record.RetryAt = nil
+
+	if record.IsRecurring() {
+		record.NextPaymentAt = record.NextPaymentAt.Add(record.Interval)
+	}
+
+	if !record.HasRemainingPayments() {
+		record.State = paymentschedule.StateCompleted
+	}
+}
+
+// GetScheduledPaymentIntentId returns the ID of the intent for a payment in a
+// payment schedule
+func GetScheduledPaymentIntentId(scheduleId string, paymentIndex uint64) string {
+	combined := fmt.Sprintf("scheduled-payment-%s-%d", scheduleId, paymentIndex)
+	hashed := sha256.Sum256([]byte(combined))
+	return base58.Encode(hashed[:])
+}
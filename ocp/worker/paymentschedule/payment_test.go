@@ -0,0 +1,249 @@
+package paymentschedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/code-payments/ocp-server/ocp/common"
+	"github.com/code-payments/ocp-server/ocp/data/paymentschedule"
+	paymentschedulepb "github.com/code-payments/ocp-server/ocp/rpc/paymentschedule/api/gen"
+	"github.com/code-payments/ocp-server/ocp/webhook"
+	timelock_token_v1 "github.com/code-payments/ocp-server/solana/timelock/v1"
+	"github.com/code-payments/ocp-server/testutil"
+)
+
+func TestScheduledPayment_OneTime(t *testing.T) {
+	env := setup(t, &testOverrides{batchSize: 10, concurrency: 2})
+
+	payer := env.setupPayer(t, common.ToCoreMintQuarks(100))
+
+	nextPaymentAt := time.Now().Add(-time.Minute)
+	due := env.createPaymentSchedule(t, payer, 12.5, 12.5, 0, 1, nextPaymentAt)
+	notDue := env.createPaymentSchedule(t, payer, 12.5, 12.5, 0, 1, time.Now().Add(time.Hour))
+
+	require.NoError(t, env.runtime.processDuePayments(env.ctx))
+
+	env.assertScheduledPaymentMade(t, due, 0, getExpectedQuarks(12.5))
+	env.assertPaymentSchedule(t, due.ScheduleId, paymentschedule.StateCompleted, 1, nextPaymentAt)
+
+	env.assertScheduledPaymentNotMade(t, notDue, 0)
+	env.assertPaymentSchedule(t, notDue.ScheduleId, paymentschedule.StateActive, 0, notDue.NextPaymentAt)
+
+	// Completed schedules don't make any more payments
+	require.NoError(t, env.runtime.processDuePayments(env.ctx))
+	env.assertScheduledPaymentNotMade(t, due, 1)
+}
+
+func TestScheduledPayment_Recurring(t *testing.T) {
+	env := setup(t, &testOverrides{batchSize: 10, concurrency: 2})
+
+	payer := env.setupPayer(t, common.ToCoreMintQuarks(100))
+
+	interval := 7 * 24 * time.Hour
+	nextPaymentAt := time.Now().Add(-15 * 24 * time.Hour)
+	record := env.createPaymentSchedule(t, payer, 10, 100, interval, 3, nextPaymentAt)
+
+	// Missed payments are caught up one at a time, and stay anchored to the
+	// original schedule
+	for i := uint64(0); i < 3; i++ {
+		require.NoError(t, env.runtime.processDuePayments(env.ctx))
+
+		nextPaymentAt = nextPaymentAt.Add(interval)
+		expectedState := paymentschedule.StateActive
+		if i == 2 {
+			expectedState = paymentschedule.StateCompleted
+		}
+
+		env.assertScheduledPaymentMade(t, record, i, getExpectedQuarks(10))
+		env.assertPaymentSchedule(t, record.ScheduleId, expectedState, i+1, nextPaymentAt)
+	}
+
+	require.NoError(t, env.runtime.processDuePayments(env.ctx))
+	env.assertScheduledPaymentNotMade(t, record, 3)
+}
+
+func TestScheduledPayment_RecurringNotDue(t *testing.T) {
+	env := setup(t, &testOverrides{batchSize: 10, concurrency: 2})
+
+	payer := env.setupPayer(t, common.ToCoreMintQuarks(100))
+
+	interval := 24 * time.Hour
+	nextPaymentAt := time.Now().Add(-time.Minute)
+	record := env.createPaymentSchedule(t, payer, 10, 100, interval, 10, nextPaymentAt)
+
+	require.NoError(t, env.runtime.processDuePayments(env.ctx))
+	env.assertScheduledPaymentMade(t, record, 0, getExpectedQuarks(10))
+	env.assertPaymentSchedule(t, record.ScheduleId, paymentschedule.StateActive, 1, nextPaymentAt.Add(interval))
+
+	require.NoError(t, env.runtime.processDuePayments(env.ctx))
+	env.assertScheduledPaymentNotMade(t, record, 1)
+	env.assertPaymentSchedule(t, record.ScheduleId, paymentschedule.StateActive, 1, nextPaymentAt.Add(interval))
+}
+
+func TestScheduledPayment_AmountCap(t *testing.T) {
+	env := setup(t, &testOverrides{batchSize: 10, concurrency: 2})
+
+	payer := env.setupPayer(t, common.ToCoreMintQuarks(100))
+
+	interval := time.Hour
+	nextPaymentAt := time.Now().Add(-24 * time.Hour)
+	record := env.createPaymentSchedule(t, payer, 10, 25, interval, 10, nextPaymentAt)
+
+	require.NoError(t, env.runtime.processDuePayments(env.ctx))
+	env.assertScheduledPaymentMade(t, record, 0, getExpectedQuarks(10))
+	env.assertPaymentSchedule(t, record.ScheduleId, paymentschedule.StateActive, 1, nextPaymentAt.Add(interval))
+
+	// Another payment would exceed the amount cap
+	require.NoError(t, env.runtime.processDuePayments(env.ctx))
+	env.assertScheduledPaymentMade(t, record, 1, getExpectedQuarks(10))
+	env.assertPaymentSchedule(t, record.ScheduleId, paymentschedule.StateCompleted, 2, nextPaymentAt.Add(2*interval))
+
+	require.NoError(t, env.runtime.processDuePayments(env.ctx))
+	env.assertScheduledPaymentNotMade(t, record, 2)
+}
+
+func TestScheduledPayment_InsufficientBalance(t *testing.T) {
+	env := setup(t, &testOverrides{batchSize: 10, concurrency: 2, maxMissedAttempts: 3, missedAttemptRetryDelay: time.Minute})
+
+	payer := env.setupPayer(t, common.ToCoreMintQuarks(15))
+
+	interval := time.Hour
+	nextPaymentAt := time.Now().Add(-24 * time.Hour)
+	record := env.createPaymentSchedule(t, payer, 10, 100, interval, 10, nextPaymentAt)
+
+	require.NoError(t, env.runtime.processDuePayments(env.ctx))
+	env.assertScheduledPaymentMade(t, record, 0, getExpectedQuarks(10))
+	env.assertPaymentSchedule(t, record.ScheduleId, paymentschedule.StateActive, 1, nextPaymentAt.Add(interval))
+
+	// Missed payments are retried with backoff until too many attempts are missed
+	for i := 1; i <= 2; i++ {
+		require.NoError(t, env.runtime.processDuePayments(env.ctx))
+		env.assertScheduledPaymentNotMade(t, record, 1)
+		actual := env.assertPaymentSchedule(t, record.ScheduleId, paymentschedule.StateActive, 1, nextPaymentAt.Add(interval))
+		assert.EqualValues(t, i, actual.MissedAttempts)
+		require.NotNil(t, actual.RetryAt)
+		assert.True(t, actual.RetryAt.After(time.Now().Add(time.Duration(i)*time.Minute-time.Second)))
+
+		// The payment isn't retried before the retry time
+		require.NoError(t, env.runtime.processDuePayments(env.ctx))
+		env.assertPaymentSchedule(t, record.ScheduleId, paymentschedule.StateActive, 1, nextPaymentAt.Add(interval))
+
+		env.expireRetry(t, record.ScheduleId)
+	}
+	env.assertNoScheduledPaymentEvent(t, webhook.EventTypeScheduledPaymentFailed, record, 1)
+
+	require.NoError(t, env.runtime.processDuePayments(env.ctx))
+	env.assertScheduledPaymentNotMade(t, record, 1)
+	env.assertPaymentSchedule(t, record.ScheduleId, paymentschedule.StateFailed, 1, nextPaymentAt.Add(interval))
+
+	event := env.assertScheduledPaymentEvent(t, webhook.EventTypeScheduledPaymentFailed, record, 1)
+	assert.Equal(t, ErrInsufficientBalance.Error(), event.FailureReason)
+	assert.Equal(t, paymentschedule.StateFailed.String(), event.State)
+
+	message := env.assertScheduledPaymentMessage(t, paymentschedulepb.ScheduledPaymentMessage_FAILED, record, 1)
+	assert.Equal(t, ErrInsufficientBalance.Error(), message.FailureReason)
+	assert.Nil(t, message.IntentId)
+}
+
+func TestScheduledPayment_RecoversFromMissedPayment(t *testing.T) {
+	env := setup(t, &testOverrides{batchSize: 10, concurrency: 2, maxMissedAttempts: 3, missedAttemptRetryDelay: time.Minute})
+
+	payer := env.setupPayer(t, common.ToCoreMintQuarks(5))
+
+	interval := time.Hour
+	nextPaymentAt := time.Now().Add(-time.Minute)
+	record := env.createPaymentSchedule(t, payer, 10, 100, interval, 10, nextPaymentAt)
+
+	require.NoError(t, env.runtime.processDuePayments(env.ctx))
+	env.assertScheduledPaymentNotMade(t, record, 0)
+	actual := env.assertPaymentSchedule(t, record.ScheduleId, paymentschedule.StateActive, 0, nextPaymentAt)
+	assert.EqualValues(t, 1, actual.MissedAttempts)
+
+	env.fundPayer(t, payer, common.ToCoreMintQuarks(10))
+	env.expireRetry(t, record.ScheduleId)
+
+	// The missed payment is made once funded, and stays anchored to the schedule
+	require.NoError(t, env.runtime.processDuePayments(env.ctx))
+	env.assertScheduledPaymentMade(t, record, 0, getExpectedQuarks(10))
+	actual = env.assertPaymentSchedule(t, record.ScheduleId, paymentschedule.StateActive, 1, nextPaymentAt.Add(interval))
+	assert.EqualValues(t, 0, actual.MissedAttempts)
+	assert.Nil(t, actual.RetryAt)
+}
+
+func TestScheduledPayment_Failures(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		setup         func(t *testing.T, env *testEnv) (*common.Account, float64)
+		expectedError error
+	}{
+		{
+			name: "payer key not held by server",
+			setup: func(t *testing.T, env *testEnv) (*common.Account, float64) {
+				return testutil.NewRandomAccount(t), 10
+			},
+			expectedError: ErrPayerNotAuthorized,
+		},
+		{
+			name: "payer account unlocked",
+			setup: func(t *testing.T, env *testEnv) (*common.Account, float64) {
+				payer := env.setupPayer(t, common.ToCoreMintQuarks(100))
+
+				timelockAccounts, err := payer.GetTimelockAccounts(testutil.NewRandomVmConfig(t, true))
+				require.NoError(t, err)
+				timelockRecord, err := env.data.GetTimelockByVault(env.ctx, timelockAccounts.Vault.PublicKey().ToBase58())
+				require.NoError(t, err)
+				timelockRecord.VaultState = timelock_token_v1.StateUnlocked
+				timelockRecord.Block += 1
+				require.NoError(t, env.data.SaveTimelock(env.ctx, timelockRecord))
+
+				return payer, 10
+			},
+			expectedError: ErrPayerNotManaged,
+		},
+		{
+			name: "aml transaction limit exceeded",
+			setup: func(t *testing.T, env *testEnv) (*common.Account, float64) {
+				return env.setupPayer(t, common.ToCoreMintQuarks(1_000)), 500
+			},
+			expectedError: ErrTransactionLimit,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			env := setup(t, &testOverrides{batchSize: 10, concurrency: 2})
+
+			payer, nativeAmount := tc.setup(t, env)
+
+			nextPaymentAt := time.Now().Add(-time.Minute)
+			record := env.createPaymentSchedule(t, payer, nativeAmount, nativeAmount, 0, 1, nextPaymentAt)
+
+			require.NoError(t, env.runtime.processDuePayments(env.ctx))
+
+			env.assertScheduledPaymentNotMade(t, record, 0)
+			env.assertPaymentSchedule(t, record.ScheduleId, paymentschedule.StateFailed, 0, nextPaymentAt)
+
+			event := env.assertScheduledPaymentEvent(t, webhook.EventTypeScheduledPaymentFailed, record, 0)
+			assert.Equal(t, tc.expectedError.Error(), event.FailureReason)
+
+			message := env.assertScheduledPaymentMessage(t, paymentschedulepb.ScheduledPaymentMessage_FAILED, record, 0)
+			assert.Equal(t, tc.expectedError.Error(), message.FailureReason)
+		})
+	}
+}
+
+func TestGetScheduledPaymentIntentId(t *testing.T) {
+	intentId := GetScheduledPaymentIntentId("schedule1", 0)
+	assert.Equal(t, intentId, GetScheduledPaymentIntentId("schedule1", 0))
+	assert.NotEqual(t, intentId, GetScheduledPaymentIntentId("schedule1", 1))
+	assert.NotEqual(t, intentId, GetScheduledPaymentIntentId("schedule2", 0))
+}
+
+func getExpectedQuarks(usdAmount float64) uint64 {
+	quarks := uint64(usdAmount * float64(common.CoreMintQuarksPerUnit))
+	if !common.IsCoreMintUsdStableCoin() {
+		quarks++
+	}
+	return quarks
+}
@@ -0,0 +1,70 @@
+package paymentschedule
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/code-payments/ocp-server/ocp/aml"
+	"github.com/code-payments/ocp-server/ocp/antispam"
+	"github.com/code-payments/ocp-server/ocp/common"
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/nonce"
+	transaction_util "github.com/code-payments/ocp-server/ocp/transaction"
+	"github.com/code-payments/ocp-server/ocp/worker"
+)
+
+type runtime struct {
+	log           *zap.Logger
+	conf          *conf
+	data          ocp_data.Provider
+	antispamGuard *antispam.Guard
+	amlGuard      *aml.Guard
+	noncePool     *transaction_util.LocalNoncePool
+}
+
+// New returns a runtime that makes public payments on an owner's behalf as
+// they become due in the payment schedules they've pre-authorized. Intents are
+// created using the client intent nonce pool for the core mint VM.
+func New(log *zap.Logger, data ocp_data.Provider, antispamGuard *antispam.Guard, amlGuard *aml.Guard, noncePools []*transaction_util.LocalNoncePool, configProvider ConfigProvider) (worker.Runtime, error) {
+	noncePool, err := transaction_util.SelectNoncePool(
+		nonce.EnvironmentVm,
+		common.CoreMintVmAccount.PublicKey().ToBase58(),
+		nonce.PurposeClientIntent,
+		noncePools...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &runtime{
+		log:           log,
+		conf:          configProvider(),
+		data:          data,
+		antispamGuard: antispamGuard,
+		amlGuard:      amlGuard,
+		noncePool:     noncePool,
+	}, nil
+}
+
+func (p *runtime) Start(ctx context.Context, interval time.Duration) error {
+	go func() {
+		err := p.paymentWorker(ctx, interval)
+		if err != nil && err != context.Canceled {
+			p.log.With(zap.Error(err)).Warn("scheduled payment processing loop terminated unexpectedly")
+		}
+	}()
+
+	go func() {
+		err := p.metricsGaugeWorker(ctx)
+		if err != nil && err != context.Canceled {
+			p.log.With(zap.Error(err)).Warn("payment schedule metrics gauge loop terminated unexpectedly")
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
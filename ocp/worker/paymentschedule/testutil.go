@@ -0,0 +1,281 @@
+package paymentschedule
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mr-tron/base58"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/protobuf/proto"
+
+	currency_lib "github.com/code-payments/ocp-server/currency"
+	"github.com/code-payments/ocp-server/ocp/aml"
+	"github.com/code-payments/ocp-server/ocp/antispam"
+	"github.com/code-payments/ocp-server/ocp/common"
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/currency"
+	"github.com/code-payments/ocp-server/ocp/data/deposit"
+	"github.com/code-payments/ocp-server/ocp/data/nonce"
+	"github.com/code-payments/ocp-server/ocp/data/paymentschedule"
+	"github.com/code-payments/ocp-server/ocp/data/transaction"
+	"github.com/code-payments/ocp-server/ocp/data/vault"
+	webhook_data "github.com/code-payments/ocp-server/ocp/data/webhook"
+	paymentschedulepb "github.com/code-payments/ocp-server/ocp/rpc/paymentschedule/api/gen"
+	transaction_util "github.com/code-payments/ocp-server/ocp/transaction"
+	"github.com/code-payments/ocp-server/ocp/webhook"
+	"github.com/code-payments/ocp-server/pointer"
+	"github.com/code-payments/ocp-server/solana"
+	timelock_token_v1 "github.com/code-payments/ocp-server/solana/timelock/v1"
+	"github.com/code-payments/ocp-server/testutil"
+)
+
+type testEnv struct {
+	ctx     context.Context
+	data    ocp_data.Provider
+	runtime *runtime
+}
+
+func setup(t *testing.T, overrides *testOverrides) *testEnv {
+	log := zaptest.NewLogger(t)
+
+	webhook.InjectTestEnabled(true)
+	t.Cleanup(func() {
+		webhook.InjectTestEnabled(false)
+	})
+
+	ctx := context.Background()
+	data := ocp_data.NewTestDataProvider()
+	testutil.SetupRandomSubsidizer(t, data)
+
+	require.NoError(t, data.ImportExchangeRates(ctx, &currency.MultiRateRecord{
+		Time: time.Now().Add(-time.Hour),
+		Rates: map[string]float64{
+			string(currency_lib.USD): 1.0,
+		},
+	}))
+
+	for range 10 {
+		var bh solana.Blockhash
+		rand.Read(bh[:])
+
+		require.NoError(t, data.SaveNonce(ctx, &nonce.Record{
+			Address:             testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+			Blockhash:           base58.Encode(bh[:]),
+			Authority:           common.GetSubsidizer().PublicKey().ToBase58(),
+			Environment:         nonce.EnvironmentVm,
+			EnvironmentInstance: common.CoreMintVmAccount.PublicKey().ToBase58(),
+			Purpose:             nonce.PurposeClientIntent,
+			State:               nonce.StateAvailable,
+		}))
+	}
+
+	noncePool, err := transaction_util.NewLocalNoncePool(
+		log,
+		data,
+		nil,
+		nonce.EnvironmentVm,
+		common.CoreMintVmAccount.PublicKey().ToBase58(),
+		nonce.PurposeClientIntent,
+		transaction_util.WithNoncePoolRefreshPoolInterval(time.Second),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		noncePool.Close()
+	})
+
+	policies, err := aml.NewStaticPolicyProvider(aml.DefaultPolicy())
+	require.NoError(t, err)
+
+	runtimeInterface, err := New(
+		log,
+		data,
+		antispam.NewGuard(antispam.NewAllowEverything()),
+		aml.NewGuard(log, data, policies),
+		[]*transaction_util.LocalNoncePool{noncePool},
+		withManualTestOverrides(overrides),
+	)
+	require.NoError(t, err)
+
+	return &testEnv{
+		ctx:     ctx,
+		data:    data,
+		runtime: runtimeInterface.(*runtime),
+	}
+}
+
+// setupPayer creates an owner whose key is held by the server, with a funded
+// core mint primary account
+func (e *testEnv) setupPayer(t *testing.T, quarks uint64) *common.Account {
+	vaultRecord, err := vault.CreateKey()
+	require.NoError(t, err)
+	require.NoError(t, e.data.SaveKey(e.ctx, vaultRecord))
+
+	owner, err := common.NewAccountFromPrivateKeyString(vaultRecord.PrivateKey)
+	require.NoError(t, err)
+
+	timelockAccounts, err := owner.GetTimelockAccounts(testutil.NewRandomVmConfig(t, true))
+	require.NoError(t, err)
+
+	timelockRecord := timelockAccounts.ToDBRecord()
+	timelockRecord.VaultState = timelock_token_v1.StateLocked
+	timelockRecord.Block = 1
+	require.NoError(t, e.data.SaveTimelock(e.ctx, timelockRecord))
+
+	if quarks > 0 {
+		e.fundPayer(t, owner, quarks)
+	}
+
+	return owner
+}
+
+// fundPayer deposits additional quarks into the payer's core mint primary account
+func (e *testEnv) fundPayer(t *testing.T, owner *common.Account, quarks uint64) {
+	timelockAccounts, err := owner.GetTimelockAccounts(testutil.NewRandomVmConfig(t, true))
+	require.NoError(t, err)
+
+	require.NoError(t, e.data.SaveExternalDeposit(e.ctx, &deposit.Record{
+		Signature:      fmt.Sprintf("txn_%s", testutil.NewRandomAccount(t).PublicKey().ToBase58()),
+		Destination:    timelockAccounts.Vault.PublicKey().ToBase58(),
+		Amount:         quarks,
+		UsdMarketValue: 1,
+
+		ConfirmationState: transaction.ConfirmationFinalized,
+		Slot:              12345,
+	}))
+}
+
+func (e *testEnv) createPaymentSchedule(t *testing.T, owner *common.Account, nativeAmount, maxTotalNativeAmount float64, interval time.Duration, maxPayments uint64, nextPaymentAt time.Time) *paymentschedule.Record {
+	record := &paymentschedule.Record{
+		ScheduleId: testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+
+		Owner: owner.PublicKey().ToBase58(),
+		Mint:  common.CoreMintAccount.PublicKey().ToBase58(),
+
+		DestinationOwner:        testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+		DestinationTokenAccount: testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+
+		ExchangeCurrency: currency_lib.USD,
+		NativeAmount:     nativeAmount,
+
+		MaxTotalNativeAmount: maxTotalNativeAmount,
+
+		Interval: interval,
+
+		MaxPayments: maxPayments,
+
+		NextPaymentAt: nextPaymentAt,
+
+		State: paymentschedule.StateActive,
+	}
+	require.NoError(t, e.data.PutPaymentSchedule(e.ctx, record))
+	return record
+}
+
+func (e *testEnv) assertPaymentSchedule(t *testing.T, scheduleId string, state paymentschedule.State, paymentsMade uint64, nextPaymentAt time.Time) *paymentschedule.Record {
+	actual, err := e.data.GetPaymentSchedule(e.ctx, scheduleId)
+	require.NoError(t, err)
+	assert.Equal(t, state, actual.State)
+	assert.Equal(t, paymentsMade, actual.PaymentsMade)
+	assert.Equal(t, float64(paymentsMade)*actual.NativeAmount, actual.TotalNativeAmountPaid)
+	assert.Equal(t, nextPaymentAt.Unix(), actual.NextPaymentAt.Unix())
+	return actual
+}
+
+// expireRetry moves the retry time of a missed payment into the past
+func (e *testEnv) expireRetry(t *testing.T, scheduleId string) {
+	record, err := e.data.GetPaymentSchedule(e.ctx, scheduleId)
+	require.NoError(t, err)
+	require.NotNil(t, record.RetryAt)
+
+	record.RetryAt = pointer.Time(time.Now().Add(-time.Second))
+	require.NoError(t, e.data.UpdatePaymentSchedule(e.ctx, record))
+}
+
+func (e *testEnv) assertScheduledPaymentMade(t *testing.T, record *paymentschedule.Record, paymentIndex uint64, expectedQuarks uint64) {
+	intentId := GetScheduledPaymentIntentId(record.ScheduleId, paymentIndex)
+
+	intentRecord, err := e.data.GetIntent(e.ctx, intentId)
+	require.NoError(t, err)
+	require.NotNil(t, intentRecord.SendPublicPaymentMetadata)
+	assert.Equal(t, record.Owner, intentRecord.InitiatorOwnerAccount)
+	assert.Equal(t, record.DestinationOwner, intentRecord.SendPublicPaymentMetadata.DestinationOwnerAccount)
+	assert.Equal(t, record.DestinationTokenAccount, intentRecord.SendPublicPaymentMetadata.DestinationTokenAccount)
+	assert.Equal(t, expectedQuarks, intentRecord.SendPublicPaymentMetadata.Quantity)
+	assert.Equal(t, record.NativeAmount, intentRecord.SendPublicPaymentMetadata.NativeAmount)
+
+	actionRecords, err := e.data.GetAllActionsByIntent(e.ctx, intentId)
+	require.NoError(t, err)
+	require.Len(t, actionRecords, 1)
+	assert.Equal(t, record.DestinationTokenAccount, *actionRecords[0].Destination)
+	assert.Equal(t, expectedQuarks, *actionRecords[0].Quantity)
+
+	fulfillmentRecords, err := e.data.GetAllFulfillmentsByIntent(e.ctx, intentId)
+	require.NoError(t, err)
+	require.Len(t, fulfillmentRecords, 1)
+	assert.Equal(t, intentRecord.Id, fulfillmentRecords[0].IntentOrderingIndex)
+	assert.NotNil(t, fulfillmentRecords[0].VirtualSignature)
+
+	e.assertScheduledPaymentEvent(t, webhook.EventTypeScheduledPaymentExecuted, record, paymentIndex)
+
+	message := e.assertScheduledPaymentMessage(t, paymentschedulepb.ScheduledPaymentMessage_EXECUTED, record, paymentIndex)
+	require.NotNil(t, message.IntentId)
+	assert.Equal(t, intentId, base58.Encode(message.IntentId.Value))
+	assert.Empty(t, message.FailureReason)
+}
+
+func (e *testEnv) assertScheduledPaymentNotMade(t *testing.T, record *paymentschedule.Record, paymentIndex uint64) {
+	_, err := e.data.GetIntent(e.ctx, GetScheduledPaymentIntentId(record.ScheduleId, paymentIndex))
+	assert.Error(t, err)
+}
+
+func (e *testEnv) assertScheduledPaymentEvent(t *testing.T, eventType webhook.EventType, record *paymentschedule.Record, paymentIndex uint64) *webhook.ScheduledPaymentEventData {
+	eventRecord, err := e.data.GetWebhookEvent(e.ctx, fmt.Sprintf("%s:%s:%d", eventType, record.ScheduleId, paymentIndex))
+	require.NoError(t, err)
+	assert.Equal(t, webhook_data.StatePending, eventRecord.State)
+
+	var event struct {
+		Data webhook.ScheduledPaymentEventData `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(eventRecord.Payload, &event))
+	assert.Equal(t, record.ScheduleId, event.Data.ScheduleId)
+	assert.Equal(t, record.Owner, event.Data.Owner)
+	assert.Equal(t, GetScheduledPaymentIntentId(record.ScheduleId, paymentIndex), event.Data.IntentId)
+	assert.Equal(t, paymentIndex, event.Data.PaymentIndex)
+	return &event.Data
+}
+
+func (e *testEnv) assertNoScheduledPaymentEvent(t *testing.T, eventType webhook.EventType, record *paymentschedule.Record, paymentIndex uint64) {
+	_, err := e.data.GetWebhookEvent(e.ctx, fmt.Sprintf("%s:%s:%d", eventType, record.ScheduleId, paymentIndex))
+	assert.Error(t, err)
+}
+
+func (e *testEnv) assertScheduledPaymentMessage(t *testing.T, kind paymentschedulepb.ScheduledPaymentMessage_Kind, record *paymentschedule.Record, paymentIndex uint64) *paymentschedulepb.ScheduledPaymentMessage {
+	messageRecords, err := e.data.GetMessages(e.ctx, paymentschedule.GetMessageBin(record.Owner))
+	require.NoError(t, err)
+
+	var found *paymentschedulepb.ScheduledPaymentMessage
+	for _, messageRecord := range messageRecords {
+		var message paymentschedulepb.ScheduledPaymentMessage
+		require.NoError(t, proto.Unmarshal(messageRecord.Message, &message))
+
+		if base58.Encode(message.ScheduleId.Value) != record.ScheduleId || message.PaymentIndex != uint32(paymentIndex) || message.Kind != kind {
+			continue
+		}
+
+		require.Nil(t, found, "duplicate scheduled payment message")
+		found = &message
+
+		messageId, err := uuid.FromBytes(message.Id.Value)
+		require.NoError(t, err)
+		assert.Equal(t, messageRecord.MessageID, messageId)
+	}
+	require.NotNil(t, found, "scheduled payment message not found")
+	return found
+}
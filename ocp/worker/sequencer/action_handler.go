@@ -123,6 +123,84 @@ func (h *NoPrivacyWithdrawActionHandler) OnFulfillmentStateChange(ctx context.Co
 	return nil
 }
 
+type InitializeLaunchpadCurrencyActionHandler struct {
+	data ocp_data.Provider
+}
+
+func NewInitializeLaunchpadCurrencyActionHandler(data ocp_data.Provider) ActionHandler {
+	return &InitializeLaunchpadCurrencyActionHandler{
+		data: data,
+	}
+}
+
+func (h *InitializeLaunchpadCurrencyActionHandler) OnFulfillmentStateChange(ctx context.Context, fulfillmentRecord *fulfillment.Record, newState fulfillment.State) error {
+	if fulfillmentRecord.FulfillmentType != fulfillment.InitializeLaunchpadCurrency {
+		return errors.New("unexpected fulfillment type")
+	}
+
+	if newState == fulfillment.StateConfirmed {
+		return markActionConfirmed(ctx, h.data, fulfillmentRecord.Intent, fulfillmentRecord.ActionId)
+	}
+
+	if newState == fulfillment.StateFailed {
+		return markActionFailed(ctx, h.data, fulfillmentRecord.Intent, fulfillmentRecord.ActionId)
+	}
+
+	return nil
+}
+
+type CreateLaunchpadCurrencyAltActionHandler struct {
+	data ocp_data.Provider
+}
+
+func NewCreateLaunchpadCurrencyAltActionHandler(data ocp_data.Provider) ActionHandler {
+	return &CreateLaunchpadCurrencyAltActionHandler{
+		data: data,
+	}
+}
+
+func (h *CreateLaunchpadCurrencyAltActionHandler) OnFulfillmentStateChange(ctx context.Context, fulfillmentRecord *fulfillment.Record, newState fulfillment.State) error {
+	if fulfillmentRecord.FulfillmentType != fulfillment.CreateLaunchpadCurrencyAlt {
+		return errors.New("unexpected fulfillment type")
+	}
+
+	if newState == fulfillment.StateConfirmed {
+		return markActionConfirmed(ctx, h.data, fulfillmentRecord.Intent, fulfillmentRecord.ActionId)
+	}
+
+	if newState == fulfillment.StateFailed {
+		return markActionFailed(ctx, h.data, fulfillmentRecord.Intent, fulfillmentRecord.ActionId)
+	}
+
+	return nil
+}
+
+type InitializeLaunchpadCurrencyVmActionHandler struct {
+	data ocp_data.Provider
+}
+
+func NewInitializeLaunchpadCurrencyVmActionHandler(data ocp_data.Provider) ActionHandler {
+	return &InitializeLaunchpadCurrencyVmActionHandler{
+		data: data,
+	}
+}
+
+func (h *InitializeLaunchpadCurrencyVmActionHandler) OnFulfillmentStateChange(ctx context.Context, fulfillmentRecord *fulfillment.Record, newState fulfillment.State) error {
+	if fulfillmentRecord.FulfillmentType != fulfillment.InitializeLaunchpadCurrencyVm {
+		return errors.New("unexpected fulfillment type")
+	}
+
+	if newState == fulfillment.StateConfirmed {
+		return markActionConfirmed(ctx, h.data, fulfillmentRecord.Intent, fulfillmentRecord.ActionId)
+	}
+
+	if newState == fulfillment.StateFailed {
+		return markActionFailed(ctx, h.data, fulfillmentRecord.Intent, fulfillmentRecord.ActionId)
+	}
+
+	return nil
+}
+
 func validateActionState(record *action.Record, states ...action.State) error {
 	for _, validState := range states {
 		if record.State == validState {
@@ -170,11 +248,33 @@ func markActionFailed(ctx context.Context, data ocp_data.Provider, intentId stri
 	return data.UpdateAction(ctx, record)
 }
 
+func markActionRevoked(ctx context.Context, data ocp_data.Provider, intentId string, actionId uint32) error {
+	record, err := data.GetActionById(ctx, intentId, actionId)
+	if err != nil {
+		return err
+	}
+
+	if record.State == action.StateRevoked {
+		return nil
+	}
+
+	err = validateActionState(record, action.StateUnknown, action.StatePending)
+	if err != nil {
+		return err
+	}
+
+	record.State = action.StateRevoked
+	return data.UpdateAction(ctx, record)
+}
+
 func getActionHandlers(data ocp_data.Provider) map[action.Type]ActionHandler {
 	handlersByType := make(map[action.Type]ActionHandler)
 	handlersByType[action.OpenAccount] = NewOpenAccountActionHandler(data)
 	handlersByType[action.CloseEmptyAccount] = NewCloseEmptyAccountActionHandler(data)
 	handlersByType[action.NoPrivacyTransfer] = NewNoPrivacyTransferActionHandler(data)
 	handlersByType[action.NoPrivacyWithdraw] = NewNoPrivacyWithdrawActionHandler(data)
+	handlersByType[action.InitializeLaunchpadCurrency] = NewInitializeLaunchpadCurrencyActionHandler(data)
+	handlersByType[action.CreateLaunchpadCurrencyAlt] = NewCreateLaunchpadCurrencyAltActionHandler(data)
+	handlersByType[action.InitializeLaunchpadCurrencyVm] = NewInitializeLaunchpadCurrencyVmActionHandler(data)
 	return handlersByType
 }
@@ -0,0 +1,212 @@
+package sequencer
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/mr-tron/base58"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/code-payments/ocp-server/ocp/common"
+	"github.com/code-payments/ocp-server/ocp/data/batch"
+	"github.com/code-payments/ocp-server/ocp/data/fulfillment"
+	transaction_util "github.com/code-payments/ocp-server/ocp/transaction"
+	"github.com/code-payments/ocp-server/solana"
+)
+
+type batchCandidate struct {
+	record    *fulfillment.Record
+	batchable *transaction_util.BatchableInstructions
+	signers   []*common.Account
+}
+
+// batchPendingFulfillments packs the virtual instructions of pending fulfillments
+// that don't yet have a transaction into shared Solana transactions, grouped by
+// VM. Anything left unbatched falls through to 1:1 on demand transactions.
+//
+// Fulfillments are only pending after everything they depend on has been
+// confirmed, so any set of pending fulfillments is safe to execute together. A
+// failed transaction requires every fulfillment in it to be retried with its own
+// transaction, so to limit the blast radius, a batch never contains two
+// fulfillments that touch the same account.
+func (p *runtime) batchPendingFulfillments(ctx context.Context, records []*fulfillment.Record) error {
+	log := p.log.With(zap.String("method", "batchPendingFulfillments"))
+
+	maxFulfillmentsPerBatch := int(p.conf.maxFulfillmentsPerBatch.Get(ctx))
+	if maxFulfillmentsPerBatch < 2 {
+		return nil
+	}
+
+	var vms []string
+	candidatesByVm := make(map[string][]*batchCandidate)
+	for _, record := range records {
+		if record.State != fulfillment.StatePending || record.Signature != nil {
+			continue
+		}
+
+		handler, ok := p.fulfillmentHandlersByType[record.FulfillmentType].(BatchableFulfillmentHandler)
+		if !ok {
+			continue
+		}
+
+		_, err := p.data.GetFulfillmentBatchByFulfillment(ctx, record.Id)
+		if err == nil {
+			continue
+		} else if err != batch.ErrNotFound {
+			return err
+		}
+
+		batchable, signers, err := handler.MakeBatchableInstructions(ctx, record)
+		if err != nil {
+			log.With(
+				zap.Error(err),
+				zap.Uint64("fulfillment", record.Id),
+			).Debug("fulfillment cannot be batched")
+			continue
+		}
+
+		vm := batchable.Vm.PublicKey().ToBase58()
+		if _, ok := candidatesByVm[vm]; !ok {
+			vms = append(vms, vm)
+		}
+		candidatesByVm[vm] = append(candidatesByVm[vm], &batchCandidate{
+			record:    record,
+			batchable: batchable,
+			signers:   signers,
+		})
+	}
+
+	for _, vm := range vms {
+		var selected []*batchCandidate
+		touchedAccounts := make(map[string]struct{})
+		for _, candidate := range candidatesByVm[vm] {
+			accounts := []string{candidate.record.Source}
+			if candidate.record.Destination != nil {
+				accounts = append(accounts, *candidate.record.Destination)
+			}
+
+			var isConflicting bool
+			for _, account := range accounts {
+				if _, ok := touchedAccounts[account]; ok {
+					isConflicting = true
+					break
+				}
+			}
+			if isConflicting {
+				continue
+			}
+
+			for _, account := range accounts {
+				touchedAccounts[account] = struct{}{}
+			}
+			selected = append(selected, candidate)
+
+			if len(selected) == maxFulfillmentsPerBatch {
+				err := p.createFulfillmentBatch(ctx, selected)
+				if err == transaction_util.ErrNoAvailableNonces {
+					return err
+				} else if err != nil {
+					log.With(zap.Error(err), zap.String("vm", vm)).Warn("failure creating fulfillment batch")
+				}
+
+				selected = nil
+				touchedAccounts = make(map[string]struct{})
+			}
+		}
+
+		if len(selected) > 1 {
+			err := p.createFulfillmentBatch(ctx, selected)
+			if err == transaction_util.ErrNoAvailableNonces {
+				return err
+			} else if err != nil {
+				log.With(zap.Error(err), zap.String("vm", vm)).Warn("failure creating fulfillment batch")
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *runtime) createFulfillmentBatch(ctx context.Context, candidates []*batchCandidate) error {
+	selectedSolanaNonce, err := p.solanaNoncePool.GetNonce(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		selectedSolanaNonce.ReleaseIfNotReserved(ctx)
+	}()
+
+	// Drop fulfillments from the end of the batch until the transaction fits
+	var txn solana.Transaction
+	for {
+		if len(candidates) < 2 {
+			return nil
+		}
+
+		var batchables []*transaction_util.BatchableInstructions
+		var signers []*common.Account
+		for _, candidate := range candidates {
+			batchables = append(batchables, candidate.batchable)
+			signers = append(signers, candidate.signers...)
+		}
+
+		txn, err = transaction_util.MakeBatchedTransaction(selectedSolanaNonce, batchables...)
+		if err != nil {
+			return err
+		}
+
+		err = signOnDemandTransaction(&txn, signers)
+		if err != nil {
+			return err
+		}
+
+		if len(txn.Marshal()) <= solana.MaxTransactionSize {
+			break
+		}
+		candidates = candidates[:len(candidates)-1]
+	}
+
+	batchRecord := &batch.Record{
+		Vm: candidates[0].batchable.Vm.PublicKey().ToBase58(),
+
+		Signature: base58.Encode(txn.Signature()),
+		Nonce:     selectedSolanaNonce.Account.PublicKey().ToBase58(),
+		Blockhash: base58.Encode(selectedSolanaNonce.Blockhash[:]),
+		Data:      txn.Marshal(),
+
+		State: batch.StatePending,
+	}
+	for _, candidate := range candidates {
+		batchRecord.Fulfillments = append(batchRecord.Fulfillments, candidate.record.Id)
+	}
+
+	updated := make([]fulfillment.Record, len(candidates))
+	err = p.data.ExecuteInTx(ctx, sql.LevelDefault, func(ctx context.Context) error {
+		// Bump the version of each fulfillment, so a concurrent attempt at making
+		// a 1:1 on demand transaction for any of them fails as stale
+		for i, candidate := range candidates {
+			updated[i] = candidate.record.Clone()
+			err := p.data.UpdateFulfillment(ctx, &updated[i])
+			if err != nil {
+				return errors.Wrap(err, "error updating fulfillment")
+			}
+		}
+
+		err := p.data.PutFulfillmentBatch(ctx, batchRecord)
+		if err != nil {
+			return errors.Wrap(err, "error creating batch")
+		}
+
+		return selectedSolanaNonce.MarkReservedWithSignature(ctx, batchRecord.Signature)
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, candidate := range candidates {
+		updated[i].CopyTo(candidate.record)
+	}
+
+	return nil
+}
@@ -1,6 +1,8 @@
 package sequencer
 
 import (
+	"time"
+
 	"github.com/code-payments/ocp-server/config"
 	"github.com/code-payments/ocp-server/config/env"
 	"github.com/code-payments/ocp-server/config/memory"
@@ -22,8 +24,23 @@ const (
 	FulfillmentBatchSizeConfigEnvName = envConfigPrefix + "WORKER_BATCH_SIZE"
 	defaultFulfillmentBatchSize       = 100
 
+	FulfillmentConcurrencyConfigEnvName = envConfigPrefix + "WORKER_CONCURRENCY"
+	defaultFulfillmentConcurrency       = 32
+
 	EnableSubsidizerChecksConfigEnvName = envConfigPrefix + "ENABLE_SUBSIDIZER_CHECKS"
 	defaultEnableSubsidizerChecks       = true
+
+	EnableFulfillmentBatchingConfigEnvName = envConfigPrefix + "ENABLE_FULFILLMENT_BATCHING"
+	defaultEnableFulfillmentBatching       = false
+
+	MaxFulfillmentsPerBatchConfigEnvName = envConfigPrefix + "MAX_FULFILLMENTS_PER_BATCH"
+	defaultMaxFulfillmentsPerBatch       = 4
+
+	FulfillmentLeaseDurationConfigEnvName = envConfigPrefix + "FULFILLMENT_LEASE_DURATION"
+	defaultFulfillmentLeaseDuration       = time.Minute
+
+	SchedulingHintWorkerCountConfigEnvName = envConfigPrefix + "SCHEDULING_HINT_WORKER_COUNT"
+	defaultSchedulingHintWorkerCount       = 32
 )
 
 type conf struct {
@@ -31,8 +48,13 @@ type conf struct {
 	disableTransactionSubmission  config.Bool
 	maxGlobalFailedFulfillments   config.Uint64
 	fulfillmentBatchSize          config.Uint64
+	fulfillmentConcurrency        config.Uint64
 	enableSubsidizerChecks        config.Bool
 	enableCachedTransactionLookup config.Bool
+	enableFulfillmentBatching     config.Bool
+	maxFulfillmentsPerBatch       config.Uint64
+	fulfillmentLeaseDuration      config.Duration
+	schedulingHintWorkerCount     config.Uint64
 }
 
 // ConfigProvider defines how config values are pulled
@@ -46,8 +68,13 @@ func WithEnvConfigs() ConfigProvider {
 			disableTransactionSubmission:  env.NewBoolConfig(DisableTransactionSubmissionConfigEnvName, defaultDisableTransactionSubmission),
 			maxGlobalFailedFulfillments:   env.NewUint64Config(MaxGlobalFailedFulfillmentsConfigEnvName, defaultMaxGlobalFailedFulfillments),
 			fulfillmentBatchSize:          env.NewUint64Config(FulfillmentBatchSizeConfigEnvName, defaultFulfillmentBatchSize),
+			fulfillmentConcurrency:        env.NewUint64Config(FulfillmentConcurrencyConfigEnvName, defaultFulfillmentConcurrency),
 			enableSubsidizerChecks:        env.NewBoolConfig(EnableSubsidizerChecksConfigEnvName, defaultEnableSubsidizerChecks),
 			enableCachedTransactionLookup: wrapper.NewBoolConfig(memory.NewConfig(false), false),
+			enableFulfillmentBatching:     env.NewBoolConfig(EnableFulfillmentBatchingConfigEnvName, defaultEnableFulfillmentBatching),
+			maxFulfillmentsPerBatch:       env.NewUint64Config(MaxFulfillmentsPerBatchConfigEnvName, defaultMaxFulfillmentsPerBatch),
+			fulfillmentLeaseDuration:      env.NewDurationConfig(FulfillmentLeaseDurationConfigEnvName, defaultFulfillmentLeaseDuration),
+			schedulingHintWorkerCount:     env.NewUint64Config(SchedulingHintWorkerCountConfigEnvName, defaultSchedulingHintWorkerCount),
 		}
 	}
 }
@@ -55,6 +82,8 @@ func WithEnvConfigs() ConfigProvider {
 type testOverrides struct {
 	disableTransactionScheduling bool
 	maxGlobalFailedFulfillments  uint64
+	enableFulfillmentBatching    bool
+	maxFulfillmentsPerBatch      uint64
 }
 
 func withManualTestOverrides(overrides *testOverrides) ConfigProvider {
@@ -64,8 +93,13 @@ func withManualTestOverrides(overrides *testOverrides) ConfigProvider {
 			disableTransactionSubmission:  wrapper.NewBoolConfig(memory.NewConfig(true), defaultDisableTransactionSubmission),
 			maxGlobalFailedFulfillments:   wrapper.NewUint64Config(memory.NewConfig(overrides.maxGlobalFailedFulfillments), defaultMaxGlobalFailedFulfillments),
 			fulfillmentBatchSize:          wrapper.NewUint64Config(memory.NewConfig(defaultFulfillmentBatchSize), defaultFulfillmentBatchSize),
+			fulfillmentConcurrency:        wrapper.NewUint64Config(memory.NewConfig(defaultFulfillmentConcurrency), defaultFulfillmentConcurrency),
 			enableSubsidizerChecks:        wrapper.NewBoolConfig(memory.NewConfig(false), defaultEnableSubsidizerChecks),
 			enableCachedTransactionLookup: wrapper.NewBoolConfig(memory.NewConfig(true), true),
+			enableFulfillmentBatching:     wrapper.NewBoolConfig(memory.NewConfig(overrides.enableFulfillmentBatching), defaultEnableFulfillmentBatching),
+			maxFulfillmentsPerBatch:       wrapper.NewUint64Config(memory.NewConfig(overrides.maxFulfillmentsPerBatch), defaultMaxFulfillmentsPerBatch),
+			fulfillmentLeaseDuration:      wrapper.NewDurationConfig(memory.NewConfig(defaultFulfillmentLeaseDuration), defaultFulfillmentLeaseDuration),
+			schedulingHintWorkerCount:     wrapper.NewUint64Config(memory.NewConfig(defaultSchedulingHintWorkerCount), defaultSchedulingHintWorkerCount),
 		}
 	}
 }
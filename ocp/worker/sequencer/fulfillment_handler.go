@@ -20,6 +20,7 @@ import (
 	transaction_util "github.com/code-payments/ocp-server/ocp/transaction"
 	vm_util "github.com/code-payments/ocp-server/ocp/vm"
 	"github.com/code-payments/ocp-server/solana"
+	address_lookup_table "github.com/code-payments/ocp-server/solana/addresslookuptable"
 	"github.com/code-payments/ocp-server/solana/token"
 	"github.com/code-payments/ocp-server/solana/vm"
 )
@@ -39,9 +40,9 @@ type FulfillmentHandler interface {
 	// SupportsOnDemandTransactions returns whether a fulfillment type supports
 	// on demand transaction creation
 	//
-	// Note: This is also being abused for an initial version of packing virtual
-	// instructions 1:1 into a Solana transaction. A new flow/strategy will be
-	// needed when we require more efficient packing.
+	// Note: This is also being abused for packing virtual instructions 1:1 into
+	// a Solana transaction. Handlers that also implement BatchableFulfillmentHandler
+	// can have their virtual instructions packed alongside others.
 	SupportsOnDemandTransactions() bool
 
 	// MakeOnDemandTransaction constructs a transaction at the time of submission
@@ -77,6 +78,20 @@ type FulfillmentHandler interface {
 	IsRevoked(ctx context.Context, fulfillmentRecord *fulfillment.Record) (revoked bool, nonceUsed bool, err error)
 }
 
+// BatchableFulfillmentHandler is an optional extension of FulfillmentHandler
+// for fulfillments whose virtual instructions can be packed alongside others
+// for the same VM into a single Solana transaction.
+type BatchableFulfillmentHandler interface {
+	FulfillmentHandler
+
+	// MakeBatchableInstructions constructs the instructions that execute the
+	// fulfillment's virtual instruction, without any nonce or compute budget
+	// instructions. Any additional signers should also be returned.
+	//
+	// Note: Implementations should not modify the provided fulfillment record.
+	MakeBatchableInstructions(ctx context.Context, fulfillmentRecord *fulfillment.Record) (*transaction_util.BatchableInstructions, []*common.Account, error)
+}
+
 type InitializeLockedTimelockAccountFulfillmentHandler struct {
 	data ocp_data.Provider
 }
@@ -320,6 +335,19 @@ func (h *NoPrivacyTransferWithAuthorityFulfillmentHandler) SupportsOnDemandTrans
 }
 
 func (h *NoPrivacyTransferWithAuthorityFulfillmentHandler) MakeOnDemandTransaction(ctx context.Context, fulfillmentRecord *fulfillment.Record, selectedSolanaNonce *transaction_util.Nonce) (*solana.Transaction, []*common.Account, error) {
+	batchable, signers, err := h.MakeBatchableInstructions(ctx, fulfillmentRecord)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	txn, err := transaction_util.MakeBatchedTransaction(selectedSolanaNonce, batchable)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &txn, signers, nil
+}
+
+func (h *NoPrivacyTransferWithAuthorityFulfillmentHandler) MakeBatchableInstructions(ctx context.Context, fulfillmentRecord *fulfillment.Record) (*transaction_util.BatchableInstructions, []*common.Account, error) {
 	actionRecord, err := h.data.GetActionById(ctx, fulfillmentRecord.Intent, fulfillmentRecord.ActionId)
 	if err != nil {
 		return nil, nil, err
@@ -370,7 +398,7 @@ func (h *NoPrivacyTransferWithAuthorityFulfillmentHandler) MakeOnDemandTransacti
 		return nil, nil, err
 	}
 
-	sourceMemory, sourceIndex, err := vm_util.GetVirtualTimelockAccountLocationInMemory(ctx, h.vmIndexerClient, vmConfig.Vm, sourceAuthority)
+	sourceMemory, sourceIndex, err := vm_util.GetVirtualTimelockAccountLocationInMemoryOrRequestDecompression(ctx, h.data, h.vmIndexerClient, vmConfig, sourceAuthority)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -380,8 +408,8 @@ func (h *NoPrivacyTransferWithAuthorityFulfillmentHandler) MakeOnDemandTransacti
 		return nil, nil, err
 	}
 
-	var txn solana.Transaction
-	var makeTxnErr error
+	var batchable *transaction_util.BatchableInstructions
+	var makeBatchableErr error
 	if isInternal {
 		destinationAccountInfoRecord, err := h.data.GetAccountInfoByTokenAddress(ctx, destinationToken.PublicKey().ToBase58())
 		if err != nil {
@@ -393,14 +421,12 @@ func (h *NoPrivacyTransferWithAuthorityFulfillmentHandler) MakeOnDemandTransacti
 			return nil, nil, err
 		}
 
-		destinationMemory, destinationIndex, err := vm_util.GetVirtualTimelockAccountLocationInMemory(ctx, h.vmIndexerClient, vmConfig.Vm, destinationAuthority)
+		destinationMemory, destinationIndex, err := vm_util.GetVirtualTimelockAccountLocationInMemoryOrRequestDecompression(ctx, h.data, h.vmIndexerClient, vmConfig, destinationAuthority)
 		if err != nil {
 			return nil, nil, err
 		}
 
-		txn, makeTxnErr = transaction_util.MakeInternalTransferWithAuthorityTransaction(
-			selectedSolanaNonce,
-
+		batchable, makeBatchableErr = transaction_util.MakeInternalTransferWithAuthorityInstructions(
 			vmConfig,
 
 			solana.Signature(virtualSignatureBytes),
@@ -451,9 +477,7 @@ func (h *NoPrivacyTransferWithAuthorityFulfillmentHandler) MakeOnDemandTransacti
 			}
 		}
 
-		txn, makeTxnErr = transaction_util.MakeExternalTransferWithAuthorityTransaction(
-			selectedSolanaNonce,
-
+		batchable, makeBatchableErr = transaction_util.MakeExternalTransferWithAuthorityInstructions(
 			vmConfig,
 
 			solana.Signature(virtualSignatureBytes),
@@ -471,10 +495,10 @@ func (h *NoPrivacyTransferWithAuthorityFulfillmentHandler) MakeOnDemandTransacti
 			*actionRecord.Quantity,
 		)
 	}
-	if makeTxnErr != nil {
-		return nil, nil, makeTxnErr
+	if makeBatchableErr != nil {
+		return nil, nil, makeBatchableErr
 	}
-	return &txn, []*common.Account{vmConfig.Authority}, nil
+	return batchable, []*common.Account{vmConfig.Authority}, nil
 }
 
 type NoPrivacyWithdrawFulfillmentHandler struct {
@@ -544,6 +568,19 @@ func (h *NoPrivacyWithdrawFulfillmentHandler) SupportsOnDemandTransactions() boo
 }
 
 func (h *NoPrivacyWithdrawFulfillmentHandler) MakeOnDemandTransaction(ctx context.Context, fulfillmentRecord *fulfillment.Record, selectedSolanaNonce *transaction_util.Nonce) (*solana.Transaction, []*common.Account, error) {
+	batchable, signers, err := h.MakeBatchableInstructions(ctx, fulfillmentRecord)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	txn, err := transaction_util.MakeBatchedTransaction(selectedSolanaNonce, batchable)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &txn, signers, nil
+}
+
+func (h *NoPrivacyWithdrawFulfillmentHandler) MakeBatchableInstructions(ctx context.Context, fulfillmentRecord *fulfillment.Record) (*transaction_util.BatchableInstructions, []*common.Account, error) {
 	virtualSignatureBytes, err := base58.Decode(*fulfillmentRecord.VirtualSignature)
 	if err != nil {
 		return nil, nil, err
@@ -589,7 +626,7 @@ func (h *NoPrivacyWithdrawFulfillmentHandler) MakeOnDemandTransaction(ctx contex
 		return nil, nil, err
 	}
 
-	sourceMemory, sourceIndex, err := vm_util.GetVirtualTimelockAccountLocationInMemory(ctx, h.vmIndexerClient, vmConfig.Vm, sourceAuthority)
+	sourceMemory, sourceIndex, err := vm_util.GetVirtualTimelockAccountLocationInMemoryOrRequestDecompression(ctx, h.data, h.vmIndexerClient, vmConfig, sourceAuthority)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -599,8 +636,8 @@ func (h *NoPrivacyWithdrawFulfillmentHandler) MakeOnDemandTransaction(ctx contex
 		return nil, nil, err
 	}
 
-	var txn solana.Transaction
-	var makeTxnErr error
+	var batchable *transaction_util.BatchableInstructions
+	var makeBatchableErr error
 	if isInternal {
 		destinationAccountInfoRecord, err := h.data.GetAccountInfoByTokenAddress(ctx, destinationToken.PublicKey().ToBase58())
 		if err != nil {
@@ -612,14 +649,12 @@ func (h *NoPrivacyWithdrawFulfillmentHandler) MakeOnDemandTransaction(ctx contex
 			return nil, nil, err
 		}
 
-		destinationMemory, destinationIndex, err := vm_util.GetVirtualTimelockAccountLocationInMemory(ctx, h.vmIndexerClient, vmConfig.Vm, destinationAuthority)
+		destinationMemory, destinationIndex, err := vm_util.GetVirtualTimelockAccountLocationInMemoryOrRequestDecompression(ctx, h.data, h.vmIndexerClient, vmConfig, destinationAuthority)
 		if err != nil {
 			return nil, nil, err
 		}
 
-		txn, makeTxnErr = transaction_util.MakeInternalWithdrawTransaction(
-			selectedSolanaNonce,
-
+		batchable, makeBatchableErr = transaction_util.MakeInternalWithdrawInstructions(
 			vmConfig,
 
 			solana.Signature(virtualSignatureBytes),
@@ -634,9 +669,7 @@ func (h *NoPrivacyWithdrawFulfillmentHandler) MakeOnDemandTransaction(ctx contex
 			destinationIndex,
 		)
 	} else {
-		txn, makeTxnErr = transaction_util.MakeExternalWithdrawTransaction(
-			selectedSolanaNonce,
-
+		batchable, makeBatchableErr = transaction_util.MakeExternalWithdrawInstructions(
 			vmConfig,
 
 			solana.Signature(virtualSignatureBytes),
@@ -650,10 +683,10 @@ func (h *NoPrivacyWithdrawFulfillmentHandler) MakeOnDemandTransaction(ctx contex
 			destinationToken,
 		)
 	}
-	if makeTxnErr != nil {
-		return nil, nil, makeTxnErr
+	if makeBatchableErr != nil {
+		return nil, nil, makeBatchableErr
 	}
-	return &txn, []*common.Account{vmConfig.Authority}, nil
+	return batchable, []*common.Account{vmConfig.Authority}, nil
 }
 
 func (h *NoPrivacyWithdrawFulfillmentHandler) OnSuccess(ctx context.Context, fulfillmentRecord *fulfillment.Record, txnRecord *transaction.Record) error {
@@ -774,7 +807,7 @@ func (h *CloseEmptyTimelockAccountFulfillmentHandler) MakeOnDemandTransaction(ct
 		return nil, nil, err
 	}
 
-	virtualAccountState, memory, index, err := vm_util.GetVirtualTimelockAccountStateInMemory(ctx, h.vmIndexerClient, vmConfig.Vm, timelockOwner)
+	virtualAccountState, memory, index, err := vm_util.GetVirtualTimelockAccountStateInMemoryOrRequestDecompression(ctx, h.data, h.vmIndexerClient, vmConfig, timelockOwner)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -835,6 +868,269 @@ func (h *CloseEmptyTimelockAccountFulfillmentHandler) IsRevoked(ctx context.Cont
 	return false, false, nil
 }
 
+type InitializeLaunchpadCurrencyFulfillmentHandler struct {
+	data ocp_data.Provider
+}
+
+func NewInitializeLaunchpadCurrencyFulfillmentHandler(data ocp_data.Provider) FulfillmentHandler {
+	return &InitializeLaunchpadCurrencyFulfillmentHandler{
+		data: data,
+	}
+}
+
+func (h *InitializeLaunchpadCurrencyFulfillmentHandler) CanSubmitToBlockchain(ctx context.Context, fulfillmentRecord *fulfillment.Record) (scheduled bool, err error) {
+	if fulfillmentRecord.FulfillmentType != fulfillment.InitializeLaunchpadCurrency {
+		return false, errors.New("invalid fulfillment type")
+	}
+
+	// Initializing the currency is always the first thing done in a launch
+	return true, nil
+}
+
+func (h *InitializeLaunchpadCurrencyFulfillmentHandler) SupportsOnDemandTransactions() bool {
+	return true
+}
+
+func (h *InitializeLaunchpadCurrencyFulfillmentHandler) MakeOnDemandTransaction(ctx context.Context, fulfillmentRecord *fulfillment.Record, selectedSolanaNonce *transaction_util.Nonce) (*solana.Transaction, []*common.Account, error) {
+	if fulfillmentRecord.FulfillmentType != fulfillment.InitializeLaunchpadCurrency {
+		return nil, nil, errors.New("invalid fulfillment type")
+	}
+
+	launchRecord, authority, currencyAccounts, err := getCurrencyLaunchAccounts(ctx, h.data, fulfillmentRecord.Intent)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	seed, err := common.NewAccountFromPublicKeyString(launchRecord.Seed)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	txn, err := transaction_util.MakeInitializeLaunchpadCurrencyTransaction(
+		selectedSolanaNonce,
+
+		authority,
+
+		launchRecord.Name,
+		launchRecord.Symbol,
+		seed,
+		launchRecord.SellFeeBps,
+
+		currencyAccounts,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &txn, []*common.Account{authority}, nil
+}
+
+func (h *InitializeLaunchpadCurrencyFulfillmentHandler) OnSuccess(ctx context.Context, fulfillmentRecord *fulfillment.Record, txnRecord *transaction.Record) error {
+	if fulfillmentRecord.FulfillmentType != fulfillment.InitializeLaunchpadCurrency {
+		return errors.New("invalid fulfillment type")
+	}
+
+	return nil
+}
+
+func (h *InitializeLaunchpadCurrencyFulfillmentHandler) OnFailure(ctx context.Context, fulfillmentRecord *fulfillment.Record, txnRecord *transaction.Record) (recovered bool, err error) {
+	if fulfillmentRecord.FulfillmentType != fulfillment.InitializeLaunchpadCurrency {
+		return false, errors.New("invalid fulfillment type")
+	}
+
+	// Fulfillment record needs to be scheduled with a new transaction.
+	//
+	// todo: Implement auto-recovery
+	return false, nil
+}
+
+func (h *InitializeLaunchpadCurrencyFulfillmentHandler) IsRevoked(ctx context.Context, fulfillmentRecord *fulfillment.Record) (revoked bool, nonceUsed bool, err error) {
+	if fulfillmentRecord.FulfillmentType != fulfillment.InitializeLaunchpadCurrency {
+		return false, false, errors.New("invalid fulfillment type")
+	}
+
+	return false, false, nil
+}
+
+type CreateLaunchpadCurrencyAltFulfillmentHandler struct {
+	data ocp_data.Provider
+}
+
+func NewCreateLaunchpadCurrencyAltFulfillmentHandler(data ocp_data.Provider) FulfillmentHandler {
+	return &CreateLaunchpadCurrencyAltFulfillmentHandler{
+		data: data,
+	}
+}
+
+func (h *CreateLaunchpadCurrencyAltFulfillmentHandler) CanSubmitToBlockchain(ctx context.Context, fulfillmentRecord *fulfillment.Record) (scheduled bool, err error) {
+	if fulfillmentRecord.FulfillmentType != fulfillment.CreateLaunchpadCurrencyAlt {
+		return false, errors.New("invalid fulfillment type")
+	}
+
+	// Don't pay rent for an ALT until we know the currency was initialized
+	return isCurrencyLaunchInitialized(ctx, h.data, fulfillmentRecord.Intent)
+}
+
+func (h *CreateLaunchpadCurrencyAltFulfillmentHandler) SupportsOnDemandTransactions() bool {
+	return true
+}
+
+func (h *CreateLaunchpadCurrencyAltFulfillmentHandler) MakeOnDemandTransaction(ctx context.Context, fulfillmentRecord *fulfillment.Record, selectedSolanaNonce *transaction_util.Nonce) (*solana.Transaction, []*common.Account, error) {
+	if fulfillmentRecord.FulfillmentType != fulfillment.CreateLaunchpadCurrencyAlt {
+		return nil, nil, errors.New("invalid fulfillment type")
+	}
+
+	launchRecord, authority, currencyAccounts, err := getCurrencyLaunchAccounts(ctx, h.data, fulfillmentRecord.Intent)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	addresses, err := getLaunchedCurrencyAltAddresses(authority, currencyAccounts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The recent slot must still be in the slot hashes sysvar when the
+	// transaction lands, otherwise it will fail and require a new transaction.
+	recentSlot, err := h.data.GetBlockchainSlot(ctx, solana.CommitmentFinalized)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	altAddress, altBump, err := address_lookup_table.GetAddress(authority.PublicKey().ToBytes(), recentSlot)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	alt, err := common.NewAccountFromPublicKeyBytes(altAddress)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The ALT address is saved now, rather than on success, because the intent
+	// handler is invoked before OnSuccess and needs it to finalize the launch.
+	err = saveCurrencyLaunchAlt(ctx, h.data, launchRecord, alt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	txn, err := transaction_util.MakeCreateAltTransaction(
+		selectedSolanaNonce,
+
+		authority,
+
+		alt,
+		altBump,
+		recentSlot,
+
+		addresses...,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &txn, []*common.Account{authority}, nil
+}
+
+func (h *CreateLaunchpadCurrencyAltFulfillmentHandler) OnSuccess(ctx context.Context, fulfillmentRecord *fulfillment.Record, txnRecord *transaction.Record) error {
+	if fulfillmentRecord.FulfillmentType != fulfillment.CreateLaunchpadCurrencyAlt {
+		return errors.New("invalid fulfillment type")
+	}
+
+	return nil
+}
+
+func (h *CreateLaunchpadCurrencyAltFulfillmentHandler) OnFailure(ctx context.Context, fulfillmentRecord *fulfillment.Record, txnRecord *transaction.Record) (recovered bool, err error) {
+	if fulfillmentRecord.FulfillmentType != fulfillment.CreateLaunchpadCurrencyAlt {
+		return false, errors.New("invalid fulfillment type")
+	}
+
+	// The currency was already initialized, so the launch must complete. The
+	// ALT transaction is retried using a more recent slot, which is the likely
+	// cause of failure.
+	return retryCurrencyLaunchFulfillment(ctx, h.data, fulfillmentRecord)
+}
+
+func (h *CreateLaunchpadCurrencyAltFulfillmentHandler) IsRevoked(ctx context.Context, fulfillmentRecord *fulfillment.Record) (revoked bool, nonceUsed bool, err error) {
+	if fulfillmentRecord.FulfillmentType != fulfillment.CreateLaunchpadCurrencyAlt {
+		return false, false, errors.New("invalid fulfillment type")
+	}
+
+	return false, false, nil
+}
+
+type InitializeLaunchpadCurrencyVmFulfillmentHandler struct {
+	data ocp_data.Provider
+}
+
+func NewInitializeLaunchpadCurrencyVmFulfillmentHandler(data ocp_data.Provider) FulfillmentHandler {
+	return &InitializeLaunchpadCurrencyVmFulfillmentHandler{
+		data: data,
+	}
+}
+
+func (h *InitializeLaunchpadCurrencyVmFulfillmentHandler) CanSubmitToBlockchain(ctx context.Context, fulfillmentRecord *fulfillment.Record) (scheduled bool, err error) {
+	if fulfillmentRecord.FulfillmentType != fulfillment.InitializeLaunchpadCurrencyVm {
+		return false, errors.New("invalid fulfillment type")
+	}
+
+	// Don't pay rent for a VM until we know the currency was initialized
+	return isCurrencyLaunchInitialized(ctx, h.data, fulfillmentRecord.Intent)
+}
+
+func (h *InitializeLaunchpadCurrencyVmFulfillmentHandler) SupportsOnDemandTransactions() bool {
+	return true
+}
+
+func (h *InitializeLaunchpadCurrencyVmFulfillmentHandler) MakeOnDemandTransaction(ctx context.Context, fulfillmentRecord *fulfillment.Record, selectedSolanaNonce *transaction_util.Nonce) (*solana.Transaction, []*common.Account, error) {
+	if fulfillmentRecord.FulfillmentType != fulfillment.InitializeLaunchpadCurrencyVm {
+		return nil, nil, errors.New("invalid fulfillment type")
+	}
+
+	_, authority, currencyAccounts, err := getCurrencyLaunchAccounts(ctx, h.data, fulfillmentRecord.Intent)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	vmConfig, err := getLaunchedCurrencyVmConfig(authority, currencyAccounts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	txn, err := transaction_util.MakeInitVmTransaction(
+		selectedSolanaNonce,
+
+		vmConfig,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &txn, []*common.Account{authority}, nil
+}
+
+func (h *InitializeLaunchpadCurrencyVmFulfillmentHandler) OnSuccess(ctx context.Context, fulfillmentRecord *fulfillment.Record, txnRecord *transaction.Record) error {
+	if fulfillmentRecord.FulfillmentType != fulfillment.InitializeLaunchpadCurrencyVm {
+		return errors.New("invalid fulfillment type")
+	}
+
+	return nil
+}
+
+func (h *InitializeLaunchpadCurrencyVmFulfillmentHandler) OnFailure(ctx context.Context, fulfillmentRecord *fulfillment.Record, txnRecord *transaction.Record) (recovered bool, err error) {
+	if fulfillmentRecord.FulfillmentType != fulfillment.InitializeLaunchpadCurrencyVm {
+		return false, errors.New("invalid fulfillment type")
+	}
+
+	// The currency was already initialized, so the launch must complete
+	return retryCurrencyLaunchFulfillment(ctx, h.data, fulfillmentRecord)
+}
+
+func (h *InitializeLaunchpadCurrencyVmFulfillmentHandler) IsRevoked(ctx context.Context, fulfillmentRecord *fulfillment.Record) (revoked bool, nonceUsed bool, err error) {
+	if fulfillmentRecord.FulfillmentType != fulfillment.InitializeLaunchpadCurrencyVm {
+		return false, false, errors.New("invalid fulfillment type")
+	}
+
+	return false, false, nil
+}
+
 func isAccountInitialized(ctx context.Context, data ocp_data.Provider, address string) (bool, error) {
 	// Try our cache of Code timelock accounts
 	timelockRecord, err := data.GetTimelockByVault(ctx, address)
@@ -879,5 +1175,8 @@ func getFulfillmentHandlers(data ocp_data.Provider, vmIndexerClient indexerpb.In
 	handlersByType[fulfillment.NoPrivacyTransferWithAuthority] = NewNoPrivacyTransferWithAuthorityFulfillmentHandler(data, vmIndexerClient)
 	handlersByType[fulfillment.NoPrivacyWithdraw] = NewNoPrivacyWithdrawFulfillmentHandler(data, vmIndexerClient)
 	handlersByType[fulfillment.CloseEmptyTimelockAccount] = NewCloseEmptyTimelockAccountFulfillmentHandler(data, vmIndexerClient)
+	handlersByType[fulfillment.InitializeLaunchpadCurrency] = NewInitializeLaunchpadCurrencyFulfillmentHandler(data)
+	handlersByType[fulfillment.CreateLaunchpadCurrencyAlt] = NewCreateLaunchpadCurrencyAltFulfillmentHandler(data)
+	handlersByType[fulfillment.InitializeLaunchpadCurrencyVm] = NewInitializeLaunchpadCurrencyVmFulfillmentHandler(data)
 	return handlersByType
 }
@@ -0,0 +1,105 @@
+package sequencer
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/code-payments/ocp-server/ocp/data/fulfillment"
+	"github.com/code-payments/ocp-server/ocp/scheduling"
+	"github.com/code-payments/ocp-server/sync"
+)
+
+const (
+	schedulingHintQueueSize = 256
+)
+
+// schedulingHintWorker wakes up the sequencer for the next fulfillment of an
+// account as soon as it's hinted that it may be schedulable, which avoids
+// waiting for the next poll of the fulfillment's state.
+func (p *runtime) schedulingHintWorker(ctx context.Context) error {
+	hints, err := p.notifier.Subscribe(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Hints are partitioned by account, so fulfillments for the same account
+	// are handled serially in scheduling order
+	workers := sync.NewStripedChannel(uint(p.conf.schedulingHintWorkerCount.Get(ctx)), schedulingHintQueueSize)
+	defer workers.Close()
+
+	for _, worker := range workers.GetChannels() {
+		go func(worker <-chan interface{}) {
+			for value := range worker {
+				hint := value.(*scheduling.Hint)
+
+				err := p.handleSchedulingHint(ctx, hint)
+				if err != nil && ctx.Err() == nil {
+					p.log.With(
+						zap.Error(err),
+						zap.String("address", hint.Address),
+					).Warn("failure handling scheduling hint")
+				}
+			}
+		}(worker)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case hint, ok := <-hints:
+			if !ok {
+				return ctx.Err()
+			}
+
+			// Dropped hints are picked up by polling
+			workers.Send([]byte(hint.Address), hint)
+		}
+	}
+}
+
+func (p *runtime) handleSchedulingHint(ctx context.Context, hint *scheduling.Hint) error {
+	next, err := p.data.GetNextSchedulableFulfillmentByAddress(ctx, hint.Address, hint.IntentOrderingIndex, hint.ActionOrderingIndex, hint.FulfillmentOrderingIndex)
+	if err == fulfillment.ErrFulfillmentNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	// Hints are delivered to every node, but only the one that claims the
+	// fulfillment processes it. Fulfillments with active scheduling disabled
+	// are never claimed, and are left to other systems to schedule.
+	record, err := p.data.ClaimFulfillmentById(ctx, next.Id, p.nodeId, time.Now().Add(p.conf.fulfillmentLeaseDuration.Get(ctx)))
+	if err == fulfillment.ErrFulfillmentNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	return p.handleLeased(ctx, record, func(ctx context.Context, record *fulfillment.Record) error {
+		initialState := record.State
+
+		err := p.handle(ctx, record)
+		if err != nil {
+			return err
+		}
+
+		// A newly scheduled fulfillment is submitted right away, rather than on
+		// the next poll of pending fulfillments
+		if initialState == fulfillment.StateUnknown && record.State == fulfillment.StatePending {
+			return p.handle(ctx, record)
+		}
+		return nil
+	})
+}
+
+// notifyFulfillmentConfirmed hints that the next fulfillments for accounts
+// involved in a confirmed fulfillment may now be schedulable
+func (p *runtime) notifyFulfillmentConfirmed(ctx context.Context, record *fulfillment.Record) {
+	err := p.notifier.Notify(ctx, scheduling.GetHintsForConfirmedFulfillment(record)...)
+	if err != nil {
+		p.log.With(zap.Error(err), zap.Uint64("id", record.Id)).Warn("failure notifying scheduling hints")
+	}
+}
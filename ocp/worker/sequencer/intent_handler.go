@@ -2,11 +2,13 @@ package sequencer
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 
 	ocp_data "github.com/code-payments/ocp-server/ocp/data"
 	"github.com/code-payments/ocp-server/ocp/data/action"
 	"github.com/code-payments/ocp-server/ocp/data/intent"
+	"github.com/code-payments/ocp-server/ocp/webhook"
 )
 
 var (
@@ -94,6 +96,12 @@ func (h *SendPublicPaymentIntentHandler) OnActionUpdated(ctx context.Context, in
 		return markIntentFailed(ctx, h.data, intentId)
 	}
 	if allConfirmed {
+		// Payment requests are only paid once funds have moved to the
+		// merchant's destination
+		err = onPaymentRequestPaid(ctx, h.data, intentId)
+		if err != nil {
+			return err
+		}
 		return markIntentConfirmed(ctx, h.data, intentId)
 	}
 	return nil
@@ -156,6 +164,52 @@ func (h *PublicDistributionIntentHandler) OnActionUpdated(ctx context.Context, i
 	return markIntentConfirmed(ctx, h.data, intentId)
 }
 
+type LaunchCurrencyIntentHandler struct {
+	data ocp_data.Provider
+}
+
+func NewLaunchCurrencyIntentHandler(data ocp_data.Provider) IntentHandler {
+	return &LaunchCurrencyIntentHandler{
+		data: data,
+	}
+}
+
+func (h *LaunchCurrencyIntentHandler) OnActionUpdated(ctx context.Context, intentId string) error {
+	actionRecords, err := h.data.GetAllActionsByIntent(ctx, intentId)
+	if err != nil {
+		return err
+	}
+
+	for _, actionRecord := range actionRecords {
+		// Intent is failed if the currency fails to initialize, which releases
+		// the symbol for another launch. Failed VM and ALT transactions are
+		// retried, since the currency already exists on the blockchain. Once
+		// retries are exhausted, the launch remains pending for manual
+		// intervention.
+		if actionRecord.State == action.StateFailed {
+			err = markCurrencyLaunchFailed(ctx, h.data, intentId)
+			if err == ErrCurrencyLaunchInitialized {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			return markIntentFailed(ctx, h.data, intentId)
+		}
+
+		if actionRecord.State != action.StateConfirmed {
+			return nil
+		}
+	}
+
+	// Intent is confirmed when the currency is initialized and its VM and ALT
+	// are created, at which point the currency metadata is available for use
+	err = onCurrencyLaunched(ctx, h.data, intentId)
+	if err != nil {
+		return err
+	}
+	return markIntentConfirmed(ctx, h.data, intentId)
+}
+
 func validateIntentState(record *intent.Record, states ...intent.State) error {
 	for _, validState := range states {
 		if record.State == validState {
@@ -180,8 +234,15 @@ func markIntentConfirmed(ctx context.Context, data ocp_data.Provider, intentId s
 		return err
 	}
 
-	record.State = intent.StateConfirmed
-	return data.SaveIntent(ctx, record)
+	return data.ExecuteInTx(ctx, sql.LevelDefault, func(ctx context.Context) error {
+		record.State = intent.StateConfirmed
+		err := data.SaveIntent(ctx, record)
+		if err != nil {
+			return err
+		}
+
+		return webhook.Enqueue(ctx, data, webhook.NewIntentEvent(webhook.EventTypeIntentConfirmed, record))
+	})
 }
 
 func markIntentFailed(ctx context.Context, data ocp_data.Provider, intentId string) error {
@@ -199,8 +260,55 @@ func markIntentFailed(ctx context.Context, data ocp_data.Provider, intentId stri
 		return err
 	}
 
-	record.State = intent.StateFailed
-	return data.SaveIntent(ctx, record)
+	return data.ExecuteInTx(ctx, sql.LevelDefault, func(ctx context.Context) error {
+		record.State = intent.StateFailed
+		err := data.SaveIntent(ctx, record)
+		if err != nil {
+			return err
+		}
+
+		if record.IntentType == intent.SendPublicPayment {
+			err = onPaymentRequestFailed(ctx, data, intentId)
+			if err != nil {
+				return err
+			}
+		}
+
+		return webhook.Enqueue(ctx, data, webhook.NewIntentEvent(webhook.EventTypeIntentFailed, record))
+	})
+}
+
+func markIntentRevoked(ctx context.Context, data ocp_data.Provider, intentId string) error {
+	record, err := data.GetIntent(ctx, intentId)
+	if err != nil {
+		return err
+	}
+
+	if record.State == intent.StateRevoked {
+		return nil
+	}
+
+	err = validateIntentState(record, intent.StatePending)
+	if err != nil {
+		return err
+	}
+
+	return data.ExecuteInTx(ctx, sql.LevelDefault, func(ctx context.Context) error {
+		record.State = intent.StateRevoked
+		err := data.SaveIntent(ctx, record)
+		if err != nil {
+			return err
+		}
+
+		if record.IntentType == intent.SendPublicPayment {
+			err = onPaymentRequestFailed(ctx, data, intentId)
+			if err != nil {
+				return err
+			}
+		}
+
+		return webhook.Enqueue(ctx, data, webhook.NewIntentEvent(webhook.EventTypeIntentRevoked, record))
+	})
 }
 func getIntentHandlers(data ocp_data.Provider) map[intent.Type]IntentHandler {
 	handlersByType := make(map[intent.Type]IntentHandler)
@@ -208,5 +316,6 @@ func getIntentHandlers(data ocp_data.Provider) map[intent.Type]IntentHandler {
 	handlersByType[intent.SendPublicPayment] = NewSendPublicPaymentIntentHandler(data)
 	handlersByType[intent.ReceivePaymentsPublicly] = NewReceivePaymentsPubliclyIntentHandler(data)
 	handlersByType[intent.PublicDistribution] = NewPublicDistributionIntentHandler(data)
+	handlersByType[intent.LaunchCurrency] = NewLaunchCurrencyIntentHandler(data)
 	return handlersByType
 }
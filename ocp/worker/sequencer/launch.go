@@ -0,0 +1,340 @@
+package sequencer
+
+import (
+	"context"
+	"crypto/ed25519"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/code-payments/ocp-server/ocp/common"
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/action"
+	"github.com/code-payments/ocp-server/ocp/data/currency"
+	"github.com/code-payments/ocp-server/ocp/data/fulfillment"
+	"github.com/code-payments/ocp-server/ocp/data/launch"
+	"github.com/code-payments/ocp-server/ocp/data/nonce"
+	vm_registry "github.com/code-payments/ocp-server/ocp/data/vm/registry"
+	"github.com/code-payments/ocp-server/solana/currencycreator"
+	"github.com/code-payments/ocp-server/solana/system"
+	timelock_token_v1 "github.com/code-payments/ocp-server/solana/timelock/v1"
+	"github.com/code-payments/ocp-server/solana/vm"
+)
+
+const (
+	maxCurrencyLaunchRetryAttempts = 5
+)
+
+var (
+	ErrCurrencyLaunchInitialized = errors.New("currency launch was initialized on the blockchain")
+)
+
+// getCurrencyLaunchAccounts gets the launch authority, with its private key, and
+// the derived currency accounts for the currency launched by the provided intent
+func getCurrencyLaunchAccounts(ctx context.Context, data ocp_data.Provider, intentId string) (*launch.Record, *common.Account, *common.LaunchpadCurrencyAccounts, error) {
+	launchRecord, err := data.GetCurrencyLaunchByIntent(ctx, intentId)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	vaultRecord, err := data.GetKey(ctx, launchRecord.Authority)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	authority, err := common.NewAccountFromPrivateKeyString(vaultRecord.PrivateKey)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	seed, err := common.NewAccountFromPublicKeyString(launchRecord.Seed)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	currencyAccounts, err := common.DeriveLaunchpadCurrencyAccounts(authority, launchRecord.Name, seed)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if currencyAccounts.Mint.PublicKey().ToBase58() != launchRecord.Mint {
+		return nil, nil, nil, errors.New("unexpected launched mint address")
+	}
+
+	return launchRecord, authority, currencyAccounts, nil
+}
+
+// getLaunchedCurrencyVmConfig gets the config for the VM of a launched currency.
+// The launch authority is also the authority of the currency's VM.
+func getLaunchedCurrencyVmConfig(authority *common.Account, currencyAccounts *common.LaunchpadCurrencyAccounts) (*common.VmConfig, error) {
+	vmAddress, _, err := vm.GetVmAddress(&vm.GetVmAddressArgs{
+		Mint:         currencyAccounts.Mint.PublicKey().ToBytes(),
+		VmAuthority:  authority.PublicKey().ToBytes(),
+		LockDuration: timelock_token_v1.DefaultNumDaysLocked,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	omnibusAddress, _, err := vm.GetVmObnibusAddress(&vm.GetVmObnibusAddressArgs{
+		Vm: vmAddress,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	vmAccount, err := common.NewAccountFromPublicKeyBytes(vmAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	omnibusAccount, err := common.NewAccountFromPublicKeyBytes(omnibusAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.VmConfig{
+		Authority:          authority,
+		Vm:                 vmAccount,
+		Omnibus:            omnibusAccount,
+		Mint:               currencyAccounts.Mint,
+		LockDurationInDays: timelock_token_v1.DefaultNumDaysLocked,
+	}, nil
+}
+
+// getLaunchedCurrencyAltAddresses gets the addresses stored in the ALT for a
+// launched currency, which must match the layout expected by transaction.GetAltForMint.
+func getLaunchedCurrencyAltAddresses(authority *common.Account, currencyAccounts *common.LaunchpadCurrencyAccounts) ([]ed25519.PublicKey, error) {
+	vmConfig, err := getLaunchedCurrencyVmConfig(authority, currencyAccounts)
+	if err != nil {
+		return nil, err
+	}
+
+	return []ed25519.PublicKey{
+		vmConfig.Vm.PublicKey().ToBytes(),
+		vmConfig.Omnibus.PublicKey().ToBytes(),
+		currencyAccounts.Mint.PublicKey().ToBytes(),
+		currencyAccounts.LiquidityPool.PublicKey().ToBytes(),
+		currencyAccounts.VaultBase.PublicKey().ToBytes(),
+		currencyAccounts.VaultMint.PublicKey().ToBytes(),
+		common.CoreMintAccount.PublicKey().ToBytes(),
+		system.RentSysVar,
+		system.RecentBlockhashesSysVar,
+	}, nil
+}
+
+// isCurrencyLaunchInitialized determines whether the currency for a launch has
+// been initialized on the blockchain
+func isCurrencyLaunchInitialized(ctx context.Context, data ocp_data.Provider, intentId string) (bool, error) {
+	initializeActionRecord, err := data.GetActionById(ctx, intentId, 0)
+	if err != nil {
+		return false, err
+	}
+
+	if initializeActionRecord.ActionType != action.InitializeLaunchpadCurrency {
+		return false, errors.New("unexpected action type for initializing the currency")
+	}
+
+	return initializeActionRecord.State == action.StateConfirmed, nil
+}
+
+// onCurrencyLaunched registers the VM and persists the currency metadata for a
+// launch whose initialization, VM and ALT transactions have been finalized
+func onCurrencyLaunched(ctx context.Context, data ocp_data.Provider, intentId string) error {
+	launchRecord, authority, currencyAccounts, err := getCurrencyLaunchAccounts(ctx, data, intentId)
+	if err != nil {
+		return err
+	}
+
+	if launchRecord.State == launch.StateFinalized {
+		return nil
+	} else if launchRecord.State != launch.StatePending {
+		return errors.Errorf("unexpected currency launch state: %s", launchRecord.State)
+	}
+
+	if len(launchRecord.Alt) == 0 {
+		return errors.New("currency launch alt is not set")
+	}
+
+	metadataRecord := &currency.MetadataRecord{
+		Name:        launchRecord.Name,
+		Symbol:      launchRecord.Symbol,
+		Description: launchRecord.Description,
+		ImageUrl:    launchRecord.ImageUrl,
+
+		Seed: launchRecord.Seed,
+
+		Authority: authority.PublicKey().ToBase58(),
+
+		Mint:     currencyAccounts.Mint.PublicKey().ToBase58(),
+		MintBump: currencyAccounts.MintBump,
+		Decimals: currencycreator.DefaultMintDecimals,
+
+		CurrencyConfig:     currencyAccounts.CurrencyConfig.PublicKey().ToBase58(),
+		CurrencyConfigBump: currencyAccounts.CurrencyConfigBump,
+
+		LiquidityPool:     currencyAccounts.LiquidityPool.PublicKey().ToBase58(),
+		LiquidityPoolBump: currencyAccounts.LiquidityPoolBump,
+
+		VaultMint:     currencyAccounts.VaultMint.PublicKey().ToBase58(),
+		VaultMintBump: currencyAccounts.VaultMintBump,
+
+		VaultCore:     currencyAccounts.VaultBase.PublicKey().ToBase58(),
+		VaultCoreBump: currencyAccounts.VaultBaseBump,
+
+		SellFeeBps: launchRecord.SellFeeBps,
+
+		Alt: launchRecord.Alt,
+
+		CreatedBy: launchRecord.CreatedBy,
+		CreatedAt: time.Now(),
+	}
+
+	vmConfig, err := getLaunchedCurrencyVmConfig(authority, currencyAccounts)
+	if err != nil {
+		return err
+	}
+
+	vmRecord := &vm_registry.Record{
+		Mint: vmConfig.Mint.PublicKey().ToBase58(),
+
+		Vm:        vmConfig.Vm.PublicKey().ToBase58(),
+		Omnibus:   vmConfig.Omnibus.PublicKey().ToBase58(),
+		Authority: vmConfig.Authority.PublicKey().ToBase58(),
+
+		LockDurationInDays: vmConfig.LockDurationInDays,
+
+		Alt: launchRecord.Alt,
+
+		CreatedAt: time.Now(),
+	}
+
+	return data.ExecuteInTx(ctx, sql.LevelDefault, func(ctx context.Context) error {
+		// The VM must be registered before the currency is usable, otherwise
+		// its virtual accounts can't be resolved by mint
+		err = data.RegisterVm(ctx, vmRecord)
+		if err != nil && err != vm_registry.ErrAlreadyExists {
+			return err
+		}
+
+		err = data.PutCurrencyMetadata(ctx, metadataRecord)
+		if err != nil && err != currency.ErrExists {
+			return err
+		}
+
+		launchRecord.State = launch.StateFinalized
+		return data.UpdateCurrencyLaunch(ctx, launchRecord)
+	})
+}
+
+// markCurrencyLaunchFailed fails a launch, which releases its symbol. It's only
+// safe to do so when nothing was created on the blockchain, so launches where the
+// currency was initialized remain pending.
+func markCurrencyLaunchFailed(ctx context.Context, data ocp_data.Provider, intentId string) error {
+	launchRecord, err := data.GetCurrencyLaunchByIntent(ctx, intentId)
+	if err != nil {
+		return err
+	}
+
+	if launchRecord.State == launch.StateFailed {
+		return nil
+	} else if launchRecord.State != launch.StatePending {
+		return errors.Errorf("unexpected currency launch state: %s", launchRecord.State)
+	}
+
+	initializeActionRecord, err := data.GetActionById(ctx, intentId, 0)
+	if err != nil {
+		return err
+	}
+	if initializeActionRecord.ActionType != action.InitializeLaunchpadCurrency {
+		return errors.New("unexpected action type for initializing the currency")
+	}
+	if initializeActionRecord.State != action.StatePending && initializeActionRecord.State != action.StateFailed {
+		return ErrCurrencyLaunchInitialized
+	}
+
+	launchRecord.State = launch.StateFailed
+	return data.UpdateCurrencyLaunch(ctx, launchRecord)
+}
+
+// retryCurrencyLaunchFulfillment schedules a failed fulfillment that must
+// complete after the currency was initialized with a new on demand transaction.
+// The failed transaction advanced its nonce, so it's released for the nonce
+// worker to refresh. Retries are capped per launch, after which the fulfillment
+// is not recovered and requires manual intervention.
+func retryCurrencyLaunchFulfillment(ctx context.Context, data ocp_data.Provider, fulfillmentRecord *fulfillment.Record) (bool, error) {
+	switch fulfillmentRecord.FulfillmentType {
+	case fulfillment.InitializeLaunchpadCurrencyVm, fulfillment.CreateLaunchpadCurrencyAlt:
+	default:
+		return false, errors.New("invalid fulfillment type")
+	}
+
+	if fulfillmentRecord.State != fulfillment.StatePending {
+		return false, errors.New("fulfillment is in unexpected state")
+	}
+
+	launchRecord, err := data.GetCurrencyLaunchByIntent(ctx, fulfillmentRecord.Intent)
+	if err != nil {
+		return false, err
+	}
+
+	if launchRecord.RetryAttempts >= maxCurrencyLaunchRetryAttempts {
+		recordCurrencyLaunchRetriesExhaustedEvent(ctx, launchRecord, fulfillmentRecord)
+		return false, nil
+	}
+
+	err = data.ExecuteInTx(ctx, sql.LevelDefault, func(ctx context.Context) error {
+		if fulfillmentRecord.Nonce != nil {
+			nonceRecord, err := data.GetNonce(ctx, *fulfillmentRecord.Nonce)
+			if err != nil {
+				return err
+			}
+
+			if *fulfillmentRecord.Signature != nonceRecord.Signature {
+				return errors.New("unexpected nonce signature")
+			}
+
+			if *fulfillmentRecord.Blockhash != nonceRecord.Blockhash {
+				return errors.New("unexpected nonce blockhash")
+			}
+
+			if nonceRecord.State != nonce.StateReserved {
+				return errors.New("unexpected nonce state")
+			}
+
+			nonceRecord.State = nonce.StateReleased
+			err = data.SaveNonce(ctx, nonceRecord)
+			if err != nil {
+				return err
+			}
+		}
+
+		launchRecord.RetryAttempts++
+		err = data.UpdateCurrencyLaunch(ctx, launchRecord)
+		if err != nil {
+			return err
+		}
+
+		fulfillmentRecord.Signature = nil
+		fulfillmentRecord.Nonce = nil
+		fulfillmentRecord.Blockhash = nil
+		fulfillmentRecord.Data = nil
+		return data.UpdateFulfillment(ctx, fulfillmentRecord)
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// saveCurrencyLaunchAlt records the ALT for a launch at the time its
+// transaction is made, since its address depends on the recent slot used.
+func saveCurrencyLaunchAlt(ctx context.Context, data ocp_data.Provider, launchRecord *launch.Record, alt *common.Account) error {
+	if launchRecord.State != launch.StatePending {
+		return errors.Errorf("unexpected currency launch state: %s", launchRecord.State)
+	}
+
+	launchRecord.Alt = alt.PublicKey().ToBase58()
+	return data.UpdateCurrencyLaunch(ctx, launchRecord)
+}
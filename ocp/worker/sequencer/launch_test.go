@@ -0,0 +1,241 @@
+package sequencer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/code-payments/ocp-server/ocp/common"
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/action"
+	"github.com/code-payments/ocp-server/ocp/data/fulfillment"
+	"github.com/code-payments/ocp-server/ocp/data/intent"
+	"github.com/code-payments/ocp-server/ocp/data/launch"
+	"github.com/code-payments/ocp-server/ocp/data/nonce"
+	"github.com/code-payments/ocp-server/ocp/data/vault"
+	"github.com/code-payments/ocp-server/pointer"
+	"github.com/code-payments/ocp-server/solana/currencycreator"
+	"github.com/code-payments/ocp-server/testutil"
+)
+
+func TestMarkCurrencyLaunchFailed(t *testing.T) {
+	for _, tc := range []struct {
+		initializeState action.State
+		expectedErr     error
+		expectedState   launch.State
+	}{
+		{action.StatePending, nil, launch.StateFailed},
+		{action.StateFailed, nil, launch.StateFailed},
+		{action.StateConfirmed, ErrCurrencyLaunchInitialized, launch.StatePending},
+	} {
+		env := setupLaunchEnv(t)
+
+		launchRecord := env.createPendingLaunch(t, tc.initializeState)
+
+		assert.Equal(t, tc.expectedErr, markCurrencyLaunchFailed(env.ctx, env.data, launchRecord.Intent))
+
+		actual, err := env.data.GetCurrencyLaunchByIntent(env.ctx, launchRecord.Intent)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expectedState, actual.State)
+	}
+}
+
+func TestRetryCurrencyLaunchAlt(t *testing.T) {
+	env := setupLaunchEnv(t)
+
+	launchRecord := env.createPendingLaunch(t, action.StateConfirmed)
+
+	signature := testutil.NewRandomAccount(t).PublicKey().ToBase58()
+	nonceRecord := &nonce.Record{
+		Address:             testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+		Authority:           testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+		Blockhash:           testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+		Environment:         nonce.EnvironmentSolana,
+		EnvironmentInstance: nonce.EnvironmentInstanceSolanaMainnet,
+		Purpose:             nonce.PurposeOnDemandTransaction,
+		State:               nonce.StateReserved,
+		Signature:           signature,
+	}
+	require.NoError(t, env.data.SaveNonce(env.ctx, nonceRecord))
+
+	fulfillmentRecord := &fulfillment.Record{
+		Intent:          launchRecord.Intent,
+		IntentType:      intent.LaunchCurrency,
+		ActionId:        1,
+		ActionType:      action.CreateLaunchpadCurrencyAlt,
+		FulfillmentType: fulfillment.CreateLaunchpadCurrencyAlt,
+		Data:            []byte("data"),
+		Signature:       pointer.String(signature),
+		Nonce:           pointer.String(nonceRecord.Address),
+		Blockhash:       pointer.String(nonceRecord.Blockhash),
+		Source:          launchRecord.Authority,
+		State:           fulfillment.StatePending,
+	}
+	require.NoError(t, env.data.PutAllFulfillments(env.ctx, fulfillmentRecord))
+
+	recovered, err := NewCreateLaunchpadCurrencyAltFulfillmentHandler(env.data).OnFailure(env.ctx, fulfillmentRecord, nil)
+	require.NoError(t, err)
+	assert.True(t, recovered)
+
+	actual, err := env.data.GetFulfillmentById(env.ctx, fulfillmentRecord.Id)
+	require.NoError(t, err)
+	assert.Equal(t, fulfillment.StatePending, actual.State)
+	assert.Nil(t, actual.Signature)
+	assert.Nil(t, actual.Nonce)
+	assert.Nil(t, actual.Blockhash)
+	assert.Empty(t, actual.Data)
+
+	actualNonce, err := env.data.GetNonce(env.ctx, nonceRecord.Address)
+	require.NoError(t, err)
+	assert.Equal(t, nonce.StateReleased, actualNonce.State)
+
+	actualLaunch, err := env.data.GetCurrencyLaunchByIntent(env.ctx, launchRecord.Intent)
+	require.NoError(t, err)
+	assert.Equal(t, launch.StatePending, actualLaunch.State)
+	assert.EqualValues(t, 1, actualLaunch.RetryAttempts)
+}
+
+func TestRetryCurrencyLaunchAlt_AttemptsExhausted(t *testing.T) {
+	env := setupLaunchEnv(t)
+
+	launchRecord := env.createPendingLaunch(t, action.StateConfirmed)
+
+	fulfillmentRecord := &fulfillment.Record{
+		Intent:          launchRecord.Intent,
+		IntentType:      intent.LaunchCurrency,
+		ActionId:        1,
+		ActionType:      action.CreateLaunchpadCurrencyAlt,
+		FulfillmentType: fulfillment.CreateLaunchpadCurrencyAlt,
+		Source:          launchRecord.Authority,
+		State:           fulfillment.StatePending,
+	}
+	require.NoError(t, env.data.PutAllFulfillments(env.ctx, fulfillmentRecord))
+
+	signature := testutil.NewRandomAccount(t).PublicKey().ToBase58()
+
+	handler := NewCreateLaunchpadCurrencyAltFulfillmentHandler(env.data)
+	for i := 0; i < maxCurrencyLaunchRetryAttempts; i++ {
+		fulfillmentRecord.Signature = pointer.String(signature)
+		require.NoError(t, env.data.UpdateFulfillment(env.ctx, fulfillmentRecord))
+
+		recovered, err := handler.OnFailure(env.ctx, fulfillmentRecord, nil)
+		require.NoError(t, err)
+		assert.True(t, recovered)
+	}
+
+	fulfillmentRecord.Signature = pointer.String(signature)
+	require.NoError(t, env.data.UpdateFulfillment(env.ctx, fulfillmentRecord))
+
+	recovered, err := handler.OnFailure(env.ctx, fulfillmentRecord, nil)
+	require.NoError(t, err)
+	assert.False(t, recovered)
+
+	actual, err := env.data.GetFulfillmentById(env.ctx, fulfillmentRecord.Id)
+	require.NoError(t, err)
+	assert.Equal(t, signature, *actual.Signature)
+
+	actualLaunch, err := env.data.GetCurrencyLaunchByIntent(env.ctx, launchRecord.Intent)
+	require.NoError(t, err)
+	assert.Equal(t, launch.StatePending, actualLaunch.State)
+	assert.EqualValues(t, maxCurrencyLaunchRetryAttempts, actualLaunch.RetryAttempts)
+}
+
+func TestOnCurrencyLaunched(t *testing.T) {
+	env := setupLaunchEnv(t)
+
+	vaultRecord, err := vault.CreateKey()
+	require.NoError(t, err)
+	require.NoError(t, env.data.SaveKey(env.ctx, vaultRecord))
+
+	authority, err := common.NewAccountFromPrivateKeyString(vaultRecord.PrivateKey)
+	require.NoError(t, err)
+
+	seed := testutil.NewRandomAccount(t)
+
+	currencyAccounts, err := common.DeriveLaunchpadCurrencyAccounts(authority, "Test Currency", seed)
+	require.NoError(t, err)
+
+	launchRecord := &launch.Record{
+		Intent:      testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+		Name:        "Test Currency",
+		Symbol:      "TEST",
+		Description: "Test currency",
+		ImageUrl:    "https://example.com/test.png",
+		Seed:        seed.PublicKey().ToBase58(),
+		Authority:   authority.PublicKey().ToBase58(),
+		Mint:        currencyAccounts.Mint.PublicKey().ToBase58(),
+		SellFeeBps:  currencycreator.DefaultSellFeeBps,
+		Alt:         testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+		CreatedBy:   testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+		State:       launch.StatePending,
+		CreatedAt:   time.Now(),
+	}
+	require.NoError(t, env.data.PutCurrencyLaunch(env.ctx, launchRecord))
+
+	expectedVmConfig, err := getLaunchedCurrencyVmConfig(authority, currencyAccounts)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, onCurrencyLaunched(env.ctx, env.data, launchRecord.Intent))
+
+		vmRecord, err := env.data.GetRegisteredVmByMint(env.ctx, launchRecord.Mint)
+		require.NoError(t, err)
+		assert.Equal(t, expectedVmConfig.Vm.PublicKey().ToBase58(), vmRecord.Vm)
+		assert.Equal(t, expectedVmConfig.Omnibus.PublicKey().ToBase58(), vmRecord.Omnibus)
+		assert.Equal(t, launchRecord.Authority, vmRecord.Authority)
+		assert.Equal(t, expectedVmConfig.LockDurationInDays, vmRecord.LockDurationInDays)
+		assert.Equal(t, launchRecord.Alt, vmRecord.Alt)
+
+		metadataRecord, err := env.data.GetCurrencyMetadata(env.ctx, launchRecord.Mint)
+		require.NoError(t, err)
+		assert.Equal(t, launchRecord.Symbol, metadataRecord.Symbol)
+
+		actualLaunch, err := env.data.GetCurrencyLaunchByIntent(env.ctx, launchRecord.Intent)
+		require.NoError(t, err)
+		assert.Equal(t, launch.StateFinalized, actualLaunch.State)
+	}
+}
+
+type launchTestEnv struct {
+	ctx  context.Context
+	data ocp_data.Provider
+}
+
+func setupLaunchEnv(t *testing.T) *launchTestEnv {
+	return &launchTestEnv{
+		ctx:  context.Background(),
+		data: ocp_data.NewTestDataProvider(),
+	}
+}
+
+func (e *launchTestEnv) createPendingLaunch(t *testing.T, initializeState action.State) *launch.Record {
+	launchRecord := &launch.Record{
+		Intent:      testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+		Name:        "Test Currency",
+		Symbol:      "TEST",
+		Description: "Test currency",
+		ImageUrl:    "https://example.com/test.png",
+		Seed:        testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+		Authority:   testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+		Mint:        testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+		CreatedBy:   testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+		State:       launch.StatePending,
+		CreatedAt:   time.Now(),
+	}
+	require.NoError(t, e.data.PutCurrencyLaunch(e.ctx, launchRecord))
+
+	actionRecord := &action.Record{
+		Intent:     launchRecord.Intent,
+		IntentType: intent.LaunchCurrency,
+		ActionId:   0,
+		ActionType: action.InitializeLaunchpadCurrency,
+		Source:     launchRecord.Authority,
+		State:      initializeState,
+	}
+	require.NoError(t, e.data.PutAllActions(e.ctx, actionRecord))
+
+	return launchRecord
+}
@@ -7,11 +7,16 @@ import (
 	"github.com/code-payments/ocp-server/metrics"
 	"github.com/code-payments/ocp-server/ocp/common"
 	"github.com/code-payments/ocp-server/ocp/data/fulfillment"
+	"github.com/code-payments/ocp-server/ocp/data/launch"
+	"github.com/code-payments/ocp-server/ocp/subsidizer"
 )
 
 const (
 	fulfillmentCountEventName  = "FulfillmentCountPollingCheck"
 	subsidizerBalanceEventName = "SubsidizerBalancePollingCheck"
+	subsidizerBudgetEventName  = "SubsidizerBudgetPollingCheck"
+
+	currencyLaunchRetriesExhaustedEventName = "CurrencyLaunchRetriesExhausted"
 )
 
 func (p *runtime) metricsGaugeWorker(ctx context.Context) error {
@@ -44,6 +49,11 @@ func (p *runtime) metricsGaugeWorker(ctx context.Context) error {
 				recordSubsidizerBalanceEvent(ctx, lamports)
 			}
 
+			budgetSnapshot, err := p.budget.GetSnapshot(ctx)
+			if err == nil {
+				recordSubsidizerBudgetEvent(ctx, budgetSnapshot)
+			}
+
 			delay = time.Second - time.Since(start)
 		}
 	}
@@ -62,3 +72,23 @@ func recordSubsidizerBalanceEvent(ctx context.Context, lamports uint64) {
 		"lamports": lamports,
 	})
 }
+
+func recordSubsidizerBudgetEvent(ctx context.Context, snapshot *subsidizer.Snapshot) {
+	metrics.RecordEvent(ctx, subsidizerBudgetEventName, map[string]interface{}{
+		"balance":        snapshot.Balance,
+		"committed":      snapshot.Committed,
+		"reserved":       snapshot.Reserved,
+		"available":      snapshot.Available,
+		"spend_rate":     snapshot.SpendRate,
+		"runway_seconds": uint64(snapshot.Runway.Seconds()),
+	})
+}
+
+func recordCurrencyLaunchRetriesExhaustedEvent(ctx context.Context, launchRecord *launch.Record, fulfillmentRecord *fulfillment.Record) {
+	metrics.RecordEvent(ctx, currencyLaunchRetriesExhaustedEventName, map[string]interface{}{
+		"intent":           launchRecord.Intent,
+		"mint":             launchRecord.Mint,
+		"fulfillment_type": fulfillmentRecord.FulfillmentType.String(),
+		"retry_attempts":   launchRecord.RetryAttempts,
+	})
+}
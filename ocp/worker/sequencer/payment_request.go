@@ -0,0 +1,83 @@
+package sequencer
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/paymentrequest"
+	"github.com/code-payments/ocp-server/ocp/webhook"
+	"github.com/code-payments/ocp-server/pointer"
+)
+
+// onPaymentRequestPaid marks the payment request reserved for the provided
+// intent as paid. It's only called once the intent is confirmed, so a request
+// is never marked paid for funds that didn't move.
+func onPaymentRequestPaid(ctx context.Context, data ocp_data.Provider, intentId string) error {
+	paymentRequestRecord, err := data.GetPaymentRequest(ctx, intentId)
+	if err == paymentrequest.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	// A failed request is still marked paid when an operator recovers its
+	// intent, since funds moved after all
+	switch paymentRequestRecord.State {
+	case paymentrequest.StatePaid:
+		return nil
+	case paymentrequest.StatePending, paymentrequest.StateFailed:
+	default:
+		return errors.Errorf("unexpected payment request state: %s", paymentRequestRecord.State)
+	}
+
+	intentRecord, err := data.GetIntent(ctx, intentId)
+	if err != nil {
+		return err
+	}
+
+	paymentRequestRecord.State = paymentrequest.StatePaid
+	paymentRequestRecord.PaidBy = pointer.String(intentRecord.InitiatorOwnerAccount)
+	paymentRequestRecord.PaidAt = pointer.Time(time.Now())
+
+	return data.ExecuteInTx(ctx, sql.LevelDefault, func(ctx context.Context) error {
+		err := data.UpdatePaymentRequest(ctx, paymentRequestRecord)
+		if err != nil {
+			return err
+		}
+
+		return webhook.Enqueue(ctx, data, webhook.NewPaymentRequestPaidEvent(paymentRequestRecord))
+	})
+}
+
+// onPaymentRequestFailed marks the payment request reserved for the provided
+// intent as failed when the intent fails or is revoked. The intent ID can't be
+// reused, so the merchant must issue a new request with a new rendezvous key.
+// It must be called within the DB transaction that updates the intent state.
+func onPaymentRequestFailed(ctx context.Context, data ocp_data.Provider, intentId string) error {
+	paymentRequestRecord, err := data.GetPaymentRequest(ctx, intentId)
+	if err == paymentrequest.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	switch paymentRequestRecord.State {
+	case paymentrequest.StateFailed:
+		return nil
+	case paymentrequest.StatePending:
+	default:
+		return errors.Errorf("unexpected payment request state: %s", paymentRequestRecord.State)
+	}
+
+	paymentRequestRecord.State = paymentrequest.StateFailed
+	err = data.UpdatePaymentRequest(ctx, paymentRequestRecord)
+	if err != nil {
+		return err
+	}
+
+	return webhook.Enqueue(ctx, data, webhook.NewPaymentRequestFailedEvent(paymentRequestRecord))
+}
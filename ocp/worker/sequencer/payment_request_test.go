@@ -0,0 +1,168 @@
+package sequencer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/code-payments/ocp-server/currency"
+	"github.com/code-payments/ocp-server/ocp/common"
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/action"
+	"github.com/code-payments/ocp-server/ocp/data/intent"
+	"github.com/code-payments/ocp-server/ocp/data/paymentrequest"
+	webhook_data "github.com/code-payments/ocp-server/ocp/data/webhook"
+	"github.com/code-payments/ocp-server/ocp/webhook"
+	"github.com/code-payments/ocp-server/testutil"
+)
+
+func TestSendPublicPaymentIntentHandler_PaymentRequest(t *testing.T) {
+	webhook.InjectTestEnabled(true)
+	defer webhook.InjectTestEnabled(false)
+
+	for _, tc := range []struct {
+		finalActionState    action.State
+		expectedIntentState intent.State
+		expectedState       paymentrequest.State
+	}{
+		{action.StatePending, intent.StatePending, paymentrequest.StatePending},
+		{action.StateFailed, intent.StateFailed, paymentrequest.StateFailed},
+		{action.StateConfirmed, intent.StateConfirmed, paymentrequest.StatePaid},
+	} {
+		env := setupPaymentRequestEnv(t)
+
+		intentRecord, paymentRequestRecord := env.createPaymentRequestIntent(t, tc.finalActionState)
+
+		require.NoError(t, NewSendPublicPaymentIntentHandler(env.data).OnActionUpdated(env.ctx, intentRecord.IntentId))
+
+		actualIntent, err := env.data.GetIntent(env.ctx, intentRecord.IntentId)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expectedIntentState, actualIntent.State)
+
+		actual, err := env.data.GetPaymentRequest(env.ctx, paymentRequestRecord.Intent)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expectedState, actual.State)
+
+		_, err = env.data.GetWebhookEvent(env.ctx, fmt.Sprintf("%s:%s", webhook.EventTypePaymentRequestPaid, paymentRequestRecord.Intent))
+		if tc.expectedState == paymentrequest.StatePaid {
+			require.NoError(t, err)
+			require.NotNil(t, actual.PaidBy)
+			assert.Equal(t, intentRecord.InitiatorOwnerAccount, *actual.PaidBy)
+			assert.NotNil(t, actual.PaidAt)
+		} else {
+			assert.Equal(t, webhook_data.ErrNotFound, err)
+			assert.Nil(t, actual.PaidBy)
+			assert.Nil(t, actual.PaidAt)
+		}
+
+		_, err = env.data.GetWebhookEvent(env.ctx, fmt.Sprintf("%s:%s", webhook.EventTypePaymentRequestFailed, paymentRequestRecord.Intent))
+		if tc.expectedState == paymentrequest.StateFailed {
+			require.NoError(t, err)
+		} else {
+			assert.Equal(t, webhook_data.ErrNotFound, err)
+		}
+	}
+}
+
+func TestSendPublicPaymentIntentHandler_PaymentRequest_Revoked(t *testing.T) {
+	webhook.InjectTestEnabled(true)
+	defer webhook.InjectTestEnabled(false)
+
+	env := setupPaymentRequestEnv(t)
+
+	intentRecord, paymentRequestRecord := env.createPaymentRequestIntent(t, action.StateUnknown)
+
+	require.NoError(t, markIntentRevoked(env.ctx, env.data, intentRecord.IntentId))
+
+	actual, err := env.data.GetPaymentRequest(env.ctx, paymentRequestRecord.Intent)
+	require.NoError(t, err)
+	assert.Equal(t, paymentrequest.StateFailed, actual.State)
+	assert.Nil(t, actual.PaidBy)
+	assert.Nil(t, actual.PaidAt)
+
+	_, err = env.data.GetWebhookEvent(env.ctx, fmt.Sprintf("%s:%s", webhook.EventTypePaymentRequestFailed, paymentRequestRecord.Intent))
+	require.NoError(t, err)
+
+	_, err = env.data.GetWebhookEvent(env.ctx, fmt.Sprintf("%s:%s", webhook.EventTypePaymentRequestPaid, paymentRequestRecord.Intent))
+	assert.Equal(t, webhook_data.ErrNotFound, err)
+}
+
+type paymentRequestTestEnv struct {
+	ctx  context.Context
+	data ocp_data.Provider
+}
+
+func setupPaymentRequestEnv(t *testing.T) *paymentRequestTestEnv {
+	return &paymentRequestTestEnv{
+		ctx:  context.Background(),
+		data: ocp_data.NewTestDataProvider(),
+	}
+}
+
+func (e *paymentRequestTestEnv) createPaymentRequestIntent(t *testing.T, finalActionState action.State) (*intent.Record, *paymentrequest.Record) {
+	rendezvousKey := testutil.NewRandomAccount(t).PublicKey().ToBase58()
+	destinationOwner := testutil.NewRandomAccount(t).PublicKey().ToBase58()
+	destination := testutil.NewRandomAccount(t).PublicKey().ToBase58()
+	source := testutil.NewRandomAccount(t).PublicKey().ToBase58()
+
+	paymentRequestRecord := &paymentrequest.Record{
+		Intent:                  rendezvousKey,
+		Owner:                   destinationOwner,
+		Mint:                    common.CoreMintAccount.PublicKey().ToBase58(),
+		DestinationTokenAccount: destination,
+		ExchangeCurrency:        currency.USD,
+		NativeAmount:            1,
+		ExpiresAt:               time.Now().Add(time.Hour),
+		State:                   paymentrequest.StatePending,
+		CreatedAt:               time.Now(),
+	}
+	require.NoError(t, e.data.PutPaymentRequest(e.ctx, paymentRequestRecord))
+
+	intentRecord := &intent.Record{
+		IntentId:              rendezvousKey,
+		IntentType:            intent.SendPublicPayment,
+		MintAccount:           common.CoreMintAccount.PublicKey().ToBase58(),
+		InitiatorOwnerAccount: testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+		SendPublicPaymentMetadata: &intent.SendPublicPaymentMetadata{
+			DestinationOwnerAccount: destinationOwner,
+			DestinationTokenAccount: destination,
+			Quantity:                1,
+			ExchangeCurrency:        currency.USD,
+			ExchangeRate:            1,
+			NativeAmount:            1,
+			UsdMarketValue:          1,
+		},
+		State: intent.StatePending,
+	}
+	require.NoError(t, e.data.SaveIntent(e.ctx, intentRecord))
+
+	actionRecords := []*action.Record{
+		{
+			Intent:      rendezvousKey,
+			IntentType:  intent.SendPublicPayment,
+			ActionId:    0,
+			ActionType:  action.NoPrivacyTransfer,
+			Source:      source,
+			Destination: &destination,
+			Quantity:    &intentRecord.SendPublicPaymentMetadata.Quantity,
+			State:       action.StateConfirmed,
+		},
+		{
+			Intent:      rendezvousKey,
+			IntentType:  intent.SendPublicPayment,
+			ActionId:    1,
+			ActionType:  action.NoPrivacyTransfer,
+			Source:      source,
+			Destination: &destination,
+			Quantity:    &intentRecord.SendPublicPaymentMetadata.Quantity,
+			State:       finalActionState,
+		},
+	}
+	require.NoError(t, e.data.PutAllActions(e.ctx, actionRecords...))
+
+	return intentRecord, paymentRequestRecord
+}
@@ -0,0 +1,224 @@
+package sequencer
+
+import (
+	"context"
+	"errors"
+
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/action"
+	"github.com/code-payments/ocp-server/ocp/data/batch"
+	"github.com/code-payments/ocp-server/ocp/data/fulfillment"
+	"github.com/code-payments/ocp-server/ocp/data/intent"
+)
+
+var (
+	ErrFulfillmentNotFailed           = errors.New("fulfillment is not in the failed state")
+	ErrBatchedFulfillmentNotRetriable = errors.New("batched fulfillment cannot be retried")
+)
+
+// The functions below are used by operators to manually recover a failed
+// fulfillment, which otherwise trips the account, intent and global circuit
+// breakers indefinitely. They're expected to be called within a DB transaction
+// that also records an audit trail of the resolution.
+
+// RetryFailedFulfillment returns a failed fulfillment to the unknown state, so
+// it's rescheduled with a new on demand transaction. The action and intent are
+// moved back to pending.
+//
+// Batched fulfillments can only be retried once their batch has failed, since
+// they're otherwise still part of a shared transaction.
+func RetryFailedFulfillment(ctx context.Context, data ocp_data.Provider, record *fulfillment.Record) error {
+	if record.State != fulfillment.StateFailed {
+		return ErrFulfillmentNotFailed
+	}
+
+	batchRecord, err := data.GetFulfillmentBatchByFulfillment(ctx, record.Id)
+	if err == nil && batchRecord.State != batch.StateFailed {
+		return ErrBatchedFulfillmentNotRetriable
+	} else if err != nil && err != batch.ErrNotFound {
+		return err
+	}
+
+	err = markActionPendingForRecovery(ctx, data, record.Intent, record.ActionId)
+	if err != nil {
+		return err
+	}
+
+	err = markIntentPendingForRecovery(ctx, data, record.Intent)
+	if err != nil {
+		return err
+	}
+
+	// The nonce for the failed transaction was already released when the
+	// fulfillment was marked as failed, so a new one is selected when the
+	// transaction is made on demand.
+	record.Signature = nil
+	record.Nonce = nil
+	record.Blockhash = nil
+	record.Data = nil
+	record.DisableActiveScheduling = false
+	record.State = fulfillment.StateUnknown
+	return data.UpdateFulfillment(ctx, record)
+}
+
+// RevokeFailedFulfillment marks a failed fulfillment and its action as revoked.
+// The intent state is left as is.
+func RevokeFailedFulfillment(ctx context.Context, data ocp_data.Provider, record *fulfillment.Record) error {
+	if record.State != fulfillment.StateFailed {
+		return ErrFulfillmentNotFailed
+	}
+
+	err := markActionForRecovery(ctx, data, record.Intent, record.ActionId, action.StateRevoked)
+	if err != nil {
+		return err
+	}
+
+	record.State = fulfillment.StateRevoked
+	return data.UpdateFulfillment(ctx, record)
+}
+
+// ResolveFailedFulfillment marks a failed fulfillment and its action as
+// confirmed, after an operator has verified the fulfillment's intended effect
+// was achieved on the blockchain by other means. The intent is re-evaluated
+// against its updated set of actions.
+func ResolveFailedFulfillment(ctx context.Context, data ocp_data.Provider, record *fulfillment.Record) error {
+	if record.State != fulfillment.StateFailed {
+		return ErrFulfillmentNotFailed
+	}
+
+	intentHandler, ok := getIntentHandlers(data)[record.IntentType]
+	if !ok {
+		return errors.New("no intent handler for intent type")
+	}
+
+	err := markActionForRecovery(ctx, data, record.Intent, record.ActionId, action.StateConfirmed)
+	if err != nil {
+		return err
+	}
+
+	err = markIntentPendingForRecovery(ctx, data, record.Intent)
+	if err != nil {
+		return err
+	}
+
+	err = intentHandler.OnActionUpdated(ctx, record.Intent)
+	if err != nil {
+		return err
+	}
+
+	record.State = fulfillment.StateConfirmed
+	return data.UpdateFulfillment(ctx, record)
+}
+
+func markActionPendingForRecovery(ctx context.Context, data ocp_data.Provider, intentId string, actionId uint32) error {
+	return markActionForRecovery(ctx, data, intentId, actionId, action.StatePending)
+}
+
+func markActionForRecovery(ctx context.Context, data ocp_data.Provider, intentId string, actionId uint32, state action.State) error {
+	record, err := data.GetActionById(ctx, intentId, actionId)
+	if err != nil {
+		return err
+	}
+
+	if record.State == state {
+		return nil
+	}
+
+	// The action may not have been marked failed if the fulfillment failed
+	// before its action handler could run
+	err = validateActionState(record, action.StatePending, action.StateFailed)
+	if err != nil {
+		return err
+	}
+
+	record.State = state
+	return data.UpdateAction(ctx, record)
+}
+
+func markIntentPendingForRecovery(ctx context.Context, data ocp_data.Provider, intentId string) error {
+	record, err := data.GetIntent(ctx, intentId)
+	if err != nil {
+		return err
+	}
+
+	if record.State != intent.StateFailed {
+		return nil
+	}
+
+	record.State = intent.StatePending
+	return data.SaveIntent(ctx, record)
+}
+
+// CircuitBreakerStatus is a snapshot of the circuit breakers that gate
+// scheduling for a fulfillment
+type CircuitBreakerStatus struct {
+	GlobalFailedFulfillments    uint64
+	MaxGlobalFailedFulfillments uint64
+	IsGlobalTripped             bool
+
+	Intent ScopedCircuitBreakerStatus
+
+	// Accounts are the circuit breakers for the source and destination
+	Accounts []ScopedCircuitBreakerStatus
+}
+
+// ScopedCircuitBreakerStatus is the circuit breaker status for an intent or
+// account
+type ScopedCircuitBreakerStatus struct {
+	Id                 string
+	FailedFulfillments uint64
+	IsTripped          bool
+}
+
+// GetGlobalCircuitBreakerStatus gets the status of the global circuit breaker
+// based on the total failed fulfillment count
+func GetGlobalCircuitBreakerStatus(ctx context.Context, data ocp_data.Provider, configProvider ConfigProvider) (failedFulfillments, maxFailedFulfillments uint64, isTripped bool, err error) {
+	failedFulfillments, err = data.GetFulfillmentCountByState(ctx, fulfillment.StateFailed)
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	maxFailedFulfillments = configProvider().maxGlobalFailedFulfillments.Get(ctx)
+	return failedFulfillments, maxFailedFulfillments, failedFulfillments > maxFailedFulfillments, nil
+}
+
+// GetCircuitBreakerStatus evaluates the same circuit breakers as the scheduler
+// for the provided fulfillment
+func GetCircuitBreakerStatus(ctx context.Context, data ocp_data.Provider, configProvider ConfigProvider, record *fulfillment.Record) (*CircuitBreakerStatus, error) {
+	var res CircuitBreakerStatus
+	var err error
+
+	res.GlobalFailedFulfillments, res.MaxGlobalFailedFulfillments, res.IsGlobalTripped, err = GetGlobalCircuitBreakerStatus(ctx, data, configProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	numFailedFulfillments, err := data.GetFulfillmentCountByIntentAndState(ctx, record.Intent, fulfillment.StateFailed)
+	if err != nil {
+		return nil, err
+	}
+	res.Intent = ScopedCircuitBreakerStatus{
+		Id:                 record.Intent,
+		FailedFulfillments: numFailedFulfillments,
+		IsTripped:          numFailedFulfillments > 0,
+	}
+
+	involvedAccounts := []string{record.Source}
+	if record.Destination != nil {
+		involvedAccounts = append(involvedAccounts, *record.Destination)
+	}
+	for _, account := range involvedAccounts {
+		numFailedFulfillments, err := data.GetFulfillmentCountByStateAndAddress(ctx, fulfillment.StateFailed, account)
+		if err != nil {
+			return nil, err
+		}
+
+		res.Accounts = append(res.Accounts, ScopedCircuitBreakerStatus{
+			Id:                 account,
+			FailedFulfillments: numFailedFulfillments,
+			IsTripped:          numFailedFulfillments > 0,
+		})
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,188 @@
+package sequencer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/action"
+	"github.com/code-payments/ocp-server/ocp/data/batch"
+	"github.com/code-payments/ocp-server/ocp/data/fulfillment"
+	"github.com/code-payments/ocp-server/ocp/data/intent"
+	"github.com/code-payments/ocp-server/pointer"
+	"github.com/code-payments/ocp-server/testutil"
+)
+
+func TestRetryFailedFulfillment(t *testing.T) {
+	env := setupRecoveryEnv(t)
+
+	fulfillmentRecord := env.createFailedFulfillment(t)
+
+	status, err := GetCircuitBreakerStatus(env.ctx, env.data, env.configProvider, fulfillmentRecord)
+	require.NoError(t, err)
+	assert.True(t, status.IsGlobalTripped)
+	assert.True(t, status.Intent.IsTripped)
+	require.Len(t, status.Accounts, 2)
+	assert.True(t, status.Accounts[0].IsTripped)
+	assert.True(t, status.Accounts[1].IsTripped)
+
+	require.NoError(t, RetryFailedFulfillment(env.ctx, env.data, fulfillmentRecord))
+
+	actual, err := env.data.GetFulfillmentById(env.ctx, fulfillmentRecord.Id)
+	require.NoError(t, err)
+	assert.Equal(t, fulfillment.StateUnknown, actual.State)
+	assert.Nil(t, actual.Signature)
+	assert.Nil(t, actual.Nonce)
+	assert.Nil(t, actual.Blockhash)
+	assert.Empty(t, actual.Data)
+	assert.False(t, actual.DisableActiveScheduling)
+
+	env.assertActionState(t, fulfillmentRecord, action.StatePending)
+	env.assertIntentState(t, fulfillmentRecord, intent.StatePending)
+
+	status, err = GetCircuitBreakerStatus(env.ctx, env.data, env.configProvider, fulfillmentRecord)
+	require.NoError(t, err)
+	assert.False(t, status.IsGlobalTripped)
+	assert.False(t, status.Intent.IsTripped)
+	for _, account := range status.Accounts {
+		assert.False(t, account.IsTripped)
+	}
+
+	assert.Equal(t, ErrFulfillmentNotFailed, RetryFailedFulfillment(env.ctx, env.data, actual))
+}
+
+func TestRetryFailedFulfillment_Batched(t *testing.T) {
+	env := setupRecoveryEnv(t)
+
+	fulfillmentRecord1 := env.createFailedFulfillment(t)
+	fulfillmentRecord2 := env.createFailedFulfillment(t)
+
+	require.NoError(t, env.data.PutFulfillmentBatch(env.ctx, &batch.Record{
+		Vm:           "vm",
+		Fulfillments: []uint64{fulfillmentRecord1.Id, fulfillmentRecord2.Id},
+		Signature:    "signature",
+		Nonce:        "nonce",
+		Blockhash:    "blockhash",
+		Data:         []byte("data"),
+		State:        batch.StatePending,
+	}))
+
+	assert.Equal(t, ErrBatchedFulfillmentNotRetriable, RetryFailedFulfillment(env.ctx, env.data, fulfillmentRecord1))
+	env.assertActionState(t, fulfillmentRecord1, action.StateFailed)
+	env.assertIntentState(t, fulfillmentRecord1, intent.StateFailed)
+
+	// Fulfillments in a failed batch are retried with their own transaction
+	batchRecord, err := env.data.GetFulfillmentBatchByFulfillment(env.ctx, fulfillmentRecord1.Id)
+	require.NoError(t, err)
+	batchRecord.State = batch.StateFailed
+	batchRecord.Data = nil
+	require.NoError(t, env.data.UpdateFulfillmentBatch(env.ctx, batchRecord))
+
+	require.NoError(t, RetryFailedFulfillment(env.ctx, env.data, fulfillmentRecord1))
+	env.assertActionState(t, fulfillmentRecord1, action.StatePending)
+	env.assertIntentState(t, fulfillmentRecord1, intent.StatePending)
+}
+
+func TestRevokeFailedFulfillment(t *testing.T) {
+	env := setupRecoveryEnv(t)
+
+	fulfillmentRecord := env.createFailedFulfillment(t)
+
+	require.NoError(t, RevokeFailedFulfillment(env.ctx, env.data, fulfillmentRecord))
+
+	actual, err := env.data.GetFulfillmentById(env.ctx, fulfillmentRecord.Id)
+	require.NoError(t, err)
+	assert.Equal(t, fulfillment.StateRevoked, actual.State)
+
+	env.assertActionState(t, fulfillmentRecord, action.StateRevoked)
+	env.assertIntentState(t, fulfillmentRecord, intent.StateFailed)
+
+	assert.Equal(t, ErrFulfillmentNotFailed, RevokeFailedFulfillment(env.ctx, env.data, actual))
+}
+
+func TestResolveFailedFulfillment(t *testing.T) {
+	env := setupRecoveryEnv(t)
+
+	fulfillmentRecord := env.createFailedFulfillment(t)
+
+	require.NoError(t, ResolveFailedFulfillment(env.ctx, env.data, fulfillmentRecord))
+
+	actual, err := env.data.GetFulfillmentById(env.ctx, fulfillmentRecord.Id)
+	require.NoError(t, err)
+	assert.Equal(t, fulfillment.StateConfirmed, actual.State)
+
+	env.assertActionState(t, fulfillmentRecord, action.StateConfirmed)
+	env.assertIntentState(t, fulfillmentRecord, intent.StateConfirmed)
+
+	assert.Equal(t, ErrFulfillmentNotFailed, ResolveFailedFulfillment(env.ctx, env.data, actual))
+}
+
+type recoveryTestEnv struct {
+	ctx            context.Context
+	data           ocp_data.Provider
+	configProvider ConfigProvider
+}
+
+func setupRecoveryEnv(t *testing.T) *recoveryTestEnv {
+	return &recoveryTestEnv{
+		ctx:  context.Background(),
+		data: ocp_data.NewTestDataProvider(),
+		configProvider: withManualTestOverrides(&testOverrides{
+			maxGlobalFailedFulfillments: 0,
+		}),
+	}
+}
+
+func (e *recoveryTestEnv) createFailedFulfillment(t *testing.T) *fulfillment.Record {
+	intentRecord := &intent.Record{
+		IntentId:              testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+		IntentType:            intent.OpenAccounts,
+		MintAccount:           testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+		InitiatorOwnerAccount: testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+		OpenAccountsMetadata:  &intent.OpenAccountsMetadata{},
+		State:                 intent.StateFailed,
+	}
+	require.NoError(t, e.data.SaveIntent(e.ctx, intentRecord))
+
+	fulfillmentRecord := &fulfillment.Record{
+		Intent:          intentRecord.IntentId,
+		IntentType:      intentRecord.IntentType,
+		ActionId:        0,
+		ActionType:      action.OpenAccount,
+		FulfillmentType: fulfillment.InitializeLockedTimelockAccount,
+		Signature:       pointer.String(testutil.NewRandomAccount(t).PublicKey().ToBase58()),
+		Nonce:           pointer.String(testutil.NewRandomAccount(t).PublicKey().ToBase58()),
+		Blockhash:       pointer.String(testutil.NewRandomAccount(t).PublicKey().ToBase58()),
+		Source:          testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+		Destination:     pointer.String(testutil.NewRandomAccount(t).PublicKey().ToBase58()),
+		State:           fulfillment.StateFailed,
+	}
+	require.NoError(t, e.data.PutAllFulfillments(e.ctx, fulfillmentRecord))
+
+	actionRecord := &action.Record{
+		Intent:     fulfillmentRecord.Intent,
+		IntentType: fulfillmentRecord.IntentType,
+		ActionId:   fulfillmentRecord.ActionId,
+		ActionType: fulfillmentRecord.ActionType,
+		Source:     fulfillmentRecord.Source,
+		State:      action.StateFailed,
+	}
+	require.NoError(t, e.data.PutAllActions(e.ctx, actionRecord))
+
+	return fulfillmentRecord
+}
+
+func (e *recoveryTestEnv) assertActionState(t *testing.T, fulfillmentRecord *fulfillment.Record, expected action.State) {
+	actionRecord, err := e.data.GetActionById(e.ctx, fulfillmentRecord.Intent, fulfillmentRecord.ActionId)
+	require.NoError(t, err)
+	assert.Equal(t, expected, actionRecord.State)
+}
+
+func (e *recoveryTestEnv) assertIntentState(t *testing.T, fulfillmentRecord *fulfillment.Record, expected intent.State) {
+	intentRecord, err := e.data.GetIntent(e.ctx, fulfillmentRecord.Intent)
+	require.NoError(t, err)
+	assert.Equal(t, expected, intentRecord.State)
+}
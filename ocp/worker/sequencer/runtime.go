@@ -2,9 +2,9 @@ package sequencer
 
 import (
 	"context"
-	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
@@ -15,6 +15,8 @@ import (
 	"github.com/code-payments/ocp-server/ocp/data/fulfillment"
 	"github.com/code-payments/ocp-server/ocp/data/intent"
 	"github.com/code-payments/ocp-server/ocp/data/nonce"
+	"github.com/code-payments/ocp-server/ocp/scheduling"
+	"github.com/code-payments/ocp-server/ocp/subsidizer"
 	"github.com/code-payments/ocp-server/ocp/transaction"
 	"github.com/code-payments/ocp-server/ocp/worker"
 )
@@ -27,28 +29,34 @@ var (
 
 type runtime struct {
 	log                       *zap.Logger
+	nodeId                    string
 	conf                      *conf
 	data                      ocp_data.Provider
 	scheduler                 Scheduler
+	notifier                  scheduling.Notifier
 	vmIndexerClient           indexerpb.IndexerClient
 	solanaNoncePool           *transaction.LocalNoncePool
+	budget                    *subsidizer.Budget
 	fulfillmentHandlersByType map[fulfillment.Type]FulfillmentHandler
 	actionHandlersByType      map[action.Type]ActionHandler
 	intentHandlersByType      map[intent.Type]IntentHandler
 }
 
-func New(log *zap.Logger, data ocp_data.Provider, scheduler Scheduler, vmIndexerClient indexerpb.IndexerClient, solanaNoncePool *transaction.LocalNoncePool, configProvider ConfigProvider) (worker.Runtime, error) {
+func New(log *zap.Logger, data ocp_data.Provider, scheduler Scheduler, notifier scheduling.Notifier, vmIndexerClient indexerpb.IndexerClient, solanaNoncePool *transaction.LocalNoncePool, budget *subsidizer.Budget, configProvider ConfigProvider) (worker.Runtime, error) {
 	if err := solanaNoncePool.Validate(nonce.EnvironmentSolana, nonce.EnvironmentInstanceSolanaMainnet, nonce.PurposeOnDemandTransaction); err != nil {
 		return nil, err
 	}
 
 	return &runtime{
 		log:                       log,
+		nodeId:                    uuid.New().String(),
 		conf:                      configProvider(),
 		data:                      data,
 		scheduler:                 scheduler,
+		notifier:                  notifier,
 		vmIndexerClient:           vmIndexerClient,
 		solanaNoncePool:           solanaNoncePool,
+		budget:                    budget,
 		fulfillmentHandlersByType: getFulfillmentHandlers(data, vmIndexerClient),
 		actionHandlersByType:      getActionHandlers(data),
 		intentHandlersByType:      getIntentHandlers(data),
@@ -56,30 +64,6 @@ func New(log *zap.Logger, data ocp_data.Provider, scheduler Scheduler, vmIndexer
 }
 
 func (p *runtime) Start(ctx context.Context, interval time.Duration) error {
-
-	// Setup workers to watch for fulfillment state changes on the Solana side
-	for _, item := range []fulfillment.State{
-		fulfillment.StateUnknown,
-		fulfillment.StatePending,
-
-		// There's no executable logic for these states yet:
-		// fulfillment.StateConfirmed,
-		// fulfillment.StateFailed,
-		// fulfillment.StateRevoked,
-	} {
-		go func(state fulfillment.State) {
-
-			// todo: Note to our future selves that there are some components of
-			//       the scheduler (ie. subsidizer balance checks) that won't
-			//       work perfectly in a multi-threaded or multi-node environment.
-			err := p.worker(ctx, state, interval)
-			if err != nil && err != context.Canceled {
-				p.log.With(zap.Error(err)).Warn(fmt.Sprintf("fulfillment processing loop terminated unexpectedly for state %d", state))
-			}
-
-		}(item)
-	}
-
 	go func() {
 		err := p.metricsGaugeWorker(ctx)
 		if err != nil && err != context.Canceled {
@@ -87,8 +71,19 @@ func (p *runtime) Start(ctx context.Context, interval time.Duration) error {
 		}
 	}()
 
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	}
+	go func() {
+		err := p.schedulingHintWorker(ctx)
+		if err != nil && err != context.Canceled {
+			p.log.With(zap.Error(err)).Warn("scheduling hint loop terminated unexpectedly")
+		}
+	}()
+
+	// Setup workers to watch for fulfillment state changes on the Solana side.
+	// Fulfillments are leased to this node while being processed, so multiple
+	// nodes can share the work.
+	//
+	// todo: Note to our future selves that there are some components of the
+	//       scheduler (ie. subsidizer budget reservations) that are only shared
+	//       in process and won't work perfectly in a multi-node environment.
+	return p.newStateMachine().Start(ctx, interval)
 }
@@ -12,6 +12,7 @@ import (
 	ocp_data "github.com/code-payments/ocp-server/ocp/data"
 	"github.com/code-payments/ocp-server/ocp/data/action"
 	"github.com/code-payments/ocp-server/ocp/data/fulfillment"
+	"github.com/code-payments/ocp-server/ocp/subsidizer"
 	"github.com/code-payments/ocp-server/pointer"
 )
 
@@ -27,6 +28,7 @@ type contextualScheduler struct {
 	log            *zap.Logger
 	data           ocp_data.Provider
 	conf           *conf
+	budget         *subsidizer.Budget
 	handlersByType map[fulfillment.Type]FulfillmentHandler
 
 	// Workaround config to allow tests to pass
@@ -42,7 +44,7 @@ type contextualScheduler struct {
 // The implementations has generic handling for:
 //  1. Precondition checks
 //  2. Circuit breaker safety mechanisms
-//  3. Subisider sanity checks
+//  3. Subsidizer budget reservations
 //
 // The implementation defers contextualized scheduling logic to handler implementations.
 //
@@ -52,11 +54,12 @@ type contextualScheduler struct {
 //     problem (likely a wavefunction collapse implementation).
 //  2. Fulfillments that require client signatures are validated to guarantee
 //     success before being created.
-func NewContextualScheduler(log *zap.Logger, data ocp_data.Provider, indexerClient indexerpb.IndexerClient, configProvider ConfigProvider) Scheduler {
+func NewContextualScheduler(log *zap.Logger, data ocp_data.Provider, indexerClient indexerpb.IndexerClient, budget *subsidizer.Budget, configProvider ConfigProvider) Scheduler {
 	return &contextualScheduler{
 		log:                     log,
 		data:                    data,
 		conf:                    configProvider(),
+		budget:                  budget,
 		handlersByType:          getFulfillmentHandlers(data, indexerClient),
 		includeSubsidizerChecks: true,
 	}
@@ -206,20 +209,15 @@ func (s *contextualScheduler) CanSubmitToBlockchain(ctx context.Context, fulfill
 
 	// todo: Need a path forward to test things that call the blockchain directly.
 	if s.includeSubsidizerChecks {
-		// Determine if there is sufficient balance in the subsidizer to cover fees
-		// for this fulfillment.
-		//
-		// todo: This is the most naive approach, isn't terribly performant, and won't
-		//       be guaranteed to work well beyond a single thread. It's better than
-		//       nothing for a quick first pass implementation.
-		// todo: We should really consider hardening before launch given sheer amount
-		//       of accounts and nonces required for privacy v3.
-		err = common.EnforceMinimumSubsidizerBalance(ctx, s.data)
+		// Reserve the estimated cost of this fulfillment against the subsidizer's
+		// budget. The reservation is shared with every other scheduling goroutine,
+		// so collectively they can't schedule more than the subsidizer can pay for.
+		err = s.budget.Reserve(ctx, getSubsidizerReservationId(fulfillmentRecord), subsidizer.FulfillmentExpense(fulfillmentRecord.FulfillmentType))
 		if err == common.ErrSubsidizerRequiresFunding {
 			log.Warn("not scheduling fulfillment because the subsidizer requires additional funding")
 			return false, nil
 		} else if err != nil {
-			log.With(zap.Error(err)).Warn("failure checking minimum subidizer balance")
+			log.With(zap.Error(err)).Warn("failure reserving subsidizer budget")
 			return false, err
 		}
 	}
@@ -227,3 +225,7 @@ func (s *contextualScheduler) CanSubmitToBlockchain(ctx context.Context, fulfill
 	log.Debug("scheduling this fulfillment for submission to blockchain")
 	return true, nil
 }
+
+func getSubsidizerReservationId(record *fulfillment.Record) string {
+	return fmt.Sprintf("fulfillment:%d", record.Id)
+}
@@ -3,6 +3,7 @@ package sequencer
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/code-payments/ocp-server/ocp/common"
 	"github.com/code-payments/ocp-server/ocp/data/fulfillment"
@@ -30,6 +31,8 @@ type mockFulfillmentHandler struct {
 
 	isRecoveredFromFailure bool
 
+	vm *common.Account
+
 	successCallbackExecuted bool
 	failureCallbackExecuted bool
 }
@@ -51,6 +54,18 @@ func (h *mockFulfillmentHandler) MakeOnDemandTransaction(ctx context.Context, fu
 	return &txn, nil, nil
 }
 
+func (h *mockFulfillmentHandler) MakeBatchableInstructions(ctx context.Context, fulfillmentRecord *fulfillment.Record) (*transaction_util.BatchableInstructions, []*common.Account, error) {
+	if !h.supportsOnDemandTxnCreation {
+		return nil, nil, errors.New("not supported")
+	}
+
+	return &transaction_util.BatchableInstructions{
+		Vm:           h.vm,
+		ComputeUnits: 100_000,
+		Instructions: []solana.Instruction{memo.Instruction(fmt.Sprintf("fulfillment-%d", fulfillmentRecord.Id))},
+	}, nil, nil
+}
+
 func (h *mockFulfillmentHandler) OnSuccess(ctx context.Context, fulfillmentRecord *fulfillment.Record, transactionRecord *transaction.Record) error {
 	h.successCallbackExecuted = true
 	return nil
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/fulfillment"
 	"github.com/code-payments/ocp-server/ocp/data/timelock"
 	timelock_token_v1 "github.com/code-payments/ocp-server/solana/timelock/v1"
 )
@@ -59,3 +60,30 @@ func markTimelockClosed(ctx context.Context, data ocp_data.Provider, vault strin
 
 	return data.SaveTimelock(ctx, record)
 }
+
+// isSourceTimelockAccountUnlocking determines whether the owner of a fulfillment's
+// source timelock account has started unlocking it, either by requesting it or
+// as observed on the blockchain.
+func isSourceTimelockAccountUnlocking(ctx context.Context, data ocp_data.Provider, fulfillmentRecord *fulfillment.Record) (bool, error) {
+	// Initializing the account is required before it can be unlocked
+	if fulfillmentRecord.FulfillmentType == fulfillment.InitializeLockedTimelockAccount {
+		return false, nil
+	}
+
+	record, err := data.GetTimelockByVault(ctx, fulfillmentRecord.Source)
+	if err == timelock.ErrTimelockNotFound {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	if record.IsUnlockRequested() {
+		return true, nil
+	}
+
+	switch record.VaultState {
+	case timelock_token_v1.StateWaitingForTimeout, timelock_token_v1.StateUnlocked:
+		return true, nil
+	}
+	return false, nil
+}
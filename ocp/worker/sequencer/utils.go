@@ -1,7 +1,10 @@
 package sequencer
 
 import (
+	"bytes"
 	"context"
+	"crypto/ed25519"
+	"database/sql"
 
 	"github.com/pkg/errors"
 
@@ -10,6 +13,7 @@ import (
 	"github.com/code-payments/ocp-server/ocp/common"
 	ocp_data "github.com/code-payments/ocp-server/ocp/data"
 	"github.com/code-payments/ocp-server/ocp/data/action"
+	"github.com/code-payments/ocp-server/ocp/data/batch"
 	"github.com/code-payments/ocp-server/ocp/data/fulfillment"
 	"github.com/code-payments/ocp-server/ocp/data/nonce"
 	"github.com/code-payments/ocp-server/ocp/data/transaction"
@@ -54,7 +58,13 @@ func (p *runtime) markFulfillmentConfirmed(ctx context.Context, record *fulfillm
 
 	record.State = fulfillment.StateConfirmed
 	record.Data = nil
-	return p.data.UpdateFulfillment(ctx, record)
+	err = p.data.UpdateFulfillment(ctx, record)
+	if err != nil {
+		return err
+	}
+
+	p.notifyFulfillmentConfirmed(ctx, record)
+	return nil
 }
 
 func (p *runtime) markFulfillmentFailed(ctx context.Context, record *fulfillment.Record) error {
@@ -73,6 +83,72 @@ func (p *runtime) markFulfillmentFailed(ctx context.Context, record *fulfillment
 	return p.data.UpdateFulfillment(ctx, record)
 }
 
+func (p *runtime) markBatchedFulfillmentConfirmed(ctx context.Context, record *fulfillment.Record, batchRecord *batch.Record) error {
+	err := p.validateFulfillmentState(record, fulfillment.StatePending)
+	if err != nil {
+		return err
+	}
+
+	err = p.markBatchInTerminalState(ctx, batchRecord, batch.StateConfirmed)
+	if err != nil {
+		return err
+	}
+
+	err = p.markVirtualNonceReleasedDueToSubmittedTransaction(ctx, record)
+	if err != nil {
+		return err
+	}
+
+	record.State = fulfillment.StateConfirmed
+	record.Data = nil
+	err = p.data.UpdateFulfillment(ctx, record)
+	if err != nil {
+		return err
+	}
+
+	p.notifyFulfillmentConfirmed(ctx, record)
+	return nil
+}
+
+// markBatchInTerminalState is called by every fulfillment in the batch, but only
+// the first call releases the shared nonce and transitions the batch.
+func (p *runtime) markBatchInTerminalState(ctx context.Context, batchRecord *batch.Record, state batch.State) error {
+	if batchRecord.State == state {
+		return nil
+	} else if batchRecord.State.IsTerminal() {
+		return errors.Errorf("batch is already in terminal %s state", batchRecord.State.String())
+	}
+
+	return p.data.ExecuteInTx(ctx, sql.LevelDefault, func(ctx context.Context) error {
+		nonceRecord, err := p.data.GetNonce(ctx, batchRecord.Nonce)
+		if err != nil {
+			return err
+		}
+
+		if batchRecord.Signature != nonceRecord.Signature {
+			return errors.New("unexpected nonce signature")
+		}
+
+		if batchRecord.Blockhash != nonceRecord.Blockhash {
+			return errors.New("unexpected nonce blockhash")
+		}
+
+		if nonceRecord.State != nonce.StateReserved {
+			return errors.New("unexpected nonce state")
+		}
+
+		nonceRecord.State = nonce.StateReleased
+		err = p.data.SaveNonce(ctx, nonceRecord)
+		if err != nil {
+			return err
+		}
+
+		batchRecord.State = state
+		batchRecord.Data = nil
+		return p.data.UpdateFulfillmentBatch(ctx, batchRecord)
+	})
+}
+
 func (p *runtime) markFulfillmentRevoked(ctx context.Context, fulfillmentRecord *fulfillment.Record, nonceUsed bool) error {
 	err := p.validateFulfillmentState(fulfillmentRecord, fulfillment.StateUnknown)
 	if err != nil {
@@ -103,6 +179,61 @@ func (p *runtime) markFulfillmentRevoked(ctx context.Context, fulfillmentRecord
 	return p.data.UpdateFulfillment(ctx, fulfillmentRecord)
 }
 
+// markFulfillmentRevokedDueToUnlock revokes a fulfillment and its action when
+// the owner of the source account is unlocking it. The fulfillment was never
+// submitted, so its nonces are safe to make available again.
+//
+// The intent can no longer complete, so its other fulfillments that haven't been
+// submitted are also revoked. The intent is revoked when nothing was submitted.
+// Otherwise, it's failed because it was partially executed on the blockchain
+// (eg. a fee transfer was made, but not the payment).
+func (p *runtime) markFulfillmentRevokedDueToUnlock(ctx context.Context, fulfillmentRecord *fulfillment.Record) error {
+	return p.data.ExecuteInTx(ctx, sql.LevelDefault, func(ctx context.Context) error {
+		intentFulfillmentRecords, err := p.data.GetAllFulfillmentsByIntent(ctx, fulfillmentRecord.Intent)
+		if err != nil {
+			return err
+		}
+
+		var isPartiallySubmitted bool
+		for _, intentFulfillmentRecord := range intentFulfillmentRecords {
+			if intentFulfillmentRecord.Id == fulfillmentRecord.Id {
+				continue
+			}
+
+			switch intentFulfillmentRecord.State {
+			case fulfillment.StateUnknown:
+				err = markActionRevoked(ctx, p.data, intentFulfillmentRecord.Intent, intentFulfillmentRecord.ActionId)
+				if err != nil {
+					return err
+				}
+
+				err = p.markFulfillmentRevoked(ctx, intentFulfillmentRecord, false)
+				if err != nil {
+					return err
+				}
+			case fulfillment.StateRevoked:
+			default:
+				isPartiallySubmitted = true
+			}
+		}
+
+		err = markActionRevoked(ctx, p.data, fulfillmentRecord.Intent, fulfillmentRecord.ActionId)
+		if err != nil {
+			return err
+		}
+
+		err = p.markFulfillmentRevoked(ctx, fulfillmentRecord, false)
+		if err != nil {
+			return err
+		}
+
+		if isPartiallySubmitted {
+			return markIntentFailed(ctx, p.data, fulfillmentRecord.Intent)
+		}
+		return markIntentRevoked(ctx, p.data, fulfillmentRecord.Intent)
+	})
+}
+
 func markFulfillmentAsActivelyScheduled(ctx context.Context, data ocp_data.Provider, fulfillmentRecord *fulfillment.Record) error {
 	if fulfillmentRecord.Id == 0 {
 		return nil
@@ -121,10 +252,18 @@ func markFulfillmentAsActivelyScheduled(ctx context.Context, data ocp_data.Provi
 }
 
 func (p *runtime) sendToBlockchain(ctx context.Context, record *fulfillment.Record) error {
+	return p.sendTransactionDataToBlockchain(ctx, record.Data)
+}
+
+func (p *runtime) sendBatchToBlockchain(ctx context.Context, batchRecord *batch.Record) error {
+	return p.sendTransactionDataToBlockchain(ctx, batchRecord.Data)
+}
+
+func (p *runtime) sendTransactionDataToBlockchain(ctx context.Context, data []byte) error {
 	var stx solana.Transaction
 	var err error
 
-	err = stx.Unmarshal(record.Data)
+	err = stx.Unmarshal(data)
 	if err != nil {
 		return err
 	}
@@ -137,20 +276,60 @@ func (p *runtime) sendToBlockchain(ctx context.Context, record *fulfillment.Reco
 	return nil
 }
 
+// signOnDemandTransaction signs a transaction made at the time of submission
+// with the subsidizer and any additional signers
+func signOnDemandTransaction(txn *solana.Transaction, signers []*common.Account) error {
+	signerPrivateKeys := []ed25519.PrivateKey{common.GetSubsidizer().PrivateKey().ToBytes()}
+	for _, signer := range signers {
+		if signer.PrivateKey() == nil {
+			return errors.New("signer private key not provided")
+		}
+
+		var isDuplicate bool
+		for _, signerPrivateKey := range signerPrivateKeys {
+			if bytes.Equal(signer.PrivateKey().ToBytes(), signerPrivateKey) {
+				isDuplicate = true
+				break
+			}
+		}
+		if !isDuplicate {
+			signerPrivateKeys = append(signerPrivateKeys, signer.PrivateKey().ToBytes())
+		}
+	}
+
+	err := txn.Sign(signerPrivateKeys...)
+	if err != nil {
+		return err
+	}
+
+	var emptySignature solana.Signature
+	for _, signature := range txn.Signatures {
+		if bytes.Equal(signature[:], emptySignature[:]) {
+			return errors.New("signature is missing")
+		}
+	}
+
+	return nil
+}
+
 func (p *runtime) getTransaction(ctx context.Context, record *fulfillment.Record) (*transaction.Record, error) {
 	if record.Signature == nil || len(*record.Signature) == 0 {
 		return nil, transaction.ErrNotFound
 	}
 
+	return p.getTransactionBySignature(ctx, *record.Signature)
+}
+
+func (p *runtime) getTransactionBySignature(ctx context.Context, signature string) (*transaction.Record, error) {
 	if p.conf.enableCachedTransactionLookup.Get(ctx) {
-		return p.data.GetTransaction(ctx, *record.Signature)
+		return p.data.GetTransaction(ctx, signature)
 	}
 
-	return p.getTransactionFromBlockchain(ctx, record)
+	return p.getTransactionFromBlockchain(ctx, signature)
 }
 
-func (p *runtime) getTransactionFromBlockchain(ctx context.Context, record *fulfillment.Record) (*transaction.Record, error) {
-	stx, err := p.data.GetBlockchainTransaction(ctx, *record.Signature, solana.CommitmentFinalized)
+func (p *runtime) getTransactionFromBlockchain(ctx context.Context, signature string) (*transaction.Record, error) {
+	stx, err := p.data.GetBlockchainTransaction(ctx, signature, solana.CommitmentFinalized)
 	if err == solana.ErrSignatureNotFound {
 		return nil, transaction.ErrNotFound
 	}
@@ -3,7 +3,6 @@ package sequencer
 import (
 	"bytes"
 	"context"
-	"sync"
 
 	"github.com/code-payments/ocp-server/ocp/common"
 	ocp_data "github.com/code-payments/ocp-server/ocp/data"
@@ -16,18 +15,7 @@ import (
 
 // todo: some of these utilities likely belong in a more common package
 
-var (
-	// Global VM storage and memory locks
-	//
-	// todo: Use a distributed lock
-	vmMemoryLock  sync.Mutex
-	vmStorageLock sync.Mutex
-)
-
 func reserveVmMemory(ctx context.Context, data ocp_data.Provider, vm *common.Account, accountType vm.VirtualAccountType, account *common.Account) (*common.Account, uint16, error) {
-	vmMemoryLock.Lock()
-	defer vmMemoryLock.Unlock()
-
 	memoryAccountAddress, index, err := data.ReserveVmMemory(ctx, vm.PublicKey().ToBase58(), accountType, account.PublicKey().ToBase58())
 	if err != nil {
 		return nil, 0, err
@@ -42,9 +30,6 @@ func reserveVmMemory(ctx context.Context, data ocp_data.Provider, vm *common.Acc
 }
 
 func reserveVmStorage(ctx context.Context, data ocp_data.Provider, vm *common.Account, purpose storage.Purpose, account *common.Account) (*common.Account, error) {
-	vmStorageLock.Lock()
-	defer vmStorageLock.Unlock()
-
 	storageAccountAddress, err := data.ReserveVmStorage(ctx, vm.PublicKey().ToBase58(), purpose, account.PublicKey().ToBase58())
 	if err != nil {
 		return nil, err
@@ -1,11 +1,8 @@
 package sequencer
 
 import (
-	"bytes"
 	"context"
-	"crypto/ed25519"
 	"database/sql"
-	"sync"
 	"time"
 
 	"github.com/mr-tron/base58"
@@ -13,70 +10,67 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/code-payments/ocp-server/database/query"
-	"github.com/code-payments/ocp-server/metrics"
-	"github.com/code-payments/ocp-server/ocp/common"
+	"github.com/code-payments/ocp-server/ocp/data/batch"
 	"github.com/code-payments/ocp-server/ocp/data/fulfillment"
 	"github.com/code-payments/ocp-server/ocp/data/transaction"
+	"github.com/code-payments/ocp-server/ocp/subsidizer"
+	"github.com/code-payments/ocp-server/ocp/worker"
 	"github.com/code-payments/ocp-server/pointer"
-	"github.com/code-payments/ocp-server/retry"
-	"github.com/code-payments/ocp-server/solana"
 )
 
-func (p *runtime) worker(runtimeCtx context.Context, state fulfillment.State, interval time.Duration) error {
-	var cursor query.Cursor
-	delay := interval
-
-	err := retry.Loop(
-		func() (err error) {
-			time.Sleep(delay)
-
-			provider := runtimeCtx.Value(metrics.ProviderContextKey).(metrics.Provider)
-			trace := provider.StartTrace("sequencer_runtime__handle_" + state.String())
-			defer trace.End()
-			tracedCtx := metrics.NewContext(runtimeCtx, trace)
-
-			// Get a batch of records in similar state (e.g. newly created, released, reserved, etc...)
-			items, err := p.data.GetAllFulfillmentsByState(
-				tracedCtx,
-				state,
-				false, // Don't poll for fulfillments that have active scheduling disabled
-				query.WithLimit(p.conf.fulfillmentBatchSize.Get(runtimeCtx)),
-				query.WithCursor(cursor),
-			)
-			if err != nil {
-				cursor = query.EmptyCursor
-				return err
-			}
-
-			// Process the batch of fulfillments in parallel
-			var wg sync.WaitGroup
-			for _, item := range items {
-				wg.Add(1)
-
-				go func(record *fulfillment.Record) {
-					defer wg.Done()
-
-					err := p.handle(tracedCtx, record)
-					if err != nil {
-						trace.OnError(err)
-					}
-				}(item)
-			}
-			wg.Wait()
+func (p *runtime) newStateMachine() *worker.StateMachine[fulfillment.State, *fulfillment.Record] {
+	handlers := make(map[fulfillment.State]worker.RecordHandler[*fulfillment.Record])
+	for _, state := range []fulfillment.State{
+		fulfillment.StateUnknown,
+		fulfillment.StatePending,
+
+		// There's no executable logic for these states yet:
+		// fulfillment.StateConfirmed,
+		// fulfillment.StateFailed,
+		// fulfillment.StateRevoked,
+	} {
+		handlers[state] = func(ctx context.Context, record *fulfillment.Record) error {
+			return p.handleLeased(ctx, record, p.handle)
+		}
+	}
 
-			// Update cursor to point to the next set of fulfillments
-			if len(items) > 0 {
-				cursor = query.ToCursor(items[len(items)-1].Id)
-			} else {
-				cursor = query.EmptyCursor
+	return worker.NewStateMachine(p.log, worker.StateMachineConfig[fulfillment.State, *fulfillment.Record]{
+		Name: "sequencer_runtime",
+		Source: func(ctx context.Context, state fulfillment.State, opts ...query.Option) ([]*fulfillment.Record, error) {
+			// Fulfillments that have active scheduling disabled are never claimed
+			leaseExpiresAt := time.Now().Add(p.conf.fulfillmentLeaseDuration.Get(ctx))
+			return p.data.ClaimFulfillmentsByState(ctx, state, p.nodeId, leaseExpiresAt, opts...)
+		},
+		RecordId: func(record *fulfillment.Record) uint64 {
+			return record.Id
+		},
+		Handlers:      handlers,
+		BatchSize:     p.conf.fulfillmentBatchSize.Get,
+		Concurrency:   p.conf.fulfillmentConcurrency.Get,
+		NotFoundError: fulfillment.ErrFulfillmentNotFound,
+
+		// Pack virtual instructions for pending fulfillments into shared
+		// transactions before they're individually processed
+		BeforeBatch: func(ctx context.Context, state fulfillment.State, records []*fulfillment.Record) error {
+			if state != fulfillment.StatePending || !p.conf.enableFulfillmentBatching.Get(ctx) {
+				return nil
 			}
-
-			return nil
+			return p.batchPendingFulfillments(ctx, records)
 		},
-		retry.NonRetriableErrors(context.Canceled),
-	)
+	})
+}
 
-	return err
+// handleLeased handles a fulfillment claimed by this node, and releases the
+// lease afterwards so it can be picked up by any node on the next poll
+func (p *runtime) handleLeased(ctx context.Context, record *fulfillment.Record, handle worker.RecordHandler[*fulfillment.Record]) error {
+	defer func() {
+		err := p.data.ReleaseFulfillmentLease(ctx, record.Id, p.nodeId)
+		if err != nil {
+			p.log.With(zap.Error(err), zap.Uint64("id", record.Id)).Warn("failure releasing fulfillment lease")
+		}
+	}()
+
+	return handle(ctx, record)
 }
 
 func (p *runtime) handle(ctx context.Context, record *fulfillment.Record) error {
@@ -134,6 +128,14 @@ func (p *runtime) handleUnknown(ctx context.Context, record *fulfillment.Record)
 		return p.markFulfillmentRevoked(ctx, record, nonceUsed)
 	}
 
+	// Funds can no longer be moved out of an account the owner is unlocking
+	isUnlocking, err := isSourceTimelockAccountUnlocking(ctx, p.data, record)
+	if err != nil {
+		return err
+	} else if isUnlocking {
+		return p.markFulfillmentRevokedDueToUnlock(ctx, record)
+	}
+
 	// Check if this fulfillment is scheduled for submission to the blockchain
 	isScheduled, err := p.scheduler.CanSubmitToBlockchain(ctx, record)
 	if err != nil {
@@ -142,7 +144,13 @@ func (p *runtime) handleUnknown(ctx context.Context, record *fulfillment.Record)
 		return nil
 	}
 
-	return p.markFulfillmentPending(ctx, record)
+	err = p.markFulfillmentPending(ctx, record)
+	if err != nil {
+		// Don't hold the subsidizer budget for a fulfillment that wasn't scheduled
+		p.budget.Release(getSubsidizerReservationId(record))
+		return err
+	}
+	return nil
 }
 
 func (p *runtime) handlePending(ctx context.Context, record *fulfillment.Record) error {
@@ -161,6 +169,17 @@ func (p *runtime) handlePending(ctx context.Context, record *fulfillment.Record)
 		return errors.Errorf("no intent handler for %d type", record.IntentType)
 	}
 
+	// Fulfillments that were packed into a batch don't own their transaction,
+	// unless the batch failed and they're being retried individually
+	if record.Signature == nil {
+		batchRecord, err := p.data.GetFulfillmentBatchByFulfillment(ctx, record.Id)
+		if err == nil && batchRecord.State != batch.StateFailed {
+			return p.handlePendingBatched(ctx, record, batchRecord, fulfillmentHandler, actionHandler, intentHandler)
+		} else if err != nil && err != batch.ErrNotFound {
+			return err
+		}
+	}
+
 	// Check on the status of the transaction
 	tx, err := p.getTransaction(ctx, record)
 	if err != nil && err != transaction.ErrNotFound {
@@ -208,6 +227,11 @@ func (p *runtime) handlePending(ctx context.Context, record *fulfillment.Record)
 				return err
 			}
 
+			// Zero fees come from records that never captured the fee
+			if tx.Fee != nil && *tx.Fee > 0 {
+				p.budget.ObserveFee(ctx, subsidizer.FulfillmentExpense(record.FulfillmentType), *tx.Fee)
+			}
+
 			// By design is the last thing so we can retry all logic
 			return p.markFulfillmentConfirmed(ctx, record)
 		}
@@ -235,29 +259,11 @@ func (p *runtime) handlePending(ctx context.Context, record *fulfillment.Record)
 				return err
 			}
 
-			signerPrivateKeys := []ed25519.PrivateKey{common.GetSubsidizer().PrivateKey().ToBytes()}
-			for _, signer := range signers {
-				if signer.PrivateKey() == nil {
-					return errors.New("signer private key not provided")
-				}
-
-				if !bytes.Equal(signer.PrivateKey().ToBytes(), signerPrivateKeys[0]) {
-					signerPrivateKeys = append(signerPrivateKeys, signer.PrivateKey().ToBytes())
-				}
-			}
-
-			err = txn.Sign(signerPrivateKeys...)
+			err = signOnDemandTransaction(txn, signers)
 			if err != nil {
 				return err
 			}
 
-			var emptySignature solana.Signature
-			for _, signature := range txn.Signatures {
-				if bytes.Equal(signature[:], emptySignature[:]) {
-					return errors.New("signature is missing")
-				}
-			}
-
 			record.Signature = pointer.String(base58.Encode(txn.Signature()))
 			record.Nonce = pointer.String(selectedSolanaNonce.Account.PublicKey().ToBase58())
 			record.Blockhash = pointer.String(base58.Encode(selectedSolanaNonce.Blockhash[:]))
@@ -288,6 +294,75 @@ func (p *runtime) handlePending(ctx context.Context, record *fulfillment.Record)
 	return nil
 }
 
+func (p *runtime) handlePendingBatched(
+	ctx context.Context,
+	record *fulfillment.Record,
+	batchRecord *batch.Record,
+	fulfillmentHandler FulfillmentHandler,
+	actionHandler ActionHandler,
+	intentHandler IntentHandler,
+) error {
+	// Check on the status of the shared transaction
+	tx, err := p.getTransactionBySignature(ctx, batchRecord.Signature)
+	if err != nil && err != transaction.ErrNotFound {
+		return err
+	}
+
+	if tx != nil {
+		// A failed transaction fails every fulfillment in the batch, even if only
+		// one of them caused it. None of the virtual instructions were executed,
+		// so each fulfillment is retried with its own on demand transaction, and
+		// only fails if that transaction does.
+		if tx.HasErrors || tx.ConfirmationState == transaction.ConfirmationFailed {
+			err = p.markBatchInTerminalState(ctx, batchRecord, batch.StateFailed)
+			if err != nil {
+				return err
+			}
+			return p.handlePending(ctx, record)
+		}
+
+		if tx.ConfirmationState == transaction.ConfirmationFinalized {
+			err := actionHandler.OnFulfillmentStateChange(ctx, record, fulfillment.StateConfirmed)
+			if err != nil {
+				return err
+			}
+
+			err = intentHandler.OnActionUpdated(ctx, record.Intent)
+			if err != nil {
+				return err
+			}
+
+			err = fulfillmentHandler.OnSuccess(ctx, record, tx)
+			if err != nil {
+				return err
+			}
+
+			// Each fulfillment observes its share of the shared fee, so the
+			// batch's fee is only counted once
+			if tx.Fee != nil && *tx.Fee > 0 {
+				p.budget.ObserveFee(ctx, subsidizer.FulfillmentExpense(record.FulfillmentType), *tx.Fee/uint64(len(batchRecord.Fulfillments)))
+			}
+
+			// By design is the last thing so we can retry all logic
+			return p.markBatchedFulfillmentConfirmed(ctx, record, batchRecord)
+		}
+	}
+
+	// We're still pending
+
+	// Only the first fulfillment in the batch re-broadcasts the shared transaction
+	if batchRecord.Fulfillments[0] != record.Id {
+		return nil
+	}
+
+	// Re-broadcast the transaction (could be the first time)
+	if !p.conf.disableTransactionSubmission.Get(ctx) {
+		return p.sendBatchToBlockchain(ctx, batchRecord)
+	}
+
+	return nil
+}
+
 func (p *runtime) handleConfirmed(ctx context.Context, record *fulfillment.Record) error {
 	// Nothing to do here. We're done...
 	return nil
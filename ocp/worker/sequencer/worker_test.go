@@ -2,6 +2,7 @@ package sequencer
 
 import (
 	"context"
+	"fmt"
 	"math/rand"
 	"testing"
 	"time"
@@ -14,16 +15,22 @@ import (
 	"github.com/code-payments/ocp-server/ocp/common"
 	ocp_data "github.com/code-payments/ocp-server/ocp/data"
 	"github.com/code-payments/ocp-server/ocp/data/action"
+	"github.com/code-payments/ocp-server/ocp/data/batch"
 	"github.com/code-payments/ocp-server/ocp/data/fulfillment"
 	"github.com/code-payments/ocp-server/ocp/data/intent"
 	"github.com/code-payments/ocp-server/ocp/data/nonce"
 	"github.com/code-payments/ocp-server/ocp/data/transaction"
 	"github.com/code-payments/ocp-server/ocp/data/vault"
+	"github.com/code-payments/ocp-server/ocp/scheduling"
+	memory_scheduling "github.com/code-payments/ocp-server/ocp/scheduling/memory"
+	"github.com/code-payments/ocp-server/ocp/subsidizer"
 	transaction_util "github.com/code-payments/ocp-server/ocp/transaction"
+	"github.com/code-payments/ocp-server/ocp/webhook"
 	"github.com/code-payments/ocp-server/pointer"
 	"github.com/code-payments/ocp-server/solana"
 	"github.com/code-payments/ocp-server/solana/memo"
 	"github.com/code-payments/ocp-server/solana/system"
+	timelock_token_v1 "github.com/code-payments/ocp-server/solana/timelock/v1"
 	"github.com/code-payments/ocp-server/testutil"
 )
 
@@ -76,6 +83,93 @@ func TestFulfillmentWorker_StateUnknown_TransitionToStateRevoked_FulfillmentHand
 	}
 }
 
+func TestFulfillmentWorker_StateUnknown_TransitionToStateRevoked_SourceAccountUnlocking(t *testing.T) {
+	webhook.InjectTestEnabled(true)
+	defer webhook.InjectTestEnabled(false)
+
+	for _, unlockRequested := range []bool{true, false} {
+		env := setupWorkerEnv(t)
+
+		owner := testutil.NewRandomAccount(t)
+		timelockAccounts, err := owner.GetTimelockAccounts(testutil.NewRandomVmConfig(t, true))
+		require.NoError(t, err)
+		timelockRecord := timelockAccounts.ToDBRecord()
+		timelockRecord.VaultState = timelock_token_v1.StateLocked
+		timelockRecord.Block = 1
+		require.NoError(t, env.data.SaveTimelock(env.ctx, timelockRecord))
+
+		fulfillmentRecord := env.createAnyFulfillmentInStateForSource(t, fulfillment.StateUnknown, timelockRecord.VaultAddress, 0)
+		fulfillmentRecord.FulfillmentType = fulfillment.NoPrivacyTransferWithAuthority
+		env.createPendingIntent(t, fulfillmentRecord.Intent)
+
+		require.NoError(t, env.worker.handle(env.ctx, fulfillmentRecord))
+		env.assertFulfillmentInState(t, *fulfillmentRecord.Signature, fulfillment.StateUnknown)
+
+		if unlockRequested {
+			require.NoError(t, env.data.MarkTimelockUnlockRequested(env.ctx, timelockRecord.VaultAddress, time.Now()))
+		} else {
+			timelockRecord.VaultState = timelock_token_v1.StateWaitingForTimeout
+			timelockRecord.Block += 1
+			require.NoError(t, env.data.SaveTimelock(env.ctx, timelockRecord))
+		}
+
+		env.scheduler.shouldSchedule = true
+		require.NoError(t, env.worker.handle(env.ctx, fulfillmentRecord))
+		env.assertFulfillmentInState(t, *fulfillmentRecord.Signature, fulfillment.StateRevoked)
+		env.assertNonceState(t, *fulfillmentRecord.Nonce, nonce.StateAvailable, "", *fulfillmentRecord.Blockhash)
+
+		actionRecord, err := env.data.GetActionById(env.ctx, fulfillmentRecord.Intent, fulfillmentRecord.ActionId)
+		require.NoError(t, err)
+		assert.Equal(t, action.StateRevoked, actionRecord.State)
+
+		env.assertIntentState(t, fulfillmentRecord.Intent, intent.StateRevoked)
+		env.assertIntentEvent(t, webhook.EventTypeIntentRevoked, fulfillmentRecord.Intent)
+	}
+}
+
+func TestFulfillmentWorker_StateUnknown_TransitionToStateRevoked_SourceAccountUnlocking_PartiallySubmittedIntent(t *testing.T) {
+	webhook.InjectTestEnabled(true)
+	defer webhook.InjectTestEnabled(false)
+
+	env := setupWorkerEnv(t)
+
+	owner := testutil.NewRandomAccount(t)
+	timelockAccounts, err := owner.GetTimelockAccounts(testutil.NewRandomVmConfig(t, true))
+	require.NoError(t, err)
+	timelockRecord := timelockAccounts.ToDBRecord()
+	timelockRecord.VaultState = timelock_token_v1.StateLocked
+	timelockRecord.Block = 1
+	require.NoError(t, env.data.SaveTimelock(env.ctx, timelockRecord))
+
+	// The fee transfer was submitted before the owner started unlocking
+	feeFulfillmentRecord := env.createAnyFulfillmentInStateForSource(t, fulfillment.StatePending, timelockRecord.VaultAddress, 0)
+	env.createPendingIntent(t, feeFulfillmentRecord.Intent)
+
+	paymentFulfillmentRecords := make([]*fulfillment.Record, 2)
+	for i := range paymentFulfillmentRecords {
+		paymentFulfillmentRecords[i] = env.createAnyFulfillmentInStateForIntent(t, fulfillment.StateUnknown, timelockRecord.VaultAddress, feeFulfillmentRecord.Intent, uint32(i+1), 0)
+		paymentFulfillmentRecords[i].FulfillmentType = fulfillment.NoPrivacyTransferWithAuthority
+	}
+
+	require.NoError(t, env.data.MarkTimelockUnlockRequested(env.ctx, timelockRecord.VaultAddress, time.Now()))
+
+	env.scheduler.shouldSchedule = true
+	require.NoError(t, env.worker.handle(env.ctx, paymentFulfillmentRecords[0]))
+
+	env.assertFulfillmentInState(t, *feeFulfillmentRecord.Signature, fulfillment.StatePending)
+	for _, paymentFulfillmentRecord := range paymentFulfillmentRecords {
+		env.assertFulfillmentInState(t, *paymentFulfillmentRecord.Signature, fulfillment.StateRevoked)
+		env.assertNonceState(t, *paymentFulfillmentRecord.Nonce, nonce.StateAvailable, "", *paymentFulfillmentRecord.Blockhash)
+
+		actionRecord, err := env.data.GetActionById(env.ctx, paymentFulfillmentRecord.Intent, paymentFulfillmentRecord.ActionId)
+		require.NoError(t, err)
+		assert.Equal(t, action.StateRevoked, actionRecord.State)
+	}
+
+	env.assertIntentState(t, feeFulfillmentRecord.Intent, intent.StateFailed)
+	env.assertIntentEvent(t, webhook.EventTypeIntentFailed, feeFulfillmentRecord.Intent)
+}
+
 func TestFulfillmentWorker_StatePending_RemainInPending(t *testing.T) {
 	env := setupWorkerEnv(t)
 
@@ -107,7 +201,7 @@ func TestFulfillmentWorker_StatePending_OnDemandTransactionCreation_HappyPath(t
 	env.fulfillmentHandler.supportsOnDemandTxnCreation = true
 
 	nonceRecord := env.generateAvailableNonce(t)
-	time.Sleep(time.Second) // todo: Better way of waiting for nonce to be claimed
+	env.waitForNoncesClaimed(t, nonceRecord)
 
 	fulfillmentRecord := env.createAnyFulfillmentInState(t, fulfillment.StatePending)
 	fulfillmentRecord.Signature = nil
@@ -214,6 +308,193 @@ func TestFulfillmentWorker_StatePending_TransitionToStateFailed(t *testing.T) {
 	}
 }
 
+func TestFulfillmentWorker_StatePending_Batching_TransitionToStateConfirmed(t *testing.T) {
+	env := setupWorkerEnv(t)
+
+	env.fulfillmentHandler.supportsOnDemandTxnCreation = true
+
+	nonceRecord := env.generateAvailableNonce(t)
+	env.waitForNoncesClaimed(t, nonceRecord)
+
+	var fulfillmentRecords []*fulfillment.Record
+	for range 3 {
+		fulfillmentRecords = append(fulfillmentRecords, env.createAnyFulfillmentInStateWithoutTransaction(t, fulfillment.StatePending))
+	}
+
+	require.NoError(t, env.worker.batchPendingFulfillments(env.ctx, fulfillmentRecords))
+
+	batchRecord := env.assertFulfillmentsBatched(t, fulfillmentRecords, nonceRecord.Address, nonceRecord.Blockhash)
+
+	// Should be a no-op in terms of transaction creation
+	require.NoError(t, env.worker.batchPendingFulfillments(env.ctx, fulfillmentRecords))
+	for _, fulfillmentRecord := range fulfillmentRecords {
+		require.NoError(t, env.worker.handle(env.ctx, fulfillmentRecord))
+		env.assertFulfillmentInStateById(t, fulfillmentRecord.Id, fulfillment.StatePending)
+		assert.Nil(t, fulfillmentRecord.Signature)
+	}
+	env.assertFulfillmentsBatched(t, fulfillmentRecords, nonceRecord.Address, nonceRecord.Blockhash)
+
+	assert.False(t, env.fulfillmentHandler.successCallbackExecuted)
+	assert.False(t, env.actionHandler.callbackExecuted)
+	assert.False(t, env.intentHandler.callbackExecuted)
+
+	require.NoError(t, env.data.SaveTransaction(env.ctx, &transaction.Record{
+		Signature:         batchRecord.Signature,
+		ConfirmationState: transaction.ConfirmationFinalized,
+		Fee:               pointer.Uint64(30_000),
+	}))
+	for _, fulfillmentRecord := range fulfillmentRecords {
+		require.NoError(t, env.worker.handle(env.ctx, fulfillmentRecord))
+		env.assertFulfillmentInStateById(t, fulfillmentRecord.Id, fulfillment.StateConfirmed)
+	}
+	env.assertNonceState(t, nonceRecord.Address, nonce.StateReleased, batchRecord.Signature, nonceRecord.Blockhash)
+	env.assertBatchInState(t, batchRecord.Id, batch.StateConfirmed)
+
+	// Each fulfillment observed its share of the shared fee
+	assert.EqualValues(t, 10_000, env.worker.budget.EstimateCost(subsidizer.FulfillmentExpense(fulfillmentRecords[0].FulfillmentType)))
+
+	assert.True(t, env.fulfillmentHandler.successCallbackExecuted)
+	assert.False(t, env.fulfillmentHandler.failureCallbackExecuted)
+	assert.True(t, env.actionHandler.callbackExecuted)
+	assert.Equal(t, fulfillment.StateConfirmed, env.actionHandler.reportedFulfillmentState)
+	assert.True(t, env.intentHandler.callbackExecuted)
+}
+
+func TestFulfillmentWorker_StatePending_Batching_FailedBatchRetriedUnbatched(t *testing.T) {
+	env := setupWorkerEnv(t)
+
+	env.fulfillmentHandler.supportsOnDemandTxnCreation = true
+
+	nonceRecord := env.generateAvailableNonce(t)
+	env.waitForNoncesClaimed(t, nonceRecord)
+
+	var fulfillmentRecords []*fulfillment.Record
+	for range 2 {
+		fulfillmentRecords = append(fulfillmentRecords, env.createAnyFulfillmentInStateWithoutTransaction(t, fulfillment.StatePending))
+	}
+
+	require.NoError(t, env.worker.batchPendingFulfillments(env.ctx, fulfillmentRecords))
+
+	batchRecord := env.assertFulfillmentsBatched(t, fulfillmentRecords, nonceRecord.Address, nonceRecord.Blockhash)
+
+	// Each fulfillment gets its own on demand transaction
+	env.waitForNoncesClaimed(t, env.generateAvailableNonce(t), env.generateAvailableNonce(t))
+	env.simulateBlockchainTransactionState(t, batchRecord.Signature, transaction.ConfirmationFailed)
+	for _, fulfillmentRecord := range fulfillmentRecords {
+		require.NoError(t, env.worker.handle(env.ctx, fulfillmentRecord))
+		require.NotNil(t, fulfillmentRecord.Signature)
+		env.assertFulfillmentInState(t, *fulfillmentRecord.Signature, fulfillment.StatePending)
+		env.assertNonceState(t, *fulfillmentRecord.Nonce, nonce.StateReserved, *fulfillmentRecord.Signature, *fulfillmentRecord.Blockhash)
+	}
+	env.assertNonceState(t, nonceRecord.Address, nonce.StateReleased, batchRecord.Signature, nonceRecord.Blockhash)
+	env.assertBatchInState(t, batchRecord.Id, batch.StateFailed)
+
+	assert.False(t, env.fulfillmentHandler.failureCallbackExecuted)
+	assert.False(t, env.actionHandler.callbackExecuted)
+	assert.False(t, env.intentHandler.callbackExecuted)
+
+	// Only the fulfillment whose own transaction fails is failed
+	env.simulateBlockchainTransactionState(t, *fulfillmentRecords[0].Signature, transaction.ConfirmationFailed)
+	env.simulateBlockchainTransactionState(t, *fulfillmentRecords[1].Signature, transaction.ConfirmationFinalized)
+
+	require.NoError(t, env.worker.handle(env.ctx, fulfillmentRecords[0]))
+	env.assertFulfillmentInState(t, *fulfillmentRecords[0].Signature, fulfillment.StateFailed)
+	assert.True(t, env.fulfillmentHandler.failureCallbackExecuted)
+	assert.Equal(t, fulfillment.StateFailed, env.actionHandler.reportedFulfillmentState)
+
+	require.NoError(t, env.worker.handle(env.ctx, fulfillmentRecords[1]))
+	env.assertFulfillmentInState(t, *fulfillmentRecords[1].Signature, fulfillment.StateConfirmed)
+	assert.True(t, env.fulfillmentHandler.successCallbackExecuted)
+	assert.Equal(t, fulfillment.StateConfirmed, env.actionHandler.reportedFulfillmentState)
+}
+
+func TestFulfillmentWorker_StatePending_Batching_Limits(t *testing.T) {
+	env := setupWorkerEnv(t)
+
+	env.fulfillmentHandler.supportsOnDemandTxnCreation = true
+
+	env.waitForNoncesClaimed(t, env.generateAvailableNonce(t), env.generateAvailableNonce(t))
+
+	// A lone fulfillment isn't batched
+	fulfillmentRecord := env.createAnyFulfillmentInStateWithoutTransaction(t, fulfillment.StatePending)
+	require.NoError(t, env.worker.batchPendingFulfillments(env.ctx, []*fulfillment.Record{fulfillmentRecord}))
+	_, err := env.data.GetFulfillmentBatchByFulfillment(env.ctx, fulfillmentRecord.Id)
+	assert.Equal(t, batch.ErrNotFound, err)
+
+	// Fulfillments touching the same account aren't batched together
+	conflicting := env.createAnyFulfillmentInStateWithoutTransaction(t, fulfillment.StatePending)
+	conflicting.Destination = pointer.String(fulfillmentRecord.Source)
+	require.NoError(t, env.worker.batchPendingFulfillments(env.ctx, []*fulfillment.Record{fulfillmentRecord, conflicting}))
+	for _, record := range []*fulfillment.Record{fulfillmentRecord, conflicting} {
+		_, err = env.data.GetFulfillmentBatchByFulfillment(env.ctx, record.Id)
+		assert.Equal(t, batch.ErrNotFound, err)
+	}
+
+	// Batches are capped in size, with any remainder batched separately
+	var fulfillmentRecords []*fulfillment.Record
+	for range 2*defaultMaxFulfillmentsPerBatch + 1 {
+		fulfillmentRecords = append(fulfillmentRecords, env.createAnyFulfillmentInStateWithoutTransaction(t, fulfillment.StatePending))
+	}
+	require.NoError(t, env.worker.batchPendingFulfillments(env.ctx, fulfillmentRecords))
+	for i, record := range fulfillmentRecords {
+		batchRecord, err := env.data.GetFulfillmentBatchByFulfillment(env.ctx, record.Id)
+		if i < 2*defaultMaxFulfillmentsPerBatch {
+			require.NoError(t, err)
+			assert.Len(t, batchRecord.Fulfillments, defaultMaxFulfillmentsPerBatch)
+		} else {
+			assert.Equal(t, batch.ErrNotFound, err)
+		}
+	}
+}
+
+func TestFulfillmentWorker_SchedulingHint_ConfirmationSchedulesNextFulfillment(t *testing.T) {
+	env := setupWorkerEnv(t)
+
+	predecessor := env.createAnyFulfillmentInState(t, fulfillment.StatePending)
+	successor := env.createAnyFulfillmentInStateForSource(t, fulfillment.StateUnknown, predecessor.Source, predecessor.IntentOrderingIndex+1)
+
+	env.startSchedulingHintWorker(t)
+
+	env.scheduler.shouldSchedule = true
+	env.simulateBlockchainTransactionState(t, *predecessor.Signature, transaction.ConfirmationFinalized)
+	require.NoError(t, env.worker.handle(env.ctx, predecessor))
+	env.assertFulfillmentInState(t, *predecessor.Signature, fulfillment.StateConfirmed)
+
+	require.Eventually(t, func() bool {
+		actual, err := env.data.GetFulfillmentById(env.ctx, successor.Id)
+		require.NoError(t, err)
+		return actual.State == fulfillment.StatePending
+	}, time.Second, 10*time.Millisecond)
+
+	// The lease is released once the hint is handled
+	_, err := env.data.ClaimFulfillmentById(env.ctx, successor.Id, "other_node", time.Now().Add(time.Minute))
+	require.NoError(t, err)
+}
+
+func TestFulfillmentWorker_SchedulingHint_UnclaimableFulfillmentsIgnored(t *testing.T) {
+	env := setupWorkerEnv(t)
+
+	predecessor := env.createAnyFulfillmentInState(t, fulfillment.StateConfirmed)
+
+	leased := env.createAnyFulfillmentInStateForSource(t, fulfillment.StateUnknown, predecessor.Source, 1)
+	_, err := env.data.ClaimFulfillmentById(env.ctx, leased.Id, "other_node", time.Now().Add(time.Minute))
+	require.NoError(t, err)
+
+	env.scheduler.shouldSchedule = true
+	hints := scheduling.GetHintsForConfirmedFulfillment(predecessor)
+	require.Len(t, hints, 1)
+
+	require.NoError(t, env.worker.handleSchedulingHint(env.ctx, hints[0]))
+	env.assertFulfillmentInState(t, *leased.Signature, fulfillment.StateUnknown)
+
+	require.NoError(t, env.data.ReleaseFulfillmentLease(env.ctx, leased.Id, "other_node"))
+	leased.DisableActiveScheduling = true
+	require.NoError(t, env.data.UpdateFulfillment(env.ctx, leased))
+
+	require.NoError(t, env.worker.handleSchedulingHint(env.ctx, hints[0]))
+	env.assertFulfillmentInState(t, *leased.Signature, fulfillment.StateUnknown)
+}
+
 type workerTestEnv struct {
 	ctx                context.Context
 	data               ocp_data.Provider
@@ -241,12 +522,20 @@ func setupWorkerEnv(t *testing.T) *workerTestEnv {
 		transaction_util.WithNoncePoolRefreshPoolInterval(time.Second),
 	)
 	require.NoError(t, err)
-	fulfillmentHandler := &mockFulfillmentHandler{}
+	t.Cleanup(func() {
+		noncePool.Close()
+	})
+
+	fulfillmentHandler := &mockFulfillmentHandler{
+		vm: testutil.NewRandomAccount(t),
+	}
 	actionHandler := &mockActionHandler{}
 	intentHandler := &mockIntentHandler{}
 
 	// todo: setup a test vm indexer
-	workerInterface, err := New(log, db, scheduler, nil, noncePool, withManualTestOverrides(&testOverrides{}))
+	workerInterface, err := New(log, db, scheduler, memory_scheduling.New(), nil, noncePool, subsidizer.NewBudget(db, subsidizer.WithEnvConfigs()), withManualTestOverrides(&testOverrides{
+		maxFulfillmentsPerBatch: defaultMaxFulfillmentsPerBatch,
+	}))
 	require.NoError(t, err)
 	worker := workerInterface.(*runtime)
 	for key := range worker.fulfillmentHandlersByType {
@@ -271,7 +560,25 @@ func setupWorkerEnv(t *testing.T) *workerTestEnv {
 	}
 }
 
+func (e *workerTestEnv) startSchedulingHintWorker(t *testing.T) {
+	ctx, cancel := context.WithCancel(e.ctx)
+	t.Cleanup(cancel)
+
+	go e.worker.schedulingHintWorker(ctx)
+
+	// Wait for the worker to subscribe, so early hints aren't missed
+	time.Sleep(50 * time.Millisecond)
+}
+
 func (e *workerTestEnv) createAnyFulfillmentInState(t *testing.T, state fulfillment.State) *fulfillment.Record {
+	return e.createAnyFulfillmentInStateForSource(t, state, testutil.NewRandomAccount(t).PublicKey().ToBase58(), 0)
+}
+
+func (e *workerTestEnv) createAnyFulfillmentInStateForSource(t *testing.T, state fulfillment.State, source string, intentOrderingIndex uint64) *fulfillment.Record {
+	return e.createAnyFulfillmentInStateForIntent(t, state, source, testutil.NewRandomAccount(t).PublicKey().ToBase58(), 3, intentOrderingIndex)
+}
+
+func (e *workerTestEnv) createAnyFulfillmentInStateForIntent(t *testing.T, state fulfillment.State, source, intentId string, actionId uint32, intentOrderingIndex uint64) *fulfillment.Record {
 	fakeCodeAccouht := testutil.NewRandomAccount(t)
 	fakeNonceAccount := testutil.NewRandomAccount(t)
 
@@ -289,17 +596,20 @@ func (e *workerTestEnv) createAnyFulfillmentInState(t *testing.T, state fulfillm
 	txn.Sign(fakeCodeAccouht.PrivateKey().ToBytes())
 
 	fulfillmentRecord := &fulfillment.Record{
-		Intent:          testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+		Intent:          intentId,
 		IntentType:      intent.OpenAccounts,
-		ActionId:        3,
+		ActionId:        actionId,
 		ActionType:      action.OpenAccount,
 		FulfillmentType: fulfillment.InitializeLockedTimelockAccount,
 		Data:            txn.Marshal(),
 		Signature:       pointer.String(base58.Encode(txn.Signature())),
-		Source:          "source",
+		Source:          source,
 		Nonce:           pointer.String(fakeNonceAccount.PublicKey().ToBase58()),
 		Blockhash:       pointer.String(base58.Encode(untypedBlockhash)),
-		State:           state,
+
+		IntentOrderingIndex: intentOrderingIndex,
+
+		State: state,
 	}
 	require.NoError(t, e.data.PutAllFulfillments(e.ctx, fulfillmentRecord))
 
@@ -328,6 +638,28 @@ func (e *workerTestEnv) createAnyFulfillmentInState(t *testing.T, state fulfillm
 	return fulfillmentRecord
 }
 
+func (e *workerTestEnv) createAnyFulfillmentInStateWithoutTransaction(t *testing.T, state fulfillment.State) *fulfillment.Record {
+	fulfillmentRecord := e.createAnyFulfillmentInState(t, state)
+	fulfillmentRecord.Signature = nil
+	fulfillmentRecord.Nonce = nil
+	fulfillmentRecord.Blockhash = nil
+	fulfillmentRecord.Data = nil
+	require.NoError(t, e.data.UpdateFulfillment(e.ctx, fulfillmentRecord))
+	return fulfillmentRecord
+}
+
+func (e *workerTestEnv) createPendingIntent(t *testing.T, intentId string) {
+	intentRecord := &intent.Record{
+		IntentId:              intentId,
+		IntentType:            intent.OpenAccounts,
+		MintAccount:           common.CoreMintAccount.PublicKey().ToBase58(),
+		InitiatorOwnerAccount: testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+		OpenAccountsMetadata:  &intent.OpenAccountsMetadata{},
+		State:                 intent.StatePending,
+	}
+	require.NoError(t, e.data.SaveIntent(e.ctx, intentRecord))
+}
+
 func (e *workerTestEnv) assertFulfillmentInState(t *testing.T, sig string, expected fulfillment.State) {
 	fulfillmentRecord, err := e.data.GetFulfillmentBySignature(e.ctx, sig)
 	require.NoError(t, err)
@@ -340,6 +672,64 @@ func (e *workerTestEnv) assertFulfillmentInState(t *testing.T, sig string, expec
 	}
 }
 
+func (e *workerTestEnv) assertFulfillmentInStateById(t *testing.T, id uint64, expected fulfillment.State) {
+	fulfillmentRecord, err := e.data.GetFulfillmentById(e.ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, expected, fulfillmentRecord.State)
+}
+
+func (e *workerTestEnv) assertFulfillmentsBatched(t *testing.T, fulfillmentRecords []*fulfillment.Record, nonceAddress, blockhash string) *batch.Record {
+	batchRecord, err := e.data.GetFulfillmentBatchByFulfillment(e.ctx, fulfillmentRecords[0].Id)
+	require.NoError(t, err)
+
+	assert.Equal(t, batch.StatePending, batchRecord.State)
+	assert.Equal(t, e.fulfillmentHandler.vm.PublicKey().ToBase58(), batchRecord.Vm)
+	assert.Equal(t, nonceAddress, batchRecord.Nonce)
+	assert.Equal(t, blockhash, batchRecord.Blockhash)
+
+	require.Len(t, batchRecord.Fulfillments, len(fulfillmentRecords))
+	for i, fulfillmentRecord := range fulfillmentRecords {
+		assert.Equal(t, fulfillmentRecord.Id, batchRecord.Fulfillments[i])
+
+		actual, err := e.data.GetFulfillmentById(e.ctx, fulfillmentRecord.Id)
+		require.NoError(t, err)
+		assert.Nil(t, actual.Signature)
+		assert.Empty(t, actual.Data)
+	}
+
+	var txn solana.Transaction
+	require.NoError(t, txn.Unmarshal(batchRecord.Data))
+	assert.Equal(t, batchRecord.Signature, base58.Encode(txn.Signature()))
+	assert.Equal(t, blockhash, base58.Encode(txn.Message.RecentBlockhash[:]))
+	require.Len(t, txn.Message.Instructions, 3+len(fulfillmentRecords))
+	for i, fulfillmentRecord := range fulfillmentRecords {
+		assert.Equal(t, fmt.Sprintf("fulfillment-%d", fulfillmentRecord.Id), string(txn.Message.Instructions[3+i].Data))
+	}
+
+	e.assertNonceState(t, nonceAddress, nonce.StateReserved, batchRecord.Signature, blockhash)
+
+	return batchRecord
+}
+
+func (e *workerTestEnv) assertBatchInState(t *testing.T, id uint64, expected batch.State) {
+	batchRecord, err := e.data.GetFulfillmentBatchById(e.ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, expected, batchRecord.State)
+	assert.Empty(t, batchRecord.Data)
+}
+
+func (e *workerTestEnv) assertIntentState(t *testing.T, intentId string, expected intent.State) {
+	intentRecord, err := e.data.GetIntent(e.ctx, intentId)
+	require.NoError(t, err)
+	assert.Equal(t, expected, intentRecord.State)
+}
+
+func (e *workerTestEnv) assertIntentEvent(t *testing.T, eventType webhook.EventType, intentId string) {
+	eventRecord, err := e.data.GetWebhookEvent(e.ctx, fmt.Sprintf("%s:%s", eventType, intentId))
+	require.NoError(t, err)
+	assert.Equal(t, string(eventType), eventRecord.EventType)
+}
+
 func (e *workerTestEnv) assertNonceState(t *testing.T, address string, expectedState nonce.State, expectedSignature, expectedBlockhash string) {
 	nonceRecord, err := e.data.GetNonce(e.ctx, address)
 	require.NoError(t, err)
@@ -348,6 +738,19 @@ func (e *workerTestEnv) assertNonceState(t *testing.T, address string, expectedS
 	assert.Equal(t, expectedBlockhash, nonceRecord.Blockhash)
 }
 
+func (e *workerTestEnv) waitForNoncesClaimed(t *testing.T, nonceRecords ...*nonce.Record) {
+	require.Eventually(t, func() bool {
+		for _, nonceRecord := range nonceRecords {
+			actual, err := e.data.GetNonce(e.ctx, nonceRecord.Address)
+			require.NoError(t, err)
+			if actual.State != nonce.StateClaimed {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
 func (e *workerTestEnv) simulateBlockchainTransactionState(t *testing.T, sig string, state transaction.Confirmation) {
 	txnRecord := &transaction.Record{
 		Signature:         sig,
@@ -0,0 +1,249 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/code-payments/ocp-server/database/query"
+	"github.com/code-payments/ocp-server/metrics"
+)
+
+const (
+	stateMachineBatchEventName = "StateMachineBatchProcessed"
+
+	defaultDrainTimeout = 30 * time.Second
+)
+
+// State is a state of a record managed by a StateMachine
+type State interface {
+	comparable
+	String() string
+}
+
+// RecordSource gets a page of records in a given state
+type RecordSource[S State, R any] func(ctx context.Context, state S, opts ...query.Option) ([]R, error)
+
+// RecordHandler processes a single record in the state it's registered against
+type RecordHandler[R any] func(ctx context.Context, record R) error
+
+// StateMachineConfig configures a StateMachine
+type StateMachineConfig[S State, R any] struct {
+	// Name identifies the worker in traces and metrics (eg. "sequencer_runtime")
+	Name string
+
+	// Source gets pages of records in a given state
+	Source RecordSource[S, R]
+
+	// RecordId returns the ID of a record used to page through the source
+	RecordId func(R) uint64
+
+	// Handlers processes records in each state. Only states with a handler are
+	// polled.
+	Handlers map[S]RecordHandler[R]
+
+	// BatchSize is the maximum number of records fetched per poll
+	BatchSize func(ctx context.Context) uint64
+
+	// Concurrency is the maximum number of records processed in parallel for a
+	// state. When nil or zero, a full batch is processed in parallel.
+	Concurrency func(ctx context.Context) uint64
+
+	// Direction is the order in which records are paged through
+	Direction query.Ordering
+
+	// NotFoundError is the error returned by the source when there are no
+	// records. It's treated as an empty page.
+	NotFoundError error
+
+	// PollOnStart polls for the first batch without waiting for an interval
+	PollOnStart bool
+
+	// DrainTimeout is how long in flight records are given to finish processing
+	// after the worker is stopped. Defaults to 30 seconds when zero.
+	DrainTimeout time.Duration
+
+	// BeforeBatch is an optional hook called with each batch before its records
+	// are individually processed. An error is recorded, but doesn't prevent the
+	// batch from being processed.
+	BeforeBatch func(ctx context.Context, state S, records []R) error
+
+	// OnSweepComplete is an optional hook called after the worker has paged
+	// through all records in a state
+	OnSweepComplete func(ctx context.Context, state S, elapsed time.Duration)
+}
+
+// StateMachine is a worker that continuously polls records by state, and
+// dispatches them to the handler for that state
+type StateMachine[S State, R any] struct {
+	log  *zap.Logger
+	conf StateMachineConfig[S, R]
+}
+
+func NewStateMachine[S State, R any](log *zap.Logger, conf StateMachineConfig[S, R]) *StateMachine[S, R] {
+	if conf.DrainTimeout == 0 {
+		conf.DrainTimeout = defaultDrainTimeout
+	}
+
+	return &StateMachine[S, R]{
+		log:  log.With(zap.String("worker", conf.Name)),
+		conf: conf,
+	}
+}
+
+// Start polls each state with a handler until the provided context is
+// cancelled, then waits for in flight records to finish processing
+func (m *StateMachine[S, R]) Start(ctx context.Context, interval time.Duration) error {
+	// Records in flight aren't interrupted when the worker is stopped, so state
+	// transitions can complete, unless they exceed the drain timeout.
+	handlerCtx, cancelHandlers := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelHandlers()
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-handlerCtx.Done():
+			return
+		}
+
+		select {
+		case <-time.After(m.conf.DrainTimeout):
+			m.log.Warn("drain timeout exceeded, cancelling in flight records")
+			cancelHandlers()
+		case <-handlerCtx.Done():
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for state, handler := range m.conf.Handlers {
+		wg.Add(1)
+
+		go func(state S, handler RecordHandler[R]) {
+			defer wg.Done()
+
+			m.poll(ctx, handlerCtx, state, handler, interval)
+		}(state, handler)
+	}
+	wg.Wait()
+
+	return ctx.Err()
+}
+
+func (m *StateMachine[S, R]) poll(runtimeCtx, handlerCtx context.Context, state S, handler RecordHandler[R], interval time.Duration) {
+	log := m.log.With(zap.String("state", state.String()))
+
+	var cursor query.Cursor
+	sweepStart := time.Now()
+
+	delay := interval
+	if m.conf.PollOnStart {
+		delay = 0
+	}
+
+	for {
+		select {
+		case <-runtimeCtx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		records, err := m.processBatch(runtimeCtx, handlerCtx, state, handler, cursor)
+		if err != nil && runtimeCtx.Err() == nil {
+			log.With(zap.Error(err)).Warn("failure getting records")
+		}
+
+		if len(records) > 0 {
+			cursor = query.ToCursor(m.conf.RecordId(records[len(records)-1]))
+		} else {
+			if err == nil && len(cursor) > 0 && m.conf.OnSweepComplete != nil {
+				m.conf.OnSweepComplete(runtimeCtx, state, time.Since(sweepStart))
+			}
+
+			cursor = query.EmptyCursor
+			sweepStart = time.Now()
+		}
+
+		delay = interval
+	}
+}
+
+func (m *StateMachine[S, R]) processBatch(runtimeCtx, handlerCtx context.Context, state S, handler RecordHandler[R], cursor query.Cursor) ([]R, error) {
+	start := time.Now()
+
+	provider := runtimeCtx.Value(metrics.ProviderContextKey).(metrics.Provider)
+	trace := provider.StartTrace(m.conf.Name + "__handle_" + state.String())
+	defer trace.End()
+	tracedCtx := metrics.NewContext(runtimeCtx, trace)
+
+	records, err := m.conf.Source(
+		tracedCtx,
+		state,
+		query.WithLimit(m.conf.BatchSize(runtimeCtx)),
+		query.WithDirection(m.conf.Direction),
+		query.WithCursor(cursor),
+	)
+	if err != nil && err == m.conf.NotFoundError {
+		return nil, nil
+	} else if err != nil {
+		trace.OnError(err)
+		return nil, err
+	}
+
+	tracedHandlerCtx := metrics.NewContext(handlerCtx, trace)
+
+	if m.conf.BeforeBatch != nil {
+		err := m.conf.BeforeBatch(tracedHandlerCtx, state, records)
+		if err != nil {
+			trace.OnError(err)
+		}
+	}
+
+	var concurrency uint64
+	if m.conf.Concurrency != nil {
+		concurrency = m.conf.Concurrency(runtimeCtx)
+	}
+	if concurrency == 0 || concurrency > uint64(len(records)) {
+		concurrency = uint64(len(records))
+	}
+	sem := make(chan struct{}, concurrency)
+
+	var failures uint64
+	var failuresMu sync.Mutex
+	var wg sync.WaitGroup
+	for _, record := range records {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(record R) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			err := handler(tracedHandlerCtx, record)
+			if err != nil {
+				trace.OnError(err)
+
+				failuresMu.Lock()
+				failures++
+				failuresMu.Unlock()
+			}
+		}(record)
+	}
+	wg.Wait()
+
+	recordBatchProcessedEvent(runtimeCtx, m.conf.Name, state.String(), uint64(len(records)), failures, time.Since(start))
+
+	return records, nil
+}
+
+func recordBatchProcessedEvent(ctx context.Context, name, state string, count, failures uint64, duration time.Duration) {
+	metrics.RecordEvent(ctx, stateMachineBatchEventName, map[string]interface{}{
+		"worker":      name,
+		"state":       state,
+		"count":       count,
+		"failures":    failures,
+		"duration_ms": duration.Milliseconds(),
+	})
+}
@@ -0,0 +1,276 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/code-payments/ocp-server/database/query"
+	"github.com/code-payments/ocp-server/metrics"
+	noop_metrics "github.com/code-payments/ocp-server/metrics/noop"
+)
+
+var errTestNotFound = errors.New("not found")
+
+type testState uint8
+
+const (
+	testStateA testState = iota
+	testStateB
+	testStateC
+)
+
+func (s testState) String() string {
+	switch s {
+	case testStateA:
+		return "a"
+	case testStateB:
+		return "b"
+	case testStateC:
+		return "c"
+	}
+	return "unknown"
+}
+
+type testRecord struct {
+	id    uint64
+	state testState
+}
+
+type testEnv struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	records []*testRecord
+}
+
+func setup(t *testing.T) *testEnv {
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), metrics.ProviderContextKey, noop_metrics.NewProvider()))
+	t.Cleanup(cancel)
+
+	return &testEnv{
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func TestStateMachine_HappyPath(t *testing.T) {
+	env := setup(t)
+	for i := 1; i <= 25; i++ {
+		env.records = append(env.records, &testRecord{id: uint64(i), state: testState(i % 3)})
+	}
+
+	var sweeps atomic.Int32
+	conf := env.newConfig(5, 0)
+	conf.Handlers = map[testState]RecordHandler[*testRecord]{
+		testStateA: env.transitionTo(testStateC),
+		testStateB: env.transitionTo(testStateC),
+	}
+	conf.OnSweepComplete = func(_ context.Context, _ testState, _ time.Duration) {
+		sweeps.Add(1)
+	}
+
+	done := env.start(t, conf)
+
+	require.Eventually(t, func() bool {
+		return env.countByState(testStateC) == 25
+	}, time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		return sweeps.Load() >= 2
+	}, time.Second, 10*time.Millisecond)
+
+	env.cancel()
+	assert.Equal(t, context.Canceled, <-done)
+}
+
+func TestStateMachine_BoundedConcurrency(t *testing.T) {
+	env := setup(t)
+	for i := 1; i <= 20; i++ {
+		env.records = append(env.records, &testRecord{id: uint64(i), state: testStateA})
+	}
+
+	var inFlight, maxInFlight atomic.Int32
+	conf := env.newConfig(20, 3)
+	conf.Handlers = map[testState]RecordHandler[*testRecord]{
+		testStateA: func(ctx context.Context, record *testRecord) error {
+			current := inFlight.Add(1)
+			defer inFlight.Add(-1)
+
+			for {
+				max := maxInFlight.Load()
+				if current <= max || maxInFlight.CompareAndSwap(max, current) {
+					break
+				}
+			}
+
+			time.Sleep(5 * time.Millisecond)
+			return env.transitionTo(testStateB)(ctx, record)
+		},
+	}
+
+	done := env.start(t, conf)
+
+	require.Eventually(t, func() bool {
+		return env.countByState(testStateB) == 20
+	}, time.Second, 10*time.Millisecond)
+	assert.EqualValues(t, 3, maxInFlight.Load())
+
+	env.cancel()
+	<-done
+}
+
+func TestStateMachine_DrainsInFlightRecords(t *testing.T) {
+	env := setup(t)
+	env.records = append(env.records, &testRecord{id: 1, state: testStateA})
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	conf := env.newConfig(1, 0)
+	conf.Handlers = map[testState]RecordHandler[*testRecord]{
+		testStateA: func(ctx context.Context, record *testRecord) error {
+			close(started)
+			<-release
+
+			// The handler context isn't cancelled with the worker
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			return env.transitionTo(testStateB)(ctx, record)
+		},
+	}
+
+	done := env.start(t, conf)
+	<-started
+
+	env.cancel()
+	select {
+	case <-done:
+		require.Fail(t, "worker stopped before draining")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	assert.Equal(t, context.Canceled, <-done)
+	assert.Equal(t, 1, env.countByState(testStateB))
+}
+
+func TestStateMachine_DrainTimeout(t *testing.T) {
+	env := setup(t)
+	env.records = append(env.records, &testRecord{id: 1, state: testStateA})
+
+	started := make(chan struct{})
+	conf := env.newConfig(1, 0)
+	conf.DrainTimeout = 50 * time.Millisecond
+	conf.Handlers = map[testState]RecordHandler[*testRecord]{
+		testStateA: func(ctx context.Context, record *testRecord) error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}
+
+	done := env.start(t, conf)
+	<-started
+
+	env.cancel()
+	select {
+	case err := <-done:
+		assert.Equal(t, context.Canceled, err)
+	case <-time.After(time.Second):
+		require.Fail(t, "worker didn't stop after drain timeout")
+	}
+	assert.Equal(t, 1, env.countByState(testStateA))
+}
+
+func (e *testEnv) newConfig(batchSize, concurrency uint64) StateMachineConfig[testState, *testRecord] {
+	return StateMachineConfig[testState, *testRecord]{
+		Name:   "test_runtime",
+		Source: e.getAllByState,
+		RecordId: func(record *testRecord) uint64 {
+			return record.id
+		},
+		BatchSize: func(_ context.Context) uint64 {
+			return batchSize
+		},
+		Concurrency: func(_ context.Context) uint64 {
+			return concurrency
+		},
+		NotFoundError: errTestNotFound,
+		PollOnStart:   true,
+	}
+}
+
+func (e *testEnv) start(t *testing.T, conf StateMachineConfig[testState, *testRecord]) <-chan error {
+	done := make(chan error, 1)
+	go func() {
+		done <- NewStateMachine(zaptest.NewLogger(t), conf).Start(e.ctx, time.Millisecond)
+	}()
+	return done
+}
+
+func (e *testEnv) getAllByState(_ context.Context, state testState, opts ...query.Option) ([]*testRecord, error) {
+	req, err := query.DefaultPaginationHandler(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor uint64
+	if len(req.Cursor) > 0 {
+		cursor = req.Cursor.ToUint64()
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var res []*testRecord
+	for _, record := range e.records {
+		if record.state != state || record.id <= cursor {
+			continue
+		}
+
+		res = append(res, &testRecord{id: record.id, state: record.state})
+		if uint64(len(res)) == req.Limit {
+			break
+		}
+	}
+
+	if len(res) == 0 {
+		return nil, errTestNotFound
+	}
+	return res, nil
+}
+
+func (e *testEnv) transitionTo(state testState) RecordHandler[*testRecord] {
+	return func(_ context.Context, record *testRecord) error {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+
+		for _, existing := range e.records {
+			if existing.id == record.id {
+				existing.state = state
+			}
+		}
+		return nil
+	}
+}
+
+func (e *testEnv) countByState(state testState) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var res int
+	for _, record := range e.records {
+		if record.state == state {
+			res++
+		}
+	}
+	return res
+}
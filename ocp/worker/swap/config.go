@@ -13,6 +13,9 @@ const (
 	BatchSizeConfigEnvName      = envConfigPrefix + "WORKER_BATCH_SIZE"
 	defaultFulfillmentBatchSize = 100
 
+	ConcurrencyConfigEnvName = envConfigPrefix + "WORKER_CONCURRENCY"
+	defaultConcurrency       = 32
+
 	ClientTimeoutToFundConfigEnvName = envConfigPrefix + "CLIENT_TIMEOUT_TO_FUND"
 	defaultClientTimeoutToFund       = 3 * time.Minute
 
@@ -22,6 +25,7 @@ const (
 
 type conf struct {
 	batchSize           config.Uint64
+	concurrency         config.Uint64
 	clientTimeoutToFund config.Duration
 	clientTimeoutToSwap config.Duration
 }
@@ -34,6 +38,7 @@ func WithEnvConfigs() ConfigProvider {
 	return func() *conf {
 		return &conf{
 			batchSize:           env.NewUint64Config(BatchSizeConfigEnvName, defaultFulfillmentBatchSize),
+			concurrency:         env.NewUint64Config(ConcurrencyConfigEnvName, defaultConcurrency),
 			clientTimeoutToFund: env.NewDurationConfig(ClientTimeoutToFundConfigEnvName, defaultClientTimeoutToFund),
 			clientTimeoutToSwap: env.NewDurationConfig(ClientTimeoutToSwapConfigEnvName, defaultClientTimeoutToSwap),
 		}
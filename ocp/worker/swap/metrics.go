@@ -11,6 +11,8 @@ import (
 const (
 	swapCountEventName     = "SwapCountPollingCheck"
 	swapFinalizedEventName = "SwapFinalized"
+
+	swapCountGaugeName = "SwapCount"
 )
 
 func (p *runtime) metricsGaugeWorker(ctx context.Context) error {
@@ -48,6 +50,9 @@ func recordSwapCountEvent(ctx context.Context, state swap.State, count uint64) {
 		"count": count,
 		"state": state.String(),
 	})
+	metrics.RecordGauge(ctx, swapCountGaugeName, float64(count), map[string]string{
+		"state": state.String(),
+	})
 }
 
 func recordSwapFinalizedEvent(ctx context.Context, swapRecord *swap.Record, quarksBought uint64) {
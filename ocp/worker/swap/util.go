@@ -20,6 +20,7 @@ import (
 	"github.com/code-payments/ocp-server/ocp/data/transaction"
 	transaction_util "github.com/code-payments/ocp-server/ocp/transaction"
 	vm_util "github.com/code-payments/ocp-server/ocp/vm"
+	"github.com/code-payments/ocp-server/ocp/webhook"
 	"github.com/code-payments/ocp-server/solana"
 	compute_budget "github.com/code-payments/ocp-server/solana/computebudget"
 	"github.com/code-payments/ocp-server/solana/memo"
@@ -58,7 +59,12 @@ func (p *runtime) markSwapFinalized(ctx context.Context, record *swap.Record) er
 
 		record.TransactionBlob = nil
 		record.State = swap.StateFinalized
-		return p.data.SaveSwap(ctx, record)
+		err = p.data.SaveSwap(ctx, record)
+		if err != nil {
+			return err
+		}
+
+		return webhook.Enqueue(ctx, p.data, webhook.NewSwapEvent(webhook.EventTypeSwapFinalized, record))
 	})
 }
 
@@ -123,7 +129,12 @@ func (p *runtime) markSwapCancelled(ctx context.Context, record *swap.Record) er
 
 		record.TransactionBlob = nil
 		record.State = swap.StateCancelled
-		return p.data.SaveSwap(ctx, record)
+		err = p.data.SaveSwap(ctx, record)
+		if err != nil {
+			return err
+		}
+
+		return webhook.Enqueue(ctx, p.data, webhook.NewSwapEvent(webhook.EventTypeSwapCancelled, record))
 	})
 }
 
@@ -372,7 +383,7 @@ func (p *runtime) makeCancellationTransaction(ctx context.Context, record *swap.
 		return nil, err
 	}
 
-	memoryAccount, memoryIndex, err := vm_util.GetVirtualTimelockAccountLocationInMemory(ctx, p.vmIndexerClient, sourceVmConfig.Vm, owner)
+	memoryAccount, memoryIndex, err := vm_util.GetVirtualTimelockAccountLocationInMemoryOrRequestDecompression(ctx, p.data, p.vmIndexerClient, sourceVmConfig, owner)
 	if err != nil {
 		return nil, err
 	}
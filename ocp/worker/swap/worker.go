@@ -2,71 +2,41 @@ package swap
 
 import (
 	"context"
-	"sync"
 	"time"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
-	"github.com/code-payments/ocp-server/database/query"
-	"github.com/code-payments/ocp-server/metrics"
 	"github.com/code-payments/ocp-server/ocp/data/intent"
 	"github.com/code-payments/ocp-server/ocp/data/swap"
-	"github.com/code-payments/ocp-server/retry"
+	"github.com/code-payments/ocp-server/ocp/subsidizer"
+	"github.com/code-payments/ocp-server/ocp/worker"
 	"github.com/code-payments/ocp-server/solana"
 )
 
-func (p *runtime) worker(runtimeCtx context.Context, state swap.State, interval time.Duration) error {
-	var cursor query.Cursor
-	delay := interval
-
-	err := retry.Loop(
-		func() (err error) {
-			time.Sleep(delay)
-
-			provider := runtimeCtx.Value(metrics.ProviderContextKey).(metrics.Provider)
-			trace := provider.StartTrace("swap_runtime__handle_" + state.String())
-			defer trace.End()
-			tracedCtx := metrics.NewContext(runtimeCtx, trace)
-
-			items, err := p.data.GetAllSwapsByState(
-				tracedCtx,
-				state,
-				query.WithLimit(p.conf.batchSize.Get(runtimeCtx)),
-				query.WithCursor(cursor),
-			)
-			if err != nil {
-				cursor = query.EmptyCursor
-				return err
-			}
-
-			var wg sync.WaitGroup
-			for _, item := range items {
-				wg.Add(1)
-
-				go func(record *swap.Record) {
-					defer wg.Done()
-
-					err := p.handle(tracedCtx, record)
-					if err != nil {
-						trace.OnError(err)
-					}
-				}(item)
-			}
-			wg.Wait()
-
-			if len(items) > 0 {
-				cursor = query.ToCursor(items[len(items)-1].Id)
-			} else {
-				cursor = query.EmptyCursor
-			}
-
-			return nil
+func (p *runtime) newStateMachine() *worker.StateMachine[swap.State, *swap.Record] {
+	handlers := make(map[swap.State]worker.RecordHandler[*swap.Record])
+	for _, state := range []swap.State{
+		swap.StateCreated,
+		swap.StateFunding,
+		swap.StateFunded,
+		swap.StateSubmitting,
+		swap.StateCancelling,
+	} {
+		handlers[state] = p.handle
+	}
+
+	return worker.NewStateMachine(p.log, worker.StateMachineConfig[swap.State, *swap.Record]{
+		Name:   "swap_runtime",
+		Source: p.data.GetAllSwapsByState,
+		RecordId: func(record *swap.Record) uint64 {
+			return record.Id
 		},
-		retry.NonRetriableErrors(context.Canceled),
-	)
-
-	return err
+		Handlers:      handlers,
+		BatchSize:     p.conf.batchSize.Get,
+		Concurrency:   p.conf.concurrency.Get,
+		NotFoundError: swap.ErrNotFound,
+	})
 }
 
 func (p *runtime) handle(ctx context.Context, record *swap.Record) error {
@@ -125,9 +95,9 @@ func (p *runtime) handleStateFunding(ctx context.Context, record *swap.Record) e
 	switch intentRecord.State {
 	case intent.StateConfirmed:
 		return p.markSwapFunded(ctx, record)
-	case intent.StateFailed:
+	case intent.StateFailed, intent.StateRevoked:
 		// todo: Should never happen, but maybe cancel the swap?
-		return errors.New("funding intent failed")
+		return errors.Errorf("funding intent is in %s state", intentRecord.State)
 	default:
 		return nil
 	}
@@ -171,6 +141,8 @@ func (p *runtime) handleStateSubmitting(ctx context.Context, record *swap.Record
 	}
 
 	if finalizedTxn != nil {
+		p.budget.ObserveFee(ctx, subsidizer.ExpenseSwap, finalizedTxn.Meta.Fee)
+
 		if finalizedTxn.Err != nil || finalizedTxn.Meta.Err != nil {
 			// todo: Recovery flow to put back source funds into the source VM
 			return p.markSwapFailed(ctx, record)
@@ -211,6 +183,8 @@ func (p *runtime) handleStateCancelling(ctx context.Context, record *swap.Record
 	}
 
 	if finalizedTxn != nil {
+		p.budget.ObserveFee(ctx, subsidizer.ExpenseSwap, finalizedTxn.Meta.Fee)
+
 		if finalizedTxn.Err != nil || finalizedTxn.Meta.Err != nil {
 			// todo: Try again?
 			return p.markSwapCancelled(ctx, record)
@@ -0,0 +1,17 @@
+package vault
+
+import (
+	"context"
+
+	"github.com/code-payments/ocp-server/metrics"
+)
+
+const (
+	reEncryptionEventName = "VaultReEncryptionPollingCheck"
+)
+
+func recordReEncryptionFailuresEvent(ctx context.Context, failed int) {
+	metrics.RecordEvent(ctx, reEncryptionEventName, map[string]interface{}{
+		"failed": failed,
+	})
+}
@@ -0,0 +1,91 @@
+package vault
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/code-payments/ocp-server/database/query"
+	"github.com/code-payments/ocp-server/metrics"
+	"github.com/code-payments/ocp-server/ocp/data/vault"
+	"github.com/code-payments/ocp-server/retry"
+)
+
+func (p *runtime) reEncryptionWorker(runtimeCtx context.Context, interval time.Duration) error {
+	delay := interval
+
+	err := retry.Loop(
+		func() (err error) {
+			time.Sleep(delay)
+
+			provider := runtimeCtx.Value(metrics.ProviderContextKey).(metrics.Provider)
+			trace := provider.StartTrace("vault_runtime__re_encryption")
+			defer trace.End()
+			tracedCtx := metrics.NewContext(runtimeCtx, trace)
+
+			err = p.reEncryptAllKeys(tracedCtx)
+			if err != nil {
+				trace.OnError(err)
+			}
+			return err
+		},
+		retry.NonRetriableErrors(context.Canceled),
+	)
+
+	return err
+}
+
+// reEncryptAllKeys walks every key in the vault and rewrites any that aren't
+// encrypted under the newest key version. Keys are listed without decrypting
+// them, so a record that fails to re-encrypt is reported and skipped rather
+// than blocking the rest of the vault.
+func (p *runtime) reEncryptAllKeys(ctx context.Context) error {
+	log := p.log.With(zap.String("method", "reEncryptAllKeys"))
+
+	var failed int
+	for _, state := range []vault.State{
+		vault.StateUnknown,
+		vault.StateAvailable,
+		vault.StateReserved,
+		vault.StateDeprecated,
+		vault.StateRevoked,
+	} {
+		var cursor query.Cursor
+		for {
+			records, err := p.data.GetAllKeyMetadataByState(
+				ctx,
+				state,
+				query.WithCursor(cursor),
+				query.WithDirection(query.Ascending),
+				query.WithLimit(p.conf.batchSize.Get(ctx)),
+			)
+			if err == vault.ErrKeyNotFound {
+				break
+			} else if err != nil {
+				return err
+			}
+
+			for _, record := range records {
+				err := p.data.ReEncryptKey(ctx, record.PublicKey)
+				if err != nil {
+					failed++
+					log.With(
+						zap.Error(err),
+						zap.String("public_key", record.PublicKey),
+						zap.Uint64("id", record.Id),
+					).Warn("failure re-encrypting key")
+				}
+			}
+
+			cursor = query.ToCursor(records[len(records)-1].Id)
+		}
+	}
+
+	recordReEncryptionFailuresEvent(ctx, failed)
+	if failed > 0 {
+		log.With(zap.Int("failed", failed)).Error("some keys could not be re-encrypted")
+	}
+
+	return nil
+}
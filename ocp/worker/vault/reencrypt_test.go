@@ -0,0 +1,146 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/code-payments/ocp-server/config/memory"
+	"github.com/code-payments/ocp-server/config/wrapper"
+	"github.com/code-payments/ocp-server/database/query"
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/vault"
+	vault_memory "github.com/code-payments/ocp-server/ocp/data/vault/memory"
+	vault_tests "github.com/code-payments/ocp-server/ocp/data/vault/tests"
+)
+
+func TestReEncryptAllKeys(t *testing.T) {
+	ctx := context.Background()
+
+	kek := vault_tests.NewKekProvider()
+	encrypter := &corruptingKeyEncrypter{
+		KeyEncrypter: vault.NewEnvelopeKeyEncrypter(kek),
+		corrupted:    make(map[string]struct{}),
+	}
+	data := &testDataProvider{
+		Provider: ocp_data.NewTestDataProvider(),
+		vault:    vault_memory.New(encrypter),
+	}
+
+	p := New(zap.NewNop(), data, func() *conf {
+		return &conf{
+			batchSize: wrapper.NewUint64Config(memory.NewConfig(uint64(3)), defaultBatchSize),
+		}
+	}).(*runtime)
+
+	states := []vault.State{
+		vault.StateUnknown,
+		vault.StateAvailable,
+		vault.StateReserved,
+		vault.StateDeprecated,
+		vault.StateRevoked,
+	}
+
+	var expected []*vault.Record
+	for i := 0; i < 4*len(states); i++ {
+		record, err := vault.CreateKey()
+		require.NoError(t, err)
+		record.State = states[i%len(states)]
+		require.NoError(t, data.SaveKey(ctx, record))
+		expected = append(expected, record)
+	}
+
+	kek.Rotate()
+
+	// One key is already on the newest version
+	record, err := vault.CreateKey()
+	require.NoError(t, err)
+	require.NoError(t, data.SaveKey(ctx, record))
+	expected = append(expected, record)
+
+	for i, record := range expected {
+		actual, err := data.GetKey(ctx, record.PublicKey)
+		require.NoError(t, err)
+		if i == len(expected)-1 {
+			assert.EqualValues(t, 2, actual.KeyVersion)
+		} else {
+			assert.EqualValues(t, 1, actual.KeyVersion)
+		}
+	}
+
+	// One key can't be decrypted, which must not block any other key
+	corrupted, err := vault.CreateKey()
+	require.NoError(t, err)
+	require.NoError(t, data.SaveKey(ctx, corrupted))
+	encrypter.corrupted[corrupted.PublicKey] = struct{}{}
+	kek.Rotate()
+
+	require.NoError(t, p.reEncryptAllKeys(ctx))
+
+	for _, record := range expected {
+		actual, err := data.GetKey(ctx, record.PublicKey)
+		require.NoError(t, err)
+		assert.Equal(t, record.PrivateKey, actual.PrivateKey)
+		assert.Equal(t, record.State, actual.State)
+		assert.EqualValues(t, 3, actual.KeyVersion, fmt.Sprintf("key %s", record.PublicKey))
+	}
+
+	_, err = data.GetKey(ctx, corrupted.PublicKey)
+	assert.Equal(t, errCorruptedKey, err)
+}
+
+// testDataProvider routes vault calls to a store with a rotatable KEK
+type testDataProvider struct {
+	ocp_data.Provider
+
+	vault vault.Store
+}
+
+func (p *testDataProvider) GetKey(ctx context.Context, publicKey string) (*vault.Record, error) {
+	return p.vault.Get(ctx, publicKey)
+}
+
+func (p *testDataProvider) SaveKey(ctx context.Context, record *vault.Record) error {
+	return p.vault.Save(ctx, record)
+}
+
+func (p *testDataProvider) GetAllKeysByState(ctx context.Context, state vault.State, opts ...query.Option) ([]*vault.Record, error) {
+	req, err := query.DefaultPaginationHandlerWithLimit(25, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return p.vault.GetAllByState(ctx, state, req.Cursor, req.Limit, req.SortBy)
+}
+
+func (p *testDataProvider) GetAllKeyMetadataByState(ctx context.Context, state vault.State, opts ...query.Option) ([]*vault.Record, error) {
+	req, err := query.DefaultPaginationHandlerWithLimit(25, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return p.vault.GetAllMetadataByState(ctx, state, req.Cursor, req.Limit, req.SortBy)
+}
+
+func (p *testDataProvider) ReEncryptKey(ctx context.Context, publicKey string) error {
+	return p.vault.ReEncrypt(ctx, publicKey)
+}
+
+var errCorruptedKey = errors.New("corrupted key")
+
+// corruptingKeyEncrypter fails to decrypt private keys for a set of public keys
+type corruptingKeyEncrypter struct {
+	vault.KeyEncrypter
+
+	corrupted map[string]struct{}
+}
+
+func (e *corruptingKeyEncrypter) Decrypt(ctx context.Context, ciphertext, publicKey string) (string, error) {
+	if _, ok := e.corrupted[publicKey]; ok {
+		return "", errCorruptedKey
+	}
+	return e.KeyEncrypter.Decrypt(ctx, ciphertext, publicKey)
+}
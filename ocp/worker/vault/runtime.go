@@ -0,0 +1,41 @@
+package vault
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/worker"
+)
+
+type runtime struct {
+	log  *zap.Logger
+	conf *conf
+	data ocp_data.Provider
+}
+
+// New returns a worker that migrates vault keys to the newest encryption key
+// version
+func New(log *zap.Logger, data ocp_data.Provider, configProvider ConfigProvider) worker.Runtime {
+	return &runtime{
+		log:  log,
+		conf: configProvider(),
+		data: data,
+	}
+}
+
+func (p *runtime) Start(ctx context.Context, interval time.Duration) error {
+	go func() {
+		err := p.reEncryptionWorker(ctx, interval)
+		if err != nil && err != context.Canceled {
+			p.log.With(zap.Error(err)).Warn("vault re-encryption processing loop terminated unexpectedly")
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,110 @@
+package vm
+
+import (
+	"time"
+
+	"github.com/code-payments/ocp-server/config"
+	"github.com/code-payments/ocp-server/config/env"
+	"github.com/code-payments/ocp-server/config/memory"
+	"github.com/code-payments/ocp-server/config/wrapper"
+	vm_program "github.com/code-payments/ocp-server/solana/vm"
+)
+
+const (
+	envConfigPrefix = "VM_MEMORY_MANAGER_RUNTIME_"
+
+	// Minimum free capacity for each virtual account type in a VM before a new
+	// memory account is provisioned
+	MinFreeMemoryCapacityConfigEnvName = envConfigPrefix + "MIN_FREE_MEMORY_CAPACITY"
+	defaultMinFreeMemoryCapacity       = 5_000
+
+	MemoryAccountCapacityConfigEnvName = envConfigPrefix + "MEMORY_ACCOUNT_CAPACITY"
+	defaultMemoryAccountCapacity       = vm_program.MemoryV0NumAccounts
+
+	// Maximum number of memory account resize instructions packed into a single
+	// transaction
+	MaxResizesPerTransactionConfigEnvName = envConfigPrefix + "MAX_RESIZES_PER_TRANSACTION"
+	defaultMaxResizesPerTransaction       = 10
+
+	// Minimum available capacity of archival storage before a new storage
+	// account is provisioned
+	MinFreeStorageCapacityConfigEnvName = envConfigPrefix + "MIN_FREE_STORAGE_CAPACITY"
+	defaultMinFreeStorageCapacity       = 10_000
+
+	// Amount of time a virtual timelock account must be inactive before it's
+	// compressed into archival storage
+	IdleAccountThresholdConfigEnvName = envConfigPrefix + "IDLE_ACCOUNT_THRESHOLD"
+	defaultIdleAccountThreshold       = 90 * 24 * time.Hour
+
+	MaxCompressionsPerRunConfigEnvName = envConfigPrefix + "MAX_COMPRESSIONS_PER_RUN"
+	defaultMaxCompressionsPerRun       = 100
+
+	BatchSizeConfigEnvName = envConfigPrefix + "BATCH_SIZE"
+	defaultBatchSize       = 100
+
+	DisableCompressionConfigEnvName = envConfigPrefix + "DISABLE_COMPRESSION"
+	defaultDisableCompression       = false
+
+	DisableSubmissionConfigEnvName = envConfigPrefix + "DISABLE_SUBMISSION"
+	defaultDisableSubmission       = false
+)
+
+type conf struct {
+	minFreeMemoryCapacity    config.Uint64
+	memoryAccountCapacity    config.Uint64
+	maxResizesPerTransaction config.Uint64
+	minFreeStorageCapacity   config.Uint64
+	idleAccountThreshold     config.Duration
+	maxCompressionsPerRun    config.Uint64
+	batchSize                config.Uint64
+	disableCompression       config.Bool
+	disableSubmission        config.Bool
+}
+
+// ConfigProvider defines how config values are pulled
+type ConfigProvider func() *conf
+
+// WithEnvConfigs returns configuration pulled from environment variables
+func WithEnvConfigs() ConfigProvider {
+	return func() *conf {
+		return &conf{
+			minFreeMemoryCapacity:    env.NewUint64Config(MinFreeMemoryCapacityConfigEnvName, defaultMinFreeMemoryCapacity),
+			memoryAccountCapacity:    env.NewUint64Config(MemoryAccountCapacityConfigEnvName, defaultMemoryAccountCapacity),
+			maxResizesPerTransaction: env.NewUint64Config(MaxResizesPerTransactionConfigEnvName, defaultMaxResizesPerTransaction),
+			minFreeStorageCapacity:   env.NewUint64Config(MinFreeStorageCapacityConfigEnvName, defaultMinFreeStorageCapacity),
+			idleAccountThreshold:     env.NewDurationConfig(IdleAccountThresholdConfigEnvName, defaultIdleAccountThreshold),
+			maxCompressionsPerRun:    env.NewUint64Config(MaxCompressionsPerRunConfigEnvName, defaultMaxCompressionsPerRun),
+			batchSize:                env.NewUint64Config(BatchSizeConfigEnvName, defaultBatchSize),
+			disableCompression:       env.NewBoolConfig(DisableCompressionConfigEnvName, defaultDisableCompression),
+			disableSubmission:        env.NewBoolConfig(DisableSubmissionConfigEnvName, defaultDisableSubmission),
+		}
+	}
+}
+
+type testOverrides struct {
+	minFreeMemoryCapacity    uint64
+	memoryAccountCapacity    uint64
+	maxResizesPerTransaction uint64
+	minFreeStorageCapacity   uint64
+	idleAccountThreshold     time.Duration
+	maxCompressionsPerRun    uint64
+	batchSize                uint64
+	disableCompression       bool
+	disableSubmission        bool
+}
+
+func withManualTestOverrides(overrides *testOverrides) ConfigProvider {
+	return func() *conf {
+		return &conf{
+			minFreeMemoryCapacity:    wrapper.NewUint64Config(memory.NewConfig(overrides.minFreeMemoryCapacity), defaultMinFreeMemoryCapacity),
+			memoryAccountCapacity:    wrapper.NewUint64Config(memory.NewConfig(overrides.memoryAccountCapacity), defaultMemoryAccountCapacity),
+			maxResizesPerTransaction: wrapper.NewUint64Config(memory.NewConfig(overrides.maxResizesPerTransaction), defaultMaxResizesPerTransaction),
+			minFreeStorageCapacity:   wrapper.NewUint64Config(memory.NewConfig(overrides.minFreeStorageCapacity), defaultMinFreeStorageCapacity),
+			idleAccountThreshold:     wrapper.NewDurationConfig(memory.NewConfig(overrides.idleAccountThreshold), defaultIdleAccountThreshold),
+			maxCompressionsPerRun:    wrapper.NewUint64Config(memory.NewConfig(overrides.maxCompressionsPerRun), defaultMaxCompressionsPerRun),
+			batchSize:                wrapper.NewUint64Config(memory.NewConfig(overrides.batchSize), defaultBatchSize),
+			disableCompression:       wrapper.NewBoolConfig(memory.NewConfig(overrides.disableCompression), defaultDisableCompression),
+			disableSubmission:        wrapper.NewBoolConfig(memory.NewConfig(overrides.disableSubmission), defaultDisableSubmission),
+		}
+	}
+}
@@ -0,0 +1,1286 @@
+package vm
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"database/sql"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/mr-tron/base58"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	indexerpb "github.com/code-payments/code-vm-indexer/generated/indexer/v1"
+
+	"github.com/code-payments/ocp-server/database/query"
+	"github.com/code-payments/ocp-server/metrics"
+	"github.com/code-payments/ocp-server/ocp/common"
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/action"
+	"github.com/code-payments/ocp-server/ocp/data/fulfillment"
+	"github.com/code-payments/ocp-server/ocp/data/nonce"
+	"github.com/code-payments/ocp-server/ocp/data/timelock"
+	vm_operation "github.com/code-payments/ocp-server/ocp/data/vm/operation"
+	"github.com/code-payments/ocp-server/ocp/data/vm/ram"
+	"github.com/code-payments/ocp-server/ocp/data/vm/registry"
+	"github.com/code-payments/ocp-server/ocp/data/vm/storage"
+	"github.com/code-payments/ocp-server/ocp/subsidizer"
+	transaction_util "github.com/code-payments/ocp-server/ocp/transaction"
+	vm_util "github.com/code-payments/ocp-server/ocp/vm"
+	"github.com/code-payments/ocp-server/ocp/worker"
+	"github.com/code-payments/ocp-server/retry"
+	"github.com/code-payments/ocp-server/retry/backoff"
+	"github.com/code-payments/ocp-server/solana"
+	vm_program "github.com/code-payments/ocp-server/solana/vm"
+)
+
+const (
+	memoryCapacityPollingCheckEventName = "VmMemoryCapacityPollingCheck"
+	vmOperationSubmittedEventName       = "VmOperationSubmitted"
+	vmOperationFinalizedEventName       = "VmOperationFinalized"
+
+	// Maximum number of bytes an account can grow by in a single instruction
+	maxAccountDataIncreasePerInstruction = 10_240
+
+	// Maximum number of attempts to find an unused memory or storage account name
+	maxAccountNameAttempts = 100
+)
+
+var (
+	errAccountNotIdle = errors.New("account is no longer idle")
+)
+
+type memoryManagerRuntime struct {
+	log             *zap.Logger
+	conf            *conf
+	data            ocp_data.Provider
+	vmIndexerClient indexerpb.IndexerClient
+	noncePool       *transaction_util.LocalNoncePool
+	budget          *subsidizer.Budget
+
+	// Position of the scan for idle virtual accounts in each VM, which wraps
+	// around once all memory allocations have been checked
+	compressionCursors map[string]query.Cursor
+}
+
+// NewMemoryManagerRuntime returns a runtime that manages the lifecycle of VM
+// memory. It provisions memory and storage accounts before capacity is exhausted,
+// compresses idle virtual timelock accounts to free memory, and decompresses
+// them back into memory on demand. Transactions are backed by the internal
+// server process nonce pool.
+func NewMemoryManagerRuntime(log *zap.Logger, data ocp_data.Provider, vmIndexerClient indexerpb.IndexerClient, noncePool *transaction_util.LocalNoncePool, budget *subsidizer.Budget, configProvider ConfigProvider) (worker.Runtime, error) {
+	if err := noncePool.Validate(nonce.EnvironmentSolana, nonce.EnvironmentInstanceSolanaMainnet, nonce.PurposeInternalServerProcess); err != nil {
+		return nil, err
+	}
+
+	return &memoryManagerRuntime{
+		log:                log,
+		conf:               configProvider(),
+		data:               data,
+		vmIndexerClient:    vmIndexerClient,
+		noncePool:          noncePool,
+		budget:             budget,
+		compressionCursors: make(map[string]query.Cursor),
+	}, nil
+}
+
+func (p *memoryManagerRuntime) Start(runtimeCtx context.Context, interval time.Duration) error {
+	for {
+		_, err := retry.Retry(
+			func() error {
+				p.log.Debug("managing vm memory")
+
+				provider := runtimeCtx.Value(metrics.ProviderContextKey).(metrics.Provider)
+				trace := provider.StartTrace("vm_memory_manager_runtime")
+				defer trace.End()
+				tracedCtx := metrics.NewContext(runtimeCtx, trace)
+
+				var firstErr error
+				for _, step := range []struct {
+					description string
+					run         func(context.Context) error
+				}{
+					{"process pending vm operations", p.ProcessPendingOperations},
+					{"process decompression requests", p.ProcessDecompressionRequests},
+					{"provision vm memory and storage", p.ProvisionMemoryAndStorage},
+					{"compress idle virtual accounts", p.CompressIdleAccounts},
+				} {
+					err := step.run(tracedCtx)
+					if err != nil {
+						trace.OnError(err)
+						p.log.With(zap.Error(err)).Warn("failed to " + step.description)
+
+						if firstErr == nil {
+							firstErr = err
+						}
+					}
+				}
+				return firstErr
+			},
+			retry.NonRetriableErrors(context.Canceled),
+			retry.BackoffWithJitter(backoff.BinaryExponential(time.Second), interval, 0.1),
+		)
+		if err != nil {
+			if err != context.Canceled {
+				// Should not happen since only non-retriable error is context.Canceled
+				p.log.With(zap.Error(err)).Warn("unexpected error when managing vm memory")
+			}
+
+			return err
+		}
+
+		select {
+		case <-runtimeCtx.Done():
+			return runtimeCtx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// ProcessPendingOperations advances operations whose transactions have been
+// finalized on the blockchain, and resubmits the transactions for the rest
+func (p *memoryManagerRuntime) ProcessPendingOperations(ctx context.Context) error {
+	log := p.log.With(zap.String("method", "ProcessPendingOperations"))
+
+	return p.forEachOperationInState(ctx, vm_operation.StatePending, func(record *vm_operation.Record) error {
+		err := p.processPendingOperation(ctx, record)
+		if err != nil {
+			log.With(
+				zap.Error(err),
+				zap.String("type", record.Type.String()),
+				zap.String("address", record.Address),
+				zap.String("signature", record.Signature),
+			).Warn("failure processing pending vm operation")
+		}
+		return err
+	})
+}
+
+func (p *memoryManagerRuntime) processPendingOperation(ctx context.Context, record *vm_operation.Record) error {
+	finalizedTxn, err := p.data.GetBlockchainTransaction(ctx, record.Signature, solana.CommitmentFinalized)
+	if err != nil && err != solana.ErrSignatureNotFound {
+		return errors.Wrap(err, "error getting finalized transaction")
+	}
+
+	if finalizedTxn == nil {
+		// Continually retry submitting the transaction until it's finalized
+		return p.submitTransaction(ctx, record)
+	}
+
+	p.budget.ObserveFee(ctx, subsidizer.ExpenseVmOperation, finalizedTxn.Meta.Fee)
+
+	if finalizedTxn.Err != nil || finalizedTxn.Meta.Err != nil {
+		return p.markOperationFinalized(ctx, record, vm_operation.StateFailed)
+	}
+
+	if record.Type == vm_operation.TypeInitMemory {
+		accountInfo, err := p.data.GetBlockchainAccountInfo(ctx, record.Address, solana.CommitmentFinalized)
+		if err != nil {
+			return errors.Wrap(err, "error getting memory account")
+		}
+
+		size := uint32(len(accountInfo.Data))
+		if size > record.TargetSize {
+			return errors.New("memory account exceeds target size")
+		}
+		record.Size = size
+
+		if record.Size < record.TargetSize {
+			return p.resizeMemory(ctx, record)
+		}
+	}
+
+	return p.markOperationFinalized(ctx, record, vm_operation.StateConfirmed)
+}
+
+// ProcessDecompressionRequests makes and submits transactions to decompress
+// virtual accounts back into memory that were requested on demand
+func (p *memoryManagerRuntime) ProcessDecompressionRequests(ctx context.Context) error {
+	log := p.log.With(zap.String("method", "ProcessDecompressionRequests"))
+
+	return p.forEachOperationInState(ctx, vm_operation.StateRequested, func(record *vm_operation.Record) error {
+		err := p.decompressAccount(ctx, record)
+		if err != nil {
+			log.With(
+				zap.Error(err),
+				zap.String("vm", record.Vm),
+				zap.String("address", record.Address),
+			).Warn("failure processing decompression request")
+		}
+		return err
+	})
+}
+
+func (p *memoryManagerRuntime) decompressAccount(ctx context.Context, record *vm_operation.Record) (err error) {
+	if record.Type != vm_operation.TypeDecompress {
+		return errors.New("unexpected vm operation type")
+	}
+
+	if record.StoredAccountType != vm_program.VirtualAccountTypeTimelock {
+		return errors.New("only virtual timelock accounts can be decompressed")
+	}
+
+	vmConfig, err := p.getVmConfig(ctx, record.Vm)
+	if err != nil {
+		return err
+	}
+
+	owner, err := common.NewAccountFromPublicKeyString(record.Owner)
+	if err != nil {
+		return err
+	}
+
+	timelockAccounts, err := owner.GetTimelockAccounts(vmConfig)
+	if err != nil {
+		return err
+	}
+
+	if timelockAccounts.Vault.PublicKey().ToBase58() != record.Address {
+		return errors.New("unexpected timelock vault address")
+	}
+
+	state, storageAccount, proof, err := vm_util.GetVirtualTimelockAccountStateInStorage(ctx, p.vmIndexerClient, vmConfig.Vm, owner)
+	if err == vm_util.ErrVirtualAccountNotCompressed {
+		// The account is already back in memory, so there's nothing to do
+		record.State = vm_operation.StateConfirmed
+		return p.data.UpdateVmOperation(ctx, record)
+	} else if err != nil {
+		return errors.Wrap(err, "error getting virtual account state in storage")
+	}
+
+	withdrawReceiptAddress, _, err := vm_program.GetWithdrawReceiptAccountAddress(&vm_program.GetWithdrawReceiptAccountAddressArgs{
+		UnlockAccount: timelockAccounts.Unlock.PublicKey().ToBytes(),
+		Nonce:         state.Nonce,
+		Vm:            vmConfig.Vm.PublicKey().ToBytes(),
+	})
+	if err != nil {
+		return err
+	}
+
+	withdrawReceipt, err := common.NewAccountFromPublicKeyBytes(withdrawReceiptAddress)
+	if err != nil {
+		return err
+	}
+
+	reservationId := getReservationId(record.Address)
+	err = p.budget.Reserve(ctx, reservationId, subsidizer.ExpenseVmOperation)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			p.budget.Release(reservationId)
+		}
+	}()
+
+	selectedNonce, err := p.noncePool.GetNonce(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		selectedNonce.ReleaseIfNotReserved(ctx)
+	}()
+
+	err = p.data.ExecuteInTx(ctx, sql.LevelDefault, func(ctx context.Context) error {
+		memoryAddress, memoryIndex, err := p.data.ReserveVmMemory(ctx, record.Vm, record.StoredAccountType, record.Address)
+		if err != nil {
+			return err
+		}
+
+		memoryAccount, err := common.NewAccountFromPublicKeyString(memoryAddress)
+		if err != nil {
+			return err
+		}
+
+		txn, err := transaction_util.MakeDecompressAccountTransaction(
+			selectedNonce,
+
+			vmConfig,
+
+			memoryAccount,
+			memoryIndex,
+
+			storageAccount,
+
+			timelockAccounts.Unlock,
+			withdrawReceipt,
+
+			record.StoredAccountType,
+			state.Marshal(),
+			proof,
+		)
+		if err != nil {
+			return err
+		}
+
+		err = signTransaction(&txn, vmConfig)
+		if err != nil {
+			return err
+		}
+
+		record.MemoryAccount = memoryAddress
+		record.MemoryIndex = memoryIndex
+		record.StorageAccount = storageAccount.PublicKey().ToBase58()
+		setOperationTransaction(record, selectedNonce, &txn)
+		record.State = vm_operation.StatePending
+
+		err = selectedNonce.MarkReservedWithSignature(ctx, record.Signature)
+		if err != nil {
+			return err
+		}
+
+		return p.data.UpdateVmOperation(ctx, record)
+	})
+	if err != nil {
+		return err
+	}
+
+	p.onOperationSubmitted(ctx, record)
+	return nil
+}
+
+// ProvisionMemoryAndStorage creates new memory accounts in each VM before free
+// capacity is exhausted, and new archival storage accounts before available
+// capacity for compressed virtual accounts is exhausted
+func (p *memoryManagerRuntime) ProvisionMemoryAndStorage(ctx context.Context) error {
+	log := p.log.With(zap.String("method", "ProvisionMemoryAndStorage"))
+
+	vmConfigs, err := p.getAllVmConfigs(ctx)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for _, vmConfig := range vmConfigs {
+		log := log.With(zap.String("vm", vmConfig.Vm.PublicKey().ToBase58()))
+
+		for _, accountType := range []vm_program.VirtualAccountType{
+			vm_program.VirtualAccountTypeTimelock,
+			vm_program.VirtualAccountTypeDurableNonce,
+		} {
+			err = p.provisionMemory(ctx, vmConfig, accountType)
+			if err != nil {
+				log.With(zap.Error(err), zap.Uint8("account_type", uint8(accountType))).Warn("failure provisioning memory")
+				failed++
+			}
+		}
+
+		err = p.provisionStorage(ctx, vmConfig)
+		if err != nil {
+			log.With(zap.Error(err)).Warn("failure provisioning storage")
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return errors.Errorf("failed to provision %d memory or storage accounts", failed)
+	}
+	return nil
+}
+
+func (p *memoryManagerRuntime) provisionMemory(ctx context.Context, vmConfig *common.VmConfig, accountType vm_program.VirtualAccountType) (err error) {
+	vmAddress := vmConfig.Vm.PublicKey().ToBase58()
+
+	memoryAccountCount, err := p.data.GetVmMemoryAccountCount(ctx, vmAddress, accountType)
+	if err != nil {
+		return err
+	}
+
+	freeCapacity, err := p.data.GetFreeVmMemoryCapacity(ctx, vmAddress, accountType)
+	if err != nil {
+		return err
+	}
+
+	metrics.RecordEvent(ctx, memoryCapacityPollingCheckEventName, map[string]interface{}{
+		"vm":                   vmAddress,
+		"account_type":         uint8(accountType),
+		"memory_account_count": memoryAccountCount,
+		"free_capacity":        freeCapacity,
+	})
+
+	// Every VM opens virtual timelock accounts, but virtual durable nonces are
+	// only used in VMs that have been explicitly setup with memory for them.
+	if accountType != vm_program.VirtualAccountTypeTimelock && memoryAccountCount == 0 {
+		return nil
+	}
+
+	if freeCapacity >= p.conf.minFreeMemoryCapacity.Get(ctx) {
+		return nil
+	}
+
+	// Memory accounts are provisioned one at a time per VM
+	inFlightCount, err := p.data.GetVmOperationCountByVmTypeAndState(ctx, vmAddress, vm_operation.TypeInitMemory, vm_operation.StatePending)
+	if err != nil {
+		return err
+	} else if inFlightCount > 0 {
+		return nil
+	}
+
+	numAccounts := p.conf.memoryAccountCapacity.Get(ctx)
+	if numAccounts == 0 || numAccounts > math.MaxUint16 {
+		return errors.New("invalid memory account capacity")
+	}
+	accountSize := vm_program.GetVirtualAccountSizeInMemory(accountType)
+	targetSize := getMemoryAccountSize(uint32(numAccounts), accountSize)
+
+	// Rent is paid by the VM authority, which is the subsidizer for the core
+	// mint VM
+	if bytes.Equal(vmConfig.Authority.PublicKey().ToBytes(), common.GetSubsidizer().PublicKey().ToBytes()) {
+		rent, err := p.data.GetBlockchainMinimumBalanceForRentExemption(ctx, uint64(targetSize))
+		if err != nil {
+			return err
+		}
+
+		snapshot, err := p.budget.GetSnapshot(ctx)
+		if err != nil {
+			return err
+		}
+
+		if snapshot.Available < rent {
+			return common.ErrSubsidizerRequiresFunding
+		}
+	}
+
+	name, memoryAccount, err := p.findUnusedAccountName(ctx, vmConfig, getMemoryAccountNamePrefix(accountType), memoryAccountCount, vm_program.GetMemoryAccountAddress)
+	if err != nil {
+		return err
+	}
+
+	reservationId := getReservationId(memoryAccount.PublicKey().ToBase58())
+	err = p.budget.Reserve(ctx, reservationId, subsidizer.ExpenseVmOperation)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			p.budget.Release(reservationId)
+		}
+	}()
+
+	selectedNonce, err := p.noncePool.GetNonce(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		selectedNonce.ReleaseIfNotReserved(ctx)
+	}()
+
+	txn, err := transaction_util.MakeInitMemoryTransaction(
+		selectedNonce,
+
+		vmConfig,
+
+		name,
+		uint32(numAccounts),
+		uint16(accountSize),
+
+		getMemoryResizes(vm_program.MemoryAccountSize, targetSize, p.conf.maxResizesPerTransaction.Get(ctx))...,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = signTransaction(&txn, vmConfig)
+	if err != nil {
+		return err
+	}
+
+	record := &vm_operation.Record{
+		Vm: vmAddress,
+
+		Type: vm_operation.TypeInitMemory,
+
+		Address: memoryAccount.PublicKey().ToBase58(),
+
+		Name:              name,
+		StoredAccountType: accountType,
+		NumAccounts:       uint32(numAccounts),
+		TargetSize:        targetSize,
+
+		State: vm_operation.StatePending,
+	}
+	setOperationTransaction(record, selectedNonce, &txn)
+
+	return p.putOperation(ctx, record, selectedNonce)
+}
+
+func (p *memoryManagerRuntime) provisionStorage(ctx context.Context, vmConfig *common.VmConfig) (err error) {
+	vmAddress := vmConfig.Vm.PublicKey().ToBase58()
+
+	_, err = p.data.FindAnyVmStorageWithAvailableCapacity(ctx, vmAddress, storage.PurposeArchival, p.conf.minFreeStorageCapacity.Get(ctx))
+	if err == nil {
+		return nil
+	} else if err != storage.ErrNotFound {
+		return err
+	}
+
+	// Storage accounts are provisioned one at a time per VM
+	inFlightCount, err := p.data.GetVmOperationCountByVmTypeAndState(ctx, vmAddress, vm_operation.TypeInitStorage, vm_operation.StatePending)
+	if err != nil {
+		return err
+	} else if inFlightCount > 0 {
+		return nil
+	}
+
+	confirmedCount, err := p.data.GetVmOperationCountByVmTypeAndState(ctx, vmAddress, vm_operation.TypeInitStorage, vm_operation.StateConfirmed)
+	if err != nil {
+		return err
+	}
+
+	name, storageAccount, err := p.findUnusedAccountName(ctx, vmConfig, "ocp_archive", confirmedCount, vm_program.GetStorageAccountAddress)
+	if err != nil {
+		return err
+	}
+
+	reservationId := getReservationId(storageAccount.PublicKey().ToBase58())
+	err = p.budget.Reserve(ctx, reservationId, subsidizer.ExpenseVmOperation)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			p.budget.Release(reservationId)
+		}
+	}()
+
+	selectedNonce, err := p.noncePool.GetNonce(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		selectedNonce.ReleaseIfNotReserved(ctx)
+	}()
+
+	txn, err := transaction_util.MakeInitStorageTransaction(selectedNonce, vmConfig, name)
+	if err != nil {
+		return err
+	}
+
+	err = signTransaction(&txn, vmConfig)
+	if err != nil {
+		return err
+	}
+
+	record := &vm_operation.Record{
+		Vm: vmAddress,
+
+		Type: vm_operation.TypeInitStorage,
+
+		Address: storageAccount.PublicKey().ToBase58(),
+
+		Name: name,
+
+		State: vm_operation.StatePending,
+	}
+	setOperationTransaction(record, selectedNonce, &txn)
+
+	return p.putOperation(ctx, record, selectedNonce)
+}
+
+// CompressIdleAccounts compresses virtual timelock accounts that have been
+// inactive for a long period of time into archival storage to free memory.
+// Each call checks the next batch of memory allocations in every VM.
+func (p *memoryManagerRuntime) CompressIdleAccounts(ctx context.Context) error {
+	log := p.log.With(zap.String("method", "CompressIdleAccounts"))
+
+	if p.conf.disableCompression.Get(ctx) {
+		return nil
+	}
+
+	vmConfigs, err := p.getAllVmConfigs(ctx)
+	if err != nil {
+		return err
+	}
+
+	remaining := p.conf.maxCompressionsPerRun.Get(ctx)
+
+	var failed int
+	for _, vmConfig := range vmConfigs {
+		if remaining == 0 {
+			break
+		}
+
+		log := log.With(zap.String("vm", vmConfig.Vm.PublicKey().ToBase58()))
+
+		compressed, err := p.compressIdleAccountsInVm(ctx, vmConfig, remaining)
+		remaining -= compressed
+		if err == common.ErrSubsidizerRequiresFunding {
+			log.Warn("subsidizer requires funding to compress idle accounts")
+			failed++
+			break
+		} else if err != nil {
+			log.With(zap.Error(err)).Warn("failure compressing idle accounts")
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return errors.Errorf("failed to compress idle accounts in %d vms", failed)
+	}
+	return nil
+}
+
+func (p *memoryManagerRuntime) compressIdleAccountsInVm(ctx context.Context, vmConfig *common.VmConfig, limit uint64) (uint64, error) {
+	vmAddress := vmConfig.Vm.PublicKey().ToBase58()
+	batchSize := p.conf.batchSize.Get(ctx)
+	idleThreshold := p.conf.idleAccountThreshold.Get(ctx)
+
+	allocations, err := p.data.GetAllVmMemoryAllocations(
+		ctx,
+		vmAddress,
+		vm_program.VirtualAccountTypeTimelock,
+		query.WithCursor(p.compressionCursors[vmAddress]),
+		query.WithDirection(query.Ascending),
+		query.WithLimit(batchSize),
+	)
+	if err == ram.ErrNoAllocations {
+		delete(p.compressionCursors, vmAddress)
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	var compressed uint64
+	for i, allocation := range allocations {
+		if compressed >= limit {
+			p.compressionCursors[vmAddress] = query.ToCursor(allocations[i-1].Id)
+			return compressed, nil
+		}
+
+		isIdle, err := p.isIdleTimelockAccount(ctx, allocation, idleThreshold)
+		if err != nil {
+			return compressed, err
+		} else if !isIdle {
+			continue
+		}
+
+		err = p.compressAccount(ctx, vmConfig, allocation, idleThreshold)
+		if err == storage.ErrNoFreeStorage {
+			// Wait for archival storage to be provisioned
+			return compressed, nil
+		} else if err == errAccountNotIdle {
+			continue
+		} else if err != nil {
+			return compressed, err
+		}
+
+		compressed++
+	}
+
+	if uint64(len(allocations)) < batchSize {
+		delete(p.compressionCursors, vmAddress)
+	} else {
+		p.compressionCursors[vmAddress] = query.ToCursor(allocations[len(allocations)-1].Id)
+	}
+	return compressed, nil
+}
+
+// isIdleTimelockAccount determines whether a virtual timelock account in memory
+// has had no activity for at least the idle threshold, and has no pending work
+// that would require it to be in memory
+func (p *memoryManagerRuntime) isIdleTimelockAccount(ctx context.Context, allocation *ram.Allocation, idleThreshold time.Duration) (bool, error) {
+	cutoff := time.Now().Add(-idleThreshold)
+
+	if allocation.LastUpdatedAt.After(cutoff) {
+		return false, nil
+	}
+
+	timelockRecord, err := p.data.GetTimelockByVault(ctx, allocation.Address)
+	if err == timelock.ErrTimelockNotFound {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	if !timelockRecord.ExistsOnBlockchain() || !timelockRecord.IsLocked() || timelockRecord.LastUpdatedAt.After(cutoff) {
+		return false, nil
+	}
+
+	_, err = p.data.GetFirstSchedulableFulfillmentByAddressAsSource(ctx, allocation.Address)
+	if err == nil {
+		return false, nil
+	} else if err != fulfillment.ErrFulfillmentNotFound {
+		return false, err
+	}
+
+	_, err = p.data.GetFirstSchedulableFulfillmentByAddressAsDestination(ctx, allocation.Address)
+	if err == nil {
+		return false, nil
+	} else if err != fulfillment.ErrFulfillmentNotFound {
+		return false, err
+	}
+
+	actionRecords, err := p.data.GetAllActionsByAddress(ctx, allocation.Address)
+	if err != nil && err != action.ErrActionNotFound {
+		return false, err
+	}
+	for _, actionRecord := range actionRecords {
+		if actionRecord.CreatedAt.After(cutoff) {
+			return false, nil
+		}
+	}
+
+	latestOperation, err := p.data.GetLatestVmOperationByAddress(ctx, allocation.Address)
+	if err == nil && (latestOperation.State.IsInFlight() || latestOperation.CreatedAt.After(cutoff)) {
+		return false, nil
+	} else if err != nil && err != vm_operation.ErrNotFound {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// compressAccount compresses an idle virtual timelock account into archival
+// storage. Idleness is rechecked in the same DB transaction that creates the
+// operation, which stops usage of the account from new fulfillments until the
+// operation completes.
+func (p *memoryManagerRuntime) compressAccount(ctx context.Context, vmConfig *common.VmConfig, allocation *ram.Allocation, idleThreshold time.Duration) (err error) {
+	timelockRecord, err := p.data.GetTimelockByVault(ctx, allocation.Address)
+	if err != nil {
+		return err
+	}
+
+	owner, err := common.NewAccountFromPublicKeyString(timelockRecord.VaultOwner)
+	if err != nil {
+		return err
+	}
+
+	state, memoryAccount, memoryIndex, err := vm_util.GetVirtualTimelockAccountStateInMemory(ctx, p.vmIndexerClient, vmConfig.Vm, owner)
+	if err != nil {
+		return errors.Wrap(err, "error getting virtual account state in memory")
+	}
+
+	if memoryAccount.PublicKey().ToBase58() != allocation.MemoryAccount || memoryIndex != allocation.Index {
+		return errors.New("virtual account memory location mismatch")
+	}
+
+	reservationId := getReservationId(allocation.Address)
+	err = p.budget.Reserve(ctx, reservationId, subsidizer.ExpenseVmOperation)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			p.budget.Release(reservationId)
+		}
+	}()
+
+	selectedNonce, err := p.noncePool.GetNonce(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		selectedNonce.ReleaseIfNotReserved(ctx)
+	}()
+
+	record := &vm_operation.Record{
+		Vm: allocation.Vm,
+
+		Type: vm_operation.TypeCompress,
+
+		Address: allocation.Address,
+
+		Owner: owner.PublicKey().ToBase58(),
+
+		StoredAccountType: vm_program.VirtualAccountTypeTimelock,
+
+		MemoryAccount: allocation.MemoryAccount,
+		MemoryIndex:   allocation.Index,
+
+		State: vm_operation.StatePending,
+	}
+
+	err = p.data.ExecuteInTx(ctx, sql.LevelDefault, func(ctx context.Context) error {
+		// An intent may have been submitted for the account since it was
+		// determined to be idle
+		isIdle, err := p.isIdleTimelockAccount(ctx, allocation, idleThreshold)
+		if err != nil {
+			return err
+		} else if !isIdle {
+			return errAccountNotIdle
+		}
+
+		storageAddress, err := p.data.ReserveVmStorage(ctx, allocation.Vm, storage.PurposeArchival, allocation.Address)
+		if err != nil {
+			return err
+		}
+
+		storageAccount, err := common.NewAccountFromPublicKeyString(storageAddress)
+		if err != nil {
+			return err
+		}
+
+		txn, err := transaction_util.MakeCompressAccountTransaction(
+			selectedNonce,
+
+			vmConfig,
+
+			memoryAccount,
+			memoryIndex,
+
+			storageAccount,
+
+			state.Marshal(),
+		)
+		if err != nil {
+			return err
+		}
+
+		err = signTransaction(&txn, vmConfig)
+		if err != nil {
+			return err
+		}
+
+		record.StorageAccount = storageAddress
+		setOperationTransaction(record, selectedNonce, &txn)
+
+		err = selectedNonce.MarkReservedWithSignature(ctx, record.Signature)
+		if err != nil {
+			return err
+		}
+
+		return p.data.PutVmOperation(ctx, record)
+	})
+	if err != nil {
+		return err
+	}
+
+	p.onOperationSubmitted(ctx, record)
+	return nil
+}
+
+// resizeMemory continues resizing a memory account after the previous
+// transaction was finalized, using a new nonce
+func (p *memoryManagerRuntime) resizeMemory(ctx context.Context, record *vm_operation.Record) error {
+	vmConfig, err := p.getVmConfig(ctx, record.Vm)
+	if err != nil {
+		return err
+	}
+
+	memoryAccount, err := common.NewAccountFromPublicKeyString(record.Address)
+	if err != nil {
+		return err
+	}
+
+	selectedNonce, err := p.noncePool.GetNonce(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		selectedNonce.ReleaseIfNotReserved(ctx)
+	}()
+
+	txn, err := transaction_util.MakeResizeMemoryTransaction(
+		selectedNonce,
+
+		vmConfig,
+
+		memoryAccount,
+
+		getMemoryResizes(record.Size, record.TargetSize, p.conf.maxResizesPerTransaction.Get(ctx))...,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = signTransaction(&txn, vmConfig)
+	if err != nil {
+		return err
+	}
+
+	err = p.data.ExecuteInTx(ctx, sql.LevelDefault, func(ctx context.Context) error {
+		err := p.releaseNonce(ctx, record)
+		if err != nil {
+			return err
+		}
+
+		setOperationTransaction(record, selectedNonce, &txn)
+
+		err = selectedNonce.MarkReservedWithSignature(ctx, record.Signature)
+		if err != nil {
+			return err
+		}
+
+		return p.data.UpdateVmOperation(ctx, record)
+	})
+	if err != nil {
+		return err
+	}
+
+	p.onOperationSubmitted(ctx, record)
+	return nil
+}
+
+func (p *memoryManagerRuntime) putOperation(ctx context.Context, record *vm_operation.Record, selectedNonce *transaction_util.Nonce) error {
+	err := p.data.ExecuteInTx(ctx, sql.LevelDefault, func(ctx context.Context) error {
+		err := selectedNonce.MarkReservedWithSignature(ctx, record.Signature)
+		if err != nil {
+			return err
+		}
+
+		return p.data.PutVmOperation(ctx, record)
+	})
+	if err != nil {
+		return err
+	}
+
+	p.onOperationSubmitted(ctx, record)
+	return nil
+}
+
+func (p *memoryManagerRuntime) onOperationSubmitted(ctx context.Context, record *vm_operation.Record) {
+	metrics.RecordEvent(ctx, vmOperationSubmittedEventName, map[string]interface{}{
+		"vm":        record.Vm,
+		"type":      record.Type.String(),
+		"address":   record.Address,
+		"signature": record.Signature,
+	})
+
+	// Failures are retried when pending operations are processed
+	submitErr := p.submitTransaction(ctx, record)
+	if submitErr != nil {
+		p.log.With(
+			zap.Error(submitErr),
+			zap.String("type", record.Type.String()),
+			zap.String("address", record.Address),
+			zap.String("signature", record.Signature),
+		).Warn("failure submitting vm operation transaction")
+	}
+}
+
+func (p *memoryManagerRuntime) submitTransaction(ctx context.Context, record *vm_operation.Record) error {
+	if p.conf.disableSubmission.Get(ctx) {
+		return nil
+	}
+
+	var txn solana.Transaction
+	err := txn.Unmarshal(record.TransactionBlob)
+	if err != nil {
+		return errors.Wrap(err, "error unmarshalling transaction")
+	}
+
+	if base58.Encode(txn.Signature()) != record.Signature {
+		return errors.New("unexpected transaction signature")
+	}
+
+	_, err = p.data.SubmitBlockchainTransaction(ctx, &txn)
+	if err != nil {
+		return errors.Wrap(err, "error submitting transaction")
+	}
+	return nil
+}
+
+func (p *memoryManagerRuntime) markOperationFinalized(ctx context.Context, record *vm_operation.Record, state vm_operation.State) error {
+	err := p.data.ExecuteInTx(ctx, sql.LevelDefault, func(ctx context.Context) error {
+		if record.State != vm_operation.StatePending {
+			return errors.New("invalid vm operation state")
+		}
+
+		err := p.releaseNonce(ctx, record)
+		if err != nil {
+			return err
+		}
+
+		if state == vm_operation.StateConfirmed {
+			err = p.onOperationConfirmed(ctx, record)
+		} else {
+			err = p.onOperationFailed(ctx, record)
+		}
+		if err != nil {
+			return err
+		}
+
+		record.TransactionBlob = nil
+		record.State = state
+		return p.data.UpdateVmOperation(ctx, record)
+	})
+	if err != nil {
+		return err
+	}
+
+	metrics.RecordEvent(ctx, vmOperationFinalizedEventName, map[string]interface{}{
+		"vm":        record.Vm,
+		"type":      record.Type.String(),
+		"address":   record.Address,
+		"signature": record.Signature,
+		"state":     record.State.String(),
+	})
+
+	return nil
+}
+
+func (p *memoryManagerRuntime) onOperationConfirmed(ctx context.Context, record *vm_operation.Record) error {
+	switch record.Type {
+	case vm_operation.TypeInitMemory:
+		accountSize := vm_program.GetVirtualAccountSizeInMemory(record.StoredAccountType)
+		err := p.data.InitializeVmMemory(ctx, &ram.Record{
+			Vm: record.Vm,
+
+			Address: record.Address,
+
+			Capacity:   uint16(record.NumAccounts),
+			NumSectors: 1,
+			NumPages:   uint16(record.NumAccounts),
+			PageSize:   uint8(accountSize),
+
+			StoredAccountType: record.StoredAccountType,
+		})
+		if err == ram.ErrAlreadyInitialized {
+			return nil
+		}
+		return err
+	case vm_operation.TypeInitStorage:
+		err := p.data.InitializeVmStorage(ctx, &storage.Record{
+			Vm: record.Vm,
+
+			Address: record.Address,
+
+			Levels:            vm_program.DefaultCompressedStateDepth,
+			AvailableCapacity: storage.GetMaxCapacity(vm_program.DefaultCompressedStateDepth),
+			Purpose:           storage.PurposeArchival,
+		})
+		if err == storage.ErrAlreadyInitialized {
+			return nil
+		}
+		return err
+	case vm_operation.TypeCompress:
+		err := p.data.FreeVmMemoryByAddress(ctx, record.Address)
+		if err == ram.ErrNotReserved {
+			return nil
+		}
+		return err
+	case vm_operation.TypeDecompress:
+		err := p.data.FreeVmStorageByAddress(ctx, record.Address)
+		if err == storage.ErrNotReserved {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// onOperationFailed releases any memory or storage that was reserved for a
+// virtual account that never made it to its new location
+func (p *memoryManagerRuntime) onOperationFailed(ctx context.Context, record *vm_operation.Record) error {
+	switch record.Type {
+	case vm_operation.TypeCompress:
+		err := p.data.FreeVmStorageByAddress(ctx, record.Address)
+		if err == storage.ErrNotReserved {
+			return nil
+		}
+		return err
+	case vm_operation.TypeDecompress:
+		err := p.data.FreeVmMemoryByAddress(ctx, record.Address)
+		if err == ram.ErrNotReserved {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func (p *memoryManagerRuntime) releaseNonce(ctx context.Context, record *vm_operation.Record) error {
+	nonceRecord, err := p.data.GetNonce(ctx, record.Nonce)
+	if err != nil {
+		return err
+	}
+
+	if nonceRecord.Signature != record.Signature {
+		return errors.New("unexpected nonce signature")
+	}
+
+	if nonceRecord.Blockhash != record.Blockhash {
+		return errors.New("unexpected nonce blockhash")
+	}
+
+	if nonceRecord.State != nonce.StateReserved {
+		return errors.New("unexpected nonce state")
+	}
+
+	nonceRecord.State = nonce.StateReleased
+	return p.data.SaveNonce(ctx, nonceRecord)
+}
+
+func (p *memoryManagerRuntime) forEachOperationInState(ctx context.Context, state vm_operation.State, process func(record *vm_operation.Record) error) error {
+	batchSize := p.conf.batchSize.Get(ctx)
+
+	var cursor query.Cursor
+	var failed int
+	for {
+		records, err := p.data.GetAllVmOperationsByState(
+			ctx,
+			state,
+			query.WithCursor(cursor),
+			query.WithDirection(query.Ascending),
+			query.WithLimit(batchSize),
+		)
+		if err == vm_operation.ErrNotFound {
+			break
+		} else if err != nil {
+			return errors.Wrapf(err, "error getting %s vm operations", state.String())
+		}
+
+		for _, record := range records {
+			err = process(record)
+			if err != nil {
+				failed++
+			}
+		}
+
+		if uint64(len(records)) < batchSize {
+			break
+		}
+		cursor = query.ToCursor(records[len(records)-1].Id)
+	}
+
+	if failed > 0 {
+		return errors.Errorf("failed to process %d %s vm operations", failed, state.String())
+	}
+	return nil
+}
+
+// findUnusedAccountName finds the first deterministically named memory or
+// storage account, starting at the provided index, that doesn't exist on the
+// blockchain and isn't used by any prior operation
+func (p *memoryManagerRuntime) findUnusedAccountName(
+	ctx context.Context,
+	vmConfig *common.VmConfig,
+	prefix string,
+	startIndex uint64,
+	getAddress func(args *vm_program.GetMemoryAccountAddressArgs) (ed25519.PublicKey, uint8, error),
+) (string, *common.Account, error) {
+	for i := startIndex; i < startIndex+maxAccountNameAttempts; i++ {
+		name := getAccountName(prefix, i)
+
+		address, _, err := getAddress(&vm_program.GetMemoryAccountAddressArgs{
+			Name: name,
+			Vm:   vmConfig.Vm.PublicKey().ToBytes(),
+		})
+		if err != nil {
+			return "", nil, err
+		}
+
+		account, err := common.NewAccountFromPublicKeyBytes(address)
+		if err != nil {
+			return "", nil, err
+		}
+
+		_, err = p.data.GetLatestVmOperationByAddress(ctx, account.PublicKey().ToBase58())
+		if err == nil {
+			continue
+		} else if err != vm_operation.ErrNotFound {
+			return "", nil, err
+		}
+
+		_, err = p.data.GetBlockchainAccountInfo(ctx, account.PublicKey().ToBase58(), solana.CommitmentFinalized)
+		if err == solana.ErrNoAccountInfo {
+			return name, account, nil
+		} else if err != nil {
+			return "", nil, err
+		}
+	}
+
+	return "", nil, errors.New("no unused account name found")
+}
+
+// getAllVmConfigs gets the configs for the core mint VM and all registered VMs
+func (p *memoryManagerRuntime) getAllVmConfigs(ctx context.Context) ([]*common.VmConfig, error) {
+	coreMintVmConfig, err := common.GetVmConfigForMint(ctx, p.data, common.CoreMintAccount)
+	if err != nil {
+		return nil, err
+	}
+	vmConfigs := []*common.VmConfig{coreMintVmConfig}
+
+	registryRecords, err := p.data.GetAllRegisteredVms(ctx)
+	if err == registry.ErrNotFound {
+		return vmConfigs, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "error getting registered vms")
+	}
+
+	for _, registryRecord := range registryRecords {
+		mint, err := common.NewAccountFromPublicKeyString(registryRecord.Mint)
+		if err != nil {
+			return nil, err
+		}
+
+		vmConfig, err := common.GetVmConfigForMint(ctx, p.data, mint)
+		if err != nil {
+			return nil, err
+		}
+		vmConfigs = append(vmConfigs, vmConfig)
+	}
+
+	return vmConfigs, nil
+}
+
+func (p *memoryManagerRuntime) getVmConfig(ctx context.Context, vm string) (*common.VmConfig, error) {
+	vmConfigs, err := p.getAllVmConfigs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, vmConfig := range vmConfigs {
+		if vmConfig.Vm.PublicKey().ToBase58() == vm {
+			return vmConfig, nil
+		}
+	}
+	return nil, errors.New("vm config not found")
+}
+
+// signTransaction signs a transaction with the subsidizer, which pays fees, and
+// the VM authority
+func signTransaction(txn *solana.Transaction, vmConfig *common.VmConfig) error {
+	signers := []ed25519.PrivateKey{common.GetSubsidizer().PrivateKey().ToBytes()}
+	if !bytes.Equal(vmConfig.Authority.PublicKey().ToBytes(), common.GetSubsidizer().PublicKey().ToBytes()) {
+		signers = append(signers, vmConfig.Authority.PrivateKey().ToBytes())
+	}
+	return txn.Sign(signers...)
+}
+
+func setOperationTransaction(record *vm_operation.Record, selectedNonce *transaction_util.Nonce, txn *solana.Transaction) {
+	record.Signature = base58.Encode(txn.Signature())
+	record.Nonce = selectedNonce.Account.PublicKey().ToBase58()
+	record.Blockhash = base58.Encode(selectedNonce.Blockhash[:])
+	record.TransactionBlob = txn.Marshal()
+}
+
+// There's at most one operation in flight per address, and it's counted as
+// committed by the budget once it's saved, so a single reservation per address
+// suffices.
+func getReservationId(address string) string {
+	return "vm_operation:" + address
+}
+
+// getMemoryAccountSize gets the total account data size of a memory account
+// that stores the provided number of virtual accounts
+func getMemoryAccountSize(numAccounts, accountSizeInMemory uint32) uint32 {
+	return uint32(vm_program.MemoryAccountSize + vm_program.GetSliceAllocatorSize(int(numAccounts), int(accountSizeInMemory)))
+}
+
+// getMemoryResizes gets the account data sizes to resize a memory account to, in
+// order, to grow it from its current size towards the target size
+func getMemoryResizes(currentSize, targetSize uint32, maxResizes uint64) []uint32 {
+	var resizes []uint32
+	for currentSize < targetSize && uint64(len(resizes)) < maxResizes {
+		currentSize = min(currentSize+maxAccountDataIncreasePerInstruction, targetSize)
+		resizes = append(resizes, currentSize)
+	}
+	return resizes
+}
+
+func getMemoryAccountNamePrefix(accountType vm_program.VirtualAccountType) string {
+	switch accountType {
+	case vm_program.VirtualAccountTypeDurableNonce:
+		return "ocp_nonce"
+	default:
+		return "ocp_timelock"
+	}
+}
+
+func getAccountName(prefix string, index uint64) string {
+	return fmt.Sprintf("%s_%d", prefix, index)
+}
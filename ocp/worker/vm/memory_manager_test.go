@@ -0,0 +1,196 @@
+package vm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/code-payments/ocp-server/database/query"
+	"github.com/code-payments/ocp-server/ocp/common"
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/action"
+	"github.com/code-payments/ocp-server/ocp/data/intent"
+	vm_operation "github.com/code-payments/ocp-server/ocp/data/vm/operation"
+	"github.com/code-payments/ocp-server/ocp/data/vm/ram"
+	"github.com/code-payments/ocp-server/pointer"
+	timelock_token_v1 "github.com/code-payments/ocp-server/solana/timelock/v1"
+	vm_program "github.com/code-payments/ocp-server/solana/vm"
+	"github.com/code-payments/ocp-server/testutil"
+)
+
+func TestGetMemoryResizes(t *testing.T) {
+	for _, tc := range []struct {
+		currentSize uint32
+		targetSize  uint32
+		maxResizes  uint64
+		expected    []uint32
+	}{
+		{0, 0, 10, nil},
+		{100, 100, 10, nil},
+		{100, 50, 10, nil},
+		{100, 5_000, 10, []uint32{5_000}},
+		{100, 30_000, 10, []uint32{10_340, 20_580, 30_000}},
+		{100, 30_000, 2, []uint32{10_340, 20_580}},
+		{100, 30_000, 0, nil},
+	} {
+		assert.Equal(t, tc.expected, getMemoryResizes(tc.currentSize, tc.targetSize, tc.maxResizes))
+	}
+}
+
+func TestGetMemoryAccountSize(t *testing.T) {
+	accountSize := vm_program.GetVirtualAccountSizeInMemory(vm_program.VirtualAccountTypeTimelock)
+
+	actual := getMemoryAccountSize(vm_program.MemoryV0NumAccounts, accountSize)
+	assert.EqualValues(t, vm_program.MemoryAccountSize+vm_program.MemoryV0NumAccounts*(vm_program.ItemStateSize+accountSize), actual)
+
+	// Resizing must eventually reach the target size
+	var resizes []uint32
+	currentSize := uint32(vm_program.MemoryAccountSize)
+	for currentSize < actual {
+		resizes = getMemoryResizes(currentSize, actual, 10)
+		require.NotEmpty(t, resizes)
+		currentSize = resizes[len(resizes)-1]
+	}
+	assert.Equal(t, actual, currentSize)
+}
+
+func TestGetAccountName(t *testing.T) {
+	for _, accountType := range []vm_program.VirtualAccountType{
+		vm_program.VirtualAccountTypeTimelock,
+		vm_program.VirtualAccountTypeDurableNonce,
+	} {
+		name := getAccountName(getMemoryAccountNamePrefix(accountType), 1_000_000)
+		assert.True(t, len(name) <= vm_program.MaxMemoryAccountNameLength)
+	}
+	assert.Equal(t, "ocp_timelock_12", getAccountName(getMemoryAccountNamePrefix(vm_program.VirtualAccountTypeTimelock), 12))
+	assert.Equal(t, "ocp_nonce_0", getAccountName(getMemoryAccountNamePrefix(vm_program.VirtualAccountTypeDurableNonce), 0))
+	assert.True(t, len(getAccountName("ocp_archive", 1_000_000)) <= vm_program.MaxStorageAccountNameSize)
+}
+
+func TestIsIdleTimelockAccount(t *testing.T) {
+	ctx := context.Background()
+	data := ocp_data.NewTestDataProvider()
+	require.NoError(t, common.InjectTestSubsidizer(ctx, data, testutil.NewRandomAccount(t)))
+
+	p := &memoryManagerRuntime{
+		log:  zaptest.NewLogger(t),
+		conf: withManualTestOverrides(&testOverrides{})(),
+		data: data,
+	}
+
+	idleThreshold := 50 * time.Millisecond
+	waitForIdleThreshold := func() {
+		time.Sleep(2 * idleThreshold)
+	}
+
+	vmConfig := testutil.NewRandomVmConfig(t, true)
+	owner := testutil.NewRandomAccount(t)
+	timelockAccounts, err := owner.GetTimelockAccounts(vmConfig)
+	require.NoError(t, err)
+	vault := timelockAccounts.Vault.PublicKey().ToBase58()
+
+	require.NoError(t, data.InitializeVmMemory(ctx, &ram.Record{
+		Vm:                vmConfig.Vm.PublicKey().ToBase58(),
+		Address:           testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+		Capacity:          10,
+		NumSectors:        1,
+		NumPages:          10,
+		PageSize:          uint8(vm_program.GetVirtualAccountSizeInMemory(vm_program.VirtualAccountTypeTimelock)),
+		StoredAccountType: vm_program.VirtualAccountTypeTimelock,
+	}))
+	_, _, err = data.ReserveVmMemory(ctx, vmConfig.Vm.PublicKey().ToBase58(), vm_program.VirtualAccountTypeTimelock, vault)
+	require.NoError(t, err)
+
+	allocations, err := data.GetAllVmMemoryAllocations(ctx, vmConfig.Vm.PublicKey().ToBase58(), vm_program.VirtualAccountTypeTimelock, query.WithLimit(10))
+	require.NoError(t, err)
+	require.Len(t, allocations, 1)
+	allocation := allocations[0]
+
+	// Timelock account isn't known
+	waitForIdleThreshold()
+	isIdle, err := p.isIdleTimelockAccount(ctx, allocation, idleThreshold)
+	require.NoError(t, err)
+	assert.False(t, isIdle)
+
+	// Timelock account isn't locked
+	timelockRecord := timelockAccounts.ToDBRecord()
+	timelockRecord.VaultState = timelock_token_v1.StateWaitingForTimeout
+	timelockRecord.Block = 1
+	require.NoError(t, data.SaveTimelock(ctx, timelockRecord))
+
+	waitForIdleThreshold()
+	isIdle, err = p.isIdleTimelockAccount(ctx, allocation, idleThreshold)
+	require.NoError(t, err)
+	assert.False(t, isIdle)
+
+	// Timelock account is locked, but was recently updated
+	timelockRecord.VaultState = timelock_token_v1.StateLocked
+	timelockRecord.Block = 2
+	require.NoError(t, data.SaveTimelock(ctx, timelockRecord))
+
+	isIdle, err = p.isIdleTimelockAccount(ctx, allocation, idleThreshold)
+	require.NoError(t, err)
+	assert.False(t, isIdle)
+
+	// Timelock account has been inactive for the idle threshold
+	waitForIdleThreshold()
+	isIdle, err = p.isIdleTimelockAccount(ctx, allocation, idleThreshold)
+	require.NoError(t, err)
+	assert.True(t, isIdle)
+
+	// Memory allocation was recently updated
+	isIdle, err = p.isIdleTimelockAccount(ctx, allocation, time.Hour)
+	require.NoError(t, err)
+	assert.False(t, isIdle)
+
+	// Recent action involving the timelock account
+	require.NoError(t, data.PutAllActions(ctx, &action.Record{
+		Intent:     testutil.NewRandomAccount(t).PublicKey().ToBase58(),
+		IntentType: intent.SendPublicPayment,
+
+		ActionId:   0,
+		ActionType: action.NoPrivacyTransfer,
+
+		Source:      vault,
+		Destination: pointer.String(testutil.NewRandomAccount(t).PublicKey().ToBase58()),
+		Quantity:    pointer.Uint64(1),
+
+		State: action.StateConfirmed,
+	}))
+
+	isIdle, err = p.isIdleTimelockAccount(ctx, allocation, idleThreshold)
+	require.NoError(t, err)
+	assert.False(t, isIdle)
+
+	waitForIdleThreshold()
+	isIdle, err = p.isIdleTimelockAccount(ctx, allocation, idleThreshold)
+	require.NoError(t, err)
+	assert.True(t, isIdle)
+
+	// In flight operation involving the timelock account
+	operationRecord := &vm_operation.Record{
+		Vm:                vmConfig.Vm.PublicKey().ToBase58(),
+		Type:              vm_operation.TypeDecompress,
+		Address:           vault,
+		Owner:             owner.PublicKey().ToBase58(),
+		StoredAccountType: vm_program.VirtualAccountTypeTimelock,
+		State:             vm_operation.StateRequested,
+	}
+	require.NoError(t, data.PutVmOperation(ctx, operationRecord))
+
+	waitForIdleThreshold()
+	isIdle, err = p.isIdleTimelockAccount(ctx, allocation, idleThreshold)
+	require.NoError(t, err)
+	assert.False(t, isIdle)
+
+	operationRecord.State = vm_operation.StateConfirmed
+	require.NoError(t, data.UpdateVmOperation(ctx, operationRecord))
+
+	isIdle, err = p.isIdleTimelockAccount(ctx, allocation, idleThreshold)
+	require.NoError(t, err)
+	assert.True(t, isIdle)
+}
@@ -0,0 +1,85 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/code-payments/ocp-server/config"
+	"github.com/code-payments/ocp-server/config/env"
+	"github.com/code-payments/ocp-server/config/memory"
+	"github.com/code-payments/ocp-server/config/wrapper"
+)
+
+const (
+	envConfigPrefix = "WEBHOOK_RUNTIME_"
+
+	EndpointUrlConfigEnvName = envConfigPrefix + "ENDPOINT_URL"
+	defaultEndpointUrl       = ""
+
+	SigningSecretConfigEnvName = envConfigPrefix + "SIGNING_SECRET"
+	defaultSigningSecret       = ""
+
+	BatchSizeConfigEnvName = envConfigPrefix + "WORKER_BATCH_SIZE"
+	defaultBatchSize       = 100
+
+	MaxAttemptsConfigEnvName = envConfigPrefix + "MAX_ATTEMPTS"
+	defaultMaxAttempts       = 12
+
+	BaseRetryDelayConfigEnvName = envConfigPrefix + "BASE_RETRY_DELAY"
+	defaultBaseRetryDelay       = 10 * time.Second
+
+	MaxRetryDelayConfigEnvName = envConfigPrefix + "MAX_RETRY_DELAY"
+	defaultMaxRetryDelay       = time.Hour
+
+	RequestTimeoutConfigEnvName = envConfigPrefix + "REQUEST_TIMEOUT"
+	defaultRequestTimeout       = 10 * time.Second
+)
+
+type conf struct {
+	endpointUrl    config.String
+	signingSecret  config.String
+	batchSize      config.Uint64
+	maxAttempts    config.Uint64
+	baseRetryDelay config.Duration
+	maxRetryDelay  config.Duration
+	requestTimeout config.Duration
+}
+
+// ConfigProvider defines how config values are pulled
+type ConfigProvider func() *conf
+
+// WithEnvConfigs returns configuration pulled from environment variables
+func WithEnvConfigs() ConfigProvider {
+	return func() *conf {
+		return &conf{
+			endpointUrl:    env.NewStringConfig(EndpointUrlConfigEnvName, defaultEndpointUrl),
+			signingSecret:  env.NewStringConfig(SigningSecretConfigEnvName, defaultSigningSecret),
+			batchSize:      env.NewUint64Config(BatchSizeConfigEnvName, defaultBatchSize),
+			maxAttempts:    env.NewUint64Config(MaxAttemptsConfigEnvName, defaultMaxAttempts),
+			baseRetryDelay: env.NewDurationConfig(BaseRetryDelayConfigEnvName, defaultBaseRetryDelay),
+			maxRetryDelay:  env.NewDurationConfig(MaxRetryDelayConfigEnvName, defaultMaxRetryDelay),
+			requestTimeout: env.NewDurationConfig(RequestTimeoutConfigEnvName, defaultRequestTimeout),
+		}
+	}
+}
+
+type testOverrides struct {
+	endpointUrl    string
+	signingSecret  string
+	maxAttempts    uint64
+	baseRetryDelay time.Duration
+	maxRetryDelay  time.Duration
+}
+
+func withManualTestOverrides(overrides *testOverrides) ConfigProvider {
+	return func() *conf {
+		return &conf{
+			endpointUrl:    wrapper.NewStringConfig(memory.NewConfig(overrides.endpointUrl), defaultEndpointUrl),
+			signingSecret:  wrapper.NewStringConfig(memory.NewConfig(overrides.signingSecret), defaultSigningSecret),
+			batchSize:      wrapper.NewUint64Config(memory.NewConfig(uint64(defaultBatchSize)), defaultBatchSize),
+			maxAttempts:    wrapper.NewUint64Config(memory.NewConfig(overrides.maxAttempts), defaultMaxAttempts),
+			baseRetryDelay: wrapper.NewDurationConfig(memory.NewConfig(overrides.baseRetryDelay), defaultBaseRetryDelay),
+			maxRetryDelay:  wrapper.NewDurationConfig(memory.NewConfig(overrides.maxRetryDelay), defaultMaxRetryDelay),
+			requestTimeout: wrapper.NewDurationConfig(memory.NewConfig(time.Second), defaultRequestTimeout),
+		}
+	}
+}
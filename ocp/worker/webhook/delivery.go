@@ -0,0 +1,160 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/code-payments/ocp-server/metrics"
+	"github.com/code-payments/ocp-server/ocp/data/webhook"
+	webhook_util "github.com/code-payments/ocp-server/ocp/webhook"
+	"github.com/code-payments/ocp-server/retry"
+	"github.com/code-payments/ocp-server/retry/backoff"
+)
+
+func (p *runtime) deliveryWorker(runtimeCtx context.Context, interval time.Duration) error {
+	delay := interval
+
+	err := retry.Loop(
+		func() (err error) {
+			time.Sleep(delay)
+
+			provider := runtimeCtx.Value(metrics.ProviderContextKey).(metrics.Provider)
+			trace := provider.StartTrace("webhook_runtime__delivery")
+			defer trace.End()
+			tracedCtx := metrics.NewContext(runtimeCtx, trace)
+
+			err = p.deliverReadyEvents(tracedCtx)
+			if err != nil {
+				trace.OnError(err)
+			}
+			return err
+		},
+		retry.NonRetriableErrors(context.Canceled),
+	)
+
+	return err
+}
+
+var errMissingSigningSecret = errors.New("webhook signing secret is not configured")
+
+// deliverReadyEvents attempts delivery for a batch of events that are due.
+// Events are never delivered unsigned, so they stay queued until a signing
+// secret is configured.
+func (p *runtime) deliverReadyEvents(ctx context.Context) error {
+	if len(p.conf.endpointUrl.Get(ctx)) == 0 {
+		return nil
+	}
+	if len(p.conf.signingSecret.Get(ctx)) == 0 {
+		return errMissingSigningSecret
+	}
+
+	records, err := p.data.GetAllWebhookEventsReadyForDelivery(ctx, time.Now(), p.conf.batchSize.Get(ctx))
+	if err == webhook.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for _, record := range records {
+		wg.Add(1)
+
+		go func(record *webhook.Record) {
+			defer wg.Done()
+
+			err := p.handle(ctx, record)
+			if err != nil {
+				p.log.With(
+					zap.Error(err),
+					zap.String("event_id", record.EventId),
+				).Warn("failure handling webhook event")
+			}
+		}(record)
+	}
+	wg.Wait()
+
+	return nil
+}
+
+func (p *runtime) handle(ctx context.Context, record *webhook.Record) error {
+	// Claim the event by pushing out its next attempt beyond the time it takes
+	// to deliver it, so other replicas won't pick it up concurrently
+	record.NextAttemptAt = time.Now().Add(2 * p.conf.requestTimeout.Get(ctx))
+	err := p.data.UpdateWebhookEvent(ctx, record)
+	if err == webhook.ErrStaleVersion {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "error claiming webhook event")
+	}
+
+	record.Attempts++
+
+	start := time.Now()
+	deliveryErr := p.deliver(ctx, record)
+	recordWebhookDeliveryEvent(ctx, record, time.Since(start), deliveryErr)
+
+	switch {
+	case deliveryErr == nil:
+		record.State = webhook.StateDelivered
+	case uint64(record.Attempts) >= p.conf.maxAttempts.Get(ctx):
+		p.log.With(
+			zap.Error(deliveryErr),
+			zap.String("event_id", record.EventId),
+			zap.Uint32("attempts", record.Attempts),
+		).Warn("webhook event delivery failed after max attempts")
+
+		record.State = webhook.StateFailed
+	default:
+		record.NextAttemptAt = time.Now().Add(p.getRetryDelay(ctx, record.Attempts))
+	}
+
+	return p.data.UpdateWebhookEvent(ctx, record)
+}
+
+// deliver POSTs the signed event payload to the configured endpoint. Any
+// non-2xx response is considered a failed delivery.
+func (p *runtime) deliver(ctx context.Context, record *webhook.Record) error {
+	ctx, cancel := context.WithTimeout(ctx, p.conf.requestTimeout.Get(ctx))
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.conf.endpointUrl.Get(ctx), bytes.NewReader(record.Payload))
+	if err != nil {
+		return err
+	}
+
+	timestamp := time.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhook_util.EventIdHeader, record.EventId)
+	req.Header.Set(webhook_util.TimestampHeader, strconv.FormatInt(timestamp.Unix(), 10))
+	req.Header.Set(webhook_util.SignatureHeader, webhook_util.Sign([]byte(p.conf.signingSecret.Get(ctx)), record.EventId, timestamp, record.Payload))
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("unexpected http status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (p *runtime) getRetryDelay(ctx context.Context, attempts uint32) time.Duration {
+	delay := backoff.BinaryExponential(p.conf.baseRetryDelay.Get(ctx))(uint(attempts))
+
+	maxDelay := p.conf.maxRetryDelay.Get(ctx)
+	if delay > maxDelay || delay <= 0 {
+		return maxDelay
+	}
+	return delay
+}
@@ -0,0 +1,228 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/code-payments/ocp-server/config/memory"
+	"github.com/code-payments/ocp-server/config/wrapper"
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/data/webhook"
+	webhook_util "github.com/code-payments/ocp-server/ocp/webhook"
+)
+
+func TestDeliverReadyEvents_HappyPath(t *testing.T) {
+	env := setup(t, &testOverrides{})
+
+	record := env.putEvent(t, "event1")
+	env.putEvent(t, "event2")
+
+	require.NoError(t, env.runtime.deliverReadyEvents(env.ctx))
+
+	for _, eventId := range []string{"event1", "event2"} {
+		actual, err := env.data.GetWebhookEvent(env.ctx, eventId)
+		require.NoError(t, err)
+		assert.Equal(t, webhook.StateDelivered, actual.State)
+		assert.EqualValues(t, 1, actual.Attempts)
+	}
+
+	env.server.mu.Lock()
+	defer env.server.mu.Unlock()
+
+	require.Len(t, env.server.requests, 2)
+	for _, req := range env.server.requests {
+		if req.eventId != record.EventId {
+			continue
+		}
+		assert.Equal(t, record.Payload, req.body)
+		assert.NoError(t, webhook_util.Verify([]byte("secret"), req.eventId, req.timestamp, req.signature, req.body, time.Minute))
+	}
+
+	// Delivered events aren't delivered again
+	require.NoError(t, env.runtime.deliverReadyEvents(env.ctx))
+	assert.Len(t, env.server.requests, 2)
+}
+
+func TestDeliverReadyEvents_RetryWithBackoff(t *testing.T) {
+	env := setup(t, &testOverrides{
+		maxAttempts:    3,
+		baseRetryDelay: time.Minute,
+		maxRetryDelay:  90 * time.Second,
+	})
+	env.server.setStatusCode(http.StatusInternalServerError)
+
+	env.putEvent(t, "event")
+
+	for i, expectedDelay := range []time.Duration{time.Minute, 90 * time.Second} {
+		start := time.Now()
+		require.NoError(t, env.runtime.deliverReadyEvents(env.ctx))
+
+		actual, err := env.data.GetWebhookEvent(env.ctx, "event")
+		require.NoError(t, err)
+		assert.Equal(t, webhook.StatePending, actual.State)
+		assert.EqualValues(t, i+1, actual.Attempts)
+		assert.WithinDuration(t, start.Add(expectedDelay), actual.NextAttemptAt, time.Second)
+
+		// Not ready for delivery until the backoff elapses
+		require.NoError(t, env.runtime.deliverReadyEvents(env.ctx))
+		assert.Len(t, env.server.getRequests(), i+1)
+
+		env.makeReady(t, "event")
+	}
+
+	require.NoError(t, env.runtime.deliverReadyEvents(env.ctx))
+
+	actual, err := env.data.GetWebhookEvent(env.ctx, "event")
+	require.NoError(t, err)
+	assert.Equal(t, webhook.StateFailed, actual.State)
+	assert.EqualValues(t, 3, actual.Attempts)
+	assert.Len(t, env.server.getRequests(), 3)
+}
+
+func TestDeliverReadyEvents_RecoversAfterFailure(t *testing.T) {
+	env := setup(t, &testOverrides{
+		maxAttempts: 3,
+	})
+	env.server.setStatusCode(http.StatusServiceUnavailable)
+
+	env.putEvent(t, "event")
+
+	require.NoError(t, env.runtime.deliverReadyEvents(env.ctx))
+	env.makeReady(t, "event")
+
+	env.server.setStatusCode(http.StatusOK)
+	require.NoError(t, env.runtime.deliverReadyEvents(env.ctx))
+
+	actual, err := env.data.GetWebhookEvent(env.ctx, "event")
+	require.NoError(t, err)
+	assert.Equal(t, webhook.StateDelivered, actual.State)
+	assert.EqualValues(t, 2, actual.Attempts)
+}
+
+func TestDeliverReadyEvents_MissingSigningSecret(t *testing.T) {
+	env := setup(t, &testOverrides{})
+	env.runtime.conf.signingSecret = wrapper.NewStringConfig(memory.NewConfig(""), defaultSigningSecret)
+
+	env.putEvent(t, "event1")
+
+	assert.Equal(t, errMissingSigningSecret, env.runtime.deliverReadyEvents(env.ctx))
+	assert.Equal(t, errMissingSigningSecret, env.runtime.Start(env.ctx, time.Second))
+
+	actual, err := env.data.GetWebhookEvent(env.ctx, "event1")
+	require.NoError(t, err)
+	assert.Equal(t, webhook.StatePending, actual.State)
+	assert.EqualValues(t, 0, actual.Attempts)
+
+	env.server.mu.Lock()
+	defer env.server.mu.Unlock()
+	assert.Empty(t, env.server.requests)
+}
+
+func TestHandle_ClaimedByAnotherReplica(t *testing.T) {
+	env := setup(t, &testOverrides{})
+
+	record := env.putEvent(t, "event")
+	stale := record.Clone()
+
+	// Another replica claims the event first
+	require.NoError(t, env.runtime.handle(env.ctx, record))
+	require.NoError(t, env.runtime.handle(env.ctx, &stale))
+
+	assert.Len(t, env.server.getRequests(), 1)
+}
+
+type testEnv struct {
+	ctx     context.Context
+	data    ocp_data.Provider
+	runtime *runtime
+	server  *testServer
+}
+
+func setup(t *testing.T, overrides *testOverrides) *testEnv {
+	server := &testServer{
+		statusCode: http.StatusOK,
+	}
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	overrides.endpointUrl = httpServer.URL
+	overrides.signingSecret = "secret"
+
+	data := ocp_data.NewTestDataProvider()
+	return &testEnv{
+		ctx:     context.Background(),
+		data:    data,
+		runtime: New(zap.NewNop(), data, withManualTestOverrides(overrides)).(*runtime),
+		server:  server,
+	}
+}
+
+func (e *testEnv) putEvent(t *testing.T, eventId string) *webhook.Record {
+	record := &webhook.Record{
+		EventId:       eventId,
+		EventType:     string(webhook_util.EventTypeIntentCreated),
+		Payload:       []byte(`{"id":"` + eventId + `"}`),
+		State:         webhook.StatePending,
+		NextAttemptAt: time.Now().Add(-time.Second),
+	}
+	require.NoError(t, e.data.PutWebhookEvent(e.ctx, record))
+	return record
+}
+
+func (e *testEnv) makeReady(t *testing.T, eventId string) {
+	record, err := e.data.GetWebhookEvent(e.ctx, eventId)
+	require.NoError(t, err)
+	record.NextAttemptAt = time.Now().Add(-time.Second)
+	require.NoError(t, e.data.UpdateWebhookEvent(e.ctx, record))
+}
+
+type testRequest struct {
+	eventId   string
+	timestamp string
+	signature string
+	body      []byte
+}
+
+type testServer struct {
+	mu         sync.Mutex
+	statusCode int
+	requests   []*testRequest
+}
+
+func (s *testServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, &testRequest{
+		eventId:   r.Header.Get(webhook_util.EventIdHeader),
+		timestamp: r.Header.Get(webhook_util.TimestampHeader),
+		signature: r.Header.Get(webhook_util.SignatureHeader),
+		body:      body,
+	})
+	w.WriteHeader(s.statusCode)
+}
+
+func (s *testServer) setStatusCode(statusCode int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.statusCode = statusCode
+}
+
+func (s *testServer) getRequests() []*testRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests
+}
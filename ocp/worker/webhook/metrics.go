@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/code-payments/ocp-server/metrics"
+	"github.com/code-payments/ocp-server/ocp/data/webhook"
+)
+
+const (
+	webhookEventCountEventName = "WebhookEventCountPollingCheck"
+	webhookDeliveryEventName   = "WebhookDelivery"
+)
+
+func (p *runtime) metricsGaugeWorker(ctx context.Context) error {
+	delay := time.Second
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+			start := time.Now()
+
+			for _, state := range []webhook.State{
+				webhook.StatePending,
+				webhook.StateFailed,
+			} {
+				count, err := p.data.GetWebhookEventCountByState(ctx, state)
+				if err != nil {
+					continue
+				}
+				recordWebhookEventCountEvent(ctx, state, count)
+			}
+
+			delay = time.Second - time.Since(start)
+		}
+	}
+}
+
+func recordWebhookEventCountEvent(ctx context.Context, state webhook.State, count uint64) {
+	metrics.RecordEvent(ctx, webhookEventCountEventName, map[string]interface{}{
+		"count": count,
+		"state": state.String(),
+	})
+}
+
+func recordWebhookDeliveryEvent(ctx context.Context, record *webhook.Record, latency time.Duration, err error) {
+	kvs := map[string]interface{}{
+		"event_type": record.EventType,
+		"attempt":    record.Attempts,
+		"latency_ms": latency.Milliseconds(),
+		"success":    err == nil,
+	}
+	if err != nil {
+		kvs["error"] = err.Error()
+	}
+	metrics.RecordEvent(ctx, webhookDeliveryEventName, kvs)
+}
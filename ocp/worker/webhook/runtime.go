@@ -0,0 +1,54 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	ocp_data "github.com/code-payments/ocp-server/ocp/data"
+	"github.com/code-payments/ocp-server/ocp/worker"
+)
+
+type runtime struct {
+	log        *zap.Logger
+	conf       *conf
+	data       ocp_data.Provider
+	httpClient *http.Client
+}
+
+// New returns a worker that delivers webhook events from the outbox
+func New(log *zap.Logger, data ocp_data.Provider, configProvider ConfigProvider) worker.Runtime {
+	return &runtime{
+		log:        log,
+		conf:       configProvider(),
+		data:       data,
+		httpClient: &http.Client{},
+	}
+}
+
+func (p *runtime) Start(ctx context.Context, interval time.Duration) error {
+	if len(p.conf.endpointUrl.Get(ctx)) > 0 && len(p.conf.signingSecret.Get(ctx)) == 0 {
+		return errMissingSigningSecret
+	}
+
+	go func() {
+		err := p.deliveryWorker(ctx, interval)
+		if err != nil && err != context.Canceled {
+			p.log.With(zap.Error(err)).Warn("webhook delivery processing loop terminated unexpectedly")
+		}
+	}()
+
+	go func() {
+		err := p.metricsGaugeWorker(ctx)
+		if err != nil && err != context.Canceled {
+			p.log.With(zap.Error(err)).Warn("webhook metrics gauge loop terminated unexpectedly")
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
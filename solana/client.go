@@ -39,6 +39,9 @@ const (
 	rpcNodeUnhealthyCode = -32005
 
 	invalidParamCode = -32602
+
+	// Reference: https://solana.com/docs/rpc/http/getmultipleaccounts
+	maxMultipleAccountsPerRequest = 100
 )
 
 type Commitment struct {
@@ -171,6 +174,7 @@ type Client interface {
 	GetAccountInfo(ed25519.PublicKey, Commitment) (AccountInfo, error)
 	GetAccountDataAfterBlock(ed25519.PublicKey, uint64) ([]byte, uint64, error)
 	GetBalance(ed25519.PublicKey) (uint64, error)
+	GetMultipleAccounts([]ed25519.PublicKey, Commitment) ([]*AccountInfo, error)
 	GetBlock(slot uint64) (*Block, error)
 	GetBlockSignatures(slot uint64) ([]string, error)
 	GetBlockTime(block uint64) (time.Time, error)
@@ -766,6 +770,78 @@ func (c *client) GetAccountInfo(account ed25519.PublicKey, commitment Commitment
 	return accountInfo, nil
 }
 
+// GetMultipleAccounts gets the account info for multiple accounts. The result
+// is ordered by the provided accounts, and contains a nil entry for any account
+// that doesn't exist.
+func (c *client) GetMultipleAccounts(accounts []ed25519.PublicKey, commitment Commitment) ([]*AccountInfo, error) {
+	type accountValue struct {
+		Lamports   uint64   `json:"lamports"`
+		Owner      string   `json:"owner"`
+		Data       []string `json:"data"`
+		Executable bool     `json:"executable"`
+	}
+
+	type rpcResponse struct {
+		Value []*accountValue `json:"value"`
+	}
+
+	rpcConfig := struct {
+		Commitment string `json:"commitment"`
+		Encoding   string `json:"encoding"`
+	}{
+		Commitment: commitment.Commitment,
+		Encoding:   "base64",
+	}
+
+	res := make([]*AccountInfo, 0, len(accounts))
+	for start := 0; start < len(accounts); start += maxMultipleAccountsPerRequest {
+		end := start + maxMultipleAccountsPerRequest
+		if end > len(accounts) {
+			end = len(accounts)
+		}
+
+		b58Accounts := make([]string, 0, end-start)
+		for _, account := range accounts[start:end] {
+			b58Accounts = append(b58Accounts, base58.Encode(account[:]))
+		}
+
+		var resp rpcResponse
+		if err := c.call(&resp, "getMultipleAccounts", b58Accounts, rpcConfig); err != nil {
+			return nil, errors.Wrap(err, "getMultipleAccounts() failed to send request")
+		}
+
+		if len(resp.Value) != end-start {
+			return nil, errors.Errorf("getMultipleAccounts() returned %d accounts, expected %d", len(resp.Value), end-start)
+		}
+
+		for _, value := range resp.Value {
+			if value == nil {
+				res = append(res, nil)
+				continue
+			}
+
+			owner, err := base58.Decode(value.Owner)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid base58 encoded owner")
+			}
+
+			data, err := base64.StdEncoding.DecodeString(value.Data[0])
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid base64 encoded data")
+			}
+
+			res = append(res, &AccountInfo{
+				Data:       data,
+				Owner:      owner,
+				Lamports:   value.Lamports,
+				Executable: value.Executable,
+			})
+		}
+	}
+
+	return res, nil
+}
+
 func (c *client) GetAccountDataAfterBlock(account ed25519.PublicKey, slot uint64) ([]byte, uint64, error) {
 	batchMethodName := "getAccountDataAfterBlock"
 
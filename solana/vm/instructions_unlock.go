@@ -0,0 +1,64 @@
+package vm
+
+import (
+	"crypto/ed25519"
+
+	"github.com/code-payments/ocp-server/solana"
+)
+
+const (
+	UnlockInstructionArgsSize = 0
+)
+
+type UnlockInstructionArgs struct {
+}
+
+type UnlockInstructionAccounts struct {
+	AccountOwner ed25519.PublicKey
+	Payer        ed25519.PublicKey
+	Vm           ed25519.PublicKey
+	UnlockState  ed25519.PublicKey
+}
+
+func NewUnlockInstruction(
+	accounts *UnlockInstructionAccounts,
+	args *UnlockInstructionArgs,
+) solana.Instruction {
+	var offset int
+
+	// Serialize instruction arguments
+	data := make([]byte, 1+UnlockInstructionArgsSize)
+
+	putCodeInstruction(data, CodeInstructionUnlock, &offset)
+
+	return solana.Instruction{
+		Program: PROGRAM_ADDRESS,
+
+		// Instruction args
+		Data: data,
+
+		// Instruction accounts
+		Accounts: []solana.AccountMeta{
+			{
+				PublicKey:  accounts.AccountOwner,
+				IsWritable: true,
+				IsSigner:   true,
+			},
+			{
+				PublicKey:  accounts.Payer,
+				IsWritable: true,
+				IsSigner:   true,
+			},
+			{
+				PublicKey:  accounts.Vm,
+				IsWritable: false,
+				IsSigner:   false,
+			},
+			{
+				PublicKey:  accounts.UnlockState,
+				IsWritable: true,
+				IsSigner:   false,
+			},
+		},
+	}
+}
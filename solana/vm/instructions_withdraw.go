@@ -0,0 +1,178 @@
+package vm
+
+import (
+	"crypto/ed25519"
+
+	"github.com/code-payments/ocp-server/solana"
+)
+
+type WithdrawVariant uint8
+
+const (
+	WithdrawVariantFromMemory WithdrawVariant = iota
+	WithdrawVariantFromStorage
+	WithdrawVariantFromDeposit
+)
+
+const (
+	WithdrawFromMemoryInstructionArgsSize = (1 + // variant
+		2) // account_index
+
+	MinWithdrawFromStorageInstructionArgsSize = (1 + // variant
+		4 + // len(packed_va)
+		4 + // len(proof)
+		SignatureSize) // signature
+
+	WithdrawFromDepositInstructionArgsSize = (1 + // variant
+		1) // bump
+)
+
+// WithdrawInstructionArgs are the arguments for withdrawing from an unlocked
+// virtual timelock account. Only the fields for the provided variant are used.
+type WithdrawInstructionArgs struct {
+	Variant WithdrawVariant
+
+	// WithdrawVariantFromMemory
+	AccountIndex uint16
+
+	// WithdrawVariantFromStorage
+	PackedVa  []uint8
+	Proof     HashArray
+	Signature Signature
+
+	// WithdrawVariantFromDeposit
+	Bump uint8
+}
+
+type WithdrawInstructionAccounts struct {
+	Depositor       ed25519.PublicKey
+	Payer           ed25519.PublicKey
+	Vm              ed25519.PublicKey
+	VmOmnibus       *ed25519.PublicKey
+	VmMemory        *ed25519.PublicKey
+	VmStorage       *ed25519.PublicKey
+	DepositPda      *ed25519.PublicKey
+	DepositAta      *ed25519.PublicKey
+	UnlockPda       ed25519.PublicKey
+	WithdrawReceipt *ed25519.PublicKey
+	ExternalAddress ed25519.PublicKey
+}
+
+func NewWithdrawInstruction(
+	accounts *WithdrawInstructionAccounts,
+	args *WithdrawInstructionArgs,
+) solana.Instruction {
+	var offset int
+
+	// Serialize instruction arguments
+	data := make([]byte, 1+GetWithdrawInstructionArgsSize(args))
+
+	putCodeInstruction(data, CodeInstructionWithdraw, &offset)
+	putUint8(data, uint8(args.Variant), &offset)
+	switch args.Variant {
+	case WithdrawVariantFromMemory:
+		putUint16(data, args.AccountIndex, &offset)
+	case WithdrawVariantFromStorage:
+		putUint8Array(data, args.PackedVa, &offset)
+		putHashArray(data, args.Proof, &offset)
+		putSignature(data, args.Signature, &offset)
+	case WithdrawVariantFromDeposit:
+		putUint8(data, args.Bump, &offset)
+	}
+
+	return solana.Instruction{
+		Program: PROGRAM_ADDRESS,
+
+		// Instruction args
+		Data: data,
+
+		// Instruction accounts
+		Accounts: []solana.AccountMeta{
+			{
+				PublicKey:  accounts.Depositor,
+				IsWritable: true,
+				IsSigner:   true,
+			},
+			{
+				PublicKey:  accounts.Payer,
+				IsWritable: true,
+				IsSigner:   true,
+			},
+			{
+				PublicKey:  accounts.Vm,
+				IsWritable: true,
+				IsSigner:   false,
+			},
+			{
+				PublicKey:  getOptionalAccountMetaAddress(accounts.VmOmnibus),
+				IsWritable: true,
+				IsSigner:   false,
+			},
+			{
+				PublicKey:  getOptionalAccountMetaAddress(accounts.VmMemory),
+				IsWritable: true,
+				IsSigner:   false,
+			},
+			{
+				PublicKey:  getOptionalAccountMetaAddress(accounts.VmStorage),
+				IsWritable: true,
+				IsSigner:   false,
+			},
+			{
+				PublicKey:  getOptionalAccountMetaAddress(accounts.DepositPda),
+				IsWritable: false,
+				IsSigner:   false,
+			},
+			{
+				PublicKey:  getOptionalAccountMetaAddress(accounts.DepositAta),
+				IsWritable: true,
+				IsSigner:   false,
+			},
+			{
+				PublicKey:  accounts.UnlockPda,
+				IsWritable: false,
+				IsSigner:   false,
+			},
+			{
+				PublicKey:  getOptionalAccountMetaAddress(accounts.WithdrawReceipt),
+				IsWritable: true,
+				IsSigner:   false,
+			},
+			{
+				PublicKey:  accounts.ExternalAddress,
+				IsWritable: true,
+				IsSigner:   false,
+			},
+			{
+				PublicKey:  SPL_TOKEN_PROGRAM_ID,
+				IsWritable: false,
+				IsSigner:   false,
+			},
+			{
+				PublicKey:  SYSTEM_PROGRAM_ID,
+				IsWritable: false,
+				IsSigner:   false,
+			},
+			{
+				PublicKey:  SYSVAR_RENT_PUBKEY,
+				IsWritable: false,
+				IsSigner:   false,
+			},
+		},
+	}
+}
+
+func GetWithdrawInstructionArgsSize(args *WithdrawInstructionArgs) int {
+	switch args.Variant {
+	case WithdrawVariantFromMemory:
+		return WithdrawFromMemoryInstructionArgsSize
+	case WithdrawVariantFromStorage:
+		return (MinWithdrawFromStorageInstructionArgsSize +
+			len(args.PackedVa) + // packed_va
+			HashSize*len(args.Proof)) // proof
+	case WithdrawVariantFromDeposit:
+		return WithdrawFromDepositInstructionArgsSize
+	default:
+		return 1
+	}
+}
@@ -4,21 +4,24 @@ import (
 	"testing"
 
 	"github.com/code-payments/ocp-server/ocp/common"
+	timelock_token_v1 "github.com/code-payments/ocp-server/solana/timelock/v1"
 )
 
 func NewRandomVmConfig(t *testing.T, isCore bool) *common.VmConfig {
 	if isCore {
 		return &common.VmConfig{
-			Authority: common.GetSubsidizer(),
-			Vm:        common.CoreMintVmAccount,
-			Omnibus:   common.CoreMintVmOmnibusAccount,
-			Mint:      common.CoreMintAccount,
+			Authority:          common.GetSubsidizer(),
+			Vm:                 common.CoreMintVmAccount,
+			Omnibus:            common.CoreMintVmOmnibusAccount,
+			Mint:               common.CoreMintAccount,
+			LockDurationInDays: timelock_token_v1.DefaultNumDaysLocked,
 		}
 	}
 	return &common.VmConfig{
-		Authority: NewRandomAccount(t),
-		Vm:        NewRandomAccount(t),
-		Omnibus:   NewRandomAccount(t),
-		Mint:      NewRandomAccount(t),
+		Authority:          NewRandomAccount(t),
+		Vm:                 NewRandomAccount(t),
+		Omnibus:            NewRandomAccount(t),
+		Mint:               NewRandomAccount(t),
+		LockDurationInDays: timelock_token_v1.DefaultNumDaysLocked,
 	}
 }